@@ -29,8 +29,24 @@ var StreamWriteKindVersion = common.KindVersion{
 
 var TopicStreamWrite = bus.UniTopic(StreamWriteKindVersion.String())
 
+var StreamDurableWriteKindVersion = common.KindVersion{
+	Version: "v1",
+	Kind:    "stream-durable-write",
+}
+
+// TopicStreamDurableWrite carries the writes which are acknowledged after being synced to the disk
+var TopicStreamDurableWrite = bus.BiTopic(StreamDurableWriteKindVersion.String())
+
 var StreamQueryKindVersion = common.KindVersion{
 	Version: "v1",
 	Kind:    "stream-query",
 }
 var TopicStreamQuery = bus.BiTopic(StreamQueryKindVersion.String())
+
+var StreamMaintenanceKindVersion = common.KindVersion{
+	Version: "v1",
+	Kind:    "stream-maintenance",
+}
+
+// TopicStreamMaintenance carries the admin operations, which are acknowledged once they complete
+var TopicStreamMaintenance = bus.BiTopic(StreamMaintenanceKindVersion.String())
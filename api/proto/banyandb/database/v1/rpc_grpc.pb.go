@@ -24,6 +24,7 @@ type StreamRegistryServiceClient interface {
 	Delete(ctx context.Context, in *StreamRegistryServiceDeleteRequest, opts ...grpc.CallOption) (*StreamRegistryServiceDeleteResponse, error)
 	Get(ctx context.Context, in *StreamRegistryServiceGetRequest, opts ...grpc.CallOption) (*StreamRegistryServiceGetResponse, error)
 	List(ctx context.Context, in *StreamRegistryServiceListRequest, opts ...grpc.CallOption) (*StreamRegistryServiceListResponse, error)
+	GetSchemaHash(ctx context.Context, in *StreamRegistryServiceGetSchemaHashRequest, opts ...grpc.CallOption) (*StreamRegistryServiceGetSchemaHashResponse, error)
 }
 
 type streamRegistryServiceClient struct {
@@ -79,6 +80,15 @@ func (c *streamRegistryServiceClient) List(ctx context.Context, in *StreamRegist
 	return out, nil
 }
 
+func (c *streamRegistryServiceClient) GetSchemaHash(ctx context.Context, in *StreamRegistryServiceGetSchemaHashRequest, opts ...grpc.CallOption) (*StreamRegistryServiceGetSchemaHashResponse, error) {
+	out := new(StreamRegistryServiceGetSchemaHashResponse)
+	err := c.cc.Invoke(ctx, "/banyandb.database.v1.StreamRegistryService/GetSchemaHash", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // StreamRegistryServiceServer is the server API for StreamRegistryService service.
 // All implementations must embed UnimplementedStreamRegistryServiceServer
 // for forward compatibility
@@ -88,6 +98,7 @@ type StreamRegistryServiceServer interface {
 	Delete(context.Context, *StreamRegistryServiceDeleteRequest) (*StreamRegistryServiceDeleteResponse, error)
 	Get(context.Context, *StreamRegistryServiceGetRequest) (*StreamRegistryServiceGetResponse, error)
 	List(context.Context, *StreamRegistryServiceListRequest) (*StreamRegistryServiceListResponse, error)
+	GetSchemaHash(context.Context, *StreamRegistryServiceGetSchemaHashRequest) (*StreamRegistryServiceGetSchemaHashResponse, error)
 	mustEmbedUnimplementedStreamRegistryServiceServer()
 }
 
@@ -110,6 +121,9 @@ func (UnimplementedStreamRegistryServiceServer) Get(context.Context, *StreamRegi
 func (UnimplementedStreamRegistryServiceServer) List(context.Context, *StreamRegistryServiceListRequest) (*StreamRegistryServiceListResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method List not implemented")
 }
+func (UnimplementedStreamRegistryServiceServer) GetSchemaHash(context.Context, *StreamRegistryServiceGetSchemaHashRequest) (*StreamRegistryServiceGetSchemaHashResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSchemaHash not implemented")
+}
 func (UnimplementedStreamRegistryServiceServer) mustEmbedUnimplementedStreamRegistryServiceServer() {}
 
 // UnsafeStreamRegistryServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -213,6 +227,24 @@ func _StreamRegistryService_List_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _StreamRegistryService_GetSchemaHash_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StreamRegistryServiceGetSchemaHashRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StreamRegistryServiceServer).GetSchemaHash(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/banyandb.database.v1.StreamRegistryService/GetSchemaHash",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StreamRegistryServiceServer).GetSchemaHash(ctx, req.(*StreamRegistryServiceGetSchemaHashRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // StreamRegistryService_ServiceDesc is the grpc.ServiceDesc for StreamRegistryService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -240,6 +272,10 @@ var StreamRegistryService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "List",
 			Handler:    _StreamRegistryService_List_Handler,
 		},
+		{
+			MethodName: "GetSchemaHash",
+			Handler:    _StreamRegistryService_GetSchemaHash_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "banyandb/database/v1/rpc.proto",
@@ -716,6 +752,7 @@ type MeasureRegistryServiceClient interface {
 	Delete(ctx context.Context, in *MeasureRegistryServiceDeleteRequest, opts ...grpc.CallOption) (*MeasureRegistryServiceDeleteResponse, error)
 	Get(ctx context.Context, in *MeasureRegistryServiceGetRequest, opts ...grpc.CallOption) (*MeasureRegistryServiceGetResponse, error)
 	List(ctx context.Context, in *MeasureRegistryServiceListRequest, opts ...grpc.CallOption) (*MeasureRegistryServiceListResponse, error)
+	GetSchemaHash(ctx context.Context, in *MeasureRegistryServiceGetSchemaHashRequest, opts ...grpc.CallOption) (*MeasureRegistryServiceGetSchemaHashResponse, error)
 }
 
 type measureRegistryServiceClient struct {
@@ -771,6 +808,15 @@ func (c *measureRegistryServiceClient) List(ctx context.Context, in *MeasureRegi
 	return out, nil
 }
 
+func (c *measureRegistryServiceClient) GetSchemaHash(ctx context.Context, in *MeasureRegistryServiceGetSchemaHashRequest, opts ...grpc.CallOption) (*MeasureRegistryServiceGetSchemaHashResponse, error) {
+	out := new(MeasureRegistryServiceGetSchemaHashResponse)
+	err := c.cc.Invoke(ctx, "/banyandb.database.v1.MeasureRegistryService/GetSchemaHash", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // MeasureRegistryServiceServer is the server API for MeasureRegistryService service.
 // All implementations must embed UnimplementedMeasureRegistryServiceServer
 // for forward compatibility
@@ -780,6 +826,7 @@ type MeasureRegistryServiceServer interface {
 	Delete(context.Context, *MeasureRegistryServiceDeleteRequest) (*MeasureRegistryServiceDeleteResponse, error)
 	Get(context.Context, *MeasureRegistryServiceGetRequest) (*MeasureRegistryServiceGetResponse, error)
 	List(context.Context, *MeasureRegistryServiceListRequest) (*MeasureRegistryServiceListResponse, error)
+	GetSchemaHash(context.Context, *MeasureRegistryServiceGetSchemaHashRequest) (*MeasureRegistryServiceGetSchemaHashResponse, error)
 	mustEmbedUnimplementedMeasureRegistryServiceServer()
 }
 
@@ -906,6 +953,24 @@ func _MeasureRegistryService_List_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MeasureRegistryService_GetSchemaHash_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MeasureRegistryServiceGetSchemaHashRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MeasureRegistryServiceServer).GetSchemaHash(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/banyandb.database.v1.MeasureRegistryService/GetSchemaHash",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MeasureRegistryServiceServer).GetSchemaHash(ctx, req.(*MeasureRegistryServiceGetSchemaHashRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // MeasureRegistryService_ServiceDesc is the grpc.ServiceDesc for MeasureRegistryService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -933,6 +998,10 @@ var MeasureRegistryService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "List",
 			Handler:    _MeasureRegistryService_List_Handler,
 		},
+		{
+			MethodName: "GetSchemaHash",
+			Handler:    _MeasureRegistryService_GetSchemaHash_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "banyandb/database/v1/rpc.proto",
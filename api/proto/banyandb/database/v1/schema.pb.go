@@ -152,11 +152,18 @@ func (FieldType) EnumDescriptor() ([]byte, []int) {
 	return file_banyandb_database_v1_schema_proto_rawDescGZIP(), []int{1}
 }
 
+// EncodingMethod decides how the data points of a series are encoded in a block.
+// A stream selects its method by ResourceOpts.encoding_method.
 type EncodingMethod int32
 
 const (
+	// ENCODING_METHOD_UNSPECIFIED works as ENCODING_METHOD_PLAIN
 	EncodingMethod_ENCODING_METHOD_UNSPECIFIED EncodingMethod = 0
-	EncodingMethod_ENCODING_METHOD_GORILLA     EncodingMethod = 1
+	// ENCODING_METHOD_GORILLA compresses the timestamps by delta-of-delta and the 8-byte values by XOR,
+	// which fits the regular samples
+	EncodingMethod_ENCODING_METHOD_GORILLA EncodingMethod = 1
+	// ENCODING_METHOD_PLAIN compresses the data points by zstd
+	EncodingMethod_ENCODING_METHOD_PLAIN EncodingMethod = 2
 )
 
 // Enum value maps for EncodingMethod.
@@ -164,10 +171,12 @@ var (
 	EncodingMethod_name = map[int32]string{
 		0: "ENCODING_METHOD_UNSPECIFIED",
 		1: "ENCODING_METHOD_GORILLA",
+		2: "ENCODING_METHOD_PLAIN",
 	}
 	EncodingMethod_value = map[string]int32{
 		"ENCODING_METHOD_UNSPECIFIED": 0,
 		"ENCODING_METHOD_GORILLA":     1,
+		"ENCODING_METHOD_PLAIN":       2,
 	}
 )
 
@@ -244,6 +253,118 @@ func (CompressionMethod) EnumDescriptor() ([]byte, []int) {
 	return file_banyandb_database_v1_schema_proto_rawDescGZIP(), []int{3}
 }
 
+// StorageEngine decides how a write lands on the existing data of a series
+type StorageEngine int32
+
+const (
+	// STORAGE_ENGINE_UNSPECIFIED works as STORAGE_ENGINE_OVERWRITE
+	StorageEngine_STORAGE_ENGINE_UNSPECIFIED StorageEngine = 0
+	// STORAGE_ENGINE_OVERWRITE replaces the data of a series at the same timestamp, which fits metrics
+	StorageEngine_STORAGE_ENGINE_OVERWRITE StorageEngine = 1
+	// STORAGE_ENGINE_APPEND keeps every write as a new one, which fits event logs
+	StorageEngine_STORAGE_ENGINE_APPEND StorageEngine = 2
+)
+
+// Enum value maps for StorageEngine.
+var (
+	StorageEngine_name = map[int32]string{
+		0: "STORAGE_ENGINE_UNSPECIFIED",
+		1: "STORAGE_ENGINE_OVERWRITE",
+		2: "STORAGE_ENGINE_APPEND",
+	}
+	StorageEngine_value = map[string]int32{
+		"STORAGE_ENGINE_UNSPECIFIED": 0,
+		"STORAGE_ENGINE_OVERWRITE":   1,
+		"STORAGE_ENGINE_APPEND":      2,
+	}
+)
+
+func (x StorageEngine) Enum() *StorageEngine {
+	p := new(StorageEngine)
+	*p = x
+	return p
+}
+
+func (x StorageEngine) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (StorageEngine) Descriptor() protoreflect.EnumDescriptor {
+	return file_banyandb_database_v1_schema_proto_enumTypes[4].Descriptor()
+}
+
+func (StorageEngine) Type() protoreflect.EnumType {
+	return &file_banyandb_database_v1_schema_proto_enumTypes[4]
+}
+
+func (x StorageEngine) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use StorageEngine.Descriptor instead.
+func (StorageEngine) EnumDescriptor() ([]byte, []int) {
+	return file_banyandb_database_v1_schema_proto_rawDescGZIP(), []int{4}
+}
+
+// ShardingAlgorithm decides how an entity is hashed to a shard.
+// A stream or a measure selects its algorithm by ResourceOpts.sharding_algorithm.
+type ShardingAlgorithm int32
+
+const (
+	// SHARDING_ALGORITHM_UNSPECIFIED works as SHARDING_ALGORITHM_MODULO
+	ShardingAlgorithm_SHARDING_ALGORITHM_UNSPECIFIED ShardingAlgorithm = 0
+	// SHARDING_ALGORITHM_MODULO takes the hash modulo shard_num, which moves almost every entity once shard_num changes
+	ShardingAlgorithm_SHARDING_ALGORITHM_MODULO ShardingAlgorithm = 1
+	// SHARDING_ALGORITHM_CONSISTENT moves about 1/N of the entities once shard_num grows to N
+	ShardingAlgorithm_SHARDING_ALGORITHM_CONSISTENT ShardingAlgorithm = 2
+	// SHARDING_ALGORITHM_PREFIX hashes the first entity tag only by the modulo,
+	// which keeps the series sharing the first tag, e.g. the instances of a service, in a single shard
+	ShardingAlgorithm_SHARDING_ALGORITHM_PREFIX ShardingAlgorithm = 3
+)
+
+// Enum value maps for ShardingAlgorithm.
+var (
+	ShardingAlgorithm_name = map[int32]string{
+		0: "SHARDING_ALGORITHM_UNSPECIFIED",
+		1: "SHARDING_ALGORITHM_MODULO",
+		2: "SHARDING_ALGORITHM_CONSISTENT",
+		3: "SHARDING_ALGORITHM_PREFIX",
+	}
+	ShardingAlgorithm_value = map[string]int32{
+		"SHARDING_ALGORITHM_UNSPECIFIED": 0,
+		"SHARDING_ALGORITHM_MODULO":      1,
+		"SHARDING_ALGORITHM_CONSISTENT":  2,
+		"SHARDING_ALGORITHM_PREFIX":      3,
+	}
+)
+
+func (x ShardingAlgorithm) Enum() *ShardingAlgorithm {
+	p := new(ShardingAlgorithm)
+	*p = x
+	return p
+}
+
+func (x ShardingAlgorithm) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ShardingAlgorithm) Descriptor() protoreflect.EnumDescriptor {
+	return file_banyandb_database_v1_schema_proto_enumTypes[5].Descriptor()
+}
+
+func (ShardingAlgorithm) Type() protoreflect.EnumType {
+	return &file_banyandb_database_v1_schema_proto_enumTypes[5]
+}
+
+func (x ShardingAlgorithm) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ShardingAlgorithm.Descriptor instead.
+func (ShardingAlgorithm) EnumDescriptor() ([]byte, []int) {
+	return file_banyandb_database_v1_schema_proto_rawDescGZIP(), []int{5}
+}
+
 type Duration_DurationUnit int32
 
 const (
@@ -283,11 +404,11 @@ func (x Duration_DurationUnit) String() string {
 }
 
 func (Duration_DurationUnit) Descriptor() protoreflect.EnumDescriptor {
-	return file_banyandb_database_v1_schema_proto_enumTypes[4].Descriptor()
+	return file_banyandb_database_v1_schema_proto_enumTypes[6].Descriptor()
 }
 
 func (Duration_DurationUnit) Type() protoreflect.EnumType {
-	return &file_banyandb_database_v1_schema_proto_enumTypes[4]
+	return &file_banyandb_database_v1_schema_proto_enumTypes[6]
 }
 
 func (x Duration_DurationUnit) Number() protoreflect.EnumNumber {
@@ -333,11 +454,11 @@ func (x IndexRule_Type) String() string {
 }
 
 func (IndexRule_Type) Descriptor() protoreflect.EnumDescriptor {
-	return file_banyandb_database_v1_schema_proto_enumTypes[5].Descriptor()
+	return file_banyandb_database_v1_schema_proto_enumTypes[7].Descriptor()
 }
 
 func (IndexRule_Type) Type() protoreflect.EnumType {
-	return &file_banyandb_database_v1_schema_proto_enumTypes[5]
+	return &file_banyandb_database_v1_schema_proto_enumTypes[7]
 }
 
 func (x IndexRule_Type) Number() protoreflect.EnumNumber {
@@ -346,7 +467,7 @@ func (x IndexRule_Type) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use IndexRule_Type.Descriptor instead.
 func (IndexRule_Type) EnumDescriptor() ([]byte, []int) {
-	return file_banyandb_database_v1_schema_proto_rawDescGZIP(), []int{10, 0}
+	return file_banyandb_database_v1_schema_proto_rawDescGZIP(), []int{11, 0}
 }
 
 type IndexRule_Location int32
@@ -382,11 +503,11 @@ func (x IndexRule_Location) String() string {
 }
 
 func (IndexRule_Location) Descriptor() protoreflect.EnumDescriptor {
-	return file_banyandb_database_v1_schema_proto_enumTypes[6].Descriptor()
+	return file_banyandb_database_v1_schema_proto_enumTypes[8].Descriptor()
 }
 
 func (IndexRule_Location) Type() protoreflect.EnumType {
-	return &file_banyandb_database_v1_schema_proto_enumTypes[6]
+	return &file_banyandb_database_v1_schema_proto_enumTypes[8]
 }
 
 func (x IndexRule_Location) Number() protoreflect.EnumNumber {
@@ -395,7 +516,7 @@ func (x IndexRule_Location) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use IndexRule_Location.Descriptor instead.
 func (IndexRule_Location) EnumDescriptor() ([]byte, []int) {
-	return file_banyandb_database_v1_schema_proto_rawDescGZIP(), []int{10, 1}
+	return file_banyandb_database_v1_schema_proto_rawDescGZIP(), []int{11, 1}
 }
 
 // Duration represents the elapsed time between two instants
@@ -703,8 +824,19 @@ type ResourceOpts struct {
 
 	// shard_num is the number of shards
 	ShardNum uint32 `protobuf:"varint,1,opt,name=shard_num,json=shardNum,proto3" json:"shard_num,omitempty"`
-	// ttl indicates time to live, how long the data will be cached
+	// ttl indicates time to live, how long the data will be cached.
+	// A sealed segment is removed once all of its data is older than ttl, and an absent one keeps the data forever.
 	Ttl *Duration `protobuf:"bytes,2,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	// storage_engine selects the write semantics of the resource
+	StorageEngine StorageEngine `protobuf:"varint,3,opt,name=storage_engine,json=storageEngine,proto3,enum=banyandb.database.v1.StorageEngine" json:"storage_engine,omitempty"`
+	// shard_overrides route the hot entities to explicit shards instead of the hashed ones
+	ShardOverrides []*ShardOverride `protobuf:"bytes,4,rep,name=shard_overrides,json=shardOverrides,proto3" json:"shard_overrides,omitempty"`
+	// sharding_algorithm hashes the entities without any override to their shards
+	ShardingAlgorithm ShardingAlgorithm `protobuf:"varint,5,opt,name=sharding_algorithm,json=shardingAlgorithm,proto3,enum=banyandb.database.v1.ShardingAlgorithm" json:"sharding_algorithm,omitempty"`
+	// max_segments removes the oldest sealed segments of a shard beyond the number, and zero keeps all of them
+	MaxSegments uint32 `protobuf:"varint,6,opt,name=max_segments,json=maxSegments,proto3" json:"max_segments,omitempty"`
+	// encoding_method selects the codec of the data blocks of a stream
+	EncodingMethod EncodingMethod `protobuf:"varint,7,opt,name=encoding_method,json=encodingMethod,proto3,enum=banyandb.database.v1.EncodingMethod" json:"encoding_method,omitempty"`
 }
 
 func (x *ResourceOpts) Reset() {
@@ -753,6 +885,99 @@ func (x *ResourceOpts) GetTtl() *Duration {
 	return nil
 }
 
+func (x *ResourceOpts) GetStorageEngine() StorageEngine {
+	if x != nil {
+		return x.StorageEngine
+	}
+	return StorageEngine_STORAGE_ENGINE_UNSPECIFIED
+}
+
+func (x *ResourceOpts) GetShardOverrides() []*ShardOverride {
+	if x != nil {
+		return x.ShardOverrides
+	}
+	return nil
+}
+
+func (x *ResourceOpts) GetShardingAlgorithm() ShardingAlgorithm {
+	if x != nil {
+		return x.ShardingAlgorithm
+	}
+	return ShardingAlgorithm_SHARDING_ALGORITHM_UNSPECIFIED
+}
+
+func (x *ResourceOpts) GetMaxSegments() uint32 {
+	if x != nil {
+		return x.MaxSegments
+	}
+	return 0
+}
+
+func (x *ResourceOpts) GetEncodingMethod() EncodingMethod {
+	if x != nil {
+		return x.EncodingMethod
+	}
+	return EncodingMethod_ENCODING_METHOD_UNSPECIFIED
+}
+
+// ShardOverride routes an entity to an explicit shard
+type ShardOverride struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// entity is the values of the entity tags in the order of Entity.tag_names
+	Entity []*v11.TagValue `protobuf:"bytes,1,rep,name=entity,proto3" json:"entity,omitempty"`
+	// shard_id should be less than shard_num
+	ShardId uint32 `protobuf:"varint,2,opt,name=shard_id,json=shardId,proto3" json:"shard_id,omitempty"`
+}
+
+func (x *ShardOverride) Reset() {
+	*x = ShardOverride{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_banyandb_database_v1_schema_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ShardOverride) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShardOverride) ProtoMessage() {}
+
+func (x *ShardOverride) ProtoReflect() protoreflect.Message {
+	mi := &file_banyandb_database_v1_schema_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShardOverride.ProtoReflect.Descriptor instead.
+func (*ShardOverride) Descriptor() ([]byte, []int) {
+	return file_banyandb_database_v1_schema_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ShardOverride) GetEntity() []*v11.TagValue {
+	if x != nil {
+		return x.Entity
+	}
+	return nil
+}
+
+func (x *ShardOverride) GetShardId() uint32 {
+	if x != nil {
+		return x.ShardId
+	}
+	return 0
+}
+
 // FieldSpec is the specification of field
 type FieldSpec struct {
 	state         protoimpl.MessageState
@@ -772,7 +997,7 @@ type FieldSpec struct {
 func (x *FieldSpec) Reset() {
 	*x = FieldSpec{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_banyandb_database_v1_schema_proto_msgTypes[6]
+		mi := &file_banyandb_database_v1_schema_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -785,7 +1010,7 @@ func (x *FieldSpec) String() string {
 func (*FieldSpec) ProtoMessage() {}
 
 func (x *FieldSpec) ProtoReflect() protoreflect.Message {
-	mi := &file_banyandb_database_v1_schema_proto_msgTypes[6]
+	mi := &file_banyandb_database_v1_schema_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -798,7 +1023,7 @@ func (x *FieldSpec) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FieldSpec.ProtoReflect.Descriptor instead.
 func (*FieldSpec) Descriptor() ([]byte, []int) {
-	return file_banyandb_database_v1_schema_proto_rawDescGZIP(), []int{6}
+	return file_banyandb_database_v1_schema_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *FieldSpec) GetName() string {
@@ -850,7 +1075,7 @@ type IntervalRule struct {
 func (x *IntervalRule) Reset() {
 	*x = IntervalRule{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_banyandb_database_v1_schema_proto_msgTypes[7]
+		mi := &file_banyandb_database_v1_schema_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -863,7 +1088,7 @@ func (x *IntervalRule) String() string {
 func (*IntervalRule) ProtoMessage() {}
 
 func (x *IntervalRule) ProtoReflect() protoreflect.Message {
-	mi := &file_banyandb_database_v1_schema_proto_msgTypes[7]
+	mi := &file_banyandb_database_v1_schema_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -876,7 +1101,7 @@ func (x *IntervalRule) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use IntervalRule.ProtoReflect.Descriptor instead.
 func (*IntervalRule) Descriptor() ([]byte, []int) {
-	return file_banyandb_database_v1_schema_proto_rawDescGZIP(), []int{7}
+	return file_banyandb_database_v1_schema_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *IntervalRule) GetTagName() string {
@@ -950,12 +1175,18 @@ type Measure struct {
 	Opts *ResourceOpts `protobuf:"bytes,6,opt,name=opts,proto3" json:"opts,omitempty"`
 	// updated_at_nanoseconds indicates when the measure is updated
 	UpdatedAtNanoseconds *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at_nanoseconds,json=updatedAtNanoseconds,proto3" json:"updated_at_nanoseconds,omitempty"`
+	// partition_interval aligns the storage partitions to the wall-clock boundaries of the interval, e.g. 1 hour.
+	// The unspecified one keeps the default layout.
+	PartitionInterval *Duration `protobuf:"bytes,8,opt,name=partition_interval,json=partitionInterval,proto3" json:"partition_interval,omitempty"`
+	// rollup_intervals are the levels of the lower-resolution aggregates materialized in the background, e.g. 1 hour and 1 day.
+	// The months aren't supported since their lengths vary.
+	RollupIntervals []*Duration `protobuf:"bytes,9,rep,name=rollup_intervals,json=rollupIntervals,proto3" json:"rollup_intervals,omitempty"`
 }
 
 func (x *Measure) Reset() {
 	*x = Measure{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_banyandb_database_v1_schema_proto_msgTypes[8]
+		mi := &file_banyandb_database_v1_schema_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -968,7 +1199,7 @@ func (x *Measure) String() string {
 func (*Measure) ProtoMessage() {}
 
 func (x *Measure) ProtoReflect() protoreflect.Message {
-	mi := &file_banyandb_database_v1_schema_proto_msgTypes[8]
+	mi := &file_banyandb_database_v1_schema_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -981,7 +1212,7 @@ func (x *Measure) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Measure.ProtoReflect.Descriptor instead.
 func (*Measure) Descriptor() ([]byte, []int) {
-	return file_banyandb_database_v1_schema_proto_rawDescGZIP(), []int{8}
+	return file_banyandb_database_v1_schema_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *Measure) GetMetadata() *v1.Metadata {
@@ -1033,6 +1264,20 @@ func (x *Measure) GetUpdatedAtNanoseconds() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *Measure) GetPartitionInterval() *Duration {
+	if x != nil {
+		return x.PartitionInterval
+	}
+	return nil
+}
+
+func (x *Measure) GetRollupIntervals() []*Duration {
+	if x != nil {
+		return x.RollupIntervals
+	}
+	return nil
+}
+
 // TopNAggregation generates offline TopN statistics for a measure's TopN approximation
 type TopNAggregation struct {
 	state         protoimpl.MessageState
@@ -1065,7 +1310,7 @@ type TopNAggregation struct {
 func (x *TopNAggregation) Reset() {
 	*x = TopNAggregation{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_banyandb_database_v1_schema_proto_msgTypes[9]
+		mi := &file_banyandb_database_v1_schema_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1078,7 +1323,7 @@ func (x *TopNAggregation) String() string {
 func (*TopNAggregation) ProtoMessage() {}
 
 func (x *TopNAggregation) ProtoReflect() protoreflect.Message {
-	mi := &file_banyandb_database_v1_schema_proto_msgTypes[9]
+	mi := &file_banyandb_database_v1_schema_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1091,7 +1336,7 @@ func (x *TopNAggregation) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TopNAggregation.ProtoReflect.Descriptor instead.
 func (*TopNAggregation) Descriptor() ([]byte, []int) {
-	return file_banyandb_database_v1_schema_proto_rawDescGZIP(), []int{9}
+	return file_banyandb_database_v1_schema_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *TopNAggregation) GetMetadata() *v1.Metadata {
@@ -1181,7 +1426,7 @@ type IndexRule struct {
 func (x *IndexRule) Reset() {
 	*x = IndexRule{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_banyandb_database_v1_schema_proto_msgTypes[10]
+		mi := &file_banyandb_database_v1_schema_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1194,7 +1439,7 @@ func (x *IndexRule) String() string {
 func (*IndexRule) ProtoMessage() {}
 
 func (x *IndexRule) ProtoReflect() protoreflect.Message {
-	mi := &file_banyandb_database_v1_schema_proto_msgTypes[10]
+	mi := &file_banyandb_database_v1_schema_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1207,7 +1452,7 @@ func (x *IndexRule) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use IndexRule.ProtoReflect.Descriptor instead.
 func (*IndexRule) Descriptor() ([]byte, []int) {
-	return file_banyandb_database_v1_schema_proto_rawDescGZIP(), []int{10}
+	return file_banyandb_database_v1_schema_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *IndexRule) GetMetadata() *v1.Metadata {
@@ -1260,7 +1505,7 @@ type Subject struct {
 func (x *Subject) Reset() {
 	*x = Subject{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_banyandb_database_v1_schema_proto_msgTypes[11]
+		mi := &file_banyandb_database_v1_schema_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1273,7 +1518,7 @@ func (x *Subject) String() string {
 func (*Subject) ProtoMessage() {}
 
 func (x *Subject) ProtoReflect() protoreflect.Message {
-	mi := &file_banyandb_database_v1_schema_proto_msgTypes[11]
+	mi := &file_banyandb_database_v1_schema_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1286,7 +1531,7 @@ func (x *Subject) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Subject.ProtoReflect.Descriptor instead.
 func (*Subject) Descriptor() ([]byte, []int) {
-	return file_banyandb_database_v1_schema_proto_rawDescGZIP(), []int{11}
+	return file_banyandb_database_v1_schema_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *Subject) GetCatalog() v1.Catalog {
@@ -1329,7 +1574,7 @@ type IndexRuleBinding struct {
 func (x *IndexRuleBinding) Reset() {
 	*x = IndexRuleBinding{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_banyandb_database_v1_schema_proto_msgTypes[12]
+		mi := &file_banyandb_database_v1_schema_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1342,7 +1587,7 @@ func (x *IndexRuleBinding) String() string {
 func (*IndexRuleBinding) ProtoMessage() {}
 
 func (x *IndexRuleBinding) ProtoReflect() protoreflect.Message {
-	mi := &file_banyandb_database_v1_schema_proto_msgTypes[12]
+	mi := &file_banyandb_database_v1_schema_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1355,7 +1600,7 @@ func (x *IndexRuleBinding) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use IndexRuleBinding.ProtoReflect.Descriptor instead.
 func (*IndexRuleBinding) Descriptor() ([]byte, []int) {
-	return file_banyandb_database_v1_schema_proto_rawDescGZIP(), []int{12}
+	return file_banyandb_database_v1_schema_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *IndexRuleBinding) GetMetadata() *v1.Metadata {
@@ -1410,6 +1655,8 @@ var file_banyandb_database_v1_schema_proto_rawDesc = []byte{
 	0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73,
 	0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x62, 0x61, 0x6e, 0x79,
 	0x61, 0x6e, 0x64, 0x62, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2f, 0x76, 0x31, 0x2f, 0x63,
+	0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1e, 0x62, 0x61, 0x6e,
+	0x79, 0x61, 0x6e, 0x64, 0x62, 0x2f, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2f, 0x76, 0x31, 0x2f, 0x63,
 	0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1d, 0x62, 0x61, 0x6e,
 	0x79, 0x61, 0x6e, 0x64, 0x62, 0x2f, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2f, 0x76, 0x31, 0x2f, 0x71,
 	0x75, 0x65, 0x72, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xed, 0x01, 0x0a, 0x08, 0x44,
@@ -1461,66 +1708,104 @@ var file_banyandb_database_v1_schema_proto_rawDesc = []byte{
 	0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x4e, 0x61, 0x6e, 0x6f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64,
 	0x73, 0x22, 0x25, 0x0a, 0x06, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12, 0x1b, 0x0a, 0x09, 0x74,
 	0x61, 0x67, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08,
-	0x74, 0x61, 0x67, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x22, 0x5d, 0x0a, 0x0c, 0x52, 0x65, 0x73, 0x6f,
-	0x75, 0x72, 0x63, 0x65, 0x4f, 0x70, 0x74, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x68, 0x61, 0x72,
-	0x64, 0x5f, 0x6e, 0x75, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x73, 0x68, 0x61,
-	0x72, 0x64, 0x4e, 0x75, 0x6d, 0x12, 0x30, 0x0a, 0x03, 0x74, 0x74, 0x6c, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x62, 0x61, 0x6e, 0x79, 0x61, 0x6e, 0x64, 0x62, 0x2e, 0x64, 0x61,
-	0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x52, 0x03, 0x74, 0x74, 0x6c, 0x22, 0x86, 0x02, 0x0a, 0x09, 0x46, 0x69, 0x65, 0x6c,
-	0x64, 0x53, 0x70, 0x65, 0x63, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x3e, 0x0a, 0x0a, 0x66, 0x69, 0x65,
-	0x6c, 0x64, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1f, 0x2e,
+	0x74, 0x61, 0x67, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x22, 0xc1, 0x03, 0x0a, 0x0c, 0x52, 0x65, 0x73,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x4f, 0x70, 0x74, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x68, 0x61,
+	0x72, 0x64, 0x5f, 0x6e, 0x75, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x73, 0x68,
+	0x61, 0x72, 0x64, 0x4e, 0x75, 0x6d, 0x12, 0x30, 0x0a, 0x03, 0x74, 0x74, 0x6c, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x62, 0x61, 0x6e, 0x79, 0x61, 0x6e, 0x64, 0x62, 0x2e, 0x64,
+	0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x03, 0x74, 0x74, 0x6c, 0x12, 0x4a, 0x0a, 0x0e, 0x73, 0x74, 0x6f, 0x72,
+	0x61, 0x67, 0x65, 0x5f, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x23, 0x2e, 0x62, 0x61, 0x6e, 0x79, 0x61, 0x6e, 0x64, 0x62, 0x2e, 0x64, 0x61, 0x74, 0x61,
+	0x62, 0x61, 0x73, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x45,
+	0x6e, 0x67, 0x69, 0x6e, 0x65, 0x52, 0x0d, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x45, 0x6e,
+	0x67, 0x69, 0x6e, 0x65, 0x12, 0x4c, 0x0a, 0x0f, 0x73, 0x68, 0x61, 0x72, 0x64, 0x5f, 0x6f, 0x76,
+	0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e,
 	0x62, 0x61, 0x6e, 0x79, 0x61, 0x6e, 0x64, 0x62, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73,
-	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x54, 0x79, 0x70, 0x65, 0x52, 0x09,
-	0x66, 0x69, 0x65, 0x6c, 0x64, 0x54, 0x79, 0x70, 0x65, 0x12, 0x4d, 0x0a, 0x0f, 0x65, 0x6e, 0x63,
-	0x6f, 0x64, 0x69, 0x6e, 0x67, 0x5f, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x0e, 0x32, 0x24, 0x2e, 0x62, 0x61, 0x6e, 0x79, 0x61, 0x6e, 0x64, 0x62, 0x2e, 0x64, 0x61,
-	0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x69,
-	0x6e, 0x67, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x52, 0x0e, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x69,
-	0x6e, 0x67, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x12, 0x56, 0x0a, 0x12, 0x63, 0x6f, 0x6d, 0x70,
-	0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x18, 0x04,
-	0x20, 0x01, 0x28, 0x0e, 0x32, 0x27, 0x2e, 0x62, 0x61, 0x6e, 0x79, 0x61, 0x6e, 0x64, 0x62, 0x2e,
-	0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d, 0x70,
-	0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x52, 0x11, 0x63,
-	0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64,
-	0x22, 0x7a, 0x0a, 0x0c, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x52, 0x75, 0x6c, 0x65,
-	0x12, 0x19, 0x0a, 0x08, 0x74, 0x61, 0x67, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x07, 0x74, 0x61, 0x67, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x03, 0x73,
-	0x74, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x03, 0x73, 0x74, 0x72, 0x12,
-	0x12, 0x0a, 0x03, 0x69, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x48, 0x00, 0x52, 0x03,
-	0x69, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18,
-	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x42,
-	0x0b, 0x0a, 0x09, 0x74, 0x61, 0x67, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0xcf, 0x03, 0x0a,
-	0x07, 0x4d, 0x65, 0x61, 0x73, 0x75, 0x72, 0x65, 0x12, 0x38, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61,
-	0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x62, 0x61, 0x6e,
-	0x79, 0x61, 0x6e, 0x64, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e,
-	0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61,
-	0x74, 0x61, 0x12, 0x46, 0x0a, 0x0c, 0x74, 0x61, 0x67, 0x5f, 0x66, 0x61, 0x6d, 0x69, 0x6c, 0x69,
-	0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x62, 0x61, 0x6e, 0x79, 0x61,
+	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x68, 0x61, 0x72, 0x64, 0x4f, 0x76, 0x65, 0x72, 0x72, 0x69,
+	0x64, 0x65, 0x52, 0x0e, 0x73, 0x68, 0x61, 0x72, 0x64, 0x4f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64,
+	0x65, 0x73, 0x12, 0x56, 0x0a, 0x12, 0x73, 0x68, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x5f, 0x61,
+	0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x27,
+	0x2e, 0x62, 0x61, 0x6e, 0x79, 0x61, 0x6e, 0x64, 0x62, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61,
+	0x73, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x68, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x41, 0x6c,
+	0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x52, 0x11, 0x73, 0x68, 0x61, 0x72, 0x64, 0x69, 0x6e,
+	0x67, 0x41, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x12, 0x21, 0x0a, 0x0c, 0x6d, 0x61,
+	0x78, 0x5f, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x0b, 0x6d, 0x61, 0x78, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x4d, 0x0a,
+	0x0f, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x5f, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x24, 0x2e, 0x62, 0x61, 0x6e, 0x79, 0x61, 0x6e, 0x64,
+	0x62, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x6e,
+	0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x52, 0x0e, 0x65, 0x6e,
+	0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x22, 0x5f, 0x0a, 0x0d,
+	0x53, 0x68, 0x61, 0x72, 0x64, 0x4f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x12, 0x33, 0x0a,
+	0x06, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e,
+	0x62, 0x61, 0x6e, 0x79, 0x61, 0x6e, 0x64, 0x62, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x76,
+	0x31, 0x2e, 0x54, 0x61, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x06, 0x65, 0x6e, 0x74, 0x69,
+	0x74, 0x79, 0x12, 0x19, 0x0a, 0x08, 0x73, 0x68, 0x61, 0x72, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x73, 0x68, 0x61, 0x72, 0x64, 0x49, 0x64, 0x22, 0x86, 0x02,
+	0x0a, 0x09, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x53, 0x70, 0x65, 0x63, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x3e, 0x0a, 0x0a, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x1f, 0x2e, 0x62, 0x61, 0x6e, 0x79, 0x61, 0x6e, 0x64, 0x62, 0x2e, 0x64,
+	0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64,
+	0x54, 0x79, 0x70, 0x65, 0x52, 0x09, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x54, 0x79, 0x70, 0x65, 0x12,
+	0x4d, 0x0a, 0x0f, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x5f, 0x6d, 0x65, 0x74, 0x68,
+	0x6f, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x24, 0x2e, 0x62, 0x61, 0x6e, 0x79, 0x61,
 	0x6e, 0x64, 0x62, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x2e, 0x76, 0x31, 0x2e,
-	0x54, 0x61, 0x67, 0x46, 0x61, 0x6d, 0x69, 0x6c, 0x79, 0x53, 0x70, 0x65, 0x63, 0x52, 0x0b, 0x74,
-	0x61, 0x67, 0x46, 0x61, 0x6d, 0x69, 0x6c, 0x69, 0x65, 0x73, 0x12, 0x37, 0x0a, 0x06, 0x66, 0x69,
-	0x65, 0x6c, 0x64, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x62, 0x61, 0x6e,
+	0x45, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x52, 0x0e,
+	0x65, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x12, 0x56,
+	0x0a, 0x12, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x6d, 0x65,
+	0x74, 0x68, 0x6f, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x27, 0x2e, 0x62, 0x61, 0x6e,
 	0x79, 0x61, 0x6e, 0x64, 0x62, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x2e, 0x76,
-	0x31, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x53, 0x70, 0x65, 0x63, 0x52, 0x06, 0x66, 0x69, 0x65,
-	0x6c, 0x64, 0x73, 0x12, 0x34, 0x0a, 0x06, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x62, 0x61, 0x6e, 0x79, 0x61, 0x6e, 0x64, 0x62, 0x2e, 0x64,
-	0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x6e, 0x74, 0x69, 0x74,
-	0x79, 0x52, 0x06, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12, 0x49, 0x0a, 0x0e, 0x69, 0x6e, 0x74,
-	0x65, 0x72, 0x76, 0x61, 0x6c, 0x5f, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28,
-	0x0b, 0x32, 0x22, 0x2e, 0x62, 0x61, 0x6e, 0x79, 0x61, 0x6e, 0x64, 0x62, 0x2e, 0x64, 0x61, 0x74,
-	0x61, 0x62, 0x61, 0x73, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61,
-	0x6c, 0x52, 0x75, 0x6c, 0x65, 0x52, 0x0d, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x52,
-	0x75, 0x6c, 0x65, 0x73, 0x12, 0x36, 0x0a, 0x04, 0x6f, 0x70, 0x74, 0x73, 0x18, 0x06, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x22, 0x2e, 0x62, 0x61, 0x6e, 0x79, 0x61, 0x6e, 0x64, 0x62, 0x2e, 0x64, 0x61,
-	0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72,
-	0x63, 0x65, 0x4f, 0x70, 0x74, 0x73, 0x52, 0x04, 0x6f, 0x70, 0x74, 0x73, 0x12, 0x50, 0x0a, 0x16,
-	0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x5f, 0x6e, 0x61, 0x6e, 0x6f, 0x73,
-	0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
-	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54,
-	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x14, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65,
-	0x64, 0x41, 0x74, 0x4e, 0x61, 0x6e, 0x6f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x22, 0x8b,
+	0x31, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x74,
+	0x68, 0x6f, 0x64, 0x52, 0x11, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x22, 0x7a, 0x0a, 0x0c, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76,
+	0x61, 0x6c, 0x52, 0x75, 0x6c, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x74, 0x61, 0x67, 0x5f, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x74, 0x61, 0x67, 0x4e, 0x61, 0x6d,
+	0x65, 0x12, 0x12, 0x0a, 0x03, 0x73, 0x74, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00,
+	0x52, 0x03, 0x73, 0x74, 0x72, 0x12, 0x12, 0x0a, 0x03, 0x69, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x03, 0x48, 0x00, 0x52, 0x03, 0x69, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x6e, 0x74,
+	0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x69, 0x6e, 0x74,
+	0x65, 0x72, 0x76, 0x61, 0x6c, 0x42, 0x0b, 0x0a, 0x09, 0x74, 0x61, 0x67, 0x5f, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x22, 0xe9, 0x04, 0x0a, 0x07, 0x4d, 0x65, 0x61, 0x73, 0x75, 0x72, 0x65, 0x12, 0x38,
+	0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1c, 0x2e, 0x62, 0x61, 0x6e, 0x79, 0x61, 0x6e, 0x64, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x6d,
+	0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x08,
+	0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x46, 0x0a, 0x0c, 0x74, 0x61, 0x67, 0x5f,
+	0x66, 0x61, 0x6d, 0x69, 0x6c, 0x69, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23,
+	0x2e, 0x62, 0x61, 0x6e, 0x79, 0x61, 0x6e, 0x64, 0x62, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61,
+	0x73, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61, 0x67, 0x46, 0x61, 0x6d, 0x69, 0x6c, 0x79, 0x53,
+	0x70, 0x65, 0x63, 0x52, 0x0b, 0x74, 0x61, 0x67, 0x46, 0x61, 0x6d, 0x69, 0x6c, 0x69, 0x65, 0x73,
+	0x12, 0x37, 0x0a, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x1f, 0x2e, 0x62, 0x61, 0x6e, 0x79, 0x61, 0x6e, 0x64, 0x62, 0x2e, 0x64, 0x61, 0x74, 0x61,
+	0x62, 0x61, 0x73, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x53, 0x70, 0x65,
+	0x63, 0x52, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x12, 0x34, 0x0a, 0x06, 0x65, 0x6e, 0x74,
+	0x69, 0x74, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x62, 0x61, 0x6e, 0x79,
+	0x61, 0x6e, 0x64, 0x62, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x2e, 0x76, 0x31,
+	0x2e, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x52, 0x06, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12,
+	0x49, 0x0a, 0x0e, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x5f, 0x72, 0x75, 0x6c, 0x65,
+	0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x62, 0x61, 0x6e, 0x79, 0x61, 0x6e,
+	0x64, 0x62, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x49,
+	0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x52, 0x75, 0x6c, 0x65, 0x52, 0x0d, 0x69, 0x6e, 0x74,
+	0x65, 0x72, 0x76, 0x61, 0x6c, 0x52, 0x75, 0x6c, 0x65, 0x73, 0x12, 0x36, 0x0a, 0x04, 0x6f, 0x70,
+	0x74, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x62, 0x61, 0x6e, 0x79, 0x61,
+	0x6e, 0x64, 0x62, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x2e, 0x76, 0x31, 0x2e,
+	0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x4f, 0x70, 0x74, 0x73, 0x52, 0x04, 0x6f, 0x70,
+	0x74, 0x73, 0x12, 0x50, 0x0a, 0x16, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74,
+	0x5f, 0x6e, 0x61, 0x6e, 0x6f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x14,
+	0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x4e, 0x61, 0x6e, 0x6f, 0x73, 0x65, 0x63,
+	0x6f, 0x6e, 0x64, 0x73, 0x12, 0x4d, 0x0a, 0x12, 0x70, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f,
+	0x6e, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1e, 0x2e, 0x62, 0x61, 0x6e, 0x79, 0x61, 0x6e, 0x64, 0x62, 0x2e, 0x64, 0x61, 0x74, 0x61,
+	0x62, 0x61, 0x73, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x11, 0x70, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x74, 0x65, 0x72,
+	0x76, 0x61, 0x6c, 0x12, 0x49, 0x0a, 0x10, 0x72, 0x6f, 0x6c, 0x6c, 0x75, 0x70, 0x5f, 0x69, 0x6e,
+	0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e,
+	0x62, 0x61, 0x6e, 0x79, 0x61, 0x6e, 0x64, 0x62, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73,
+	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0f, 0x72,
+	0x6f, 0x6c, 0x6c, 0x75, 0x70, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x73, 0x22, 0x8b,
 	0x04, 0x0a, 0x0f, 0x54, 0x6f, 0x70, 0x4e, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69,
 	0x6f, 0x6e, 0x12, 0x38, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01,
 	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x62, 0x61, 0x6e, 0x79, 0x61, 0x6e, 0x64, 0x62, 0x2e,
@@ -1622,18 +1907,36 @@ var file_banyandb_database_v1_schema_proto_rawDesc = []byte{
 	0x59, 0x50, 0x45, 0x5f, 0x53, 0x54, 0x52, 0x49, 0x4e, 0x47, 0x10, 0x01, 0x12, 0x12, 0x0a, 0x0e,
 	0x46, 0x49, 0x45, 0x4c, 0x44, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x49, 0x4e, 0x54, 0x10, 0x02,
 	0x12, 0x1a, 0x0a, 0x16, 0x46, 0x49, 0x45, 0x4c, 0x44, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x44,
-	0x41, 0x54, 0x41, 0x5f, 0x42, 0x49, 0x4e, 0x41, 0x52, 0x59, 0x10, 0x03, 0x2a, 0x4e, 0x0a, 0x0e,
+	0x41, 0x54, 0x41, 0x5f, 0x42, 0x49, 0x4e, 0x41, 0x52, 0x59, 0x10, 0x03, 0x2a, 0x69, 0x0a, 0x0e,
 	0x45, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x12, 0x1f,
 	0x0a, 0x1b, 0x45, 0x4e, 0x43, 0x4f, 0x44, 0x49, 0x4e, 0x47, 0x5f, 0x4d, 0x45, 0x54, 0x48, 0x4f,
 	0x44, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12,
 	0x1b, 0x0a, 0x17, 0x45, 0x4e, 0x43, 0x4f, 0x44, 0x49, 0x4e, 0x47, 0x5f, 0x4d, 0x45, 0x54, 0x48,
-	0x4f, 0x44, 0x5f, 0x47, 0x4f, 0x52, 0x49, 0x4c, 0x4c, 0x41, 0x10, 0x01, 0x2a, 0x54, 0x0a, 0x11,
-	0x43, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x74, 0x68, 0x6f,
-	0x64, 0x12, 0x22, 0x0a, 0x1e, 0x43, 0x4f, 0x4d, 0x50, 0x52, 0x45, 0x53, 0x53, 0x49, 0x4f, 0x4e,
-	0x5f, 0x4d, 0x45, 0x54, 0x48, 0x4f, 0x44, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46,
-	0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1b, 0x0a, 0x17, 0x43, 0x4f, 0x4d, 0x50, 0x52, 0x45, 0x53,
-	0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x4d, 0x45, 0x54, 0x48, 0x4f, 0x44, 0x5f, 0x5a, 0x53, 0x54, 0x44,
-	0x10, 0x01, 0x42, 0x72, 0x0a, 0x2a, 0x6f, 0x72, 0x67, 0x2e, 0x61, 0x70, 0x61, 0x63, 0x68, 0x65,
+	0x4f, 0x44, 0x5f, 0x47, 0x4f, 0x52, 0x49, 0x4c, 0x4c, 0x41, 0x10, 0x01, 0x12, 0x19, 0x0a, 0x15,
+	0x45, 0x4e, 0x43, 0x4f, 0x44, 0x49, 0x4e, 0x47, 0x5f, 0x4d, 0x45, 0x54, 0x48, 0x4f, 0x44, 0x5f,
+	0x50, 0x4c, 0x41, 0x49, 0x4e, 0x10, 0x02, 0x2a, 0x54, 0x0a, 0x11, 0x43, 0x6f, 0x6d, 0x70, 0x72,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x12, 0x22, 0x0a, 0x1e,
+	0x43, 0x4f, 0x4d, 0x50, 0x52, 0x45, 0x53, 0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x4d, 0x45, 0x54, 0x48,
+	0x4f, 0x44, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00,
+	0x12, 0x1b, 0x0a, 0x17, 0x43, 0x4f, 0x4d, 0x50, 0x52, 0x45, 0x53, 0x53, 0x49, 0x4f, 0x4e, 0x5f,
+	0x4d, 0x45, 0x54, 0x48, 0x4f, 0x44, 0x5f, 0x5a, 0x53, 0x54, 0x44, 0x10, 0x01, 0x2a, 0x68, 0x0a,
+	0x0d, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x45, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x12, 0x1e,
+	0x0a, 0x1a, 0x53, 0x54, 0x4f, 0x52, 0x41, 0x47, 0x45, 0x5f, 0x45, 0x4e, 0x47, 0x49, 0x4e, 0x45,
+	0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1c,
+	0x0a, 0x18, 0x53, 0x54, 0x4f, 0x52, 0x41, 0x47, 0x45, 0x5f, 0x45, 0x4e, 0x47, 0x49, 0x4e, 0x45,
+	0x5f, 0x4f, 0x56, 0x45, 0x52, 0x57, 0x52, 0x49, 0x54, 0x45, 0x10, 0x01, 0x12, 0x19, 0x0a, 0x15,
+	0x53, 0x54, 0x4f, 0x52, 0x41, 0x47, 0x45, 0x5f, 0x45, 0x4e, 0x47, 0x49, 0x4e, 0x45, 0x5f, 0x41,
+	0x50, 0x50, 0x45, 0x4e, 0x44, 0x10, 0x02, 0x2a, 0x98, 0x01, 0x0a, 0x11, 0x53, 0x68, 0x61, 0x72,
+	0x64, 0x69, 0x6e, 0x67, 0x41, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x12, 0x22, 0x0a,
+	0x1e, 0x53, 0x48, 0x41, 0x52, 0x44, 0x49, 0x4e, 0x47, 0x5f, 0x41, 0x4c, 0x47, 0x4f, 0x52, 0x49,
+	0x54, 0x48, 0x4d, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10,
+	0x00, 0x12, 0x1d, 0x0a, 0x19, 0x53, 0x48, 0x41, 0x52, 0x44, 0x49, 0x4e, 0x47, 0x5f, 0x41, 0x4c,
+	0x47, 0x4f, 0x52, 0x49, 0x54, 0x48, 0x4d, 0x5f, 0x4d, 0x4f, 0x44, 0x55, 0x4c, 0x4f, 0x10, 0x01,
+	0x12, 0x21, 0x0a, 0x1d, 0x53, 0x48, 0x41, 0x52, 0x44, 0x49, 0x4e, 0x47, 0x5f, 0x41, 0x4c, 0x47,
+	0x4f, 0x52, 0x49, 0x54, 0x48, 0x4d, 0x5f, 0x43, 0x4f, 0x4e, 0x53, 0x49, 0x53, 0x54, 0x45, 0x4e,
+	0x54, 0x10, 0x02, 0x12, 0x1d, 0x0a, 0x19, 0x53, 0x48, 0x41, 0x52, 0x44, 0x49, 0x4e, 0x47, 0x5f,
+	0x41, 0x4c, 0x47, 0x4f, 0x52, 0x49, 0x54, 0x48, 0x4d, 0x5f, 0x50, 0x52, 0x45, 0x46, 0x49, 0x58,
+	0x10, 0x03, 0x42, 0x72, 0x0a, 0x2a, 0x6f, 0x72, 0x67, 0x2e, 0x61, 0x70, 0x61, 0x63, 0x68, 0x65,
 	0x2e, 0x73, 0x6b, 0x79, 0x77, 0x61, 0x6c, 0x6b, 0x69, 0x6e, 0x67, 0x2e, 0x62, 0x61, 0x6e, 0x79,
 	0x61, 0x6e, 0x64, 0x62, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x2e, 0x76, 0x31,
 	0x5a, 0x44, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x70, 0x61,
@@ -1655,76 +1958,87 @@ func file_banyandb_database_v1_schema_proto_rawDescGZIP() []byte {
 	return file_banyandb_database_v1_schema_proto_rawDescData
 }
 
-var file_banyandb_database_v1_schema_proto_enumTypes = make([]protoimpl.EnumInfo, 7)
-var file_banyandb_database_v1_schema_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_banyandb_database_v1_schema_proto_enumTypes = make([]protoimpl.EnumInfo, 9)
+var file_banyandb_database_v1_schema_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
 var file_banyandb_database_v1_schema_proto_goTypes = []interface{}{
 	(TagType)(0),                  // 0: banyandb.database.v1.TagType
 	(FieldType)(0),                // 1: banyandb.database.v1.FieldType
 	(EncodingMethod)(0),           // 2: banyandb.database.v1.EncodingMethod
 	(CompressionMethod)(0),        // 3: banyandb.database.v1.CompressionMethod
-	(Duration_DurationUnit)(0),    // 4: banyandb.database.v1.Duration.DurationUnit
-	(IndexRule_Type)(0),           // 5: banyandb.database.v1.IndexRule.Type
-	(IndexRule_Location)(0),       // 6: banyandb.database.v1.IndexRule.Location
-	(*Duration)(nil),              // 7: banyandb.database.v1.Duration
-	(*TagFamilySpec)(nil),         // 8: banyandb.database.v1.TagFamilySpec
-	(*TagSpec)(nil),               // 9: banyandb.database.v1.TagSpec
-	(*Stream)(nil),                // 10: banyandb.database.v1.Stream
-	(*Entity)(nil),                // 11: banyandb.database.v1.Entity
-	(*ResourceOpts)(nil),          // 12: banyandb.database.v1.ResourceOpts
-	(*FieldSpec)(nil),             // 13: banyandb.database.v1.FieldSpec
-	(*IntervalRule)(nil),          // 14: banyandb.database.v1.IntervalRule
-	(*Measure)(nil),               // 15: banyandb.database.v1.Measure
-	(*TopNAggregation)(nil),       // 16: banyandb.database.v1.TopNAggregation
-	(*IndexRule)(nil),             // 17: banyandb.database.v1.IndexRule
-	(*Subject)(nil),               // 18: banyandb.database.v1.Subject
-	(*IndexRuleBinding)(nil),      // 19: banyandb.database.v1.IndexRuleBinding
-	(*v1.Metadata)(nil),           // 20: banyandb.common.v1.Metadata
-	(*timestamppb.Timestamp)(nil), // 21: google.protobuf.Timestamp
-	(v11.Sort)(0),                 // 22: banyandb.model.v1.Sort
-	(*v11.Criteria)(nil),          // 23: banyandb.model.v1.Criteria
-	(v1.Catalog)(0),               // 24: banyandb.common.v1.Catalog
+	(StorageEngine)(0),            // 4: banyandb.database.v1.StorageEngine
+	(ShardingAlgorithm)(0),        // 5: banyandb.database.v1.ShardingAlgorithm
+	(Duration_DurationUnit)(0),    // 6: banyandb.database.v1.Duration.DurationUnit
+	(IndexRule_Type)(0),           // 7: banyandb.database.v1.IndexRule.Type
+	(IndexRule_Location)(0),       // 8: banyandb.database.v1.IndexRule.Location
+	(*Duration)(nil),              // 9: banyandb.database.v1.Duration
+	(*TagFamilySpec)(nil),         // 10: banyandb.database.v1.TagFamilySpec
+	(*TagSpec)(nil),               // 11: banyandb.database.v1.TagSpec
+	(*Stream)(nil),                // 12: banyandb.database.v1.Stream
+	(*Entity)(nil),                // 13: banyandb.database.v1.Entity
+	(*ResourceOpts)(nil),          // 14: banyandb.database.v1.ResourceOpts
+	(*ShardOverride)(nil),         // 15: banyandb.database.v1.ShardOverride
+	(*FieldSpec)(nil),             // 16: banyandb.database.v1.FieldSpec
+	(*IntervalRule)(nil),          // 17: banyandb.database.v1.IntervalRule
+	(*Measure)(nil),               // 18: banyandb.database.v1.Measure
+	(*TopNAggregation)(nil),       // 19: banyandb.database.v1.TopNAggregation
+	(*IndexRule)(nil),             // 20: banyandb.database.v1.IndexRule
+	(*Subject)(nil),               // 21: banyandb.database.v1.Subject
+	(*IndexRuleBinding)(nil),      // 22: banyandb.database.v1.IndexRuleBinding
+	(*v1.Metadata)(nil),           // 23: banyandb.common.v1.Metadata
+	(*timestamppb.Timestamp)(nil), // 24: google.protobuf.Timestamp
+	(*v11.TagValue)(nil),          // 25: banyandb.model.v1.TagValue
+	(v11.Sort)(0),                 // 26: banyandb.model.v1.Sort
+	(*v11.Criteria)(nil),          // 27: banyandb.model.v1.Criteria
+	(v1.Catalog)(0),               // 28: banyandb.common.v1.Catalog
 }
 var file_banyandb_database_v1_schema_proto_depIdxs = []int32{
-	4,  // 0: banyandb.database.v1.Duration.unit:type_name -> banyandb.database.v1.Duration.DurationUnit
-	9,  // 1: banyandb.database.v1.TagFamilySpec.tags:type_name -> banyandb.database.v1.TagSpec
+	6,  // 0: banyandb.database.v1.Duration.unit:type_name -> banyandb.database.v1.Duration.DurationUnit
+	11, // 1: banyandb.database.v1.TagFamilySpec.tags:type_name -> banyandb.database.v1.TagSpec
 	0,  // 2: banyandb.database.v1.TagSpec.type:type_name -> banyandb.database.v1.TagType
-	20, // 3: banyandb.database.v1.Stream.metadata:type_name -> banyandb.common.v1.Metadata
-	8,  // 4: banyandb.database.v1.Stream.tag_families:type_name -> banyandb.database.v1.TagFamilySpec
-	11, // 5: banyandb.database.v1.Stream.entity:type_name -> banyandb.database.v1.Entity
-	12, // 6: banyandb.database.v1.Stream.opts:type_name -> banyandb.database.v1.ResourceOpts
-	21, // 7: banyandb.database.v1.Stream.updated_at_nanoseconds:type_name -> google.protobuf.Timestamp
-	7,  // 8: banyandb.database.v1.ResourceOpts.ttl:type_name -> banyandb.database.v1.Duration
-	1,  // 9: banyandb.database.v1.FieldSpec.field_type:type_name -> banyandb.database.v1.FieldType
-	2,  // 10: banyandb.database.v1.FieldSpec.encoding_method:type_name -> banyandb.database.v1.EncodingMethod
-	3,  // 11: banyandb.database.v1.FieldSpec.compression_method:type_name -> banyandb.database.v1.CompressionMethod
-	20, // 12: banyandb.database.v1.Measure.metadata:type_name -> banyandb.common.v1.Metadata
-	8,  // 13: banyandb.database.v1.Measure.tag_families:type_name -> banyandb.database.v1.TagFamilySpec
-	13, // 14: banyandb.database.v1.Measure.fields:type_name -> banyandb.database.v1.FieldSpec
-	11, // 15: banyandb.database.v1.Measure.entity:type_name -> banyandb.database.v1.Entity
-	14, // 16: banyandb.database.v1.Measure.interval_rules:type_name -> banyandb.database.v1.IntervalRule
-	12, // 17: banyandb.database.v1.Measure.opts:type_name -> banyandb.database.v1.ResourceOpts
-	21, // 18: banyandb.database.v1.Measure.updated_at_nanoseconds:type_name -> google.protobuf.Timestamp
-	20, // 19: banyandb.database.v1.TopNAggregation.metadata:type_name -> banyandb.common.v1.Metadata
-	20, // 20: banyandb.database.v1.TopNAggregation.source_measure:type_name -> banyandb.common.v1.Metadata
-	22, // 21: banyandb.database.v1.TopNAggregation.field_value_sort:type_name -> banyandb.model.v1.Sort
-	23, // 22: banyandb.database.v1.TopNAggregation.criteria:type_name -> banyandb.model.v1.Criteria
-	12, // 23: banyandb.database.v1.TopNAggregation.opts:type_name -> banyandb.database.v1.ResourceOpts
-	21, // 24: banyandb.database.v1.TopNAggregation.updated_at_nanoseconds:type_name -> google.protobuf.Timestamp
-	20, // 25: banyandb.database.v1.IndexRule.metadata:type_name -> banyandb.common.v1.Metadata
-	5,  // 26: banyandb.database.v1.IndexRule.type:type_name -> banyandb.database.v1.IndexRule.Type
-	6,  // 27: banyandb.database.v1.IndexRule.location:type_name -> banyandb.database.v1.IndexRule.Location
-	21, // 28: banyandb.database.v1.IndexRule.updated_at:type_name -> google.protobuf.Timestamp
-	24, // 29: banyandb.database.v1.Subject.catalog:type_name -> banyandb.common.v1.Catalog
-	20, // 30: banyandb.database.v1.IndexRuleBinding.metadata:type_name -> banyandb.common.v1.Metadata
-	18, // 31: banyandb.database.v1.IndexRuleBinding.subject:type_name -> banyandb.database.v1.Subject
-	21, // 32: banyandb.database.v1.IndexRuleBinding.begin_at:type_name -> google.protobuf.Timestamp
-	21, // 33: banyandb.database.v1.IndexRuleBinding.expire_at:type_name -> google.protobuf.Timestamp
-	21, // 34: banyandb.database.v1.IndexRuleBinding.updated_at:type_name -> google.protobuf.Timestamp
-	35, // [35:35] is the sub-list for method output_type
-	35, // [35:35] is the sub-list for method input_type
-	35, // [35:35] is the sub-list for extension type_name
-	35, // [35:35] is the sub-list for extension extendee
-	0,  // [0:35] is the sub-list for field type_name
+	23, // 3: banyandb.database.v1.Stream.metadata:type_name -> banyandb.common.v1.Metadata
+	10, // 4: banyandb.database.v1.Stream.tag_families:type_name -> banyandb.database.v1.TagFamilySpec
+	13, // 5: banyandb.database.v1.Stream.entity:type_name -> banyandb.database.v1.Entity
+	14, // 6: banyandb.database.v1.Stream.opts:type_name -> banyandb.database.v1.ResourceOpts
+	24, // 7: banyandb.database.v1.Stream.updated_at_nanoseconds:type_name -> google.protobuf.Timestamp
+	9,  // 8: banyandb.database.v1.ResourceOpts.ttl:type_name -> banyandb.database.v1.Duration
+	4,  // 9: banyandb.database.v1.ResourceOpts.storage_engine:type_name -> banyandb.database.v1.StorageEngine
+	15, // 10: banyandb.database.v1.ResourceOpts.shard_overrides:type_name -> banyandb.database.v1.ShardOverride
+	5,  // 11: banyandb.database.v1.ResourceOpts.sharding_algorithm:type_name -> banyandb.database.v1.ShardingAlgorithm
+	2,  // 12: banyandb.database.v1.ResourceOpts.encoding_method:type_name -> banyandb.database.v1.EncodingMethod
+	25, // 13: banyandb.database.v1.ShardOverride.entity:type_name -> banyandb.model.v1.TagValue
+	1,  // 14: banyandb.database.v1.FieldSpec.field_type:type_name -> banyandb.database.v1.FieldType
+	2,  // 15: banyandb.database.v1.FieldSpec.encoding_method:type_name -> banyandb.database.v1.EncodingMethod
+	3,  // 16: banyandb.database.v1.FieldSpec.compression_method:type_name -> banyandb.database.v1.CompressionMethod
+	23, // 17: banyandb.database.v1.Measure.metadata:type_name -> banyandb.common.v1.Metadata
+	10, // 18: banyandb.database.v1.Measure.tag_families:type_name -> banyandb.database.v1.TagFamilySpec
+	16, // 19: banyandb.database.v1.Measure.fields:type_name -> banyandb.database.v1.FieldSpec
+	13, // 20: banyandb.database.v1.Measure.entity:type_name -> banyandb.database.v1.Entity
+	17, // 21: banyandb.database.v1.Measure.interval_rules:type_name -> banyandb.database.v1.IntervalRule
+	14, // 22: banyandb.database.v1.Measure.opts:type_name -> banyandb.database.v1.ResourceOpts
+	24, // 23: banyandb.database.v1.Measure.updated_at_nanoseconds:type_name -> google.protobuf.Timestamp
+	9,  // 24: banyandb.database.v1.Measure.partition_interval:type_name -> banyandb.database.v1.Duration
+	9,  // 25: banyandb.database.v1.Measure.rollup_intervals:type_name -> banyandb.database.v1.Duration
+	23, // 26: banyandb.database.v1.TopNAggregation.metadata:type_name -> banyandb.common.v1.Metadata
+	23, // 27: banyandb.database.v1.TopNAggregation.source_measure:type_name -> banyandb.common.v1.Metadata
+	26, // 28: banyandb.database.v1.TopNAggregation.field_value_sort:type_name -> banyandb.model.v1.Sort
+	27, // 29: banyandb.database.v1.TopNAggregation.criteria:type_name -> banyandb.model.v1.Criteria
+	14, // 30: banyandb.database.v1.TopNAggregation.opts:type_name -> banyandb.database.v1.ResourceOpts
+	24, // 31: banyandb.database.v1.TopNAggregation.updated_at_nanoseconds:type_name -> google.protobuf.Timestamp
+	23, // 32: banyandb.database.v1.IndexRule.metadata:type_name -> banyandb.common.v1.Metadata
+	7,  // 33: banyandb.database.v1.IndexRule.type:type_name -> banyandb.database.v1.IndexRule.Type
+	8,  // 34: banyandb.database.v1.IndexRule.location:type_name -> banyandb.database.v1.IndexRule.Location
+	24, // 35: banyandb.database.v1.IndexRule.updated_at:type_name -> google.protobuf.Timestamp
+	28, // 36: banyandb.database.v1.Subject.catalog:type_name -> banyandb.common.v1.Catalog
+	23, // 37: banyandb.database.v1.IndexRuleBinding.metadata:type_name -> banyandb.common.v1.Metadata
+	21, // 38: banyandb.database.v1.IndexRuleBinding.subject:type_name -> banyandb.database.v1.Subject
+	24, // 39: banyandb.database.v1.IndexRuleBinding.begin_at:type_name -> google.protobuf.Timestamp
+	24, // 40: banyandb.database.v1.IndexRuleBinding.expire_at:type_name -> google.protobuf.Timestamp
+	24, // 41: banyandb.database.v1.IndexRuleBinding.updated_at:type_name -> google.protobuf.Timestamp
+	42, // [42:42] is the sub-list for method output_type
+	42, // [42:42] is the sub-list for method input_type
+	42, // [42:42] is the sub-list for extension type_name
+	42, // [42:42] is the sub-list for extension extendee
+	0,  // [0:42] is the sub-list for field type_name
 }
 
 func init() { file_banyandb_database_v1_schema_proto_init() }
@@ -1806,7 +2120,7 @@ func file_banyandb_database_v1_schema_proto_init() {
 			}
 		}
 		file_banyandb_database_v1_schema_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*FieldSpec); i {
+			switch v := v.(*ShardOverride); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1818,7 +2132,7 @@ func file_banyandb_database_v1_schema_proto_init() {
 			}
 		}
 		file_banyandb_database_v1_schema_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*IntervalRule); i {
+			switch v := v.(*FieldSpec); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1830,7 +2144,7 @@ func file_banyandb_database_v1_schema_proto_init() {
 			}
 		}
 		file_banyandb_database_v1_schema_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Measure); i {
+			switch v := v.(*IntervalRule); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1842,7 +2156,7 @@ func file_banyandb_database_v1_schema_proto_init() {
 			}
 		}
 		file_banyandb_database_v1_schema_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TopNAggregation); i {
+			switch v := v.(*Measure); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1854,7 +2168,7 @@ func file_banyandb_database_v1_schema_proto_init() {
 			}
 		}
 		file_banyandb_database_v1_schema_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*IndexRule); i {
+			switch v := v.(*TopNAggregation); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1866,7 +2180,7 @@ func file_banyandb_database_v1_schema_proto_init() {
 			}
 		}
 		file_banyandb_database_v1_schema_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Subject); i {
+			switch v := v.(*IndexRule); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1878,6 +2192,18 @@ func file_banyandb_database_v1_schema_proto_init() {
 			}
 		}
 		file_banyandb_database_v1_schema_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Subject); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_banyandb_database_v1_schema_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*IndexRuleBinding); i {
 			case 0:
 				return &v.state
@@ -1890,7 +2216,7 @@ func file_banyandb_database_v1_schema_proto_init() {
 			}
 		}
 	}
-	file_banyandb_database_v1_schema_proto_msgTypes[7].OneofWrappers = []interface{}{
+	file_banyandb_database_v1_schema_proto_msgTypes[8].OneofWrappers = []interface{}{
 		(*IntervalRule_Str)(nil),
 		(*IntervalRule_Int)(nil),
 	}
@@ -1899,8 +2225,8 @@ func file_banyandb_database_v1_schema_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_banyandb_database_v1_schema_proto_rawDesc,
-			NumEnums:      7,
-			NumMessages:   13,
+			NumEnums:      9,
+			NumMessages:   14,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
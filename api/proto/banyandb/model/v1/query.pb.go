@@ -92,6 +92,11 @@ func (Sort) EnumDescriptor() ([]byte, []int) {
 // For EQ, NE, LT, GT, LE and GE, only one operand should be given, i.e. one-to-one relationship.
 // HAVING and NOT_HAVING allow multi-value to be the operand such as array/vector, i.e. one-to-many relationship.
 // For example, "keyA" contains "valueA" **and** "valueB"
+// IN and NOT_IN test whether a single-value tag is one of the given values.
+// MATCH tests a string tag against a regular expression. It can't be served by indices,
+// so the tag is read and evaluated during the scan.
+// EXISTS and MISSING test whether the tag is set regardless of its value, and the value is ignored.
+// EXISTS is served by the index bound to the tag if there is one, while MISSING is always evaluated during the scan.
 type Condition_BinaryOp int32
 
 const (
@@ -106,6 +111,9 @@ const (
 	Condition_BINARY_OP_NOT_HAVING  Condition_BinaryOp = 8
 	Condition_BINARY_OP_IN          Condition_BinaryOp = 9
 	Condition_BINARY_OP_NOT_IN      Condition_BinaryOp = 10
+	Condition_BINARY_OP_MATCH       Condition_BinaryOp = 11
+	Condition_BINARY_OP_EXISTS      Condition_BinaryOp = 12
+	Condition_BINARY_OP_MISSING     Condition_BinaryOp = 13
 )
 
 // Enum value maps for Condition_BinaryOp.
@@ -122,6 +130,9 @@ var (
 		8:  "BINARY_OP_NOT_HAVING",
 		9:  "BINARY_OP_IN",
 		10: "BINARY_OP_NOT_IN",
+		11: "BINARY_OP_MATCH",
+		12: "BINARY_OP_EXISTS",
+		13: "BINARY_OP_MISSING",
 	}
 	Condition_BinaryOp_value = map[string]int32{
 		"BINARY_OP_UNSPECIFIED": 0,
@@ -135,6 +146,9 @@ var (
 		"BINARY_OP_NOT_HAVING":  8,
 		"BINARY_OP_IN":          9,
 		"BINARY_OP_NOT_IN":      10,
+		"BINARY_OP_MATCH":       11,
+		"BINARY_OP_EXISTS":      12,
+		"BINARY_OP_MISSING":     13,
 	}
 )
 
@@ -165,6 +179,58 @@ func (Condition_BinaryOp) EnumDescriptor() ([]byte, []int) {
 	return file_banyandb_model_v1_query_proto_rawDescGZIP(), []int{2, 0}
 }
 
+type LogicalExpression_LogicalOp int32
+
+const (
+	LogicalExpression_LOGICAL_OP_UNSPECIFIED LogicalExpression_LogicalOp = 0
+	LogicalExpression_LOGICAL_OP_AND         LogicalExpression_LogicalOp = 1
+	LogicalExpression_LOGICAL_OP_OR          LogicalExpression_LogicalOp = 2
+	LogicalExpression_LOGICAL_OP_NOT         LogicalExpression_LogicalOp = 3
+)
+
+// Enum value maps for LogicalExpression_LogicalOp.
+var (
+	LogicalExpression_LogicalOp_name = map[int32]string{
+		0: "LOGICAL_OP_UNSPECIFIED",
+		1: "LOGICAL_OP_AND",
+		2: "LOGICAL_OP_OR",
+		3: "LOGICAL_OP_NOT",
+	}
+	LogicalExpression_LogicalOp_value = map[string]int32{
+		"LOGICAL_OP_UNSPECIFIED": 0,
+		"LOGICAL_OP_AND":         1,
+		"LOGICAL_OP_OR":          2,
+		"LOGICAL_OP_NOT":         3,
+	}
+)
+
+func (x LogicalExpression_LogicalOp) Enum() *LogicalExpression_LogicalOp {
+	p := new(LogicalExpression_LogicalOp)
+	*p = x
+	return p
+}
+
+func (x LogicalExpression_LogicalOp) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (LogicalExpression_LogicalOp) Descriptor() protoreflect.EnumDescriptor {
+	return file_banyandb_model_v1_query_proto_enumTypes[2].Descriptor()
+}
+
+func (LogicalExpression_LogicalOp) Type() protoreflect.EnumType {
+	return &file_banyandb_model_v1_query_proto_enumTypes[2]
+}
+
+func (x LogicalExpression_LogicalOp) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use LogicalExpression_LogicalOp.Descriptor instead.
+func (LogicalExpression_LogicalOp) EnumDescriptor() ([]byte, []int) {
+	return file_banyandb_model_v1_query_proto_rawDescGZIP(), []int{4, 0}
+}
+
 // Pair is the building block of a record which is equivalent to a key-value pair.
 // In the context of Trace, it could be metadata of a trace such as service_name, service_instance, etc.
 // Besides, other tags are organized in key-value pair in the underlying storage layer.
@@ -401,6 +467,145 @@ func (x *Criteria) GetConditions() []*Condition {
 	return nil
 }
 
+// LogicalExpression combines the expressions with a logical operator.
+// NOT takes exactly one expression.
+type LogicalExpression struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Op          LogicalExpression_LogicalOp `protobuf:"varint,1,opt,name=op,proto3,enum=banyandb.model.v1.LogicalExpression_LogicalOp" json:"op,omitempty"`
+	Expressions []*Expression               `protobuf:"bytes,2,rep,name=expressions,proto3" json:"expressions,omitempty"`
+}
+
+func (x *LogicalExpression) Reset() {
+	*x = LogicalExpression{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_banyandb_model_v1_query_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LogicalExpression) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogicalExpression) ProtoMessage() {}
+
+func (x *LogicalExpression) ProtoReflect() protoreflect.Message {
+	mi := &file_banyandb_model_v1_query_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogicalExpression.ProtoReflect.Descriptor instead.
+func (*LogicalExpression) Descriptor() ([]byte, []int) {
+	return file_banyandb_model_v1_query_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *LogicalExpression) GetOp() LogicalExpression_LogicalOp {
+	if x != nil {
+		return x.Op
+	}
+	return LogicalExpression_LOGICAL_OP_UNSPECIFIED
+}
+
+func (x *LogicalExpression) GetExpressions() []*Expression {
+	if x != nil {
+		return x.Expressions
+	}
+	return nil
+}
+
+// Expression is a node of a boolean expression tree.
+// The conditions of a criteria leaf are combined by AND.
+type Expression struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Exp:
+	//	*Expression_Criteria
+	//	*Expression_Logical
+	Exp isExpression_Exp `protobuf_oneof:"exp"`
+}
+
+func (x *Expression) Reset() {
+	*x = Expression{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_banyandb_model_v1_query_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Expression) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Expression) ProtoMessage() {}
+
+func (x *Expression) ProtoReflect() protoreflect.Message {
+	mi := &file_banyandb_model_v1_query_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Expression.ProtoReflect.Descriptor instead.
+func (*Expression) Descriptor() ([]byte, []int) {
+	return file_banyandb_model_v1_query_proto_rawDescGZIP(), []int{5}
+}
+
+func (m *Expression) GetExp() isExpression_Exp {
+	if m != nil {
+		return m.Exp
+	}
+	return nil
+}
+
+func (x *Expression) GetCriteria() *Criteria {
+	if x, ok := x.GetExp().(*Expression_Criteria); ok {
+		return x.Criteria
+	}
+	return nil
+}
+
+func (x *Expression) GetLogical() *LogicalExpression {
+	if x, ok := x.GetExp().(*Expression_Logical); ok {
+		return x.Logical
+	}
+	return nil
+}
+
+type isExpression_Exp interface {
+	isExpression_Exp()
+}
+
+type Expression_Criteria struct {
+	Criteria *Criteria `protobuf:"bytes,1,opt,name=criteria,proto3,oneof"`
+}
+
+type Expression_Logical struct {
+	Logical *LogicalExpression `protobuf:"bytes,2,opt,name=logical,proto3,oneof"`
+}
+
+func (*Expression_Criteria) isExpression_Exp() {}
+
+func (*Expression_Logical) isExpression_Exp() {}
+
 // QueryOrder means a Sort operation to be done for a given index rule.
 // The index_rule_name refers to the name of a index rule bound to the subject.
 type QueryOrder struct {
@@ -415,7 +620,7 @@ type QueryOrder struct {
 func (x *QueryOrder) Reset() {
 	*x = QueryOrder{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_banyandb_model_v1_query_proto_msgTypes[4]
+		mi := &file_banyandb_model_v1_query_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -428,7 +633,7 @@ func (x *QueryOrder) String() string {
 func (*QueryOrder) ProtoMessage() {}
 
 func (x *QueryOrder) ProtoReflect() protoreflect.Message {
-	mi := &file_banyandb_model_v1_query_proto_msgTypes[4]
+	mi := &file_banyandb_model_v1_query_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -441,7 +646,7 @@ func (x *QueryOrder) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use QueryOrder.ProtoReflect.Descriptor instead.
 func (*QueryOrder) Descriptor() ([]byte, []int) {
-	return file_banyandb_model_v1_query_proto_rawDescGZIP(), []int{4}
+	return file_banyandb_model_v1_query_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *QueryOrder) GetIndexRuleName() string {
@@ -470,7 +675,7 @@ type TagProjection struct {
 func (x *TagProjection) Reset() {
 	*x = TagProjection{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_banyandb_model_v1_query_proto_msgTypes[5]
+		mi := &file_banyandb_model_v1_query_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -483,7 +688,7 @@ func (x *TagProjection) String() string {
 func (*TagProjection) ProtoMessage() {}
 
 func (x *TagProjection) ProtoReflect() protoreflect.Message {
-	mi := &file_banyandb_model_v1_query_proto_msgTypes[5]
+	mi := &file_banyandb_model_v1_query_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -496,7 +701,7 @@ func (x *TagProjection) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TagProjection.ProtoReflect.Descriptor instead.
 func (*TagProjection) Descriptor() ([]byte, []int) {
-	return file_banyandb_model_v1_query_proto_rawDescGZIP(), []int{5}
+	return file_banyandb_model_v1_query_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *TagProjection) GetTagFamilies() []*TagProjection_TagFamily {
@@ -520,7 +725,7 @@ type TimeRange struct {
 func (x *TimeRange) Reset() {
 	*x = TimeRange{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_banyandb_model_v1_query_proto_msgTypes[6]
+		mi := &file_banyandb_model_v1_query_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -533,7 +738,7 @@ func (x *TimeRange) String() string {
 func (*TimeRange) ProtoMessage() {}
 
 func (x *TimeRange) ProtoReflect() protoreflect.Message {
-	mi := &file_banyandb_model_v1_query_proto_msgTypes[6]
+	mi := &file_banyandb_model_v1_query_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -546,7 +751,7 @@ func (x *TimeRange) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TimeRange.ProtoReflect.Descriptor instead.
 func (*TimeRange) Descriptor() ([]byte, []int) {
-	return file_banyandb_model_v1_query_proto_rawDescGZIP(), []int{6}
+	return file_banyandb_model_v1_query_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *TimeRange) GetBegin() *timestamppb.Timestamp {
@@ -575,7 +780,7 @@ type TagProjection_TagFamily struct {
 func (x *TagProjection_TagFamily) Reset() {
 	*x = TagProjection_TagFamily{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_banyandb_model_v1_query_proto_msgTypes[7]
+		mi := &file_banyandb_model_v1_query_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -588,7 +793,7 @@ func (x *TagProjection_TagFamily) String() string {
 func (*TagProjection_TagFamily) ProtoMessage() {}
 
 func (x *TagProjection_TagFamily) ProtoReflect() protoreflect.Message {
-	mi := &file_banyandb_model_v1_query_proto_msgTypes[7]
+	mi := &file_banyandb_model_v1_query_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -601,7 +806,7 @@ func (x *TagProjection_TagFamily) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TagProjection_TagFamily.ProtoReflect.Descriptor instead.
 func (*TagProjection_TagFamily) Descriptor() ([]byte, []int) {
-	return file_banyandb_model_v1_query_proto_rawDescGZIP(), []int{5, 0}
+	return file_banyandb_model_v1_query_proto_rawDescGZIP(), []int{7, 0}
 }
 
 func (x *TagProjection_TagFamily) GetName() string {
@@ -637,7 +842,7 @@ var file_banyandb_model_v1_query_proto_rawDesc = []byte{
 	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
 	0x12, 0x2a, 0x0a, 0x04, 0x74, 0x61, 0x67, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16,
 	0x2e, 0x62, 0x61, 0x6e, 0x79, 0x61, 0x6e, 0x64, 0x62, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2e,
-	0x76, 0x31, 0x2e, 0x54, 0x61, 0x67, 0x52, 0x04, 0x74, 0x61, 0x67, 0x73, 0x22, 0xf5, 0x02, 0x0a,
+	0x76, 0x31, 0x2e, 0x54, 0x61, 0x67, 0x52, 0x04, 0x74, 0x61, 0x67, 0x73, 0x22, 0xb7, 0x03, 0x0a,
 	0x09, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
 	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x35,
 	0x0a, 0x02, 0x6f, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x25, 0x2e, 0x62, 0x61, 0x6e,
@@ -646,7 +851,7 @@ var file_banyandb_model_v1_query_proto_rawDesc = []byte{
 	0x70, 0x52, 0x02, 0x6f, 0x70, 0x12, 0x31, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x03,
 	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x62, 0x61, 0x6e, 0x79, 0x61, 0x6e, 0x64, 0x62, 0x2e,
 	0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61, 0x67, 0x56, 0x61, 0x6c, 0x75,
-	0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0xe9, 0x01, 0x0a, 0x08, 0x42, 0x69, 0x6e,
+	0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0xab, 0x02, 0x0a, 0x08, 0x42, 0x69, 0x6e,
 	0x61, 0x72, 0x79, 0x4f, 0x70, 0x12, 0x19, 0x0a, 0x15, 0x42, 0x49, 0x4e, 0x41, 0x52, 0x59, 0x5f,
 	0x4f, 0x50, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00,
 	0x12, 0x10, 0x0a, 0x0c, 0x42, 0x49, 0x4e, 0x41, 0x52, 0x59, 0x5f, 0x4f, 0x50, 0x5f, 0x45, 0x51,
@@ -661,14 +866,43 @@ var file_banyandb_model_v1_query_proto_rawDesc = []byte{
 	0x4e, 0x4f, 0x54, 0x5f, 0x48, 0x41, 0x56, 0x49, 0x4e, 0x47, 0x10, 0x08, 0x12, 0x10, 0x0a, 0x0c,
 	0x42, 0x49, 0x4e, 0x41, 0x52, 0x59, 0x5f, 0x4f, 0x50, 0x5f, 0x49, 0x4e, 0x10, 0x09, 0x12, 0x14,
 	0x0a, 0x10, 0x42, 0x49, 0x4e, 0x41, 0x52, 0x59, 0x5f, 0x4f, 0x50, 0x5f, 0x4e, 0x4f, 0x54, 0x5f,
-	0x49, 0x4e, 0x10, 0x0a, 0x22, 0x70, 0x0a, 0x08, 0x43, 0x72, 0x69, 0x74, 0x65, 0x72, 0x69, 0x61,
-	0x12, 0x26, 0x0a, 0x0f, 0x74, 0x61, 0x67, 0x5f, 0x66, 0x61, 0x6d, 0x69, 0x6c, 0x79, 0x5f, 0x6e,
-	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x74, 0x61, 0x67, 0x46, 0x61,
-	0x6d, 0x69, 0x6c, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x3c, 0x0a, 0x0a, 0x63, 0x6f, 0x6e, 0x64,
-	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x62,
-	0x61, 0x6e, 0x79, 0x61, 0x6e, 0x64, 0x62, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x76, 0x31,
-	0x2e, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x63, 0x6f, 0x6e, 0x64,
-	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x61, 0x0a, 0x0a, 0x51, 0x75, 0x65, 0x72, 0x79, 0x4f,
+	0x49, 0x4e, 0x10, 0x0a, 0x12, 0x13, 0x0a, 0x0f, 0x42, 0x49, 0x4e, 0x41, 0x52, 0x59, 0x5f, 0x4f,
+	0x50, 0x5f, 0x4d, 0x41, 0x54, 0x43, 0x48, 0x10, 0x0b, 0x12, 0x14, 0x0a, 0x10, 0x42, 0x49, 0x4e,
+	0x41, 0x52, 0x59, 0x5f, 0x4f, 0x50, 0x5f, 0x45, 0x58, 0x49, 0x53, 0x54, 0x53, 0x10, 0x0c, 0x12,
+	0x15, 0x0a, 0x11, 0x42, 0x49, 0x4e, 0x41, 0x52, 0x59, 0x5f, 0x4f, 0x50, 0x5f, 0x4d, 0x49, 0x53,
+	0x53, 0x49, 0x4e, 0x47, 0x10, 0x0d, 0x22, 0x70, 0x0a, 0x08, 0x43, 0x72, 0x69, 0x74, 0x65, 0x72,
+	0x69, 0x61, 0x12, 0x26, 0x0a, 0x0f, 0x74, 0x61, 0x67, 0x5f, 0x66, 0x61, 0x6d, 0x69, 0x6c, 0x79,
+	0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x74, 0x61, 0x67,
+	0x46, 0x61, 0x6d, 0x69, 0x6c, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x3c, 0x0a, 0x0a, 0x63, 0x6f,
+	0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c,
+	0x2e, 0x62, 0x61, 0x6e, 0x79, 0x61, 0x6e, 0x64, 0x62, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2e,
+	0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x63, 0x6f,
+	0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0xf8, 0x01, 0x0a, 0x11, 0x4c, 0x6f, 0x67,
+	0x69, 0x63, 0x61, 0x6c, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x3e,
+	0x0a, 0x02, 0x6f, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x2e, 0x2e, 0x62, 0x61, 0x6e,
+	0x79, 0x61, 0x6e, 0x64, 0x62, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x4c,
+	0x6f, 0x67, 0x69, 0x63, 0x61, 0x6c, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x2e, 0x4c, 0x6f, 0x67, 0x69, 0x63, 0x61, 0x6c, 0x4f, 0x70, 0x52, 0x02, 0x6f, 0x70, 0x12, 0x3f,
+	0x0a, 0x0b, 0x65, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x62, 0x61, 0x6e, 0x79, 0x61, 0x6e, 0x64, 0x62, 0x2e, 0x6d,
+	0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x52, 0x0b, 0x65, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x22,
+	0x62, 0x0a, 0x09, 0x4c, 0x6f, 0x67, 0x69, 0x63, 0x61, 0x6c, 0x4f, 0x70, 0x12, 0x1a, 0x0a, 0x16,
+	0x4c, 0x4f, 0x47, 0x49, 0x43, 0x41, 0x4c, 0x5f, 0x4f, 0x50, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45,
+	0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x12, 0x0a, 0x0e, 0x4c, 0x4f, 0x47, 0x49,
+	0x43, 0x41, 0x4c, 0x5f, 0x4f, 0x50, 0x5f, 0x41, 0x4e, 0x44, 0x10, 0x01, 0x12, 0x11, 0x0a, 0x0d,
+	0x4c, 0x4f, 0x47, 0x49, 0x43, 0x41, 0x4c, 0x5f, 0x4f, 0x50, 0x5f, 0x4f, 0x52, 0x10, 0x02, 0x12,
+	0x12, 0x0a, 0x0e, 0x4c, 0x4f, 0x47, 0x49, 0x43, 0x41, 0x4c, 0x5f, 0x4f, 0x50, 0x5f, 0x4e, 0x4f,
+	0x54, 0x10, 0x03, 0x22, 0x90, 0x01, 0x0a, 0x0a, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x12, 0x39, 0x0a, 0x08, 0x63, 0x72, 0x69, 0x74, 0x65, 0x72, 0x69, 0x61, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x62, 0x61, 0x6e, 0x79, 0x61, 0x6e, 0x64, 0x62, 0x2e,
+	0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x69, 0x74, 0x65, 0x72, 0x69,
+	0x61, 0x48, 0x00, 0x52, 0x08, 0x63, 0x72, 0x69, 0x74, 0x65, 0x72, 0x69, 0x61, 0x12, 0x40, 0x0a,
+	0x07, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24,
+	0x2e, 0x62, 0x61, 0x6e, 0x79, 0x61, 0x6e, 0x64, 0x62, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2e,
+	0x76, 0x31, 0x2e, 0x4c, 0x6f, 0x67, 0x69, 0x63, 0x61, 0x6c, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x07, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x61, 0x6c, 0x42,
+	0x05, 0x0a, 0x03, 0x65, 0x78, 0x70, 0x22, 0x61, 0x0a, 0x0a, 0x51, 0x75, 0x65, 0x72, 0x79, 0x4f,
 	0x72, 0x64, 0x65, 0x72, 0x12, 0x26, 0x0a, 0x0f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x5f, 0x72, 0x75,
 	0x6c, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x69,
 	0x6e, 0x64, 0x65, 0x78, 0x52, 0x75, 0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x2b, 0x0a, 0x04,
@@ -716,37 +950,44 @@ func file_banyandb_model_v1_query_proto_rawDescGZIP() []byte {
 	return file_banyandb_model_v1_query_proto_rawDescData
 }
 
-var file_banyandb_model_v1_query_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_banyandb_model_v1_query_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_banyandb_model_v1_query_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_banyandb_model_v1_query_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
 var file_banyandb_model_v1_query_proto_goTypes = []interface{}{
-	(Sort)(0),                       // 0: banyandb.model.v1.Sort
-	(Condition_BinaryOp)(0),         // 1: banyandb.model.v1.Condition.BinaryOp
-	(*Tag)(nil),                     // 2: banyandb.model.v1.Tag
-	(*TagFamily)(nil),               // 3: banyandb.model.v1.TagFamily
-	(*Condition)(nil),               // 4: banyandb.model.v1.Condition
-	(*Criteria)(nil),                // 5: banyandb.model.v1.Criteria
-	(*QueryOrder)(nil),              // 6: banyandb.model.v1.QueryOrder
-	(*TagProjection)(nil),           // 7: banyandb.model.v1.TagProjection
-	(*TimeRange)(nil),               // 8: banyandb.model.v1.TimeRange
-	(*TagProjection_TagFamily)(nil), // 9: banyandb.model.v1.TagProjection.TagFamily
-	(*TagValue)(nil),                // 10: banyandb.model.v1.TagValue
-	(*timestamppb.Timestamp)(nil),   // 11: google.protobuf.Timestamp
+	(Sort)(0),                        // 0: banyandb.model.v1.Sort
+	(Condition_BinaryOp)(0),          // 1: banyandb.model.v1.Condition.BinaryOp
+	(LogicalExpression_LogicalOp)(0), // 2: banyandb.model.v1.LogicalExpression.LogicalOp
+	(*Tag)(nil),                      // 3: banyandb.model.v1.Tag
+	(*TagFamily)(nil),                // 4: banyandb.model.v1.TagFamily
+	(*Condition)(nil),                // 5: banyandb.model.v1.Condition
+	(*Criteria)(nil),                 // 6: banyandb.model.v1.Criteria
+	(*LogicalExpression)(nil),        // 7: banyandb.model.v1.LogicalExpression
+	(*Expression)(nil),               // 8: banyandb.model.v1.Expression
+	(*QueryOrder)(nil),               // 9: banyandb.model.v1.QueryOrder
+	(*TagProjection)(nil),            // 10: banyandb.model.v1.TagProjection
+	(*TimeRange)(nil),                // 11: banyandb.model.v1.TimeRange
+	(*TagProjection_TagFamily)(nil),  // 12: banyandb.model.v1.TagProjection.TagFamily
+	(*TagValue)(nil),                 // 13: banyandb.model.v1.TagValue
+	(*timestamppb.Timestamp)(nil),    // 14: google.protobuf.Timestamp
 }
 var file_banyandb_model_v1_query_proto_depIdxs = []int32{
-	10, // 0: banyandb.model.v1.Tag.value:type_name -> banyandb.model.v1.TagValue
-	2,  // 1: banyandb.model.v1.TagFamily.tags:type_name -> banyandb.model.v1.Tag
+	13, // 0: banyandb.model.v1.Tag.value:type_name -> banyandb.model.v1.TagValue
+	3,  // 1: banyandb.model.v1.TagFamily.tags:type_name -> banyandb.model.v1.Tag
 	1,  // 2: banyandb.model.v1.Condition.op:type_name -> banyandb.model.v1.Condition.BinaryOp
-	10, // 3: banyandb.model.v1.Condition.value:type_name -> banyandb.model.v1.TagValue
-	4,  // 4: banyandb.model.v1.Criteria.conditions:type_name -> banyandb.model.v1.Condition
-	0,  // 5: banyandb.model.v1.QueryOrder.sort:type_name -> banyandb.model.v1.Sort
-	9,  // 6: banyandb.model.v1.TagProjection.tag_families:type_name -> banyandb.model.v1.TagProjection.TagFamily
-	11, // 7: banyandb.model.v1.TimeRange.begin:type_name -> google.protobuf.Timestamp
-	11, // 8: banyandb.model.v1.TimeRange.end:type_name -> google.protobuf.Timestamp
-	9,  // [9:9] is the sub-list for method output_type
-	9,  // [9:9] is the sub-list for method input_type
-	9,  // [9:9] is the sub-list for extension type_name
-	9,  // [9:9] is the sub-list for extension extendee
-	0,  // [0:9] is the sub-list for field type_name
+	13, // 3: banyandb.model.v1.Condition.value:type_name -> banyandb.model.v1.TagValue
+	5,  // 4: banyandb.model.v1.Criteria.conditions:type_name -> banyandb.model.v1.Condition
+	2,  // 5: banyandb.model.v1.LogicalExpression.op:type_name -> banyandb.model.v1.LogicalExpression.LogicalOp
+	8,  // 6: banyandb.model.v1.LogicalExpression.expressions:type_name -> banyandb.model.v1.Expression
+	6,  // 7: banyandb.model.v1.Expression.criteria:type_name -> banyandb.model.v1.Criteria
+	7,  // 8: banyandb.model.v1.Expression.logical:type_name -> banyandb.model.v1.LogicalExpression
+	0,  // 9: banyandb.model.v1.QueryOrder.sort:type_name -> banyandb.model.v1.Sort
+	12, // 10: banyandb.model.v1.TagProjection.tag_families:type_name -> banyandb.model.v1.TagProjection.TagFamily
+	14, // 11: banyandb.model.v1.TimeRange.begin:type_name -> google.protobuf.Timestamp
+	14, // 12: banyandb.model.v1.TimeRange.end:type_name -> google.protobuf.Timestamp
+	13, // [13:13] is the sub-list for method output_type
+	13, // [13:13] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
 }
 
 func init() { file_banyandb_model_v1_query_proto_init() }
@@ -805,7 +1046,7 @@ func file_banyandb_model_v1_query_proto_init() {
 			}
 		}
 		file_banyandb_model_v1_query_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*QueryOrder); i {
+			switch v := v.(*LogicalExpression); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -817,7 +1058,7 @@ func file_banyandb_model_v1_query_proto_init() {
 			}
 		}
 		file_banyandb_model_v1_query_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TagProjection); i {
+			switch v := v.(*Expression); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -829,7 +1070,7 @@ func file_banyandb_model_v1_query_proto_init() {
 			}
 		}
 		file_banyandb_model_v1_query_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TimeRange); i {
+			switch v := v.(*QueryOrder); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -841,6 +1082,30 @@ func file_banyandb_model_v1_query_proto_init() {
 			}
 		}
 		file_banyandb_model_v1_query_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TagProjection); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_banyandb_model_v1_query_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TimeRange); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_banyandb_model_v1_query_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*TagProjection_TagFamily); i {
 			case 0:
 				return &v.state
@@ -853,13 +1118,17 @@ func file_banyandb_model_v1_query_proto_init() {
 			}
 		}
 	}
+	file_banyandb_model_v1_query_proto_msgTypes[5].OneofWrappers = []interface{}{
+		(*Expression_Criteria)(nil),
+		(*Expression_Logical)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_banyandb_model_v1_query_proto_rawDesc,
-			NumEnums:      2,
-			NumMessages:   8,
+			NumEnums:      3,
+			NumMessages:   10,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -21,6 +21,15 @@ const _ = grpc.SupportPackageIsVersion7
 type StreamServiceClient interface {
 	Query(ctx context.Context, in *QueryRequest, opts ...grpc.CallOption) (*QueryResponse, error)
 	Write(ctx context.Context, opts ...grpc.CallOption) (StreamService_WriteClient, error)
+	// Maintenance is an admin RPC which returns once the operation completes
+	Maintenance(ctx context.Context, in *MaintenanceRequest, opts ...grpc.CallOption) (*MaintenanceResponse, error)
+	// StorageStats is an admin RPC which returns the disk usage, the segments and the blocks of each shard of a stream
+	StorageStats(ctx context.Context, in *StorageStatsRequest, opts ...grpc.CallOption) (*StorageStatsResponse, error)
+	// SetWritePaused is an admin RPC which pauses or resumes the writes of a stream
+	SetWritePaused(ctx context.Context, in *SetWritePausedRequest, opts ...grpc.CallOption) (*SetWritePausedResponse, error)
+	GetWriteState(ctx context.Context, in *GetWriteStateRequest, opts ...grpc.CallOption) (*GetWriteStateResponse, error)
+	// ListTopology is an admin RPC which returns the shards and the series assignments known by the liaison
+	ListTopology(ctx context.Context, in *ListTopologyRequest, opts ...grpc.CallOption) (*ListTopologyResponse, error)
 }
 
 type streamServiceClient struct {
@@ -71,12 +80,66 @@ func (x *streamServiceWriteClient) Recv() (*WriteResponse, error) {
 	return m, nil
 }
 
+func (c *streamServiceClient) Maintenance(ctx context.Context, in *MaintenanceRequest, opts ...grpc.CallOption) (*MaintenanceResponse, error) {
+	out := new(MaintenanceResponse)
+	err := c.cc.Invoke(ctx, "/banyandb.stream.v1.StreamService/Maintenance", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *streamServiceClient) StorageStats(ctx context.Context, in *StorageStatsRequest, opts ...grpc.CallOption) (*StorageStatsResponse, error) {
+	out := new(StorageStatsResponse)
+	err := c.cc.Invoke(ctx, "/banyandb.stream.v1.StreamService/StorageStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *streamServiceClient) SetWritePaused(ctx context.Context, in *SetWritePausedRequest, opts ...grpc.CallOption) (*SetWritePausedResponse, error) {
+	out := new(SetWritePausedResponse)
+	err := c.cc.Invoke(ctx, "/banyandb.stream.v1.StreamService/SetWritePaused", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *streamServiceClient) GetWriteState(ctx context.Context, in *GetWriteStateRequest, opts ...grpc.CallOption) (*GetWriteStateResponse, error) {
+	out := new(GetWriteStateResponse)
+	err := c.cc.Invoke(ctx, "/banyandb.stream.v1.StreamService/GetWriteState", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *streamServiceClient) ListTopology(ctx context.Context, in *ListTopologyRequest, opts ...grpc.CallOption) (*ListTopologyResponse, error) {
+	out := new(ListTopologyResponse)
+	err := c.cc.Invoke(ctx, "/banyandb.stream.v1.StreamService/ListTopology", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // StreamServiceServer is the server API for StreamService service.
 // All implementations must embed UnimplementedStreamServiceServer
 // for forward compatibility
 type StreamServiceServer interface {
 	Query(context.Context, *QueryRequest) (*QueryResponse, error)
 	Write(StreamService_WriteServer) error
+	// Maintenance is an admin RPC which returns once the operation completes
+	Maintenance(context.Context, *MaintenanceRequest) (*MaintenanceResponse, error)
+	// StorageStats is an admin RPC which returns the disk usage, the segments and the blocks of each shard of a stream
+	StorageStats(context.Context, *StorageStatsRequest) (*StorageStatsResponse, error)
+	// SetWritePaused is an admin RPC which pauses or resumes the writes of a stream
+	SetWritePaused(context.Context, *SetWritePausedRequest) (*SetWritePausedResponse, error)
+	GetWriteState(context.Context, *GetWriteStateRequest) (*GetWriteStateResponse, error)
+	// ListTopology is an admin RPC which returns the shards and the series assignments known by the liaison
+	ListTopology(context.Context, *ListTopologyRequest) (*ListTopologyResponse, error)
 	mustEmbedUnimplementedStreamServiceServer()
 }
 
@@ -90,6 +153,21 @@ func (UnimplementedStreamServiceServer) Query(context.Context, *QueryRequest) (*
 func (UnimplementedStreamServiceServer) Write(StreamService_WriteServer) error {
 	return status.Errorf(codes.Unimplemented, "method Write not implemented")
 }
+func (UnimplementedStreamServiceServer) Maintenance(context.Context, *MaintenanceRequest) (*MaintenanceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Maintenance not implemented")
+}
+func (UnimplementedStreamServiceServer) StorageStats(context.Context, *StorageStatsRequest) (*StorageStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StorageStats not implemented")
+}
+func (UnimplementedStreamServiceServer) SetWritePaused(context.Context, *SetWritePausedRequest) (*SetWritePausedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetWritePaused not implemented")
+}
+func (UnimplementedStreamServiceServer) GetWriteState(context.Context, *GetWriteStateRequest) (*GetWriteStateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWriteState not implemented")
+}
+func (UnimplementedStreamServiceServer) ListTopology(context.Context, *ListTopologyRequest) (*ListTopologyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTopology not implemented")
+}
 func (UnimplementedStreamServiceServer) mustEmbedUnimplementedStreamServiceServer() {}
 
 // UnsafeStreamServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -147,6 +225,96 @@ func (x *streamServiceWriteServer) Recv() (*WriteRequest, error) {
 	return m, nil
 }
 
+func _StreamService_Maintenance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MaintenanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StreamServiceServer).Maintenance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/banyandb.stream.v1.StreamService/Maintenance",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StreamServiceServer).Maintenance(ctx, req.(*MaintenanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StreamService_StorageStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StorageStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StreamServiceServer).StorageStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/banyandb.stream.v1.StreamService/StorageStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StreamServiceServer).StorageStats(ctx, req.(*StorageStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StreamService_SetWritePaused_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetWritePausedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StreamServiceServer).SetWritePaused(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/banyandb.stream.v1.StreamService/SetWritePaused",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StreamServiceServer).SetWritePaused(ctx, req.(*SetWritePausedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StreamService_GetWriteState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWriteStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StreamServiceServer).GetWriteState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/banyandb.stream.v1.StreamService/GetWriteState",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StreamServiceServer).GetWriteState(ctx, req.(*GetWriteStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StreamService_ListTopology_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTopologyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StreamServiceServer).ListTopology(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/banyandb.stream.v1.StreamService/ListTopology",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StreamServiceServer).ListTopology(ctx, req.(*ListTopologyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // StreamService_ServiceDesc is the grpc.ServiceDesc for StreamService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -158,6 +326,26 @@ var StreamService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Query",
 			Handler:    _StreamService_Query_Handler,
 		},
+		{
+			MethodName: "Maintenance",
+			Handler:    _StreamService_Maintenance_Handler,
+		},
+		{
+			MethodName: "StorageStats",
+			Handler:    _StreamService_StorageStats_Handler,
+		},
+		{
+			MethodName: "SetWritePaused",
+			Handler:    _StreamService_SetWritePaused_Handler,
+		},
+		{
+			MethodName: "GetWriteState",
+			Handler:    _StreamService_GetWriteState_Handler,
+		},
+		{
+			MethodName: "ListTopology",
+			Handler:    _StreamService_ListTopology_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
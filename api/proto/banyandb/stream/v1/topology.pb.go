@@ -0,0 +1,472 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.27.1
+// 	protoc        v3.18.1
+// source: banyandb/stream/v1/topology.proto
+
+package v1
+
+import (
+	reflect "reflect"
+	sync "sync"
+
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+
+	v1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/common/v1"
+	v11 "github.com/apache/skywalking-banyandb/api/proto/banyandb/database/v1"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// SubjectTopology is how the liaison shards the writes of a stream, which is learned from the shard and entity events
+type SubjectTopology struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// metadata is the identity of the stream
+	Metadata *v1.Metadata `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	// shard_num is the total of the shards in the latest shard event, and zero if none is received
+	ShardNum uint32 `protobuf:"varint,2,opt,name=shard_num,json=shardNum,proto3" json:"shard_num,omitempty"`
+	// shard_ids are the shards announced by the shard events in order
+	ShardIds []uint64 `protobuf:"varint,3,rep,packed,name=shard_ids,json=shardIds,proto3" json:"shard_ids,omitempty"`
+	// entity_tag_num is the number of the tags composing the entity, and zero if no entity event is received
+	EntityTagNum uint32 `protobuf:"varint,4,opt,name=entity_tag_num,json=entityTagNum,proto3" json:"entity_tag_num,omitempty"`
+	// sharding_algorithm hashes the series without any override to their shards
+	ShardingAlgorithm v11.ShardingAlgorithm `protobuf:"varint,5,opt,name=sharding_algorithm,json=shardingAlgorithm,proto3,enum=banyandb.database.v1.ShardingAlgorithm" json:"sharding_algorithm,omitempty"`
+	// shard_overrides are the series assigned to explicit shards
+	ShardOverrides []*v11.ShardOverride `protobuf:"bytes,6,rep,name=shard_overrides,json=shardOverrides,proto3" json:"shard_overrides,omitempty"`
+	// updated_at is when the latest event of the stream is received
+	UpdatedAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+}
+
+func (x *SubjectTopology) Reset() {
+	*x = SubjectTopology{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_banyandb_stream_v1_topology_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubjectTopology) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubjectTopology) ProtoMessage() {}
+
+func (x *SubjectTopology) ProtoReflect() protoreflect.Message {
+	mi := &file_banyandb_stream_v1_topology_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubjectTopology.ProtoReflect.Descriptor instead.
+func (*SubjectTopology) Descriptor() ([]byte, []int) {
+	return file_banyandb_stream_v1_topology_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SubjectTopology) GetMetadata() *v1.Metadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *SubjectTopology) GetShardNum() uint32 {
+	if x != nil {
+		return x.ShardNum
+	}
+	return 0
+}
+
+func (x *SubjectTopology) GetShardIds() []uint64 {
+	if x != nil {
+		return x.ShardIds
+	}
+	return nil
+}
+
+func (x *SubjectTopology) GetEntityTagNum() uint32 {
+	if x != nil {
+		return x.EntityTagNum
+	}
+	return 0
+}
+
+func (x *SubjectTopology) GetShardingAlgorithm() v11.ShardingAlgorithm {
+	if x != nil {
+		return x.ShardingAlgorithm
+	}
+	return v11.ShardingAlgorithm(0)
+}
+
+func (x *SubjectTopology) GetShardOverrides() []*v11.ShardOverride {
+	if x != nil {
+		return x.ShardOverrides
+	}
+	return nil
+}
+
+func (x *SubjectTopology) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type GroupTopology struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Group string `protobuf:"bytes,1,opt,name=group,proto3" json:"group,omitempty"`
+	// subjects are ordered by their names
+	Subjects []*SubjectTopology `protobuf:"bytes,2,rep,name=subjects,proto3" json:"subjects,omitempty"`
+}
+
+func (x *GroupTopology) Reset() {
+	*x = GroupTopology{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_banyandb_stream_v1_topology_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GroupTopology) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupTopology) ProtoMessage() {}
+
+func (x *GroupTopology) ProtoReflect() protoreflect.Message {
+	mi := &file_banyandb_stream_v1_topology_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupTopology.ProtoReflect.Descriptor instead.
+func (*GroupTopology) Descriptor() ([]byte, []int) {
+	return file_banyandb_stream_v1_topology_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GroupTopology) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
+func (x *GroupTopology) GetSubjects() []*SubjectTopology {
+	if x != nil {
+		return x.Subjects
+	}
+	return nil
+}
+
+type ListTopologyRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// group limits the topology to a group, and the empty one lists all of them
+	Group string `protobuf:"bytes,1,opt,name=group,proto3" json:"group,omitempty"`
+}
+
+func (x *ListTopologyRequest) Reset() {
+	*x = ListTopologyRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_banyandb_stream_v1_topology_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListTopologyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTopologyRequest) ProtoMessage() {}
+
+func (x *ListTopologyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_banyandb_stream_v1_topology_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTopologyRequest.ProtoReflect.Descriptor instead.
+func (*ListTopologyRequest) Descriptor() ([]byte, []int) {
+	return file_banyandb_stream_v1_topology_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListTopologyRequest) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
+type ListTopologyResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// groups are ordered by their names
+	Groups []*GroupTopology `protobuf:"bytes,1,rep,name=groups,proto3" json:"groups,omitempty"`
+}
+
+func (x *ListTopologyResponse) Reset() {
+	*x = ListTopologyResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_banyandb_stream_v1_topology_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListTopologyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTopologyResponse) ProtoMessage() {}
+
+func (x *ListTopologyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_banyandb_stream_v1_topology_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTopologyResponse.ProtoReflect.Descriptor instead.
+func (*ListTopologyResponse) Descriptor() ([]byte, []int) {
+	return file_banyandb_stream_v1_topology_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListTopologyResponse) GetGroups() []*GroupTopology {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+var File_banyandb_stream_v1_topology_proto protoreflect.FileDescriptor
+
+var file_banyandb_stream_v1_topology_proto_rawDesc = []byte{
+	0x0a, 0x21, 0x62, 0x61, 0x6e, 0x79, 0x61, 0x6e, 0x64, 0x62, 0x2f, 0x73, 0x74, 0x72, 0x65, 0x61,
+	0x6d, 0x2f, 0x76, 0x31, 0x2f, 0x74, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x12, 0x12, 0x62, 0x61, 0x6e, 0x79, 0x61, 0x6e, 0x64, 0x62, 0x2e, 0x73, 0x74,
+	0x72, 0x65, 0x61, 0x6d, 0x2e, 0x76, 0x31, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x62, 0x61, 0x6e, 0x79, 0x61, 0x6e,
+	0x64, 0x62, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2f, 0x76, 0x31, 0x2f, 0x63, 0x6f, 0x6d,
+	0x6d, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x21, 0x62, 0x61, 0x6e, 0x79, 0x61,
+	0x6e, 0x64, 0x62, 0x2f, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x2f, 0x76, 0x31, 0x2f,
+	0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x8c, 0x03, 0x0a,
+	0x0f, 0x53, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x54, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79,
+	0x12, 0x38, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x62, 0x61, 0x6e, 0x79, 0x61, 0x6e, 0x64, 0x62, 0x2e, 0x63, 0x6f,
+	0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
+	0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x68,
+	0x61, 0x72, 0x64, 0x5f, 0x6e, 0x75, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x73,
+	0x68, 0x61, 0x72, 0x64, 0x4e, 0x75, 0x6d, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x68, 0x61, 0x72, 0x64,
+	0x5f, 0x69, 0x64, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x04, 0x52, 0x08, 0x73, 0x68, 0x61, 0x72,
+	0x64, 0x49, 0x64, 0x73, 0x12, 0x24, 0x0a, 0x0e, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x5f, 0x74,
+	0x61, 0x67, 0x5f, 0x6e, 0x75, 0x6d, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x65, 0x6e,
+	0x74, 0x69, 0x74, 0x79, 0x54, 0x61, 0x67, 0x4e, 0x75, 0x6d, 0x12, 0x56, 0x0a, 0x12, 0x73, 0x68,
+	0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x5f, 0x61, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x27, 0x2e, 0x62, 0x61, 0x6e, 0x79, 0x61, 0x6e, 0x64,
+	0x62, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x68,
+	0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x41, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x52,
+	0x11, 0x73, 0x68, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x41, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74,
+	0x68, 0x6d, 0x12, 0x4c, 0x0a, 0x0f, 0x73, 0x68, 0x61, 0x72, 0x64, 0x5f, 0x6f, 0x76, 0x65, 0x72,
+	0x72, 0x69, 0x64, 0x65, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x62, 0x61,
+	0x6e, 0x79, 0x61, 0x6e, 0x64, 0x62, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x2e,
+	0x76, 0x31, 0x2e, 0x53, 0x68, 0x61, 0x72, 0x64, 0x4f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65,
+	0x52, 0x0e, 0x73, 0x68, 0x61, 0x72, 0x64, 0x4f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x73,
+	0x12, 0x39, 0x0a, 0x0a, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x52, 0x09, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0x66, 0x0a, 0x0d, 0x47,
+	0x72, 0x6f, 0x75, 0x70, 0x54, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x12, 0x14, 0x0a, 0x05,
+	0x67, 0x72, 0x6f, 0x75, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x67, 0x72, 0x6f,
+	0x75, 0x70, 0x12, 0x3f, 0x0a, 0x08, 0x73, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x62, 0x61, 0x6e, 0x79, 0x61, 0x6e, 0x64, 0x62, 0x2e,
+	0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x75, 0x62, 0x6a, 0x65, 0x63,
+	0x74, 0x54, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x52, 0x08, 0x73, 0x75, 0x62, 0x6a, 0x65,
+	0x63, 0x74, 0x73, 0x22, 0x2b, 0x0a, 0x13, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x6f, 0x70, 0x6f, 0x6c,
+	0x6f, 0x67, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x67, 0x72,
+	0x6f, 0x75, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70,
+	0x22, 0x51, 0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x39, 0x0a, 0x06, 0x67, 0x72, 0x6f, 0x75,
+	0x70, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x62, 0x61, 0x6e, 0x79, 0x61,
+	0x6e, 0x64, 0x62, 0x2e, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x72,
+	0x6f, 0x75, 0x70, 0x54, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x52, 0x06, 0x67, 0x72, 0x6f,
+	0x75, 0x70, 0x73, 0x42, 0x6e, 0x0a, 0x28, 0x6f, 0x72, 0x67, 0x2e, 0x61, 0x70, 0x61, 0x63, 0x68,
+	0x65, 0x2e, 0x73, 0x6b, 0x79, 0x77, 0x61, 0x6c, 0x6b, 0x69, 0x6e, 0x67, 0x2e, 0x62, 0x61, 0x6e,
+	0x79, 0x61, 0x6e, 0x64, 0x62, 0x2e, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x2e, 0x76, 0x31, 0x5a,
+	0x42, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x70, 0x61, 0x63,
+	0x68, 0x65, 0x2f, 0x73, 0x6b, 0x79, 0x77, 0x61, 0x6c, 0x6b, 0x69, 0x6e, 0x67, 0x2d, 0x62, 0x61,
+	0x6e, 0x79, 0x61, 0x6e, 0x64, 0x62, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2f, 0x62, 0x61, 0x6e, 0x79, 0x61, 0x6e, 0x64, 0x62, 0x2f, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d,
+	0x2f, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_banyandb_stream_v1_topology_proto_rawDescOnce sync.Once
+	file_banyandb_stream_v1_topology_proto_rawDescData = file_banyandb_stream_v1_topology_proto_rawDesc
+)
+
+func file_banyandb_stream_v1_topology_proto_rawDescGZIP() []byte {
+	file_banyandb_stream_v1_topology_proto_rawDescOnce.Do(func() {
+		file_banyandb_stream_v1_topology_proto_rawDescData = protoimpl.X.CompressGZIP(file_banyandb_stream_v1_topology_proto_rawDescData)
+	})
+	return file_banyandb_stream_v1_topology_proto_rawDescData
+}
+
+var file_banyandb_stream_v1_topology_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_banyandb_stream_v1_topology_proto_goTypes = []interface{}{
+	(*SubjectTopology)(nil),       // 0: banyandb.stream.v1.SubjectTopology
+	(*GroupTopology)(nil),         // 1: banyandb.stream.v1.GroupTopology
+	(*ListTopologyRequest)(nil),   // 2: banyandb.stream.v1.ListTopologyRequest
+	(*ListTopologyResponse)(nil),  // 3: banyandb.stream.v1.ListTopologyResponse
+	(*v1.Metadata)(nil),           // 4: banyandb.common.v1.Metadata
+	(v11.ShardingAlgorithm)(0),    // 5: banyandb.database.v1.ShardingAlgorithm
+	(*v11.ShardOverride)(nil),     // 6: banyandb.database.v1.ShardOverride
+	(*timestamppb.Timestamp)(nil), // 7: google.protobuf.Timestamp
+}
+var file_banyandb_stream_v1_topology_proto_depIdxs = []int32{
+	4, // 0: banyandb.stream.v1.SubjectTopology.metadata:type_name -> banyandb.common.v1.Metadata
+	5, // 1: banyandb.stream.v1.SubjectTopology.sharding_algorithm:type_name -> banyandb.database.v1.ShardingAlgorithm
+	6, // 2: banyandb.stream.v1.SubjectTopology.shard_overrides:type_name -> banyandb.database.v1.ShardOverride
+	7, // 3: banyandb.stream.v1.SubjectTopology.updated_at:type_name -> google.protobuf.Timestamp
+	0, // 4: banyandb.stream.v1.GroupTopology.subjects:type_name -> banyandb.stream.v1.SubjectTopology
+	1, // 5: banyandb.stream.v1.ListTopologyResponse.groups:type_name -> banyandb.stream.v1.GroupTopology
+	6, // [6:6] is the sub-list for method output_type
+	6, // [6:6] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_banyandb_stream_v1_topology_proto_init() }
+func file_banyandb_stream_v1_topology_proto_init() {
+	if File_banyandb_stream_v1_topology_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_banyandb_stream_v1_topology_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubjectTopology); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_banyandb_stream_v1_topology_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GroupTopology); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_banyandb_stream_v1_topology_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListTopologyRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_banyandb_stream_v1_topology_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListTopologyResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_banyandb_stream_v1_topology_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_banyandb_stream_v1_topology_proto_goTypes,
+		DependencyIndexes: file_banyandb_stream_v1_topology_proto_depIdxs,
+		MessageInfos:      file_banyandb_stream_v1_topology_proto_msgTypes,
+	}.Build()
+	File_banyandb_stream_v1_topology_proto = out.File
+	file_banyandb_stream_v1_topology_proto_rawDesc = nil
+	file_banyandb_stream_v1_topology_proto_goTypes = nil
+	file_banyandb_stream_v1_topology_proto_depIdxs = nil
+}
@@ -36,6 +36,7 @@ var (
 	_              IndexStore      = (*badgerDB)(nil)
 	_              y.Iterator      = (*mergedIter)(nil)
 	_              TimeSeriesStore = (*badgerTSS)(nil)
+	bitDelete      byte            = 1 << 0
 	bitMergeEntry  byte            = 1 << 3
 	ErrKeyNotFound                 = badger.ErrKeyNotFound
 )
@@ -54,6 +55,74 @@ func (b *badgerTSS) Close() error {
 	return nil
 }
 
+func (b *badgerTSS) Sync() error {
+	return b.db.Sync()
+}
+
+func (b *badgerTSS) Compact() error {
+	return b.db.Flatten(1)
+}
+
+// copyTo puts the values of all the keys and timestamps into dst, except the ones drop returns true for.
+// All the values have to be in the level files, where they're encoded.
+func (b *badgerTSS) copyTo(dst TimeSeriesStore, drop func(key []byte, ts uint64) bool) (int, error) {
+	it := b.db.NewIterator(badger.DefaultIteratorOptions)
+	defer func() {
+		_ = it.Close()
+	}()
+	var dropped int
+	var lastKey []byte
+	seen := make(map[uint64]struct{})
+	for it.Rewind(); it.Valid(); it.Next() {
+		key := y.ParseKey(it.Key())
+		if !bytes.Equal(key, lastKey) {
+			lastKey = y.Copy(key)
+			seen = make(map[uint64]struct{})
+		}
+		points, err := b.decode(lastKey, it.Value().Value)
+		if err != nil {
+			return dropped, err
+		}
+		for _, p := range points {
+			if _, ok := seen[p.ts]; ok {
+				continue
+			}
+			seen[p.ts] = struct{}{}
+			if drop(lastKey, p.ts) {
+				dropped++
+				continue
+			}
+			// Get picks the latest one among the values written at the same timestamp
+			if val, errGet := b.Get(lastKey, p.ts); errGet == nil && val != nil {
+				p.val = y.Copy(val)
+			}
+			if err = dst.Put(lastKey, p.val, p.ts); err != nil {
+				return dropped, err
+			}
+		}
+	}
+	return dropped, nil
+}
+
+type point struct {
+	ts  uint64
+	val []byte
+}
+
+func (b *badgerTSS) decode(key, data []byte) ([]point, error) {
+	decoder := b.dbOpts.DecoderPool.Get(key)
+	defer b.dbOpts.DecoderPool.Put(decoder)
+	if err := decoder.Decode(key, data); err != nil {
+		return nil, err
+	}
+	points := make([]point, 0, decoder.Len())
+	iter := decoder.Iterator()
+	for iter.Next() {
+		points = append(points, point{ts: iter.Time(), val: y.Copy(iter.Val())})
+	}
+	return points, iter.Error()
+}
+
 type mergedIter struct {
 	delegated Iterator
 	valid     bool
@@ -126,6 +195,9 @@ func (b *badgerDB) Scan(key []byte, opt ScanOpts, f ScanFunc) error {
 		_ = it.Close()
 	}()
 	for it.Seek(y.KeyWithTs(key, math.MaxInt64)); it.Valid(); it.Next() {
+		if it.Value().Meta&bitDelete > 0 {
+			continue
+		}
 		k := y.ParseKey(it.Key())
 		err := f(b.shardID, k, func() ([]byte, error) {
 			return y.Copy(it.Value().Value), nil
@@ -207,6 +279,65 @@ func (b *badgerDB) PutWithVersion(key, val []byte, version uint64) error {
 	return b.db.Put(y.KeyWithTs(key, version), val)
 }
 
+func (b *badgerDB) Delete(keys ...[]byte) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	wb := b.db.NewWriteBatch()
+	defer wb.Cancel()
+	for _, key := range keys {
+		// the delete marker shadows the value put at the same version, and the compaction drops both
+		if err := wb.DeleteAt(key, math.MaxInt64); err != nil {
+			return err
+		}
+	}
+	return wb.Flush()
+}
+
+// copyTo puts all the versions of the keys into dst, except the deleted ones
+func (b *badgerDB) copyTo(dst *badgerDB) error {
+	it := b.db.NewIterator(badger.DefaultIteratorOptions)
+	defer func() {
+		_ = it.Close()
+	}()
+	for it.Rewind(); it.Valid(); it.Next() {
+		if it.Value().Meta&bitDelete > 0 {
+			continue
+		}
+		if err := dst.db.Put(y.Copy(it.Key()), y.Copy(it.Value().Value)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rewriteValues puts the new values at the versions of the replaced ones, which shadow the latter
+func (b *badgerDB) rewriteValues(rewrite func(key, val []byte) ([]byte, bool)) (int, error) {
+	type entry struct {
+		key []byte
+		val []byte
+	}
+	var entries []entry
+	it := b.db.NewIterator(badger.DefaultIteratorOptions)
+	for it.Rewind(); it.Valid(); it.Next() {
+		if it.Value().Meta&bitDelete > 0 {
+			continue
+		}
+		if val, ok := rewrite(y.ParseKey(it.Key()), it.Value().Value); ok {
+			entries = append(entries, entry{key: y.Copy(it.Key()), val: val})
+		}
+	}
+	if err := it.Close(); err != nil {
+		return 0, err
+	}
+	for i, e := range entries {
+		if err := b.db.Put(e.key, e.val); err != nil {
+			return i, err
+		}
+	}
+	return len(entries), nil
+}
+
 func (b *badgerDB) Get(key []byte) ([]byte, error) {
 	v, err := b.db.Get(y.KeyWithTs(key, math.MaxInt64))
 	if err == badger.ErrKeyNotFound {
@@ -225,6 +356,9 @@ func (b *badgerDB) GetAll(key []byte, applyFn func([]byte) error) error {
 		if !bytes.Equal(y.ParseKey(iter.Key()), key) {
 			break
 		}
+		if iter.Value().Meta&bitDelete > 0 {
+			continue
+		}
 		count++
 		err := applyFn(y.Copy(iter.Value().Value))
 		if err != nil {
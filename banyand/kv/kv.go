@@ -21,9 +21,11 @@ import (
 	"fmt"
 	"io"
 	"math"
+	"path/filepath"
 
 	"github.com/dgraph-io/badger/v3"
 	"github.com/pkg/errors"
+	"go.uber.org/multierr"
 
 	"github.com/apache/skywalking-banyandb/pkg/encoding"
 	"github.com/apache/skywalking-banyandb/pkg/logger"
@@ -41,6 +43,8 @@ type Writer interface {
 	// Put a value
 	Put(key, val []byte) error
 	PutWithVersion(key, val []byte, version uint64) error
+	// Delete removes the keys, whose values are dropped from the disk by the compaction
+	Delete(keys ...[]byte) error
 }
 
 type ScanFunc func(shardID int, key []byte, getVal func() ([]byte, error)) error
@@ -73,6 +77,8 @@ type TimeSeriesWriter interface {
 	// PutAsync a value with a timestamp/version asynchronously.
 	// Injected "f" func will notice the result of value write.
 	PutAsync(key, val []byte, ts uint64, f func(error)) error
+	// Sync flushes the written values and the write-ahead log to the disk
+	Sync() error
 }
 
 type TimeSeriesReader interface {
@@ -87,6 +93,8 @@ type TimeSeriesStore interface {
 	io.Closer
 	TimeSeriesWriter
 	TimeSeriesReader
+	// Compact merges all the levels of the underlying LSM tree into one
+	Compact() error
 }
 
 type TimeSeriesOptions func(TimeSeriesStore)
@@ -146,17 +154,94 @@ func OpenTimeSeriesStore(shardID int, path string, options ...TimeSeriesOptions)
 	for _, opt := range options {
 		opt(btss)
 	}
-	// Put all values into LSM
-	btss.dbOpts = btss.dbOpts.WithVLogPercentile(1.0)
+	// Put all values into LSM.
+	// The flushes started by badger.Open keep all the versions and encode them with the pools of the options,
+	// which are passed to NewTSet again since it resets them.
+	btss.dbOpts = btss.dbOpts.WithVLogPercentile(1.0).WithNumVersionsToKeep(math.MaxInt64)
+	// NewTSet sets up the db without any lock, so no flush should run until it returns
+	if err := flushMemTables(btss.dbOpts); err != nil {
+		return nil, fmt.Errorf("failed to replay time series store: %v", err)
+	}
 	var err error
 	btss.db, err = badger.Open(btss.dbOpts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open time series store: %v", err)
 	}
-	btss.TSet = *badger.NewTSet(btss.db)
+	btss.TSet = *badger.NewTSet(btss.db,
+		badger.WithEncoderPool(btss.dbOpts.EncoderPool),
+		badger.WithDecoderPool(btss.dbOpts.DecoderPool))
 	return btss, nil
 }
 
+// flushMemTables flushes the memtables left by an unclean close, which are replayed from the WAL and flushed
+// in the background once the store is opened. A store closed cleanly has no memtable file.
+func flushMemTables(opts badger.Options) error {
+	memTables, err := filepath.Glob(filepath.Join(opts.Dir, "*.mem"))
+	if err != nil || len(memTables) < 1 {
+		return err
+	}
+	db, err := badger.Open(opts)
+	if err != nil {
+		return err
+	}
+	// the close waits until the memtables are flushed
+	return db.Close()
+}
+
+// RewriteTimeSeriesStore copies the values of the closed store at srcPath to a new store at dstPath,
+// and skips the ones drop returns true for. It returns the number of the skipped values.
+func RewriteTimeSeriesStore(srcPath, dstPath string, drop func(key []byte, ts uint64) bool,
+	options ...TimeSeriesOptions) (n int, err error) {
+	dst, err := OpenTimeSeriesStore(0, dstPath, options...)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		err = multierr.Append(err, dst.Close())
+	}()
+	return copyTimeSeriesStore(srcPath, dst, drop, options...)
+}
+
+// MergeTimeSeriesStores copies the values of the closed stores at srcPaths to a new store at dstPath
+func MergeTimeSeriesStores(srcPaths []string, dstPath string, options ...TimeSeriesOptions) (err error) {
+	dst, err := OpenTimeSeriesStore(0, dstPath, options...)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = multierr.Append(err, dst.Close())
+	}()
+	keepAll := func(_ []byte, _ uint64) bool {
+		return false
+	}
+	for _, srcPath := range srcPaths {
+		if _, err = copyTimeSeriesStore(srcPath, dst, keepAll, options...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func copyTimeSeriesStore(srcPath string, dst TimeSeriesStore, drop func(key []byte, ts uint64) bool,
+	options ...TimeSeriesOptions) (n int, err error) {
+	// reopening the store flushes the values replayed from the write-ahead log into the level files,
+	// whose values are all encoded
+	src, err := OpenTimeSeriesStore(0, srcPath, options...)
+	if err != nil {
+		return 0, err
+	}
+	if err = src.Close(); err != nil {
+		return 0, err
+	}
+	if src, err = OpenTimeSeriesStore(0, srcPath, options...); err != nil {
+		return 0, err
+	}
+	defer func() {
+		err = multierr.Append(err, src.Close())
+	}()
+	return src.(*badgerTSS).copyTo(dst, drop)
+}
+
 type StoreOptions func(Store)
 
 // StoreWithLogger sets a external logger into underlying Store
@@ -193,6 +278,39 @@ func OpenStore(shardID int, path string, options ...StoreOptions) (Store, error)
 	return bdb, nil
 }
 
+// MergeStores copies all the versions of the keys in the closed stores at srcPaths to a new store at dstPath.
+// A key put at the same version by more than one of them keeps the value of the last one.
+func MergeStores(srcPaths []string, dstPath string, options ...StoreOptions) (err error) {
+	dst, err := OpenStore(0, dstPath, options...)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = multierr.Append(err, dst.Close())
+	}()
+	for _, srcPath := range srcPaths {
+		src, errOpen := OpenStore(0, srcPath, options...)
+		if errOpen != nil {
+			return errOpen
+		}
+		err = multierr.Append(src.(*badgerDB).copyTo(dst.(*badgerDB)), src.Close())
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RewriteValues replaces the values of all the versions of the keys in the store, which rewrite returns
+// the new ones for. It returns the number of the replaced values.
+func RewriteValues(store Store, rewrite func(key, val []byte) ([]byte, bool)) (int, error) {
+	bdb, ok := store.(*badgerDB)
+	if !ok {
+		return 0, errors.Errorf("the store %T doesn't support rewriting its values", store)
+	}
+	return bdb.rewriteValues(rewrite)
+}
+
 type IndexOptions func(store IndexStore)
 
 // IndexWithLogger sets a external logger into underlying IndexStore
@@ -223,3 +341,20 @@ func OpenIndexStore(shardID int, path string, options ...IndexOptions) (IndexSto
 	}
 	return bdb, nil
 }
+
+// VerifyChecksum opens the store at path in the read-only mode and verifies the checksums of all its tables
+func VerifyChecksum(path string, l *logger.Logger) (err error) {
+	opts := badger.DefaultOptions(path).
+		WithReadOnly(true).
+		WithLogger(&badgerLog{
+			delegated: l.Named("verify-kv"),
+		})
+	db, err := badger.Open(opts)
+	if err != nil {
+		return fmt.Errorf("failed to open store: %v", err)
+	}
+	defer func() {
+		err = multierr.Append(err, db.Close())
+	}()
+	return db.VerifyChecksum()
+}
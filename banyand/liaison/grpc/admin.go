@@ -0,0 +1,124 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package grpc
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	grpcmetadata "google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/apache/skywalking-banyandb/api/data"
+	streamv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/stream/v1"
+	"github.com/apache/skywalking-banyandb/pkg/bus"
+)
+
+const (
+	// adminAuthHeader carries the admin token as "Bearer <token>"
+	adminAuthHeader           = "authorization"
+	defaultMaintenanceTimeout = 30 * time.Second
+)
+
+// authorizeAdmin guards the admin RPCs, which are disabled if no admin token is configured
+func (s *Server) authorizeAdmin(ctx context.Context) error {
+	if s.adminToken == "" {
+		return status.Error(codes.PermissionDenied, "the admin RPCs are disabled without admin-token")
+	}
+	md, _ := grpcmetadata.FromIncomingContext(ctx)
+	expected := []byte("Bearer " + s.adminToken)
+	for _, v := range md.Get(adminAuthHeader) {
+		if subtle.ConstantTimeCompare([]byte(v), expected) == 1 {
+			return nil
+		}
+	}
+	return status.Error(codes.Unauthenticated, "invalid admin token")
+}
+
+// Maintenance returns once the storage completes the operation.
+// The deadline of the request, or maintenance-timeout if it has none, bounds the waiting.
+func (s *Server) Maintenance(ctx context.Context, req *streamv1.MaintenanceRequest) (*streamv1.MaintenanceResponse, error) {
+	if err := s.authorizeAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if req.GetOp() == streamv1.MaintenanceOp_MAINTENANCE_OP_UNSPECIFIED {
+		return nil, status.Error(codes.InvalidArgument, "op is absent")
+	}
+	msg, err := s.awaitAdmin(ctx, req, fmt.Sprintf("%s of %s/%s", req.GetOp(),
+		req.GetMetadata().GetGroup(), req.GetMetadata().GetName()))
+	if err != nil {
+		return nil, err
+	}
+	if errMaintain, ok := msg.Data().(error); ok {
+		return nil, errMaintain
+	}
+	return &streamv1.MaintenanceResponse{}, nil
+}
+
+// StorageStats returns the footprint of each shard of the stream, which the storage walks on the disk.
+// It's bounded like Maintenance.
+func (s *Server) StorageStats(ctx context.Context, req *streamv1.StorageStatsRequest) (*streamv1.StorageStatsResponse, error) {
+	if err := s.authorizeAdmin(ctx); err != nil {
+		return nil, err
+	}
+	msg, err := s.awaitAdmin(ctx, req, fmt.Sprintf("the storage stats of %s/%s",
+		req.GetMetadata().GetGroup(), req.GetMetadata().GetName()))
+	if err != nil {
+		return nil, err
+	}
+	switch d := msg.Data().(type) {
+	case error:
+		return nil, d
+	case *streamv1.StorageStatsResponse:
+		return d, nil
+	}
+	return nil, status.Errorf(codes.Internal, "unexpected reply %T", msg.Data())
+}
+
+// awaitAdmin publishes the admin request to the storage and waits for its reply.
+// The deadline of the request, or maintenance-timeout if it has none, bounds the waiting.
+func (s *Server) awaitAdmin(ctx context.Context, req interface{}, desc string) (bus.Message, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.maintenanceTimeout)
+		defer cancel()
+	}
+	feat, err := s.pipeline.Publish(data.TopicStreamMaintenance, bus.NewMessage(bus.MessageID(time.Now().UnixNano()), req))
+	if err != nil {
+		return bus.Message{}, err
+	}
+	type result struct {
+		msg bus.Message
+		err error
+	}
+	// the future is always drained, otherwise the listener is blocked after the timeout
+	resultCh := make(chan result, 1)
+	go func() {
+		msg, errFeat := feat.Get()
+		resultCh <- result{msg: msg, err: errFeat}
+	}()
+	select {
+	case <-ctx.Done():
+		return bus.Message{}, status.Errorf(codes.DeadlineExceeded, "%s isn't completed: %v", desc, ctx.Err())
+	case r := <-resultCh:
+		return r.msg, r.err
+	}
+}
@@ -0,0 +1,239 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package grpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	grpcmetadata "google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/apache/skywalking-banyandb/api/data"
+	commonv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/common/v1"
+	modelv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/model/v1"
+	streamv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/stream/v1"
+	"github.com/apache/skywalking-banyandb/banyand/queue"
+	"github.com/apache/skywalking-banyandb/pkg/bus"
+	"github.com/apache/skywalking-banyandb/pkg/logger"
+	"github.com/apache/skywalking-banyandb/pkg/partition"
+)
+
+type fakeMaintainer struct {
+	releaseCh chan struct{}
+	ops       chan streamv1.MaintenanceOp
+}
+
+func (f *fakeMaintainer) Rev(message bus.Message) (resp bus.Message) {
+	req := message.Data().(*streamv1.MaintenanceRequest)
+	<-f.releaseCh
+	f.ops <- req.GetOp()
+	return bus.NewMessage(message.ID(), &streamv1.MaintenanceResponse{})
+}
+
+func TestMaintenance(t *testing.T) {
+	req := require.New(t)
+	req.NoError(logger.Init(logger.Logging{
+		Env:   "dev",
+		Level: "warn",
+	}))
+	pipeline, err := queue.NewQueue(context.TODO(), nil)
+	req.NoError(err)
+	maintainer := &fakeMaintainer{
+		releaseCh: make(chan struct{}),
+		ops:       make(chan streamv1.MaintenanceOp, 10),
+	}
+	req.NoError(pipeline.Subscribe(data.TopicStreamMaintenance, maintainer))
+
+	s := NewServer(context.TODO(), pipeline, nil, nil)
+	s.log = logger.GetLogger("test")
+	s.maintenanceTimeout = 100 * time.Millisecond
+	request := &streamv1.MaintenanceRequest{
+		Metadata: &commonv1.Metadata{
+			Name:  "sw",
+			Group: "default",
+		},
+		Op: streamv1.MaintenanceOp_MAINTENANCE_OP_FLUSH,
+	}
+	withToken := func(token string) context.Context {
+		return grpcmetadata.NewIncomingContext(context.Background(), grpcmetadata.Pairs(adminAuthHeader, "Bearer "+token))
+	}
+
+	_, err = s.Maintenance(withToken("secret"), request)
+	req.Equal(codes.PermissionDenied, status.Code(err))
+
+	s.adminToken = "secret"
+	_, err = s.Maintenance(context.Background(), request)
+	req.Equal(codes.Unauthenticated, status.Code(err))
+	_, err = s.Maintenance(withToken("guess"), request)
+	req.Equal(codes.Unauthenticated, status.Code(err))
+	_, err = s.Maintenance(withToken("secret"), &streamv1.MaintenanceRequest{Metadata: request.Metadata})
+	req.Equal(codes.InvalidArgument, status.Code(err))
+
+	// the maintainer is blocked, so the request times out
+	_, err = s.Maintenance(withToken("secret"), request)
+	req.Equal(codes.DeadlineExceeded, status.Code(err))
+	close(maintainer.releaseCh)
+	req.Equal(streamv1.MaintenanceOp_MAINTENANCE_OP_FLUSH, <-maintainer.ops)
+
+	resp, err := s.Maintenance(withToken("secret"), request)
+	req.NoError(err)
+	req.NotNil(resp)
+	req.Equal(streamv1.MaintenanceOp_MAINTENANCE_OP_FLUSH, <-maintainer.ops)
+}
+
+type fakeStatsReporter struct{}
+
+func (fakeStatsReporter) Rev(message bus.Message) (resp bus.Message) {
+	if _, ok := message.Data().(*streamv1.StorageStatsRequest); !ok {
+		return bus.NewMessage(message.ID(), errors.New("unexpected request"))
+	}
+	return bus.NewMessage(message.ID(), &streamv1.StorageStatsResponse{
+		Shards: []*streamv1.ShardStorageStats{{Id: 0, Bytes: 1024, Segments: 1, Blocks: 2}},
+	})
+}
+
+func TestStorageStats(t *testing.T) {
+	req := require.New(t)
+	req.NoError(logger.Init(logger.Logging{
+		Env:   "dev",
+		Level: "warn",
+	}))
+	pipeline, err := queue.NewQueue(context.TODO(), nil)
+	req.NoError(err)
+	req.NoError(pipeline.Subscribe(data.TopicStreamMaintenance, fakeStatsReporter{}))
+
+	s := NewServer(context.TODO(), pipeline, nil, nil)
+	s.log = logger.GetLogger("test")
+	s.maintenanceTimeout = time.Second
+	request := &streamv1.StorageStatsRequest{
+		Metadata: &commonv1.Metadata{
+			Name:  "sw",
+			Group: "default",
+		},
+	}
+	_, err = s.StorageStats(context.Background(), request)
+	req.Equal(codes.PermissionDenied, status.Code(err))
+
+	s.adminToken = "secret"
+	_, err = s.StorageStats(context.Background(), request)
+	req.Equal(codes.Unauthenticated, status.Code(err))
+
+	resp, err := s.StorageStats(grpcmetadata.NewIncomingContext(context.Background(),
+		grpcmetadata.Pairs(adminAuthHeader, "Bearer secret")), request)
+	req.NoError(err)
+	req.Len(resp.GetShards(), 1)
+	req.Equal(int64(1024), resp.GetShards()[0].GetBytes())
+	req.Equal(uint32(2), resp.GetShards()[0].GetBlocks())
+}
+
+type fakeQuerier struct{}
+
+func (fakeQuerier) Rev(message bus.Message) (resp bus.Message) {
+	return bus.NewMessage(message.ID(), []*streamv1.Element{{ElementId: "1"}})
+}
+
+func TestWritePause(t *testing.T) {
+	req := require.New(t)
+	req.NoError(logger.Init(logger.Logging{
+		Env:   "dev",
+		Level: "warn",
+	}))
+	pipeline, err := queue.NewQueue(context.TODO(), nil)
+	req.NoError(err)
+	writer := &blockingWriter{
+		flushCh: make(chan struct{}),
+		revCh:   make(chan struct{}, 10),
+	}
+	close(writer.flushCh)
+	req.NoError(pipeline.Subscribe(data.TopicStreamWrite, writer))
+	req.NoError(pipeline.Subscribe(data.TopicStreamQuery, fakeQuerier{}))
+
+	metadata := &commonv1.Metadata{
+		Name:  "sw",
+		Group: "default",
+	}
+	s := NewServer(context.TODO(), pipeline, nil, nil)
+	s.log = logger.GetLogger("test")
+	s.adminToken = "secret"
+	s.shardRepo.shardEventsMap[getID(metadata)] = 2
+	s.entityRepo.entitiesMap[getID(metadata)] = partition.EntityLocator{{FamilyOffset: 0, TagOffset: 0}}
+	adminCtx := grpcmetadata.NewIncomingContext(context.Background(), grpcmetadata.Pairs(adminAuthHeader, "Bearer secret"))
+	write := func() error {
+		writeServer := &fakeWriteServer{
+			reqCh:  make(chan *streamv1.WriteRequest),
+			respCh: make(chan *streamv1.WriteResponse, 1),
+		}
+		doneCh := make(chan error)
+		go func() {
+			doneCh <- s.Write(writeServer)
+		}()
+		writeServer.reqCh <- &streamv1.WriteRequest{
+			Metadata: metadata,
+			Element: &streamv1.ElementValue{
+				ElementId: "1",
+				TagFamilies: []*modelv1.TagFamilyForWrite{{Tags: []*modelv1.TagValue{
+					{Value: &modelv1.TagValue_Str{Str: &modelv1.Str{Value: "webapp_id"}}},
+				}}},
+			},
+		}
+		select {
+		case <-writeServer.respCh:
+			close(writeServer.reqCh)
+			return <-doneCh
+		case err := <-doneCh:
+			return err
+		}
+	}
+
+	_, err = s.SetWritePaused(context.Background(), &streamv1.SetWritePausedRequest{Metadata: metadata, Paused: true})
+	req.Equal(codes.Unauthenticated, status.Code(err))
+	req.NoError(write())
+
+	resp, err := s.SetWritePaused(adminCtx, &streamv1.SetWritePausedRequest{Metadata: metadata, Paused: true})
+	req.NoError(err)
+	req.True(resp.GetState().GetPaused())
+	pausedAt := resp.GetState().GetPausedAt().AsTime()
+	req.Equal(codes.Unavailable, status.Code(write()))
+	queryResp, err := s.Query(context.Background(), &streamv1.QueryRequest{Metadata: metadata})
+	req.NoError(err)
+	req.Len(queryResp.GetElements(), 1)
+
+	// pausing again keeps the original pause time
+	resp, err = s.SetWritePaused(adminCtx, &streamv1.SetWritePausedRequest{Metadata: metadata, Paused: true})
+	req.NoError(err)
+	req.Equal(pausedAt, resp.GetState().GetPausedAt().AsTime())
+	state, err := s.GetWriteState(context.Background(), &streamv1.GetWriteStateRequest{Metadata: metadata})
+	req.NoError(err)
+	req.True(state.GetState().GetPaused())
+	other, err := s.GetWriteState(context.Background(), &streamv1.GetWriteStateRequest{
+		Metadata: &commonv1.Metadata{Name: "other", Group: "default"},
+	})
+	req.NoError(err)
+	req.False(other.GetState().GetPaused())
+
+	resp, err = s.SetWritePaused(adminCtx, &streamv1.SetWritePausedRequest{Metadata: metadata, Paused: false})
+	req.NoError(err)
+	req.False(resp.GetState().GetPaused())
+	req.Nil(resp.GetState().GetPausedAt())
+	req.NoError(write())
+}
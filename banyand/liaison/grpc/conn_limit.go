@@ -0,0 +1,80 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package grpc
+
+import (
+	"net"
+	"sync"
+	"sync/atomic"
+
+	"github.com/apache/skywalking-banyandb/pkg/logger"
+)
+
+// limitListener closes the accepted connections beyond the limit right away.
+// Unlike blocking the accept loop, the rejected clients fail fast and the backlog doesn't pile up.
+type limitListener struct {
+	net.Listener
+	limit int64
+	conns int64
+	log   *logger.Logger
+}
+
+// newLimitListener wraps l with the limit of the concurrent connections, and a non-positive limit means unlimited
+func newLimitListener(l net.Listener, limit int, log *logger.Logger) net.Listener {
+	return &limitListener{
+		Listener: l,
+		limit:    int64(limit),
+		log:      log,
+	}
+}
+
+func (l *limitListener) Accept() (net.Conn, error) {
+	for {
+		conn, err := l.Listener.Accept()
+		if err != nil {
+			return nil, err
+		}
+		n := atomic.AddInt64(&l.conns, 1)
+		if l.limit > 0 && n > l.limit {
+			atomic.AddInt64(&l.conns, -1)
+			l.log.Warn().Str("remote", conn.RemoteAddr().String()).Int64("limit", l.limit).
+				Msg("reject the connection beyond the limit")
+			_ = conn.Close()
+			continue
+		}
+		activeConnections.Set(float64(n))
+		return &limitConn{Conn: conn, release: l.release}, nil
+	}
+}
+
+func (l *limitListener) release() {
+	activeConnections.Set(float64(atomic.AddInt64(&l.conns, -1)))
+}
+
+// limitConn gives its slot back to the listener once it's closed
+type limitConn struct {
+	net.Conn
+	once    sync.Once
+	release func()
+}
+
+func (c *limitConn) Close() error {
+	err := c.Conn.Close()
+	c.once.Do(c.release)
+	return err
+}
@@ -0,0 +1,75 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package grpc
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	grpclib "google.golang.org/grpc"
+
+	"github.com/apache/skywalking-banyandb/pkg/logger"
+)
+
+func TestLimitListener(t *testing.T) {
+	req := require.New(t)
+	req.NoError(logger.Init(logger.Logging{
+		Env:   "dev",
+		Level: "warn",
+	}))
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	req.NoError(err)
+	ser := grpclib.NewServer()
+	go func() {
+		_ = ser.Serve(newLimitListener(lis, 2, logger.GetLogger("test")))
+	}()
+	defer ser.Stop()
+
+	// the server sends its settings to an accepted connection, while it closes a rejected one without a word
+	dial := func() (net.Conn, bool) {
+		conn, errDial := net.Dial("tcp", lis.Addr().String())
+		req.NoError(errDial)
+		req.NoError(conn.SetReadDeadline(time.Now().Add(time.Second)))
+		n, _ := conn.Read(make([]byte, 1))
+		return conn, n > 0
+	}
+	conns := make([]net.Conn, 0, 2)
+	for i := 0; i < 2; i++ {
+		conn, accepted := dial()
+		defer conn.Close()
+		req.True(accepted)
+		conns = append(conns, conn)
+	}
+	req.Equal(float64(2), testutil.ToFloat64(activeConnections))
+	conn, accepted := dial()
+	conn.Close()
+	req.False(accepted, "the connection beyond the limit should be rejected")
+	req.Equal(float64(2), testutil.ToFloat64(activeConnections))
+
+	// a closed connection gives its slot back
+	req.NoError(conns[0].Close())
+	req.Eventually(func() bool {
+		return testutil.ToFloat64(activeConnections) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	conn, accepted = dial()
+	defer conn.Close()
+	req.True(accepted)
+}
@@ -34,6 +34,8 @@ type identity struct {
 
 type shardRepo struct {
 	log            *logger.Logger
+	ready          *readiness
+	topology       *topology
 	shardEventsMap map[identity]uint32
 	sync.RWMutex
 }
@@ -44,7 +46,10 @@ func (s *shardRepo) Rev(message bus.Message) (resp bus.Message) {
 		s.log.Warn().Msg("invalid e data type")
 		return
 	}
+	repoEvents.WithLabelValues("shard", databasev1.Action_name[int32(e.Action)]).Inc()
 	s.setShardNum(e)
+	s.topology.onShardEvent(e)
+	s.ready.markShardEvent()
 	s.log.Info().
 		Str("action", databasev1.Action_name[int32(e.Action)]).
 		Uint64("shardID", e.Shard.Id).
@@ -80,9 +85,22 @@ func getID(metadata *commonv1.Metadata) identity {
 	}
 }
 
+func (i identity) metadata() *commonv1.Metadata {
+	return &commonv1.Metadata{
+		Name:  i.name,
+		Group: i.group,
+	}
+}
+
 type entityRepo struct {
 	log         *logger.Logger
+	ready       *readiness
+	topology    *topology
 	entitiesMap map[identity]partition.EntityLocator
+	// overridesMap holds the shard overrides of the schemas having them
+	overridesMap map[identity]partition.ShardOverrides
+	// strategiesMap holds the sharding strategies of the schemas with the known algorithms
+	strategiesMap map[identity]partition.ShardingStrategy
 	sync.RWMutex
 }
 
@@ -93,6 +111,7 @@ func (s *entityRepo) Rev(message bus.Message) (resp bus.Message) {
 		return
 	}
 	id := getID(e.GetSubject())
+	repoEvents.WithLabelValues("entity", databasev1.Action_name[int32(e.Action)]).Inc()
 	s.log.Info().
 		Str("action", databasev1.Action_name[int32(e.Action)]).
 		Interface("subject", id).
@@ -109,9 +128,29 @@ func (s *entityRepo) Rev(message bus.Message) (resp bus.Message) {
 			})
 		}
 		s.entitiesMap[id] = en
+		overrides, errOverrides := partition.ParseShardOverrides(len(en), e.GetShardOverrides())
+		if errOverrides != nil {
+			s.log.Warn().Err(errOverrides).Interface("subject", id).Msg("ignored the invalid shard overrides")
+		}
+		if overrides == nil {
+			delete(s.overridesMap, id)
+		} else {
+			s.overridesMap[id] = overrides
+		}
+		strategy, errStrategy := partition.NewShardingStrategy(e.GetShardingAlgorithm())
+		if errStrategy != nil {
+			s.log.Warn().Err(errStrategy).Interface("subject", id).Msg("ignored the unknown sharding algorithm")
+			delete(s.strategiesMap, id)
+		} else {
+			s.strategiesMap[id] = strategy
+		}
 	case databasev1.Action_ACTION_DELETE:
 		delete(s.entitiesMap, id)
+		delete(s.overridesMap, id)
+		delete(s.strategiesMap, id)
 	}
+	s.topology.onEntityEvent(e)
+	s.ready.markEntityEvent()
 	return
 }
 
@@ -124,3 +163,20 @@ func (s *entityRepo) getLocator(id identity) (partition.EntityLocator, bool) {
 	}
 	return el, true
 }
+
+// getOverrides returns nil if the schema has no shard override
+func (s *entityRepo) getOverrides(id identity) partition.ShardOverrides {
+	s.RWMutex.RLock()
+	defer s.RWMutex.RUnlock()
+	return s.overridesMap[id]
+}
+
+// getStrategy returns the sharding strategy of the schema, which is the modulo by default
+func (s *entityRepo) getStrategy(id identity) partition.ShardingStrategy {
+	s.RWMutex.RLock()
+	defer s.RWMutex.RUnlock()
+	if strategy, ok := s.strategiesMap[id]; ok {
+		return strategy
+	}
+	return partition.ModuloStrategy{}
+}
@@ -0,0 +1,91 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package grpc
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	commonv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/common/v1"
+	databasev1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/database/v1"
+	"github.com/apache/skywalking-banyandb/pkg/bus"
+	"github.com/apache/skywalking-banyandb/pkg/logger"
+	"github.com/apache/skywalking-banyandb/pkg/partition"
+)
+
+func TestRepo_ConcurrentEvents(t *testing.T) {
+	req := require.New(t)
+	req.NoError(logger.Init(logger.Logging{
+		Env:   "dev",
+		Level: "warn",
+	}))
+	l := logger.GetLogger("test")
+	shards := &shardRepo{log: l, shardEventsMap: make(map[identity]uint32)}
+	entities := &entityRepo{
+		log:           l,
+		entitiesMap:   make(map[identity]partition.EntityLocator),
+		overridesMap:  make(map[identity]partition.ShardOverrides),
+		strategiesMap: make(map[identity]partition.ShardingStrategy),
+	}
+	const num = 50
+	metadata := func(i int) *commonv1.Metadata {
+		return &commonv1.Metadata{Group: fmt.Sprintf("group-%d", i%5), Name: fmt.Sprintf("stream-%d", i)}
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < num; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			shards.Rev(bus.NewMessage(bus.MessageID(i), &databasev1.ShardEvent{
+				Shard:  &databasev1.Shard{Total: uint32(i + 1), Metadata: metadata(i)},
+				Action: databasev1.Action_ACTION_PUT,
+			}))
+		}(i)
+		go func(i int) {
+			defer wg.Done()
+			entities.Rev(bus.NewMessage(bus.MessageID(i), &databasev1.EntityEvent{
+				Subject:           metadata(i),
+				EntityLocator:     []*databasev1.EntityEvent_TagLocator{{FamilyOffset: 0, TagOffset: uint32(i)}},
+				ShardingAlgorithm: databasev1.ShardingAlgorithm_SHARDING_ALGORITHM_PREFIX,
+				Action:            databasev1.Action_ACTION_PUT,
+			}))
+			// the readers run alongside the writers
+			_, _ = entities.getLocator(getID(metadata(i / 2)))
+			_ = entities.getStrategy(getID(metadata(i / 2)))
+		}(i)
+	}
+	wg.Wait()
+
+	// every event is kept by its own group and name
+	for i := 0; i < num; i++ {
+		id := getID(metadata(i))
+		shardNum, ok := shards.shardNum(id)
+		req.True(ok)
+		req.Equal(uint32(i+1), shardNum)
+		locator, ok := entities.getLocator(id)
+		req.True(ok)
+		req.Equal(partition.EntityLocator{{FamilyOffset: 0, TagOffset: i}}, locator)
+		req.Equal(partition.PrefixStrategy{}, entities.getStrategy(id))
+	}
+	_, ok := entities.getLocator(getID(metadata(num)))
+	req.False(ok)
+	req.Equal(partition.ModuloStrategy{}, entities.getStrategy(getID(metadata(num))))
+}
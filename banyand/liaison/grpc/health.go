@@ -0,0 +1,80 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package grpc
+
+import (
+	"sync"
+
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	streamv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/stream/v1"
+)
+
+// readiness serves grpc.health.v1.Health. The liaison turns SERVING once the subscriptions of PreRun succeed and
+// both a shard event and an entity event arrive, since a write can't be routed without them.
+// It turns NOT_SERVING for good once the liaison is stopping.
+type readiness struct {
+	health     *health.Server
+	mu         sync.Mutex
+	subscribed bool
+	shardRev   bool
+	entityRev  bool
+}
+
+func newReadiness() *readiness {
+	r := &readiness{health: health.NewServer()}
+	r.setStatus(healthpb.HealthCheckResponse_NOT_SERVING)
+	return r
+}
+
+func (r *readiness) markSubscribed() {
+	r.mark(func() { r.subscribed = true })
+}
+
+func (r *readiness) markShardEvent() {
+	r.mark(func() { r.shardRev = true })
+}
+
+func (r *readiness) markEntityEvent() {
+	r.mark(func() { r.entityRev = true })
+}
+
+// mark is a no-op on a nil readiness, which leaves the repos working alone
+func (r *readiness) mark(fn func()) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	fn()
+	if r.subscribed && r.shardRev && r.entityRev {
+		r.setStatus(healthpb.HealthCheckResponse_SERVING)
+	}
+}
+
+func (r *readiness) setStatus(status healthpb.HealthCheckResponse_ServingStatus) {
+	// the empty service stands for the liaison as a whole
+	r.health.SetServingStatus("", status)
+	r.health.SetServingStatus(streamv1.StreamService_ServiceDesc.ServiceName, status)
+}
+
+// shutdown ignores the later events, so the probes keep failing while the requests are being drained
+func (r *readiness) shutdown() {
+	r.health.Shutdown()
+}
@@ -0,0 +1,94 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package grpc
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	grpclib "google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	commonv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/common/v1"
+	databasev1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/database/v1"
+	streamv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/stream/v1"
+	"github.com/apache/skywalking-banyandb/pkg/bus"
+	"github.com/apache/skywalking-banyandb/pkg/logger"
+	"github.com/apache/skywalking-banyandb/pkg/partition"
+)
+
+func TestReadiness(t *testing.T) {
+	req := require.New(t)
+	req.NoError(logger.Init(logger.Logging{
+		Env:   "dev",
+		Level: "warn",
+	}))
+	l := logger.GetLogger("test")
+	ready := newReadiness()
+	shards := &shardRepo{log: l, ready: ready, shardEventsMap: make(map[identity]uint32)}
+	entities := &entityRepo{
+		log:           l,
+		ready:         ready,
+		entitiesMap:   make(map[identity]partition.EntityLocator),
+		overridesMap:  make(map[identity]partition.ShardOverrides),
+		strategiesMap: make(map[identity]partition.ShardingStrategy),
+	}
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	req.NoError(err)
+	ser := grpclib.NewServer()
+	healthpb.RegisterHealthServer(ser, ready.health)
+	go func() {
+		_ = ser.Serve(lis)
+	}()
+	defer ser.Stop()
+
+	conn, err := grpclib.Dial(lis.Addr().String(), grpclib.WithInsecure())
+	req.NoError(err)
+	defer conn.Close()
+	client := healthpb.NewHealthClient(conn)
+	status := func(service string) healthpb.HealthCheckResponse_ServingStatus {
+		resp, errCheck := client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+		req.NoError(errCheck)
+		return resp.GetStatus()
+	}
+	metadata := &commonv1.Metadata{Group: "default", Name: "sw"}
+
+	req.Equal(healthpb.HealthCheckResponse_NOT_SERVING, status(""))
+	ready.markSubscribed()
+	shards.Rev(bus.NewMessage(bus.MessageID(1), &databasev1.ShardEvent{
+		Shard:  &databasev1.Shard{Total: 2, Metadata: metadata},
+		Action: databasev1.Action_ACTION_PUT,
+	}))
+	// a write can't be routed without the entity locator
+	req.Equal(healthpb.HealthCheckResponse_NOT_SERVING, status(""))
+	entities.Rev(bus.NewMessage(bus.MessageID(2), &databasev1.EntityEvent{
+		Subject:       metadata,
+		EntityLocator: []*databasev1.EntityEvent_TagLocator{{FamilyOffset: 0, TagOffset: 0}},
+		Action:        databasev1.Action_ACTION_PUT,
+	}))
+	req.Equal(healthpb.HealthCheckResponse_SERVING, status(""))
+	req.Equal(healthpb.HealthCheckResponse_SERVING, status(streamv1.StreamService_ServiceDesc.ServiceName))
+
+	// the liaison stays unready once it's stopping
+	ready.shutdown()
+	req.Equal(healthpb.HealthCheckResponse_NOT_SERVING, status(""))
+	ready.markEntityEvent()
+	req.Equal(healthpb.HealthCheckResponse_NOT_SERVING, status(""))
+}
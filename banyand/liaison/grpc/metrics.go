@@ -0,0 +1,122 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	grpclib "google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// entityFindFailures counts the elements whose entities can't be resolved from their tags
+var entityFindFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "banyandb",
+	Subsystem: "liaison",
+	Name:      "entity_find_failures_total",
+	Help:      "The number of elements failing to resolve their entities",
+}, []string{"group", "stream", "reason"})
+
+// writePaused is 1 if the writes of the stream are paused, otherwise 0
+var writePaused = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: "banyandb",
+	Subsystem: "liaison",
+	Name:      "write_paused",
+	Help:      "Whether the writes of the stream are paused",
+}, []string{"group", "stream"})
+
+// activeConnections is the number of the client connections being served
+var activeConnections = prometheus.NewGauge(prometheus.GaugeOpts{
+	Namespace: "banyandb",
+	Subsystem: "liaison",
+	Name:      "active_connections",
+	Help:      "The number of the client connections being served",
+})
+
+// grpcRequests counts the finished RPCs by their status codes
+var grpcRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "banyandb",
+	Subsystem: "liaison",
+	Name:      "grpc_requests_total",
+	Help:      "The number of the finished RPCs",
+}, []string{"method", "code"})
+
+// grpcLatency observes the duration of an RPC, which lasts as long as the stream for a streaming one
+var grpcLatency = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: "banyandb",
+	Subsystem: "liaison",
+	Name:      "grpc_request_duration_seconds",
+	Help:      "The duration of the RPCs",
+	Buckets:   prometheus.DefBuckets,
+}, []string{"method", "code"})
+
+// grpcInFlight is the number of the RPCs being served
+var grpcInFlight = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: "banyandb",
+	Subsystem: "liaison",
+	Name:      "grpc_requests_in_flight",
+	Help:      "The number of the RPCs being served",
+}, []string{"method"})
+
+// repoEvents counts the shard and the entity events received from the data nodes
+var repoEvents = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "banyandb",
+	Subsystem: "liaison",
+	Name:      "repo_events_total",
+	Help:      "The number of the shard and the entity events received",
+}, []string{"kind", "action"})
+
+func init() {
+	prometheus.MustRegister(entityFindFailures, writePaused, activeConnections,
+		grpcRequests, grpcLatency, grpcInFlight, repoEvents)
+}
+
+// observe starts to serve an RPC, and the returned function records the result of it
+func observe(method string) func(err error) {
+	start := time.Now()
+	inFlight := grpcInFlight.WithLabelValues(method)
+	inFlight.Inc()
+	return func(err error) {
+		inFlight.Dec()
+		code := status.Code(err).String()
+		grpcRequests.WithLabelValues(method, code).Inc()
+		grpcLatency.WithLabelValues(method, code).Observe(time.Since(start).Seconds())
+	}
+}
+
+func metricsUnaryInterceptor() grpclib.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpclib.UnaryServerInfo,
+		handler grpclib.UnaryHandler) (interface{}, error) {
+		done := observe(info.FullMethod)
+		resp, err := handler(ctx, req)
+		done(err)
+		return resp, err
+	}
+}
+
+func metricsStreamInterceptor() grpclib.StreamServerInterceptor {
+	return func(srv interface{}, ss grpclib.ServerStream, info *grpclib.StreamServerInfo,
+		handler grpclib.StreamHandler) error {
+		done := observe(info.FullMethod)
+		err := handler(srv, ss)
+		done(err)
+		return err
+	}
+}
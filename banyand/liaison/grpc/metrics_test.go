@@ -0,0 +1,100 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	grpclib "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	commonv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/common/v1"
+	databasev1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/database/v1"
+	"github.com/apache/skywalking-banyandb/pkg/bus"
+	"github.com/apache/skywalking-banyandb/pkg/logger"
+	"github.com/apache/skywalking-banyandb/pkg/partition"
+)
+
+func TestMetricsInterceptor(t *testing.T) {
+	req := require.New(t)
+	const method = "/banyandb.stream.v1.StreamService/Query"
+	unary := metricsUnaryInterceptor()
+	info := &grpclib.UnaryServerInfo{FullMethod: method}
+	handle := func(err error) {
+		_, errHandle := unary(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			// the in-flight RPC is counted during the handling
+			if testutil.ToFloat64(grpcInFlight.WithLabelValues(method)) != 1 {
+				return nil, status.Error(codes.Internal, "the RPC isn't in flight")
+			}
+			return nil, err
+		})
+		req.Equal(err, errHandle)
+	}
+	ok := testutil.ToFloat64(grpcRequests.WithLabelValues(method, codes.OK.String()))
+	invalid := testutil.ToFloat64(grpcRequests.WithLabelValues(method, codes.InvalidArgument.String()))
+	handle(nil)
+	handle(nil)
+	handle(status.Error(codes.InvalidArgument, "invalid"))
+	req.Equal(ok+2, testutil.ToFloat64(grpcRequests.WithLabelValues(method, codes.OK.String())))
+	req.Equal(invalid+1, testutil.ToFloat64(grpcRequests.WithLabelValues(method, codes.InvalidArgument.String())))
+	req.Zero(testutil.ToFloat64(grpcInFlight.WithLabelValues(method)))
+	req.NotZero(testutil.CollectAndCount(grpcLatency))
+
+	const streamMethod = "/banyandb.stream.v1.StreamService/Write"
+	unavailable := testutil.ToFloat64(grpcRequests.WithLabelValues(streamMethod, codes.Unavailable.String()))
+	err := metricsStreamInterceptor()(nil, nil, &grpclib.StreamServerInfo{FullMethod: streamMethod},
+		func(srv interface{}, stream grpclib.ServerStream) error {
+			return status.Error(codes.Unavailable, "paused")
+		})
+	req.Error(err)
+	req.Equal(unavailable+1, testutil.ToFloat64(grpcRequests.WithLabelValues(streamMethod, codes.Unavailable.String())))
+}
+
+func TestRepoEvents(t *testing.T) {
+	req := require.New(t)
+	req.NoError(logger.Init(logger.Logging{
+		Env:   "dev",
+		Level: "warn",
+	}))
+	l := logger.GetLogger("test")
+	shards := &shardRepo{log: l, shardEventsMap: make(map[identity]uint32)}
+	entities := &entityRepo{
+		log:           l,
+		entitiesMap:   make(map[identity]partition.EntityLocator),
+		overridesMap:  make(map[identity]partition.ShardOverrides),
+		strategiesMap: make(map[identity]partition.ShardingStrategy),
+	}
+	metadata := &commonv1.Metadata{Group: "default", Name: "sw"}
+	put := databasev1.Action_name[int32(databasev1.Action_ACTION_PUT)]
+	shardPuts := testutil.ToFloat64(repoEvents.WithLabelValues("shard", put))
+	entityPuts := testutil.ToFloat64(repoEvents.WithLabelValues("entity", put))
+	shards.Rev(bus.NewMessage(bus.MessageID(1), &databasev1.ShardEvent{
+		Shard:  &databasev1.Shard{Id: 0, Total: 2, Metadata: metadata},
+		Action: databasev1.Action_ACTION_PUT,
+	}))
+	entities.Rev(bus.NewMessage(bus.MessageID(2), &databasev1.EntityEvent{
+		Subject: metadata,
+		Action:  databasev1.Action_ACTION_PUT,
+	}))
+	req.Equal(shardPuts+1, testutil.ToFloat64(repoEvents.WithLabelValues("shard", put)))
+	req.Equal(entityPuts+1, testutil.ToFloat64(repoEvents.WithLabelValues("entity", put)))
+}
@@ -0,0 +1,96 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package grpc
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	commonv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/common/v1"
+	streamv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/stream/v1"
+)
+
+// writePauses records the streams whose writes are paused and when they are paused
+type writePauses struct {
+	sync.RWMutex
+	pausedAt map[identity]time.Time
+}
+
+func newWritePauses() *writePauses {
+	return &writePauses{pausedAt: make(map[identity]time.Time)}
+}
+
+func (w *writePauses) set(id identity, paused bool) {
+	w.Lock()
+	defer w.Unlock()
+	if !paused {
+		delete(w.pausedAt, id)
+		writePaused.WithLabelValues(id.group, id.name).Set(0)
+		return
+	}
+	if _, ok := w.pausedAt[id]; ok {
+		return
+	}
+	w.pausedAt[id] = time.Now()
+	writePaused.WithLabelValues(id.group, id.name).Set(1)
+}
+
+func (w *writePauses) isPaused(id identity) bool {
+	w.RLock()
+	defer w.RUnlock()
+	_, ok := w.pausedAt[id]
+	return ok
+}
+
+func (w *writePauses) state(metadata *commonv1.Metadata) *streamv1.WriteState {
+	w.RLock()
+	defer w.RUnlock()
+	state := &streamv1.WriteState{Metadata: metadata}
+	if at, ok := w.pausedAt[getID(metadata)]; ok {
+		state.Paused = true
+		state.PausedAt = timestamppb.New(at)
+	}
+	return state
+}
+
+// SetWritePaused pauses or resumes the writes of a stream in this liaison.
+// Pausing a paused stream keeps the original pause time.
+func (s *Server) SetWritePaused(ctx context.Context, req *streamv1.SetWritePausedRequest) (*streamv1.SetWritePausedResponse, error) {
+	if err := s.authorizeAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if req.GetMetadata() == nil {
+		return nil, status.Error(codes.InvalidArgument, "metadata is absent")
+	}
+	s.writePauses.set(getID(req.GetMetadata()), req.GetPaused())
+	s.log.Info().Str("group", req.GetMetadata().GetGroup()).Str("stream", req.GetMetadata().GetName()).
+		Bool("paused", req.GetPaused()).Msg("set the write state")
+	return &streamv1.SetWritePausedResponse{State: s.writePauses.state(req.GetMetadata())}, nil
+}
+
+func (s *Server) GetWriteState(_ context.Context, req *streamv1.GetWriteStateRequest) (*streamv1.GetWriteStateResponse, error) {
+	if req.GetMetadata() == nil {
+		return nil, status.Error(codes.InvalidArgument, "metadata is absent")
+	}
+	return &streamv1.GetWriteStateResponse{State: s.writePauses.state(req.GetMetadata())}, nil
+}
@@ -0,0 +1,318 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package grpc
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/time/rate"
+	grpclib "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	commonv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/common/v1"
+	measurev1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/measure/v1"
+	streamv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/stream/v1"
+	"github.com/apache/skywalking-banyandb/pkg/logger"
+)
+
+const defaultQuotaReloadInterval = 30 * time.Second
+
+var (
+	ErrInvalidQuota = errors.New("invalid group quota")
+
+	// the quotas only apply to the data services, the registries are never throttled
+	quotaServices = []string{
+		"/" + streamv1.StreamService_ServiceDesc.ServiceName + "/",
+		"/" + measurev1.MeasureService_ServiceDesc.ServiceName + "/",
+	}
+)
+
+// groupQuota limits the requests to a group. A zero field disables the limit.
+type groupQuota struct {
+	// QPS is the number of requests or streamed messages allowed per second
+	QPS   float64 `json:"qps"`
+	Burst int     `json:"burst"`
+	// Concurrency is the number of in-flight calls
+	Concurrency int `json:"concurrency"`
+}
+
+func (q groupQuota) validate() error {
+	if q.QPS < 0 || q.Burst < 0 || q.Concurrency < 0 {
+		return errors.WithMessagef(ErrInvalidQuota, "negative limits: %+v", q)
+	}
+	if q.QPS > 0 && q.Burst < 1 {
+		return errors.WithMessagef(ErrInvalidQuota, "burst should be positive when qps is set: %+v", q)
+	}
+	return nil
+}
+
+type groupLimiter struct {
+	quota   groupQuota
+	limiter *rate.Limiter
+	// inflight is a semaphore, a replaced one is still released by the calls acquiring it
+	inflight chan struct{}
+}
+
+func newGroupLimiter(quota groupQuota) *groupLimiter {
+	l := &groupLimiter{quota: quota}
+	if quota.QPS > 0 {
+		l.limiter = rate.NewLimiter(rate.Limit(quota.QPS), quota.Burst)
+	}
+	if quota.Concurrency > 0 {
+		l.inflight = make(chan struct{}, quota.Concurrency)
+	}
+	return l
+}
+
+func (l *groupLimiter) allow() bool {
+	return l.limiter == nil || l.limiter.Allow()
+}
+
+func (l *groupLimiter) acquire() (release func(), ok bool) {
+	sem := l.inflight
+	if sem == nil {
+		return func() {}, true
+	}
+	select {
+	case sem <- struct{}{}:
+		return func() { <-sem }, true
+	default:
+		return nil, false
+	}
+}
+
+// quotaLimiter enforces the quotas of groups, which are reloaded from a file
+type quotaLimiter struct {
+	log      *logger.Logger
+	file     string
+	modTime  time.Time
+	limiters map[string]*groupLimiter
+	stopCh   chan struct{}
+	sync.RWMutex
+}
+
+func newQuotaLimiter(log *logger.Logger) *quotaLimiter {
+	return &quotaLimiter{
+		log:      log,
+		limiters: make(map[string]*groupLimiter),
+	}
+}
+
+// reload replaces the quotas. The limiter of an unchanged quota is kept to preserve its tokens and in-flight calls.
+func (q *quotaLimiter) reload(quotas map[string]groupQuota) error {
+	for group, quota := range quotas {
+		if err := quota.validate(); err != nil {
+			return errors.WithMessagef(err, "group %s", group)
+		}
+	}
+	q.Lock()
+	defer q.Unlock()
+	limiters := make(map[string]*groupLimiter, len(quotas))
+	for group, quota := range quotas {
+		if l, ok := q.limiters[group]; ok && l.quota == quota {
+			limiters[group] = l
+			continue
+		}
+		limiters[group] = newGroupLimiter(quota)
+	}
+	q.limiters = limiters
+	return nil
+}
+
+// load reads the quotas from the file if it's modified since the last loading.
+// The file is a JSON object keyed by the group name, e.g. {"sw_metric": {"qps": 1000, "burst": 2000, "concurrency": 16}}.
+func (q *quotaLimiter) load(file string) error {
+	info, err := os.Stat(file)
+	if err != nil {
+		return errors.Wrapf(err, "failed to stat the quota file %s", file)
+	}
+	if file == q.file && info.ModTime().Equal(q.modTime) {
+		return nil
+	}
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read the quota file %s", file)
+	}
+	quotas := make(map[string]groupQuota)
+	if err = json.Unmarshal(data, &quotas); err != nil {
+		return errors.Wrapf(err, "failed to parse the quota file %s", file)
+	}
+	if err = q.reload(quotas); err != nil {
+		return err
+	}
+	q.file = file
+	q.modTime = info.ModTime()
+	q.log.Info().Str("file", file).Int("groups", len(quotas)).Msg("loaded group quotas")
+	return nil
+}
+
+// watch reloads the file periodically, the current quotas stay if the file turns invalid
+func (q *quotaLimiter) watch(file string, interval time.Duration) {
+	q.stopCh = make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if err := q.load(file); err != nil {
+					q.log.Warn().Err(err).Msg("failed to reload group quotas")
+				}
+			case <-q.stopCh:
+				return
+			}
+		}
+	}()
+}
+
+func (q *quotaLimiter) stop() {
+	if q.stopCh != nil {
+		close(q.stopCh)
+	}
+}
+
+func (q *quotaLimiter) get(group string) *groupLimiter {
+	q.RLock()
+	defer q.RUnlock()
+	return q.limiters[group]
+}
+
+// wait blocks until the qps quota of the group lets one more message through, or the context is done
+func (q *quotaLimiter) wait(ctx context.Context, group string) error {
+	l := q.get(group)
+	if l == nil || l.limiter == nil {
+		return nil
+	}
+	if err := l.limiter.Wait(ctx); err != nil {
+		if ctx.Err() != nil {
+			return status.FromContextError(ctx.Err()).Err()
+		}
+		return status.Errorf(codes.ResourceExhausted, "the group %s exceeds its qps quota: %v", group, err)
+	}
+	return nil
+}
+
+// acquireConcurrency holds the concurrency quota of the group regardless of its qps quota
+func (q *quotaLimiter) acquireConcurrency(group string) (func(), error) {
+	l := q.get(group)
+	if l == nil {
+		return func() {}, nil
+	}
+	release, ok := l.acquire()
+	if !ok {
+		return nil, status.Errorf(codes.ResourceExhausted, "the group %s exceeds its concurrency quota", group)
+	}
+	return release, nil
+}
+
+func (q *quotaLimiter) acquire(group string) (func(), error) {
+	if l := q.get(group); l != nil && !l.allow() {
+		return nil, status.Errorf(codes.ResourceExhausted, "the group %s exceeds its qps quota", group)
+	}
+	return q.acquireConcurrency(group)
+}
+
+func (q *quotaLimiter) unaryInterceptor() grpclib.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpclib.UnaryServerInfo,
+		handler grpclib.UnaryHandler) (interface{}, error) {
+		group, ok := targetGroup(info.FullMethod, req)
+		if !ok {
+			return handler(ctx, req)
+		}
+		release, err := q.acquire(group)
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+		return handler(ctx, req)
+	}
+}
+
+func (q *quotaLimiter) streamInterceptor() grpclib.StreamServerInterceptor {
+	return func(srv interface{}, ss grpclib.ServerStream, info *grpclib.StreamServerInfo,
+		handler grpclib.StreamHandler) error {
+		if !isQuotaService(info.FullMethod) {
+			return handler(srv, ss)
+		}
+		qs := &quotaStream{ServerStream: ss, limiter: q, method: info.FullMethod}
+		defer qs.release()
+		return handler(srv, qs)
+	}
+}
+
+// quotaStream throttles every received message by the qps quota, which delays the message instead of failing
+// the whole stream, and holds the concurrency quota of the first message's group until the stream ends
+type quotaStream struct {
+	grpclib.ServerStream
+	limiter   *quotaLimiter
+	method    string
+	onRelease func()
+}
+
+func (s *quotaStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	group, ok := targetGroup(s.method, m)
+	if !ok {
+		return nil
+	}
+	if s.onRelease == nil {
+		release, err := s.limiter.acquireConcurrency(group)
+		if err != nil {
+			return err
+		}
+		s.onRelease = release
+	}
+	return s.limiter.wait(s.Context(), group)
+}
+
+func (s *quotaStream) release() {
+	if s.onRelease != nil {
+		s.onRelease()
+	}
+}
+
+func isQuotaService(fullMethod string) bool {
+	for _, prefix := range quotaServices {
+		if strings.HasPrefix(fullMethod, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// targetGroup extracts the group from the metadata of a data service's request
+func targetGroup(fullMethod string, req interface{}) (string, bool) {
+	if !isQuotaService(fullMethod) {
+		return "", false
+	}
+	r, ok := req.(interface{ GetMetadata() *commonv1.Metadata })
+	if !ok || r.GetMetadata().GetGroup() == "" {
+		return "", false
+	}
+	return r.GetMetadata().GetGroup(), true
+}
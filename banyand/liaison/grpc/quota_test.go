@@ -0,0 +1,160 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package grpc
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	grpclib "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	commonv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/common/v1"
+	streamv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/stream/v1"
+	"github.com/apache/skywalking-banyandb/pkg/logger"
+)
+
+func TestQuotaLimiter_ThrottleByGroup(t *testing.T) {
+	req := require.New(t)
+	req.NoError(logger.Init(logger.Logging{
+		Env:   "dev",
+		Level: "warn",
+	}))
+	dir, err := ioutil.TempDir("", "banyandb-quota")
+	req.NoError(err)
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "quota.json")
+	req.NoError(ioutil.WriteFile(file, []byte(`{"throttled": {"qps": 0.001, "burst": 3}}`), 0600))
+
+	q := newQuotaLimiter(logger.GetLogger("test"))
+	req.NoError(q.load(file))
+	interceptor := q.unaryInterceptor()
+	info := &grpclib.UnaryServerInfo{FullMethod: "/banyandb.stream.v1.StreamService/Query"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return &streamv1.QueryResponse{}, nil
+	}
+	query := func(group string) error {
+		_, errQuery := interceptor(context.Background(), &streamv1.QueryRequest{
+			Metadata: &commonv1.Metadata{Group: group, Name: "sw"},
+		}, info, handler)
+		return errQuery
+	}
+
+	for i := 0; i < 3; i++ {
+		req.NoError(query("throttled"))
+	}
+	err = query("throttled")
+	req.Error(err)
+	req.Equal(codes.ResourceExhausted, status.Code(err))
+	for i := 0; i < 10; i++ {
+		req.NoError(query("default"))
+	}
+
+	// the registries are never throttled
+	_, err = interceptor(context.Background(), &streamv1.QueryRequest{
+		Metadata: &commonv1.Metadata{Group: "throttled", Name: "sw"},
+	}, &grpclib.UnaryServerInfo{FullMethod: "/banyandb.database.v1.StreamRegistryService/Get"}, handler)
+	req.NoError(err)
+
+	// reload a larger burst without restarting
+	req.NoError(ioutil.WriteFile(file, []byte(`{"throttled": {"qps": 0.001, "burst": 5}}`), 0600))
+	modTime := time.Now().Add(time.Second)
+	req.NoError(os.Chtimes(file, modTime, modTime))
+	req.NoError(q.load(file))
+	for i := 0; i < 5; i++ {
+		req.NoError(query("throttled"))
+	}
+	req.Equal(codes.ResourceExhausted, status.Code(query("throttled")))
+}
+
+func TestQuotaLimiter_Concurrency(t *testing.T) {
+	req := require.New(t)
+	req.NoError(logger.Init(logger.Logging{
+		Env:   "dev",
+		Level: "warn",
+	}))
+	q := newQuotaLimiter(logger.GetLogger("test"))
+	req.NoError(q.reload(map[string]groupQuota{"throttled": {Concurrency: 1}}))
+	req.ErrorIs(q.reload(map[string]groupQuota{"throttled": {QPS: 1}}), ErrInvalidQuota)
+
+	release, err := q.acquire("throttled")
+	req.NoError(err)
+	_, err = q.acquire("throttled")
+	req.Equal(codes.ResourceExhausted, status.Code(err))
+	_, err = q.acquire("default")
+	req.NoError(err)
+	release()
+	release, err = q.acquire("throttled")
+	req.NoError(err)
+	release()
+}
+
+// fakeQuotaStream receives the write requests of the group until the context is done
+type fakeQuotaStream struct {
+	grpclib.ServerStream
+	ctx   context.Context
+	group string
+}
+
+func (f *fakeQuotaStream) Context() context.Context {
+	return f.ctx
+}
+
+func (f *fakeQuotaStream) RecvMsg(m interface{}) error {
+	m.(*streamv1.WriteRequest).Metadata = &commonv1.Metadata{Group: f.group, Name: "sw"}
+	return nil
+}
+
+func TestQuotaLimiter_ThrottleStream(t *testing.T) {
+	req := require.New(t)
+	req.NoError(logger.Init(logger.Logging{
+		Env:   "dev",
+		Level: "warn",
+	}))
+	q := newQuotaLimiter(logger.GetLogger("test"))
+	req.NoError(q.reload(map[string]groupQuota{"throttled": {QPS: 20, Burst: 1, Concurrency: 1}}))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	qs := &quotaStream{
+		ServerStream: &fakeQuotaStream{ctx: ctx, group: "throttled"},
+		limiter:      q,
+		method:       "/banyandb.stream.v1.StreamService/Write",
+	}
+	defer qs.release()
+
+	// the messages over the qps quota are delayed rather than failing the stream
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		req.NoError(qs.RecvMsg(&streamv1.WriteRequest{}))
+	}
+	req.GreaterOrEqual(time.Since(start), 90*time.Millisecond)
+
+	// the stream holds the concurrency quota
+	_, err := q.acquireConcurrency("throttled")
+	req.Equal(codes.ResourceExhausted, status.Code(err))
+
+	// the waiting message gives up once the stream ends
+	cancel()
+	req.Equal(codes.Canceled, status.Code(qs.RecvMsg(&streamv1.WriteRequest{})))
+}
@@ -20,11 +20,36 @@ package grpc
 import (
 	"context"
 
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
 	databasev1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/database/v1"
 	"github.com/apache/skywalking-banyandb/banyand/metadata"
 	"github.com/apache/skywalking-banyandb/banyand/metadata/schema"
 )
 
+// registryError translates the errors of the schema registry into the status codes, which tell the clients
+// whether to retry, to read the entity again, or to fix the request. The other errors are returned as they are.
+func registryError(err error) error {
+	switch {
+	case errors.Is(err, schema.ErrEntityNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, schema.ErrEntityAlreadyExists):
+		// the creation conflicts with an existing entity, which a retry doesn't
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, schema.ErrConflict):
+		// the update carrying a revision loses the race, and the clients should read the entity again
+		return status.Error(codes.Aborted, err.Error())
+	case errors.Is(err, schema.ErrReferredByBindings):
+		// the stream or the measure is still referred, which a retry with cascade deletes along
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, schema.ErrInvalidSchema), errors.Is(err, schema.ErrInvalidListOpt):
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return err
+}
+
 type streamRegistryServer struct {
 	schemaRegistry metadata.Service
 	databasev1.UnimplementedStreamRegistryServiceServer
@@ -32,25 +57,37 @@ type streamRegistryServer struct {
 
 func (rs *streamRegistryServer) Create(ctx context.Context,
 	req *databasev1.StreamRegistryServiceCreateRequest) (*databasev1.StreamRegistryServiceCreateResponse, error) {
-	if err := rs.schemaRegistry.StreamRegistry().UpdateStream(ctx, req.GetStream()); err != nil {
-		return nil, err
+	var err error
+	if req.GetDryRun() {
+		err = rs.schemaRegistry.StreamRegistry().ValidateStream(ctx, req.GetStream(), schema.ValidateOpt{Create: true})
+	} else {
+		err = rs.schemaRegistry.StreamRegistry().CreateStream(ctx, req.GetStream())
+	}
+	if err != nil {
+		return nil, registryError(err)
 	}
 	return &databasev1.StreamRegistryServiceCreateResponse{}, nil
 }
 
 func (rs *streamRegistryServer) Update(ctx context.Context,
 	req *databasev1.StreamRegistryServiceUpdateRequest) (*databasev1.StreamRegistryServiceUpdateResponse, error) {
-	if err := rs.schemaRegistry.StreamRegistry().UpdateStream(ctx, req.GetStream()); err != nil {
-		return nil, err
+	var err error
+	if req.GetDryRun() {
+		err = rs.schemaRegistry.StreamRegistry().ValidateStream(ctx, req.GetStream(), schema.ValidateOpt{})
+	} else {
+		err = rs.schemaRegistry.StreamRegistry().UpdateStream(ctx, req.GetStream())
+	}
+	if err != nil {
+		return nil, registryError(err)
 	}
 	return &databasev1.StreamRegistryServiceUpdateResponse{}, nil
 }
 
 func (rs *streamRegistryServer) Delete(ctx context.Context,
 	req *databasev1.StreamRegistryServiceDeleteRequest) (*databasev1.StreamRegistryServiceDeleteResponse, error) {
-	ok, err := rs.schemaRegistry.StreamRegistry().DeleteStream(ctx, req.GetMetadata())
+	ok, err := rs.schemaRegistry.StreamRegistry().DeleteStream(ctx, req.GetMetadata(), schema.DeleteOpt{Cascade: req.GetCascade()})
 	if err != nil {
-		return nil, err
+		return nil, registryError(err)
 	}
 	return &databasev1.StreamRegistryServiceDeleteResponse{
 		Deleted: ok,
@@ -61,18 +98,39 @@ func (rs *streamRegistryServer) Get(ctx context.Context,
 	req *databasev1.StreamRegistryServiceGetRequest) (*databasev1.StreamRegistryServiceGetResponse, error) {
 	entity, err := rs.schemaRegistry.StreamRegistry().GetStream(ctx, req.GetMetadata())
 	if err != nil {
-		return nil, err
+		return nil, registryError(err)
+	}
+	hash, err := schema.Hash(entity)
+	if err != nil {
+		return nil, registryError(err)
 	}
 	return &databasev1.StreamRegistryServiceGetResponse{
-		Stream: entity,
+		Stream:     entity,
+		SchemaHash: hash,
+	}, nil
+}
+
+// GetSchemaHash spares the clients caching the stream from fetching it to tell whether it's changed
+func (rs *streamRegistryServer) GetSchemaHash(ctx context.Context,
+	req *databasev1.StreamRegistryServiceGetSchemaHashRequest) (*databasev1.StreamRegistryServiceGetSchemaHashResponse, error) {
+	entity, err := rs.schemaRegistry.StreamRegistry().GetStream(ctx, req.GetMetadata())
+	if err != nil {
+		return nil, registryError(err)
+	}
+	hash, err := schema.Hash(entity)
+	if err != nil {
+		return nil, registryError(err)
+	}
+	return &databasev1.StreamRegistryServiceGetSchemaHashResponse{
+		SchemaHash: hash,
 	}, nil
 }
 
 func (rs *streamRegistryServer) List(ctx context.Context,
 	req *databasev1.StreamRegistryServiceListRequest) (*databasev1.StreamRegistryServiceListResponse, error) {
-	entities, err := rs.schemaRegistry.StreamRegistry().ListStream(ctx, schema.ListOpt{Group: req.GetGroup()})
+	entities, _, err := rs.schemaRegistry.StreamRegistry().ListStream(ctx, schema.ListOpt{Group: req.GetGroup()})
 	if err != nil {
-		return nil, err
+		return nil, registryError(err)
 	}
 	return &databasev1.StreamRegistryServiceListResponse{
 		Stream: entities,
@@ -87,8 +145,14 @@ type indexRuleBindingRegistryServer struct {
 func (rs *indexRuleBindingRegistryServer) Create(ctx context.Context,
 	req *databasev1.IndexRuleBindingRegistryServiceCreateRequest) (
 	*databasev1.IndexRuleBindingRegistryServiceCreateResponse, error) {
-	if err := rs.schemaRegistry.IndexRuleBindingRegistry().UpdateIndexRuleBinding(ctx, req.GetIndexRuleBinding()); err != nil {
-		return nil, err
+	var err error
+	if req.GetDryRun() {
+		err = rs.schemaRegistry.IndexRuleBindingRegistry().ValidateIndexRuleBinding(ctx, req.GetIndexRuleBinding(), schema.ValidateOpt{Create: true})
+	} else {
+		err = rs.schemaRegistry.IndexRuleBindingRegistry().CreateIndexRuleBinding(ctx, req.GetIndexRuleBinding())
+	}
+	if err != nil {
+		return nil, registryError(err)
 	}
 	return &databasev1.IndexRuleBindingRegistryServiceCreateResponse{}, nil
 }
@@ -96,8 +160,14 @@ func (rs *indexRuleBindingRegistryServer) Create(ctx context.Context,
 func (rs *indexRuleBindingRegistryServer) Update(ctx context.Context,
 	req *databasev1.IndexRuleBindingRegistryServiceUpdateRequest) (
 	*databasev1.IndexRuleBindingRegistryServiceUpdateResponse, error) {
-	if err := rs.schemaRegistry.IndexRuleBindingRegistry().UpdateIndexRuleBinding(ctx, req.GetIndexRuleBinding()); err != nil {
-		return nil, err
+	var err error
+	if req.GetDryRun() {
+		err = rs.schemaRegistry.IndexRuleBindingRegistry().ValidateIndexRuleBinding(ctx, req.GetIndexRuleBinding(), schema.ValidateOpt{})
+	} else {
+		err = rs.schemaRegistry.IndexRuleBindingRegistry().UpdateIndexRuleBinding(ctx, req.GetIndexRuleBinding())
+	}
+	if err != nil {
+		return nil, registryError(err)
 	}
 	return &databasev1.IndexRuleBindingRegistryServiceUpdateResponse{}, nil
 }
@@ -107,7 +177,7 @@ func (rs *indexRuleBindingRegistryServer) Delete(ctx context.Context,
 	*databasev1.IndexRuleBindingRegistryServiceDeleteResponse, error) {
 	ok, err := rs.schemaRegistry.IndexRuleBindingRegistry().DeleteIndexRuleBinding(ctx, req.GetMetadata())
 	if err != nil {
-		return nil, err
+		return nil, registryError(err)
 	}
 	return &databasev1.IndexRuleBindingRegistryServiceDeleteResponse{
 		Deleted: ok,
@@ -119,7 +189,7 @@ func (rs *indexRuleBindingRegistryServer) Get(ctx context.Context,
 	*databasev1.IndexRuleBindingRegistryServiceGetResponse, error) {
 	entity, err := rs.schemaRegistry.IndexRuleBindingRegistry().GetIndexRuleBinding(ctx, req.GetMetadata())
 	if err != nil {
-		return nil, err
+		return nil, registryError(err)
 	}
 	return &databasev1.IndexRuleBindingRegistryServiceGetResponse{
 		IndexRuleBinding: entity,
@@ -129,10 +199,10 @@ func (rs *indexRuleBindingRegistryServer) Get(ctx context.Context,
 func (rs *indexRuleBindingRegistryServer) List(ctx context.Context,
 	req *databasev1.IndexRuleBindingRegistryServiceListRequest) (
 	*databasev1.IndexRuleBindingRegistryServiceListResponse, error) {
-	entities, err := rs.schemaRegistry.IndexRuleBindingRegistry().
+	entities, _, err := rs.schemaRegistry.IndexRuleBindingRegistry().
 		ListIndexRuleBinding(ctx, schema.ListOpt{Group: req.GetGroup()})
 	if err != nil {
-		return nil, err
+		return nil, registryError(err)
 	}
 	return &databasev1.IndexRuleBindingRegistryServiceListResponse{
 		IndexRuleBinding: entities,
@@ -146,16 +216,28 @@ type indexRuleRegistryServer struct {
 
 func (rs *indexRuleRegistryServer) Create(ctx context.Context, req *databasev1.IndexRuleRegistryServiceCreateRequest) (
 	*databasev1.IndexRuleRegistryServiceCreateResponse, error) {
-	if err := rs.schemaRegistry.IndexRuleRegistry().UpdateIndexRule(ctx, req.GetIndexRule()); err != nil {
-		return nil, err
+	var err error
+	if req.GetDryRun() {
+		err = rs.schemaRegistry.IndexRuleRegistry().ValidateIndexRule(ctx, req.GetIndexRule(), schema.ValidateOpt{Create: true})
+	} else {
+		err = rs.schemaRegistry.IndexRuleRegistry().CreateIndexRule(ctx, req.GetIndexRule())
+	}
+	if err != nil {
+		return nil, registryError(err)
 	}
 	return &databasev1.IndexRuleRegistryServiceCreateResponse{}, nil
 }
 
 func (rs *indexRuleRegistryServer) Update(ctx context.Context, req *databasev1.IndexRuleRegistryServiceUpdateRequest) (
 	*databasev1.IndexRuleRegistryServiceUpdateResponse, error) {
-	if err := rs.schemaRegistry.IndexRuleRegistry().UpdateIndexRule(ctx, req.GetIndexRule()); err != nil {
-		return nil, err
+	var err error
+	if req.GetDryRun() {
+		err = rs.schemaRegistry.IndexRuleRegistry().ValidateIndexRule(ctx, req.GetIndexRule(), schema.ValidateOpt{})
+	} else {
+		err = rs.schemaRegistry.IndexRuleRegistry().UpdateIndexRule(ctx, req.GetIndexRule())
+	}
+	if err != nil {
+		return nil, registryError(err)
 	}
 	return &databasev1.IndexRuleRegistryServiceUpdateResponse{}, nil
 }
@@ -164,7 +246,7 @@ func (rs *indexRuleRegistryServer) Delete(ctx context.Context, req *databasev1.I
 	*databasev1.IndexRuleRegistryServiceDeleteResponse, error) {
 	ok, err := rs.schemaRegistry.IndexRuleRegistry().DeleteIndexRule(ctx, req.GetMetadata())
 	if err != nil {
-		return nil, err
+		return nil, registryError(err)
 	}
 	return &databasev1.IndexRuleRegistryServiceDeleteResponse{
 		Deleted: ok,
@@ -175,7 +257,7 @@ func (rs *indexRuleRegistryServer) Get(ctx context.Context, req *databasev1.Inde
 	*databasev1.IndexRuleRegistryServiceGetResponse, error) {
 	entity, err := rs.schemaRegistry.IndexRuleRegistry().GetIndexRule(ctx, req.GetMetadata())
 	if err != nil {
-		return nil, err
+		return nil, registryError(err)
 	}
 	return &databasev1.IndexRuleRegistryServiceGetResponse{
 		IndexRule: entity,
@@ -184,9 +266,9 @@ func (rs *indexRuleRegistryServer) Get(ctx context.Context, req *databasev1.Inde
 
 func (rs *indexRuleRegistryServer) List(ctx context.Context, req *databasev1.IndexRuleRegistryServiceListRequest) (
 	*databasev1.IndexRuleRegistryServiceListResponse, error) {
-	entities, err := rs.schemaRegistry.IndexRuleRegistry().ListIndexRule(ctx, schema.ListOpt{Group: req.GetGroup()})
+	entities, _, err := rs.schemaRegistry.IndexRuleRegistry().ListIndexRule(ctx, schema.ListOpt{Group: req.GetGroup()})
 	if err != nil {
-		return nil, err
+		return nil, registryError(err)
 	}
 	return &databasev1.IndexRuleRegistryServiceListResponse{
 		IndexRule: entities,
@@ -200,25 +282,37 @@ type measureRegistryServer struct {
 
 func (rs *measureRegistryServer) Create(ctx context.Context, req *databasev1.MeasureRegistryServiceCreateRequest) (
 	*databasev1.MeasureRegistryServiceCreateResponse, error) {
-	if err := rs.schemaRegistry.MeasureRegistry().UpdateMeasure(ctx, req.GetMeasure()); err != nil {
-		return nil, err
+	var err error
+	if req.GetDryRun() {
+		err = rs.schemaRegistry.MeasureRegistry().ValidateMeasure(ctx, req.GetMeasure(), schema.ValidateOpt{Create: true})
+	} else {
+		err = rs.schemaRegistry.MeasureRegistry().CreateMeasure(ctx, req.GetMeasure())
+	}
+	if err != nil {
+		return nil, registryError(err)
 	}
 	return &databasev1.MeasureRegistryServiceCreateResponse{}, nil
 }
 
 func (rs *measureRegistryServer) Update(ctx context.Context, req *databasev1.MeasureRegistryServiceUpdateRequest) (
 	*databasev1.MeasureRegistryServiceUpdateResponse, error) {
-	if err := rs.schemaRegistry.MeasureRegistry().UpdateMeasure(ctx, req.GetMeasure()); err != nil {
-		return nil, err
+	var err error
+	if req.GetDryRun() {
+		err = rs.schemaRegistry.MeasureRegistry().ValidateMeasure(ctx, req.GetMeasure(), schema.ValidateOpt{})
+	} else {
+		err = rs.schemaRegistry.MeasureRegistry().UpdateMeasure(ctx, req.GetMeasure())
+	}
+	if err != nil {
+		return nil, registryError(err)
 	}
 	return &databasev1.MeasureRegistryServiceUpdateResponse{}, nil
 }
 
 func (rs *measureRegistryServer) Delete(ctx context.Context, req *databasev1.MeasureRegistryServiceDeleteRequest) (
 	*databasev1.MeasureRegistryServiceDeleteResponse, error) {
-	ok, err := rs.schemaRegistry.MeasureRegistry().DeleteMeasure(ctx, req.GetMetadata())
+	ok, err := rs.schemaRegistry.MeasureRegistry().DeleteMeasure(ctx, req.GetMetadata(), schema.DeleteOpt{Cascade: req.GetCascade()})
 	if err != nil {
-		return nil, err
+		return nil, registryError(err)
 	}
 	return &databasev1.MeasureRegistryServiceDeleteResponse{
 		Deleted: ok,
@@ -229,18 +323,39 @@ func (rs *measureRegistryServer) Get(ctx context.Context, req *databasev1.Measur
 	*databasev1.MeasureRegistryServiceGetResponse, error) {
 	entity, err := rs.schemaRegistry.MeasureRegistry().GetMeasure(ctx, req.GetMetadata())
 	if err != nil {
-		return nil, err
+		return nil, registryError(err)
+	}
+	hash, err := schema.Hash(entity)
+	if err != nil {
+		return nil, registryError(err)
 	}
 	return &databasev1.MeasureRegistryServiceGetResponse{
-		Measure: entity,
+		Measure:    entity,
+		SchemaHash: hash,
+	}, nil
+}
+
+// GetSchemaHash spares the clients caching the measure from fetching it to tell whether it's changed
+func (rs *measureRegistryServer) GetSchemaHash(ctx context.Context, req *databasev1.MeasureRegistryServiceGetSchemaHashRequest) (
+	*databasev1.MeasureRegistryServiceGetSchemaHashResponse, error) {
+	entity, err := rs.schemaRegistry.MeasureRegistry().GetMeasure(ctx, req.GetMetadata())
+	if err != nil {
+		return nil, registryError(err)
+	}
+	hash, err := schema.Hash(entity)
+	if err != nil {
+		return nil, registryError(err)
+	}
+	return &databasev1.MeasureRegistryServiceGetSchemaHashResponse{
+		SchemaHash: hash,
 	}, nil
 }
 
 func (rs *measureRegistryServer) List(ctx context.Context, req *databasev1.MeasureRegistryServiceListRequest) (
 	*databasev1.MeasureRegistryServiceListResponse, error) {
-	entities, err := rs.schemaRegistry.MeasureRegistry().ListMeasure(ctx, schema.ListOpt{Group: req.GetGroup()})
+	entities, _, err := rs.schemaRegistry.MeasureRegistry().ListMeasure(ctx, schema.ListOpt{Group: req.GetGroup()})
 	if err != nil {
-		return nil, err
+		return nil, registryError(err)
 	}
 	return &databasev1.MeasureRegistryServiceListResponse{
 		Measure: entities,
@@ -255,7 +370,7 @@ type groupRegistryServer struct {
 func (rs *groupRegistryServer) Create(ctx context.Context, req *databasev1.GroupRegistryServiceCreateRequest) (
 	*databasev1.GroupRegistryServiceCreateResponse, error) {
 	if err := rs.schemaRegistry.GroupRegistry().CreateGroup(ctx, req.GetGroup()); err != nil {
-		return nil, err
+		return nil, registryError(err)
 	}
 	return &databasev1.GroupRegistryServiceCreateResponse{}, nil
 }
@@ -264,7 +379,7 @@ func (rs *groupRegistryServer) Delete(ctx context.Context, req *databasev1.Group
 	*databasev1.GroupRegistryServiceDeleteResponse, error) {
 	deleted, err := rs.schemaRegistry.GroupRegistry().DeleteGroup(ctx, req.GetGroup())
 	if err != nil {
-		return nil, err
+		return nil, registryError(err)
 	}
 	return &databasev1.GroupRegistryServiceDeleteResponse{
 		Deleted: deleted,
@@ -275,7 +390,7 @@ func (rs *groupRegistryServer) Get(ctx context.Context, req *databasev1.GroupReg
 	*databasev1.GroupRegistryServiceExistResponse, error) {
 	g, err := rs.schemaRegistry.GroupRegistry().GetGroup(ctx, req.GetGroup())
 	if err != nil {
-		return nil, err
+		return nil, registryError(err)
 	}
 	return &databasev1.GroupRegistryServiceExistResponse{
 		Group: g,
@@ -286,7 +401,7 @@ func (rs *groupRegistryServer) List(ctx context.Context, req *databasev1.GroupRe
 	*databasev1.GroupRegistryServiceListResponse, error) {
 	groups, err := rs.schemaRegistry.GroupRegistry().ListGroup(ctx)
 	if err != nil {
-		return nil, err
+		return nil, registryError(err)
 	}
 	return &databasev1.GroupRegistryServiceListResponse{
 		Group: groups,
@@ -23,11 +23,12 @@ import (
 
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 
 	commonv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/common/v1"
 	databasev1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/database/v1"
-	"github.com/apache/skywalking-banyandb/banyand/metadata/schema"
 )
 
 func TestStreamRegistry(t *testing.T) {
@@ -55,9 +56,14 @@ func TestStreamRegistry(t *testing.T) {
 	req.NoError(err)
 	req.NotNil(getResp)
 
-	// 2 - DELETE
+	// 2 - DELETE, which takes the binding of the stream along
+	_, err = client.Delete(context.TODO(), &databasev1.StreamRegistryServiceDeleteRequest{
+		Metadata: meta,
+	})
+	req.Equal(codes.FailedPrecondition, status.Code(err))
 	deleteResp, err := client.Delete(context.TODO(), &databasev1.StreamRegistryServiceDeleteRequest{
 		Metadata: meta,
+		Cascade:  true,
 	})
 	req.NoError(err)
 	req.NotNil(deleteResp)
@@ -67,12 +73,33 @@ func TestStreamRegistry(t *testing.T) {
 	_, err = client.Get(context.TODO(), &databasev1.StreamRegistryServiceGetRequest{
 		Metadata: meta,
 	})
-	errStatus, _ := status.FromError(err)
-	req.Equal(errStatus.Message(), schema.ErrEntityNotFound.Error())
+	req.Equal(codes.NotFound, status.Code(err))
+
+	// the dry run of the creation persists nothing
+	_, err = client.Create(context.TODO(), &databasev1.StreamRegistryServiceCreateRequest{Stream: getResp.GetStream(), DryRun: true})
+	req.NoError(err)
+	_, err = client.Get(context.TODO(), &databasev1.StreamRegistryServiceGetRequest{
+		Metadata: meta,
+	})
+	req.Equal(codes.NotFound, status.Code(err))
+
+	// the entity referring to an unknown tag is rejected
+	invalid := proto.Clone(getResp.GetStream()).(*databasev1.Stream)
+	invalid.Entity.TagNames = append(invalid.Entity.TagNames, "absent")
+	_, err = client.Create(context.TODO(), &databasev1.StreamRegistryServiceCreateRequest{Stream: invalid})
+	req.Equal(codes.InvalidArgument, status.Code(err))
 
 	// 4 - CREATE
 	_, err = client.Create(context.TODO(), &databasev1.StreamRegistryServiceCreateRequest{Stream: getResp.GetStream()})
 	req.NoError(err)
+	// re-creating the stream doesn't overwrite it, while the identical retry succeeds
+	_, err = client.Create(context.TODO(), &databasev1.StreamRegistryServiceCreateRequest{Stream: getResp.GetStream()})
+	req.NoError(err)
+	different := proto.Clone(getResp.GetStream()).(*databasev1.Stream)
+	different.TagFamilies[0].Tags = append(different.TagFamilies[0].Tags,
+		&databasev1.TagSpec{Name: "extra", Type: databasev1.TagType_TAG_TYPE_STRING})
+	_, err = client.Create(context.TODO(), &databasev1.StreamRegistryServiceCreateRequest{Stream: different})
+	req.Equal(codes.AlreadyExists, status.Code(err))
 
 	// 5 - GET - > Not Nil
 	getResp, err = client.Get(context.TODO(), &databasev1.StreamRegistryServiceGetRequest{
@@ -80,6 +107,48 @@ func TestStreamRegistry(t *testing.T) {
 	})
 	req.NoError(err)
 	req.NotNil(getResp)
+
+	// the dry run of the update fails as the update would do
+	stale := getResp.GetStream()
+	stale.Metadata.ModRevision--
+	_, err = client.Update(context.TODO(), &databasev1.StreamRegistryServiceUpdateRequest{Stream: stale, DryRun: true})
+	req.Equal(codes.Aborted, status.Code(err))
+}
+
+func TestIndexRuleBindingRegistry_CreateRetry(t *testing.T) {
+	req := require.New(t)
+	gracefulStop := setup(req, testData{
+		TLS:  false,
+		addr: "localhost:17912",
+	})
+	defer gracefulStop()
+
+	conn, err := grpc.Dial("localhost:17912", grpc.WithInsecure())
+	req.NoError(err)
+	req.NotNil(conn)
+
+	client := databasev1.NewIndexRuleBindingRegistryServiceClient(conn)
+	getResp, err := client.Get(context.TODO(), &databasev1.IndexRuleBindingRegistryServiceGetRequest{
+		Metadata: &commonv1.Metadata{
+			Group: "default",
+			Name:  "sw-index-rule-binding",
+		},
+	})
+	req.NoError(err)
+
+	// identical retry
+	_, err = client.Create(context.TODO(), &databasev1.IndexRuleBindingRegistryServiceCreateRequest{
+		IndexRuleBinding: getResp.GetIndexRuleBinding(),
+	})
+	req.NoError(err)
+
+	// conflicting retry
+	conflicting := proto.Clone(getResp.GetIndexRuleBinding()).(*databasev1.IndexRuleBinding)
+	conflicting.Rules = conflicting.Rules[1:]
+	_, err = client.Create(context.TODO(), &databasev1.IndexRuleBindingRegistryServiceCreateRequest{
+		IndexRuleBinding: conflicting,
+	})
+	req.Equal(codes.AlreadyExists, status.Code(err))
 }
 
 func TestIndexRuleBindingRegistry(t *testing.T) {
@@ -119,8 +188,7 @@ func TestIndexRuleBindingRegistry(t *testing.T) {
 	_, err = client.Get(context.TODO(), &databasev1.IndexRuleBindingRegistryServiceGetRequest{
 		Metadata: meta,
 	})
-	errStatus, _ := status.FromError(err)
-	req.Equal(errStatus.Message(), schema.ErrEntityNotFound.Error())
+	req.Equal(codes.NotFound, status.Code(err))
 
 	// 4 - CREATE
 	_, err = client.Create(context.TODO(), &databasev1.IndexRuleBindingRegistryServiceCreateRequest{IndexRuleBinding: getResp.GetIndexRuleBinding()})
@@ -171,8 +239,7 @@ func TestIndexRuleRegistry(t *testing.T) {
 	_, err = client.Get(context.TODO(), &databasev1.IndexRuleRegistryServiceGetRequest{
 		Metadata: meta,
 	})
-	errStatus, _ := status.FromError(err)
-	req.Equal(errStatus.Message(), schema.ErrEntityNotFound.Error())
+	req.Equal(codes.NotFound, status.Code(err))
 
 	// 4 - CREATE
 	_, err = client.Create(context.TODO(), &databasev1.IndexRuleRegistryServiceCreateRequest{IndexRule: getResp.GetIndexRule()})
@@ -185,3 +252,101 @@ func TestIndexRuleRegistry(t *testing.T) {
 	req.NoError(err)
 	req.NotNil(getResp)
 }
+
+func TestMeasureRegistry(t *testing.T) {
+	req := require.New(t)
+	gracefulStop := setup(req, testData{
+		TLS:  false,
+		addr: "localhost:17912",
+	})
+	defer gracefulStop()
+
+	// the server might not be listening yet once the setup returns
+	conn, err := grpc.Dial("localhost:17912", grpc.WithInsecure())
+	req.NoError(err)
+	req.NotNil(conn)
+
+	client := databasev1.NewMeasureRegistryServiceClient(conn)
+	req.NotNil(client)
+
+	meta := &commonv1.Metadata{
+		Group: "default",
+		Name:  "cpm",
+	}
+	measure := &databasev1.Measure{
+		Metadata: meta,
+		TagFamilies: []*databasev1.TagFamilySpec{{
+			Name: "default",
+			Tags: []*databasev1.TagSpec{
+				{Name: "entity_id", Type: databasev1.TagType_TAG_TYPE_STRING},
+				{Name: "scope", Type: databasev1.TagType_TAG_TYPE_STRING},
+			},
+		}},
+		Fields: []*databasev1.FieldSpec{{
+			Name:              "value",
+			FieldType:         databasev1.FieldType_FIELD_TYPE_INT,
+			EncodingMethod:    databasev1.EncodingMethod_ENCODING_METHOD_GORILLA,
+			CompressionMethod: databasev1.CompressionMethod_COMPRESSION_METHOD_ZSTD,
+		}},
+		Entity: &databasev1.Entity{TagNames: []string{"entity_id"}},
+	}
+
+	// 1 - GET -> Nil
+	_, err = client.Get(context.TODO(), &databasev1.MeasureRegistryServiceGetRequest{Metadata: meta})
+	req.Equal(codes.NotFound, status.Code(err))
+
+	// the dry run of the creation persists nothing
+	_, err = client.Create(context.TODO(), &databasev1.MeasureRegistryServiceCreateRequest{Measure: measure, DryRun: true})
+	req.NoError(err)
+	_, err = client.Get(context.TODO(), &databasev1.MeasureRegistryServiceGetRequest{Metadata: meta})
+	req.Equal(codes.NotFound, status.Code(err))
+
+	// the entity referring to an unknown tag is rejected
+	invalid := proto.Clone(measure).(*databasev1.Measure)
+	invalid.Entity.TagNames = append(invalid.Entity.TagNames, "absent")
+	_, err = client.Create(context.TODO(), &databasev1.MeasureRegistryServiceCreateRequest{Measure: invalid})
+	req.Equal(codes.InvalidArgument, status.Code(err))
+
+	// 2 - CREATE
+	_, err = client.Create(context.TODO(), &databasev1.MeasureRegistryServiceCreateRequest{Measure: measure})
+	req.NoError(err)
+	// re-creating the measure doesn't overwrite it, while the identical retry succeeds
+	_, err = client.Create(context.TODO(), &databasev1.MeasureRegistryServiceCreateRequest{Measure: measure})
+	req.NoError(err)
+	different := proto.Clone(measure).(*databasev1.Measure)
+	different.Fields[0].Name = "summation"
+	_, err = client.Create(context.TODO(), &databasev1.MeasureRegistryServiceCreateRequest{Measure: different})
+	req.Equal(codes.AlreadyExists, status.Code(err))
+
+	// 3 - GET -> Not Nil
+	getResp, err := client.Get(context.TODO(), &databasev1.MeasureRegistryServiceGetRequest{Metadata: meta})
+	req.NoError(err)
+	req.NotNil(getResp)
+	req.Equal("value", getResp.GetMeasure().GetFields()[0].GetName())
+	hashResp, err := client.GetSchemaHash(context.TODO(), &databasev1.MeasureRegistryServiceGetSchemaHashRequest{Metadata: meta})
+	req.NoError(err)
+	req.Equal(getResp.GetSchemaHash(), hashResp.GetSchemaHash())
+
+	// 4 - LIST
+	listResp, err := client.List(context.TODO(), &databasev1.MeasureRegistryServiceListRequest{Group: "default"})
+	req.NoError(err)
+	req.Len(listResp.GetMeasure(), 1)
+
+	// 5 - UPDATE, which fails if the revision is stale
+	updated := getResp.GetMeasure()
+	updated.Fields = append(updated.Fields, different.Fields[0])
+	_, err = client.Update(context.TODO(), &databasev1.MeasureRegistryServiceUpdateRequest{Measure: updated})
+	req.NoError(err)
+	_, err = client.Update(context.TODO(), &databasev1.MeasureRegistryServiceUpdateRequest{Measure: updated})
+	req.Equal(codes.Aborted, status.Code(err))
+	hashResp, err = client.GetSchemaHash(context.TODO(), &databasev1.MeasureRegistryServiceGetSchemaHashRequest{Metadata: meta})
+	req.NoError(err)
+	req.NotEqual(getResp.GetSchemaHash(), hashResp.GetSchemaHash())
+
+	// 6 - DELETE
+	deleteResp, err := client.Delete(context.TODO(), &databasev1.MeasureRegistryServiceDeleteRequest{Metadata: meta})
+	req.NoError(err)
+	req.True(deleteResp.GetDeleted())
+	_, err = client.Get(context.TODO(), &databasev1.MeasureRegistryServiceGetRequest{Metadata: meta})
+	req.Equal(codes.NotFound, status.Code(err))
+}
@@ -20,10 +20,14 @@ package grpc
 import (
 	"context"
 	"net"
+	"net/http"
+	"time"
 
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	grpclib "google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 
 	"github.com/apache/skywalking-banyandb/api/event"
 	databasev1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/database/v1"
@@ -36,18 +40,28 @@ import (
 	"github.com/apache/skywalking-banyandb/pkg/run"
 )
 
-const defaultRecvSize = 1024 * 1024 * 10
+const (
+	defaultRecvSize          = 1024 * 1024 * 10
+	defaultMaxTagsPerElement = 1024
+	defaultMaxTagValueBytes  = 1024 * 1024
+)
 
 var (
-	ErrServerCert = errors.New("invalid server cert file")
-	ErrServerKey  = errors.New("invalid server key file")
-	ErrNoAddr     = errors.New("no address")
-	ErrQueryMsg   = errors.New("invalid query message")
+	ErrServerCert    = errors.New("invalid server cert file")
+	ErrServerKey     = errors.New("invalid server key file")
+	ErrNoAddr        = errors.New("no address")
+	ErrQueryMsg      = errors.New("invalid query message")
+	ErrElementLimits = errors.New("element limits should be positive")
+	ErrQuotaInterval = errors.New("group-quota-reload-interval should be positive")
+	ErrMaintenance   = errors.New("maintenance-timeout should be positive")
+	ErrConnLimits    = errors.New("max-concurrent-connections and max-concurrent-streams shouldn't be negative")
+	ErrShutdown      = errors.New("shutdown-timeout should be positive")
 )
 
 type Server struct {
 	addr           string
 	maxRecvMsgSize int
+	elementLimits  elementLimits
 	tls            bool
 	certFile       string
 	keyFile        string
@@ -58,6 +72,24 @@ type Server struct {
 	creds          credentials.TransportCredentials
 	shardRepo      *shardRepo
 	entityRepo     *entityRepo
+	quotaFile      string
+	quotaInterval  time.Duration
+	quota          *quotaLimiter
+	adminToken     string
+	// maintenanceTimeout bounds an admin maintenance RPC without a deadline
+	maintenanceTimeout time.Duration
+	writePauses        *writePauses
+	// maxConns and maxStreams are unlimited if they're zero
+	maxConns   int
+	maxStreams int
+	// metricsAddr serves the metrics at /metrics unless it's empty
+	metricsAddr string
+	metricsSer  *http.Server
+	// shutdownTimeout bounds the graceful stop, after which the remaining streams are closed by force
+	shutdownTimeout time.Duration
+	streams         *streamTracker
+	ready           *readiness
+	topology        *topology
 	*streamRegistryServer
 	*indexRuleBindingRegistryServer
 	*indexRuleRegistryServer
@@ -67,11 +99,27 @@ type Server struct {
 }
 
 func NewServer(_ context.Context, pipeline queue.Queue, repo discovery.ServiceRepo, schemaRegistry metadata.Service) *Server {
+	ready := newReadiness()
+	topo := newTopology()
 	return &Server{
-		pipeline:   pipeline,
-		repo:       repo,
-		shardRepo:  &shardRepo{shardEventsMap: make(map[identity]uint32)},
-		entityRepo: &entityRepo{entitiesMap: make(map[identity]partition.EntityLocator)},
+		pipeline: pipeline,
+		repo:     repo,
+		elementLimits: elementLimits{
+			maxTags:       defaultMaxTagsPerElement,
+			maxValueBytes: defaultMaxTagValueBytes,
+		},
+		writePauses: newWritePauses(),
+		streams:     &streamTracker{},
+		ready:       ready,
+		topology:    topo,
+		shardRepo:   &shardRepo{ready: ready, topology: topo, shardEventsMap: make(map[identity]uint32)},
+		entityRepo: &entityRepo{
+			ready:         ready,
+			topology:      topo,
+			entitiesMap:   make(map[identity]partition.EntityLocator),
+			overridesMap:  make(map[identity]partition.ShardOverrides),
+			strategiesMap: make(map[identity]partition.ShardingStrategy),
+		},
 		streamRegistryServer: &streamRegistryServer{
 			schemaRegistry: schemaRegistry,
 		},
@@ -94,11 +142,16 @@ func (s *Server) PreRun() error {
 	s.log = logger.GetLogger("liaison-grpc")
 	s.shardRepo.log = s.log
 	s.entityRepo.log = s.log
+	s.quota = newQuotaLimiter(s.log)
 	err := s.repo.Subscribe(event.StreamTopicShardEvent, s.shardRepo)
 	if err != nil {
 		return err
 	}
-	return s.repo.Subscribe(event.StreamTopicEntityEvent, s.entityRepo)
+	if err = s.repo.Subscribe(event.StreamTopicEntityEvent, s.entityRepo); err != nil {
+		return err
+	}
+	s.ready.markSubscribed()
+	return nil
 }
 
 func (s *Server) Name() string {
@@ -112,6 +165,26 @@ func (s *Server) FlagSet() *run.FlagSet {
 	fs.StringVarP(&s.certFile, "cert-file", "", "", "The TLS cert file")
 	fs.StringVarP(&s.keyFile, "key-file", "", "", "The TLS key file")
 	fs.StringVarP(&s.addr, "addr", "", ":17912", "The address of banyand listens")
+	fs.IntVarP(&s.elementLimits.maxTags, "max-tags-per-element", "", defaultMaxTagsPerElement,
+		"The max number of tags in a written element")
+	fs.IntVarP(&s.elementLimits.maxValueBytes, "max-tag-value-bytes", "", defaultMaxTagValueBytes,
+		"The max size of a tag value in a written element")
+	fs.StringVarP(&s.quotaFile, "group-quota-file", "", "",
+		"The JSON file of the per-group qps and concurrency quotas, which is reloaded once modified")
+	fs.DurationVarP(&s.quotaInterval, "group-quota-reload-interval", "", defaultQuotaReloadInterval,
+		"The interval to check the modification of group-quota-file")
+	fs.StringVarP(&s.adminToken, "admin-token", "", "",
+		"The bearer token of the admin RPCs, which are disabled if it's empty")
+	fs.DurationVarP(&s.maintenanceTimeout, "maintenance-timeout", "", defaultMaintenanceTimeout,
+		"The default timeout of a maintenance RPC")
+	fs.IntVarP(&s.maxConns, "max-concurrent-connections", "", 0,
+		"The max number of the client connections, beyond which the new ones are rejected. Zero means unlimited")
+	fs.IntVarP(&s.maxStreams, "max-concurrent-streams", "", 0,
+		"The max number of the concurrent streams in a connection. Zero means unlimited")
+	fs.StringVarP(&s.metricsAddr, "metrics-addr", "", "",
+		"The address serving the Prometheus metrics at /metrics. Empty means disabled")
+	fs.DurationVarP(&s.shutdownTimeout, "shutdown-timeout", "", defaultShutdownTimeout,
+		"The timeout of draining the requests on stopping, after which the remaining streams are closed by force")
 	return fs
 }
 
@@ -119,6 +192,21 @@ func (s *Server) Validate() error {
 	if s.addr == "" {
 		return ErrNoAddr
 	}
+	if s.elementLimits.maxTags < 1 || s.elementLimits.maxValueBytes < 1 {
+		return ErrElementLimits
+	}
+	if s.quotaFile != "" && s.quotaInterval <= 0 {
+		return ErrQuotaInterval
+	}
+	if s.maintenanceTimeout <= 0 {
+		return ErrMaintenance
+	}
+	if s.maxConns < 0 || s.maxStreams < 0 {
+		return ErrConnLimits
+	}
+	if s.shutdownTimeout <= 0 {
+		return ErrShutdown
+	}
 	if !s.tls {
 		return nil
 	}
@@ -149,6 +237,27 @@ func (s *Server) Serve() error {
 		opts = []grpclib.ServerOption{grpclib.Creds(s.creds)}
 	}
 	opts = append(opts, grpclib.MaxRecvMsgSize(s.maxRecvMsgSize))
+	if s.maxStreams > 0 {
+		opts = append(opts, grpclib.MaxConcurrentStreams(uint32(s.maxStreams)))
+	}
+	// the metrics cover the RPCs rejected by the quotas
+	unaryInterceptors := []grpclib.UnaryServerInterceptor{traceUnaryInterceptor(), metricsUnaryInterceptor()}
+	streamInterceptors := []grpclib.StreamServerInterceptor{
+		traceStreamInterceptor(), metricsStreamInterceptor(),
+		s.streams.streamInterceptor(),
+	}
+	if s.quotaFile != "" {
+		if errQuota := s.quota.load(s.quotaFile); errQuota != nil {
+			s.log.Fatal().Err(errQuota).Msg("Failed to load group quotas")
+		}
+		s.quota.watch(s.quotaFile, s.quotaInterval)
+		unaryInterceptors = append(unaryInterceptors, s.quota.unaryInterceptor())
+		streamInterceptors = append(streamInterceptors, s.quota.streamInterceptor())
+	}
+	opts = append(opts,
+		grpclib.ChainUnaryInterceptor(unaryInterceptors...),
+		grpclib.ChainStreamInterceptor(streamInterceptors...),
+	)
 	s.ser = grpclib.NewServer(opts...)
 	streamv1.RegisterStreamServiceServer(s.ser, s)
 	// register *Registry
@@ -157,12 +266,37 @@ func (s *Server) Serve() error {
 	databasev1.RegisterIndexRuleRegistryServiceServer(s.ser, s.indexRuleRegistryServer)
 	databasev1.RegisterStreamRegistryServiceServer(s.ser, s.streamRegistryServer)
 	databasev1.RegisterMeasureRegistryServiceServer(s.ser, s.measureRegistryServer)
+	healthpb.RegisterHealthServer(s.ser, s.ready.health)
 
+	if s.metricsAddr != "" {
+		s.serveMetrics()
+	}
 	s.log.Info().Str("addr", s.addr).Msg("Listening to")
-	return s.ser.Serve(lis)
+	return s.ser.Serve(newLimitListener(lis, s.maxConns, s.log))
+}
+
+func (s *Server) serveMetrics() {
+	lis, err := net.Listen("tcp", s.metricsAddr)
+	if err != nil {
+		s.log.Fatal().Err(err).Msg("Failed to listen to the metrics address")
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	s.metricsSer = &http.Server{Handler: mux}
+	s.log.Info().Str("addr", s.metricsAddr).Msg("Serving the metrics")
+	go func() {
+		if errServe := s.metricsSer.Serve(lis); errServe != nil && errServe != http.ErrServerClosed {
+			s.log.Error().Err(errServe).Msg("Failed to serve the metrics")
+		}
+	}()
 }
 
 func (s *Server) GracefulStop() {
 	s.log.Info().Msg("stopping")
-	s.ser.GracefulStop()
+	s.ready.shutdown()
+	s.quota.stop()
+	if s.metricsSer != nil {
+		_ = s.metricsSer.Close()
+	}
+	drain(s.ser, s.shutdownTimeout, s.streams, s.log)
 }
@@ -0,0 +1,66 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package grpc
+
+import (
+	"sync/atomic"
+	"time"
+
+	grpclib "google.golang.org/grpc"
+
+	"github.com/apache/skywalking-banyandb/pkg/logger"
+)
+
+const defaultShutdownTimeout = 30 * time.Second
+
+// streamTracker counts the streams being served, e.g. the writes held open by the clients
+type streamTracker struct {
+	active int64
+}
+
+func (t *streamTracker) streamInterceptor() grpclib.StreamServerInterceptor {
+	return func(srv interface{}, ss grpclib.ServerStream, _ *grpclib.StreamServerInfo,
+		handler grpclib.StreamHandler) error {
+		atomic.AddInt64(&t.active, 1)
+		defer atomic.AddInt64(&t.active, -1)
+		return handler(srv, ss)
+	}
+}
+
+func (t *streamTracker) count() int64 {
+	return atomic.LoadInt64(&t.active)
+}
+
+// drain stops ser gracefully, and closes the remaining streams by force once the timeout elapses
+func drain(ser *grpclib.Server, timeout time.Duration, tracker *streamTracker, log *logger.Logger) {
+	stopped := make(chan struct{})
+	go func() {
+		ser.GracefulStop()
+		close(stopped)
+	}()
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-stopped:
+	case <-timer.C:
+		log.Warn().Int64("streams", tracker.count()).Dur("timeout", timeout).
+			Msg("force to close the streams exceeding the shutdown timeout")
+		ser.Stop()
+		<-stopped
+	}
+}
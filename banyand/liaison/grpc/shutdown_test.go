@@ -0,0 +1,78 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package grpc
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	grpclib "google.golang.org/grpc"
+
+	streamv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/stream/v1"
+	"github.com/apache/skywalking-banyandb/pkg/logger"
+)
+
+// holdingStreamServer never finishes a write stream until the server closes it
+type holdingStreamServer struct {
+	streamv1.UnimplementedStreamServiceServer
+}
+
+func (holdingStreamServer) Write(stream streamv1.StreamService_WriteServer) error {
+	<-stream.Context().Done()
+	return stream.Context().Err()
+}
+
+func TestDrain(t *testing.T) {
+	req := require.New(t)
+	req.NoError(logger.Init(logger.Logging{
+		Env:   "dev",
+		Level: "warn",
+	}))
+	tracker := &streamTracker{}
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	req.NoError(err)
+	ser := grpclib.NewServer(grpclib.StreamInterceptor(tracker.streamInterceptor()))
+	streamv1.RegisterStreamServiceServer(ser, holdingStreamServer{})
+	go func() {
+		_ = ser.Serve(lis)
+	}()
+
+	conn, err := grpclib.Dial(lis.Addr().String(), grpclib.WithInsecure())
+	req.NoError(err)
+	defer conn.Close()
+	client := streamv1.NewStreamServiceClient(conn)
+	stream, err := client.Write(context.Background())
+	req.NoError(err)
+	req.NoError(stream.Send(&streamv1.WriteRequest{}))
+	req.Eventually(func() bool {
+		return tracker.count() == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// the stream held by the client is closed by force once the timeout elapses
+	start := time.Now()
+	drain(ser, 100*time.Millisecond, tracker, logger.GetLogger("test"))
+	req.Less(time.Since(start), 5*time.Second)
+	_, err = stream.Recv()
+	req.Error(err)
+	req.Eventually(func() bool {
+		return tracker.count() == 0
+	}, 5*time.Second, 10*time.Millisecond)
+}
@@ -22,10 +22,19 @@ import (
 	"io"
 	"time"
 
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/apache/skywalking-banyandb/api/common"
 	"github.com/apache/skywalking-banyandb/api/data"
 	streamv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/stream/v1"
+	"github.com/apache/skywalking-banyandb/banyand/queue"
 	"github.com/apache/skywalking-banyandb/banyand/tsdb"
 	"github.com/apache/skywalking-banyandb/pkg/bus"
+	"github.com/apache/skywalking-banyandb/pkg/partition"
+	"github.com/apache/skywalking-banyandb/pkg/tracing"
 )
 
 func (s *Server) Write(stream streamv1.StreamService_WriteServer) error {
@@ -37,35 +46,149 @@ func (s *Server) Write(stream streamv1.StreamService_WriteServer) error {
 		if err != nil {
 			return err
 		}
-		id := getID(writeEntity.GetMetadata())
-		shardNum, existed := s.shardRepo.shardNum(id)
-		if !existed {
+		// the oversized element is rejected alone, and the stream goes on with the rest
+		if errLimit := s.elementLimits.check(writeEntity.GetElement()); errLimit != nil {
+			s.log.Warn().Err(errLimit).Str("trace_id", tracing.TraceID(stream.Context())).Msg("reject the oversized element")
+			if errSend := reject(stream, errLimit); errSend != nil {
+				return errSend
+			}
 			continue
 		}
-		locator, existed := s.entityRepo.getLocator(id)
-		if !existed {
-			continue
+		id := getID(writeEntity.GetMetadata())
+		if s.writePauses.isPaused(id) {
+			return status.Errorf(codes.Unavailable, "the writes of %s/%s are paused", id.group, id.name)
 		}
-		entity, shardID, err := locator.Locate(writeEntity.GetElement().TagFamilies, shardNum)
+		// the element failing to find its shard is rejected alone as well
+		entity, shardID, err := s.locateElement(id, writeEntity.GetElement())
 		if err != nil {
-			s.log.Error().Err(err).Msg("failed to locate write target")
+			s.log.Error().Err(err).Str("trace_id", tracing.TraceID(stream.Context())).Msg("failed to locate write target")
+			if errSend := reject(stream, err); errSend != nil {
+				return errSend
+			}
 			continue
 		}
-		message := bus.NewMessage(bus.MessageID(time.Now().UnixNano()), &streamv1.InternalWriteRequest{
+		ctx, span := tracing.Start(stream.Context(), "stream.enqueue")
+		header := bus.Header{}
+		tracing.Inject(ctx, header)
+		message := bus.NewMessageWithHeader(bus.MessageID(time.Now().UnixNano()), &streamv1.InternalWriteRequest{
 			Request:    writeEntity,
 			ShardId:    uint32(shardID),
 			SeriesHash: tsdb.HashEntity(entity),
-		})
-		_, errWritePub := s.pipeline.Publish(data.TopicStreamWrite, message)
+		}, header)
+		ackLevel, errWritePub := s.publishWrite(writeEntity.GetAckLevel(), message)
 		if errWritePub != nil {
+			span.RecordError(errWritePub)
+			span.End()
 			return errWritePub
 		}
-		if errSend := stream.Send(&streamv1.WriteResponse{}); errSend != nil {
+		span.End()
+		if errSend := stream.Send(&streamv1.WriteResponse{AckLevel: ackLevel}); errSend != nil {
 			return errSend
 		}
 	}
 }
 
+// reject answers the element with the status of its rejection
+func reject(stream streamv1.StreamService_WriteServer, err error) error {
+	st := status.Convert(err)
+	return stream.Send(&streamv1.WriteResponse{
+		Code:    uint32(st.Code()),
+		Message: st.Message(),
+	})
+}
+
+// locateElement finds the shard and the entity of an element of the stream id. It fails with NotFound
+// if the shards or the entity of the stream are unknown, and with InvalidArgument if the element doesn't match the entity.
+func (s *Server) locateElement(id identity, element *streamv1.ElementValue) (tsdb.Entity, common.ShardID, error) {
+	shardNum, existed := s.shardRepo.shardNum(id)
+	if !existed {
+		return nil, 0, status.Errorf(codes.NotFound, "the shards of %s/%s are unknown", id.group, id.name)
+	}
+	locator, existed := s.entityRepo.getLocator(id)
+	if !existed {
+		return nil, 0, status.Errorf(codes.NotFound, "the entity of %s/%s is unknown", id.group, id.name)
+	}
+	entity, shardID, err := locate(id, locator, s.entityRepo.getOverrides(id), s.entityRepo.getStrategy(id),
+		element, shardNum)
+	if err != nil {
+		return nil, 0, status.Errorf(codes.InvalidArgument, "failed to locate the shard of the element %s: %v",
+			element.GetElementId(), err)
+	}
+	return entity, shardID, nil
+}
+
+// locate finds the shard and the entity of an element, and counts the failures
+func locate(id identity, locator partition.EntityLocator, overrides partition.ShardOverrides,
+	strategy partition.ShardingStrategy, element *streamv1.ElementValue, shardNum uint32) (tsdb.Entity, common.ShardID, error) {
+	entity, shardID, err := locator.Locate(element.GetTagFamilies(), shardNum, overrides, strategy)
+	if err != nil {
+		entityFindFailures.WithLabelValues(id.group, id.name, partition.FailureReason(err)).Inc()
+	}
+	return entity, shardID, err
+}
+
+// elementLimits prevents a single element from blowing up a block
+type elementLimits struct {
+	maxTags       int
+	maxValueBytes int
+}
+
+func (l elementLimits) check(element *streamv1.ElementValue) error {
+	var tagNum int
+	for _, family := range element.GetTagFamilies() {
+		tagNum += len(family.GetTags())
+		if tagNum > l.maxTags {
+			return status.Errorf(codes.InvalidArgument, "the element %s exceeds max-tags-per-element(%d)",
+				element.GetElementId(), l.maxTags)
+		}
+		for _, tag := range family.GetTags() {
+			if size := proto.Size(tag); size > l.maxValueBytes {
+				return status.Errorf(codes.InvalidArgument, "the element %s has a tag value of %d bytes, exceeding max-tag-value-bytes(%d)",
+					element.GetElementId(), size, l.maxValueBytes)
+			}
+		}
+	}
+	return nil
+}
+
+// publishWrite sends the write to the queue and waits until the ack level is satisfied.
+// The full queue fails the write with ResourceExhausted and the paused consumers with Unavailable,
+// and the client should retry it later.
+func (s *Server) publishWrite(ackLevel streamv1.AckLevel, message bus.Message) (streamv1.AckLevel, error) {
+	switch ackLevel {
+	case streamv1.AckLevel_ACK_LEVEL_NONE:
+		// the queue doesn't wait for the consumers, so the backpressure still reaches the client
+		_, err := s.pipeline.Publish(data.TopicStreamWrite, message)
+		return ackLevel, backpressure(err)
+	case streamv1.AckLevel_ACK_LEVEL_DURABLE:
+		feat, err := s.pipeline.Publish(data.TopicStreamDurableWrite, message)
+		if err != nil {
+			return ackLevel, backpressure(err)
+		}
+		msg, err := feat.Get()
+		if err != nil {
+			return ackLevel, err
+		}
+		if errWrite, ok := msg.Data().(error); ok {
+			return ackLevel, errWrite
+		}
+		return ackLevel, nil
+	}
+	_, err := s.pipeline.Publish(data.TopicStreamWrite, message)
+	return streamv1.AckLevel_ACK_LEVEL_QUEUED, backpressure(err)
+}
+
+// backpressure converts the full queue into ResourceExhausted, and the open circuit into Unavailable
+func backpressure(err error) error {
+	switch {
+	case errors.Is(err, queue.ErrQueueFull):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case errors.Is(err, queue.ErrCircuitOpen):
+		return status.Error(codes.Unavailable, err.Error())
+	}
+	return err
+}
+
 func (s *Server) Query(_ context.Context, entityCriteria *streamv1.QueryRequest) (*streamv1.QueryResponse, error) {
 	message := bus.NewMessage(bus.MessageID(time.Now().UnixNano()), entityCriteria)
 	feat, errQuery := s.pipeline.Publish(data.TopicStreamQuery, message)
@@ -76,6 +199,9 @@ func (s *Server) Query(_ context.Context, entityCriteria *streamv1.QueryRequest)
 	if errFeat != nil {
 		return nil, errFeat
 	}
+	if errQuery, ok := msg.Data().(error); ok {
+		return nil, errQuery
+	}
 	queryMsg, ok := msg.Data().([]*streamv1.Element)
 	if !ok {
 		return nil, ErrQueryMsg
@@ -22,24 +22,35 @@ import (
 	"encoding/base64"
 	"fmt"
 	"io"
+	"net"
 	"path/filepath"
 	"runtime"
+	"strings"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	grpclib "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
 
+	"github.com/apache/skywalking-banyandb/api/data"
+	commonv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/common/v1"
+	modelv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/model/v1"
 	streamv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/stream/v1"
 	"github.com/apache/skywalking-banyandb/banyand/discovery"
 	"github.com/apache/skywalking-banyandb/banyand/metadata"
 	"github.com/apache/skywalking-banyandb/banyand/query"
 	"github.com/apache/skywalking-banyandb/banyand/queue"
 	"github.com/apache/skywalking-banyandb/banyand/stream"
+	"github.com/apache/skywalking-banyandb/pkg/bus"
 	"github.com/apache/skywalking-banyandb/pkg/logger"
+	"github.com/apache/skywalking-banyandb/pkg/partition"
 	pbv1 "github.com/apache/skywalking-banyandb/pkg/pb/v1"
 	"github.com/apache/skywalking-banyandb/pkg/run"
 	"github.com/apache/skywalking-banyandb/pkg/test"
@@ -116,6 +127,8 @@ func setup(req *require.Assertions, testData testData) func() {
 		keyFile := filepath.Join(testData.basePath, "testdata/server_key.pem")
 		flags = append(flags, "--cert-file="+certFile)
 		flags = append(flags, "--key-file="+keyFile)
+	}
+	if testData.addr != "" {
 		flags = append(flags, "--addr="+testData.addr)
 	}
 	err = g.RegisterFlags().Parse(flags)
@@ -135,6 +148,14 @@ func setup(req *require.Assertions, testData testData) func() {
 		deferFunc()
 	}()
 	req.NoError(startListener.WaitUntilStarted())
+	// the services are started in parallel, so the server might not be listening yet
+	req.NoError(test.Retry(50, 100*time.Millisecond, func() error {
+		conn, errDial := net.DialTimeout("tcp", testData.addr, time.Second)
+		if errDial != nil {
+			return errDial
+		}
+		return conn.Close()
+	}))
 	return func() {
 		closer.GracefulStop()
 		wg.Wait()
@@ -249,9 +270,7 @@ func streamWrite(t *testing.T, tc caseData, conn *grpclib.ClientConn) {
 	client := streamv1.NewStreamServiceClient(conn)
 	ctx := context.Background()
 	writeClient, errorWrite := client.Write(ctx)
-	if errorWrite != nil {
-		t.Errorf("%v.write(_) = _, %v", client, errorWrite)
-	}
+	require.NoError(t, errorWrite)
 	waitc := make(chan struct{})
 	go func() {
 		for {
@@ -284,3 +303,533 @@ func streamQuery(tester *require.Assertions, conn *grpclib.ClientConn, request *
 	tester.NotNil(queryResponse)
 	return queryResponse
 }
+
+var _ streamv1.StreamService_WriteServer = (*fakeWriteServer)(nil)
+
+type fakeWriteServer struct {
+	grpclib.ServerStream
+	reqCh  chan *streamv1.WriteRequest
+	respCh chan *streamv1.WriteResponse
+}
+
+func (f *fakeWriteServer) Recv() (*streamv1.WriteRequest, error) {
+	req, ok := <-f.reqCh
+	if !ok {
+		return nil, io.EOF
+	}
+	return req, nil
+}
+
+func (f *fakeWriteServer) Context() context.Context {
+	return context.Background()
+}
+
+func (f *fakeWriteServer) Send(resp *streamv1.WriteResponse) error {
+	f.respCh <- resp
+	return nil
+}
+
+// blockingWriter holds writes until the flush signal is sent
+type blockingWriter struct {
+	flushCh chan struct{}
+	revCh   chan struct{}
+}
+
+func (b *blockingWriter) Rev(message bus.Message) (resp bus.Message) {
+	b.revCh <- struct{}{}
+	<-b.flushCh
+	return bus.NewMessage(message.ID(), nil)
+}
+
+func TestStreamWrite_AckLevel(t *testing.T) {
+	req := require.New(t)
+	req.NoError(logger.Init(logger.Logging{
+		Env:   "dev",
+		Level: "warn",
+	}))
+	pipeline, err := queue.NewQueue(context.TODO(), nil)
+	req.NoError(err)
+	writer := &blockingWriter{
+		flushCh: make(chan struct{}),
+		revCh:   make(chan struct{}, 2),
+	}
+	req.NoError(pipeline.Subscribe(data.TopicStreamWrite, writer))
+	req.NoError(pipeline.Subscribe(data.TopicStreamDurableWrite, writer))
+
+	metadata := &commonv1.Metadata{
+		Name:  "sw",
+		Group: "default",
+	}
+	s := NewServer(context.TODO(), pipeline, nil, nil)
+	s.log = logger.GetLogger("test")
+	s.shardRepo.shardEventsMap[getID(metadata)] = 2
+	s.entityRepo.entitiesMap[getID(metadata)] = partition.EntityLocator{{FamilyOffset: 0, TagOffset: 0}}
+
+	writeServer := &fakeWriteServer{
+		reqCh:  make(chan *streamv1.WriteRequest),
+		respCh: make(chan *streamv1.WriteResponse),
+	}
+	doneCh := make(chan error)
+	go func() {
+		doneCh <- s.Write(writeServer)
+	}()
+	newRequest := func(ackLevel streamv1.AckLevel) *streamv1.WriteRequest {
+		return &streamv1.WriteRequest{
+			Metadata: metadata,
+			AckLevel: ackLevel,
+			Element: &streamv1.ElementValue{
+				ElementId: "1",
+				TagFamilies: []*modelv1.TagFamilyForWrite{
+					{
+						Tags: []*modelv1.TagValue{
+							{
+								Value: &modelv1.TagValue_Str{Str: &modelv1.Str{Value: "webapp_id"}},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+	receive := func() *streamv1.WriteResponse {
+		select {
+		case resp := <-writeServer.respCh:
+			return resp
+		case <-time.After(5 * time.Second):
+			req.FailNow("timeout to receive a response")
+		}
+		return nil
+	}
+
+	// the default level is acknowledged once the write is enqueued even though it isn't flushed
+	writeServer.reqCh <- newRequest(streamv1.AckLevel_ACK_LEVEL_UNSPECIFIED)
+	req.Equal(streamv1.AckLevel_ACK_LEVEL_QUEUED, receive().GetAckLevel())
+	writeServer.reqCh <- newRequest(streamv1.AckLevel_ACK_LEVEL_NONE)
+	req.Equal(streamv1.AckLevel_ACK_LEVEL_NONE, receive().GetAckLevel())
+	<-writer.revCh
+
+	// the durable write isn't acknowledged until the flush signal is sent
+	writeServer.reqCh <- newRequest(streamv1.AckLevel_ACK_LEVEL_DURABLE)
+	<-writer.revCh
+	select {
+	case <-writeServer.respCh:
+		req.FailNow("the durable write is acknowledged before being flushed")
+	case <-time.After(100 * time.Millisecond):
+	}
+	close(writer.flushCh)
+	req.Equal(streamv1.AckLevel_ACK_LEVEL_DURABLE, receive().GetAckLevel())
+
+	close(writeServer.reqCh)
+	req.NoError(<-doneCh)
+}
+
+func TestStreamWrite_OversizedElement(t *testing.T) {
+	req := require.New(t)
+	req.NoError(logger.Init(logger.Logging{
+		Env:   "dev",
+		Level: "warn",
+	}))
+	pipeline, err := queue.NewQueue(context.TODO(), nil)
+	req.NoError(err)
+	writer := &blockingWriter{
+		flushCh: make(chan struct{}),
+		revCh:   make(chan struct{}, 10),
+	}
+	close(writer.flushCh)
+	req.NoError(pipeline.Subscribe(data.TopicStreamWrite, writer))
+
+	metadata := &commonv1.Metadata{
+		Name:  "sw",
+		Group: "default",
+	}
+	s := NewServer(context.TODO(), pipeline, nil, nil)
+	s.log = logger.GetLogger("test")
+	s.elementLimits = elementLimits{
+		maxTags:       3,
+		maxValueBytes: 32,
+	}
+	s.shardRepo.shardEventsMap[getID(metadata)] = 2
+	s.entityRepo.entitiesMap[getID(metadata)] = partition.EntityLocator{{FamilyOffset: 0, TagOffset: 0}}
+
+	newRequest := func(tags ...string) *streamv1.WriteRequest {
+		family := &modelv1.TagFamilyForWrite{}
+		for _, tag := range tags {
+			family.Tags = append(family.Tags, &modelv1.TagValue{
+				Value: &modelv1.TagValue_Str{Str: &modelv1.Str{Value: tag}},
+			})
+		}
+		return &streamv1.WriteRequest{
+			Metadata: metadata,
+			Element: &streamv1.ElementValue{
+				ElementId:   "1",
+				TagFamilies: []*modelv1.TagFamilyForWrite{family},
+			},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		oversized *streamv1.WriteRequest
+		wantLimit string
+	}{
+		{
+			name:      "too many tags",
+			oversized: newRequest("webapp_id", "10.0.0.1_id", "/home_id", "GET"),
+			wantLimit: "max-tags-per-element",
+		},
+		{
+			name:      "too large value",
+			oversized: newRequest("webapp_id", strings.Repeat("x", 64)),
+			wantLimit: "max-tag-value-bytes",
+		},
+	}
+	// the elements go through the gRPC stream of the server
+	client, stop := serveWrite(req, s)
+	defer stop()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := require.New(t)
+			writeClient, errWrite := client.Write(context.Background())
+			req.NoError(errWrite)
+			req.NoError(writeClient.Send(tt.oversized))
+			resp, errRecv := writeClient.Recv()
+			req.NoError(errRecv)
+			req.Equal(codes.InvalidArgument, codes.Code(resp.GetCode()))
+			req.Contains(resp.GetMessage(), tt.wantLimit)
+			// the valid element after the oversized one proves the stream is still open
+			req.NoError(writeClient.Send(newRequest("webapp_id", "10.0.0.1_id", "/home_id")))
+			resp, errRecv = writeClient.Recv()
+			req.NoError(errRecv)
+			req.Equal(codes.OK, codes.Code(resp.GetCode()))
+			req.Equal(streamv1.AckLevel_ACK_LEVEL_QUEUED, resp.GetAckLevel())
+			req.NoError(writeClient.CloseSend())
+			_, errRecv = writeClient.Recv()
+			req.Equal(io.EOF, errRecv)
+		})
+	}
+}
+
+// serveWrite serves the stream service of s on a random port
+func serveWrite(req *require.Assertions, s *Server) (streamv1.StreamServiceClient, func()) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	req.NoError(err)
+	ser := grpclib.NewServer()
+	streamv1.RegisterStreamServiceServer(ser, s)
+	go func() {
+		_ = ser.Serve(lis)
+	}()
+	conn, err := grpclib.Dial(lis.Addr().String(), grpclib.WithInsecure())
+	req.NoError(err)
+	return streamv1.NewStreamServiceClient(conn), func() {
+		_ = conn.Close()
+		ser.Stop()
+	}
+}
+
+func TestStreamWrite_EntityFindFailures(t *testing.T) {
+	req := require.New(t)
+	req.NoError(logger.Init(logger.Logging{
+		Env:   "dev",
+		Level: "warn",
+	}))
+	pipeline, err := queue.NewQueue(context.TODO(), nil)
+	req.NoError(err)
+	writer := &blockingWriter{
+		flushCh: make(chan struct{}),
+		revCh:   make(chan struct{}, 10),
+	}
+	close(writer.flushCh)
+	req.NoError(pipeline.Subscribe(data.TopicStreamWrite, writer))
+
+	s := NewServer(context.TODO(), pipeline, nil, nil)
+	s.log = logger.GetLogger("test")
+	newRequest := func(name string, tags ...*modelv1.TagValue) *streamv1.WriteRequest {
+		metadata := &commonv1.Metadata{
+			Name:  name,
+			Group: "default",
+		}
+		s.shardRepo.shardEventsMap[getID(metadata)] = 2
+		return &streamv1.WriteRequest{
+			Metadata: metadata,
+			Element: &streamv1.ElementValue{
+				ElementId:   "1",
+				TagFamilies: []*modelv1.TagFamilyForWrite{{Tags: tags}},
+			},
+		}
+	}
+	str := &modelv1.TagValue{Value: &modelv1.TagValue_Str{Str: &modelv1.Str{Value: "webapp_id"}}}
+	valid := newRequest("sw", str)
+	s.entityRepo.entitiesMap[getID(valid.GetMetadata())] = partition.EntityLocator{{FamilyOffset: 0, TagOffset: 0}}
+
+	tests := []struct {
+		name       string
+		locator    partition.EntityLocator
+		malformed  *streamv1.WriteRequest
+		wantReason string
+	}{
+		{
+			name:       "bad family offset",
+			locator:    partition.EntityLocator{{FamilyOffset: 1, TagOffset: 0}},
+			malformed:  newRequest("bad_family", str),
+			wantReason: "bad_family_offset",
+		},
+		{
+			name:       "bad tag offset",
+			locator:    partition.EntityLocator{{FamilyOffset: 0, TagOffset: 1}},
+			malformed:  newRequest("bad_tag", str),
+			wantReason: "bad_tag_offset",
+		},
+		{
+			name:       "marshal error",
+			locator:    partition.EntityLocator{{FamilyOffset: 0, TagOffset: 0}},
+			malformed:  newRequest("null_tag", &modelv1.TagValue{Value: &modelv1.TagValue_Null{}}),
+			wantReason: "marshal_error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := require.New(t)
+			id := getID(tt.malformed.GetMetadata())
+			s.entityRepo.entitiesMap[id] = tt.locator
+			counter := entityFindFailures.WithLabelValues(id.group, id.name, tt.wantReason)
+			before := testutil.ToFloat64(counter)
+
+			writeServer := &fakeWriteServer{
+				reqCh:  make(chan *streamv1.WriteRequest),
+				respCh: make(chan *streamv1.WriteResponse, 1),
+			}
+			doneCh := make(chan error)
+			go func() {
+				doneCh <- s.Write(writeServer)
+			}()
+			// the malformed element is rejected, and the valid one after it proves the stream is still open
+			writeServer.reqCh <- tt.malformed
+			resp := <-writeServer.respCh
+			req.Equal(codes.InvalidArgument, codes.Code(resp.GetCode()))
+			req.Contains(resp.GetMessage(), "failed to locate")
+			writeServer.reqCh <- valid
+			req.Equal(streamv1.AckLevel_ACK_LEVEL_QUEUED, (<-writeServer.respCh).GetAckLevel())
+			close(writeServer.reqCh)
+			req.NoError(<-doneCh)
+			req.Equal(before+1, testutil.ToFloat64(counter))
+		})
+	}
+}
+
+func TestStreamWrite_UnknownStream(t *testing.T) {
+	req := require.New(t)
+	req.NoError(logger.Init(logger.Logging{
+		Env:   "dev",
+		Level: "warn",
+	}))
+	pipeline, err := queue.NewQueue(context.TODO(), nil)
+	req.NoError(err)
+	writer := &blockingWriter{
+		flushCh: make(chan struct{}),
+		revCh:   make(chan struct{}, 10),
+	}
+	close(writer.flushCh)
+	req.NoError(pipeline.Subscribe(data.TopicStreamWrite, writer))
+
+	s := NewServer(context.TODO(), pipeline, nil, nil)
+	s.log = logger.GetLogger("test")
+	newRequest := func(name string) *streamv1.WriteRequest {
+		return &streamv1.WriteRequest{
+			Metadata: &commonv1.Metadata{
+				Name:  name,
+				Group: "default",
+			},
+			Element: &streamv1.ElementValue{
+				ElementId: "1",
+				TagFamilies: []*modelv1.TagFamilyForWrite{
+					{
+						Tags: []*modelv1.TagValue{
+							{
+								Value: &modelv1.TagValue_Str{Str: &modelv1.Str{Value: "webapp_id"}},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+	valid := newRequest("sw")
+	s.shardRepo.shardEventsMap[getID(valid.GetMetadata())] = 2
+	s.entityRepo.entitiesMap[getID(valid.GetMetadata())] = partition.EntityLocator{{FamilyOffset: 0, TagOffset: 0}}
+	noEntity := newRequest("no_entity")
+	s.shardRepo.shardEventsMap[getID(noEntity.GetMetadata())] = 2
+
+	tests := []struct {
+		name        string
+		unknown     *streamv1.WriteRequest
+		wantMessage string
+	}{
+		{
+			name:        "unknown shards",
+			unknown:     newRequest("no_shard"),
+			wantMessage: "the shards of default/no_shard are unknown",
+		},
+		{
+			name:        "unknown entity",
+			unknown:     noEntity,
+			wantMessage: "the entity of default/no_entity is unknown",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := require.New(t)
+			writeServer := &fakeWriteServer{
+				reqCh:  make(chan *streamv1.WriteRequest),
+				respCh: make(chan *streamv1.WriteResponse, 1),
+			}
+			doneCh := make(chan error)
+			go func() {
+				doneCh <- s.Write(writeServer)
+			}()
+			// the element of the unknown stream is rejected, and the valid one after it is still written
+			writeServer.reqCh <- tt.unknown
+			resp := <-writeServer.respCh
+			req.Equal(codes.NotFound, codes.Code(resp.GetCode()))
+			req.Equal(tt.wantMessage, resp.GetMessage())
+			writeServer.reqCh <- valid
+			resp = <-writeServer.respCh
+			req.Equal(codes.OK, codes.Code(resp.GetCode()))
+			req.Equal(streamv1.AckLevel_ACK_LEVEL_QUEUED, resp.GetAckLevel())
+			close(writeServer.reqCh)
+			req.NoError(<-doneCh)
+		})
+	}
+}
+
+func TestStreamWrite_Backpressure(t *testing.T) {
+	req := require.New(t)
+	req.NoError(logger.Init(logger.Logging{
+		Env:   "dev",
+		Level: "warn",
+	}))
+	pipeline, err := queue.NewQueue(context.TODO(), nil)
+	req.NoError(err)
+	req.NoError(pipeline.FlagSet().Parse([]string{"--queue-capacity=1"}))
+	writer := &blockingWriter{
+		flushCh: make(chan struct{}),
+		revCh:   make(chan struct{}, 2),
+	}
+	defer close(writer.flushCh)
+	req.NoError(pipeline.Subscribe(data.TopicStreamWrite, writer))
+
+	metadata := &commonv1.Metadata{
+		Name:  "sw",
+		Group: "default",
+	}
+	s := NewServer(context.TODO(), pipeline, nil, nil)
+	s.log = logger.GetLogger("test")
+	s.shardRepo.shardEventsMap[getID(metadata)] = 2
+	s.entityRepo.entitiesMap[getID(metadata)] = partition.EntityLocator{{FamilyOffset: 0, TagOffset: 0}}
+
+	writeServer := &fakeWriteServer{
+		reqCh:  make(chan *streamv1.WriteRequest),
+		respCh: make(chan *streamv1.WriteResponse),
+	}
+	doneCh := make(chan error)
+	go func() {
+		doneCh <- s.Write(writeServer)
+	}()
+	request := &streamv1.WriteRequest{
+		Metadata: metadata,
+		AckLevel: streamv1.AckLevel_ACK_LEVEL_NONE,
+		Element: &streamv1.ElementValue{
+			ElementId: "1",
+			TagFamilies: []*modelv1.TagFamilyForWrite{
+				{
+					Tags: []*modelv1.TagValue{
+						{
+							Value: &modelv1.TagValue_Str{Str: &modelv1.Str{Value: "webapp_id"}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	// the writer holds the first write, which fills the queue
+	writeServer.reqCh <- request
+	req.Equal(streamv1.AckLevel_ACK_LEVEL_NONE, (<-writeServer.respCh).GetAckLevel())
+	<-writer.revCh
+	writeServer.reqCh <- request
+	select {
+	case err = <-doneCh:
+	case <-time.After(5 * time.Second):
+		req.FailNow("timeout to reject the write")
+	}
+	req.Equal(codes.ResourceExhausted, status.Code(err))
+}
+
+// failingWriter fails every write as the storage is down
+type failingWriter struct{}
+
+func (failingWriter) Rev(message bus.Message) (resp bus.Message) {
+	return bus.NewMessage(message.ID(), queue.StorageFailure(errors.New("the storage is down")))
+}
+
+func TestStreamWrite_CircuitOpen(t *testing.T) {
+	req := require.New(t)
+	req.NoError(logger.Init(logger.Logging{
+		Env:   "dev",
+		Level: "warn",
+	}))
+	pipeline, err := queue.NewQueue(context.TODO(), nil)
+	req.NoError(err)
+	req.NoError(pipeline.FlagSet().Parse([]string{
+		"--breaker-topics=" + data.TopicStreamWrite.ID,
+		"--breaker-max-failures=1",
+		"--breaker-backoff=1h",
+	}))
+	req.NoError(pipeline.Subscribe(data.TopicStreamWrite, failingWriter{}))
+
+	metadata := &commonv1.Metadata{
+		Name:  "sw",
+		Group: "default",
+	}
+	s := NewServer(context.TODO(), pipeline, nil, nil)
+	s.log = logger.GetLogger("test")
+	s.shardRepo.shardEventsMap[getID(metadata)] = 2
+	s.entityRepo.entitiesMap[getID(metadata)] = partition.EntityLocator{{FamilyOffset: 0, TagOffset: 0}}
+
+	writeServer := &fakeWriteServer{
+		reqCh:  make(chan *streamv1.WriteRequest),
+		respCh: make(chan *streamv1.WriteResponse),
+	}
+	doneCh := make(chan error)
+	go func() {
+		doneCh <- s.Write(writeServer)
+	}()
+	request := &streamv1.WriteRequest{
+		Metadata: metadata,
+		Element: &streamv1.ElementValue{
+			ElementId: "1",
+			TagFamilies: []*modelv1.TagFamilyForWrite{
+				{
+					Tags: []*modelv1.TagValue{
+						{
+							Value: &modelv1.TagValue_Str{Str: &modelv1.Str{Value: "webapp_id"}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	// the failure of the first write opens the circuit
+	writeServer.reqCh <- request
+	req.Equal(streamv1.AckLevel_ACK_LEVEL_QUEUED, (<-writeServer.respCh).GetAckLevel())
+	req.Eventually(func() bool {
+		return pipeline.BreakerState(data.TopicStreamWrite) == queue.BreakerOpen
+	}, 5*time.Second, 10*time.Millisecond)
+	writeServer.reqCh <- request
+	select {
+	case err = <-doneCh:
+	case <-time.After(5 * time.Second):
+		req.FailNow("timeout to reject the write")
+	}
+	req.Equal(codes.Unavailable, status.Code(err))
+}
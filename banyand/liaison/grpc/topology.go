@@ -0,0 +1,134 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package grpc
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	databasev1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/database/v1"
+	streamv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/stream/v1"
+)
+
+// topology catalogs the shard and entity events received by the liaison, which explains where the writes are routed.
+// A schema is dropped once the events delete both its shards and its entity.
+type topology struct {
+	mu       sync.RWMutex
+	subjects map[identity]*streamv1.SubjectTopology
+}
+
+func newTopology() *topology {
+	return &topology{subjects: make(map[identity]*streamv1.SubjectTopology)}
+}
+
+func (t *topology) onShardEvent(e *databasev1.ShardEvent) {
+	t.update(getID(e.GetShard().GetMetadata()), func(sub *streamv1.SubjectTopology) {
+		id := e.GetShard().GetId()
+		i := sort.Search(len(sub.ShardIds), func(i int) bool { return sub.ShardIds[i] >= id })
+		exists := i < len(sub.ShardIds) && sub.ShardIds[i] == id
+		switch e.GetAction() {
+		case databasev1.Action_ACTION_PUT:
+			sub.ShardNum = e.GetShard().GetTotal()
+			if !exists {
+				sub.ShardIds = append(sub.ShardIds[:i], append([]uint64{id}, sub.ShardIds[i:]...)...)
+			}
+		case databasev1.Action_ACTION_DELETE:
+			// the shard repo forgets the total along with any of the shards, so does the topology
+			sub.ShardNum = 0
+			if exists {
+				sub.ShardIds = append(sub.ShardIds[:i], sub.ShardIds[i+1:]...)
+			}
+		}
+	})
+}
+
+func (t *topology) onEntityEvent(e *databasev1.EntityEvent) {
+	t.update(getID(e.GetSubject()), func(sub *streamv1.SubjectTopology) {
+		switch e.GetAction() {
+		case databasev1.Action_ACTION_PUT:
+			sub.EntityTagNum = uint32(len(e.GetEntityLocator()))
+			sub.ShardingAlgorithm = e.GetShardingAlgorithm()
+			sub.ShardOverrides = e.GetShardOverrides()
+		case databasev1.Action_ACTION_DELETE:
+			sub.EntityTagNum = 0
+			sub.ShardingAlgorithm = databasev1.ShardingAlgorithm_SHARDING_ALGORITHM_UNSPECIFIED
+			sub.ShardOverrides = nil
+		}
+	})
+}
+
+// update is a no-op on a nil topology, which leaves the repos working alone
+func (t *topology) update(id identity, fn func(sub *streamv1.SubjectTopology)) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	sub, ok := t.subjects[id]
+	if !ok {
+		sub = &streamv1.SubjectTopology{Metadata: id.metadata()}
+	}
+	fn(sub)
+	sub.UpdatedAt = timestamppb.Now()
+	if sub.ShardNum == 0 && len(sub.ShardIds) == 0 && sub.EntityTagNum == 0 {
+		delete(t.subjects, id)
+		return
+	}
+	t.subjects[id] = sub
+}
+
+// list returns the copies of the topologies of the group, or all of them if the group is empty
+func (t *topology) list(group string) []*streamv1.GroupTopology {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	groups := make(map[string]*streamv1.GroupTopology)
+	for id, sub := range t.subjects {
+		if group != "" && id.group != group {
+			continue
+		}
+		g, ok := groups[id.group]
+		if !ok {
+			g = &streamv1.GroupTopology{Group: id.group}
+			groups[id.group] = g
+		}
+		g.Subjects = append(g.Subjects, proto.Clone(sub).(*streamv1.SubjectTopology))
+	}
+	result := make([]*streamv1.GroupTopology, 0, len(groups))
+	for _, g := range groups {
+		sort.Slice(g.Subjects, func(i, j int) bool {
+			return g.Subjects[i].GetMetadata().GetName() < g.Subjects[j].GetMetadata().GetName()
+		})
+		result = append(result, g)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].GetGroup() < result[j].GetGroup()
+	})
+	return result
+}
+
+// ListTopology returns the shards and the shard overrides of the streams known by this liaison
+func (s *Server) ListTopology(ctx context.Context, req *streamv1.ListTopologyRequest) (*streamv1.ListTopologyResponse, error) {
+	if err := s.authorizeAdmin(ctx); err != nil {
+		return nil, err
+	}
+	return &streamv1.ListTopologyResponse{Groups: s.topology.list(req.GetGroup())}, nil
+}
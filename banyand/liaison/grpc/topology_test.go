@@ -0,0 +1,110 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	grpcmetadata "google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	commonv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/common/v1"
+	databasev1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/database/v1"
+	modelv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/model/v1"
+	streamv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/stream/v1"
+	"github.com/apache/skywalking-banyandb/pkg/bus"
+	"github.com/apache/skywalking-banyandb/pkg/logger"
+)
+
+func TestListTopology(t *testing.T) {
+	req := require.New(t)
+	req.NoError(logger.Init(logger.Logging{
+		Env:   "dev",
+		Level: "warn",
+	}))
+	s := NewServer(context.TODO(), nil, nil, nil)
+	s.log = logger.GetLogger("test")
+	s.shardRepo.log = s.log
+	s.entityRepo.log = s.log
+	s.adminToken = "secret"
+	adminCtx := grpcmetadata.NewIncomingContext(context.Background(), grpcmetadata.Pairs(adminAuthHeader, "Bearer secret"))
+	sw := &commonv1.Metadata{Group: "default", Name: "sw"}
+	shardEvent := func(metadata *commonv1.Metadata, id uint64, action databasev1.Action) {
+		s.shardRepo.Rev(bus.NewMessage(bus.MessageID(id), &databasev1.ShardEvent{
+			Shard:  &databasev1.Shard{Id: id, Total: 2, Metadata: metadata},
+			Action: action,
+		}))
+	}
+	override := &databasev1.ShardOverride{
+		Entity:  []*modelv1.TagValue{{Value: &modelv1.TagValue_Str{Str: &modelv1.Str{Value: "hot"}}}},
+		ShardId: 1,
+	}
+	list := func(group string) []*streamv1.GroupTopology {
+		resp, err := s.ListTopology(adminCtx, &streamv1.ListTopologyRequest{Group: group})
+		req.NoError(err)
+		return resp.GetGroups()
+	}
+
+	_, err := s.ListTopology(context.Background(), &streamv1.ListTopologyRequest{})
+	req.Equal(codes.Unauthenticated, status.Code(err))
+	req.Empty(list(""))
+
+	shardEvent(sw, 1, databasev1.Action_ACTION_PUT)
+	shardEvent(sw, 0, databasev1.Action_ACTION_PUT)
+	shardEvent(sw, 1, databasev1.Action_ACTION_PUT)
+	shardEvent(&commonv1.Metadata{Group: "another", Name: "sw"}, 0, databasev1.Action_ACTION_PUT)
+	s.entityRepo.Rev(bus.NewMessage(bus.MessageID(0), &databasev1.EntityEvent{
+		Subject:           sw,
+		EntityLocator:     []*databasev1.EntityEvent_TagLocator{{FamilyOffset: 0, TagOffset: 0}},
+		ShardOverrides:    []*databasev1.ShardOverride{override},
+		ShardingAlgorithm: databasev1.ShardingAlgorithm_SHARDING_ALGORITHM_CONSISTENT,
+		Action:            databasev1.Action_ACTION_PUT,
+	}))
+
+	groups := list("")
+	req.Len(groups, 2)
+	req.Equal("another", groups[0].GetGroup())
+	req.Equal("default", groups[1].GetGroup())
+	groups = list("default")
+	req.Len(groups, 1)
+	req.Len(groups[0].GetSubjects(), 1)
+	topo := groups[0].GetSubjects()[0]
+	req.True(proto.Equal(sw, topo.GetMetadata()))
+	req.Equal(uint32(2), topo.GetShardNum())
+	req.Equal([]uint64{0, 1}, topo.GetShardIds())
+	req.Equal(uint32(1), topo.GetEntityTagNum())
+	req.Equal(databasev1.ShardingAlgorithm_SHARDING_ALGORITHM_CONSISTENT, topo.GetShardingAlgorithm())
+	req.Len(topo.GetShardOverrides(), 1)
+	req.True(proto.Equal(override, topo.GetShardOverrides()[0]))
+	req.NotNil(topo.GetUpdatedAt())
+
+	// the schema is dropped once both its shards and its entity are deleted
+	shardEvent(sw, 0, databasev1.Action_ACTION_DELETE)
+	shardEvent(sw, 1, databasev1.Action_ACTION_DELETE)
+	req.Len(list("default"), 1)
+	s.entityRepo.Rev(bus.NewMessage(bus.MessageID(1), &databasev1.EntityEvent{
+		Subject: sw,
+		Action:  databasev1.Action_ACTION_DELETE,
+	}))
+	req.Empty(list("default"))
+	req.Len(list(""), 1)
+}
@@ -0,0 +1,82 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package grpc
+
+import (
+	"context"
+
+	grpclib "google.golang.org/grpc"
+	grpcmetadata "google.golang.org/grpc/metadata"
+
+	"github.com/apache/skywalking-banyandb/pkg/tracing"
+)
+
+// metadataCarrier reads the trace context, e.g. traceparent, from the incoming gRPC metadata
+type metadataCarrier grpcmetadata.MD
+
+func (c metadataCarrier) Get(key string) string {
+	values := grpcmetadata.MD(c).Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+func (c metadataCarrier) Set(key string, value string) {
+	grpcmetadata.MD(c).Set(key, value)
+}
+
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+func extractTraceContext(ctx context.Context) context.Context {
+	md, ok := grpcmetadata.FromIncomingContext(ctx)
+	if !ok {
+		return ctx
+	}
+	return tracing.Extract(ctx, metadataCarrier(md))
+}
+
+func traceUnaryInterceptor() grpclib.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, _ *grpclib.UnaryServerInfo,
+		handler grpclib.UnaryHandler) (interface{}, error) {
+		return handler(extractTraceContext(ctx), req)
+	}
+}
+
+func traceStreamInterceptor() grpclib.StreamServerInterceptor {
+	return func(srv interface{}, ss grpclib.ServerStream, _ *grpclib.StreamServerInfo,
+		handler grpclib.StreamHandler) error {
+		return handler(srv, &tracedStream{ServerStream: ss, ctx: extractTraceContext(ss.Context())})
+	}
+}
+
+// tracedStream carries the trace context of the client in its context
+type tracedStream struct {
+	grpclib.ServerStream
+	ctx context.Context
+}
+
+func (s *tracedStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,132 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	grpclib "google.golang.org/grpc"
+	grpcmetadata "google.golang.org/grpc/metadata"
+
+	"github.com/apache/skywalking-banyandb/api/data"
+	commonv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/common/v1"
+	modelv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/model/v1"
+	streamv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/stream/v1"
+	"github.com/apache/skywalking-banyandb/banyand/queue"
+	"github.com/apache/skywalking-banyandb/pkg/bus"
+	"github.com/apache/skywalking-banyandb/pkg/logger"
+	"github.com/apache/skywalking-banyandb/pkg/partition"
+)
+
+const (
+	testTraceID     = "4bf92f3577b34da6a3ce929d0e0e4736"
+	testParentID    = "00f067aa0ba902b7"
+	testTraceParent = "00-" + testTraceID + "-" + testParentID + "-01"
+)
+
+// headerRecorder records the headers of the received messages
+type headerRecorder struct {
+	headerCh chan bus.Header
+}
+
+func (h *headerRecorder) Rev(message bus.Message) (resp bus.Message) {
+	h.headerCh <- message.Header()
+	return bus.NewMessage(message.ID(), nil)
+}
+
+type ctxWriteServer struct {
+	*fakeWriteServer
+	ctx context.Context
+}
+
+func (c *ctxWriteServer) Context() context.Context {
+	return c.ctx
+}
+
+func TestTracePropagation(t *testing.T) {
+	req := require.New(t)
+	req.NoError(logger.Init(logger.Logging{
+		Env:   "dev",
+		Level: "warn",
+	}))
+	pipeline, err := queue.NewQueue(context.TODO(), nil)
+	req.NoError(err)
+	recorder := &headerRecorder{headerCh: make(chan bus.Header, 1)}
+	req.NoError(pipeline.Subscribe(data.TopicStreamWrite, recorder))
+
+	metadata := &commonv1.Metadata{
+		Name:  "sw",
+		Group: "default",
+	}
+	s := NewServer(context.TODO(), pipeline, nil, nil)
+	s.log = logger.GetLogger("test")
+	s.shardRepo.shardEventsMap[getID(metadata)] = 2
+	s.entityRepo.entitiesMap[getID(metadata)] = partition.EntityLocator{{FamilyOffset: 0, TagOffset: 0}}
+
+	// write sends an element through the trace interceptor and returns the header received by the queue
+	write := func() bus.Header {
+		writeServer := &fakeWriteServer{
+			reqCh:  make(chan *streamv1.WriteRequest),
+			respCh: make(chan *streamv1.WriteResponse, 1),
+		}
+		incoming := grpcmetadata.NewIncomingContext(context.Background(), grpcmetadata.Pairs("traceparent", testTraceParent))
+		doneCh := make(chan error)
+		go func() {
+			doneCh <- traceStreamInterceptor()(nil, &ctxWriteServer{fakeWriteServer: writeServer, ctx: incoming},
+				&grpclib.StreamServerInfo{FullMethod: "/banyandb.stream.v1.StreamService/Write"},
+				func(_ interface{}, ss grpclib.ServerStream) error {
+					return s.Write(&ctxWriteServer{fakeWriteServer: writeServer, ctx: ss.Context()})
+				})
+		}()
+		writeServer.reqCh <- &streamv1.WriteRequest{
+			Metadata: metadata,
+			Element: &streamv1.ElementValue{
+				ElementId: "1",
+				TagFamilies: []*modelv1.TagFamilyForWrite{{Tags: []*modelv1.TagValue{
+					{Value: &modelv1.TagValue_Str{Str: &modelv1.Str{Value: "webapp_id"}}},
+				}}},
+			},
+		}
+		<-writeServer.respCh
+		close(writeServer.reqCh)
+		req.NoError(<-doneCh)
+		return <-recorder.headerCh
+	}
+
+	// the trace context is propagated as is without a tracer provider
+	req.Equal(testTraceParent, write().Get("traceparent"))
+
+	exporter := tracetest.NewInMemoryExporter()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+	defer otel.SetTracerProvider(trace.NewNoopTracerProvider())
+	header := write()
+	spans := exporter.GetSpans()
+	req.Len(spans, 1)
+	enqueue := spans[0]
+	req.Equal("stream.enqueue", enqueue.Name)
+	req.Equal(testTraceID, enqueue.SpanContext.TraceID().String())
+	req.Equal(testParentID, enqueue.Parent.SpanID().String())
+	// the queue message carries the enqueue span, which is a child of the client's span
+	req.Equal("00-"+testTraceID+"-"+enqueue.SpanContext.SpanID().String()+"-01", header.Get("traceparent"))
+}
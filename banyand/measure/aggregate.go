@@ -0,0 +1,215 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package measure
+
+import (
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+
+	databasev1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/database/v1"
+	"github.com/apache/skywalking-banyandb/banyand/tsdb"
+)
+
+var (
+	ErrInvalidResolution      = errors.New("resolution should be positive")
+	ErrUnsupportedAggregation = errors.New("only the int fields can be aggregated")
+)
+
+// AggregateRequest asks for the aggregates of a field over the buckets sized by Resolution.
+// The nil entries of Entity match all the series.
+type AggregateRequest struct {
+	Entity     tsdb.Entity
+	TimeRange  tsdb.TimeRange
+	Field      string
+	Resolution time.Duration
+}
+
+// AggregatedPoint summarizes the values in the bucket starting at Timestamp
+type AggregatedPoint struct {
+	Timestamp time.Time
+	Sum       int64
+	Count     int64
+	Min       int64
+	Max       int64
+}
+
+// Aggregate reads the coarsest rollup which satisfies the request.
+// It falls back to the raw data points if none of the rollups does, and the blocks whose
+// data points fall into a single bucket are served by their statistics instead.
+func (s *measure) Aggregate(req AggregateRequest) ([]AggregatedPoint, error) {
+	if req.Resolution <= 0 {
+		return nil, errors.WithStack(ErrInvalidResolution)
+	}
+	var fieldSpec *databasev1.FieldSpec
+	for _, f := range s.intFields() {
+		if f.GetName() == req.Field {
+			fieldSpec = f
+			break
+		}
+	}
+	if fieldSpec == nil {
+		return nil, errors.Wrapf(ErrUnsupportedAggregation, "field:%s", req.Field)
+	}
+	shards, err := s.Shards(req.Entity)
+	if err != nil {
+		return nil, err
+	}
+	entity := req.Entity
+	if len(entity) < 1 {
+		entity = make(tsdb.Entity, len(s.schema.GetEntity().GetTagNames()))
+	}
+	path := tsdb.NewPath(entity)
+	r := s.pickRollup(req.TimeRange, req.Resolution)
+	buckets := make(map[time.Time]*aggregation)
+	bucketAt := func(ts time.Time) *aggregation {
+		a, ok := buckets[ts]
+		if !ok {
+			a = &aggregation{}
+			buckets[ts] = a
+		}
+		return a
+	}
+	for _, shard := range shards {
+		seriesList, errList := shard.Series().List(path)
+		if errList != nil {
+			return nil, errList
+		}
+		if r == nil {
+			for _, series := range seriesList {
+				unserved, errStats := aggregateBlocks(series, req, bucketAt)
+				if errStats != nil {
+					return nil, errStats
+				}
+				for _, timeRange := range unserved {
+					raw, errRaw := s.aggregateRaw(series, timeRange, req.Resolution,
+						[]*databasev1.FieldSpec{fieldSpec}, &s.rawReads)
+					if errRaw != nil {
+						return nil, errRaw
+					}
+					for ts, bucket := range raw {
+						if a, ok := bucket[req.Field]; ok {
+							bucketAt(ts).merge(*a)
+						}
+					}
+				}
+			}
+			continue
+		}
+		rollupShard, errShard := r.db.Shard(shard.ID())
+		if errShard != nil {
+			return nil, errShard
+		}
+		for _, series := range seriesList {
+			rollupSeries, errSeries := rollupShard.Series().GetByID(series.ID())
+			if errSeries != nil {
+				return nil, errSeries
+			}
+			errScan := s.scan(rollupSeries, req.TimeRange, func(item tsdb.Item) error {
+				atomic.AddUint64(&s.rollupReads, 1)
+				data, errFamily := item.Family(string(familyIdentity(req.Field, rollupFlag)))
+				if errFamily != nil {
+					// the field is absent from all the data points in this interval
+					return nil
+				}
+				var a aggregation
+				if errUnmarshal := a.unmarshal(data); errUnmarshal != nil {
+					return errUnmarshal
+				}
+				bucketAt(time.Unix(0, int64(item.Time())).Truncate(req.Resolution)).merge(a)
+				return nil
+			})
+			if errScan != nil {
+				return nil, errScan
+			}
+		}
+	}
+	points := make([]AggregatedPoint, 0, len(buckets))
+	for ts, a := range buckets {
+		points = append(points, AggregatedPoint{
+			Timestamp: ts,
+			Sum:       a.Sum,
+			Count:     a.Count,
+			Min:       a.Min,
+			Max:       a.Max,
+		})
+	}
+	sort.Slice(points, func(i, j int) bool {
+		return points[i].Timestamp.Before(points[j].Timestamp)
+	})
+	return points, nil
+}
+
+// aggregateBlocks merges the statistics of the blocks whose data points of the series are inside the time range
+// and fall into a single bucket. It returns the time ranges of the other blocks, whose data points have to be read.
+func aggregateBlocks(series tsdb.Series, req AggregateRequest, bucketAt func(ts time.Time) *aggregation) ([]tsdb.TimeRange, error) {
+	span, err := series.Span(req.TimeRange)
+	if err != nil {
+		if errors.Is(err, tsdb.ErrEmptySeriesSpan) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer func() {
+		_ = span.Close()
+	}()
+	blocks, err := span.Stats(req.Field)
+	if err != nil {
+		return nil, err
+	}
+	var unserved []tsdb.TimeRange
+	for _, b := range blocks {
+		if b.Column != nil && b.Column.Count < 1 {
+			continue
+		}
+		if b.Column == nil ||
+			b.Written.Start.Before(req.TimeRange.Start) || b.Written.End.After(req.TimeRange.End) ||
+			!b.Written.Start.Truncate(req.Resolution).Equal(b.Written.End.Add(-time.Nanosecond).Truncate(req.Resolution)) {
+			unserved = append(unserved, b.TimeRange)
+			continue
+		}
+		bucketAt(b.Written.Start.Truncate(req.Resolution)).merge(aggregation{
+			Sum:   b.Column.Sum,
+			Count: b.Column.Count,
+			Min:   b.Column.Min,
+			Max:   b.Column.Max,
+		})
+	}
+	return unserved, nil
+}
+
+// pickRollup returns the coarsest rollup whose intervals nest in the buckets of resolution and
+// cover timeRange entirely. It returns nil if the raw data points have to be read.
+func (s *measure) pickRollup(timeRange tsdb.TimeRange, resolution time.Duration) *rollup {
+	for _, r := range s.rollups {
+		if resolution%r.interval != 0 {
+			continue
+		}
+		if !timeRange.Start.Equal(timeRange.Start.Truncate(r.interval)) ||
+			!timeRange.End.Equal(timeRange.End.Truncate(r.interval)) {
+			continue
+		}
+		if timeRange.Start.Before(r.from) || timeRange.End.After(r.materialized()) {
+			continue
+		}
+		return r
+	}
+	return nil
+}
@@ -19,6 +19,10 @@ package measure
 
 import (
 	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
 
 	databasev1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/database/v1"
 	"github.com/apache/skywalking-banyandb/banyand/tsdb"
@@ -26,32 +30,52 @@ import (
 	"github.com/apache/skywalking-banyandb/pkg/encoding"
 	"github.com/apache/skywalking-banyandb/pkg/logger"
 	"github.com/apache/skywalking-banyandb/pkg/partition"
+	pbv1 "github.com/apache/skywalking-banyandb/pkg/pb/v1"
 )
 
 // a chunk is 1MB
 const chunkSize = 1 << 20
 
 type measure struct {
+	// rawReads and rollupReads count the data points read by the aggregations
+	rawReads      uint64
+	rollupReads   uint64
 	name          string
 	group         string
 	l             *logger.Logger
 	schema        *databasev1.Measure
 	db            tsdb.Database
 	entityLocator partition.EntityLocator
-	indexRules    []*databasev1.IndexRule
-	indexWriter   *index.Writer
+	// shardOverrides route the hot entities to their explicit shards
+	shardOverrides partition.ShardOverrides
+	// shardingStrategy routes the other entities
+	shardingStrategy partition.ShardingStrategy
+	indexRules       []*databasev1.IndexRule
+	indexWriter      *index.Writer
+	rollups          []*rollup
+	rollupStopCh     chan struct{}
+	rollupWg         sync.WaitGroup
 }
 
 func (s *measure) Close() error {
 	_ = s.indexWriter.Close()
-	return s.db.Close()
+	return multierr.Append(s.closeRollups(), s.db.Close())
 }
 
-func (s *measure) parseSchema() {
+func (s *measure) parseSchema() (err error) {
 	sm := s.schema
 	meta := sm.GetMetadata()
 	s.name, s.group = meta.GetName(), meta.GetGroup()
-	s.entityLocator = partition.NewEntityLocator(sm.TagFamilies, sm.Entity)
+	if s.entityLocator, err = partition.NewEntityLocator(sm.TagFamilies, sm.Entity); err != nil {
+		return err
+	}
+	if s.shardingStrategy, err = partition.NewShardingStrategy(sm.GetOpts().GetShardingAlgorithm()); err != nil {
+		return err
+	}
+	if s.shardOverrides, err = partition.ParseShardOverrides(len(sm.Entity.GetTagNames()), sm.GetOpts().GetShardOverrides()); err != nil {
+		return err
+	}
+	return s.shardOverrides.Validate(sm.GetOpts().GetShardNum())
 }
 
 type measureSpec struct {
@@ -65,7 +89,17 @@ func openMeasure(root string, spec measureSpec, l *logger.Logger) (*measure, err
 		indexRules: spec.indexRules,
 		l:          l,
 	}
-	sm.parseSchema()
+	if err := sm.parseSchema(); err != nil {
+		return nil, errors.WithMessagef(err, "failed to parse the schema of %s", sm.name)
+	}
+	partitioner, err := newPartitioner(sm.schema.GetPartitionInterval())
+	if err != nil {
+		return nil, err
+	}
+	ttl, err := pbv1.TTLOf(sm.schema.GetOpts())
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to parse the schema of %s", sm.name)
+	}
 	ctx := context.WithValue(context.Background(), logger.ContextKey, l)
 
 	db, err := tsdb.OpenDatabase(
@@ -78,11 +112,21 @@ func openMeasure(root string, spec measureSpec, l *logger.Logger) (*measure, err
 				EncoderPool: encoding.NewPlainEncoderPool(chunkSize),
 				DecoderPool: encoding.NewPlainDecoderPool(chunkSize),
 			},
+			StorageEngine: sm.schema.GetOpts().GetStorageEngine(),
+			TTL:           ttl,
+			MaxSegments:   sm.schema.GetOpts().GetMaxSegments(),
+			Partitioner:   partitioner,
 		})
 	if err != nil {
 		return nil, err
 	}
 	sm.db = db
+	sm.rollups, err = openRollups(ctx, root, sm)
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	sm.startRollups()
 	sm.indexWriter = index.NewWriter(ctx, index.WriterOptions{
 		DB:         db,
 		ShardNum:   spec.schema.GetOpts().ShardNum,
@@ -91,3 +135,19 @@ func openMeasure(root string, spec measureSpec, l *logger.Logger) (*measure, err
 	})
 	return sm, nil
 }
+
+// newPartitioner aligns the partitions to the wall-clock boundaries of the interval.
+// It returns nil if the interval is absent.
+func newPartitioner(interval *databasev1.Duration) (tsdb.Partitioner, error) {
+	if interval.GetUnit() == databasev1.Duration_DURATION_UNIT_UNSPECIFIED {
+		return nil, nil
+	}
+	if interval.GetUnit() == databasev1.Duration_DURATION_UNIT_MONTH {
+		return tsdb.NewMonthPartitioner(int(interval.GetVal()))
+	}
+	d, err := pbv1.DurationOf(interval)
+	if err != nil {
+		return nil, errors.WithMessagef(tsdb.ErrInvalidPartition, "%v", err)
+	}
+	return tsdb.NewIntervalPartitioner(d)
+}
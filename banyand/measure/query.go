@@ -29,7 +29,6 @@ import (
 	measurev1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/measure/v1"
 	modelv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/model/v1"
 	"github.com/apache/skywalking-banyandb/banyand/tsdb"
-	"github.com/apache/skywalking-banyandb/pkg/partition"
 )
 
 var (
@@ -47,6 +46,7 @@ type Measure interface {
 	Shard(id common.ShardID) (tsdb.Shard, error)
 	ParseTagFamily(family string, item tsdb.Item) (*modelv1.TagFamily, error)
 	ParseField(name string, item tsdb.Item) (*measurev1.DataPoint_Field, error)
+	Aggregate(req AggregateRequest) ([]AggregatedPoint, error)
 }
 
 var _ Measure = (*measure)(nil)
@@ -60,11 +60,11 @@ func (s *measure) Shards(entity tsdb.Entity) ([]tsdb.Shard, error) {
 			return s.db.Shards(), nil
 		}
 	}
-	shardID, err := partition.ShardID(entity.Marshal(), s.schema.GetOpts().GetShardNum())
+	shardID, err := s.shardOverrides.ShardID(entity, s.schema.GetOpts().GetShardNum(), s.shardingStrategy)
 	if err != nil {
 		return nil, err
 	}
-	shard, err := s.db.Shard(common.ShardID(shardID))
+	shard, err := s.db.Shard(shardID)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,424 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package measure
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+
+	databasev1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/database/v1"
+	modelv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/model/v1"
+	"github.com/apache/skywalking-banyandb/banyand/tsdb"
+	"github.com/apache/skywalking-banyandb/pkg/convert"
+	"github.com/apache/skywalking-banyandb/pkg/encoding"
+	pbv1 "github.com/apache/skywalking-banyandb/pkg/pb/v1"
+)
+
+var ErrInvalidRollup = errors.New("invalid rollup interval")
+
+const (
+	// rollupFlag marks the families holding the aggregates of a field in the rollup databases
+	rollupFlag byte = 0xff
+	// rollupLookback bounds the raw data materialized by a rollup job started from scratch
+	rollupLookback = 7 * 24 * time.Hour
+	// rollupCheckInterval is how often the job looks for the completed intervals
+	rollupCheckInterval = time.Minute
+	// rollupStateFile keeps the materialized window of a rollup in its location, which survives the restarts
+	rollupStateFile = "rollup.json"
+)
+
+// aggregation summarizes the int values of a field
+type aggregation struct {
+	Sum   int64
+	Count int64
+	Min   int64
+	Max   int64
+}
+
+func (a *aggregation) add(v int64) {
+	a.merge(aggregation{Sum: v, Count: 1, Min: v, Max: v})
+}
+
+func (a *aggregation) merge(other aggregation) {
+	if other.Count < 1 {
+		return
+	}
+	if a.Count < 1 {
+		*a = other
+		return
+	}
+	a.Sum += other.Sum
+	a.Count += other.Count
+	if other.Min < a.Min {
+		a.Min = other.Min
+	}
+	if other.Max > a.Max {
+		a.Max = other.Max
+	}
+}
+
+func (a aggregation) marshal() []byte {
+	data := make([]byte, 0, 32)
+	for _, v := range []int64{a.Sum, a.Count, a.Min, a.Max} {
+		data = append(data, convert.Int64ToBytes(v)...)
+	}
+	return data
+}
+
+func (a *aggregation) unmarshal(data []byte) error {
+	if len(data) != 32 {
+		return errors.Wrapf(ErrMalformedElement, "the size of an aggregation is %d", len(data))
+	}
+	a.Sum = convert.BytesToInt64(data[0:8])
+	a.Count = convert.BytesToInt64(data[8:16])
+	a.Min = convert.BytesToInt64(data[16:24])
+	a.Max = convert.BytesToInt64(data[24:32])
+	return nil
+}
+
+// rollup materializes the aggregates of the int fields over each interval into a separated database.
+// A data point is written to the rollup at the start of its interval.
+type rollup struct {
+	interval time.Duration
+	db       tsdb.Database
+	location string
+
+	// from is the start of the materialized intervals
+	from time.Time
+
+	// jobMu serializes the materializations, which read the raw data out of mu
+	jobMu sync.Mutex
+	mu    sync.RWMutex
+	// watermark is the end of the materialized intervals, which is persisted along with from
+	watermark time.Time
+}
+
+// rollupState is the materialized window of a rollup in nanoseconds
+type rollupState struct {
+	From      int64 `json:"from"`
+	Watermark int64 `json:"watermark"`
+}
+
+func (r *rollup) materialized() time.Time {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.watermark
+}
+
+// advance moves the watermark to end once the intervals before it are materialized
+func (r *rollup) advance(end time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	data, err := json.Marshal(rollupState{From: r.from.UnixNano(), Watermark: end.UnixNano()})
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(r.location, rollupStateFile)
+	tmp := path + ".tmp"
+	if err = ioutil.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	if err = os.Rename(tmp, path); err != nil {
+		return err
+	}
+	r.watermark = end
+	return nil
+}
+
+// loadRollupState returns the materialized window persisted at location, or the lookback window before now
+// if the rollup starts from scratch
+func loadRollupState(location string, interval time.Duration, now time.Time) (from, watermark time.Time, err error) {
+	data, err := ioutil.ReadFile(filepath.Join(location, rollupStateFile))
+	if errors.Is(err, os.ErrNotExist) {
+		from = now.Add(-rollupLookback).Truncate(interval)
+		return from, from, nil
+	}
+	if err != nil {
+		return from, watermark, err
+	}
+	var state rollupState
+	if err = json.Unmarshal(data, &state); err != nil {
+		return from, watermark, errors.Wrapf(err, "failed to decode the rollup state of %s", location)
+	}
+	return time.Unix(0, state.From), time.Unix(0, state.Watermark), nil
+}
+
+func openRollups(ctx context.Context, root string, sm *measure) ([]*rollup, error) {
+	intervals := sm.schema.GetRollupIntervals()
+	if len(intervals) < 1 {
+		return nil, nil
+	}
+	rr := make([]*rollup, 0, len(intervals))
+	closeAll := func() {
+		for _, r := range rr {
+			_ = r.db.Close()
+		}
+	}
+	now := time.Now()
+	for _, d := range intervals {
+		interval, err := pbv1.DurationOf(d)
+		if err != nil {
+			closeAll()
+			return nil, errors.WithMessagef(ErrInvalidRollup, "%s: %v", d, err)
+		}
+		partitioner, err := tsdb.NewIntervalPartitioner(24 * interval)
+		if err != nil {
+			closeAll()
+			return nil, err
+		}
+		location := filepath.Join(root, "rollup", sm.group, sm.name, interval.String())
+		// a rematerialized interval replaces its aggregates rather than adding up to them whatever the measure's engine is
+		db, err := tsdb.OpenDatabase(ctx, tsdb.DatabaseOpts{
+			Location: location,
+			ShardNum: sm.schema.GetOpts().GetShardNum(),
+			EncodingMethod: tsdb.EncodingMethod{
+				EncoderPool: encoding.NewPlainEncoderPool(chunkSize),
+				DecoderPool: encoding.NewPlainDecoderPool(chunkSize),
+			},
+			StorageEngine: databasev1.StorageEngine_STORAGE_ENGINE_OVERWRITE,
+			Partitioner:   partitioner,
+		})
+		if err != nil {
+			closeAll()
+			return nil, err
+		}
+		from, watermark, err := loadRollupState(location, interval, now)
+		if err != nil {
+			_ = db.Close()
+			closeAll()
+			return nil, err
+		}
+		rr = append(rr, &rollup{
+			interval:  interval,
+			db:        db,
+			location:  location,
+			from:      from,
+			watermark: watermark,
+		})
+	}
+	// the coarsest one goes first
+	sort.Slice(rr, func(i, j int) bool {
+		return rr[i].interval > rr[j].interval
+	})
+	return rr, nil
+}
+
+// intFields returns the fields which could be aggregated
+func (s *measure) intFields() []*databasev1.FieldSpec {
+	fields := make([]*databasev1.FieldSpec, 0, len(s.schema.GetFields()))
+	for _, f := range s.schema.GetFields() {
+		if f.GetFieldType() == databasev1.FieldType_FIELD_TYPE_INT {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+func (s *measure) startRollups() {
+	if len(s.rollups) < 1 {
+		return
+	}
+	s.rollupStopCh = make(chan struct{})
+	s.rollupWg.Add(1)
+	go func() {
+		defer s.rollupWg.Done()
+		ticker := time.NewTicker(rollupCheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-s.rollupStopCh:
+				return
+			case now := <-ticker.C:
+				if err := s.materialize(now); err != nil {
+					s.l.Warn().Err(err).Str("measure", s.name).Msg("failed to materialize rollups")
+				}
+			}
+		}
+	}()
+}
+
+func (s *measure) closeRollups() (err error) {
+	if s.rollupStopCh != nil {
+		close(s.rollupStopCh)
+		s.rollupWg.Wait()
+	}
+	for _, r := range s.rollups {
+		err = multierr.Append(err, r.db.Close())
+	}
+	return err
+}
+
+// materialize aggregates the raw data points of the intervals completed before now.
+// The data points arriving after their intervals are materialized are absent from the rollups.
+func (s *measure) materialize(now time.Time) (err error) {
+	fields := s.intFields()
+	if len(fields) < 1 {
+		return nil
+	}
+	for _, r := range s.rollups {
+		err = multierr.Append(err, s.materializeRollup(r, fields, now))
+	}
+	return err
+}
+
+// materializeRollup holds the lock of the watermark only to advance it, so the aggregations keep reading the
+// materialized intervals during the scan.
+func (s *measure) materializeRollup(r *rollup, fields []*databasev1.FieldSpec, now time.Time) error {
+	r.jobMu.Lock()
+	defer r.jobMu.Unlock()
+	start, end := r.materialized(), now.Truncate(r.interval)
+	if !end.After(start) {
+		return nil
+	}
+	timeRange := tsdb.NewTimeRange(start, end)
+	path := tsdb.NewPath(make([]tsdb.Entry, len(s.schema.GetEntity().GetTagNames())))
+	for _, shard := range s.db.Shards() {
+		seriesList, err := shard.Series().List(path)
+		if err != nil {
+			return err
+		}
+		rollupShard, err := r.db.Shard(shard.ID())
+		if err != nil {
+			return err
+		}
+		for _, series := range seriesList {
+			buckets, err := s.aggregateRaw(series, timeRange, r.interval, fields, nil)
+			if err != nil {
+				return err
+			}
+			if len(buckets) < 1 {
+				continue
+			}
+			rollupSeries, err := rollupShard.Series().GetByID(series.ID())
+			if err != nil {
+				return err
+			}
+			for ts, aggregations := range buckets {
+				if err := writeRollupPoint(rollupSeries, ts, aggregations); err != nil {
+					return errors.WithMessagef(err, "rollup %s of series %d at %s", r.interval, series.ID(), ts)
+				}
+			}
+		}
+	}
+	s.l.Debug().
+		Str("measure", s.name).
+		Dur("interval", r.interval).
+		Times("time_range", []time.Time{timeRange.Start, timeRange.End}).
+		Msg("materialized the rollup")
+	return errors.WithMessagef(r.advance(end), "failed to persist the watermark of the rollup %s", r.interval)
+}
+
+// aggregateRaw reads the raw data points of a series and aggregates them into the buckets sized by interval.
+// The points read are counted by reads if it's present.
+func (s *measure) aggregateRaw(series tsdb.Series, timeRange tsdb.TimeRange, interval time.Duration,
+	fields []*databasev1.FieldSpec, reads *uint64) (map[time.Time]map[string]*aggregation, error) {
+	buckets := make(map[time.Time]map[string]*aggregation)
+	err := s.scan(series, timeRange, func(item tsdb.Item) error {
+		if reads != nil {
+			atomic.AddUint64(reads, 1)
+		}
+		ts := time.Unix(0, int64(item.Time())).Truncate(interval)
+		bucket, ok := buckets[ts]
+		if !ok {
+			bucket = make(map[string]*aggregation, len(fields))
+			buckets[ts] = bucket
+		}
+		for _, f := range fields {
+			data, errFamily := item.Family(string(familyIdentity(f.GetName(), encoderFieldFlag(f))))
+			if errFamily != nil || len(data) != 8 {
+				// the field is absent from this data point
+				continue
+			}
+			a, ok := bucket[f.GetName()]
+			if !ok {
+				a = &aggregation{}
+				bucket[f.GetName()] = a
+			}
+			a.add(convert.BytesToInt64(data))
+		}
+		return nil
+	})
+	return buckets, err
+}
+
+func (s *measure) scan(series tsdb.Series, timeRange tsdb.TimeRange, fn func(item tsdb.Item) error) error {
+	span, err := series.Span(timeRange)
+	if err != nil {
+		if errors.Is(err, tsdb.ErrEmptySeriesSpan) {
+			return nil
+		}
+		return err
+	}
+	defer func() {
+		_ = span.Close()
+	}()
+	seeker, err := span.SeekerBuilder().OrderByTime(modelv1.Sort_SORT_ASC).Build()
+	if err != nil {
+		return err
+	}
+	iters, err := seeker.Seek()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		for _, iter := range iters {
+			_ = iter.Close()
+		}
+	}()
+	for _, iter := range iters {
+		for iter.Next() {
+			if err := fn(iter.Val()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func writeRollupPoint(series tsdb.Series, ts time.Time, aggregations map[string]*aggregation) error {
+	if len(aggregations) < 1 {
+		return nil
+	}
+	span, err := series.Span(tsdb.NewTimeRangeDuration(ts, 0))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = span.Close()
+	}()
+	builder := span.WriterBuilder().Time(ts)
+	for name, a := range aggregations {
+		builder.Family(familyIdentity(name, rollupFlag), a.marshal())
+	}
+	writer, err := builder.Build()
+	if err != nil {
+		return err
+	}
+	_, err = writer.Write()
+	return err
+}
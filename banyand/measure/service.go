@@ -89,7 +89,7 @@ func (s *service) Name() string {
 }
 
 func (s *service) PreRun() error {
-	schemas, err := s.metadata.MeasureRegistry().ListMeasure(context.TODO(), schema.ListOpt{})
+	schemas, _, err := s.metadata.MeasureRegistry().ListMeasure(context.TODO(), schema.ListOpt{})
 	if err != nil {
 		return err
 	}
@@ -133,9 +133,11 @@ func (s *service) Serve() error {
 				Name:  sMeta.name,
 				Group: sMeta.group,
 			},
-			EntityLocator: locator,
-			Time:          nowBp,
-			Action:        databasev1.Action_ACTION_PUT,
+			EntityLocator:     locator,
+			ShardOverrides:    sMeta.schema.GetOpts().GetShardOverrides(),
+			ShardingAlgorithm: sMeta.schema.GetOpts().GetShardingAlgorithm(),
+			Time:              nowBp,
+			Action:            databasev1.Action_ACTION_PUT,
 		}))
 		if err != nil {
 			return err
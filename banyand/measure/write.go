@@ -27,6 +27,7 @@ import (
 	databasev1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/database/v1"
 	measurev1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/measure/v1"
 	modelv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/model/v1"
+	"github.com/apache/skywalking-banyandb/banyand/queue"
 	"github.com/apache/skywalking-banyandb/banyand/tsdb"
 	"github.com/apache/skywalking-banyandb/banyand/tsdb/index"
 	"github.com/apache/skywalking-banyandb/pkg/bus"
@@ -44,12 +45,15 @@ const (
 )
 
 func (s *measure) Write(value *measurev1.DataPointValue) error {
-	entity, shardID, err := s.entityLocator.Locate(value.GetTagFamilies(), s.schema.GetOpts().GetShardNum())
+	entity, shardID, err := s.entityLocator.Locate(value.GetTagFamilies(), s.schema.GetOpts().GetShardNum(), s.shardOverrides,
+		s.shardingStrategy)
 	if err != nil {
 		return err
 	}
 	waitCh := make(chan struct{})
-	err = s.write(shardID, tsdb.HashEntity(entity), value, func() {
+	var errIndex error
+	err = s.write(shardID, tsdb.HashEntity(entity), value, func(errCb error) {
+		errIndex = errCb
 		close(waitCh)
 	})
 	if err != nil {
@@ -57,7 +61,7 @@ func (s *measure) Write(value *measurev1.DataPointValue) error {
 		return err
 	}
 	<-waitCh
-	return nil
+	return errIndex
 }
 
 func (s *measure) write(shardID common.ShardID, seriesHashKey []byte, value *measurev1.DataPointValue, cb index.CallbackFn) error {
@@ -73,9 +77,10 @@ func (s *measure) write(shardID common.ShardID, seriesHashKey []byte, value *mea
 	if err != nil {
 		return err
 	}
+	// the failures of the storage are marked to be counted by the breaker of the queue
 	series, err := shard.Series().GetByHashKey(seriesHashKey)
 	if err != nil {
-		return err
+		return queue.StorageFailure(err)
 	}
 	t := value.GetTimestamp().AsTime()
 	wp, err := series.Span(tsdb.NewTimeRangeDuration(t, 0))
@@ -83,7 +88,7 @@ func (s *measure) write(shardID common.ShardID, seriesHashKey []byte, value *mea
 		if wp != nil {
 			_ = wp.Close()
 		}
-		return err
+		return queue.StorageFailure(err)
 	}
 	writeFn := func() (tsdb.Writer, error) {
 		builder := wp.WriterBuilder().Time(t)
@@ -125,12 +130,16 @@ func (s *measure) write(shardID common.ShardID, seriesHashKey []byte, value *mea
 				continue
 			}
 			builder.Family(familyIdentity(sm.GetFields()[fi].GetName(), encoderFieldFlag(fieldSpec)), data)
+			if fType == databasev1.FieldType_FIELD_TYPE_INT {
+				builder.Stat(fieldSpec.GetName(), fieldValue.GetInt().GetValue())
+			}
 		}
 		writer, errWrite := builder.Build()
 		if errWrite != nil {
 			return nil, errWrite
 		}
 		_, errWrite = writer.Write()
+		errWrite = queue.StorageFailure(errWrite)
 		s.l.Debug().
 			Time("ts", t).
 			Int("ts_nano", t.Nanosecond()).
@@ -182,6 +191,7 @@ func (w *writeCallback) Rev(message bus.Message) (resp bus.Message) {
 	err := w.schemaMap[id].write(common.ShardID(writeEvent.GetShardId()), writeEvent.GetSeriesHash(), writeEvent.GetRequest().GetDataPoint(), nil)
 	if err != nil {
 		w.l.Debug().Err(err)
+		return bus.NewMessage(message.ID(), err)
 	}
 	return
 }
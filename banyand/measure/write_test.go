@@ -21,7 +21,9 @@ import (
 	"context"
 	"embed"
 	"encoding/json"
+	"fmt"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -31,8 +33,11 @@ import (
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	commonv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/common/v1"
+	databasev1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/database/v1"
 	measurev1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/measure/v1"
+	modelv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/model/v1"
 	"github.com/apache/skywalking-banyandb/banyand/metadata"
+	"github.com/apache/skywalking-banyandb/banyand/tsdb"
 	"github.com/apache/skywalking-banyandb/pkg/logger"
 	"github.com/apache/skywalking-banyandb/pkg/test"
 	testmeasure "github.com/apache/skywalking-banyandb/pkg/test/measure"
@@ -44,7 +49,220 @@ func Test_Measure_Write(t *testing.T) {
 	writeData(t, "write_data.json", s)
 }
 
+func Test_Measure_Partition(t *testing.T) {
+	r := require.New(t)
+	s, root, deferFunc := setupWithSchema(t, func(schema *databasev1.Measure) {
+		schema.PartitionInterval = &databasev1.Duration{
+			Val:  1,
+			Unit: databasev1.Duration_DURATION_UNIT_HOUR,
+		}
+	})
+	defer deferFunc()
+	boundary := time.Now().Truncate(time.Hour)
+	points := []time.Time{boundary.Add(-time.Second), boundary.Add(time.Second)}
+	tagFamilies := []*modelv1.TagFamilyForWrite{{
+		Tags: []*modelv1.TagValue{
+			{Value: &modelv1.TagValue_Str{Str: &modelv1.Str{Value: "1"}}},
+			{Value: &modelv1.TagValue_Str{Str: &modelv1.Str{Value: "minute"}}},
+		},
+	}}
+	for i, ts := range points {
+		r.NoError(s.Write(&measurev1.DataPointValue{
+			Timestamp:   timestamppb.New(ts),
+			TagFamilies: tagFamilies,
+			Fields: []*modelv1.FieldValue{
+				{Value: &modelv1.FieldValue_Int{Int: &modelv1.Int{Value: int64(i)}}},
+			},
+		}))
+	}
+
+	_, shardID, err := s.entityLocator.Locate(tagFamilies, s.schema.GetOpts().GetShardNum(), s.shardOverrides,
+		s.shardingStrategy)
+	r.NoError(err)
+	for _, ts := range points {
+		segment := filepath.Join(root, fmt.Sprintf("shard-%d", shardID),
+			"seg-"+ts.UTC().Truncate(time.Hour).Format("200601021504"))
+		r.DirExists(segment, "no partition holds %s", ts)
+	}
+	shard, err := s.Shard(shardID)
+	r.NoError(err)
+	series, err := shard.Series().Get(tsdb.Entity{tsdb.Entry("1")})
+	r.NoError(err)
+	span, err := series.Span(tsdb.NewTimeRangeDuration(boundary.Add(-time.Hour), 2*time.Hour))
+	r.NoError(err)
+	defer func() {
+		_ = span.Close()
+	}()
+	seeker, err := span.SeekerBuilder().OrderByTime(modelv1.Sort_SORT_ASC).Build()
+	r.NoError(err)
+	iters, err := seeker.Seek()
+	r.NoError(err)
+	var got []uint64
+	for _, iter := range iters {
+		for iter.Next() {
+			got = append(got, iter.Val().Time())
+		}
+		r.NoError(iter.Close())
+	}
+	r.Equal([]uint64{uint64(points[0].UnixNano()), uint64(points[1].UnixNano())}, got)
+}
+
+func Test_Measure_Rollup(t *testing.T) {
+	r := require.New(t)
+	s, _, deferFunc := setupWithSchema(t, func(schema *databasev1.Measure) {
+		schema.PartitionInterval = &databasev1.Duration{
+			Val:  1,
+			Unit: databasev1.Duration_DURATION_UNIT_HOUR,
+		}
+		schema.RollupIntervals = []*databasev1.Duration{
+			{Val: 1, Unit: databasev1.Duration_DURATION_UNIT_HOUR},
+			{Val: 1, Unit: databasev1.Duration_DURATION_UNIT_DAY},
+		}
+		// the rollups overwrite their aggregates anyway
+		schema.Opts.StorageEngine = databasev1.StorageEngine_STORAGE_ENGINE_APPEND
+	})
+	defer deferFunc()
+	base := time.Now().Truncate(time.Hour).Add(-3 * time.Hour)
+	var expected []AggregatedPoint
+	for h := 0; h < 3; h++ {
+		bucket := AggregatedPoint{Timestamp: base.Add(time.Duration(h) * time.Hour)}
+		for k := 0; k < 3; k++ {
+			v := int64(h*10 + k)
+			r.NoError(s.Write(&measurev1.DataPointValue{
+				Timestamp: timestamppb.New(bucket.Timestamp.Add(time.Duration(k) * 10 * time.Minute)),
+				TagFamilies: []*modelv1.TagFamilyForWrite{{
+					Tags: []*modelv1.TagValue{
+						{Value: &modelv1.TagValue_Str{Str: &modelv1.Str{Value: "1"}}},
+						{Value: &modelv1.TagValue_Str{Str: &modelv1.Str{Value: "minute"}}},
+					},
+				}},
+				Fields: []*modelv1.FieldValue{
+					{Value: &modelv1.FieldValue_Int{Int: &modelv1.Int{Value: v}}},
+				},
+			}))
+			bucket.Sum += v
+			bucket.Count++
+			if k == 0 {
+				bucket.Min = v
+			}
+			bucket.Max = v
+		}
+		expected = append(expected, bucket)
+	}
+	req := AggregateRequest{
+		Entity:     tsdb.Entity{tsdb.Entry("1")},
+		TimeRange:  tsdb.NewTimeRangeDuration(base, 3*time.Hour),
+		Field:      "summation",
+		Resolution: time.Hour,
+	}
+
+	// none of the rollups is materialized yet, and each hour's block is served by its statistics
+	got, err := s.Aggregate(req)
+	r.NoError(err)
+	r.Equal(expected, got)
+	r.Equal(uint64(0), s.rawReads)
+	r.Equal(uint64(0), s.rollupReads)
+
+	r.NoError(s.materialize(time.Now()))
+	got, err = s.Aggregate(req)
+	r.NoError(err)
+	r.Equal(expected, got)
+	r.Equal(uint64(0), s.rawReads)
+	r.Equal(uint64(3), s.rollupReads)
+
+	// the watermarks survive the restarts
+	for _, rollup := range s.rollups {
+		from, watermark, errState := loadRollupState(rollup.location, rollup.interval, time.Now().Add(time.Hour))
+		r.NoError(errState)
+		r.True(from.Equal(rollup.from))
+		r.True(watermark.Equal(rollup.materialized()))
+	}
+
+	// materializing the intervals again doesn't add up to their aggregates
+	for _, rollup := range s.rollups {
+		rollup.watermark = rollup.from
+	}
+	r.NoError(s.materialize(time.Now()))
+	got, err = s.Aggregate(req)
+	r.NoError(err)
+	r.Equal(expected, got)
+	r.Equal(uint64(6), s.rollupReads)
+
+	_, err = s.Aggregate(AggregateRequest{Field: "summation"})
+	r.ErrorIs(err, ErrInvalidResolution)
+}
+
+func Test_Measure_AggregateBlockStats(t *testing.T) {
+	r := require.New(t)
+	s, _, deferFunc := setupWithSchema(t, func(schema *databasev1.Measure) {
+		schema.PartitionInterval = &databasev1.Duration{
+			Val:  1,
+			Unit: databasev1.Duration_DURATION_UNIT_HOUR,
+		}
+	})
+	defer deferFunc()
+	base := time.Now().Truncate(time.Hour).Add(-3 * time.Hour)
+	write := func(ts time.Time, v int64) {
+		r.NoError(s.Write(&measurev1.DataPointValue{
+			Timestamp: timestamppb.New(ts),
+			TagFamilies: []*modelv1.TagFamilyForWrite{{
+				Tags: []*modelv1.TagValue{
+					{Value: &modelv1.TagValue_Str{Str: &modelv1.Str{Value: "1"}}},
+					{Value: &modelv1.TagValue_Str{Str: &modelv1.Str{Value: "minute"}}},
+				},
+			}},
+			Fields: []*modelv1.FieldValue{
+				{Value: &modelv1.FieldValue_Int{Int: &modelv1.Int{Value: v}}},
+			},
+		}))
+	}
+	for h := 0; h < 3; h++ {
+		for k := 0; k < 3; k++ {
+			write(base.Add(time.Duration(h)*time.Hour+time.Duration(k)*20*time.Minute), int64(h*10+k))
+		}
+	}
+	aggregate := func(timeRange tsdb.TimeRange, resolution time.Duration) []AggregatedPoint {
+		s.rawReads = 0
+		got, err := s.Aggregate(AggregateRequest{
+			Entity:     tsdb.Entity{tsdb.Entry("1")},
+			TimeRange:  timeRange,
+			Field:      "summation",
+			Resolution: resolution,
+		})
+		r.NoError(err)
+		return got
+	}
+
+	got := aggregate(tsdb.NewTimeRangeDuration(base, 3*time.Hour), time.Hour)
+	r.Equal([]AggregatedPoint{
+		{Timestamp: base, Sum: 3, Count: 3, Min: 0, Max: 2},
+		{Timestamp: base.Add(time.Hour), Sum: 33, Count: 3, Min: 10, Max: 12},
+		{Timestamp: base.Add(2 * time.Hour), Sum: 63, Count: 3, Min: 20, Max: 22},
+	}, got)
+	r.Equal(uint64(0), s.rawReads, "the fully covered blocks are served by their statistics")
+
+	got = aggregate(tsdb.NewTimeRangeDuration(base.Add(10*time.Minute), 2*time.Hour), time.Hour)
+	r.Equal([]AggregatedPoint{
+		{Timestamp: base, Sum: 3, Count: 2, Min: 1, Max: 2},
+		{Timestamp: base.Add(time.Hour), Sum: 33, Count: 3, Min: 10, Max: 12},
+		{Timestamp: base.Add(2 * time.Hour), Sum: 20, Count: 1, Min: 20, Max: 20},
+	}, got)
+	r.Equal(uint64(3), s.rawReads, "only the partially covered blocks are read")
+
+	got = aggregate(tsdb.NewTimeRangeDuration(base, time.Hour), 30*time.Minute)
+	r.Equal([]AggregatedPoint{
+		{Timestamp: base, Sum: 1, Count: 2, Min: 0, Max: 1},
+		{Timestamp: base.Add(30 * time.Minute), Sum: 2, Count: 1, Min: 2, Max: 2},
+	}, got)
+	r.Equal(uint64(3), s.rawReads, "a block spanning several buckets is read")
+}
+
 func setup(t *testing.T) (*measure, func()) {
+	s, _, deferFunc := setupWithSchema(t, nil)
+	return s, deferFunc
+}
+
+func setupWithSchema(t *testing.T, schemaFn func(schema *databasev1.Measure)) (*measure, string, func()) {
 	req := require.New(t)
 	req.NoError(logger.Init(logger.Logging{
 		Env:   "dev",
@@ -70,6 +288,9 @@ func setup(t *testing.T) (*measure, func()) {
 		Group: "default",
 	})
 	req.NoError(err)
+	if schemaFn != nil {
+		schemaFn(sa)
+	}
 	iRules, err := mService.IndexRules(context.TODO(), sa.Metadata)
 	req.NoError(err)
 	sSpec := measureSpec{
@@ -78,7 +299,7 @@ func setup(t *testing.T) (*measure, func()) {
 	}
 	s, err := openMeasure(tempDir, sSpec, logger.GetLogger("test"))
 	req.NoError(err)
-	return s, func() {
+	return s, tempDir, func() {
 		_ = s.Close()
 		mService.GracefulStop()
 		deferFunc()
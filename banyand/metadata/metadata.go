@@ -56,11 +56,24 @@ type Service interface {
 type service struct {
 	schemaRegistry schema.Registry
 	rootDir        string
+	compressValues bool
+	etcdEndpoints  []string
+	// deferBindingValidation lets the bindings be written before their index rules
+	deferBindingValidation bool
+	// cacheMaxStaleness is how long the cached schemas are served after losing etcd
+	cacheMaxStaleness time.Duration
 }
 
 func (s *service) FlagSet() *run.FlagSet {
 	fs := run.NewFlagSet("metadata")
 	fs.StringVarP(&s.rootDir, "metadata-root-path", "", "/tmp", "the root path of metadata")
+	fs.BoolVarP(&s.compressValues, "metadata-compress-values", "", false, "compress the schemas stored in etcd")
+	fs.StringSliceVarP(&s.etcdEndpoints, "metadata-etcd-endpoints", "", nil,
+		"the endpoints of an external etcd cluster, which replaces the embedded one")
+	fs.BoolVarP(&s.deferBindingValidation, "metadata-defer-binding-validation", "", false,
+		"warn about the index rule bindings referring to absent index rules instead of rejecting them")
+	fs.DurationVarP(&s.cacheMaxStaleness, "metadata-cache-max-staleness", "", time.Minute,
+		"how long the cached schemas are served once etcd is unavailable, and zero disables serving the stale ones")
 	return fs
 }
 
@@ -72,13 +85,20 @@ func (s *service) Validate() error {
 }
 
 func (s *service) PreRun() error {
-	var err error
-	s.schemaRegistry, err = schema.NewEtcdSchemaRegistry(schema.UseRandomListener(),
-		schema.RootDir(s.rootDir))
+	opts := []schema.RegistryOption{schema.UseRandomListener(),
+		schema.RootDir(s.rootDir), schema.CompressValues(s.compressValues),
+		schema.DeferBindingValidation(s.deferBindingValidation)}
+	if len(s.etcdEndpoints) > 0 {
+		opts = append(opts, schema.UseEndpoints(s.etcdEndpoints, nil))
+	}
+	registry, err := schema.NewEtcdSchemaRegistry(opts...)
 	if err != nil {
 		return err
 	}
-	<-s.schemaRegistry.ReadyNotify()
+	<-registry.ReadyNotify()
+	if s.schemaRegistry, err = schema.NewEtcdCachedRegistry(registry, schema.MaxStaleness(s.cacheMaxStaleness)); err != nil {
+		return multierr.Append(err, registry.Close())
+	}
 	return nil
 }
 
@@ -125,36 +145,5 @@ func (s *service) Name() string {
 }
 
 func (s *service) IndexRules(ctx context.Context, subject *commonv1.Metadata) ([]*databasev1.IndexRule, error) {
-	bindings, err := s.schemaRegistry.ListIndexRuleBinding(ctx, schema.ListOpt{Group: subject.Group})
-	if err != nil {
-		return nil, err
-	}
-	now := time.Now()
-	foundRules := make([]string, 0)
-	for _, binding := range bindings {
-		if binding.GetBeginAt().AsTime().After(now) ||
-			binding.GetExpireAt().AsTime().Before(now) {
-			continue
-		}
-		sub := binding.GetSubject()
-		if sub.Name != subject.Name {
-			continue
-		}
-		foundRules = append(foundRules, binding.Rules...)
-	}
-	result := make([]*databasev1.IndexRule, 0, len(foundRules))
-	var indexRuleErr error
-	for _, rule := range foundRules {
-		r, getErr := s.schemaRegistry.GetIndexRule(ctx, &commonv1.Metadata{
-			Name:  rule,
-			Group: subject.Group,
-		})
-		if getErr != nil {
-			indexRuleErr = multierr.Append(indexRuleErr, err)
-			continue
-		}
-		result = append(result, r)
-
-	}
-	return result, indexRuleErr
+	return schema.BoundIndexRules(ctx, s.schemaRegistry, subject)
 }
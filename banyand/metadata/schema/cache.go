@@ -0,0 +1,650 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package schema
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"google.golang.org/protobuf/proto"
+
+	commonv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/common/v1"
+	databasev1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/database/v1"
+)
+
+var (
+	_ Registry = (*CachedRegistry)(nil)
+
+	ErrUnsupportedRegistry = errors.New("the registry doesn't support caching")
+
+	errCompacted = errors.New("the revision is compacted")
+	// cacheKeyPrefix covers all the schemas in the current key format
+	cacheKeyPrefix = currentKey(GroupsKeyPrefix)
+	// cacheRetryInterval is the backoff of rebuilding the cache after a failure
+	cacheRetryInterval = time.Second
+	// defaultMaxStaleness is how long the cached schemas are served after losing the backend
+	defaultMaxStaleness = time.Minute
+	// cacheSyncTimeout bounds how long a write through the cache waits for the cache to observe it
+	cacheSyncTimeout = 5 * time.Second
+)
+
+// CachedRegistry serves the gets and the lists of the schemas from memory.
+// The cache is bootstrapped by a full list capturing the revision, and then applies the deltas watched from the next revision.
+// It's rebuilt from scratch only if the revision it resumes from is compacted.
+// The cache is eventually consistent: a get falls back to the registry if the key isn't cached yet,
+// or it's in the legacy format. The results of the lists are kept until the next delta.
+// The writes through the cache, and the events of its Watch, are returned once the cache observes them,
+// so the reads following them see the changes.
+// While the watch is broken, a get or a list reads through the registry, and a get serves the last-known value
+// if the registry fails as well, up to the max staleness since the watch broke.
+type CachedRegistry struct {
+	Registry
+	kv      clientv3.KV
+	watcher clientv3.Watcher
+
+	mu       sync.RWMutex
+	entries  map[string]keyValue
+	pages    map[listKey]interface{}
+	revision int64
+	// progress is closed and replaced whenever the revision moves or the watch breaks
+	progress chan struct{}
+	// generation increases on each change of the entries, so a page listed across a change isn't cached
+	generation uint64
+	// brokenAt is when the cache lost the sync with the backend, and it's zero while in sync
+	brokenAt     time.Time
+	maxStaleness time.Duration
+	now          func() time.Time
+	// bootstraps counts the full lists to bootstrap the cache
+	bootstraps uint64
+	// hits and misses count the reads served by the cache and the ones reading through the registry
+	hits   uint64
+	misses uint64
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// listKey identifies a page of a list. The prefix is the entity prefix of the listed kind,
+// or GroupsKeyPrefix for the list of groups.
+type listKey struct {
+	prefix string
+	opt    ListOpt
+}
+
+type listResult struct {
+	messages []proto.Message
+	next     string
+}
+
+type CacheOption func(*CachedRegistry)
+
+// MaxStaleness sets how long the cached schemas are served after both the watch and the registry fail.
+// Zero disables serving the stale schemas.
+func MaxStaleness(maxStaleness time.Duration) CacheOption {
+	return func(c *CachedRegistry) {
+		c.maxStaleness = maxStaleness
+	}
+}
+
+// NewEtcdCachedRegistry bootstraps the cache of the registry, which has to be backed by etcd.
+// Closing the returned registry closes r as well.
+func NewEtcdCachedRegistry(r Registry, options ...CacheOption) (*CachedRegistry, error) {
+	c, err := newCachedRegistry(r)
+	if err != nil {
+		return nil, err
+	}
+	for _, opt := range options {
+		opt(c)
+	}
+	if err = c.list(context.Background()); err != nil {
+		return nil, err
+	}
+	c.start()
+	return c, nil
+}
+
+func newCachedRegistry(r Registry) (*CachedRegistry, error) {
+	e, ok := r.(*etcdSchemaRegistry)
+	if !ok {
+		return nil, errors.Wrapf(ErrUnsupportedRegistry, "%T", r)
+	}
+	return &CachedRegistry{
+		Registry: r,
+		kv:       e.kv,
+		watcher:  e.watcher,
+		entries:  make(map[string]keyValue),
+		pages:    make(map[listKey]interface{}),
+		progress: make(chan struct{}),
+
+		maxStaleness: defaultMaxStaleness,
+		now:          time.Now,
+	}, nil
+}
+
+// Stats returns the number of the reads served by the cache and the ones reading through the registry
+func (c *CachedRegistry) Stats() (hits, misses uint64) {
+	return atomic.LoadUint64(&c.hits), atomic.LoadUint64(&c.misses)
+}
+
+// list rebuilds the cache from scratch
+func (c *CachedRegistry) list(ctx context.Context) error {
+	resp, err := c.kv.Get(ctx, cacheKeyPrefix, clientv3.WithPrefix())
+	if err != nil {
+		return err
+	}
+	entries := make(map[string]keyValue, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		key := strings.TrimPrefix(string(kv.Key), currentKeyRoot)
+		entries[key] = keyValue{key: key, value: kv.Value, createRevision: kv.CreateRevision, modRevision: kv.ModRevision}
+	}
+	c.mu.Lock()
+	c.entries = entries
+	c.brokenAt = time.Time{}
+	c.changedLocked()
+	c.advanceLocked(resp.Header.Revision)
+	c.mu.Unlock()
+	atomic.AddUint64(&c.bootstraps, 1)
+	return nil
+}
+
+// changedLocked drops the cached pages, which the caller should hold the lock of c for
+func (c *CachedRegistry) changedLocked() {
+	c.generation++
+	c.pages = make(map[listKey]interface{})
+}
+
+// advanceLocked moves the revision forward and wakes up its waiters, which the caller should hold the lock of c for
+func (c *CachedRegistry) advanceLocked(rev int64) {
+	c.revision = rev
+	close(c.progress)
+	c.progress = make(chan struct{})
+}
+
+func (c *CachedRegistry) start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	c.cancel = cancel
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		for {
+			err := c.watch(ctx)
+			if ctx.Err() != nil {
+				return
+			}
+			c.markBroken()
+			if errors.Is(err, errCompacted) {
+				err = c.list(ctx)
+			}
+			if err == nil {
+				continue
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(cacheRetryInterval):
+			}
+		}
+	}()
+}
+
+// watch applies the deltas after the cached revision until the watch channel is closed
+func (c *CachedRegistry) watch(ctx context.Context) error {
+	c.mu.RLock()
+	rev := c.revision
+	c.mu.RUnlock()
+	wch := c.watcher.Watch(clientv3.WithRequireLeader(ctx), cacheKeyPrefix, clientv3.WithPrefix(), clientv3.WithRev(rev+1),
+		clientv3.WithCreatedNotify())
+	for resp := range wch {
+		if resp.CompactRevision != 0 {
+			return errors.Wrapf(errCompacted, "revision %d, compacted %d", rev, resp.CompactRevision)
+		}
+		if err := resp.Err(); err != nil {
+			return err
+		}
+		if resp.Created {
+			// the deltas after the cached revision are delivered by the established watch
+			c.markSynced()
+			continue
+		}
+		if resp.IsProgressNotify() {
+			// all the deltas up to the revision are applied
+			c.progressed(resp.Header.Revision)
+			continue
+		}
+		c.apply(resp)
+	}
+	return nil
+}
+
+func (c *CachedRegistry) markBroken() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.brokenAt.IsZero() {
+		c.brokenAt = c.now()
+		c.changedLocked()
+		c.advanceLocked(c.revision)
+	}
+}
+
+func (c *CachedRegistry) markSynced() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.brokenAt = time.Time{}
+}
+
+func (c *CachedRegistry) apply(resp clientv3.WatchResponse) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, ev := range resp.Events {
+		key := strings.TrimPrefix(string(ev.Kv.Key), currentKeyRoot)
+		switch ev.Type {
+		case clientv3.EventTypePut:
+			c.entries[key] = keyValue{key: key, value: ev.Kv.Value, createRevision: ev.Kv.CreateRevision,
+				modRevision: ev.Kv.ModRevision}
+		case clientv3.EventTypeDelete:
+			delete(c.entries, key)
+		}
+	}
+	c.changedLocked()
+	if resp.Header.Revision > c.revision {
+		c.advanceLocked(resp.Header.Revision)
+	}
+}
+
+func (c *CachedRegistry) progressed(rev int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if rev > c.revision {
+		c.advanceLocked(rev)
+	}
+}
+
+// waitRevision blocks until the cache applies the deltas up to rev, or its watch breaks which makes
+// the reads go through the registry. It returns false if ctx is done before that.
+func (c *CachedRegistry) waitRevision(ctx context.Context, rev int64) bool {
+	for {
+		c.mu.RLock()
+		done, progress := c.revision >= rev || !c.brokenAt.IsZero(), c.progress
+		c.mu.RUnlock()
+		if done {
+			return true
+		}
+		select {
+		case <-ctx.Done():
+			return false
+		case <-progress:
+		}
+	}
+}
+
+// written waits for the cache to observe a successful write, which is returned anyway once the wait times out
+func (c *CachedRegistry) written(ctx context.Context, err error) error {
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, cacheSyncTimeout)
+	defer cancel()
+	resp, errRev := c.kv.Get(ctx, KeyFormatMarker, clientv3.WithCountOnly())
+	if errRev != nil {
+		return nil
+	}
+	// the revision might be moved by the keys out of the cache, whose progress is reported on request
+	_ = c.watcher.RequestProgress(clientv3.WithRequireLeader(ctx))
+	c.waitRevision(ctx, resp.Header.Revision)
+	return nil
+}
+
+// Watch emits the events of the registry once the cache observes them,
+// so the reads reacting to an event see the change
+func (c *CachedRegistry) Watch(ctx context.Context, opt WatchOpt) (<-chan Event, error) {
+	events, err := c.Registry.Watch(ctx, opt)
+	if err != nil {
+		return nil, err
+	}
+	ch := make(chan Event)
+	go func() {
+		defer close(ch)
+		for event := range events {
+			if !c.waitRevision(ctx, event.Revision) {
+				return
+			}
+			select {
+			case ch <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch, nil
+}
+
+func (c *CachedRegistry) load(key string, message proto.Message) bool {
+	c.mu.RLock()
+	kv, ok := c.entries[key]
+	c.mu.RUnlock()
+	if !ok || decodeValue(kv.value, message) != nil {
+		return false
+	}
+	setModRevision(message, kv.modRevision)
+	return true
+}
+
+// get loads the cached value into message, or gets it by fetch which returns the value from the registry.
+// It reports whether the value is the last-known one served on the failure of the registry.
+func (c *CachedRegistry) get(key string, message proto.Message, fetch func() (proto.Message, error)) (stale bool, err error) {
+	c.mu.RLock()
+	brokenAt := c.brokenAt
+	c.mu.RUnlock()
+	if brokenAt.IsZero() && c.load(key, message) {
+		atomic.AddUint64(&c.hits, 1)
+		return false, nil
+	}
+	atomic.AddUint64(&c.misses, 1)
+	entity, err := fetch()
+	if err == nil {
+		proto.Merge(message, entity)
+		return false, nil
+	}
+	if brokenAt.IsZero() || errors.Is(err, ErrEntityNotFound) {
+		return false, err
+	}
+	if c.now().Sub(brokenAt) > c.maxStaleness || !c.load(key, message) {
+		return false, err
+	}
+	return true, nil
+}
+
+// lookupPage returns the cached page of key, along with the generation to store the page fetched on a miss
+func (c *CachedRegistry) lookupPage(key listKey) (interface{}, uint64, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	page, ok := c.pages[key]
+	if ok && c.brokenAt.IsZero() {
+		atomic.AddUint64(&c.hits, 1)
+		return page, c.generation, true
+	}
+	atomic.AddUint64(&c.misses, 1)
+	return nil, c.generation, false
+}
+
+// storePage caches the page unless the entries change after the generation
+func (c *CachedRegistry) storePage(key listKey, generation uint64, page interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if generation != c.generation || !c.brokenAt.IsZero() {
+		return
+	}
+	c.pages[key] = page
+}
+
+// listPage returns the copies of the cached entities, or reads them through fetch
+func (c *CachedRegistry) listPage(key listKey, fetch func() (listResult, error)) ([]proto.Message, string, error) {
+	page, generation, ok := c.lookupPage(key)
+	if !ok {
+		result, err := fetch()
+		if err != nil {
+			return nil, "", err
+		}
+		c.storePage(key, generation, result)
+		page = result
+	}
+	result := page.(listResult)
+	messages := make([]proto.Message, 0, len(result.messages))
+	for _, m := range result.messages {
+		messages = append(messages, proto.Clone(m))
+	}
+	return messages, result.next, nil
+}
+
+func (c *CachedRegistry) GetGroup(ctx context.Context, group string) (*commonv1.Group, error) {
+	var entity commonv1.Group
+	if _, err := c.get(formatGroupKey(group), &entity, func() (proto.Message, error) {
+		return c.Registry.GetGroup(ctx, group)
+	}); err != nil {
+		return nil, err
+	}
+	return &entity, nil
+}
+
+func (c *CachedRegistry) ListGroup(ctx context.Context) ([]string, error) {
+	key := listKey{prefix: GroupsKeyPrefix}
+	page, generation, ok := c.lookupPage(key)
+	if ok {
+		return append([]string(nil), page.([]string)...), nil
+	}
+	groups, err := c.Registry.ListGroup(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.storePage(key, generation, append([]string(nil), groups...))
+	return groups, nil
+}
+
+func (c *CachedRegistry) GetStream(ctx context.Context, metadata *commonv1.Metadata) (*databasev1.Stream, error) {
+	entity, _, err := c.GetStreamOrStale(ctx, metadata)
+	return entity, err
+}
+
+// GetStreamOrStale is GetStream reporting whether the stream is the last-known one,
+// which is served because neither the watch nor the registry is available.
+func (c *CachedRegistry) GetStreamOrStale(ctx context.Context, metadata *commonv1.Metadata) (*databasev1.Stream, bool, error) {
+	var entity databasev1.Stream
+	stale, err := c.get(formatSteamKey(metadata), &entity, func() (proto.Message, error) {
+		return c.Registry.GetStream(ctx, metadata)
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return &entity, stale, nil
+}
+
+func (c *CachedRegistry) ListStream(ctx context.Context, opt ListOpt) ([]*databasev1.Stream, string, error) {
+	messages, next, err := c.listPage(listKey{prefix: StreamKeyPrefix, opt: opt}, func() (listResult, error) {
+		entities, n, errList := c.Registry.ListStream(ctx, opt)
+		result := listResult{messages: make([]proto.Message, 0, len(entities)), next: n}
+		for _, entity := range entities {
+			result.messages = append(result.messages, entity)
+		}
+		return result, errList
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	entities := make([]*databasev1.Stream, 0, len(messages))
+	for _, message := range messages {
+		entities = append(entities, message.(*databasev1.Stream))
+	}
+	return entities, next, nil
+}
+
+func (c *CachedRegistry) GetMeasure(ctx context.Context, metadata *commonv1.Metadata) (*databasev1.Measure, error) {
+	var entity databasev1.Measure
+	if _, err := c.get(formatMeasureKey(metadata), &entity, func() (proto.Message, error) {
+		return c.Registry.GetMeasure(ctx, metadata)
+	}); err != nil {
+		return nil, err
+	}
+	return &entity, nil
+}
+
+func (c *CachedRegistry) ListMeasure(ctx context.Context, opt ListOpt) ([]*databasev1.Measure, string, error) {
+	messages, next, err := c.listPage(listKey{prefix: MeasureKeyPrefix, opt: opt}, func() (listResult, error) {
+		entities, n, errList := c.Registry.ListMeasure(ctx, opt)
+		result := listResult{messages: make([]proto.Message, 0, len(entities)), next: n}
+		for _, entity := range entities {
+			result.messages = append(result.messages, entity)
+		}
+		return result, errList
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	entities := make([]*databasev1.Measure, 0, len(messages))
+	for _, message := range messages {
+		entities = append(entities, message.(*databasev1.Measure))
+	}
+	return entities, next, nil
+}
+
+func (c *CachedRegistry) GetIndexRule(ctx context.Context, metadata *commonv1.Metadata) (*databasev1.IndexRule, error) {
+	var entity databasev1.IndexRule
+	if _, err := c.get(formatIndexRuleKey(metadata), &entity, func() (proto.Message, error) {
+		return c.Registry.GetIndexRule(ctx, metadata)
+	}); err != nil {
+		return nil, err
+	}
+	return &entity, nil
+}
+
+func (c *CachedRegistry) ListIndexRule(ctx context.Context, opt ListOpt) ([]*databasev1.IndexRule, string, error) {
+	messages, next, err := c.listPage(listKey{prefix: IndexRuleKeyPrefix, opt: opt}, func() (listResult, error) {
+		entities, n, errList := c.Registry.ListIndexRule(ctx, opt)
+		result := listResult{messages: make([]proto.Message, 0, len(entities)), next: n}
+		for _, entity := range entities {
+			result.messages = append(result.messages, entity)
+		}
+		return result, errList
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	entities := make([]*databasev1.IndexRule, 0, len(messages))
+	for _, message := range messages {
+		entities = append(entities, message.(*databasev1.IndexRule))
+	}
+	return entities, next, nil
+}
+
+func (c *CachedRegistry) GetIndexRuleBinding(ctx context.Context, metadata *commonv1.Metadata) (*databasev1.IndexRuleBinding, error) {
+	var entity databasev1.IndexRuleBinding
+	if _, err := c.get(formatIndexRuleBindingKey(metadata), &entity, func() (proto.Message, error) {
+		return c.Registry.GetIndexRuleBinding(ctx, metadata)
+	}); err != nil {
+		return nil, err
+	}
+	return &entity, nil
+}
+
+func (c *CachedRegistry) ListIndexRuleBinding(ctx context.Context,
+	opt ListOpt) ([]*databasev1.IndexRuleBinding, string, error) {
+	messages, next, err := c.listPage(listKey{prefix: IndexRuleBindingKeyPrefix, opt: opt}, func() (listResult, error) {
+		entities, n, errList := c.Registry.ListIndexRuleBinding(ctx, opt)
+		result := listResult{messages: make([]proto.Message, 0, len(entities)), next: n}
+		for _, entity := range entities {
+			result.messages = append(result.messages, entity)
+		}
+		return result, errList
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	entities := make([]*databasev1.IndexRuleBinding, 0, len(messages))
+	for _, message := range messages {
+		entities = append(entities, message.(*databasev1.IndexRuleBinding))
+	}
+	return entities, next, nil
+}
+
+func (c *CachedRegistry) Txn(ctx context.Context, fn func(tx RegistryTx) error) error {
+	return c.written(ctx, c.Registry.Txn(ctx, fn))
+}
+
+func (c *CachedRegistry) CreateGroup(ctx context.Context, group string) error {
+	return c.written(ctx, c.Registry.CreateGroup(ctx, group))
+}
+
+func (c *CachedRegistry) DeleteGroup(ctx context.Context, group string) (bool, error) {
+	deleted, err := c.Registry.DeleteGroup(ctx, group)
+	return deleted, c.written(ctx, err)
+}
+
+func (c *CachedRegistry) DeleteGroupsByPrefix(ctx context.Context, prefix string) (int, error) {
+	num, err := c.Registry.DeleteGroupsByPrefix(ctx, prefix)
+	return num, c.written(ctx, err)
+}
+
+func (c *CachedRegistry) CreateStream(ctx context.Context, stream *databasev1.Stream) error {
+	return c.written(ctx, c.Registry.CreateStream(ctx, stream))
+}
+
+func (c *CachedRegistry) UpdateStream(ctx context.Context, stream *databasev1.Stream) error {
+	return c.written(ctx, c.Registry.UpdateStream(ctx, stream))
+}
+
+func (c *CachedRegistry) DeleteStream(ctx context.Context, metadata *commonv1.Metadata, opt DeleteOpt) (bool, error) {
+	deleted, err := c.Registry.DeleteStream(ctx, metadata, opt)
+	return deleted, c.written(ctx, err)
+}
+
+func (c *CachedRegistry) CreateMeasure(ctx context.Context, measure *databasev1.Measure) error {
+	return c.written(ctx, c.Registry.CreateMeasure(ctx, measure))
+}
+
+func (c *CachedRegistry) UpdateMeasure(ctx context.Context, measure *databasev1.Measure) error {
+	return c.written(ctx, c.Registry.UpdateMeasure(ctx, measure))
+}
+
+func (c *CachedRegistry) DeleteMeasure(ctx context.Context, metadata *commonv1.Metadata, opt DeleteOpt) (bool, error) {
+	deleted, err := c.Registry.DeleteMeasure(ctx, metadata, opt)
+	return deleted, c.written(ctx, err)
+}
+
+func (c *CachedRegistry) CreateIndexRule(ctx context.Context, indexRule *databasev1.IndexRule) error {
+	return c.written(ctx, c.Registry.CreateIndexRule(ctx, indexRule))
+}
+
+func (c *CachedRegistry) UpdateIndexRule(ctx context.Context, indexRule *databasev1.IndexRule) error {
+	return c.written(ctx, c.Registry.UpdateIndexRule(ctx, indexRule))
+}
+
+func (c *CachedRegistry) DeleteIndexRule(ctx context.Context, metadata *commonv1.Metadata) (bool, error) {
+	deleted, err := c.Registry.DeleteIndexRule(ctx, metadata)
+	return deleted, c.written(ctx, err)
+}
+
+func (c *CachedRegistry) CreateIndexRuleBinding(ctx context.Context, indexRuleBinding *databasev1.IndexRuleBinding) error {
+	return c.written(ctx, c.Registry.CreateIndexRuleBinding(ctx, indexRuleBinding))
+}
+
+func (c *CachedRegistry) UpdateIndexRuleBinding(ctx context.Context, indexRuleBinding *databasev1.IndexRuleBinding) error {
+	return c.written(ctx, c.Registry.UpdateIndexRuleBinding(ctx, indexRuleBinding))
+}
+
+func (c *CachedRegistry) DeleteIndexRuleBinding(ctx context.Context, metadata *commonv1.Metadata) (bool, error) {
+	deleted, err := c.Registry.DeleteIndexRuleBinding(ctx, metadata)
+	return deleted, c.written(ctx, err)
+}
+
+func (c *CachedRegistry) DeleteBindingAndRules(ctx context.Context, metadata *commonv1.Metadata) (int, error) {
+	num, err := c.Registry.DeleteBindingAndRules(ctx, metadata)
+	return num, c.written(ctx, err)
+}
+
+// Close stops watching the deltas before closing the registry
+func (c *CachedRegistry) Close() error {
+	if c.cancel != nil {
+		c.cancel()
+		c.wg.Wait()
+	}
+	return c.Registry.Close()
+}
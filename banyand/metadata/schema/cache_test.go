@@ -0,0 +1,273 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package schema
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	commonv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/common/v1"
+	databasev1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/database/v1"
+)
+
+func cachedStream(c *CachedRegistry, meta *commonv1.Metadata) *databasev1.Stream {
+	var entity databasev1.Stream
+	if !c.load(formatSteamKey(meta), &entity) {
+		return nil
+	}
+	return &entity
+}
+
+func Test_CachedRegistry_WatchDeltas(t *testing.T) {
+	req := require.New(t)
+	registry, err := NewEtcdSchemaRegistry(useUnixDomain(), useRandomTempDir())
+	req.NoError(err)
+	req.NoError(preloadSchema(registry))
+	c, err := NewEtcdCachedRegistry(registry)
+	req.NoError(err)
+	defer c.Close()
+	req.Equal(uint64(1), atomic.LoadUint64(&c.bootstraps))
+
+	meta := &commonv1.Metadata{Name: "sw", Group: "default"}
+	s := cachedStream(c, meta)
+	req.NotNil(s)
+	s.GetOpts().ShardNum = 7
+	req.NoError(c.UpdateStream(context.TODO(), s))
+	req.Eventually(func() bool {
+		cached := cachedStream(c, meta)
+		return cached != nil && cached.GetOpts().GetShardNum() == 7
+	}, 5*time.Second, 10*time.Millisecond)
+
+	deleted, err := c.DeleteStream(context.TODO(), meta, DeleteOpt{Cascade: true})
+	req.NoError(err)
+	req.True(deleted)
+	req.Eventually(func() bool {
+		return cachedStream(c, meta) == nil
+	}, 5*time.Second, 10*time.Millisecond)
+	req.Equal(uint64(1), atomic.LoadUint64(&c.bootstraps), "the deltas are applied without another full list")
+}
+
+func Test_CachedRegistry_Compacted(t *testing.T) {
+	req := require.New(t)
+	registry, err := NewEtcdSchemaRegistry(useUnixDomain(), useRandomTempDir())
+	req.NoError(err)
+	req.NoError(preloadSchema(registry))
+	c, err := newCachedRegistry(registry)
+	req.NoError(err)
+	defer c.Close()
+	req.NoError(c.list(context.TODO()))
+
+	// the stream changes after the list, and the revision is compacted before watching
+	meta := &commonv1.Metadata{Name: "sw", Group: "default"}
+	s, err := registry.GetStream(context.TODO(), meta)
+	req.NoError(err)
+	s.GetOpts().ShardNum = 7
+	req.NoError(registry.UpdateStream(context.TODO(), s))
+	resp, err := c.kv.Get(context.TODO(), KeyFormatMarker)
+	req.NoError(err)
+	_, err = c.kv.Compact(context.TODO(), resp.Header.Revision)
+	req.NoError(err)
+
+	c.start()
+	req.Eventually(func() bool {
+		cached := cachedStream(c, meta)
+		return cached != nil && cached.GetOpts().GetShardNum() == 7
+	}, 5*time.Second, 10*time.Millisecond)
+	req.Equal(uint64(2), atomic.LoadUint64(&c.bootstraps))
+}
+
+type unavailableRegistry struct {
+	Registry
+}
+
+func (r *unavailableRegistry) GetStream(_ context.Context, _ *commonv1.Metadata) (*databasev1.Stream, error) {
+	return nil, errUnavailable
+}
+
+var errUnavailable = errors.New("etcd is unavailable")
+
+func Test_CachedRegistry_StaleOnError(t *testing.T) {
+	req := require.New(t)
+	registry, err := NewEtcdSchemaRegistry(useUnixDomain(), useRandomTempDir())
+	req.NoError(err)
+	req.NoError(preloadSchema(registry))
+	c, err := newCachedRegistry(registry)
+	req.NoError(err)
+	defer c.Close()
+	MaxStaleness(time.Minute)(c)
+	now := time.Now()
+	c.now = func() time.Time { return now }
+	req.NoError(c.list(context.TODO()))
+	meta := &commonv1.Metadata{Name: "sw", Group: "default"}
+	s, stale, err := c.GetStreamOrStale(context.TODO(), meta)
+	req.NoError(err)
+	req.False(stale)
+	req.Equal("sw", s.GetMetadata().GetName())
+
+	// the watch breaks, and the reads through the registry fail as well
+	c.Registry = &unavailableRegistry{Registry: registry}
+	c.markBroken()
+	now = now.Add(30 * time.Second)
+	s, stale, err = c.GetStreamOrStale(context.TODO(), meta)
+	req.NoError(err)
+	req.True(stale)
+	req.Equal("sw", s.GetMetadata().GetName())
+	_, err = c.GetStream(context.TODO(), meta)
+	req.NoError(err)
+	_, _, err = c.GetStreamOrStale(context.TODO(), &commonv1.Metadata{Name: "absent", Group: "default"})
+	req.ErrorIs(err, errUnavailable)
+
+	// the cached stream is too stale to serve
+	now = now.Add(time.Minute)
+	_, _, err = c.GetStreamOrStale(context.TODO(), meta)
+	req.ErrorIs(err, errUnavailable)
+
+	// the cache serves the fresh streams again once it's rebuilt
+	req.NoError(c.list(context.TODO()))
+	s, stale, err = c.GetStreamOrStale(context.TODO(), meta)
+	req.NoError(err)
+	req.False(stale)
+	req.Equal("sw", s.GetMetadata().GetName())
+}
+
+func Test_CachedRegistry_ReadThroughWhenBroken(t *testing.T) {
+	req := require.New(t)
+	registry, err := NewEtcdSchemaRegistry(useUnixDomain(), useRandomTempDir())
+	req.NoError(err)
+	req.NoError(preloadSchema(registry))
+	c, err := newCachedRegistry(registry)
+	req.NoError(err)
+	defer c.Close()
+	req.NoError(c.list(context.TODO()))
+
+	// the cache misses the update while the watch is broken, so the gets read through the registry
+	c.markBroken()
+	meta := &commonv1.Metadata{Name: "sw", Group: "default"}
+	s, err := registry.GetStream(context.TODO(), meta)
+	req.NoError(err)
+	s.GetOpts().ShardNum = 7
+	req.NoError(registry.UpdateStream(context.TODO(), s))
+	got, stale, err := c.GetStreamOrStale(context.TODO(), meta)
+	req.NoError(err)
+	req.False(stale)
+	req.Equal(uint32(7), got.GetOpts().GetShardNum())
+}
+
+func Test_CachedRegistry_Lists(t *testing.T) {
+	req := require.New(t)
+	registry, err := NewEtcdSchemaRegistry(useUnixDomain(), useRandomTempDir())
+	req.NoError(err)
+	req.NoError(preloadSchema(registry))
+	c, err := NewEtcdCachedRegistry(registry)
+	req.NoError(err)
+	defer c.Close()
+	ctx := context.TODO()
+
+	meta := &commonv1.Metadata{Name: "sw", Group: "default"}
+	s, err := c.GetStream(ctx, meta)
+	req.NoError(err)
+	streams, _, err := c.ListStream(ctx, ListOpt{Group: "default"})
+	req.NoError(err)
+	req.Len(streams, 1)
+	// the cached entities are copies, which callers are free to change
+	streams[0].GetOpts().ShardNum = 7
+	listed, _, err := c.ListStream(ctx, ListOpt{Group: "default"})
+	req.NoError(err)
+	req.NotEqual(uint32(7), listed[0].GetOpts().GetShardNum())
+	hits, misses := c.Stats()
+	req.Equal(uint64(2), hits)
+	req.Equal(uint64(1), misses)
+
+	// the writes through the cache are visible to the following reads
+	another := proto.Clone(s).(*databasev1.Stream)
+	another.Metadata = &commonv1.Metadata{Name: "another", Group: "default"}
+	req.NoError(c.CreateStream(ctx, another))
+	listed, _, err = c.ListStream(ctx, ListOpt{Group: "default"})
+	req.NoError(err)
+	req.Len(listed, 2)
+	s.GetOpts().ShardNum = 7
+	req.NoError(c.UpdateStream(ctx, s))
+	updated, err := c.GetStream(ctx, meta)
+	req.NoError(err)
+	req.Equal(uint32(7), updated.GetOpts().GetShardNum())
+
+	// so are the changes of the events emitted by the cache
+	events, err := c.Watch(ctx, WatchOpt{Kinds: []Kind{KindStream}})
+	req.NoError(err)
+	updated.GetOpts().ShardNum = 9
+	req.NoError(registry.UpdateStream(ctx, updated))
+	select {
+	case event := <-events:
+		req.Equal("sw", event.Metadata.GetName())
+	case <-time.After(5 * time.Second):
+		req.Fail("the event is absent")
+	}
+	got, err := c.GetStream(ctx, meta)
+	req.NoError(err)
+	req.Equal(uint32(9), got.GetOpts().GetShardNum())
+}
+
+func Test_CachedRegistry_Concurrent(t *testing.T) {
+	req := require.New(t)
+	registry, err := NewEtcdSchemaRegistry(useUnixDomain(), useRandomTempDir())
+	req.NoError(err)
+	req.NoError(preloadSchema(registry))
+	c, err := NewEtcdCachedRegistry(registry)
+	req.NoError(err)
+	defer c.Close()
+	ctx := context.TODO()
+
+	meta := &commonv1.Metadata{Name: "sw", Group: "default"}
+	s, err := c.GetStream(ctx, meta)
+	req.NoError(err)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				if _, errGet := c.GetStream(ctx, meta); errGet != nil {
+					t.Error(errGet)
+					return
+				}
+				if _, _, errList := c.ListIndexRule(ctx, ListOpt{Group: "default"}); errList != nil {
+					t.Error(errList)
+					return
+				}
+			}
+		}()
+	}
+	for i := uint32(1); i <= 5; i++ {
+		s.Metadata.ModRevision = 0
+		s.GetOpts().ShardNum = i
+		req.NoError(c.UpdateStream(ctx, s))
+	}
+	wg.Wait()
+	updated, err := c.GetStream(ctx, meta)
+	req.NoError(err)
+	req.Equal(uint32(5), updated.GetOpts().GetShardNum())
+	hits, _ := c.Stats()
+	req.NotZero(hits)
+}
@@ -19,21 +19,29 @@ package schema
 
 import (
 	"context"
+	"crypto/tls"
+	"encoding/base64"
 	"fmt"
 	"math/rand"
 	"net/url"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/pkg/errors"
 	clientv3 "go.etcd.io/etcd/client/v3"
 	"go.etcd.io/etcd/server/v3/embed"
+	"go.uber.org/multierr"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	commonv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/common/v1"
 	databasev1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/database/v1"
+	"github.com/apache/skywalking-banyandb/pkg/logger"
+	pbv1 "github.com/apache/skywalking-banyandb/pkg/pb/v1"
 )
 
 var (
@@ -45,6 +53,26 @@ var (
 
 	ErrEntityNotFound             = errors.New("entity is not found")
 	ErrUnexpectedNumberOfEntities = errors.New("unexpected number of entities")
+	ErrCorruptValue               = errors.New("the stored value is corrupt")
+	ErrConcurrentUpdate           = errors.New("the entities are updated concurrently")
+	// ErrEntityAlreadyExists tells a different entity exists under the key to create
+	ErrEntityAlreadyExists = errors.New("a different entity exists")
+	// ErrConflict tells an update carrying a revision loses the race, and it should read the entity again
+	ErrConflict = errors.New("the entity is modified since the revision")
+	// ErrInvalidListOpt tells the cursor or the selector in ListOpt is malformed, or the order doesn't support paging
+	ErrInvalidListOpt = errors.New("the list option is invalid")
+	// ErrInvalidSchema tells the entity to write is malformed, which is what the validation errors below are
+	ErrInvalidSchema = errors.New("the schema is invalid")
+	// ErrUnknownEntityTag tells the entity of a stream or a measure refers to a tag absent in its tag families
+	ErrUnknownEntityTag = errors.WithMessage(ErrInvalidSchema, "the entity refers to an unknown tag")
+	// ErrInvalidBindingWindow tells the index rule binding expires before it begins, or it has expired
+	ErrInvalidBindingWindow = errors.WithMessage(ErrInvalidSchema, "the validity window of the index rule binding is invalid")
+	// ErrIndexRuleNotExist tells the index rule binding refers to an index rule absent in its group
+	ErrIndexRuleNotExist = errors.WithMessage(ErrInvalidSchema, "the index rule binding refers to an absent index rule")
+	// ErrReferredByBindings tells the stream or the measure to delete is the subject of some index rule bindings
+	ErrReferredByBindings = errors.New("the entity is referred by index rule bindings")
+	// ErrEmptyGroupPrefix prevents deleting all the groups by an empty prefix
+	ErrEmptyGroupPrefix = errors.New("the prefix of the groups to delete is empty")
 
 	GroupsKeyPrefix           = "/groups/"
 	GroupMetadataKey          = "/__meta_group__"
@@ -52,6 +80,8 @@ var (
 	IndexRuleBindingKeyPrefix = "/index-rule-bindings/"
 	IndexRuleKeyPrefix        = "/index-rules/"
 	MeasureKeyPrefix          = "/measures/"
+
+	defaultOperationTimeout = 10 * time.Second
 )
 
 type RegistryOption func(*etcdSchemaRegistryConfig)
@@ -76,9 +106,53 @@ func UseRandomListener() RegistryOption {
 	}
 }
 
+// WithOpTimeout sets the default timeout of an etcd operation, which is applied if the caller's context has no deadline.
+// A non-positive timeout disables it.
+func WithOpTimeout(timeout time.Duration) RegistryOption {
+	return func(config *etcdSchemaRegistryConfig) {
+		config.operationTimeout = timeout
+	}
+}
+
+// CompressValues compresses the stored schemas if it shrinks them.
+// The plain and the compressed values are both readable no matter whether it's enabled.
+func CompressValues(enabled bool) RegistryOption {
+	return func(config *etcdSchemaRegistryConfig) {
+		config.compressValues = enabled
+	}
+}
+
+// UseEndpoints connects the registry to the external etcd cluster at endpoints instead of starting an embedded one.
+// A nil tlsConfig connects over plain TCP.
+func UseEndpoints(endpoints []string, tlsConfig *tls.Config) RegistryOption {
+	return func(config *etcdSchemaRegistryConfig) {
+		config.endpoints = endpoints
+		config.tlsConfig = tlsConfig
+	}
+}
+
+// DeferBindingValidation stores the index rule binding referring to the absent index rules with a warning
+// instead of rejecting it, which lets the bootstrap write the bindings before their rules.
+func DeferBindingValidation(enabled bool) RegistryOption {
+	return func(config *etcdSchemaRegistryConfig) {
+		config.deferBindingValidation = enabled
+	}
+}
+
 type etcdSchemaRegistry struct {
+	// server is nil if the registry connects to an external cluster
 	server *embed.Etcd
-	kv     clientv3.KV
+	client *clientv3.Client
+	// closeCh is closed once the client to the external cluster is closed
+	closeCh   chan struct{}
+	closeOnce sync.Once
+	kv        clientv3.KV
+	watcher   clientv3.Watcher
+	compress  bool
+	// deferBindingValidation warns about the absent index rules of a binding instead of rejecting it
+	deferBindingValidation bool
+	// keysMigrated is 1 if all the keys are in the current format
+	keysMigrated int32
 }
 
 type etcdSchemaRegistryConfig struct {
@@ -88,6 +162,14 @@ type etcdSchemaRegistryConfig struct {
 	listenerClientURL string
 	// listenerPeerURL is the listener for peer
 	listenerPeerURL string
+	// operationTimeout is the default timeout of an operation
+	operationTimeout time.Duration
+	compressValues   bool
+	// endpoints of the external cluster, which disable the embedded server
+	endpoints []string
+	tlsConfig *tls.Config
+	// deferBindingValidation only warns about the absent index rules of a binding
+	deferBindingValidation bool
 }
 
 func (e *etcdSchemaRegistry) GetGroup(ctx context.Context, group string) (*commonv1.Group, error) {
@@ -100,34 +182,83 @@ func (e *etcdSchemaRegistry) GetGroup(ctx context.Context, group string) (*commo
 }
 
 func (e *etcdSchemaRegistry) ListGroup(ctx context.Context) ([]string, error) {
-	messages, err := e.kv.Get(ctx, GroupsKeyPrefix, clientv3.WithFromKey(), clientv3.WithRange(incrementLastByte(GroupsKeyPrefix)))
+	messages, err := e.rangeWithPrefix(ctx, GroupsKeyPrefix)
 	if err != nil {
 		return nil, err
 	}
 
-	if messages.Count == 0 {
+	if len(messages) == 0 {
 		return []string{}, nil
 	}
 
 	var groups []string
-	for _, kv := range messages.Kvs {
-		// kv.Key = "/groups/" + {group} + "/__meta_info__"
-		groupWithSuffix := strings.TrimPrefix(string(kv.Key), GroupsKeyPrefix)
+	for _, kv := range messages {
+		// kv.key = "/groups/" + {group} + "/__meta_info__"
+		groupWithSuffix := strings.TrimPrefix(kv.key, GroupsKeyPrefix)
 		if strings.HasSuffix(groupWithSuffix, GroupMetadataKey) {
 			groups = append(groups, strings.TrimSuffix(groupWithSuffix, GroupMetadataKey))
 		}
 	}
+	sort.Strings(groups)
+	return groups, nil
+}
 
+func (e *etcdSchemaRegistry) ListGroups(ctx context.Context) ([]*commonv1.Group, error) {
+	messages, err := e.rangeWithPrefix(ctx, GroupsKeyPrefix)
+	if err != nil {
+		return nil, err
+	}
+	groups := make([]*commonv1.Group, 0)
+	for _, kv := range messages {
+		if !strings.HasSuffix(kv.key, GroupMetadataKey) {
+			continue
+		}
+		var g commonv1.Group
+		if err = decodeValue(kv.value, &g); err != nil {
+			return nil, errors.Wrap(err, kv.key)
+		}
+		groups = append(groups, &g)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].GetName() < groups[j].GetName()
+	})
 	return groups, nil
 }
 
+func (e *etcdSchemaRegistry) DeleteGroupsByPrefix(ctx context.Context, prefix string) (int, error) {
+	if prefix == "" {
+		return 0, ErrEmptyGroupPrefix
+	}
+	groups, err := e.ListGroup(ctx)
+	if err != nil {
+		return 0, err
+	}
+	var deleted int
+	err = e.Txn(ctx, func(tx RegistryTx) error {
+		for _, g := range groups {
+			if !strings.HasPrefix(g, prefix) {
+				continue
+			}
+			if errDelete := tx.DeleteGroup(g); errDelete != nil {
+				return errDelete
+			}
+			deleted++
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return deleted, nil
+}
+
 func (e *etcdSchemaRegistry) DeleteGroup(ctx context.Context, group string) (bool, error) {
 	g, err := e.GetGroup(ctx, group)
 	if err != nil {
 		return false, errors.Wrap(err, group)
 	}
 	keyPrefix := GroupsKeyPrefix + g.GetName() + "/"
-	_, err = e.kv.Delete(ctx, keyPrefix, clientv3.WithRange(incrementLastByte(keyPrefix)))
+	_, err = e.deleteKey(ctx, keyPrefix, incrementLastByte(keyPrefix))
 	if err != nil {
 		return false, err
 	}
@@ -145,12 +276,12 @@ func (e *etcdSchemaRegistry) CreateGroup(ctx context.Context, group string) erro
 }
 
 func (e *etcdSchemaRegistry) touchGroup(ctx context.Context, g *commonv1.Group) error {
-	groupBytes, err := proto.Marshal(g)
+	groupBytes, err := encodeValue(g, e.compress)
 	if err != nil {
 		return err
 	}
 	g.UpdatedAt = timestamppb.Now()
-	_, err = e.kv.Put(ctx, formatGroupKey(g.GetName()), string(groupBytes))
+	_, err = e.kv.Put(ctx, currentKey(formatGroupKey(g.GetName())), string(groupBytes))
 	return err
 }
 
@@ -162,28 +293,35 @@ func (e *etcdSchemaRegistry) GetMeasure(ctx context.Context, metadata *commonv1.
 	return &entity, nil
 }
 
-func (e *etcdSchemaRegistry) ListMeasure(ctx context.Context, opt ListOpt) ([]*databasev1.Measure, error) {
-	keyPrefixes, err := e.listPrefixesForEntity(ctx, opt, MeasureKeyPrefix)
+func (e *etcdSchemaRegistry) ListMeasure(ctx context.Context, opt ListOpt) ([]*databasev1.Measure, string, error) {
+	messages, next, err := e.listEntities(ctx, opt, MeasureKeyPrefix, func() proto.Message {
+		return &databasev1.Measure{}
+	})
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
+	entities := make([]*databasev1.Measure, 0, len(messages))
+	for _, message := range messages {
+		entities = append(entities, message.(*databasev1.Measure))
+	}
+	return entities, next, nil
+}
 
-	var entities []*databasev1.Measure
-	for _, keyPrefix := range keyPrefixes {
-		messages, err := e.listWithPrefix(ctx, keyPrefix, func() proto.Message {
-			return &databasev1.Measure{}
-		})
-		if err != nil {
-			return nil, err
-		}
-		for _, message := range messages {
-			entities = append(entities, message.(*databasev1.Measure))
-		}
+func (e *etcdSchemaRegistry) CreateMeasure(ctx context.Context, measure *databasev1.Measure) error {
+	if err := validateEntity(measure.GetMetadata(), measure.GetTagFamilies(), measure.GetEntity()); err != nil {
+		return err
+	}
+	g, err := e.GetGroup(ctx, measure.GetMetadata().GetGroup())
+	if err != nil {
+		return errors.Wrap(err, measure.GetMetadata().GetGroup())
 	}
-	return entities, nil
+	return e.create(ctx, g, formatMeasureKey(measure.GetMetadata()), measure, &databasev1.Measure{})
 }
 
 func (e *etcdSchemaRegistry) UpdateMeasure(ctx context.Context, measure *databasev1.Measure) error {
+	if err := validateEntity(measure.GetMetadata(), measure.GetTagFamilies(), measure.GetEntity()); err != nil {
+		return err
+	}
 	g, err := e.GetGroup(ctx, measure.GetMetadata().GetGroup())
 	if err != nil {
 		return errors.Wrap(err, measure.GetMetadata().GetGroup())
@@ -191,12 +329,20 @@ func (e *etcdSchemaRegistry) UpdateMeasure(ctx context.Context, measure *databas
 	return e.update(ctx, g, formatMeasureKey(measure.GetMetadata()), measure)
 }
 
-func (e *etcdSchemaRegistry) DeleteMeasure(ctx context.Context, metadata *commonv1.Metadata) (bool, error) {
-	g, err := e.GetGroup(ctx, metadata.GetGroup())
-	if err != nil {
-		return false, errors.Wrap(err, metadata.GetGroup())
+// validateEntity rejects the entity referring to a tag absent in families, which would be dropped silently
+// by the entity locator of the writes
+func validateEntity(metadata *commonv1.Metadata, families []*databasev1.TagFamilySpec, entity *databasev1.Entity) error {
+	for _, tagName := range entity.GetTagNames() {
+		if _, _, tag := pbv1.FindTagByName(families, tagName); tag == nil {
+			return errors.Wrapf(ErrUnknownEntityTag, "tag %q of %s/%s is absent in the tag families",
+				tagName, metadata.GetGroup(), metadata.GetName())
+		}
 	}
-	return e.delete(ctx, g, formatMeasureKey(metadata))
+	return nil
+}
+
+func (e *etcdSchemaRegistry) DeleteMeasure(ctx context.Context, metadata *commonv1.Metadata, opt DeleteOpt) (bool, error) {
+	return e.deleteSubject(ctx, commonv1.Catalog_CATALOG_MEASURE, metadata, formatMeasureKey(metadata), opt)
 }
 
 func (e *etcdSchemaRegistry) GetStream(ctx context.Context, metadata *commonv1.Metadata) (*databasev1.Stream, error) {
@@ -207,30 +353,35 @@ func (e *etcdSchemaRegistry) GetStream(ctx context.Context, metadata *commonv1.M
 	return &entity, nil
 }
 
-func (e *etcdSchemaRegistry) ListStream(ctx context.Context, opt ListOpt) ([]*databasev1.Stream, error) {
-	keyPrefixes, err := e.listPrefixesForEntity(ctx, opt, StreamKeyPrefix)
+func (e *etcdSchemaRegistry) ListStream(ctx context.Context, opt ListOpt) ([]*databasev1.Stream, string, error) {
+	messages, next, err := e.listEntities(ctx, opt, StreamKeyPrefix, func() proto.Message {
+		return &databasev1.Stream{}
+	})
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
-
-	var entities []*databasev1.Stream
-	for _, keyPrefix := range keyPrefixes {
-		messages, err := e.listWithPrefix(ctx, keyPrefix, func() proto.Message {
-			return &databasev1.Stream{}
-		})
-		if err != nil {
-			return nil, err
-		}
-
-		for _, message := range messages {
-			entities = append(entities, message.(*databasev1.Stream))
-		}
+	entities := make([]*databasev1.Stream, 0, len(messages))
+	for _, message := range messages {
+		entities = append(entities, message.(*databasev1.Stream))
 	}
+	return entities, next, nil
+}
 
-	return entities, nil
+func (e *etcdSchemaRegistry) CreateStream(ctx context.Context, stream *databasev1.Stream) error {
+	if err := validateEntity(stream.GetMetadata(), stream.GetTagFamilies(), stream.GetEntity()); err != nil {
+		return err
+	}
+	g, err := e.GetGroup(ctx, stream.GetMetadata().GetGroup())
+	if err != nil {
+		return errors.Wrap(err, stream.GetMetadata().GetGroup())
+	}
+	return e.create(ctx, g, formatSteamKey(stream.GetMetadata()), stream, &databasev1.Stream{})
 }
 
 func (e *etcdSchemaRegistry) UpdateStream(ctx context.Context, stream *databasev1.Stream) error {
+	if err := validateEntity(stream.GetMetadata(), stream.GetTagFamilies(), stream.GetEntity()); err != nil {
+		return err
+	}
 	g, err := e.GetGroup(ctx, stream.GetMetadata().GetGroup())
 	if err != nil {
 		return errors.Wrap(err, stream.GetMetadata().GetGroup())
@@ -238,12 +389,64 @@ func (e *etcdSchemaRegistry) UpdateStream(ctx context.Context, stream *databasev
 	return e.update(ctx, g, formatSteamKey(stream.GetMetadata()), stream)
 }
 
-func (e *etcdSchemaRegistry) DeleteStream(ctx context.Context, metadata *commonv1.Metadata) (bool, error) {
+func (e *etcdSchemaRegistry) DeleteStream(ctx context.Context, metadata *commonv1.Metadata, opt DeleteOpt) (bool, error) {
+	return e.deleteSubject(ctx, commonv1.Catalog_CATALOG_STREAM, metadata, formatSteamKey(metadata), opt)
+}
+
+// deleteSubject deletes a stream or a measure, and the index rule bindings whose subject it is if opt.Cascade is true.
+// Otherwise, it fails with ErrReferredByBindings listing the bindings.
+func (e *etcdSchemaRegistry) deleteSubject(ctx context.Context, catalog commonv1.Catalog, metadata *commonv1.Metadata,
+	key string, opt DeleteOpt) (bool, error) {
 	g, err := e.GetGroup(ctx, metadata.GetGroup())
 	if err != nil {
 		return false, errors.Wrap(err, metadata.GetGroup())
 	}
-	return e.delete(ctx, g, formatSteamKey(metadata))
+	// the bindings changed after the revision fail the transaction, so none of them is left referring to the subject
+	resp, err := e.kv.Get(ctx, KeyFormatMarker)
+	if err != nil {
+		return false, err
+	}
+	rev := resp.Header.Revision
+	bindings, _, err := e.ListIndexRuleBinding(ctx, ListOpt{Group: metadata.GetGroup()})
+	if err != nil {
+		return false, err
+	}
+	var referring []string
+	for _, b := range bindings {
+		if b.GetSubject().GetCatalog() == catalog && b.GetSubject().GetName() == metadata.GetName() {
+			referring = append(referring, b.GetMetadata().GetName())
+		}
+	}
+	if len(referring) > 0 && !opt.Cascade {
+		return false, errors.Wrapf(ErrReferredByBindings, "%s/%s is the subject of the bindings %s",
+			metadata.GetGroup(), metadata.GetName(), strings.Join(referring, ", "))
+	}
+	bindingPrefix := GroupsKeyPrefix + metadata.GetGroup() + IndexRuleBindingKeyPrefix
+	ops := []clientv3.Op{clientv3.OpDelete(currentKey(key)), clientv3.OpDelete(key)}
+	for _, name := range referring {
+		bindingKey := formatIndexRuleBindingKey(&commonv1.Metadata{Group: metadata.GetGroup(), Name: name})
+		ops = append(ops, clientv3.OpDelete(currentKey(bindingKey)), clientv3.OpDelete(bindingKey))
+	}
+	txnResp, err := e.kv.Txn(ctx).
+		If(
+			clientv3.Compare(clientv3.ModRevision(currentKey(bindingPrefix)), "<", rev+1).WithPrefix(),
+			clientv3.Compare(clientv3.ModRevision(bindingPrefix), "<", rev+1).WithPrefix(),
+		).
+		Then(ops...).
+		Commit()
+	if err != nil {
+		return false, err
+	}
+	if !txnResp.Succeeded {
+		return false, errors.Wrapf(ErrConcurrentUpdate, "the bindings of the group %s", metadata.GetGroup())
+	}
+	// the responses of the subject come first for both key formats
+	deleted := txnResp.Responses[0].GetResponseDeleteRange().GetDeleted()+
+		txnResp.Responses[1].GetResponseDeleteRange().GetDeleted() > 0
+	if deleted || len(referring) > 0 {
+		return deleted, e.touchGroup(ctx, g)
+	}
+	return false, nil
 }
 
 func (e *etcdSchemaRegistry) GetIndexRuleBinding(ctx context.Context, metadata *commonv1.Metadata) (*databasev1.IndexRuleBinding, error) {
@@ -254,33 +457,166 @@ func (e *etcdSchemaRegistry) GetIndexRuleBinding(ctx context.Context, metadata *
 	return &indexRuleBinding, nil
 }
 
-func (e *etcdSchemaRegistry) ListIndexRuleBinding(ctx context.Context, opt ListOpt) ([]*databasev1.IndexRuleBinding, error) {
-	keyPrefixes, err := e.listPrefixesForEntity(ctx, opt, IndexRuleBindingKeyPrefix)
+func (e *etcdSchemaRegistry) ListIndexRuleBinding(ctx context.Context, opt ListOpt) ([]*databasev1.IndexRuleBinding, string, error) {
+	messages, next, err := e.listEntities(ctx, opt, IndexRuleBindingKeyPrefix, func() proto.Message {
+		return &databasev1.IndexRuleBinding{}
+	})
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
+	entities := make([]*databasev1.IndexRuleBinding, 0, len(messages))
+	for _, message := range messages {
+		entities = append(entities, message.(*databasev1.IndexRuleBinding))
+	}
+	return entities, next, nil
+}
 
-	var entities []*databasev1.IndexRuleBinding
-	for _, keyPrefix := range keyPrefixes {
-		messages, err := e.listWithPrefix(ctx, keyPrefix, func() proto.Message {
-			return &databasev1.IndexRuleBinding{}
-		})
-		if err != nil {
-			return nil, err
-		}
-		for _, message := range messages {
-			entities = append(entities, message.(*databasev1.IndexRuleBinding))
-		}
+func (e *etcdSchemaRegistry) CreateIndexRuleBinding(ctx context.Context, indexRuleBinding *databasev1.IndexRuleBinding) error {
+	if err := validateBindingWindow(indexRuleBinding, time.Now()); err != nil {
+		return err
+	}
+	ruleCmps, err := e.validateBindingRules(ctx, indexRuleBinding, nil)
+	if err != nil {
+		return err
+	}
+	g, err := e.GetGroup(ctx, indexRuleBinding.GetMetadata().GetGroup())
+	if err != nil {
+		return errors.Wrap(err, indexRuleBinding.GetMetadata().GetGroup())
 	}
-	return entities, nil
+	return e.create(ctx, g, formatIndexRuleBindingKey(indexRuleBinding.GetMetadata()), indexRuleBinding,
+		&databasev1.IndexRuleBinding{}, ruleCmps...)
 }
 
 func (e *etcdSchemaRegistry) UpdateIndexRuleBinding(ctx context.Context, indexRuleBinding *databasev1.IndexRuleBinding) error {
+	if err := validateBindingWindow(indexRuleBinding, time.Now()); err != nil {
+		return err
+	}
+	ruleCmps, err := e.validateBindingRules(ctx, indexRuleBinding, nil)
+	if err != nil {
+		return err
+	}
 	g, err := e.GetGroup(ctx, indexRuleBinding.GetMetadata().GetGroup())
 	if err != nil {
 		return errors.Wrap(err, indexRuleBinding.GetMetadata().GetGroup())
 	}
-	return e.update(ctx, g, formatIndexRuleBindingKey(indexRuleBinding.GetMetadata()), indexRuleBinding)
+	return e.update(ctx, g, formatIndexRuleBindingKey(indexRuleBinding.GetMetadata()), indexRuleBinding, ruleCmps...)
+}
+
+// ActiveIndexRuleBindings returns the bindings of all the groups which are active at the instant
+func (e *etcdSchemaRegistry) ActiveIndexRuleBindings(ctx context.Context, at time.Time) ([]*databasev1.IndexRuleBinding, error) {
+	bindings, _, err := e.ListIndexRuleBinding(ctx, ListOpt{})
+	if err != nil {
+		return nil, err
+	}
+	active := bindings[:0]
+	for _, b := range bindings {
+		if IsBindingActive(b, at) {
+			active = append(active, b)
+		}
+	}
+	return active, nil
+}
+
+// IsBindingActive tells whether the instant is within the validity window of the binding, including both ends
+func IsBindingActive(binding *databasev1.IndexRuleBinding, at time.Time) bool {
+	return !binding.GetBeginAt().AsTime().After(at) && !binding.GetExpireAt().AsTime().Before(at)
+}
+
+// BoundIndexRules returns the index rules bound to the subject by the active bindings
+func BoundIndexRules(ctx context.Context, registry interface {
+	IndexRule
+	IndexRuleBinding
+}, subject *commonv1.Metadata) ([]*databasev1.IndexRule, error) {
+	bindings, _, err := registry.ListIndexRuleBinding(ctx, ListOpt{Group: subject.Group})
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	foundRules := make([]string, 0)
+	for _, binding := range bindings {
+		if !IsBindingActive(binding, now) {
+			continue
+		}
+		sub := binding.GetSubject()
+		if sub.Name != subject.Name {
+			continue
+		}
+		foundRules = append(foundRules, binding.Rules...)
+	}
+	result := make([]*databasev1.IndexRule, 0, len(foundRules))
+	var indexRuleErr error
+	for _, rule := range foundRules {
+		r, getErr := registry.GetIndexRule(ctx, &commonv1.Metadata{
+			Name:  rule,
+			Group: subject.Group,
+		})
+		if getErr != nil {
+			indexRuleErr = multierr.Append(indexRuleErr, err)
+			continue
+		}
+		result = append(result, r)
+
+	}
+	return result, indexRuleErr
+}
+
+// validateBindingWindow rejects the binding expiring before it begins, or the expired one which would never be active
+func validateBindingWindow(binding *databasev1.IndexRuleBinding, now time.Time) error {
+	meta := binding.GetMetadata()
+	beginAt, expireAt := binding.GetBeginAt().AsTime(), binding.GetExpireAt().AsTime()
+	if !expireAt.After(beginAt) {
+		return errors.Wrapf(ErrInvalidBindingWindow, "%s/%s expires at %s, which isn't after its beginning at %s",
+			meta.GetGroup(), meta.GetName(), expireAt.Format(time.RFC3339), beginAt.Format(time.RFC3339))
+	}
+	if expireAt.Before(now) {
+		return errors.Wrapf(ErrInvalidBindingWindow, "%s/%s has expired at %s",
+			meta.GetGroup(), meta.GetName(), expireAt.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// validateBindingRules rejects the binding referring to the index rules absent in its group, which would fail
+// the index writer later. The rules are looked up by pending first if it's not nil, whose ok is false
+// if the key of the rule isn't pending. The absent rules are logged instead if the validation is deferred.
+// The returned compares hold once the stored rules are still there, which should guard the put of the binding
+// in the same etcd transaction. Otherwise, a rule deleted after it's read leaves the binding dangling.
+func (e *etcdSchemaRegistry) validateBindingRules(ctx context.Context, binding *databasev1.IndexRuleBinding,
+	pending func(key string) (exists bool, ok bool)) ([]clientv3.Cmp, error) {
+	meta := binding.GetMetadata()
+	var absent []string
+	var cmps []clientv3.Cmp
+	for _, rule := range binding.GetRules() {
+		key := formatIndexRuleKey(&commonv1.Metadata{Group: meta.GetGroup(), Name: rule})
+		if pending != nil {
+			if exists, ok := pending(key); ok {
+				if !exists {
+					absent = append(absent, rule)
+				}
+				continue
+			}
+		}
+		resp, err := e.getKey(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		if resp.Count == 0 {
+			absent = append(absent, rule)
+			continue
+		}
+		// the rule deleted and created again is another one, whose creation revision differs
+		stored := resp.Kvs[0]
+		cmps = append(cmps, clientv3.Compare(clientv3.CreateRevision(string(stored.Key)), "=", stored.CreateRevision))
+	}
+	if len(absent) == 0 {
+		return cmps, nil
+	}
+	err := errors.Wrapf(ErrIndexRuleNotExist, "%s/%s refers to the absent index rules %s",
+		meta.GetGroup(), meta.GetName(), strings.Join(absent, ", "))
+	if e.deferBindingValidation {
+		logger.GetLogger("metadata").Warn().Err(err).Msg("the validation of the index rule binding is deferred")
+		return nil, nil
+	}
+	return nil, err
 }
 
 func (e *etcdSchemaRegistry) DeleteIndexRuleBinding(ctx context.Context, metadata *commonv1.Metadata) (bool, error) {
@@ -291,6 +627,73 @@ func (e *etcdSchemaRegistry) DeleteIndexRuleBinding(ctx context.Context, metadat
 	return e.delete(ctx, g, formatIndexRuleBindingKey(metadata))
 }
 
+func (e *etcdSchemaRegistry) DeleteBindingAndRules(ctx context.Context, metadata *commonv1.Metadata) (int, error) {
+	g, err := e.GetGroup(ctx, metadata.GetGroup())
+	if err != nil {
+		return 0, errors.Wrap(err, metadata.GetGroup())
+	}
+	// the bindings changed after the revision fail the transaction, so the references are consistent with the deletion
+	resp, err := e.kv.Get(ctx, KeyFormatMarker)
+	if err != nil {
+		return 0, err
+	}
+	rev := resp.Header.Revision
+	bindings, _, err := e.ListIndexRuleBinding(ctx, ListOpt{Group: metadata.GetGroup()})
+	if err != nil {
+		return 0, err
+	}
+	var rules []string
+	referred := make(map[string]struct{})
+	found := false
+	for _, b := range bindings {
+		if b.GetMetadata().GetName() == metadata.GetName() {
+			rules = b.GetRules()
+			found = true
+			continue
+		}
+		for _, r := range b.GetRules() {
+			referred[r] = struct{}{}
+		}
+	}
+	if !found {
+		return 0, ErrEntityNotFound
+	}
+	bindingPrefix := GroupsKeyPrefix + metadata.GetGroup() + IndexRuleBindingKeyPrefix
+	deleteOps := func(key string) []clientv3.Op {
+		return []clientv3.Op{clientv3.OpDelete(currentKey(key)), clientv3.OpDelete(key)}
+	}
+	ops := deleteOps(formatIndexRuleBindingKey(metadata))
+	for _, r := range rules {
+		if _, ok := referred[r]; ok {
+			continue
+		}
+		referred[r] = struct{}{}
+		ops = append(ops, deleteOps(formatIndexRuleKey(&commonv1.Metadata{Group: metadata.GetGroup(), Name: r}))...)
+	}
+	txnResp, err := e.kv.Txn(ctx).
+		If(
+			clientv3.Compare(clientv3.ModRevision(currentKey(bindingPrefix)), "<", rev+1).WithPrefix(),
+			clientv3.Compare(clientv3.ModRevision(bindingPrefix), "<", rev+1).WithPrefix(),
+		).
+		Then(ops...).
+		Commit()
+	if err != nil {
+		return 0, err
+	}
+	if !txnResp.Succeeded {
+		return 0, errors.Wrapf(ErrConcurrentUpdate, "the bindings of the group %s", metadata.GetGroup())
+	}
+	var deleted int
+	// the responses of the binding are skipped, and every rule has a pair of responses for both key formats
+	for i := 2; i < len(txnResp.Responses); i += 2 {
+		if txnResp.Responses[i].GetResponseDeleteRange().GetDeleted()+
+			txnResp.Responses[i+1].GetResponseDeleteRange().GetDeleted() > 0 {
+			deleted++
+		}
+	}
+	return deleted, e.touchGroup(ctx, g)
+}
+
 func (e *etcdSchemaRegistry) GetIndexRule(ctx context.Context, metadata *commonv1.Metadata) (*databasev1.IndexRule, error) {
 	var entity databasev1.IndexRule
 	if err := e.get(ctx, formatIndexRuleKey(metadata), &entity); err != nil {
@@ -299,25 +702,26 @@ func (e *etcdSchemaRegistry) GetIndexRule(ctx context.Context, metadata *commonv
 	return &entity, nil
 }
 
-func (e *etcdSchemaRegistry) ListIndexRule(ctx context.Context, opt ListOpt) ([]*databasev1.IndexRule, error) {
-	keyPrefixes, err := e.listPrefixesForEntity(ctx, opt, IndexRuleKeyPrefix)
+func (e *etcdSchemaRegistry) ListIndexRule(ctx context.Context, opt ListOpt) ([]*databasev1.IndexRule, string, error) {
+	messages, next, err := e.listEntities(ctx, opt, IndexRuleKeyPrefix, func() proto.Message {
+		return &databasev1.IndexRule{}
+	})
 	if err != nil {
-		return nil, err
+		return nil, "", err
+	}
+	entities := make([]*databasev1.IndexRule, 0, len(messages))
+	for _, message := range messages {
+		entities = append(entities, message.(*databasev1.IndexRule))
 	}
+	return entities, next, nil
+}
 
-	var entities []*databasev1.IndexRule
-	for _, keyPrefix := range keyPrefixes {
-		messages, err := e.listWithPrefix(ctx, keyPrefix, func() proto.Message {
-			return &databasev1.IndexRule{}
-		})
-		if err != nil {
-			return nil, err
-		}
-		for _, message := range messages {
-			entities = append(entities, message.(*databasev1.IndexRule))
-		}
+func (e *etcdSchemaRegistry) CreateIndexRule(ctx context.Context, indexRule *databasev1.IndexRule) error {
+	g, err := e.GetGroup(ctx, indexRule.GetMetadata().GetGroup())
+	if err != nil {
+		return errors.Wrap(err, indexRule.GetMetadata().GetGroup())
 	}
-	return entities, nil
+	return e.create(ctx, g, formatIndexRuleKey(indexRule.GetMetadata()), indexRule, &databasev1.IndexRule{})
 }
 
 func (e *etcdSchemaRegistry) UpdateIndexRule(ctx context.Context, indexRule *databasev1.IndexRule) error {
@@ -336,19 +740,44 @@ func (e *etcdSchemaRegistry) DeleteIndexRule(ctx context.Context, metadata *comm
 	return e.delete(ctx, g, formatIndexRuleKey(metadata))
 }
 
+// readyCh is always closed, since the client to an external cluster is ready once it's created
+var readyCh = func() chan struct{} {
+	ch := make(chan struct{})
+	close(ch)
+	return ch
+}()
+
 func (e *etcdSchemaRegistry) ReadyNotify() <-chan struct{} {
+	if e.server == nil {
+		return readyCh
+	}
 	return e.server.Server.ReadyNotify()
 }
 
 func (e *etcdSchemaRegistry) StopNotify() <-chan struct{} {
+	if e.server == nil {
+		return e.closeCh
+	}
 	return e.server.Server.StopNotify()
 }
 
 func (e *etcdSchemaRegistry) StoppingNotify() <-chan struct{} {
+	if e.server == nil {
+		return e.closeCh
+	}
 	return e.server.Server.StoppingNotify()
 }
 
+// Close stops the embedded server, or only closes the client to the external cluster which is left running
 func (e *etcdSchemaRegistry) Close() error {
+	if e.server == nil {
+		var err error
+		e.closeOnce.Do(func() {
+			close(e.closeCh)
+			err = e.client.Close()
+		})
+		return err
+	}
 	e.server.Close()
 	return nil
 }
@@ -358,11 +787,24 @@ func NewEtcdSchemaRegistry(options ...RegistryOption) (Registry, error) {
 		rootDir:           os.TempDir(),
 		listenerClientURL: embed.DefaultListenClientURLs,
 		listenerPeerURL:   embed.DefaultListenPeerURLs,
+		operationTimeout:  defaultOperationTimeout,
 	}
 	for _, opt := range options {
 		opt(registryConfig)
 	}
-	// TODO: allow use cluster setting
+	if len(registryConfig.endpoints) > 0 {
+		client, err := clientv3.New(clientv3.Config{
+			Endpoints:   registryConfig.endpoints,
+			DialTimeout: registryConfig.operationTimeout,
+			TLS:         registryConfig.tlsConfig,
+		})
+		if err != nil {
+			return nil, errors.WithMessagef(err, "failed to connect to %v", registryConfig.endpoints)
+		}
+		reg := newEtcdSchemaRegistry(client, registryConfig)
+		reg.closeCh = make(chan struct{})
+		return reg, nil
+	}
 	embedConfig := newStandaloneEtcdConfig(registryConfig)
 	e, err := embed.StartEtcd(embedConfig)
 	if err != nil {
@@ -375,16 +817,127 @@ func NewEtcdSchemaRegistry(options ...RegistryOption) (Registry, error) {
 	if err != nil {
 		return nil, err
 	}
-	kvClient := clientv3.NewKV(client)
-	reg := &etcdSchemaRegistry{
-		server: e,
-		kv:     kvClient,
-	}
+	reg := newEtcdSchemaRegistry(client, registryConfig)
+	reg.server = e
 	return reg, nil
 }
 
+func newEtcdSchemaRegistry(client *clientv3.Client, config *etcdSchemaRegistryConfig) *etcdSchemaRegistry {
+	return &etcdSchemaRegistry{
+		client:                 client,
+		kv:                     newTimeoutKV(clientv3.NewKV(client), config.operationTimeout),
+		watcher:                clientv3.NewWatcher(client),
+		compress:               config.compressValues,
+		deferBindingValidation: config.deferBindingValidation,
+	}
+}
+
+var (
+	_ clientv3.KV  = (*timeoutKV)(nil)
+	_ clientv3.Txn = (*timeoutTxn)(nil)
+)
+
+// timeoutKV applies the default timeout to the operations whose context has no deadline,
+// to prevent them from hanging forever against a stuck etcd. The operations running out of
+// the deadline fail with a wrapped context.DeadlineExceeded.
+type timeoutKV struct {
+	clientv3.KV
+	timeout time.Duration
+}
+
+func newTimeoutKV(kv clientv3.KV, timeout time.Duration) clientv3.KV {
+	return &timeoutKV{
+		KV:      kv,
+		timeout: timeout,
+	}
+}
+
+func (t *timeoutKV) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.Deadline(); ok || t.timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, t.timeout)
+}
+
+func (t *timeoutKV) Put(ctx context.Context, key, val string, opts ...clientv3.OpOption) (*clientv3.PutResponse, error) {
+	ctx, cancel := t.withTimeout(ctx)
+	defer cancel()
+	resp, err := t.KV.Put(ctx, key, val, opts...)
+	return resp, deadlineError(ctx, err)
+}
+
+func (t *timeoutKV) Get(ctx context.Context, key string, opts ...clientv3.OpOption) (*clientv3.GetResponse, error) {
+	ctx, cancel := t.withTimeout(ctx)
+	defer cancel()
+	resp, err := t.KV.Get(ctx, key, opts...)
+	return resp, deadlineError(ctx, err)
+}
+
+func (t *timeoutKV) Delete(ctx context.Context, key string, opts ...clientv3.OpOption) (*clientv3.DeleteResponse, error) {
+	ctx, cancel := t.withTimeout(ctx)
+	defer cancel()
+	resp, err := t.KV.Delete(ctx, key, opts...)
+	return resp, deadlineError(ctx, err)
+}
+
+func (t *timeoutKV) Do(ctx context.Context, op clientv3.Op) (clientv3.OpResponse, error) {
+	ctx, cancel := t.withTimeout(ctx)
+	defer cancel()
+	resp, err := t.KV.Do(ctx, op)
+	return resp, deadlineError(ctx, err)
+}
+
+// Txn starts the timeout once the transaction is created, and it ends once the transaction is committed
+func (t *timeoutKV) Txn(ctx context.Context) clientv3.Txn {
+	ctx, cancel := t.withTimeout(ctx)
+	return &timeoutTxn{
+		Txn:    t.KV.Txn(ctx),
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+type timeoutTxn struct {
+	clientv3.Txn
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+func (t *timeoutTxn) If(cs ...clientv3.Cmp) clientv3.Txn {
+	t.Txn = t.Txn.If(cs...)
+	return t
+}
+
+func (t *timeoutTxn) Then(ops ...clientv3.Op) clientv3.Txn {
+	t.Txn = t.Txn.Then(ops...)
+	return t
+}
+
+func (t *timeoutTxn) Else(ops ...clientv3.Op) clientv3.Txn {
+	t.Txn = t.Txn.Else(ops...)
+	return t
+}
+
+func (t *timeoutTxn) Commit() (*clientv3.TxnResponse, error) {
+	defer t.cancel()
+	resp, err := t.Txn.Commit()
+	return resp, deadlineError(t.ctx, err)
+}
+
+// deadlineError tells the failure caused by the deadline of ctx by wrapping context.DeadlineExceeded,
+// which the etcd client might report as an error of gRPC
+func deadlineError(ctx context.Context, err error) error {
+	if err == nil || !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return err
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return errors.Wrap(err, "the etcd operation timed out")
+	}
+	return errors.Wrapf(context.DeadlineExceeded, "the etcd operation timed out: %v", err)
+}
+
 func (e *etcdSchemaRegistry) get(ctx context.Context, key string, message proto.Message) error {
-	resp, err := e.kv.Get(ctx, key)
+	resp, err := e.getKey(ctx, key)
 	if err != nil {
 		return err
 	}
@@ -394,38 +947,283 @@ func (e *etcdSchemaRegistry) get(ctx context.Context, key string, message proto.
 	if resp.Count > 1 {
 		return ErrUnexpectedNumberOfEntities
 	}
-	if err := proto.Unmarshal(resp.Kvs[0].Value, message); err != nil {
+	if err := decodeValue(resp.Kvs[0].Value, message); err != nil {
 		return err
 	}
+	setModRevision(message, resp.Kvs[0].ModRevision)
 	return nil
 }
 
-func (e *etcdSchemaRegistry) update(ctx context.Context, group *commonv1.Group, key string, message proto.Message) error {
-	val, err := proto.Marshal(message)
+// update overwrites the entity if the message carries no revision. Otherwise, it fails with ErrConflict
+// unless the stored entity is at the revision, and the message gets the new revision once it succeeds.
+// It fails with ErrConflict as well if any of cmps doesn't hold.
+func (e *etcdSchemaRegistry) update(ctx context.Context, group *commonv1.Group, key string, message proto.Message,
+	cmps ...clientv3.Cmp) error {
+	val, err := encodeValue(withoutModRevision(message), e.compress)
 	if err != nil {
 		return err
 	}
-	_, err = e.kv.Put(ctx, key, string(val))
+	var rev int64
+	if m, ok := message.(hasMetadata); ok {
+		rev = m.GetMetadata().GetModRevision()
+	}
+	if rev == 0 {
+		if len(cmps) == 0 {
+			if _, errPut := e.kv.Put(ctx, currentKey(key), string(val)); errPut != nil {
+				return errPut
+			}
+			return e.touchGroup(ctx, group)
+		}
+		resp, errTxn := e.kv.Txn(ctx).If(cmps...).Then(clientv3.OpPut(currentKey(key), string(val))).Commit()
+		if errTxn != nil {
+			return errTxn
+		}
+		if !resp.Succeeded {
+			return errors.Wrapf(ErrConflict, "the entities %s refers to are modified concurrently", key)
+		}
+		return e.touchGroup(ctx, group)
+	}
+	resp, err := e.kv.Txn(ctx).
+		If(append([]clientv3.Cmp{clientv3.Compare(clientv3.ModRevision(currentKey(key)), "=", rev)}, cmps...)...).
+		Then(clientv3.OpPut(currentKey(key), string(val))).
+		Commit()
 	if err != nil {
 		return err
 	}
+	if !resp.Succeeded {
+		legacy, errLegacy := e.readsLegacy(ctx)
+		if errLegacy != nil {
+			return errLegacy
+		}
+		if legacy {
+			// the revision might be read from the key in the legacy format, which isn't migrated yet
+			resp, err = e.kv.Txn(ctx).
+				If(append([]clientv3.Cmp{clientv3.Compare(clientv3.CreateRevision(currentKey(key)), "=", 0),
+					clientv3.Compare(clientv3.ModRevision(key), "=", rev)}, cmps...)...).
+				Then(clientv3.OpPut(currentKey(key), string(val))).
+				Commit()
+			if err != nil {
+				return err
+			}
+		}
+	}
+	if !resp.Succeeded {
+		return errors.Wrapf(ErrConflict, "%s at revision %d", key, rev)
+	}
+	setModRevision(message, resp.Header.Revision)
 	return e.touchGroup(ctx, group)
 }
 
-func (e *etcdSchemaRegistry) listWithPrefix(ctx context.Context, prefix string, factory func() proto.Message) ([]proto.Message, error) {
-	resp, err := e.kv.Get(ctx, prefix, clientv3.WithFromKey(), clientv3.WithRange(incrementLastByte(prefix)))
+// withoutModRevision returns the message to store, whose revision is tracked by etcd instead
+func withoutModRevision(message proto.Message) proto.Message {
+	m, ok := message.(hasMetadata)
+	if !ok || m.GetMetadata().GetModRevision() == 0 {
+		return message
+	}
+	cloned := proto.Clone(message)
+	cloned.(hasMetadata).GetMetadata().ModRevision = 0
+	return cloned
+}
+
+func setModRevision(message proto.Message, rev int64) {
+	if m, ok := message.(hasMetadata); ok && m.GetMetadata() != nil {
+		m.GetMetadata().ModRevision = rev
+	}
+}
+
+// create puts the message if the key is absent. The key holding the same schema, as Hash tells, is left as it is,
+// which makes a retried creation succeed, while the one holding a different schema fails with ErrEntityAlreadyExists.
+// existing receives the stored message for the comparison. The absent key isn't put but fails with ErrConflict
+// if any of cmps doesn't hold.
+func (e *etcdSchemaRegistry) create(ctx context.Context, group *commonv1.Group, key string, message, existing proto.Message,
+	cmps ...clientv3.Cmp) error {
+	message = withoutModRevision(message)
+	val, err := encodeValue(message, e.compress)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	entities := make([]proto.Message, resp.Count)
-	for i := int64(0); i < resp.Count; i++ {
-		message := factory()
-		if err := proto.Unmarshal(resp.Kvs[0].Value, message); err != nil {
-			return nil, err
+	legacy, err := e.readsLegacy(ctx)
+	if err != nil {
+		return err
+	}
+	var stored []byte
+	if legacy {
+		resp, errGet := e.kv.Get(ctx, key)
+		if errGet != nil {
+			return errGet
+		}
+		if resp.Count > 0 {
+			stored = resp.Kvs[0].Value
+		}
+	}
+	if stored == nil {
+		resp, errTxn := e.kv.Txn(ctx).
+			If(append([]clientv3.Cmp{clientv3.Compare(clientv3.CreateRevision(currentKey(key)), "=", 0)}, cmps...)...).
+			Then(clientv3.OpPut(currentKey(key), string(val))).
+			Else(clientv3.OpGet(currentKey(key))).
+			Commit()
+		if errTxn != nil {
+			return errTxn
+		}
+		if resp.Succeeded {
+			return e.touchGroup(ctx, group)
 		}
-		entities[i] = message
+		kvs := resp.Responses[0].GetResponseRange().Kvs
+		if len(kvs) == 0 {
+			return errors.Wrapf(ErrConflict, "the entities %s refers to are modified concurrently", key)
+		}
+		stored = kvs[0].Value
+	}
+	return checkExisting(key, message, stored, existing)
+}
+
+// checkExisting fails with ErrEntityAlreadyExists if the stored entity differs from the message without its revision
+func checkExisting(key string, message proto.Message, stored []byte, existing proto.Message) error {
+	if err := decodeValue(stored, existing); err != nil {
+		return err
 	}
-	return entities, nil
+	expected, err := Hash(message)
+	if err != nil {
+		return err
+	}
+	actual, err := Hash(existing)
+	if err != nil {
+		return err
+	}
+	if expected != actual {
+		return errors.Wrap(ErrEntityAlreadyExists, key)
+	}
+	return nil
+}
+
+// listEntities returns the entities of all the groups or the group in opt matching opt.Selector, ordered by opt.OrderBy.
+// If opt.Limit is positive, it returns a page of them along with the cursor of the next page,
+// which is empty once the last page is returned.
+func (e *etcdSchemaRegistry) listEntities(ctx context.Context, opt ListOpt, entityPrefix string,
+	factory func() proto.Message) ([]proto.Message, string, error) {
+	paged := opt.Limit > 0 || opt.Continue != ""
+	if paged && opt.OrderBy != ListOrderByName {
+		return nil, "", errors.Wrap(ErrInvalidListOpt, "only the entities ordered by name are paged")
+	}
+	afterGroup, after, err := decodeContinue(opt.Continue, entityPrefix)
+	if err != nil {
+		return nil, "", err
+	}
+	keyPrefixes, err := e.listPrefixesForEntity(ctx, opt, entityPrefix)
+	if err != nil {
+		return nil, "", err
+	}
+	sel, err := parseSelector(opt.Selector, entityPrefix)
+	if err != nil {
+		return nil, "", err
+	}
+	var entities []listedEntity
+	var lastKeys []string
+	for _, keyPrefix := range keyPrefixes {
+		// the prefixes are ordered by their groups, and the groups before the cursor's are listed by the previous pages
+		group := strings.TrimSuffix(strings.TrimPrefix(keyPrefix, GroupsKeyPrefix), entityPrefix)
+		if after != "" && group < afterGroup {
+			continue
+		}
+		from := ""
+		if group == afterGroup {
+			from = after
+		}
+		// the entities dropped by the selector are made up by ranging again after the last key
+		for {
+			var limit int64
+			if opt.Limit > 0 {
+				// one more entity tells whether the next page exists
+				limit = int64(opt.Limit + 1 - len(entities))
+			}
+			page, errRange := e.rangePage(ctx, keyPrefix, from, limit)
+			if errRange != nil {
+				return nil, "", errRange
+			}
+			for _, kv := range page {
+				message := factory()
+				if errUnmarshal := decodeValue(kv.value, message); errUnmarshal != nil {
+					return nil, "", errUnmarshal
+				}
+				if !sel.matches(message) {
+					continue
+				}
+				setModRevision(message, kv.modRevision)
+				entities = append(entities, listedEntity{message: message, createRevision: kv.createRevision})
+				lastKeys = append(lastKeys, kv.key)
+			}
+			if limit == 0 || int64(len(page)) < limit || len(entities) > opt.Limit {
+				break
+			}
+			from = page[len(page)-1].key
+		}
+		if opt.Limit > 0 && len(entities) > opt.Limit {
+			break
+		}
+	}
+	var next string
+	if opt.Limit > 0 && len(entities) > opt.Limit {
+		entities = entities[:opt.Limit]
+		next = encodeContinue(lastKeys[opt.Limit-1])
+	}
+	sortEntities(entities, opt.OrderBy)
+	messages := make([]proto.Message, len(entities))
+	for i := range entities {
+		messages[i] = entities[i].message
+	}
+	return messages, next, nil
+}
+
+// encodeContinue hides the last key of a page in an opaque cursor
+func encodeContinue(key string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(key))
+}
+
+// decodeContinue returns the last key listed by the previous page, and the group of the key
+func decodeContinue(cursor, entityPrefix string) (group, key string, err error) {
+	if cursor == "" {
+		return "", "", nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return "", "", errors.Wrapf(ErrInvalidListOpt, "malformed cursor %q", cursor)
+	}
+	key = string(data)
+	i := strings.Index(key, entityPrefix)
+	if !strings.HasPrefix(key, GroupsKeyPrefix) || i < len(GroupsKeyPrefix) {
+		return "", "", errors.Wrapf(ErrInvalidListOpt, "malformed cursor %q", cursor)
+	}
+	return key[len(GroupsKeyPrefix):i], key, nil
+}
+
+type listedEntity struct {
+	message        proto.Message
+	createRevision int64
+}
+
+type hasMetadata interface {
+	GetMetadata() *commonv1.Metadata
+}
+
+func sortEntities(entities []listedEntity, order ListOrder) {
+	byName := func(i, j int) bool {
+		mi := entities[i].message.(hasMetadata).GetMetadata()
+		mj := entities[j].message.(hasMetadata).GetMetadata()
+		if mi.GetGroup() != mj.GetGroup() {
+			return mi.GetGroup() < mj.GetGroup()
+		}
+		return mi.GetName() < mj.GetName()
+	}
+	if order != ListOrderByCreateRevision {
+		sort.SliceStable(entities, byName)
+		return
+	}
+	sort.SliceStable(entities, func(i, j int) bool {
+		if entities[i].createRevision != entities[j].createRevision {
+			return entities[i].createRevision < entities[j].createRevision
+		}
+		return byName(i, j)
+	})
 }
 
 func (e *etcdSchemaRegistry) listPrefixesForEntity(ctx context.Context, opt ListOpt, entityPrefix string) ([]string, error) {
@@ -447,11 +1245,11 @@ func (e *etcdSchemaRegistry) listPrefixesForEntity(ctx context.Context, opt List
 }
 
 func (e *etcdSchemaRegistry) delete(ctx context.Context, g *commonv1.Group, key string) (bool, error) {
-	resp, err := e.kv.Delete(ctx, key)
+	deleted, err := e.deleteKey(ctx, key, "")
 	if err != nil {
 		return false, err
 	}
-	if resp.Deleted > 0 {
+	if deleted > 0 {
 		return true, e.touchGroup(ctx, g)
 	}
 	return false, nil
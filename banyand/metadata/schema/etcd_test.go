@@ -23,15 +23,23 @@ import (
 	"fmt"
 	"os"
 	"path"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	commonv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/common/v1"
 	databasev1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/database/v1"
+	"github.com/apache/skywalking-banyandb/pkg/logger"
 )
 
 const indexRuleDir = "testdata/index_rules"
@@ -50,45 +58,15 @@ func preloadSchema(e Registry) error {
 		return err
 	}
 
-	s := &databasev1.Stream{}
-	if err := protojson.Unmarshal([]byte(streamJSON), s); err != nil {
-		return err
-	}
-	err := e.UpdateStream(context.Background(), s)
-	if err != nil {
-		return err
-	}
-
-	indexRuleBinding := &databasev1.IndexRuleBinding{}
-	if err = protojson.Unmarshal([]byte(indexRuleBindingJSON), indexRuleBinding); err != nil {
-		return err
-	}
-	err = e.UpdateIndexRuleBinding(context.Background(), indexRuleBinding)
+	docs, err := ReadDocuments(indexRuleStore, indexRuleDir, KindIndexRule)
 	if err != nil {
 		return err
 	}
-
-	entries, err := indexRuleStore.ReadDir(indexRuleDir)
-	if err != nil {
-		return err
-	}
-	for _, entry := range entries {
-		data, err := indexRuleStore.ReadFile(indexRuleDir + "/" + entry.Name())
-		if err != nil {
-			return err
-		}
-		var idxRule databasev1.IndexRule
-		err = protojson.Unmarshal(data, &idxRule)
-		if err != nil {
-			return err
-		}
-		err = e.UpdateIndexRule(context.Background(), &idxRule)
-		if err != nil {
-			return err
-		}
-	}
-
-	return nil
+	// the index rules are imported before the binding referring to them
+	return Import(context.Background(), e, append(docs, []Document{
+		{Kind: KindStream, Source: "testdata/stream.json", Data: []byte(streamJSON)},
+		{Kind: KindIndexRuleBinding, Source: "testdata/index_rule_binding.json", Data: []byte(indexRuleBindingJSON)},
+	}...)...)
 }
 
 type HasMetadata interface {
@@ -208,7 +186,7 @@ func Test_Etcd_Entity_List(t *testing.T) {
 		{
 			name: "List Stream without Group",
 			list: func(r Registry) (int, error) {
-				entities, innerErr := r.ListStream(context.TODO(), ListOpt{})
+				entities, _, innerErr := r.ListStream(context.TODO(), ListOpt{})
 				if innerErr != nil {
 					return 0, innerErr
 				}
@@ -219,7 +197,7 @@ func Test_Etcd_Entity_List(t *testing.T) {
 		{
 			name: "List Stream with Group default",
 			list: func(r Registry) (int, error) {
-				entities, innerErr := r.ListStream(context.TODO(), ListOpt{Group: "default"})
+				entities, _, innerErr := r.ListStream(context.TODO(), ListOpt{Group: "default"})
 				if innerErr != nil {
 					return 0, innerErr
 				}
@@ -230,7 +208,7 @@ func Test_Etcd_Entity_List(t *testing.T) {
 		{
 			name: "List IndexRuleBinding without Group",
 			list: func(r Registry) (int, error) {
-				entities, innerErr := r.ListIndexRuleBinding(context.TODO(), ListOpt{})
+				entities, _, innerErr := r.ListIndexRuleBinding(context.TODO(), ListOpt{})
 				if innerErr != nil {
 					return 0, innerErr
 				}
@@ -241,7 +219,7 @@ func Test_Etcd_Entity_List(t *testing.T) {
 		{
 			name: "List IndexRuleBinding with Group",
 			list: func(r Registry) (int, error) {
-				entities, innerErr := r.ListIndexRuleBinding(context.TODO(), ListOpt{Group: "default"})
+				entities, _, innerErr := r.ListIndexRuleBinding(context.TODO(), ListOpt{Group: "default"})
 				if innerErr != nil {
 					return 0, innerErr
 				}
@@ -252,7 +230,7 @@ func Test_Etcd_Entity_List(t *testing.T) {
 		{
 			name: "List IndexRule without Group",
 			list: func(r Registry) (int, error) {
-				entities, innerErr := r.ListIndexRule(context.TODO(), ListOpt{})
+				entities, _, innerErr := r.ListIndexRule(context.TODO(), ListOpt{})
 				if innerErr != nil {
 					return 0, innerErr
 				}
@@ -263,7 +241,7 @@ func Test_Etcd_Entity_List(t *testing.T) {
 		{
 			name: "List IndexRule with Group",
 			list: func(r Registry) (int, error) {
-				entities, innerErr := r.ListIndexRule(context.TODO(), ListOpt{Group: "default"})
+				entities, _, innerErr := r.ListIndexRule(context.TODO(), ListOpt{Group: "default"})
 				if innerErr != nil {
 					return 0, innerErr
 				}
@@ -274,7 +252,7 @@ func Test_Etcd_Entity_List(t *testing.T) {
 		{
 			name: "List Measure without Group",
 			list: func(r Registry) (int, error) {
-				entities, innerErr := r.ListMeasure(context.TODO(), ListOpt{})
+				entities, _, innerErr := r.ListMeasure(context.TODO(), ListOpt{})
 				if innerErr != nil {
 					return 0, innerErr
 				}
@@ -285,7 +263,7 @@ func Test_Etcd_Entity_List(t *testing.T) {
 		{
 			name: "List Measure with Group",
 			list: func(r Registry) (int, error) {
-				entities, innerErr := r.ListMeasure(context.TODO(), ListOpt{Group: "default"})
+				entities, _, innerErr := r.ListMeasure(context.TODO(), ListOpt{Group: "default"})
 				if innerErr != nil {
 					return 0, innerErr
 				}
@@ -325,7 +303,7 @@ func Test_Etcd_Delete(t *testing.T) {
 		{
 			name: "Delete IndexRule",
 			list: func(r Registry) (int, error) {
-				entities, innerErr := r.ListIndexRule(context.TODO(), ListOpt{Group: "default"})
+				entities, _, innerErr := r.ListIndexRule(context.TODO(), ListOpt{Group: "default"})
 				if innerErr != nil {
 					return 0, innerErr
 				}
@@ -344,7 +322,7 @@ func Test_Etcd_Delete(t *testing.T) {
 		{
 			name: "Delete Group",
 			list: func(r Registry) (int, error) {
-				entities, innerErr := r.ListIndexRule(context.TODO(), ListOpt{Group: "default"})
+				entities, _, innerErr := r.ListIndexRule(context.TODO(), ListOpt{Group: "default"})
 				if innerErr != nil {
 					return 0, innerErr
 				}
@@ -373,3 +351,877 @@ func Test_Etcd_Delete(t *testing.T) {
 		})
 	}
 }
+
+// blockedKV simulates a stuck etcd which never responds until the context is done
+type blockedKV struct {
+	clientv3.KV
+}
+
+func (b *blockedKV) Get(ctx context.Context, _ string, _ ...clientv3.OpOption) (*clientv3.GetResponse, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func (b *blockedKV) Put(ctx context.Context, _, _ string, _ ...clientv3.OpOption) (*clientv3.PutResponse, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func (b *blockedKV) Txn(ctx context.Context) clientv3.Txn {
+	return &blockedTxn{ctx: ctx}
+}
+
+// blockedTxn reports the deadline as the error of gRPC like the etcd client might do
+type blockedTxn struct {
+	clientv3.Txn
+	ctx context.Context
+}
+
+func (b *blockedTxn) If(_ ...clientv3.Cmp) clientv3.Txn {
+	return b
+}
+
+func (b *blockedTxn) Then(_ ...clientv3.Op) clientv3.Txn {
+	return b
+}
+
+func (b *blockedTxn) Commit() (*clientv3.TxnResponse, error) {
+	<-b.ctx.Done()
+	return nil, status.Error(codes.DeadlineExceeded, "the deadline is exceeded")
+}
+
+func Test_Etcd_OperationTimeout(t *testing.T) {
+	tester := assert.New(t)
+	timeout := 100 * time.Millisecond
+	registry := &etcdSchemaRegistry{
+		kv: newTimeoutKV(&blockedKV{}, timeout),
+	}
+
+	start := time.Now()
+	_, err := registry.GetGroup(context.TODO(), "default")
+	tester.ErrorIs(err, context.DeadlineExceeded)
+	tester.GreaterOrEqual(time.Since(start), timeout)
+
+	start = time.Now()
+	err = registry.touchGroup(context.TODO(), &commonv1.Group{Name: "default"})
+	tester.ErrorIs(err, context.DeadlineExceeded)
+	tester.GreaterOrEqual(time.Since(start), timeout)
+
+	// the caller's deadline takes precedence over the default timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	start = time.Now()
+	_, err = registry.GetGroup(ctx, "default")
+	tester.ErrorIs(err, context.DeadlineExceeded)
+	tester.Less(time.Since(start), timeout)
+
+	// so is the transaction, whose error of gRPC is translated
+	start = time.Now()
+	err = registry.update(context.TODO(), &commonv1.Group{Name: "default"}, formatSteamKey(&commonv1.Metadata{Group: "default", Name: "sw"}),
+		&databasev1.Stream{Metadata: &commonv1.Metadata{Group: "default", Name: "sw", ModRevision: 1}})
+	tester.ErrorIs(err, context.DeadlineExceeded)
+	tester.NotErrorIs(err, ErrEntityNotFound)
+	tester.GreaterOrEqual(time.Since(start), timeout)
+
+	// the operations without the default timeout still respect the caller's deadline
+	registry.kv = newTimeoutKV(&blockedKV{}, 0)
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = registry.GetGroup(ctx, "default")
+	tester.ErrorIs(err, context.DeadlineExceeded)
+}
+
+func Test_Etcd_LegacyKeyFormat(t *testing.T) {
+	tester := require.New(t)
+	registry, err := NewEtcdSchemaRegistry(useUnixDomain(), useRandomTempDir())
+	tester.NoError(err)
+	defer registry.Close()
+	kv := registry.(*etcdSchemaRegistry).kv
+	ctx := context.TODO()
+
+	// seed the keys in the legacy format, as the registry before the upgrade did
+	put := func(key string, message proto.Message) {
+		val, errMarshal := proto.Marshal(message)
+		tester.NoError(errMarshal)
+		_, errPut := kv.Put(ctx, key, string(val))
+		tester.NoError(errPut)
+	}
+	s := &databasev1.Stream{}
+	tester.NoError(protojson.Unmarshal([]byte(streamJSON), s))
+	put(formatGroupKey("default"), &commonv1.Group{Name: "default"})
+	put(formatSteamKey(s.GetMetadata()), s)
+	untouched := proto.Clone(s).(*databasev1.Stream)
+	untouched.Metadata.Name = "untouched"
+	put(formatSteamKey(untouched.GetMetadata()), untouched)
+
+	groups, err := registry.ListGroup(ctx)
+	tester.NoError(err)
+	tester.Equal([]string{"default"}, groups)
+	got, err := registry.GetStream(ctx, s.GetMetadata())
+	tester.NoError(err)
+	tester.Equal(s.GetMetadata().GetName(), got.GetMetadata().GetName())
+	streams, _, err := registry.ListStream(ctx, ListOpt{})
+	tester.NoError(err)
+	tester.Len(streams, 2)
+
+	// the update is written in the current format only, which shadows the legacy one
+	s.Opts.ShardNum = 7
+	tester.NoError(registry.UpdateStream(ctx, s))
+	resp, err := kv.Get(ctx, currentKey(formatSteamKey(s.GetMetadata())))
+	tester.NoError(err)
+	tester.EqualValues(1, resp.Count)
+	legacy := &databasev1.Stream{}
+	resp, err = kv.Get(ctx, formatSteamKey(s.GetMetadata()))
+	tester.NoError(err)
+	tester.NoError(proto.Unmarshal(resp.Kvs[0].Value, legacy))
+	tester.NotEqual(uint32(7), legacy.GetOpts().GetShardNum())
+	got, err = registry.GetStream(ctx, s.GetMetadata())
+	tester.NoError(err)
+	tester.Equal(uint32(7), got.GetOpts().GetShardNum())
+	streams, _, err = registry.ListStream(ctx, ListOpt{Group: "default"})
+	tester.NoError(err)
+	tester.Len(streams, 2)
+	tester.Equal(uint32(7), streams[0].GetOpts().GetShardNum())
+	tester.Equal("untouched", streams[1].GetMetadata().GetName())
+
+	// the updated stream and the group are in the current format, so only the untouched stream is migrated
+	migrated, err := migrateKeys(ctx, kv)
+	tester.NoError(err)
+	tester.Equal(1, migrated)
+	resp, err = kv.Get(ctx, GroupsKeyPrefix, clientv3.WithPrefix())
+	tester.NoError(err)
+	tester.Zero(resp.Count)
+	got, err = registry.GetStream(ctx, s.GetMetadata())
+	tester.NoError(err)
+	tester.Equal(uint32(7), got.GetOpts().GetShardNum())
+	streams, _, err = registry.ListStream(ctx, ListOpt{})
+	tester.NoError(err)
+	tester.Len(streams, 2)
+	// the registry stops reading the legacy keys after the migration
+	tester.Equal(int32(1), registry.(*etcdSchemaRegistry).keysMigrated)
+
+	deleted, err := registry.DeleteStream(ctx, s.GetMetadata(), DeleteOpt{})
+	tester.NoError(err)
+	tester.True(deleted)
+	_, err = registry.GetStream(ctx, s.GetMetadata())
+	tester.ErrorIs(err, ErrEntityNotFound)
+}
+
+func Test_Etcd_DeleteSubject(t *testing.T) {
+	tester := require.New(t)
+	registry, err := NewEtcdSchemaRegistry(useUnixDomain(), useRandomTempDir())
+	tester.NoError(err)
+	defer registry.Close()
+	tester.NoError(preloadSchema(registry))
+	ctx := context.TODO()
+
+	streamMeta := &commonv1.Metadata{Group: "default", Name: "sw"}
+	bindingMeta := &commonv1.Metadata{Group: "default", Name: "sw-index-rule-binding"}
+	binding, err := registry.GetIndexRuleBinding(ctx, bindingMeta)
+	tester.NoError(err)
+	measureMeta := &commonv1.Metadata{Group: "default", Name: "service_cpm"}
+	tester.NoError(registry.CreateMeasure(ctx, &databasev1.Measure{Metadata: measureMeta}))
+	measureBinding := proto.Clone(binding).(*databasev1.IndexRuleBinding)
+	measureBinding.Metadata = &commonv1.Metadata{Group: "default", Name: "service_cpm-index-rule-binding"}
+	measureBinding.Subject = &databasev1.Subject{Catalog: commonv1.Catalog_CATALOG_MEASURE, Name: "service_cpm"}
+	tester.NoError(registry.CreateIndexRuleBinding(ctx, measureBinding))
+
+	// the subject of a binding is kept without cascade
+	_, err = registry.DeleteStream(ctx, streamMeta, DeleteOpt{})
+	tester.ErrorIs(err, ErrReferredByBindings)
+	tester.Contains(err.Error(), "sw-index-rule-binding")
+	_, err = registry.GetStream(ctx, streamMeta)
+	tester.NoError(err)
+	_, err = registry.DeleteMeasure(ctx, measureMeta, DeleteOpt{})
+	tester.ErrorIs(err, ErrReferredByBindings)
+	tester.Contains(err.Error(), "service_cpm-index-rule-binding")
+
+	// the cascade takes the bindings of the subject along, while the ones of the other subjects stay
+	deleted, err := registry.DeleteStream(ctx, streamMeta, DeleteOpt{Cascade: true})
+	tester.NoError(err)
+	tester.True(deleted)
+	_, err = registry.GetStream(ctx, streamMeta)
+	tester.ErrorIs(err, ErrEntityNotFound)
+	_, err = registry.GetIndexRuleBinding(ctx, bindingMeta)
+	tester.ErrorIs(err, ErrEntityNotFound)
+	_, err = registry.GetIndexRuleBinding(ctx, measureBinding.GetMetadata())
+	tester.NoError(err)
+	rules, _, err := registry.ListIndexRule(ctx, ListOpt{Group: "default"})
+	tester.NoError(err)
+	tester.NotEmpty(rules)
+
+	deleted, err = registry.DeleteMeasure(ctx, measureMeta, DeleteOpt{Cascade: true})
+	tester.NoError(err)
+	tester.True(deleted)
+	bindings, _, err := registry.ListIndexRuleBinding(ctx, ListOpt{Group: "default"})
+	tester.NoError(err)
+	tester.Empty(bindings)
+
+	// the subject without any binding is deleted without cascade
+	tester.NoError(registry.CreateMeasure(ctx, &databasev1.Measure{Metadata: measureMeta}))
+	deleted, err = registry.DeleteMeasure(ctx, measureMeta, DeleteOpt{})
+	tester.NoError(err)
+	tester.True(deleted)
+	deleted, err = registry.DeleteMeasure(ctx, measureMeta, DeleteOpt{})
+	tester.NoError(err)
+	tester.False(deleted)
+}
+
+func Test_Etcd_IndexRuleBindingWindow(t *testing.T) {
+	tester := require.New(t)
+	registry, err := NewEtcdSchemaRegistry(useUnixDomain(), useRandomTempDir())
+	tester.NoError(err)
+	defer registry.Close()
+	tester.NoError(preloadSchema(registry))
+	ctx := context.TODO()
+
+	preloaded, err := registry.GetIndexRuleBinding(ctx, &commonv1.Metadata{Group: "default", Name: "sw-index-rule-binding"})
+	tester.NoError(err)
+	now := time.Now()
+	binding := func(name string, beginAt, expireAt time.Time) *databasev1.IndexRuleBinding {
+		b := proto.Clone(preloaded).(*databasev1.IndexRuleBinding)
+		b.Metadata = &commonv1.Metadata{Group: "default", Name: name}
+		b.BeginAt = timestamppb.New(beginAt)
+		b.ExpireAt = timestamppb.New(expireAt)
+		return b
+	}
+
+	// the inverted and the expired windows are rejected
+	inverted := binding("inverted", now.Add(2*time.Hour), now.Add(time.Hour))
+	tester.ErrorIs(registry.CreateIndexRuleBinding(ctx, inverted), ErrInvalidBindingWindow)
+	tester.ErrorIs(registry.UpdateIndexRuleBinding(ctx, inverted), ErrInvalidBindingWindow)
+	tester.ErrorIs(registry.Txn(ctx, func(tx RegistryTx) error {
+		return tx.UpdateIndexRuleBinding(inverted)
+	}), ErrInvalidBindingWindow)
+	tester.ErrorIs(registry.UpdateIndexRuleBinding(ctx, binding("empty", now, now)), ErrInvalidBindingWindow)
+	expired := binding("expired", now.Add(-2*time.Hour), now.Add(-time.Hour))
+	tester.ErrorIs(registry.UpdateIndexRuleBinding(ctx, expired), ErrInvalidBindingWindow)
+	bindings, _, err := registry.ListIndexRuleBinding(ctx, ListOpt{})
+	tester.NoError(err)
+	tester.Len(bindings, 1)
+
+	// only the bindings whose windows contain the instant are active
+	tester.NoError(registry.CreateIndexRuleBinding(ctx, binding("upcoming", now.Add(time.Hour), now.Add(2*time.Hour))))
+	names := func(at time.Time) []string {
+		active, errActive := registry.ActiveIndexRuleBindings(ctx, at)
+		tester.NoError(errActive)
+		result := make([]string, 0, len(active))
+		for _, b := range active {
+			result = append(result, b.GetMetadata().GetName())
+		}
+		return result
+	}
+	tester.Equal([]string{"sw-index-rule-binding"}, names(now))
+	tester.Equal([]string{"sw-index-rule-binding", "upcoming"}, names(now.Add(90*time.Minute)))
+	tester.Equal([]string{"sw-index-rule-binding", "upcoming"}, names(now.Add(2*time.Hour)))
+	tester.Equal([]string{"sw-index-rule-binding"}, names(now.Add(3*time.Hour)))
+	tester.Empty(names(preloaded.GetExpireAt().AsTime().Add(time.Nanosecond)))
+}
+
+func Test_Etcd_ListOrder(t *testing.T) {
+	tester := require.New(t)
+	registry, err := NewEtcdSchemaRegistry(useUnixDomain(), useRandomTempDir())
+	tester.NoError(err)
+	defer registry.Close()
+	ctx := context.TODO()
+
+	template := &databasev1.Stream{}
+	tester.NoError(protojson.Unmarshal([]byte(streamJSON), template))
+	create := func(group, name string) {
+		tester.NoError(registry.CreateGroup(ctx, group))
+		s := proto.Clone(template).(*databasev1.Stream)
+		s.Metadata.Group = group
+		s.Metadata.Name = name
+		tester.NoError(registry.UpdateStream(ctx, s))
+	}
+	names := func(streams []*databasev1.Stream) []string {
+		result := make([]string, 0, len(streams))
+		for _, s := range streams {
+			result = append(result, s.GetMetadata().GetGroup()+"/"+s.GetMetadata().GetName())
+		}
+		return result
+	}
+	inserted := []string{"sw-b/c", "sw-a/b", "sw-b/a", "sw-a/c", "sw/z", "sw-a/a"}
+	for _, n := range inserted {
+		parts := strings.SplitN(n, "/", 2)
+		create(parts[0], parts[1])
+	}
+
+	groups, err := registry.ListGroup(ctx)
+	tester.NoError(err)
+	tester.Equal([]string{"sw", "sw-a", "sw-b"}, groups)
+	want := []string{"sw/z", "sw-a/a", "sw-a/b", "sw-a/c", "sw-b/a", "sw-b/c"}
+	for i := 0; i < 3; i++ {
+		streams, _, errList := registry.ListStream(ctx, ListOpt{})
+		tester.NoError(errList)
+		tester.Equal(want, names(streams))
+	}
+	streams, _, err := registry.ListStream(ctx, ListOpt{Group: "sw-b"})
+	tester.NoError(err)
+	tester.Equal([]string{"sw-b/a", "sw-b/c"}, names(streams))
+
+	// updating a stream keeps its creation revision
+	s := proto.Clone(template).(*databasev1.Stream)
+	s.Metadata.Group, s.Metadata.Name = "sw-b", "c"
+	s.Opts.ShardNum = 7
+	tester.NoError(registry.UpdateStream(ctx, s))
+	streams, _, err = registry.ListStream(ctx, ListOpt{OrderBy: ListOrderByCreateRevision})
+	tester.NoError(err)
+	tester.Equal(inserted, names(streams))
+}
+
+func Test_Etcd_ListPages(t *testing.T) {
+	tester := require.New(t)
+	registry, err := NewEtcdSchemaRegistry(useUnixDomain(), useRandomTempDir())
+	tester.NoError(err)
+	defer registry.Close()
+	ctx := context.TODO()
+
+	template := &databasev1.Stream{}
+	tester.NoError(protojson.Unmarshal([]byte(streamJSON), template))
+	for _, n := range []string{"sw-b/c", "sw-a/b", "sw-b/a", "sw-a/c", "sw/z", "sw-a/a"} {
+		parts := strings.SplitN(n, "/", 2)
+		tester.NoError(registry.CreateGroup(ctx, parts[0]))
+		s := proto.Clone(template).(*databasev1.Stream)
+		s.Metadata.Group, s.Metadata.Name = parts[0], parts[1]
+		tester.NoError(registry.UpdateStream(ctx, s))
+	}
+	listAll := func(opt ListOpt) (pages [][]string) {
+		for {
+			streams, next, errList := registry.ListStream(ctx, opt)
+			tester.NoError(errList)
+			page := make([]string, 0, len(streams))
+			for _, s := range streams {
+				page = append(page, s.GetMetadata().GetGroup()+"/"+s.GetMetadata().GetName())
+			}
+			pages = append(pages, page)
+			if next == "" {
+				return pages
+			}
+			opt.Continue = next
+		}
+	}
+
+	// the pages cross the groups in the order of their names
+	tester.Equal([][]string{
+		{"sw/z", "sw-a/a", "sw-a/b", "sw-a/c"},
+		{"sw-b/a", "sw-b/c"},
+	}, listAll(ListOpt{Limit: 4}))
+	tester.Equal([][]string{
+		{"sw/z", "sw-a/a"},
+		{"sw-a/b", "sw-a/c"},
+		{"sw-b/a", "sw-b/c"},
+	}, listAll(ListOpt{Limit: 2}))
+	// the last page which is full has no cursor
+	tester.Equal([][]string{{"sw-a/a", "sw-a/b", "sw-a/c"}}, listAll(ListOpt{Group: "sw-a", Limit: 3}))
+	tester.Equal([][]string{{"sw-a/a", "sw-a/b"}, {"sw-a/c"}}, listAll(ListOpt{Group: "sw-a", Limit: 2}))
+
+	_, _, err = registry.ListStream(ctx, ListOpt{Limit: 2, Continue: "not a cursor"})
+	tester.ErrorIs(err, ErrInvalidListOpt)
+	_, next, err := registry.ListStream(ctx, ListOpt{Limit: 2})
+	tester.NoError(err)
+	_, _, err = registry.ListIndexRule(ctx, ListOpt{Limit: 2, Continue: next})
+	tester.ErrorIs(err, ErrInvalidListOpt, "the cursor of another kind of entities is invalid")
+	_, _, err = registry.ListStream(ctx, ListOpt{Limit: 2, OrderBy: ListOrderByCreateRevision})
+	tester.ErrorIs(err, ErrInvalidListOpt)
+}
+
+func Test_Etcd_ListSelector(t *testing.T) {
+	tester := require.New(t)
+	registry, err := NewEtcdSchemaRegistry(useUnixDomain(), useRandomTempDir())
+	tester.NoError(err)
+	defer registry.Close()
+	tester.NoError(preloadSchema(registry))
+	ctx := context.TODO()
+
+	template := &databasev1.Stream{}
+	tester.NoError(protojson.Unmarshal([]byte(streamJSON), template))
+	for _, n := range []string{"sw-a/a", "sw-a/b", "sw-a/c", "sw-b/a"} {
+		parts := strings.SplitN(n, "/", 2)
+		tester.NoError(registry.CreateGroup(ctx, parts[0]))
+		s := proto.Clone(template).(*databasev1.Stream)
+		s.Metadata.Group, s.Metadata.Name = parts[0], parts[1]
+		if n == "sw-a/b" || n == "sw-b/a" {
+			s.Entity.TagNames = []string{"service_id"}
+		}
+		tester.NoError(registry.UpdateStream(ctx, s))
+	}
+	listAll := func(opt ListOpt) (pages [][]string) {
+		for {
+			streams, next, errList := registry.ListStream(ctx, opt)
+			tester.NoError(errList)
+			page := make([]string, 0, len(streams))
+			for _, s := range streams {
+				page = append(page, s.GetMetadata().GetGroup()+"/"+s.GetMetadata().GetName())
+			}
+			pages = append(pages, page)
+			if next == "" {
+				return pages
+			}
+			opt.Continue = next
+		}
+	}
+
+	tester.Equal([][]string{{"default/sw", "sw-a/a", "sw-a/c"}}, listAll(ListOpt{Selector: "entity=state"}))
+	tester.Equal([][]string{{"sw-b/a"}}, listAll(ListOpt{Selector: "entity=service_id, group=sw-b"}))
+	tester.Equal([][]string{{"sw-a/c"}}, listAll(ListOpt{Group: "sw-a", Selector: "name=c,tag=trace_id"}))
+	// the pages are filled by the matched entities, skipping the unmatched ones in between
+	tester.Equal([][]string{{"default/sw", "sw-a/a"}, {"sw-a/c"}}, listAll(ListOpt{Selector: "entity=state", Limit: 2}))
+	tester.Equal([][]string{{"sw-a/a"}, {"sw-a/c"}}, listAll(ListOpt{Group: "sw-a", Selector: "entity=state", Limit: 1}))
+
+	rules, _, err := registry.ListIndexRule(ctx, ListOpt{Selector: "type=TYPE_TREE"})
+	tester.NoError(err)
+	tester.Len(rules, 2)
+	for _, r := range rules {
+		tester.Equal(databasev1.IndexRule_TYPE_TREE, r.GetType())
+	}
+	bindings, _, err := registry.ListIndexRuleBinding(ctx, ListOpt{Selector: "subject=sw,catalog=CATALOG_STREAM"})
+	tester.NoError(err)
+	tester.Len(bindings, 1)
+	bindings, _, err = registry.ListIndexRuleBinding(ctx, ListOpt{Selector: "rule=nonexistent"})
+	tester.NoError(err)
+	tester.Empty(bindings)
+
+	_, _, err = registry.ListStream(ctx, ListOpt{Selector: "entity"})
+	tester.ErrorIs(err, ErrInvalidListOpt)
+	_, _, err = registry.ListStream(ctx, ListOpt{Selector: "field=value"})
+	tester.ErrorIs(err, ErrInvalidListOpt, "the fields of a stream aren't selectable")
+}
+
+func Test_Etcd_CompressedValues(t *testing.T) {
+	tester := require.New(t)
+	registry, err := NewEtcdSchemaRegistry(useUnixDomain(), useRandomTempDir(), CompressValues(true))
+	tester.NoError(err)
+	defer registry.Close()
+	kv := registry.(*etcdSchemaRegistry).kv
+	ctx := context.TODO()
+
+	s := &databasev1.Stream{}
+	tester.NoError(protojson.Unmarshal([]byte(streamJSON), s))
+	for i := 0; i < 100; i++ {
+		family := &databasev1.TagFamilySpec{Name: fmt.Sprintf("family_%d", i)}
+		for j := 0; j < 10; j++ {
+			family.Tags = append(family.Tags, &databasev1.TagSpec{
+				Name: fmt.Sprintf("tag_%d_%d", i, j),
+				Type: databasev1.TagType_TAG_TYPE_STRING,
+			})
+		}
+		s.TagFamilies = append(s.TagFamilies, family)
+	}
+	tester.NoError(registry.CreateGroup(ctx, s.GetMetadata().GetGroup()))
+	tester.NoError(registry.UpdateStream(ctx, s))
+
+	plain, err := proto.Marshal(s)
+	tester.NoError(err)
+	resp, err := kv.Get(ctx, currentKey(formatSteamKey(s.GetMetadata())))
+	tester.NoError(err)
+	tester.EqualValues(1, resp.Count)
+	stored := resp.Kvs[0].Value
+	tester.Equal(compressedMarker, stored[0])
+	tester.Less(len(stored), len(plain))
+
+	got, err := registry.GetStream(ctx, s.GetMetadata())
+	tester.NoError(err)
+	tester.True(proto.Equal(s, withoutModRevision(got)))
+	streams, _, err := registry.ListStream(ctx, ListOpt{Group: s.GetMetadata().GetGroup()})
+	tester.NoError(err)
+	tester.Len(streams, 1)
+	tester.True(proto.Equal(s, withoutModRevision(streams[0])))
+
+	// the plain values written before enabling the compression are still readable
+	untouched := proto.Clone(s).(*databasev1.Stream)
+	untouched.Metadata.Name = "untouched"
+	plain, err = proto.Marshal(untouched)
+	tester.NoError(err)
+	_, err = kv.Put(ctx, currentKey(formatSteamKey(untouched.GetMetadata())), string(plain))
+	tester.NoError(err)
+	got, err = registry.GetStream(ctx, untouched.GetMetadata())
+	tester.NoError(err)
+	tester.True(proto.Equal(untouched, withoutModRevision(got)))
+	streams, _, err = registry.ListStream(ctx, ListOpt{Group: s.GetMetadata().GetGroup()})
+	tester.NoError(err)
+	tester.Len(streams, 2)
+
+	// a truncated value is reported rather than decoded partially
+	_, err = kv.Put(ctx, currentKey(formatSteamKey(untouched.GetMetadata())), string(stored[:len(stored)/2]))
+	tester.NoError(err)
+	_, err = registry.GetStream(ctx, untouched.GetMetadata())
+	tester.ErrorIs(err, ErrCorruptValue)
+}
+
+func Test_Etcd_DeleteBindingAndRules(t *testing.T) {
+	tester := require.New(t)
+	registry, err := NewEtcdSchemaRegistry(useUnixDomain(), useRandomTempDir())
+	tester.NoError(err)
+	defer registry.Close()
+	tester.NoError(preloadSchema(registry))
+	ctx := context.TODO()
+
+	// another binding shares two of the rules
+	shared := &databasev1.IndexRuleBinding{}
+	tester.NoError(protojson.Unmarshal([]byte(indexRuleBindingJSON), shared))
+	shared.Metadata.Name = "sw-shared-binding"
+	shared.Rules = []string{"trace_id", "duration"}
+	tester.NoError(registry.UpdateIndexRuleBinding(ctx, shared))
+	rules, _, err := registry.ListIndexRule(ctx, ListOpt{Group: "default"})
+	tester.NoError(err)
+	tester.Len(rules, 10)
+
+	bindingMeta := &commonv1.Metadata{Name: "sw-index-rule-binding", Group: "default"}
+	deleted, err := registry.DeleteBindingAndRules(ctx, bindingMeta)
+	tester.NoError(err)
+	tester.Equal(8, deleted)
+	_, err = registry.GetIndexRuleBinding(ctx, bindingMeta)
+	tester.ErrorIs(err, ErrEntityNotFound)
+	rules, _, err = registry.ListIndexRule(ctx, ListOpt{Group: "default"})
+	tester.NoError(err)
+	names := make([]string, 0, len(rules))
+	for _, r := range rules {
+		names = append(names, r.GetMetadata().GetName())
+	}
+	tester.Equal([]string{"duration", "trace_id"}, names)
+	_, err = registry.GetIndexRuleBinding(ctx, shared.GetMetadata())
+	tester.NoError(err)
+
+	_, err = registry.DeleteBindingAndRules(ctx, bindingMeta)
+	tester.ErrorIs(err, ErrEntityNotFound)
+	// the rules are exclusive once the other binding is gone
+	deleted, err = registry.DeleteBindingAndRules(ctx, shared.GetMetadata())
+	tester.NoError(err)
+	tester.Equal(2, deleted)
+	rules, _, err = registry.ListIndexRule(ctx, ListOpt{Group: "default"})
+	tester.NoError(err)
+	tester.Empty(rules)
+}
+
+func Test_Etcd_DeleteGroupsByPrefix(t *testing.T) {
+	tester := require.New(t)
+	registry, err := NewEtcdSchemaRegistry(useUnixDomain(), useRandomTempDir())
+	tester.NoError(err)
+	defer registry.Close()
+	tester.NoError(preloadSchema(registry))
+	ctx := context.TODO()
+
+	s, err := registry.GetStream(ctx, &commonv1.Metadata{Group: "default", Name: "sw"})
+	tester.NoError(err)
+	for _, group := range []string{"tenant-a-1", "tenant-a-2", "tenant-b"} {
+		tester.NoError(registry.CreateGroup(ctx, group))
+		stream := proto.Clone(s).(*databasev1.Stream)
+		stream.Metadata = &commonv1.Metadata{Group: group, Name: "sw"}
+		tester.NoError(registry.CreateStream(ctx, stream))
+	}
+	groups, err := registry.ListGroups(ctx)
+	tester.NoError(err)
+	names := make([]string, 0, len(groups))
+	for _, g := range groups {
+		names = append(names, g.GetName())
+	}
+	tester.Equal([]string{"default", "tenant-a-1", "tenant-a-2", "tenant-b"}, names)
+
+	_, err = registry.DeleteGroupsByPrefix(ctx, "")
+	tester.ErrorIs(err, ErrEmptyGroupPrefix)
+	deleted, err := registry.DeleteGroupsByPrefix(ctx, "tenant-a-")
+	tester.NoError(err)
+	tester.Equal(2, deleted)
+	names, err = registry.ListGroup(ctx)
+	tester.NoError(err)
+	tester.Equal([]string{"default", "tenant-b"}, names)
+	// the entities of the deleted groups are gone along with them
+	for _, group := range []string{"tenant-a-1", "tenant-a-2"} {
+		_, err = registry.GetStream(ctx, &commonv1.Metadata{Group: group, Name: "sw"})
+		tester.ErrorIs(err, ErrEntityNotFound)
+	}
+	_, err = registry.GetStream(ctx, &commonv1.Metadata{Group: "tenant-b", Name: "sw"})
+	tester.NoError(err)
+
+	deleted, err = registry.DeleteGroupsByPrefix(ctx, "tenant-a-")
+	tester.NoError(err)
+	tester.Zero(deleted)
+}
+
+func Test_Etcd_SchemaHash(t *testing.T) {
+	tester := require.New(t)
+	registry, err := NewEtcdSchemaRegistry(useUnixDomain(), useRandomTempDir())
+	tester.NoError(err)
+	defer registry.Close()
+	tester.NoError(preloadSchema(registry))
+	ctx := context.TODO()
+	md := &commonv1.Metadata{Group: "default", Name: "sw"}
+
+	hashOf := func() uint64 {
+		s, errGet := registry.GetStream(ctx, md)
+		tester.NoError(errGet)
+		hash, errHash := Hash(s)
+		tester.NoError(errHash)
+		return hash
+	}
+	origin := hashOf()
+
+	// a no-op update only touches the bookkeeping fields
+	s, err := registry.GetStream(ctx, md)
+	tester.NoError(err)
+	s.UpdatedAtNanoseconds = timestamppb.Now()
+	tester.NoError(registry.UpdateStream(ctx, s))
+	tester.Equal(origin, hashOf())
+	tester.NoError(registry.UpdateStream(ctx, s))
+	tester.Equal(origin, hashOf())
+
+	// altering a tag changes the hash
+	s.TagFamilies[1].Tags[0].Type = databasev1.TagType_TAG_TYPE_INT
+	tester.NoError(registry.UpdateStream(ctx, s))
+	altered := hashOf()
+	tester.NotEqual(origin, altered)
+	tester.NoError(registry.UpdateStream(ctx, s))
+	tester.Equal(altered, hashOf())
+}
+
+func Test_Etcd_Create(t *testing.T) {
+	tester := require.New(t)
+	registry, err := NewEtcdSchemaRegistry(useUnixDomain(), useRandomTempDir())
+	tester.NoError(err)
+	defer registry.Close()
+	tester.NoError(preloadSchema(registry))
+	ctx := context.TODO()
+
+	s, err := registry.GetStream(ctx, &commonv1.Metadata{Group: "default", Name: "sw"})
+	tester.NoError(err)
+	// the retry of an identical creation succeeds
+	tester.NoError(registry.CreateStream(ctx, s))
+	s.UpdatedAtNanoseconds = timestamppb.Now()
+	tester.NoError(registry.CreateStream(ctx, s))
+
+	// the conflicting one fails, and the existing one is kept
+	conflicting := proto.Clone(s).(*databasev1.Stream)
+	conflicting.Opts.ShardNum++
+	tester.ErrorIs(registry.CreateStream(ctx, conflicting), ErrEntityAlreadyExists)
+	got, err := registry.GetStream(ctx, s.GetMetadata())
+	tester.NoError(err)
+	tester.Equal(s.GetOpts().GetShardNum(), got.GetOpts().GetShardNum())
+
+	// the absent one is created
+	conflicting.Metadata.Name = "sw_created"
+	tester.NoError(registry.CreateStream(ctx, conflicting))
+	got, err = registry.GetStream(ctx, conflicting.GetMetadata())
+	tester.NoError(err)
+	tester.True(proto.Equal(withoutModRevision(conflicting), withoutModRevision(got)))
+}
+
+func Test_Etcd_UpdateWithRevision(t *testing.T) {
+	tester := require.New(t)
+	registry, err := NewEtcdSchemaRegistry(useUnixDomain(), useRandomTempDir())
+	tester.NoError(err)
+	defer registry.Close()
+	tester.NoError(preloadSchema(registry))
+	ctx := context.TODO()
+	meta := &commonv1.Metadata{Group: "default", Name: "sw"}
+
+	first, err := registry.GetStream(ctx, meta)
+	tester.NoError(err)
+	tester.NotZero(first.GetMetadata().GetModRevision())
+	second, err := registry.GetStream(ctx, meta)
+	tester.NoError(err)
+	tester.Equal(first.GetMetadata().GetModRevision(), second.GetMetadata().GetModRevision())
+
+	// the first writer wins, and gets the new revision
+	first.Opts.ShardNum = 7
+	readRevision := first.GetMetadata().GetModRevision()
+	tester.NoError(registry.UpdateStream(ctx, first))
+	tester.Greater(first.GetMetadata().GetModRevision(), readRevision)
+	second.Opts.ShardNum = 8
+	tester.ErrorIs(registry.UpdateStream(ctx, second), ErrConflict)
+	got, err := registry.GetStream(ctx, meta)
+	tester.NoError(err)
+	tester.Equal(uint32(7), got.GetOpts().GetShardNum())
+	tester.Equal(first.GetMetadata().GetModRevision(), got.GetMetadata().GetModRevision())
+
+	// the revision isn't stored in the value
+	resp, err := registry.(*etcdSchemaRegistry).kv.Get(ctx, currentKey(formatSteamKey(meta)))
+	tester.NoError(err)
+	stored := &databasev1.Stream{}
+	tester.NoError(decodeValue(resp.Kvs[0].Value, stored))
+	tester.Zero(stored.GetMetadata().GetModRevision())
+
+	// a zero revision overwrites the entity as before
+	second.Metadata.ModRevision = 0
+	tester.NoError(registry.UpdateStream(ctx, second))
+	got, err = registry.GetStream(ctx, meta)
+	tester.NoError(err)
+	tester.Equal(uint32(8), got.GetOpts().GetShardNum())
+
+	// the measures are updated in the same way
+	measureMeta := &commonv1.Metadata{Group: "default", Name: "service_cpm"}
+	tester.NoError(registry.CreateMeasure(ctx, &databasev1.Measure{Metadata: measureMeta}))
+	m, err := registry.GetMeasure(ctx, measureMeta)
+	tester.NoError(err)
+	tester.NotZero(m.GetMetadata().GetModRevision())
+	stale := proto.Clone(m).(*databasev1.Measure)
+	tester.NoError(registry.UpdateMeasure(ctx, m))
+	tester.ErrorIs(registry.UpdateMeasure(ctx, stale), ErrConflict)
+}
+
+func Test_Etcd_UseEndpoints(t *testing.T) {
+	tester := require.New(t)
+	embedded, err := NewEtcdSchemaRegistry(useUnixDomain(), useRandomTempDir())
+	tester.NoError(err)
+	defer embedded.Close()
+	tester.NoError(preloadSchema(embedded))
+	ctx := context.TODO()
+	endpoint := embedded.(*etcdSchemaRegistry).server.Config().ACUrls[0].String()
+
+	registry, err := NewEtcdSchemaRegistry(UseEndpoints([]string{endpoint}, nil))
+	tester.NoError(err)
+	<-registry.ReadyNotify()
+	s, err := registry.GetStream(ctx, &commonv1.Metadata{Group: "default", Name: "sw"})
+	tester.NoError(err)
+	tester.Equal("sw", s.GetMetadata().GetName())
+	measureMeta := &commonv1.Metadata{Group: "default", Name: "service_cpm"}
+	tester.NoError(registry.CreateMeasure(ctx, &databasev1.Measure{Metadata: measureMeta}))
+	_, err = embedded.GetMeasure(ctx, measureMeta)
+	tester.NoError(err)
+
+	// closing the registry leaves the external cluster running
+	tester.NoError(registry.Close())
+	tester.NoError(registry.Close())
+	<-registry.StoppingNotify()
+	<-registry.StopNotify()
+	_, err = embedded.GetStream(ctx, &commonv1.Metadata{Group: "default", Name: "sw"})
+	tester.NoError(err)
+}
+
+func Test_Etcd_UnknownEntityTag(t *testing.T) {
+	tester := require.New(t)
+	registry, err := NewEtcdSchemaRegistry(useUnixDomain(), useRandomTempDir())
+	tester.NoError(err)
+	defer registry.Close()
+	tester.NoError(preloadSchema(registry))
+	ctx := context.TODO()
+
+	s, err := registry.GetStream(ctx, &commonv1.Metadata{Group: "default", Name: "sw"})
+	tester.NoError(err)
+	invalid := proto.Clone(s).(*databasev1.Stream)
+	invalid.Metadata.Name = "sw_invalid"
+	invalid.Metadata.ModRevision = 0
+	invalid.Entity.TagNames = append(invalid.Entity.TagNames, "absent_tag")
+	err = registry.CreateStream(ctx, invalid)
+	tester.ErrorIs(err, ErrUnknownEntityTag)
+	tester.Contains(err.Error(), "absent_tag")
+	_, err = registry.GetStream(ctx, invalid.GetMetadata())
+	tester.ErrorIs(err, ErrEntityNotFound)
+	invalid.Metadata.Name = "sw"
+	tester.ErrorIs(registry.UpdateStream(ctx, invalid), ErrUnknownEntityTag)
+
+	measure := &databasev1.Measure{
+		Metadata: &commonv1.Metadata{Group: "default", Name: "service_cpm"},
+		TagFamilies: []*databasev1.TagFamilySpec{{
+			Name: "default",
+			Tags: []*databasev1.TagSpec{{Name: "id", Type: databasev1.TagType_TAG_TYPE_STRING}},
+		}},
+		Entity: &databasev1.Entity{TagNames: []string{"id", "entity_id"}},
+	}
+	err = registry.CreateMeasure(ctx, measure)
+	tester.ErrorIs(err, ErrUnknownEntityTag)
+	tester.Contains(err.Error(), "entity_id")
+	measure.Entity.TagNames = []string{"id"}
+	tester.NoError(registry.CreateMeasure(ctx, measure))
+	measure.Entity.TagNames = []string{"entity_id"}
+	tester.ErrorIs(registry.UpdateMeasure(ctx, measure), ErrUnknownEntityTag)
+}
+
+func Test_Etcd_IndexRuleBindingRules(t *testing.T) {
+	tester := require.New(t)
+	registry, err := NewEtcdSchemaRegistry(useUnixDomain(), useRandomTempDir())
+	tester.NoError(err)
+	defer registry.Close()
+	tester.NoError(preloadSchema(registry))
+	ctx := context.TODO()
+
+	preloaded, err := registry.GetIndexRuleBinding(ctx, &commonv1.Metadata{Group: "default", Name: "sw-index-rule-binding"})
+	tester.NoError(err)
+	binding := proto.Clone(preloaded).(*databasev1.IndexRuleBinding)
+	binding.Metadata = &commonv1.Metadata{Group: "default", Name: "absent-rules"}
+	binding.Rules = append(binding.Rules, "absent_rule")
+
+	// the binding referring to an absent rule is rejected, which names the rule
+	err = registry.CreateIndexRuleBinding(ctx, binding)
+	tester.ErrorIs(err, ErrIndexRuleNotExist)
+	tester.ErrorIs(err, ErrInvalidSchema)
+	tester.Contains(err.Error(), "absent_rule")
+	tester.NotContains(err.Error(), "trace_id")
+	tester.ErrorIs(registry.UpdateIndexRuleBinding(ctx, binding), ErrIndexRuleNotExist)
+	tester.ErrorIs(registry.ValidateIndexRuleBinding(ctx, binding, ValidateOpt{Create: true}), ErrIndexRuleNotExist)
+	tester.ErrorIs(registry.Txn(ctx, func(tx RegistryTx) error {
+		return tx.UpdateIndexRuleBinding(binding)
+	}), ErrIndexRuleNotExist)
+	_, err = registry.GetIndexRuleBinding(ctx, binding.GetMetadata())
+	tester.ErrorIs(err, ErrEntityNotFound)
+
+	// the rule created by the same transaction is found, while the deleted one is absent
+	rule, err := registry.GetIndexRule(ctx, &commonv1.Metadata{Group: "default", Name: "trace_id"})
+	tester.NoError(err)
+	tester.ErrorIs(registry.Txn(ctx, func(tx RegistryTx) error {
+		if errTx := tx.DeleteIndexRule(rule.GetMetadata()); errTx != nil {
+			return errTx
+		}
+		return tx.UpdateIndexRuleBinding(preloaded)
+	}), ErrIndexRuleNotExist)
+	absentRule := proto.Clone(rule).(*databasev1.IndexRule)
+	absentRule.Metadata = &commonv1.Metadata{Group: "default", Name: "absent_rule"}
+	tester.NoError(registry.Txn(ctx, func(tx RegistryTx) error {
+		if errTx := tx.UpdateIndexRule(absentRule); errTx != nil {
+			return errTx
+		}
+		return tx.UpdateIndexRuleBinding(binding)
+	}))
+	_, err = registry.GetIndexRuleBinding(ctx, binding.GetMetadata())
+	tester.NoError(err)
+
+	// the rule deleted between the validation and the put fails the put of the binding
+	e := registry.(*etcdSchemaRegistry)
+	racing := proto.Clone(binding).(*databasev1.IndexRuleBinding)
+	racing.Metadata = &commonv1.Metadata{Group: "default", Name: "racing"}
+	ruleCmps, err := e.validateBindingRules(ctx, racing, nil)
+	tester.NoError(err)
+	tester.Len(ruleCmps, len(racing.GetRules()))
+	_, err = registry.DeleteIndexRule(ctx, absentRule.GetMetadata())
+	tester.NoError(err)
+	g, err := registry.GetGroup(ctx, "default")
+	tester.NoError(err)
+	key := formatIndexRuleBindingKey(racing.GetMetadata())
+	tester.ErrorIs(e.create(ctx, g, key, racing, &databasev1.IndexRuleBinding{}, ruleCmps...), ErrConflict)
+	tester.ErrorIs(e.update(ctx, g, key, racing, ruleCmps...), ErrConflict)
+	_, err = registry.GetIndexRuleBinding(ctx, racing.GetMetadata())
+	tester.ErrorIs(err, ErrEntityNotFound)
+}
+
+func Test_Etcd_DeferBindingValidation(t *testing.T) {
+	tester := require.New(t)
+	tester.NoError(logger.Init(logger.Logging{
+		Env:   "dev",
+		Level: "warn",
+	}))
+	registry, err := NewEtcdSchemaRegistry(useUnixDomain(), useRandomTempDir(), DeferBindingValidation(true))
+	tester.NoError(err)
+	defer registry.Close()
+	ctx := context.TODO()
+	tester.NoError(registry.CreateGroup(ctx, "default"))
+
+	// the binding is stored before its rules, as the bootstrap might do
+	binding := &databasev1.IndexRuleBinding{}
+	tester.NoError(protojson.Unmarshal([]byte(indexRuleBindingJSON), binding))
+	tester.NoError(registry.ValidateIndexRuleBinding(ctx, binding, ValidateOpt{Create: true}))
+	tester.NoError(registry.CreateIndexRuleBinding(ctx, binding))
+	tester.NoError(registry.UpdateIndexRuleBinding(ctx, binding))
+	tester.NoError(registry.Txn(ctx, func(tx RegistryTx) error {
+		return tx.UpdateIndexRuleBinding(binding)
+	}))
+	_, err = registry.GetIndexRuleBinding(ctx, binding.GetMetadata())
+	tester.NoError(err)
+
+	// the rules loaded later complete the binding
+	docs, err := ReadDocuments(indexRuleStore, indexRuleDir, KindIndexRule)
+	tester.NoError(err)
+	tester.NoError(Import(ctx, registry, docs...))
+	rules, _, err := registry.ListIndexRule(ctx, ListOpt{Group: "default"})
+	tester.NoError(err)
+	tester.Len(rules, len(binding.GetRules()))
+}
@@ -0,0 +1,52 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package schema
+
+import (
+	"google.golang.org/protobuf/proto"
+
+	"github.com/apache/skywalking-banyandb/pkg/convert"
+)
+
+// updatedAtField and modRevisionField are the bookkeeping fields of the schemas, which don't contribute to their hashes
+const (
+	updatedAtField   = "updated_at_nanoseconds"
+	metadataField    = "metadata"
+	modRevisionField = "mod_revision"
+)
+
+// Hash returns a stable hash of the schema. It changes only if the schema changes,
+// hence a no-op update keeps it, and the clients refetch the cached schema only if it differs.
+func Hash(message proto.Message) (uint64, error) {
+	m := proto.Clone(message)
+	r := m.ProtoReflect()
+	if fd := r.Descriptor().Fields().ByName(updatedAtField); fd != nil {
+		r.Clear(fd)
+	}
+	if fd := r.Descriptor().Fields().ByName(metadataField); fd != nil && r.Has(fd) {
+		md := r.Mutable(fd).Message()
+		if revision := md.Descriptor().Fields().ByName(modRevisionField); revision != nil {
+			md.Clear(revision)
+		}
+	}
+	val, err := proto.MarshalOptions{Deterministic: true}.Marshal(m)
+	if err != nil {
+		return 0, err
+	}
+	return convert.Hash(val), nil
+}
@@ -0,0 +1,230 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package schema
+
+import (
+	"context"
+	"encoding/json"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	commonv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/common/v1"
+	databasev1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/database/v1"
+)
+
+var ErrMalformedDocument = errors.New("schema document is malformed")
+
+// Kind is the kind of the resource a document describes or an event is about
+type Kind int
+
+const (
+	KindStream Kind = iota
+	KindMeasure
+	KindIndexRule
+	KindIndexRuleBinding
+	// KindGroup is watched, and its document is only loaded by LoadSchemaDir
+	KindGroup
+)
+
+func (k Kind) String() string {
+	switch k {
+	case KindStream:
+		return "stream"
+	case KindMeasure:
+		return "measure"
+	case KindIndexRule:
+		return "index_rule"
+	case KindIndexRuleBinding:
+		return "index_rule_binding"
+	case KindGroup:
+		return "group"
+	}
+	return "unknown"
+}
+
+// Document is a resource in JSON along with where it's from, for example, a filename or a key
+type Document struct {
+	Kind   Kind
+	Source string
+	Data   []byte
+}
+
+// ReadDocuments reads the json files in a directory as the documents of a kind
+func ReadDocuments(fsys fs.FS, dir string, kind Kind) ([]Document, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+	docs := make([]Document, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || path.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		source := path.Join(dir, entry.Name())
+		data, errRead := fs.ReadFile(fsys, source)
+		if errRead != nil {
+			return nil, errRead
+		}
+		docs = append(docs, Document{Kind: kind, Source: source, Data: data})
+	}
+	return docs, nil
+}
+
+// Import loads the documents into the registry in order.
+// It's best-effort: a document failing to load doesn't stop the rest,
+// and the returned error collects all the failures, each of which names its document.
+func Import(ctx context.Context, r Registry, docs ...Document) (err error) {
+	for _, doc := range docs {
+		err = multierr.Append(err, importDocument(ctx, r, doc))
+	}
+	return err
+}
+
+func importDocument(ctx context.Context, r Registry, doc Document) error {
+	var update func() error
+	var m proto.Message
+	switch doc.Kind {
+	case KindStream:
+		s := &databasev1.Stream{}
+		m, update = s, func() error { return r.UpdateStream(ctx, s) }
+	case KindMeasure:
+		s := &databasev1.Measure{}
+		m, update = s, func() error { return r.UpdateMeasure(ctx, s) }
+	case KindIndexRule:
+		s := &databasev1.IndexRule{}
+		m, update = s, func() error { return r.UpdateIndexRule(ctx, s) }
+	case KindIndexRuleBinding:
+		s := &databasev1.IndexRuleBinding{}
+		m, update = s, func() error { return r.UpdateIndexRuleBinding(ctx, s) }
+	case KindGroup:
+		g := &commonv1.Group{}
+		m, update = g, func() error { return r.CreateGroup(ctx, g.GetName()) }
+	default:
+		return errors.Errorf("%s %s: unknown kind %d", doc.Kind, doc.Source, doc.Kind)
+	}
+	if err := protojson.Unmarshal(doc.Data, m); err != nil {
+		return errors.Wrapf(ErrMalformedDocument, "%s %s: %v", doc.Kind, doc.Source, err)
+	}
+	if err := update(); err != nil {
+		return errors.WithMessagef(err, "%s %s", doc.Kind, doc.Source)
+	}
+	return nil
+}
+
+// LoadSchemaDir imports the json files under dir and its subdirectories, which bootstraps the registry.
+// The kind of a file is decided by the nearest directory named after a kind, e.g. "streams" or "index_rules",
+// or by the fields of the document otherwise. The groups are loaded first, then the streams and the measures,
+// then the index rules, and the bindings are the last. Like Import, the returned error collects all the files failing to load.
+func LoadSchemaDir(ctx context.Context, r Registry, fsys fs.FS, dir string) error {
+	var docs []Document
+	var err error
+	errWalk := fs.WalkDir(fsys, dir, func(p string, entry fs.DirEntry, errEntry error) error {
+		if errEntry != nil {
+			err = multierr.Append(err, errEntry)
+			return nil
+		}
+		if entry.IsDir() || path.Ext(p) != ".json" {
+			return nil
+		}
+		data, errRead := fs.ReadFile(fsys, p)
+		if errRead != nil {
+			err = multierr.Append(err, errRead)
+			return nil
+		}
+		kind, ok := kindOfDir(strings.TrimPrefix(path.Dir(p), dir))
+		if !ok {
+			if kind, errRead = detectKind(data); errRead != nil {
+				err = multierr.Append(err, errors.WithMessage(errRead, p))
+				return nil
+			}
+		}
+		docs = append(docs, Document{Kind: kind, Source: p, Data: data})
+		return nil
+	})
+	if errWalk != nil {
+		return errWalk
+	}
+	// the walk lists the files in lexical order, which is kept among the documents of a kind
+	sort.SliceStable(docs, func(i, j int) bool {
+		return loadingOrder(docs[i].Kind) < loadingOrder(docs[j].Kind)
+	})
+	return multierr.Append(err, Import(ctx, r, docs...))
+}
+
+// loadingOrder ranks a kind before the ones depending on it
+func loadingOrder(kind Kind) int {
+	switch kind {
+	case KindGroup:
+		return 0
+	case KindStream, KindMeasure:
+		return 1
+	case KindIndexRule:
+		return 2
+	}
+	return 3
+}
+
+// kindOfDir returns the kind named by the nearest directory of a relative path, e.g. "streams" or "index-rule-bindings"
+func kindOfDir(dir string) (Kind, bool) {
+	parts := strings.Split(dir, "/")
+	for i := len(parts) - 1; i >= 0; i-- {
+		name := strings.TrimSuffix(strings.ReplaceAll(parts[i], "-", "_"), "s")
+		for _, kind := range []Kind{KindGroup, KindStream, KindMeasure, KindIndexRule, KindIndexRuleBinding} {
+			if name == kind.String() {
+				return kind, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// detectKind tells the kind of a document by its fields, which are either in lowerCamelCase or in snake_case
+func detectKind(data []byte) (Kind, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return 0, errors.Wrap(ErrMalformedDocument, err.Error())
+	}
+	has := func(names ...string) bool {
+		for _, n := range names {
+			if _, ok := fields[n]; ok {
+				return true
+			}
+		}
+		return false
+	}
+	switch {
+	case has("subject", "rules"):
+		return KindIndexRuleBinding, nil
+	case has("fields", "intervalRules", "interval_rules"):
+		return KindMeasure, nil
+	case has("entity", "tagFamilies", "tag_families"):
+		return KindStream, nil
+	case has("tags", "type", "location"):
+		return KindIndexRule, nil
+	case has("name") && !has("metadata"):
+		return KindGroup, nil
+	}
+	return 0, errors.Wrap(ErrMalformedDocument, "the kind is unknown")
+}
@@ -0,0 +1,111 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package schema
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	commonv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/common/v1"
+	databasev1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/database/v1"
+)
+
+func Test_Import_BestEffort(t *testing.T) {
+	req := require.New(t)
+	registry, err := NewEtcdSchemaRegistry(useUnixDomain(), useRandomTempDir())
+	req.NoError(err)
+	defer registry.Close()
+	req.NoError(registry.CreateGroup(context.TODO(), "default"))
+
+	dbInstance, err := indexRuleStore.ReadFile(indexRuleDir + "/db.instance.json")
+	req.NoError(err)
+	bundle := fstest.MapFS{
+		"rules/db.instance.json": {Data: dbInstance},
+		"rules/broken.json":      {Data: []byte(`{"metadata": {"name": "broken"`)},
+		"rules/README.md":        {Data: []byte("not a document")},
+	}
+	docs, err := ReadDocuments(bundle, "rules", KindIndexRule)
+	req.NoError(err)
+	req.Len(docs, 2)
+	docs = append(docs, Document{Kind: KindStream, Source: "stream.json", Data: []byte(streamJSON)})
+
+	err = Import(context.TODO(), registry, docs...)
+	req.Error(err)
+	req.True(errors.Is(err, ErrMalformedDocument))
+	req.Contains(err.Error(), "index_rule rules/broken.json")
+	req.NotContains(err.Error(), "db.instance")
+
+	_, err = registry.GetIndexRule(context.TODO(), &commonv1.Metadata{Name: "db.instance", Group: "default"})
+	req.NoError(err)
+	_, err = registry.GetStream(context.TODO(), &commonv1.Metadata{Name: "sw", Group: "default"})
+	req.NoError(err)
+}
+
+func Test_LoadSchemaDir(t *testing.T) {
+	req := require.New(t)
+	registry, err := NewEtcdSchemaRegistry(useUnixDomain(), useRandomTempDir())
+	req.NoError(err)
+	defer registry.Close()
+
+	dbInstance, err := indexRuleStore.ReadFile(indexRuleDir + "/db.instance.json")
+	req.NoError(err)
+	// the binding refers to the only rule of the bundle
+	binding := &databasev1.IndexRuleBinding{}
+	req.NoError(protojson.Unmarshal([]byte(indexRuleBindingJSON), binding))
+	binding.Rules = []string{"db.instance"}
+	bindingJSON, err := protojson.Marshal(binding)
+	req.NoError(err)
+	// the bindings and the streams are walked before the group and the rules they depend on
+	bootstrap := fstest.MapFS{
+		"bootstrap/a_binding.json":          {Data: bindingJSON},
+		"bootstrap/groups/default.json":     {Data: []byte(`{"name": "default"}`)},
+		"bootstrap/rules/db.instance.json":  {Data: dbInstance},
+		"bootstrap/default/streams/sw.json": {Data: []byte(streamJSON)},
+		"bootstrap/unknown.json":            {Data: []byte(`{"metadata": {"name": "unknown", "group": "default"}}`)},
+		"bootstrap/streams/broken.json":     {Data: []byte(`{"metadata": {"name": "broken"`)},
+		"README.md":                         {Data: []byte("not a document")},
+	}
+
+	err = LoadSchemaDir(context.TODO(), registry, bootstrap, "bootstrap")
+	req.Error(err)
+	req.True(errors.Is(err, ErrMalformedDocument))
+	req.Contains(err.Error(), "bootstrap/unknown.json")
+	req.Contains(err.Error(), "stream bootstrap/streams/broken.json")
+	req.NotContains(err.Error(), "default.json")
+	req.NotContains(err.Error(), "a_binding.json")
+
+	_, err = registry.GetGroup(context.TODO(), "default")
+	req.NoError(err)
+	_, err = registry.GetStream(context.TODO(), &commonv1.Metadata{Name: "sw", Group: "default"})
+	req.NoError(err)
+	_, err = registry.GetIndexRule(context.TODO(), &commonv1.Metadata{Name: "db.instance", Group: "default"})
+	req.NoError(err)
+	_, err = registry.GetIndexRuleBinding(context.TODO(), &commonv1.Metadata{Name: "sw-index-rule-binding", Group: "default"})
+	req.NoError(err)
+
+	// loading it again upserts the same entities
+	req.NoError(LoadSchemaDir(context.TODO(), registry, fstest.MapFS{
+		"groups/default.json":   {Data: []byte(`{"name": "default"}`)},
+		"index-rules/rule.json": {Data: dbInstance},
+	}, "."))
+}
@@ -0,0 +1,190 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package schema
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync/atomic"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// The keys of schemas are versioned to allow a rolling upgrade to change their format.
+// During the migration window, the registry writes the keys in the current format only,
+// and reads the legacy ones as well until KeyFormatMarker claims all of them are migrated.
+const (
+	// KeyFormatMarker stores the version of the key format that all the keys are in
+	KeyFormatMarker = "/__key_format__"
+
+	keyFormatCurrent = "2"
+	// currentKeyRoot is prepended to a key in the legacy format to get the current one
+	currentKeyRoot = "/v2"
+)
+
+type keyValue struct {
+	// key is in the legacy format, which the callers use to compose and parse keys
+	key   string
+	value []byte
+	// createRevision is the revision of etcd when the key is created
+	createRevision int64
+	modRevision    int64
+}
+
+func currentKey(legacyKey string) string {
+	return currentKeyRoot + legacyKey
+}
+
+// readsLegacy reports whether the legacy keys might exist, which stops once they are migrated
+func (e *etcdSchemaRegistry) readsLegacy(ctx context.Context) (bool, error) {
+	if atomic.LoadInt32(&e.keysMigrated) == 1 {
+		return false, nil
+	}
+	resp, err := e.kv.Get(ctx, KeyFormatMarker)
+	if err != nil {
+		return false, err
+	}
+	if resp.Count > 0 && string(resp.Kvs[0].Value) == keyFormatCurrent {
+		atomic.StoreInt32(&e.keysMigrated, 1)
+		return false, nil
+	}
+	return true, nil
+}
+
+// getKey returns the value of a key, which prefers the current format to the legacy one
+func (e *etcdSchemaRegistry) getKey(ctx context.Context, key string) (*clientv3.GetResponse, error) {
+	resp, err := e.kv.Get(ctx, currentKey(key))
+	if err != nil || resp.Count > 0 {
+		return resp, err
+	}
+	legacy, err := e.readsLegacy(ctx)
+	if err != nil || !legacy {
+		return resp, err
+	}
+	return e.kv.Get(ctx, key)
+}
+
+// rangeWithPrefix returns the key-values under the prefix in both formats, ordered by their keys.
+// The current format takes precedence if a key exists in both.
+func (e *etcdSchemaRegistry) rangeWithPrefix(ctx context.Context, prefix string) ([]keyValue, error) {
+	return e.rangePage(ctx, prefix, "", 0)
+}
+
+// rangePage works like rangeWithPrefix, but it returns at most limit key-values after the key after.
+// An empty after starts from the prefix, and a non-positive limit returns all of them.
+func (e *etcdSchemaRegistry) rangePage(ctx context.Context, prefix, after string, limit int64) ([]keyValue, error) {
+	start := prefix
+	if after >= prefix {
+		// the smallest key greater than after
+		start = after + "\x00"
+	}
+	opts := []clientv3.OpOption{clientv3.WithFromKey(), clientv3.WithRange(currentKey(incrementLastByte(prefix)))}
+	if limit > 0 {
+		opts = append(opts, clientv3.WithLimit(limit))
+	}
+	resp, err := e.kv.Get(ctx, currentKey(start), opts...)
+	if err != nil {
+		return nil, err
+	}
+	kvMap := make(map[string]keyValue, resp.Count)
+	for _, kv := range resp.Kvs {
+		key := strings.TrimPrefix(string(kv.Key), currentKeyRoot)
+		kvMap[key] = keyValue{key: key, value: kv.Value, createRevision: kv.CreateRevision, modRevision: kv.ModRevision}
+	}
+	legacy, err := e.readsLegacy(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if legacy {
+		opts[1] = clientv3.WithRange(incrementLastByte(prefix))
+		resp, err = e.kv.Get(ctx, start, opts...)
+		if err != nil {
+			return nil, err
+		}
+		for _, kv := range resp.Kvs {
+			if _, ok := kvMap[string(kv.Key)]; !ok {
+				kvMap[string(kv.Key)] = keyValue{key: string(kv.Key), value: kv.Value,
+					createRevision: kv.CreateRevision, modRevision: kv.ModRevision}
+			}
+		}
+	}
+	kvs := make([]keyValue, 0, len(kvMap))
+	for _, kv := range kvMap {
+		kvs = append(kvs, kv)
+	}
+	sort.Slice(kvs, func(i, j int) bool {
+		return kvs[i].key < kvs[j].key
+	})
+	// both formats might fill the page, so the merged one is trimmed
+	if limit > 0 && int64(len(kvs)) > limit {
+		kvs = kvs[:limit]
+	}
+	return kvs, nil
+}
+
+// deleteKey removes a key in both formats. The range end is applied if it's not empty.
+func (e *etcdSchemaRegistry) deleteKey(ctx context.Context, key, rangeEnd string) (int64, error) {
+	var deleted int64
+	for _, k := range []struct{ key, end string }{
+		{key: currentKey(key), end: currentKey(rangeEnd)},
+		{key: key, end: rangeEnd},
+	} {
+		var opts []clientv3.OpOption
+		if rangeEnd != "" {
+			opts = append(opts, clientv3.WithRange(k.end))
+		}
+		resp, err := e.kv.Delete(ctx, k.key, opts...)
+		if err != nil {
+			return deleted, err
+		}
+		deleted += resp.Deleted
+	}
+	return deleted, nil
+}
+
+// migrateKeys moves the keys in the legacy format to the current one, and marks the migration is done.
+// It should run offline, when none of the readers or writers of the legacy keys exists.
+// A key written in the current format during the migration window is never overwritten by the legacy one.
+func migrateKeys(ctx context.Context, kv clientv3.KV) (int, error) {
+	resp, err := kv.Get(ctx, GroupsKeyPrefix, clientv3.WithFromKey(), clientv3.WithRange(incrementLastByte(GroupsKeyPrefix)))
+	if err != nil {
+		return 0, err
+	}
+	var migrated int
+	for _, legacy := range resp.Kvs {
+		key := currentKey(string(legacy.Key))
+		txnResp, errTxn := kv.Txn(ctx).
+			If(clientv3.Compare(clientv3.CreateRevision(key), "=", 0)).
+			Then(clientv3.OpPut(key, string(legacy.Value))).
+			Commit()
+		if errTxn != nil {
+			return migrated, errTxn
+		}
+		if txnResp.Succeeded {
+			migrated++
+		}
+		if _, errDelete := kv.Delete(ctx, string(legacy.Key)); errDelete != nil {
+			return migrated, errDelete
+		}
+	}
+	if _, err = kv.Put(ctx, KeyFormatMarker, keyFormatCurrent); err != nil {
+		return migrated, err
+	}
+	return migrated, nil
+}
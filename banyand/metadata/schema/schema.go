@@ -20,13 +20,54 @@ package schema
 import (
 	"context"
 	"io"
+	"time"
 
 	commonv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/common/v1"
 	databasev1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/database/v1"
 )
 
+// ListOrder decides the order of the entities returned by the List* methods
+type ListOrder int
+
+const (
+	// ListOrderByName orders the entities by their groups, then by their names
+	ListOrderByName ListOrder = iota
+	// ListOrderByCreateRevision orders the entities by when they are created, and the ones created together by their names
+	ListOrderByCreateRevision
+)
+
 type ListOpt struct {
 	Group string
+	// OrderBy is ListOrderByName by default
+	OrderBy ListOrder
+	// Limit bounds the number of the entities in a page, and zero returns all of them.
+	// Only the entities ordered by name are paged.
+	Limit int
+	// Continue is the cursor returned along with the previous page, and the empty one starts from the first page.
+	// The cursor returned along with the last page is empty.
+	Continue string
+	// Selector lists only the entities matching all of its comma separated key=value pairs, e.g. "entity=service_id,tag=trace_id".
+	// A key having several values of an entity, e.g. its tags, matches if any of them equals the value.
+	// All the kinds select "group" and "name", besides:
+	//   - streams: "entity", "tag" and "tag_family"
+	//   - measures: "entity", "tag", "tag_family" and "field"
+	//   - index rules: "tag", "type" and "location", the latter two of which are the names of the enums, e.g. "TYPE_INVERTED"
+	//   - index rule bindings: "rule", "subject" and "catalog", e.g. "CATALOG_STREAM"
+	// The unknown keys fail the list with ErrInvalidListOpt.
+	Selector string
+}
+
+// DeleteOpt decides what happens to the index rule bindings whose subject is the deleted stream or measure
+type DeleteOpt struct {
+	// Cascade deletes the bindings along with their subject, otherwise the deletion fails with ErrReferredByBindings
+	Cascade bool
+}
+
+// ValidateOpt decides which of the writes a validation checks
+type ValidateOpt struct {
+	// Create checks the creation, which fails with ErrEntityAlreadyExists if a different entity exists.
+	// Otherwise, it checks the update, which fails with ErrConflict if the entity isn't at the revision it carries.
+	Create bool
 }
 
 type Registry interface {
@@ -34,6 +75,10 @@ type Registry interface {
 	ReadyNotify() <-chan struct{}
 	StopNotify() <-chan struct{}
 	StoppingNotify() <-chan struct{}
+	// Watch emits the changes of the schemas matching opt until ctx is done
+	Watch(ctx context.Context, opt WatchOpt) (<-chan Event, error)
+	// Txn commits the changes collected by fn together. None of them is committed if fn or the commit fails.
+	Txn(ctx context.Context, fn func(tx RegistryTx) error) error
 	Stream
 	IndexRule
 	IndexRuleBinding
@@ -41,39 +86,86 @@ type Registry interface {
 	Group
 }
 
+// RegistryTx collects the changes of a transaction. They are applied in order, so a later change to
+// the same entity replaces the earlier one. A change carrying a revision fails the whole transaction
+// with ErrConflict if the stored entity isn't at the revision.
+type RegistryTx interface {
+	// CreateGroup works like Group.CreateGroup, and the entities of the group created by the transaction
+	// are allowed to be written by the transaction as well
+	CreateGroup(group string) error
+	// DeleteGroup deletes all the entities of the group except the ones written after it by the transaction
+	DeleteGroup(group string) error
+	UpdateStream(stream *databasev1.Stream) error
+	DeleteStream(metadata *commonv1.Metadata) error
+	UpdateIndexRule(indexRule *databasev1.IndexRule) error
+	DeleteIndexRule(metadata *commonv1.Metadata) error
+	UpdateIndexRuleBinding(indexRuleBinding *databasev1.IndexRuleBinding) error
+	DeleteIndexRuleBinding(metadata *commonv1.Metadata) error
+	UpdateMeasure(measure *databasev1.Measure) error
+	DeleteMeasure(metadata *commonv1.Metadata) error
+}
+
 type Stream interface {
 	GetStream(ctx context.Context, metadata *commonv1.Metadata) (*databasev1.Stream, error)
-	ListStream(ctx context.Context, opt ListOpt) ([]*databasev1.Stream, error)
+	ListStream(ctx context.Context, opt ListOpt) ([]*databasev1.Stream, string, error)
+	// CreateStream fails with ErrEntityAlreadyExists if a different one exists, while it succeeds if the same one exists
+	CreateStream(ctx context.Context, stream *databasev1.Stream) error
 	UpdateStream(ctx context.Context, stream *databasev1.Stream) error
-	DeleteStream(ctx context.Context, metadata *commonv1.Metadata) (bool, error)
+	// ValidateStream runs the checks of the creation or the update of the stream without writing it
+	ValidateStream(ctx context.Context, stream *databasev1.Stream, opt ValidateOpt) error
+	DeleteStream(ctx context.Context, metadata *commonv1.Metadata, opt DeleteOpt) (bool, error)
 }
 
 type IndexRule interface {
 	GetIndexRule(ctx context.Context, metadata *commonv1.Metadata) (*databasev1.IndexRule, error)
-	ListIndexRule(ctx context.Context, opt ListOpt) ([]*databasev1.IndexRule, error)
+	ListIndexRule(ctx context.Context, opt ListOpt) ([]*databasev1.IndexRule, string, error)
+	// CreateIndexRule fails with ErrEntityAlreadyExists if a different one exists, while it succeeds if the same one exists
+	CreateIndexRule(ctx context.Context, indexRule *databasev1.IndexRule) error
 	UpdateIndexRule(ctx context.Context, indexRule *databasev1.IndexRule) error
+	// ValidateIndexRule runs the checks of the creation or the update of the index rule without writing it
+	ValidateIndexRule(ctx context.Context, indexRule *databasev1.IndexRule, opt ValidateOpt) error
 	DeleteIndexRule(ctx context.Context, metadata *commonv1.Metadata) (bool, error)
 }
 
 type IndexRuleBinding interface {
 	GetIndexRuleBinding(ctx context.Context, metadata *commonv1.Metadata) (*databasev1.IndexRuleBinding, error)
-	ListIndexRuleBinding(ctx context.Context, opt ListOpt) ([]*databasev1.IndexRuleBinding, error)
+	ListIndexRuleBinding(ctx context.Context, opt ListOpt) ([]*databasev1.IndexRuleBinding, string, error)
+	// CreateIndexRuleBinding fails with ErrEntityAlreadyExists if a different one exists, while it succeeds if the same one exists.
+	// The binding and its update fail with ErrInvalidBindingWindow if it expires before it begins or it has expired.
+	CreateIndexRuleBinding(ctx context.Context, indexRuleBinding *databasev1.IndexRuleBinding) error
 	UpdateIndexRuleBinding(ctx context.Context, indexRuleBinding *databasev1.IndexRuleBinding) error
+	// ValidateIndexRuleBinding runs the checks of the creation or the update of the binding without writing it
+	ValidateIndexRuleBinding(ctx context.Context, indexRuleBinding *databasev1.IndexRuleBinding, opt ValidateOpt) error
 	DeleteIndexRuleBinding(ctx context.Context, metadata *commonv1.Metadata) (bool, error)
+	// ActiveIndexRuleBindings returns the bindings of all the groups whose validity windows contain the instant
+	ActiveIndexRuleBindings(ctx context.Context, at time.Time) ([]*databasev1.IndexRuleBinding, error)
+	// DeleteBindingAndRules deletes the binding and the index rules referred only by it in a transaction.
+	// It returns the number of the deleted index rules.
+	DeleteBindingAndRules(ctx context.Context, metadata *commonv1.Metadata) (int, error)
 }
 
 type Measure interface {
 	GetMeasure(ctx context.Context, metadata *commonv1.Metadata) (*databasev1.Measure, error)
-	ListMeasure(ctx context.Context, opt ListOpt) ([]*databasev1.Measure, error)
+	ListMeasure(ctx context.Context, opt ListOpt) ([]*databasev1.Measure, string, error)
+	// CreateMeasure fails with ErrEntityAlreadyExists if a different one exists, while it succeeds if the same one exists
+	CreateMeasure(ctx context.Context, measure *databasev1.Measure) error
 	UpdateMeasure(ctx context.Context, measure *databasev1.Measure) error
-	DeleteMeasure(ctx context.Context, metadata *commonv1.Metadata) (bool, error)
+	// ValidateMeasure runs the checks of the creation or the update of the measure without writing it
+	ValidateMeasure(ctx context.Context, measure *databasev1.Measure, opt ValidateOpt) error
+	DeleteMeasure(ctx context.Context, metadata *commonv1.Metadata, opt DeleteOpt) (bool, error)
 }
 
 type Group interface {
 	GetGroup(ctx context.Context, group string) (*commonv1.Group, error)
+	// ListGroup returns the names of the groups in order
 	ListGroup(ctx context.Context) ([]string, error)
+	// ListGroups returns the groups ordered by their names
+	ListGroups(ctx context.Context) ([]*commonv1.Group, error)
 	// DeleteGroup delete all items belonging to the group
 	DeleteGroup(ctx context.Context, group string) (bool, error)
+	// DeleteGroupsByPrefix deletes the groups whose names start with the prefix, along with their entities,
+	// in a transaction. It returns the number of the deleted groups, and the empty prefix fails with ErrEmptyGroupPrefix.
+	DeleteGroupsByPrefix(ctx context.Context, prefix string) (int, error)
 	// CreateGroup works like `touch` in unix systems.
 	// 1. It will create the group if it does not exist.
 	// 2. It will update the updated_at timestamp to the current timestamp.
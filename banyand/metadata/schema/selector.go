@@ -0,0 +1,150 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package schema
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	databasev1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/database/v1"
+)
+
+// requirement is a key=value pair of a selector
+type requirement struct {
+	key   string
+	value string
+}
+
+// selector is the parsed ListOpt.Selector. An entity is selected if it meets all the requirements.
+type selector []requirement
+
+// selectableKeys are the keys accepted by the selectors of an entity kind, besides "group" and "name"
+var selectableKeys = map[string]map[string]struct{}{
+	StreamKeyPrefix:           {"entity": {}, "tag": {}, "tag_family": {}},
+	MeasureKeyPrefix:          {"entity": {}, "tag": {}, "tag_family": {}, "field": {}},
+	IndexRuleKeyPrefix:        {"tag": {}, "type": {}, "location": {}},
+	IndexRuleBindingKeyPrefix: {"rule": {}, "subject": {}, "catalog": {}},
+}
+
+// parseSelector parses the comma separated key=value pairs, whose keys should be selectable by the entity kind
+func parseSelector(s, entityPrefix string) (selector, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+	var result selector
+	for _, pair := range strings.Split(s, ",") {
+		i := strings.Index(pair, "=")
+		if i < 0 {
+			return nil, errors.Wrapf(ErrInvalidListOpt, "malformed selector %q", s)
+		}
+		r := requirement{key: strings.TrimSpace(pair[:i]), value: strings.TrimSpace(pair[i+1:])}
+		if r.key != "group" && r.key != "name" {
+			if _, ok := selectableKeys[entityPrefix][r.key]; !ok {
+				return nil, errors.Wrapf(ErrInvalidListOpt, "the key %q isn't selectable", r.key)
+			}
+		}
+		result = append(result, r)
+	}
+	return result, nil
+}
+
+// matches reports whether the entity meets all the requirements. A key having several values,
+// e.g. the tags of a stream, meets the requirement if any of them equals the value of it.
+func (s selector) matches(message proto.Message) bool {
+	for _, r := range s {
+		if !containsString(selectableValues(message, r.key), r.value) {
+			return false
+		}
+	}
+	return true
+}
+
+func selectableValues(message proto.Message, key string) []string {
+	metadata := message.(hasMetadata).GetMetadata()
+	switch key {
+	case "group":
+		return []string{metadata.GetGroup()}
+	case "name":
+		return []string{metadata.GetName()}
+	}
+	switch entity := message.(type) {
+	case *databasev1.Stream:
+		return familyValues(entity.GetTagFamilies(), entity.GetEntity(), key)
+	case *databasev1.Measure:
+		if key == "field" {
+			values := make([]string, 0, len(entity.GetFields()))
+			for _, f := range entity.GetFields() {
+				values = append(values, f.GetName())
+			}
+			return values
+		}
+		return familyValues(entity.GetTagFamilies(), entity.GetEntity(), key)
+	case *databasev1.IndexRule:
+		switch key {
+		case "tag":
+			return entity.GetTags()
+		case "type":
+			return []string{entity.GetType().String()}
+		case "location":
+			return []string{entity.GetLocation().String()}
+		}
+	case *databasev1.IndexRuleBinding:
+		switch key {
+		case "rule":
+			return entity.GetRules()
+		case "subject":
+			return []string{entity.GetSubject().GetName()}
+		case "catalog":
+			return []string{entity.GetSubject().GetCatalog().String()}
+		}
+	}
+	return nil
+}
+
+func familyValues(families []*databasev1.TagFamilySpec, entity *databasev1.Entity, key string) []string {
+	switch key {
+	case "entity":
+		return entity.GetTagNames()
+	case "tag_family":
+		values := make([]string, 0, len(families))
+		for _, f := range families {
+			values = append(values, f.GetName())
+		}
+		return values
+	case "tag":
+		var values []string
+		for _, f := range families {
+			for _, t := range f.GetTags() {
+				values = append(values, t.GetName())
+			}
+		}
+		return values
+	}
+	return nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
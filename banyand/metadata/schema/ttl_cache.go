@@ -0,0 +1,386 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package schema
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	commonv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/common/v1"
+	databasev1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/database/v1"
+)
+
+var (
+	_ Registry   = (*TTLCachedRegistry)(nil)
+	_ CacheStats = (*TTLCachedRegistry)(nil)
+	_ CacheStats = (*CachedRegistry)(nil)
+)
+
+// CacheStats is implemented by the caches of the registry
+type CacheStats interface {
+	// Stats returns the number of the reads served by the cache and the ones reading through the registry
+	Stats() (hits, misses uint64)
+}
+
+// TTLCachedRegistry reads through the registry, and keeps the results of the gets and lists until the ttl is over.
+// Unlike CachedRegistry, it works with any Registry: the entries are invalidated by the events of Registry.Watch,
+// so a change is visible once its event is observed, or the stale entry expires.
+// If the watch is closed, the cache is flushed and the watch is established again, since the events in between are lost.
+type TTLCachedRegistry struct {
+	Registry
+	ttl time.Duration
+	now func() time.Time
+
+	mu    sync.Mutex
+	gets  map[string]ttlEntry
+	lists map[listKey]ttlEntry
+	// generation increases on each invalidation, so a result fetched across an invalidation isn't cached
+	generation uint64
+
+	hits   uint64
+	misses uint64
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+type ttlEntry struct {
+	value    interface{}
+	expireAt time.Time
+}
+
+// NewCachedRegistry wraps inner with a read-through cache of the gets and the lists, which are kept until the ttl
+// is over or their changes are watched. A non-positive ttl keeps the entries until they are invalidated.
+// The returned registry implements CacheStats, and closing it closes inner as well.
+func NewCachedRegistry(inner Registry, ttl time.Duration) Registry {
+	c := newTTLCachedRegistry(inner, ttl)
+	ctx, cancel := context.WithCancel(context.Background())
+	c.cancel = cancel
+	// the first watch is established before any entry is cached
+	events, err := inner.Watch(ctx, WatchOpt{})
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		c.watch(ctx, events, err)
+	}()
+	return c
+}
+
+func newTTLCachedRegistry(inner Registry, ttl time.Duration) *TTLCachedRegistry {
+	return &TTLCachedRegistry{
+		Registry: inner,
+		ttl:      ttl,
+		now:      time.Now,
+		gets:     make(map[string]ttlEntry),
+		lists:    make(map[listKey]ttlEntry),
+	}
+}
+
+// watch invalidates the entries by the events until ctx is done, and establishes the watch again once it's closed
+func (c *TTLCachedRegistry) watch(ctx context.Context, events <-chan Event, err error) {
+	resync := Event{Type: EventTypeResync}
+	for {
+		if err == nil {
+			for event := range events {
+				c.invalidate(event)
+			}
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		// the changes are lost until the watch is established again
+		c.invalidate(resync)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(watchRetryInterval):
+		}
+		if events, err = c.Registry.Watch(ctx, WatchOpt{}); err == nil {
+			// the entries cached before the watch might miss the changes
+			c.invalidate(resync)
+		}
+	}
+}
+
+// Stats returns the number of the reads served by the cache and the ones reading through the registry
+func (c *TTLCachedRegistry) Stats() (hits, misses uint64) {
+	return atomic.LoadUint64(&c.hits), atomic.LoadUint64(&c.misses)
+}
+
+func (c *TTLCachedRegistry) invalidate(event Event) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.generation++
+	switch {
+	case event.Type == EventTypeResync:
+		c.gets = make(map[string]ttlEntry)
+		c.lists = make(map[listKey]ttlEntry)
+	case event.Kind == KindGroup:
+		// deleting a group deletes all of its entities
+		group := event.Metadata.GetName()
+		for key := range c.gets {
+			if strings.HasPrefix(key, GroupsKeyPrefix+group+"/") {
+				delete(c.gets, key)
+			}
+		}
+		for key := range c.lists {
+			if key.opt.Group == "" || key.opt.Group == group {
+				delete(c.lists, key)
+			}
+		}
+	default:
+		prefix := entityPrefixOf(event.Kind)
+		delete(c.gets, formatKey(prefix, event.Metadata))
+		for key := range c.lists {
+			if key.prefix == prefix && (key.opt.Group == "" || key.opt.Group == event.Metadata.GetGroup()) {
+				delete(c.lists, key)
+			}
+		}
+	}
+}
+
+func entityPrefixOf(kind Kind) string {
+	for prefix, k := range kindPrefixes {
+		if k == kind {
+			return prefix
+		}
+	}
+	return ""
+}
+
+func (c *TTLCachedRegistry) lookup(get string, list listKey) (interface{}, uint64, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var entry ttlEntry
+	var ok bool
+	if get != "" {
+		entry, ok = c.gets[get]
+	} else {
+		entry, ok = c.lists[list]
+	}
+	if ok && (c.ttl <= 0 || c.now().Before(entry.expireAt)) {
+		atomic.AddUint64(&c.hits, 1)
+		return entry.value, c.generation, true
+	}
+	atomic.AddUint64(&c.misses, 1)
+	return nil, c.generation, false
+}
+
+// store caches the value unless an invalidation happens after the generation
+func (c *TTLCachedRegistry) store(get string, list listKey, generation uint64, value interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if generation != c.generation {
+		return
+	}
+	entry := ttlEntry{value: value, expireAt: c.now().Add(c.ttl)}
+	if get != "" {
+		c.gets[get] = entry
+		return
+	}
+	c.lists[list] = entry
+}
+
+// get returns a copy of the cached entity, or reads it through fetch
+func (c *TTLCachedRegistry) get(key string, fetch func() (proto.Message, error)) (proto.Message, error) {
+	value, generation, ok := c.lookup(key, listKey{})
+	if ok {
+		return proto.Clone(value.(proto.Message)), nil
+	}
+	entity, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+	c.store(key, listKey{}, generation, proto.Clone(entity))
+	return entity, nil
+}
+
+// list returns the copies of the cached entities, or reads them through fetch
+func (c *TTLCachedRegistry) list(key listKey, fetch func() (listResult, error)) ([]proto.Message, string, error) {
+	value, generation, ok := c.lookup("", key)
+	if !ok {
+		result, err := fetch()
+		if err != nil {
+			return nil, "", err
+		}
+		c.store("", key, generation, result)
+		value = result
+	}
+	result := value.(listResult)
+	messages := make([]proto.Message, 0, len(result.messages))
+	for _, m := range result.messages {
+		messages = append(messages, proto.Clone(m))
+	}
+	return messages, result.next, nil
+}
+
+func (c *TTLCachedRegistry) GetGroup(ctx context.Context, group string) (*commonv1.Group, error) {
+	entity, err := c.get(formatGroupKey(group), func() (proto.Message, error) {
+		return c.Registry.GetGroup(ctx, group)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entity.(*commonv1.Group), nil
+}
+
+func (c *TTLCachedRegistry) ListGroup(ctx context.Context) ([]string, error) {
+	key := listKey{prefix: GroupsKeyPrefix}
+	value, generation, ok := c.lookup("", key)
+	if ok {
+		return append([]string(nil), value.([]string)...), nil
+	}
+	groups, err := c.Registry.ListGroup(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.store("", key, generation, append([]string(nil), groups...))
+	return groups, nil
+}
+
+func (c *TTLCachedRegistry) GetStream(ctx context.Context, metadata *commonv1.Metadata) (*databasev1.Stream, error) {
+	entity, err := c.get(formatSteamKey(metadata), func() (proto.Message, error) {
+		return c.Registry.GetStream(ctx, metadata)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entity.(*databasev1.Stream), nil
+}
+
+func (c *TTLCachedRegistry) ListStream(ctx context.Context, opt ListOpt) ([]*databasev1.Stream, string, error) {
+	messages, next, err := c.list(listKey{prefix: StreamKeyPrefix, opt: opt}, func() (listResult, error) {
+		entities, n, errList := c.Registry.ListStream(ctx, opt)
+		result := listResult{messages: make([]proto.Message, 0, len(entities)), next: n}
+		for _, entity := range entities {
+			result.messages = append(result.messages, entity)
+		}
+		return result, errList
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	entities := make([]*databasev1.Stream, 0, len(messages))
+	for _, message := range messages {
+		entities = append(entities, message.(*databasev1.Stream))
+	}
+	return entities, next, nil
+}
+
+func (c *TTLCachedRegistry) GetMeasure(ctx context.Context, metadata *commonv1.Metadata) (*databasev1.Measure, error) {
+	entity, err := c.get(formatMeasureKey(metadata), func() (proto.Message, error) {
+		return c.Registry.GetMeasure(ctx, metadata)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entity.(*databasev1.Measure), nil
+}
+
+func (c *TTLCachedRegistry) ListMeasure(ctx context.Context, opt ListOpt) ([]*databasev1.Measure, string, error) {
+	messages, next, err := c.list(listKey{prefix: MeasureKeyPrefix, opt: opt}, func() (listResult, error) {
+		entities, n, errList := c.Registry.ListMeasure(ctx, opt)
+		result := listResult{messages: make([]proto.Message, 0, len(entities)), next: n}
+		for _, entity := range entities {
+			result.messages = append(result.messages, entity)
+		}
+		return result, errList
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	entities := make([]*databasev1.Measure, 0, len(messages))
+	for _, message := range messages {
+		entities = append(entities, message.(*databasev1.Measure))
+	}
+	return entities, next, nil
+}
+
+func (c *TTLCachedRegistry) GetIndexRule(ctx context.Context, metadata *commonv1.Metadata) (*databasev1.IndexRule, error) {
+	entity, err := c.get(formatIndexRuleKey(metadata), func() (proto.Message, error) {
+		return c.Registry.GetIndexRule(ctx, metadata)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entity.(*databasev1.IndexRule), nil
+}
+
+func (c *TTLCachedRegistry) ListIndexRule(ctx context.Context, opt ListOpt) ([]*databasev1.IndexRule, string, error) {
+	messages, next, err := c.list(listKey{prefix: IndexRuleKeyPrefix, opt: opt}, func() (listResult, error) {
+		entities, n, errList := c.Registry.ListIndexRule(ctx, opt)
+		result := listResult{messages: make([]proto.Message, 0, len(entities)), next: n}
+		for _, entity := range entities {
+			result.messages = append(result.messages, entity)
+		}
+		return result, errList
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	entities := make([]*databasev1.IndexRule, 0, len(messages))
+	for _, message := range messages {
+		entities = append(entities, message.(*databasev1.IndexRule))
+	}
+	return entities, next, nil
+}
+
+func (c *TTLCachedRegistry) GetIndexRuleBinding(ctx context.Context,
+	metadata *commonv1.Metadata) (*databasev1.IndexRuleBinding, error) {
+	entity, err := c.get(formatIndexRuleBindingKey(metadata), func() (proto.Message, error) {
+		return c.Registry.GetIndexRuleBinding(ctx, metadata)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entity.(*databasev1.IndexRuleBinding), nil
+}
+
+func (c *TTLCachedRegistry) ListIndexRuleBinding(ctx context.Context,
+	opt ListOpt) ([]*databasev1.IndexRuleBinding, string, error) {
+	messages, next, err := c.list(listKey{prefix: IndexRuleBindingKeyPrefix, opt: opt}, func() (listResult, error) {
+		entities, n, errList := c.Registry.ListIndexRuleBinding(ctx, opt)
+		result := listResult{messages: make([]proto.Message, 0, len(entities)), next: n}
+		for _, entity := range entities {
+			result.messages = append(result.messages, entity)
+		}
+		return result, errList
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	entities := make([]*databasev1.IndexRuleBinding, 0, len(messages))
+	for _, message := range messages {
+		entities = append(entities, message.(*databasev1.IndexRuleBinding))
+	}
+	return entities, next, nil
+}
+
+// Close stops watching the invalidations before closing the registry
+func (c *TTLCachedRegistry) Close() error {
+	if c.cancel != nil {
+		c.cancel()
+		c.wg.Wait()
+	}
+	return c.Registry.Close()
+}
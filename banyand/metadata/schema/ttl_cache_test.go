@@ -0,0 +1,227 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package schema
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	commonv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/common/v1"
+	databasev1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/database/v1"
+)
+
+func Test_TTLCachedRegistry_Invalidate(t *testing.T) {
+	req := require.New(t)
+	registry, err := NewEtcdSchemaRegistry(useUnixDomain(), useRandomTempDir())
+	req.NoError(err)
+	req.NoError(preloadSchema(registry))
+	c := NewCachedRegistry(registry, time.Hour)
+	defer c.Close()
+	ctx := context.TODO()
+
+	meta := &commonv1.Metadata{Name: "sw", Group: "default"}
+	s, err := c.GetStream(ctx, meta)
+	req.NoError(err)
+	streams, _, err := c.ListStream(ctx, ListOpt{Group: "default"})
+	req.NoError(err)
+	req.Len(streams, 1)
+	// the cached entities are copies, which callers are free to change
+	s.GetOpts().ShardNum = 7
+	cached, err := c.GetStream(ctx, meta)
+	req.NoError(err)
+	req.NotEqual(uint32(7), cached.GetOpts().GetShardNum())
+	_, _, err = c.ListStream(ctx, ListOpt{Group: "default"})
+	req.NoError(err)
+	stats := c.(CacheStats)
+	hits, misses := stats.Stats()
+	req.Equal(uint64(2), hits)
+	req.Equal(uint64(2), misses)
+
+	// the watched changes invalidate the entries long before the ttl is over
+	req.NoError(registry.UpdateStream(ctx, s))
+	req.Eventually(func() bool {
+		updated, errGet := c.GetStream(ctx, meta)
+		return errGet == nil && updated.GetOpts().GetShardNum() == 7
+	}, 5*time.Second, 10*time.Millisecond)
+	another := proto.Clone(s).(*databasev1.Stream)
+	another.Metadata = &commonv1.Metadata{Name: "another", Group: "default"}
+	req.NoError(registry.CreateStream(ctx, another))
+	req.Eventually(func() bool {
+		listed, _, errList := c.ListStream(ctx, ListOpt{Group: "default"})
+		return errList == nil && len(listed) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	// the index rules are kept
+	_, misses = stats.Stats()
+	_, err = c.GetIndexRule(ctx, &commonv1.Metadata{Name: "db.instance", Group: "default"})
+	req.NoError(err)
+	_, err = c.GetIndexRule(ctx, &commonv1.Metadata{Name: "db.instance", Group: "default"})
+	req.NoError(err)
+	_, moreMisses := stats.Stats()
+	req.Equal(misses+1, moreMisses)
+
+	deleted, err := registry.DeleteStream(ctx, meta, DeleteOpt{Cascade: true})
+	req.NoError(err)
+	req.True(deleted)
+	req.Eventually(func() bool {
+		_, errGet := c.GetStream(ctx, meta)
+		return errGet != nil
+	}, 5*time.Second, 10*time.Millisecond)
+	_, err = c.GetStream(ctx, meta)
+	req.ErrorIs(err, ErrEntityNotFound)
+}
+
+func Test_TTLCachedRegistry_Expire(t *testing.T) {
+	req := require.New(t)
+	registry, err := NewEtcdSchemaRegistry(useUnixDomain(), useRandomTempDir())
+	req.NoError(err)
+	req.NoError(preloadSchema(registry))
+	defer registry.Close()
+	// without the watch, only the ttl expires the entries
+	c := newTTLCachedRegistry(registry, time.Minute)
+	now := time.Now()
+	c.now = func() time.Time {
+		return now
+	}
+	ctx := context.TODO()
+
+	meta := &commonv1.Metadata{Name: "sw", Group: "default"}
+	s, err := c.GetStream(ctx, meta)
+	req.NoError(err)
+	s.GetOpts().ShardNum = 7
+	req.NoError(registry.UpdateStream(ctx, s))
+	cached, err := c.GetStream(ctx, meta)
+	req.NoError(err)
+	req.NotEqual(uint32(7), cached.GetOpts().GetShardNum())
+
+	now = now.Add(time.Minute)
+	cached, err = c.GetStream(ctx, meta)
+	req.NoError(err)
+	req.Equal(uint32(7), cached.GetOpts().GetShardNum())
+	hits, misses := c.Stats()
+	req.Equal(uint64(1), hits)
+	req.Equal(uint64(2), misses)
+}
+
+func Test_TTLCachedRegistry_Concurrent(t *testing.T) {
+	req := require.New(t)
+	registry, err := NewEtcdSchemaRegistry(useUnixDomain(), useRandomTempDir())
+	req.NoError(err)
+	req.NoError(preloadSchema(registry))
+	c := NewCachedRegistry(registry, time.Hour)
+	defer c.Close()
+	ctx := context.TODO()
+
+	meta := &commonv1.Metadata{Name: "sw", Group: "default"}
+	s, err := c.GetStream(ctx, meta)
+	req.NoError(err)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				if _, errGet := c.GetStream(ctx, meta); errGet != nil {
+					t.Error(errGet)
+					return
+				}
+				if _, _, errList := c.ListIndexRule(ctx, ListOpt{Group: "default"}); errList != nil {
+					t.Error(errList)
+					return
+				}
+			}
+		}()
+	}
+	for i := uint32(1); i <= 5; i++ {
+		s.Metadata.ModRevision = 0
+		s.GetOpts().ShardNum = i
+		req.NoError(registry.UpdateStream(ctx, s))
+	}
+	wg.Wait()
+	req.Eventually(func() bool {
+		updated, errGet := c.GetStream(ctx, meta)
+		return errGet == nil && updated.GetOpts().GetShardNum() == 5
+	}, 5*time.Second, 10*time.Millisecond)
+	hits, _ := c.(CacheStats).Stats()
+	req.NotZero(hits)
+}
+
+// brokenWatchRegistry misses all the events in its first watch, which is closed once broken is
+type brokenWatchRegistry struct {
+	Registry
+	broken  chan struct{}
+	watches int32
+}
+
+func (r *brokenWatchRegistry) Watch(ctx context.Context, opt WatchOpt) (<-chan Event, error) {
+	if atomic.AddInt32(&r.watches, 1) > 1 {
+		return r.Registry.Watch(ctx, opt)
+	}
+	ch := make(chan Event)
+	go func() {
+		defer close(ch)
+		select {
+		case <-r.broken:
+		case <-ctx.Done():
+		}
+	}()
+	return ch, nil
+}
+
+func Test_TTLCachedRegistry_Rewatch(t *testing.T) {
+	req := require.New(t)
+	registry, err := NewEtcdSchemaRegistry(useUnixDomain(), useRandomTempDir())
+	req.NoError(err)
+	req.NoError(preloadSchema(registry))
+	inner := &brokenWatchRegistry{Registry: registry, broken: make(chan struct{})}
+	c := NewCachedRegistry(inner, time.Hour)
+	defer c.Close()
+	ctx := context.TODO()
+
+	meta := &commonv1.Metadata{Name: "sw", Group: "default"}
+	s, err := c.GetStream(ctx, meta)
+	req.NoError(err)
+	s.GetOpts().ShardNum = 7
+	req.NoError(registry.UpdateStream(ctx, s))
+	cached, err := c.GetStream(ctx, meta)
+	req.NoError(err)
+	req.NotEqual(uint32(7), cached.GetOpts().GetShardNum())
+
+	// the closed watch flushes the cache, and it's established again
+	close(inner.broken)
+	req.Eventually(func() bool {
+		updated, errGet := c.GetStream(ctx, meta)
+		return errGet == nil && updated.GetOpts().GetShardNum() == 7
+	}, 5*time.Second, 10*time.Millisecond)
+	req.Eventually(func() bool {
+		return atomic.LoadInt32(&inner.watches) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	s, err = c.GetStream(ctx, meta)
+	req.NoError(err)
+	s.GetOpts().ShardNum = 8
+	req.NoError(registry.UpdateStream(ctx, s))
+	req.Eventually(func() bool {
+		updated, errGet := c.GetStream(ctx, meta)
+		return errGet == nil && updated.GetOpts().GetShardNum() == 8
+	}, 5*time.Second, 10*time.Millisecond)
+}
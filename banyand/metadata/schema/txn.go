@@ -0,0 +1,305 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package schema
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	commonv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/common/v1"
+	databasev1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/database/v1"
+)
+
+var _ RegistryTx = (*etcdTx)(nil)
+
+// txnChange is a pending put or deletion of an entity
+type txnChange struct {
+	// key is in the legacy format
+	key   string
+	group string
+	// value is nil for a deletion
+	value []byte
+	rev   int64
+	// message receives the new revision once the transaction is committed
+	message proto.Message
+}
+
+// etcdTx collects the changes, which are all committed in a single etcd transaction
+type etcdTx struct {
+	compress bool
+	changes  map[string]*txnChange
+	keys     []string
+	created  map[string]struct{}
+	deleted  map[string]struct{}
+}
+
+func newEtcdTx(compress bool) *etcdTx {
+	return &etcdTx{
+		compress: compress,
+		changes:  make(map[string]*txnChange),
+		created:  make(map[string]struct{}),
+		deleted:  make(map[string]struct{}),
+	}
+}
+
+func (tx *etcdTx) record(c *txnChange) {
+	if _, ok := tx.changes[c.key]; !ok {
+		tx.keys = append(tx.keys, c.key)
+	}
+	tx.changes[c.key] = c
+}
+
+func (tx *etcdTx) put(key string, metadata *commonv1.Metadata, message proto.Message) error {
+	val, err := encodeValue(withoutModRevision(message), tx.compress)
+	if err != nil {
+		return err
+	}
+	tx.record(&txnChange{
+		key:     key,
+		group:   metadata.GetGroup(),
+		value:   val,
+		rev:     metadata.GetModRevision(),
+		message: message,
+	})
+	return nil
+}
+
+func (tx *etcdTx) delete(key string, metadata *commonv1.Metadata) error {
+	tx.record(&txnChange{
+		key:   key,
+		group: metadata.GetGroup(),
+	})
+	return nil
+}
+
+func (tx *etcdTx) CreateGroup(group string) error {
+	tx.created[group] = struct{}{}
+	return nil
+}
+
+func (tx *etcdTx) DeleteGroup(group string) error {
+	for key, c := range tx.changes {
+		if c.group == group {
+			delete(tx.changes, key)
+		}
+	}
+	delete(tx.created, group)
+	tx.deleted[group] = struct{}{}
+	return nil
+}
+
+func (tx *etcdTx) UpdateStream(stream *databasev1.Stream) error {
+	if err := validateEntity(stream.GetMetadata(), stream.GetTagFamilies(), stream.GetEntity()); err != nil {
+		return err
+	}
+	return tx.put(formatSteamKey(stream.GetMetadata()), stream.GetMetadata(), stream)
+}
+
+func (tx *etcdTx) DeleteStream(metadata *commonv1.Metadata) error {
+	return tx.delete(formatSteamKey(metadata), metadata)
+}
+
+func (tx *etcdTx) UpdateIndexRule(indexRule *databasev1.IndexRule) error {
+	return tx.put(formatIndexRuleKey(indexRule.GetMetadata()), indexRule.GetMetadata(), indexRule)
+}
+
+func (tx *etcdTx) DeleteIndexRule(metadata *commonv1.Metadata) error {
+	return tx.delete(formatIndexRuleKey(metadata), metadata)
+}
+
+func (tx *etcdTx) UpdateIndexRuleBinding(indexRuleBinding *databasev1.IndexRuleBinding) error {
+	if err := validateBindingWindow(indexRuleBinding, time.Now()); err != nil {
+		return err
+	}
+	return tx.put(formatIndexRuleBindingKey(indexRuleBinding.GetMetadata()), indexRuleBinding.GetMetadata(), indexRuleBinding)
+}
+
+func (tx *etcdTx) DeleteIndexRuleBinding(metadata *commonv1.Metadata) error {
+	return tx.delete(formatIndexRuleBindingKey(metadata), metadata)
+}
+
+func (tx *etcdTx) UpdateMeasure(measure *databasev1.Measure) error {
+	if err := validateEntity(measure.GetMetadata(), measure.GetTagFamilies(), measure.GetEntity()); err != nil {
+		return err
+	}
+	return tx.put(formatMeasureKey(measure.GetMetadata()), measure.GetMetadata(), measure)
+}
+
+func (tx *etcdTx) DeleteMeasure(metadata *commonv1.Metadata) error {
+	return tx.delete(formatMeasureKey(metadata), metadata)
+}
+
+// Txn commits the changes in a single etcd transaction, which is bounded by the max operations of it.
+// The groups of the changed entities should exist or be created by the transaction, and they are touched as well.
+func (e *etcdSchemaRegistry) Txn(ctx context.Context, fn func(tx RegistryTx) error) error {
+	tx := newEtcdTx(e.compress)
+	if err := fn(tx); err != nil {
+		return err
+	}
+	if len(tx.keys) == 0 && len(tx.created) == 0 && len(tx.deleted) == 0 {
+		return nil
+	}
+	legacy, err := e.readsLegacy(ctx)
+	if err != nil {
+		return err
+	}
+	var cmps []clientv3.Cmp
+	var ops []clientv3.Op
+	written := make(map[string]struct{})
+	addOp := func(key string, op clientv3.Op) {
+		written[key] = struct{}{}
+		ops = append(ops, op)
+	}
+	touched := make(map[string]struct{}, len(tx.created))
+	for group := range tx.created {
+		touched[group] = struct{}{}
+	}
+	var changes []*txnChange
+	for _, key := range tx.keys {
+		c, ok := tx.changes[key]
+		// the key dropped by DeleteGroup and recorded again appears twice
+		if _, done := written[currentKey(key)]; !ok || done {
+			continue
+		}
+		_, created := tx.created[c.group]
+		if _, deleted := tx.deleted[c.group]; deleted && !created && c.value == nil {
+			// the deletion of the group takes the entity along
+			continue
+		}
+		changes = append(changes, c)
+		touched[c.group] = struct{}{}
+		if c.value == nil {
+			addOp(currentKey(key), clientv3.OpDelete(currentKey(key)))
+			if legacy {
+				addOp(key, clientv3.OpDelete(key))
+			}
+			continue
+		}
+		addOp(currentKey(key), clientv3.OpPut(currentKey(key), string(c.value)))
+		if c.rev != 0 {
+			cmps = append(cmps, clientv3.Compare(clientv3.ModRevision(currentKey(key)), "=", c.rev))
+		}
+	}
+	for _, c := range changes {
+		binding, ok := c.message.(*databasev1.IndexRuleBinding)
+		if !ok {
+			continue
+		}
+		// the rules might be written or deleted by the transaction, and the deleted group takes its rules along
+		_, groupDeleted := tx.deleted[binding.GetMetadata().GetGroup()]
+		pending := func(key string) (bool, bool) {
+			if r, ok := tx.changes[key]; ok {
+				return r.value != nil, true
+			}
+			return false, groupDeleted
+		}
+		ruleCmps, errRules := e.validateBindingRules(ctx, binding, pending)
+		if errRules != nil {
+			return errRules
+		}
+		cmps = append(cmps, ruleCmps...)
+	}
+	now := timestamppb.Now()
+	for _, group := range sortedGroups(touched) {
+		g := &commonv1.Group{Name: group}
+		if _, ok := tx.created[group]; !ok {
+			if _, ok = tx.deleted[group]; ok {
+				return errors.Wrap(ErrEntityNotFound, group)
+			}
+			if g, err = e.GetGroup(ctx, group); err != nil {
+				return errors.Wrap(err, group)
+			}
+			if !legacy {
+				// the group isn't deleted before the commit
+				cmps = append(cmps, clientv3.Compare(clientv3.CreateRevision(currentKey(formatGroupKey(group))), ">", 0))
+			}
+		}
+		g.UpdatedAt = now
+		val, errEncode := encodeValue(g, e.compress)
+		if errEncode != nil {
+			return errEncode
+		}
+		key := currentKey(formatGroupKey(group))
+		addOp(key, clientv3.OpPut(key, string(val)))
+	}
+	for _, group := range sortedGroups(tx.deleted) {
+		groupCmps, groupOps, errDelete := e.deleteGroupOps(ctx, group, legacy, written)
+		if errDelete != nil {
+			return errDelete
+		}
+		cmps = append(cmps, groupCmps...)
+		ops = append(ops, groupOps...)
+	}
+	resp, err := e.kv.Txn(ctx).If(cmps...).Then(ops...).Commit()
+	if err != nil {
+		return err
+	}
+	if !resp.Succeeded {
+		return errors.Wrap(ErrConflict, "the entities of the transaction are modified concurrently")
+	}
+	for _, c := range changes {
+		if c.value != nil {
+			setModRevision(c.message, resp.Header.Revision)
+		}
+	}
+	return nil
+}
+
+// deleteGroupOps deletes the keys of the group except the written ones. The keys written
+// after they are listed fail the transaction, so none of them is left behind.
+func (e *etcdSchemaRegistry) deleteGroupOps(ctx context.Context, group string, legacy bool,
+	written map[string]struct{}) ([]clientv3.Cmp, []clientv3.Op, error) {
+	if _, err := e.GetGroup(ctx, group); err != nil {
+		return nil, nil, errors.Wrap(err, group)
+	}
+	prefix := GroupsKeyPrefix + group + "/"
+	prefixes := []string{currentKey(prefix)}
+	if legacy {
+		prefixes = append(prefixes, prefix)
+	}
+	var cmps []clientv3.Cmp
+	var ops []clientv3.Op
+	for _, p := range prefixes {
+		resp, err := e.kv.Get(ctx, p, clientv3.WithPrefix(), clientv3.WithKeysOnly())
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, kv := range resp.Kvs {
+			if _, ok := written[string(kv.Key)]; !ok {
+				ops = append(ops, clientv3.OpDelete(string(kv.Key)))
+			}
+		}
+		cmps = append(cmps, clientv3.Compare(clientv3.ModRevision(p), "<", resp.Header.Revision+1).WithPrefix())
+	}
+	return cmps, ops, nil
+}
+
+func sortedGroups(groups map[string]struct{}) []string {
+	result := make([]string, 0, len(groups))
+	for g := range groups {
+		result = append(result, g)
+	}
+	sort.Strings(result)
+	return result
+}
@@ -0,0 +1,198 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package schema
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	commonv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/common/v1"
+	databasev1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/database/v1"
+)
+
+func Test_Etcd_Txn(t *testing.T) {
+	tester := require.New(t)
+	registry, err := NewEtcdSchemaRegistry(useUnixDomain(), useRandomTempDir())
+	tester.NoError(err)
+	defer registry.Close()
+	tester.NoError(preloadSchema(registry))
+	ctx := context.TODO()
+
+	s, err := registry.GetStream(ctx, &commonv1.Metadata{Group: "default", Name: "sw"})
+	tester.NoError(err)
+	rule, err := registry.GetIndexRule(ctx, &commonv1.Metadata{Group: "default", Name: "db.instance"})
+	tester.NoError(err)
+	binding, err := registry.GetIndexRuleBinding(ctx, &commonv1.Metadata{Group: "default", Name: "sw-index-rule-binding"})
+	tester.NoError(err)
+	provision := func(group string) func(tx RegistryTx) error {
+		return func(tx RegistryTx) error {
+			if errTx := tx.CreateGroup(group); errTx != nil {
+				return errTx
+			}
+			stream := proto.Clone(s).(*databasev1.Stream)
+			stream.Metadata = &commonv1.Metadata{Group: group, Name: "sw"}
+			if errTx := tx.UpdateStream(stream); errTx != nil {
+				return errTx
+			}
+			indexRule := proto.Clone(rule).(*databasev1.IndexRule)
+			indexRule.Metadata = &commonv1.Metadata{Group: group, Name: "db.instance"}
+			if errTx := tx.UpdateIndexRule(indexRule); errTx != nil {
+				return errTx
+			}
+			indexRuleBinding := proto.Clone(binding).(*databasev1.IndexRuleBinding)
+			indexRuleBinding.Metadata = &commonv1.Metadata{Group: group, Name: "sw-index-rule-binding"}
+			indexRuleBinding.Subject.Name = "sw"
+			indexRuleBinding.Rules = []string{"db.instance"}
+			return tx.UpdateIndexRuleBinding(indexRuleBinding)
+		}
+	}
+	exists := func(group string) bool {
+		_, errGet := registry.GetGroup(ctx, group)
+		if errors.Is(errGet, ErrEntityNotFound) {
+			return false
+		}
+		tester.NoError(errGet)
+		return true
+	}
+
+	// a whole group is provisioned at once
+	tester.NoError(registry.Txn(ctx, provision("provisioned")))
+	tester.True(exists("provisioned"))
+	streams, _, err := registry.ListStream(ctx, ListOpt{Group: "provisioned"})
+	tester.NoError(err)
+	tester.Len(streams, 1)
+	tester.NotZero(streams[0].GetMetadata().GetModRevision())
+	rules, _, err := registry.ListIndexRule(ctx, ListOpt{Group: "provisioned"})
+	tester.NoError(err)
+	tester.Len(rules, 1)
+	bindings, _, err := registry.ListIndexRuleBinding(ctx, ListOpt{Group: "provisioned"})
+	tester.NoError(err)
+	tester.Len(bindings, 1)
+
+	// none of the changes is committed if the function fails
+	failure := errors.New("failure")
+	err = registry.Txn(ctx, func(tx RegistryTx) error {
+		tester.NoError(provision("failed")(tx))
+		return failure
+	})
+	tester.ErrorIs(err, failure)
+	tester.False(exists("failed"))
+
+	// nor if the group of an entity is absent
+	err = registry.Txn(ctx, func(tx RegistryTx) error {
+		tester.NoError(provision("absent")(tx))
+		return tx.DeleteMeasure(&commonv1.Metadata{Group: "nonexistent", Name: "service_cpm"})
+	})
+	tester.ErrorIs(err, ErrEntityNotFound)
+	tester.False(exists("absent"))
+
+	// nor if the entity refers to an unknown tag
+	err = registry.Txn(ctx, func(tx RegistryTx) error {
+		tester.NoError(provision("invalid")(tx))
+		return tx.UpdateMeasure(&databasev1.Measure{
+			Metadata: &commonv1.Metadata{Group: "invalid", Name: "service_cpm"},
+			Entity:   &databasev1.Entity{TagNames: []string{"entity_id"}},
+		})
+	})
+	tester.ErrorIs(err, ErrUnknownEntityTag)
+	tester.False(exists("invalid"))
+
+	// nor if a revision is stale
+	stale := proto.Clone(streams[0]).(*databasev1.Stream)
+	stale.GetOpts().ShardNum++
+	tester.NoError(registry.UpdateStream(ctx, streams[0]))
+	err = registry.Txn(ctx, func(tx RegistryTx) error {
+		tester.NoError(provision("conflicted")(tx))
+		return tx.UpdateStream(stale)
+	})
+	tester.ErrorIs(err, ErrConflict)
+	tester.False(exists("conflicted"))
+	got, err := registry.GetStream(ctx, stale.GetMetadata())
+	tester.NoError(err)
+	tester.Equal(streams[0].GetOpts().GetShardNum(), got.GetOpts().GetShardNum())
+
+	// the changes across the groups are committed together, and the revisions are updated
+	tester.NoError(registry.Txn(ctx, func(tx RegistryTx) error {
+		if errTx := tx.DeleteIndexRule(rules[0].GetMetadata()); errTx != nil {
+			return errTx
+		}
+		got.GetOpts().ShardNum++
+		return tx.UpdateStream(got)
+	}))
+	_, err = registry.GetIndexRule(ctx, rules[0].GetMetadata())
+	tester.ErrorIs(err, ErrEntityNotFound)
+	updated, err := registry.GetStream(ctx, got.GetMetadata())
+	tester.NoError(err)
+	tester.Equal(got.GetOpts().GetShardNum(), updated.GetOpts().GetShardNum())
+	tester.Equal(got.GetMetadata().GetModRevision(), updated.GetMetadata().GetModRevision())
+}
+
+func Test_Etcd_TxnReprovisionGroup(t *testing.T) {
+	tester := require.New(t)
+	registry, err := NewEtcdSchemaRegistry(useUnixDomain(), useRandomTempDir())
+	tester.NoError(err)
+	defer registry.Close()
+	tester.NoError(preloadSchema(registry))
+	ctx := context.TODO()
+
+	s, err := registry.GetStream(ctx, &commonv1.Metadata{Group: "default", Name: "sw"})
+	tester.NoError(err)
+	// the group is replaced by the definition only having the stream
+	tester.NoError(registry.Txn(ctx, func(tx RegistryTx) error {
+		if errTx := tx.UpdateStream(s); errTx != nil {
+			return errTx
+		}
+		if errTx := tx.DeleteGroup("default"); errTx != nil {
+			return errTx
+		}
+		if errTx := tx.CreateGroup("default"); errTx != nil {
+			return errTx
+		}
+		s.GetOpts().ShardNum = 7
+		return tx.UpdateStream(s)
+	}))
+	g, err := registry.GetGroup(ctx, "default")
+	tester.NoError(err)
+	tester.NotNil(g.GetUpdatedAt())
+	streams, _, err := registry.ListStream(ctx, ListOpt{Group: "default"})
+	tester.NoError(err)
+	tester.Len(streams, 1)
+	tester.Equal(uint32(7), streams[0].GetOpts().GetShardNum())
+	rules, _, err := registry.ListIndexRule(ctx, ListOpt{Group: "default"})
+	tester.NoError(err)
+	tester.Empty(rules)
+	bindings, _, err := registry.ListIndexRuleBinding(ctx, ListOpt{Group: "default"})
+	tester.NoError(err)
+	tester.Empty(bindings)
+
+	// the group deleted without being created again takes its entities along
+	tester.NoError(registry.Txn(ctx, func(tx RegistryTx) error {
+		if errTx := tx.DeleteGroup("default"); errTx != nil {
+			return errTx
+		}
+		return tx.DeleteStream(s.GetMetadata())
+	}))
+	_, err = registry.GetGroup(ctx, "default")
+	tester.ErrorIs(err, ErrEntityNotFound)
+	_, err = registry.GetStream(ctx, s.GetMetadata())
+	tester.ErrorIs(err, ErrEntityNotFound)
+}
@@ -0,0 +1,84 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package schema
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	commonv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/common/v1"
+	databasev1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/database/v1"
+)
+
+func (e *etcdSchemaRegistry) ValidateStream(ctx context.Context, stream *databasev1.Stream, opt ValidateOpt) error {
+	if err := validateEntity(stream.GetMetadata(), stream.GetTagFamilies(), stream.GetEntity()); err != nil {
+		return err
+	}
+	return e.validateWrite(ctx, stream.GetMetadata(), formatSteamKey(stream.GetMetadata()), stream, &databasev1.Stream{}, opt)
+}
+
+func (e *etcdSchemaRegistry) ValidateMeasure(ctx context.Context, measure *databasev1.Measure, opt ValidateOpt) error {
+	if err := validateEntity(measure.GetMetadata(), measure.GetTagFamilies(), measure.GetEntity()); err != nil {
+		return err
+	}
+	return e.validateWrite(ctx, measure.GetMetadata(), formatMeasureKey(measure.GetMetadata()), measure, &databasev1.Measure{}, opt)
+}
+
+func (e *etcdSchemaRegistry) ValidateIndexRule(ctx context.Context, indexRule *databasev1.IndexRule, opt ValidateOpt) error {
+	return e.validateWrite(ctx, indexRule.GetMetadata(), formatIndexRuleKey(indexRule.GetMetadata()),
+		indexRule, &databasev1.IndexRule{}, opt)
+}
+
+func (e *etcdSchemaRegistry) ValidateIndexRuleBinding(ctx context.Context, indexRuleBinding *databasev1.IndexRuleBinding,
+	opt ValidateOpt) error {
+	if err := validateBindingWindow(indexRuleBinding, time.Now()); err != nil {
+		return err
+	}
+	if _, err := e.validateBindingRules(ctx, indexRuleBinding, nil); err != nil {
+		return err
+	}
+	return e.validateWrite(ctx, indexRuleBinding.GetMetadata(), formatIndexRuleBindingKey(indexRuleBinding.GetMetadata()),
+		indexRuleBinding, &databasev1.IndexRuleBinding{}, opt)
+}
+
+// validateWrite checks the group of the entity exists, and the entity stored at the key
+// lets the creation or the update succeed. The write might still fail if the entity is changed after the validation.
+func (e *etcdSchemaRegistry) validateWrite(ctx context.Context, metadata *commonv1.Metadata, key string,
+	message, existing proto.Message, opt ValidateOpt) error {
+	if _, err := e.GetGroup(ctx, metadata.GetGroup()); err != nil {
+		return errors.Wrap(err, metadata.GetGroup())
+	}
+	resp, err := e.getKey(ctx, key)
+	if err != nil {
+		return err
+	}
+	if opt.Create {
+		if resp.Count == 0 {
+			return nil
+		}
+		return checkExisting(key, withoutModRevision(message), resp.Kvs[0].Value, existing)
+	}
+	rev := metadata.GetModRevision()
+	if rev != 0 && (resp.Count == 0 || resp.Kvs[0].ModRevision != rev) {
+		return errors.Wrapf(ErrConflict, "%s at revision %d", key, rev)
+	}
+	return nil
+}
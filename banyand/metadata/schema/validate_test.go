@@ -0,0 +1,79 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package schema
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	commonv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/common/v1"
+	databasev1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/database/v1"
+)
+
+func Test_Etcd_Validate(t *testing.T) {
+	tester := require.New(t)
+	registry, err := NewEtcdSchemaRegistry(useUnixDomain(), useRandomTempDir())
+	tester.NoError(err)
+	defer registry.Close()
+	tester.NoError(preloadSchema(registry))
+	ctx := context.TODO()
+	create := ValidateOpt{Create: true}
+
+	s, err := registry.GetStream(ctx, &commonv1.Metadata{Group: "default", Name: "sw"})
+	tester.NoError(err)
+	// the same stream is created again, while a different one isn't
+	tester.NoError(registry.ValidateStream(ctx, s, create))
+	changed := proto.Clone(s).(*databasev1.Stream)
+	changed.GetOpts().ShardNum++
+	tester.ErrorIs(registry.ValidateStream(ctx, changed, create), ErrEntityAlreadyExists)
+	tester.NoError(registry.ValidateStream(ctx, changed, ValidateOpt{}))
+
+	// the stale revision fails the update
+	changed.GetMetadata().ModRevision--
+	tester.ErrorIs(registry.ValidateStream(ctx, changed, ValidateOpt{}), ErrConflict)
+
+	// the new stream is validated without being written
+	created := proto.Clone(s).(*databasev1.Stream)
+	created.Metadata = &commonv1.Metadata{Group: "default", Name: "validated"}
+	tester.NoError(registry.ValidateStream(ctx, created, create))
+	_, err = registry.GetStream(ctx, created.GetMetadata())
+	tester.ErrorIs(err, ErrEntityNotFound)
+	created.GetMetadata().ModRevision = 1
+	tester.ErrorIs(registry.ValidateStream(ctx, created, ValidateOpt{}), ErrConflict, "the absent stream isn't at any revision")
+
+	// so are the malformed entities and the ones of the absent groups
+	created.GetMetadata().ModRevision = 0
+	created.GetEntity().TagNames = append(created.GetEntity().GetTagNames(), "unknown")
+	tester.ErrorIs(registry.ValidateStream(ctx, created, create), ErrUnknownEntityTag)
+	tester.ErrorIs(registry.ValidateMeasure(ctx, &databasev1.Measure{
+		Metadata: &commonv1.Metadata{Group: "nonexistent", Name: "service_cpm"},
+	}, create), ErrEntityNotFound)
+	binding, err := registry.GetIndexRuleBinding(ctx, &commonv1.Metadata{Group: "default", Name: "sw-index-rule-binding"})
+	tester.NoError(err)
+	binding.ExpireAt = timestamppb.New(time.Now().Add(-time.Hour))
+	tester.ErrorIs(registry.ValidateIndexRuleBinding(ctx, binding, ValidateOpt{}), ErrInvalidBindingWindow)
+	rule, err := registry.GetIndexRule(ctx, &commonv1.Metadata{Group: "default", Name: "db.instance"})
+	tester.NoError(err)
+	tester.NoError(registry.ValidateIndexRule(ctx, rule, create))
+	tester.NoError(registry.ValidateIndexRule(ctx, rule, ValidateOpt{}))
+}
@@ -0,0 +1,69 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package schema
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+)
+
+// compressedMarker prefixes a compressed value. A plain value never starts with it,
+// because a protobuf field number can't be zero, so the readers detect the format of every value.
+const compressedMarker byte = 0x00
+
+// encodeValue marshals the message, and compresses it if compress is true and the compressed one is smaller
+func encodeValue(message proto.Message, compress bool) ([]byte, error) {
+	val, err := proto.Marshal(message)
+	if err != nil || !compress {
+		return val, err
+	}
+	var buf bytes.Buffer
+	buf.WriteByte(compressedMarker)
+	w := gzip.NewWriter(&buf)
+	if _, err = w.Write(val); err != nil {
+		return nil, err
+	}
+	if err = w.Close(); err != nil {
+		return nil, err
+	}
+	if buf.Len() >= len(val) {
+		return val, nil
+	}
+	return buf.Bytes(), nil
+}
+
+// decodeValue unmarshals a value in either the plain or the compressed format
+func decodeValue(data []byte, message proto.Message) error {
+	if len(data) == 0 || data[0] != compressedMarker {
+		return proto.Unmarshal(data, message)
+	}
+	r, err := gzip.NewReader(bytes.NewReader(data[1:]))
+	if err != nil {
+		return errors.WithMessage(ErrCorruptValue, err.Error())
+	}
+	defer r.Close()
+	val, err := ioutil.ReadAll(r)
+	if err != nil {
+		return errors.WithMessage(ErrCorruptValue, err.Error())
+	}
+	return proto.Unmarshal(val, message)
+}
@@ -0,0 +1,198 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package schema
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	clientv3 "go.etcd.io/etcd/client/v3"
+
+	commonv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/common/v1"
+)
+
+// watchRetryInterval is the backoff of re-establishing a broken watch
+var watchRetryInterval = time.Second
+
+var kindPrefixes = map[string]Kind{
+	StreamKeyPrefix:           KindStream,
+	MeasureKeyPrefix:          KindMeasure,
+	IndexRuleKeyPrefix:        KindIndexRule,
+	IndexRuleBindingKeyPrefix: KindIndexRuleBinding,
+}
+
+type EventType int
+
+const (
+	EventTypePut EventType = iota + 1
+	EventTypeDelete
+	// EventTypeResync means the events since the last one are compacted and lost.
+	// The watcher should reload all the schemas it cares about.
+	EventTypeResync
+)
+
+// Event is a change of a schema. The metadata of a group only has the name of the group.
+// A resync event has no metadata, and its kind is meaningless.
+type Event struct {
+	Kind     Kind
+	Type     EventType
+	Metadata *commonv1.Metadata
+	// Revision is the revision of etcd when the change happens
+	Revision int64
+}
+
+type WatchOpt struct {
+	// Group only watches the schemas in the group if it's not empty
+	Group string
+	// Kinds only watches the schemas of these kinds if it's not empty
+	Kinds []Kind
+	// Revision watches the changes after it. Zero watches the ones after Watch is called.
+	Revision int64
+}
+
+func (o WatchOpt) matches(event Event) bool {
+	if event.Type == EventTypeResync {
+		return true
+	}
+	if o.Group != "" {
+		group := event.Metadata.GetGroup()
+		if event.Kind == KindGroup {
+			group = event.Metadata.GetName()
+		}
+		if group != o.Group {
+			return false
+		}
+	}
+	if len(o.Kinds) == 0 {
+		return true
+	}
+	for _, k := range o.Kinds {
+		if k == event.Kind {
+			return true
+		}
+	}
+	return false
+}
+
+// parseKey resolves the kind and the metadata of a schema from its key in the legacy format
+func parseKey(key string) (Kind, *commonv1.Metadata, bool) {
+	if !strings.HasPrefix(key, GroupsKeyPrefix) {
+		return 0, nil, false
+	}
+	rest := strings.TrimPrefix(key, GroupsKeyPrefix)
+	i := strings.Index(rest, "/")
+	if i < 0 {
+		return 0, nil, false
+	}
+	group, rest := rest[:i], rest[i:]
+	if rest == GroupMetadataKey {
+		return KindGroup, &commonv1.Metadata{Name: group}, true
+	}
+	for prefix, kind := range kindPrefixes {
+		if strings.HasPrefix(rest, prefix) {
+			return kind, &commonv1.Metadata{Group: group, Name: strings.TrimPrefix(rest, prefix)}, true
+		}
+	}
+	return 0, nil, false
+}
+
+// Watch emits the changes of the schemas until ctx is done, and then the channel is closed.
+// A broken watch is re-established from the last observed revision.
+func (e *etcdSchemaRegistry) Watch(ctx context.Context, opt WatchOpt) (<-chan Event, error) {
+	rev := opt.Revision
+	if rev <= 0 {
+		resp, err := e.kv.Get(ctx, KeyFormatMarker, clientv3.WithCountOnly())
+		if err != nil {
+			return nil, err
+		}
+		rev = resp.Header.Revision
+	}
+	ch := make(chan Event)
+	go func() {
+		defer close(ch)
+		for {
+			var err error
+			rev, err = e.watch(ctx, opt, rev, ch)
+			if ctx.Err() != nil {
+				return
+			}
+			if err == nil {
+				continue
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(watchRetryInterval):
+			}
+		}
+	}()
+	return ch, nil
+}
+
+// watch emits the changes after rev until the watch channel is closed, and returns the last observed revision
+func (e *etcdSchemaRegistry) watch(ctx context.Context, opt WatchOpt, rev int64, ch chan<- Event) (int64, error) {
+	watchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	wch := e.watcher.Watch(clientv3.WithRequireLeader(watchCtx), cacheKeyPrefix, clientv3.WithPrefix(),
+		clientv3.WithRev(rev+1))
+	for resp := range wch {
+		if resp.CompactRevision != 0 {
+			// the changes up to the compacted revision are lost
+			if !emit(ctx, ch, Event{Type: EventTypeResync, Revision: resp.CompactRevision}) {
+				return rev, ctx.Err()
+			}
+			return resp.CompactRevision - 1, nil
+		}
+		if err := resp.Err(); err != nil {
+			return rev, err
+		}
+		for _, ev := range resp.Events {
+			kind, metadata, ok := parseKey(strings.TrimPrefix(string(ev.Kv.Key), currentKeyRoot))
+			if !ok {
+				continue
+			}
+			event := Event{
+				Kind:     kind,
+				Type:     EventTypePut,
+				Metadata: metadata,
+				Revision: ev.Kv.ModRevision,
+			}
+			if ev.Type == clientv3.EventTypeDelete {
+				event.Type = EventTypeDelete
+			}
+			if opt.matches(event) && !emit(ctx, ch, event) {
+				return rev, ctx.Err()
+			}
+		}
+		if resp.Header.Revision > rev {
+			rev = resp.Header.Revision
+		}
+	}
+	return rev, errors.New("the watch channel is closed")
+}
+
+func emit(ctx context.Context, ch chan<- Event, event Event) bool {
+	select {
+	case ch <- event:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,146 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package schema
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	clientv3 "go.etcd.io/etcd/client/v3"
+
+	commonv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/common/v1"
+)
+
+func receive(req *require.Assertions, ch <-chan Event) Event {
+	select {
+	case event, ok := <-ch:
+		req.True(ok, "the channel is closed")
+		return event
+	case <-time.After(5 * time.Second):
+		req.FailNow("no event is received")
+	}
+	return Event{}
+}
+
+func Test_Watch(t *testing.T) {
+	req := require.New(t)
+	registry, err := NewEtcdSchemaRegistry(useUnixDomain(), useRandomTempDir())
+	req.NoError(err)
+	defer registry.Close()
+	req.NoError(preloadSchema(registry))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch, err := registry.Watch(ctx, WatchOpt{Kinds: []Kind{KindStream}})
+	req.NoError(err)
+	meta := &commonv1.Metadata{Name: "sw", Group: "default"}
+	s, err := registry.GetStream(context.TODO(), meta)
+	req.NoError(err)
+	s.GetOpts().ShardNum = 7
+	req.NoError(registry.UpdateStream(context.TODO(), s))
+	event := receive(req, ch)
+	req.Equal(KindStream, event.Kind)
+	req.Equal(EventTypePut, event.Type)
+	req.Equal("default", event.Metadata.GetGroup())
+	req.Equal("sw", event.Metadata.GetName())
+
+	deleted, err := registry.DeleteStream(context.TODO(), meta, DeleteOpt{Cascade: true})
+	req.NoError(err)
+	req.True(deleted)
+	event = receive(req, ch)
+	req.Equal(EventTypeDelete, event.Type)
+	req.Equal("sw", event.Metadata.GetName())
+
+	cancel()
+	select {
+	case _, ok := <-ch:
+		req.False(ok)
+	case <-time.After(5 * time.Second):
+		req.FailNow("the channel isn't closed")
+	}
+}
+
+// breakingWatcher breaks the first watch after it delivers a response, like a disconnection
+type breakingWatcher struct {
+	clientv3.Watcher
+	watches int32
+}
+
+func (w *breakingWatcher) Watch(ctx context.Context, key string, opts ...clientv3.OpOption) clientv3.WatchChan {
+	if atomic.AddInt32(&w.watches, 1) > 1 {
+		return w.Watcher.Watch(ctx, key, opts...)
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	wch := w.Watcher.Watch(ctx, key, opts...)
+	ch := make(chan clientv3.WatchResponse)
+	go func() {
+		defer cancel()
+		defer close(ch)
+		if resp, ok := <-wch; ok {
+			ch <- resp
+		}
+	}()
+	return ch
+}
+
+func Test_Watch_Reconnect(t *testing.T) {
+	req := require.New(t)
+	registry, err := NewEtcdSchemaRegistry(useUnixDomain(), useRandomTempDir())
+	req.NoError(err)
+	defer registry.Close()
+	req.NoError(preloadSchema(registry))
+	e := registry.(*etcdSchemaRegistry)
+	watcher := &breakingWatcher{Watcher: e.watcher}
+	e.watcher = watcher
+	interval := watchRetryInterval
+	watchRetryInterval = 10 * time.Millisecond
+	defer func() {
+		watchRetryInterval = interval
+	}()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch, err := registry.Watch(ctx, WatchOpt{Group: "default", Kinds: []Kind{KindStream}})
+	req.NoError(err)
+	meta := &commonv1.Metadata{Name: "sw", Group: "default"}
+	s, err := registry.GetStream(context.TODO(), meta)
+	req.NoError(err)
+	s.GetOpts().ShardNum = 7
+	req.NoError(registry.UpdateStream(context.TODO(), s))
+	first := receive(req, ch)
+	req.Equal(EventTypePut, first.Type)
+
+	// the update happens while the watch might be broken
+	s.GetOpts().ShardNum = 8
+	req.NoError(registry.UpdateStream(context.TODO(), s))
+	second := receive(req, ch)
+	req.Equal(EventTypePut, second.Type)
+	req.Equal("sw", second.Metadata.GetName())
+	req.Greater(second.Revision, first.Revision)
+	req.Eventually(func() bool {
+		return atomic.LoadInt32(&watcher.watches) > 1
+	}, 5*time.Second, 10*time.Millisecond, "the watch is re-established")
+	select {
+	case event := <-ch:
+		req.FailNow("unexpected event", "%v", event)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
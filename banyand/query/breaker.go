@@ -0,0 +1,89 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package query
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/apache/skywalking-banyandb/pkg/logger"
+)
+
+var ErrBreakerOpen = errors.New("the analyzer fails repeatedly, the requests are rejected temporarily")
+
+type breakerState struct {
+	failures  int
+	openUntil time.Time
+}
+
+// breaker fast-fails the requests of a stream for a backoff period once the analyzer fails threshold times in a row.
+// The first request after the backoff is let through, and the breaker trips again immediately if it fails.
+type breaker struct {
+	sync.Mutex
+	l         *logger.Logger
+	threshold int
+	backoff   time.Duration
+	states    map[string]*breakerState
+	now       func() time.Time
+}
+
+func newBreaker(l *logger.Logger, threshold int, backoff time.Duration) *breaker {
+	return &breaker{
+		l:         l,
+		threshold: threshold,
+		backoff:   backoff,
+		states:    make(map[string]*breakerState),
+		now:       time.Now,
+	}
+}
+
+// call invokes fn unless the breaker of the key is open. A threshold less than one disables the breaker.
+func (b *breaker) call(key string, fn func() error) error {
+	if b.threshold < 1 {
+		return fn()
+	}
+	b.Lock()
+	if s, ok := b.states[key]; ok && b.now().Before(s.openUntil) {
+		openUntil := s.openUntil
+		b.Unlock()
+		return errors.WithMessagef(ErrBreakerOpen, "stream=%s, until=%s", key, openUntil.Format(time.RFC3339))
+	}
+	b.Unlock()
+	err := fn()
+	b.Lock()
+	defer b.Unlock()
+	if err == nil {
+		delete(b.states, key)
+		return nil
+	}
+	s, ok := b.states[key]
+	if !ok {
+		s = &breakerState{}
+		b.states[key] = s
+	}
+	s.failures++
+	if s.failures >= b.threshold {
+		s.openUntil = b.now().Add(b.backoff)
+		// log once per tripping instead of per request
+		b.l.Warn().Err(err).Str("stream", key).Int("failures", s.failures).
+			Time("open_until", s.openUntil).Msg("the analyzer breaker is open")
+	}
+	return err
+}
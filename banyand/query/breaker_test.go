@@ -0,0 +1,82 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package query
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/apache/skywalking-banyandb/pkg/logger"
+)
+
+func TestBreaker(t *testing.T) {
+	tester := require.New(t)
+	tester.NoError(logger.Init(logger.Logging{
+		Env:   "dev",
+		Level: "info",
+	}))
+	now := time.Unix(0, 0)
+	b := newBreaker(logger.GetLogger("test"), 3, 10*time.Second)
+	b.now = func() time.Time { return now }
+	errAnalyze := errors.New("malformed schema")
+	calls := 0
+	failing := func() error {
+		calls++
+		return errAnalyze
+	}
+
+	for i := 0; i < 3; i++ {
+		tester.ErrorIs(b.call("default/sw", failing), errAnalyze)
+	}
+	tester.Equal(3, calls)
+	// the breaker is open, the requests fail fast without invoking the analyzer
+	for i := 0; i < 10; i++ {
+		tester.ErrorIs(b.call("default/sw", failing), ErrBreakerOpen)
+	}
+	tester.Equal(3, calls)
+	// the other streams are not affected
+	tester.NoError(b.call("default/other", func() error { return nil }))
+
+	// the first request after the backoff is let through, and its failure trips the breaker again
+	now = now.Add(10 * time.Second)
+	tester.ErrorIs(b.call("default/sw", failing), errAnalyze)
+	tester.Equal(4, calls)
+	tester.ErrorIs(b.call("default/sw", failing), ErrBreakerOpen)
+
+	// a success closes the breaker
+	now = now.Add(10 * time.Second)
+	tester.NoError(b.call("default/sw", func() error { return nil }))
+	tester.ErrorIs(b.call("default/sw", failing), errAnalyze)
+	tester.ErrorIs(b.call("default/sw", failing), errAnalyze)
+	tester.Equal(6, calls)
+}
+
+func TestBreaker_Disabled(t *testing.T) {
+	require.NoError(t, logger.Init(logger.Logging{
+		Env:   "dev",
+		Level: "info",
+	}))
+	b := newBreaker(logger.GetLogger("test"), 0, time.Minute)
+	errAnalyze := errors.New("malformed schema")
+	for i := 0; i < 10; i++ {
+		require.ErrorIs(t, b.call("default/sw", func() error { return errAnalyze }), errAnalyze)
+	}
+}
@@ -21,6 +21,8 @@ import (
 	"context"
 	"time"
 
+	"github.com/pkg/errors"
+
 	"github.com/apache/skywalking-banyandb/api/data"
 	streamv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/stream/v1"
 	"github.com/apache/skywalking-banyandb/banyand/discovery"
@@ -30,6 +32,7 @@ import (
 	"github.com/apache/skywalking-banyandb/pkg/bus"
 	"github.com/apache/skywalking-banyandb/pkg/logger"
 	"github.com/apache/skywalking-banyandb/pkg/query/logical"
+	"github.com/apache/skywalking-banyandb/pkg/run"
 )
 
 const (
@@ -38,7 +41,12 @@ const (
 
 var (
 	_ Executor            = (*queryProcessor)(nil)
+	_ run.Config          = (*queryProcessor)(nil)
 	_ bus.MessageListener = (*queryProcessor)(nil)
+	_ run.Service         = (*queryProcessor)(nil)
+
+	ErrInvalidMaxCost = errors.New("the max cost of a query should not be negative")
+	ErrInvalidBreaker = errors.New("the threshold and the backoff of the analyzer breaker should not be negative")
 )
 
 type queryProcessor struct {
@@ -48,6 +56,15 @@ type queryProcessor struct {
 	log           *logger.Logger
 	serviceRepo   discovery.ServiceRepo
 	pipeline      queue.Queue
+	// maxCost rejects the queries whose estimated items exceed it
+	maxCost int
+	// breakerThreshold is the number of the consecutive analyzer failures tripping the breaker of a stream
+	breakerThreshold int
+	breakerBackoff   time.Duration
+	breaker          *breaker
+	// analyzer caches the schemas of the streams across the queries
+	analyzer *logical.Analyzer
+	stopCh   chan struct{}
 }
 
 func (q *queryProcessor) Rev(message bus.Message) (resp bus.Message) {
@@ -66,13 +83,15 @@ func (q *queryProcessor) Rev(message bus.Message) (resp bus.Message) {
 		return
 	}
 
-	analyzer, err := logical.CreateAnalyzerFromMetaService(q.metaService)
-	if err != nil {
-		q.logger.Error().Err(err).Msg("fail to build analyzer")
-		return
+	analyzer := q.analyzer
+	var s logical.Schema
+	err = q.breaker.call(meta.GetGroup()+"/"+meta.GetName(), func() (errBuild error) {
+		s, errBuild = analyzer.BuildStreamSchemaWithIndexRules(context.TODO(), meta, ec.IndexRules())
+		return errBuild
+	})
+	if errors.Is(err, ErrBreakerOpen) {
+		return bus.NewMessage(bus.MessageID(time.Now().UnixNano()), err)
 	}
-
-	s, err := analyzer.BuildStreamSchema(context.TODO(), meta)
 	if err != nil {
 		q.logger.Error().Err(err).Msg("fail to build trace schema")
 		return
@@ -84,6 +103,18 @@ func (q *queryProcessor) Rev(message bus.Message) (resp bus.Message) {
 		return
 	}
 
+	// the estimation seeks the indices as well, so it's skipped unless the rejection is enabled
+	if q.maxCost > 0 {
+		cost, errCost := logical.Explain(p, ec)
+		if errCost != nil {
+			q.log.Warn().Err(errCost).Msg("fail to estimate the cost of the query plan")
+		} else if cost.Exceeds(q.maxCost) {
+			q.log.Warn().Str("plan", p.String()).Str("cost", cost.String()).Msg("reject an expensive query")
+			return bus.NewMessage(bus.MessageID(time.Now().UnixNano()),
+				errors.WithMessagef(logical.ErrQueryTooExpensive, "%s, threshold=%d", cost, q.maxCost))
+		}
+	}
+
 	entities, err := p.Execute(ec)
 	if err != nil {
 		q.logger.Error().Err(err).Msg("fail to execute the query plan")
@@ -100,7 +131,44 @@ func (q *queryProcessor) Name() string {
 	return moduleName
 }
 
+func (q *queryProcessor) FlagSet() *run.FlagSet {
+	fs := run.NewFlagSet("query")
+	fs.IntVarP(&q.maxCost, "query-max-cost", "", 0,
+		"Reject the queries estimated to read more items than it. Zero disables the rejection")
+	fs.IntVarP(&q.breakerThreshold, "query-breaker-threshold", "", 5,
+		"The number of the consecutive analyzer failures rejecting the queries of a stream temporarily. Zero disables the breaker")
+	fs.DurationVarP(&q.breakerBackoff, "query-breaker-backoff", "", 10*time.Second,
+		"The period of rejecting the queries of a stream once the analyzer fails repeatedly")
+	return fs
+}
+
+func (q *queryProcessor) Validate() error {
+	if q.maxCost < 0 {
+		return ErrInvalidMaxCost
+	}
+	if q.breakerThreshold < 0 || q.breakerBackoff < 0 {
+		return ErrInvalidBreaker
+	}
+	return nil
+}
+
 func (q *queryProcessor) PreRun() error {
 	q.log = logger.GetLogger(moduleName)
+	q.breaker = newBreaker(q.log, q.breakerThreshold, q.breakerBackoff)
+	var err error
+	if q.analyzer, err = logical.CreateCachedAnalyzerFromMetaService(q.metaService); err != nil {
+		return err
+	}
+	q.stopCh = make(chan struct{})
 	return q.pipeline.Subscribe(data.TopicStreamQuery, q)
 }
+
+func (q *queryProcessor) Serve() error {
+	<-q.stopCh
+	return nil
+}
+
+func (q *queryProcessor) GracefulStop() {
+	_ = q.analyzer.Close()
+	close(q.stopCh)
+}
@@ -126,6 +126,7 @@ func setupServices(tester *require.Assertions) (stream.Service, queue.Queue, fun
 
 	return streamSvc, pipeline, func() {
 		deferFunc()
+		executor.GracefulStop()
 		metadataSvc.GracefulStop()
 		_ = os.RemoveAll(rootPath)
 		_ = os.RemoveAll(etcdRootDir)
@@ -29,6 +29,7 @@ import (
 
 type Executor interface {
 	run.PreRunner
+	run.Service
 }
 
 func NewExecutor(_ context.Context, streamService stream.Service, metaService metadata.Service,
@@ -0,0 +1,163 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package queue
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/apache/skywalking-banyandb/pkg/bus"
+)
+
+type BreakerState int32
+
+const (
+	// BreakerClosed lets messages flow to the listener
+	BreakerClosed BreakerState = iota
+	// BreakerHalfOpen lets a trial message through to probe the listener
+	BreakerHalfOpen
+	// BreakerOpen pauses the consumption until the backoff elapses
+	BreakerOpen
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "closed"
+	case BreakerHalfOpen:
+		return "half-open"
+	case BreakerOpen:
+		return "open"
+	}
+	return "unknown"
+}
+
+type BreakerOpts struct {
+	// MaxFailures is the number of consecutive failures that opens the breaker.
+	// Zero disables the breaker.
+	MaxFailures int
+	// Backoff is the pause before consuming again
+	Backoff time.Duration
+}
+
+// storageFailure marks the failure of the storage, which is the only kind of failure the breakers count
+type storageFailure struct {
+	error
+}
+
+func (f storageFailure) Unwrap() error {
+	return f.error
+}
+
+// StorageFailure marks err as a failure of the storage. The other errors responded by the listeners,
+// such as the ones of the invalid messages, are caused by the clients, and they never open the breakers.
+func StorageFailure(err error) error {
+	if err == nil {
+		return nil
+	}
+	return storageFailure{error: err}
+}
+
+// IsStorageFailure tells whether err is marked by StorageFailure
+func IsStorageFailure(err error) bool {
+	var f storageFailure
+	return errors.As(err, &f)
+}
+
+var _ bus.MessageListener = (*breaker)(nil)
+
+// breaker wraps a downstream listener. A response carrying a storage failure counts as a failure,
+// while the one carrying any other error is ignored.
+// Once the breaker opens, the consumer loop is held until the backoff elapses,
+// then a trial message decides whether to close it or to open it again.
+type breaker struct {
+	sync.Mutex
+	listener bus.MessageListener
+	opts     BreakerOpts
+	now      func() time.Time
+	sleep    func(time.Duration)
+
+	state     BreakerState
+	failures  int
+	openUntil time.Time
+}
+
+func newBreaker(listener bus.MessageListener, opts BreakerOpts) *breaker {
+	return &breaker{
+		listener: listener,
+		opts:     opts,
+		now:      time.Now,
+		sleep:    time.Sleep,
+	}
+}
+
+func (b *breaker) Rev(message bus.Message) bus.Message {
+	b.await()
+	resp := b.listener.Rev(message)
+	err, failed := resp.Data().(error)
+	if failed && !IsStorageFailure(err) {
+		return resp
+	}
+	b.record(failed)
+	return resp
+}
+
+func (b *breaker) State() BreakerState {
+	b.Lock()
+	defer b.Unlock()
+	if b.state == BreakerOpen && !b.now().Before(b.openUntil) {
+		return BreakerHalfOpen
+	}
+	return b.state
+}
+
+func (b *breaker) await() {
+	b.Lock()
+	if b.state != BreakerOpen {
+		b.Unlock()
+		return
+	}
+	wait := b.openUntil.Sub(b.now())
+	b.Unlock()
+	if wait > 0 {
+		b.sleep(wait)
+	}
+	b.Lock()
+	b.state = BreakerHalfOpen
+	b.Unlock()
+}
+
+func (b *breaker) record(failed bool) {
+	b.Lock()
+	defer b.Unlock()
+	if !failed {
+		b.failures = 0
+		b.state = BreakerClosed
+		return
+	}
+	b.failures++
+	if b.opts.MaxFailures < 1 {
+		return
+	}
+	if b.state == BreakerHalfOpen || b.failures >= b.opts.MaxFailures {
+		b.state = BreakerOpen
+		b.openUntil = b.now().Add(b.opts.Backoff)
+	}
+}
@@ -0,0 +1,154 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package queue
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/apache/skywalking-banyandb/pkg/bus"
+)
+
+var errDownstream = errors.New("downstream failure")
+
+type flakyListener struct {
+	failures int32
+	received int32
+}
+
+func (f *flakyListener) Rev(message bus.Message) bus.Message {
+	atomic.AddInt32(&f.received, 1)
+	if atomic.AddInt32(&f.failures, -1) >= 0 {
+		return bus.NewMessage(message.ID(), StorageFailure(errDownstream))
+	}
+	return bus.Message{}
+}
+
+func TestQueue_Breaker(t *testing.T) {
+	tester := require.New(t)
+	q, err := NewQueue(context.TODO(), nil)
+	tester.NoError(err)
+	l := q.(*local)
+	l.breakerOpts = BreakerOpts{
+		MaxFailures: 3,
+		Backoff:     200 * time.Millisecond,
+	}
+	l.breakerTopics = []string{"flaky"}
+	topic := bus.UniTopic("flaky")
+	listener := &flakyListener{failures: 3}
+	tester.NoError(q.Subscribe(topic, listener))
+	tester.Equal(BreakerClosed, q.BreakerState(topic))
+
+	for i := 0; i < 3; i++ {
+		_, err = q.Publish(topic, bus.NewMessage(bus.MessageID(i), i))
+		tester.NoError(err)
+	}
+	tester.Eventually(func() bool {
+		return q.BreakerState(topic) == BreakerOpen
+	}, time.Second, 10*time.Millisecond)
+	_, err = q.Publish(topic, bus.NewMessage(bus.MessageID(3), 3))
+	tester.ErrorIs(err, ErrCircuitOpen)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&listener.received))
+
+	tester.Eventually(func() bool {
+		return q.BreakerState(topic) == BreakerHalfOpen
+	}, time.Second, 10*time.Millisecond)
+	_, err = q.Publish(topic, bus.NewMessage(bus.MessageID(4), 4))
+	tester.NoError(err)
+	tester.Eventually(func() bool {
+		return q.BreakerState(topic) == BreakerClosed
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, int32(4), atomic.LoadInt32(&listener.received))
+}
+
+func TestBreaker_ReopenOnFailedTrial(t *testing.T) {
+	tester := assert.New(t)
+	now := time.Now()
+	var slept time.Duration
+	b := newBreaker(&flakyListener{failures: 3}, BreakerOpts{
+		MaxFailures: 2,
+		Backoff:     time.Minute,
+	})
+	b.now = func() time.Time {
+		return now
+	}
+	b.sleep = func(d time.Duration) {
+		slept += d
+		now = now.Add(d)
+	}
+	b.Rev(bus.Message{})
+	tester.Equal(BreakerClosed, b.State())
+	b.Rev(bus.Message{})
+	tester.Equal(BreakerOpen, b.State())
+	b.Rev(bus.Message{})
+	tester.Equal(time.Minute, slept)
+	tester.Equal(BreakerOpen, b.State())
+	b.Rev(bus.Message{})
+	tester.Equal(2*time.Minute, slept)
+	tester.Equal(BreakerClosed, b.State())
+}
+
+// invalidListener fails every message as if it's sent by a bad client
+type invalidListener struct {
+	received int32
+}
+
+func (i *invalidListener) Rev(message bus.Message) bus.Message {
+	atomic.AddInt32(&i.received, 1)
+	return bus.NewMessage(message.ID(), errors.New("malformed message"))
+}
+
+func TestQueue_BreakerOptIn(t *testing.T) {
+	tester := require.New(t)
+	q, err := NewQueue(context.TODO(), nil)
+	tester.NoError(err)
+	tester.NoError(q.FlagSet().Parse([]string{"--breaker-topics=invalid", "--breaker-max-failures=1"}))
+
+	// the failures of the clients never open the breaker
+	invalid := bus.UniTopic("invalid")
+	listener := &invalidListener{}
+	tester.NoError(q.Subscribe(invalid, listener))
+	for i := 0; i < 3; i++ {
+		_, err = q.Publish(invalid, bus.NewMessage(bus.MessageID(i), i))
+		tester.NoError(err)
+	}
+	tester.Eventually(func() bool {
+		return atomic.LoadInt32(&listener.received) == 3
+	}, time.Second, 10*time.Millisecond)
+	tester.Equal(BreakerClosed, q.BreakerState(invalid))
+
+	// the topic without opting in isn't guarded even though the storage fails
+	unguarded := bus.UniTopic("unguarded")
+	flaky := &flakyListener{failures: 3}
+	tester.NoError(q.Subscribe(unguarded, flaky))
+	for i := 0; i < 4; i++ {
+		_, err = q.Publish(unguarded, bus.NewMessage(bus.MessageID(i), i))
+		tester.NoError(err)
+	}
+	tester.Eventually(func() bool {
+		return atomic.LoadInt32(&flaky.received) == 4
+	}, time.Second, 10*time.Millisecond)
+	tester.Equal(BreakerClosed, q.BreakerState(unguarded))
+	tester.Empty(q.(*local).breakers[unguarded])
+}
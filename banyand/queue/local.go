@@ -18,26 +18,178 @@
 package queue
 
 import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+
 	"github.com/apache/skywalking-banyandb/banyand/discovery"
 	"github.com/apache/skywalking-banyandb/pkg/bus"
+	"github.com/apache/skywalking-banyandb/pkg/run"
+)
+
+var (
+	ErrCircuitOpen        = errors.New("the consumer of the topic is paused")
+	ErrInvalidBreakerOpts = errors.New("breaker options are invalid")
+	// ErrQueueFull tells the messages pending on the topic reach the capacity, and the publisher should back off
+	ErrQueueFull            = errors.New("the queue of the topic is full")
+	ErrInvalidQueueCapacity = errors.New("the capacity of the queue should be non-negative")
 )
 
 var _ bus.Publisher = (*local)(nil)
 var _ bus.Subscriber = (*local)(nil)
 
 type local struct {
-	local *bus.Bus
-	repo  discovery.ServiceRepo
+	local       *bus.Bus
+	repo        discovery.ServiceRepo
+	breakerOpts BreakerOpts
+	// breakerTopics are the IDs of the topics whose listeners are guarded by the breakers
+	breakerTopics []string
+
+	// capacity is the high-water mark of the messages pending on a topic, and zero leaves it unbounded
+	capacity int64
+	depths   map[bus.Topic]*topicDepth
+
+	listeners map[bus.Topic]int
+	breakers  map[bus.Topic][]*breaker
+	mu        sync.RWMutex
+}
+
+// topicDepth counts the deliveries of the messages to the listeners of a topic, which aren't consumed yet
+type topicDepth struct {
+	pending int64
+	gauge   prometheus.Gauge
+}
+
+func (d *topicDepth) add(n int64) int64 {
+	d.gauge.Add(float64(n))
+	return atomic.AddInt64(&d.pending, n)
 }
 
+var _ bus.MessageListener = (*depthListener)(nil)
+
+// depthListener releases a delivery once the listener consumes it
+type depthListener struct {
+	listener bus.MessageListener
+	depth    *topicDepth
+}
+
+func (d *depthListener) Rev(message bus.Message) bus.Message {
+	defer d.depth.add(-1)
+	return d.listener.Rev(message)
+}
+
+// Subscribe guards the listener with a breaker if the topic opts in to the breakers
 func (l *local) Subscribe(topic bus.Topic, listener bus.MessageListener) error {
-	return l.local.Subscribe(topic, listener)
+	var b *breaker
+	if l.breakerEnabled(topic) {
+		b = newBreaker(listener, l.breakerOpts)
+		listener = b
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	depth, ok := l.depths[topic]
+	if !ok {
+		depth = &topicDepth{gauge: queueDepth.WithLabelValues(topic.ID)}
+	}
+	if err := l.local.Subscribe(topic, &depthListener{listener: listener, depth: depth}); err != nil {
+		return err
+	}
+	l.depths[topic] = depth
+	l.listeners[topic]++
+	if b != nil {
+		l.breakers[topic] = append(l.breakers[topic], b)
+	}
+	return nil
+}
+
+func (l *local) breakerEnabled(topic bus.Topic) bool {
+	for _, id := range l.breakerTopics {
+		if id == topic.ID {
+			return true
+		}
+	}
+	return false
 }
 
 func (l *local) Publish(topic bus.Topic, message ...bus.Message) (bus.Future, error) {
-	return l.local.Publish(topic, message...)
+	if l.BreakerState(topic) == BreakerOpen {
+		return nil, errors.Wrapf(ErrCircuitOpen, "topic: %s", topic.ID)
+	}
+	release, err := l.acquire(topic, len(message))
+	if err != nil {
+		return nil, err
+	}
+	f, err := l.local.Publish(topic, message...)
+	if err != nil {
+		release()
+	}
+	return f, err
+}
+
+// acquire reserves the deliveries of the messages to every listener of the topic, which fails with ErrQueueFull
+// if they exceed the capacity. The returned function releases them if the publishing fails.
+func (l *local) acquire(topic bus.Topic, num int) (func(), error) {
+	l.mu.RLock()
+	depth, ok := l.depths[topic]
+	n := int64(num * l.listeners[topic])
+	l.mu.RUnlock()
+	if !ok || n == 0 {
+		return func() {}, nil
+	}
+	if pending := depth.add(n); l.capacity > 0 && pending > l.capacity {
+		depth.add(-n)
+		return nil, errors.Wrapf(ErrQueueFull, "topic: %s, capacity: %d", topic.ID, l.capacity)
+	}
+	return func() { depth.add(-n) }, nil
+}
+
+// Depth returns the number of the deliveries of the messages pending on the topic
+func (l *local) Depth(topic bus.Topic) int64 {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	if depth, ok := l.depths[topic]; ok {
+		return atomic.LoadInt64(&depth.pending)
+	}
+	return 0
+}
+
+func (l *local) BreakerState(topic bus.Topic) BreakerState {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	state := BreakerClosed
+	for _, b := range l.breakers[topic] {
+		if s := b.State(); s > state {
+			state = s
+		}
+	}
+	return state
+}
+
+func (l *local) FlagSet() *run.FlagSet {
+	fs := run.NewFlagSet("queue")
+	fs.StringSliceVarP(&l.breakerTopics, "breaker-topics", "", nil,
+		"the IDs of the topics whose consumers are paused by the breakers once the storage fails, none of them by default")
+	fs.IntVarP(&l.breakerOpts.MaxFailures, "breaker-max-failures", "", defaultBreakerMaxFailures,
+		"the number of consecutive failures pausing a consumer, 0 disables the breaker")
+	fs.DurationVarP(&l.breakerOpts.Backoff, "breaker-backoff", "", defaultBreakerBackoff,
+		"the pause before a paused consumer resumes")
+	fs.Int64VarP(&l.capacity, "queue-capacity", "", defaultQueueCapacity,
+		"the high-water mark of the messages pending on a topic, beyond which the publishing fails, 0 disables it")
+	return fs
+}
+
+func (l *local) Validate() error {
+	if l.breakerOpts.MaxFailures < 0 || l.breakerOpts.Backoff < 0 {
+		return ErrInvalidBreakerOpts
+	}
+	if l.capacity < 0 {
+		return ErrInvalidQueueCapacity
+	}
+	return nil
 }
 
-func (l local) Name() string {
+func (l *local) Name() string {
 	return "local-pipeline"
 }
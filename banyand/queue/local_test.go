@@ -0,0 +1,69 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package queue
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/apache/skywalking-banyandb/pkg/bus"
+)
+
+type blockedListener struct {
+	releaseCh chan struct{}
+}
+
+func (b *blockedListener) Rev(message bus.Message) bus.Message {
+	<-b.releaseCh
+	return bus.Message{}
+}
+
+func TestQueue_Backpressure(t *testing.T) {
+	tester := require.New(t)
+	q, err := NewQueue(context.TODO(), nil)
+	tester.NoError(err)
+	l := q.(*local)
+	tester.NoError(l.FlagSet().Parse([]string{"--queue-capacity=3"}))
+	tester.NoError(l.Validate())
+	topic := bus.UniTopic("blocked")
+	listener := &blockedListener{releaseCh: make(chan struct{})}
+	tester.NoError(q.Subscribe(topic, listener))
+
+	for i := 0; i < 3; i++ {
+		_, err = q.Publish(topic, bus.NewMessage(bus.MessageID(i), i))
+		tester.NoError(err)
+	}
+	tester.Equal(int64(3), q.Depth(topic))
+	_, err = q.Publish(topic, bus.NewMessage(bus.MessageID(3), 3))
+	tester.ErrorIs(err, ErrQueueFull)
+	// the rejected messages aren't counted
+	tester.Equal(int64(3), q.Depth(topic))
+
+	close(listener.releaseCh)
+	tester.Eventually(func() bool {
+		return q.Depth(topic) == 0
+	}, time.Second, 10*time.Millisecond)
+	_, err = q.Publish(topic, bus.NewMessage(bus.MessageID(4), 4))
+	tester.NoError(err)
+
+	tester.NoError(l.FlagSet().Parse([]string{"--queue-capacity=-1"}))
+	tester.ErrorIs(l.Validate(), ErrInvalidQueueCapacity)
+}
@@ -0,0 +1,32 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package queue
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// queueDepth is the number of the messages published to the topic but not consumed yet
+var queueDepth = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: "banyandb",
+	Subsystem: "queue",
+	Name:      "depth",
+	Help:      "The number of the messages pending on the topic",
+}, []string{"topic"})
+
+func init() {
+	prometheus.MustRegister(queueDepth)
+}
@@ -19,21 +19,41 @@ package queue
 
 import (
 	"context"
+	"time"
 
 	"github.com/apache/skywalking-banyandb/banyand/discovery"
 	"github.com/apache/skywalking-banyandb/pkg/bus"
 	"github.com/apache/skywalking-banyandb/pkg/run"
 )
 
+const (
+	defaultBreakerMaxFailures = 5
+	defaultBreakerBackoff     = time.Second
+	defaultQueueCapacity      = 100000
+)
+
 type Queue interface {
-	run.Unit
+	run.Config
 	bus.Subscriber
 	bus.Publisher
+	// BreakerState returns the most severe state of the breakers guarding the topic's listeners
+	BreakerState(topic bus.Topic) BreakerState
+	// Depth returns the number of the messages published to the topic but not consumed yet.
+	// A message counts once for each listener of the topic.
+	Depth(topic bus.Topic) int64
 }
 
 func NewQueue(_ context.Context, repo discovery.ServiceRepo) (Queue, error) {
 	return &local{
 		repo:  repo,
 		local: bus.NewBus(),
+		breakerOpts: BreakerOpts{
+			MaxFailures: defaultBreakerMaxFailures,
+			Backoff:     defaultBreakerBackoff,
+		},
+		capacity:  defaultQueueCapacity,
+		depths:    make(map[bus.Topic]*topicDepth),
+		listeners: make(map[bus.Topic]int),
+		breakers:  make(map[bus.Topic][]*breaker),
 	}, nil
 }
@@ -32,6 +32,7 @@ import (
 	"github.com/apache/skywalking-banyandb/banyand/metadata"
 	"github.com/apache/skywalking-banyandb/banyand/metadata/schema"
 	"github.com/apache/skywalking-banyandb/banyand/queue"
+	"github.com/apache/skywalking-banyandb/banyand/tsdb"
 	"github.com/apache/skywalking-banyandb/pkg/bus"
 	"github.com/apache/skywalking-banyandb/pkg/logger"
 	"github.com/apache/skywalking-banyandb/pkg/run"
@@ -57,6 +58,7 @@ type service struct {
 	l             *logger.Logger
 	metadata      metadata.Repo
 	root          string
+	chunkSize     int
 	pipeline      queue.Queue
 	repo          discovery.ServiceRepo
 	stopCh        chan struct{}
@@ -74,6 +76,7 @@ func (s *service) Stream(stream *commonv1.Metadata) (Stream, error) {
 func (s *service) FlagSet() *run.FlagSet {
 	flagS := run.NewFlagSet("storage")
 	flagS.StringVar(&s.root, "root-path", "/tmp", "the root path of database")
+	flagS.IntVar(&s.chunkSize, "stream-chunk-size", tsdb.DefaultChunkSize, "the size of the values of an encoded block in bytes")
 	return flagS
 }
 
@@ -81,7 +84,7 @@ func (s *service) Validate() error {
 	if s.root == "" {
 		return ErrEmptyRootPath
 	}
-	return nil
+	return tsdb.ValidateChunkSize(s.chunkSize)
 }
 
 func (s *service) Name() string {
@@ -89,7 +92,7 @@ func (s *service) Name() string {
 }
 
 func (s *service) PreRun() error {
-	schemas, err := s.metadata.StreamRegistry().ListStream(context.TODO(), schema.ListOpt{})
+	schemas, _, err := s.metadata.StreamRegistry().ListStream(context.TODO(), schema.ListOpt{})
 	if err != nil {
 		return err
 	}
@@ -104,6 +107,7 @@ func (s *service) PreRun() error {
 		sm, errTS := openStream(s.root, streamSpec{
 			schema:     sa,
 			indexRules: iRules,
+			chunkSize:  s.chunkSize,
 		}, s.l)
 		if errTS != nil {
 			return errTS
@@ -133,9 +137,11 @@ func (s *service) Serve() error {
 				Name:  sMeta.name,
 				Group: sMeta.group,
 			},
-			EntityLocator: locator,
-			Time:          nowBp,
-			Action:        databasev1.Action_ACTION_PUT,
+			EntityLocator:     locator,
+			ShardOverrides:    sMeta.schema.GetOpts().GetShardOverrides(),
+			ShardingAlgorithm: sMeta.schema.GetOpts().GetShardingAlgorithm(),
+			Time:              nowBp,
+			Action:            databasev1.Action_ACTION_PUT,
 		}))
 		if err != nil {
 			return err
@@ -170,6 +176,14 @@ func (s *service) Serve() error {
 	if errWrite != nil {
 		return errWrite
 	}
+	errWrite = s.pipeline.Subscribe(data.TopicStreamDurableWrite, s.writeListener)
+	if errWrite != nil {
+		return errWrite
+	}
+	errMaintenance := s.pipeline.Subscribe(data.TopicStreamMaintenance, setUpMaintenanceCallback(s.l, s.schemaMap))
+	if errMaintenance != nil {
+		return errMaintenance
+	}
 	s.stopCh = make(chan struct{})
 	<-s.stopCh
 	return nil
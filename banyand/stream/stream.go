@@ -19,18 +19,19 @@ package stream
 
 import (
 	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
 
 	databasev1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/database/v1"
 	"github.com/apache/skywalking-banyandb/banyand/tsdb"
 	"github.com/apache/skywalking-banyandb/banyand/tsdb/index"
-	"github.com/apache/skywalking-banyandb/pkg/encoding"
 	"github.com/apache/skywalking-banyandb/pkg/logger"
 	"github.com/apache/skywalking-banyandb/pkg/partition"
+	pbv1 "github.com/apache/skywalking-banyandb/pkg/pb/v1"
 )
 
-// a chunk is 1MB
-const chunkSize = 1 << 20
-
 type stream struct {
 	name          string
 	group         string
@@ -38,25 +39,48 @@ type stream struct {
 	schema        *databasev1.Stream
 	db            tsdb.Database
 	entityLocator partition.EntityLocator
-	indexRules    []*databasev1.IndexRule
-	indexWriter   *index.Writer
+	// shardOverrides route the hot entities to their explicit shards
+	shardOverrides partition.ShardOverrides
+	// shardingStrategy routes the other entities
+	shardingStrategy partition.ShardingStrategy
+	indexWriter      *index.Writer
+
+	// rulesMu guards the index rules, which are swapped by a reindex
+	rulesMu    sync.RWMutex
+	indexRules []*databasev1.IndexRule
+	reindexing bool
+	// reindexHook is called before backfilling each series, which lets the tests interleave with a reindex
+	reindexHook func()
 }
 
+// Close closes the stream in two phases. The index writer drains the pending messages first, whose indices
+// are written into the blocks, and the blocks are synced. Then the database is closed even if the first phase fails.
 func (s *stream) Close() error {
-	_ = s.indexWriter.Close()
-	return s.db.Close()
+	err := multierr.Append(s.indexWriter.Close(), s.db.Flush())
+	return multierr.Append(err, s.db.Close())
 }
 
-func (s *stream) parseSchema() {
+func (s *stream) parseSchema() (err error) {
 	sm := s.schema
 	meta := sm.GetMetadata()
 	s.name, s.group = meta.GetName(), meta.GetGroup()
-	s.entityLocator = partition.NewEntityLocator(sm.TagFamilies, sm.Entity)
+	if s.entityLocator, err = partition.NewEntityLocator(sm.TagFamilies, sm.Entity); err != nil {
+		return err
+	}
+	if s.shardingStrategy, err = partition.NewShardingStrategy(sm.GetOpts().GetShardingAlgorithm()); err != nil {
+		return err
+	}
+	if s.shardOverrides, err = partition.ParseShardOverrides(len(sm.Entity.GetTagNames()), sm.GetOpts().GetShardOverrides()); err != nil {
+		return err
+	}
+	return s.shardOverrides.Validate(sm.GetOpts().GetShardNum())
 }
 
 type streamSpec struct {
 	schema     *databasev1.Stream
 	indexRules []*databasev1.IndexRule
+	// chunkSize bounds the values of an encoded block in bytes, and zero falls back to tsdb.DefaultChunkSize
+	chunkSize int
 }
 
 func openStream(root string, spec streamSpec, l *logger.Logger) (*stream, error) {
@@ -65,7 +89,17 @@ func openStream(root string, spec streamSpec, l *logger.Logger) (*stream, error)
 		indexRules: spec.indexRules,
 		l:          l,
 	}
-	sm.parseSchema()
+	if err := sm.parseSchema(); err != nil {
+		return nil, errors.WithMessagef(err, "failed to parse the schema of %s", sm.name)
+	}
+	ttl, err := pbv1.TTLOf(sm.schema.GetOpts())
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to parse the schema of %s", sm.name)
+	}
+	codec, err := pbv1.CodecOf(sm.schema.GetOpts())
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to parse the schema of %s", sm.name)
+	}
 	ctx := context.WithValue(context.Background(), logger.ContextKey, l)
 	db, err := tsdb.OpenDatabase(
 		ctx,
@@ -74,9 +108,12 @@ func openStream(root string, spec streamSpec, l *logger.Logger) (*stream, error)
 			ShardNum:   sm.schema.GetOpts().GetShardNum(),
 			IndexRules: spec.indexRules,
 			EncodingMethod: tsdb.EncodingMethod{
-				EncoderPool: encoding.NewPlainEncoderPool(chunkSize),
-				DecoderPool: encoding.NewPlainDecoderPool(chunkSize),
+				Codec: codec,
 			},
+			ChunkSize:     spec.chunkSize,
+			StorageEngine: sm.schema.GetOpts().GetStorageEngine(),
+			TTL:           ttl,
+			MaxSegments:   sm.schema.GetOpts().GetMaxSegments(),
 		})
 	if err != nil {
 		return nil, err
@@ -0,0 +1,244 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package stream
+
+import (
+	"io"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/ipc"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/apache/skywalking-banyandb/api/common"
+	databasev1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/database/v1"
+	modelv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/model/v1"
+	"github.com/apache/skywalking-banyandb/banyand/kv"
+	"github.com/apache/skywalking-banyandb/banyand/tsdb"
+)
+
+// ColumnarFormat is the file format of the exported elements
+type ColumnarFormat int
+
+const (
+	// ColumnarFormatArrow is the Arrow IPC streaming format
+	ColumnarFormatArrow ColumnarFormat = iota
+)
+
+var ErrUnsupportedFormat = errors.New("the columnar format is not supported")
+
+const (
+	exportColumnElementID = "element_id"
+	exportColumnTimestamp = "timestamp"
+	// exportBatchSize is the max number of the rows in a record batch
+	exportBatchSize = 1024
+)
+
+// ExportColumnar writes the elements of the shard in the time range to w in the columnar format.
+// Besides the element id and the timestamp, every tag is a nullable column named "<family>.<tag>",
+// whose type is derived from the tag spec.
+func (s *stream) ExportColumnar(shardID common.ShardID, timeRange tsdb.TimeRange, w io.Writer, format ColumnarFormat) error {
+	if format != ColumnarFormatArrow {
+		return errors.Wrapf(ErrUnsupportedFormat, "%d", format)
+	}
+	shard, err := s.db.Shard(shardID)
+	if err != nil {
+		return err
+	}
+	schema, err := s.exportSchema()
+	if err != nil {
+		return err
+	}
+	mem := memory.NewGoAllocator()
+	e := &exporter{
+		stream:  s,
+		builder: array.NewRecordBuilder(mem, schema),
+		writer:  ipc.NewWriter(w, ipc.WithSchema(schema), ipc.WithAllocator(mem)),
+	}
+	defer e.builder.Release()
+	// the nil entries match any series of the shard
+	seriesList, err := shard.Series().List(tsdb.NewPath(make(tsdb.Entity, len(s.entityLocator))))
+	if err != nil {
+		return err
+	}
+	for _, series := range seriesList {
+		if err = e.exportSeries(series, timeRange); err != nil {
+			return err
+		}
+	}
+	if err = e.flush(); err != nil {
+		return err
+	}
+	return e.writer.Close()
+}
+
+func (s *stream) exportSchema() (*arrow.Schema, error) {
+	fields := []arrow.Field{
+		{Name: exportColumnElementID, Type: arrow.BinaryTypes.String},
+		{Name: exportColumnTimestamp, Type: arrow.FixedWidthTypes.Timestamp_ns},
+	}
+	for _, family := range s.schema.GetTagFamilies() {
+		for _, tag := range family.GetTags() {
+			var t arrow.DataType
+			switch tag.GetType() {
+			case databasev1.TagType_TAG_TYPE_STRING:
+				t = arrow.BinaryTypes.String
+			case databasev1.TagType_TAG_TYPE_INT:
+				t = arrow.PrimitiveTypes.Int64
+			case databasev1.TagType_TAG_TYPE_STRING_ARRAY:
+				t = arrow.ListOf(arrow.BinaryTypes.String)
+			case databasev1.TagType_TAG_TYPE_INT_ARRAY:
+				t = arrow.ListOf(arrow.PrimitiveTypes.Int64)
+			case databasev1.TagType_TAG_TYPE_DATA_BINARY:
+				t = arrow.BinaryTypes.Binary
+			default:
+				return nil, errors.Wrapf(ErrUnsupportedFormat, "tag %s has the type %s", tag.GetName(), tag.GetType())
+			}
+			fields = append(fields, arrow.Field{Name: family.GetName() + "." + tag.GetName(), Type: t, Nullable: true})
+		}
+	}
+	return arrow.NewSchema(fields, nil), nil
+}
+
+type exporter struct {
+	stream  *stream
+	builder *array.RecordBuilder
+	writer  *ipc.Writer
+	rows    int
+}
+
+func (e *exporter) exportSeries(series tsdb.Series, timeRange tsdb.TimeRange) (err error) {
+	sp, err := series.Span(timeRange)
+	if err != nil {
+		if errors.Is(err, tsdb.ErrEmptySeriesSpan) {
+			return nil
+		}
+		return err
+	}
+	defer func() {
+		_ = sp.Close()
+	}()
+	seeker, err := sp.SeekerBuilder().OrderByTime(modelv1.Sort_SORT_ASC).Build()
+	if err != nil {
+		return err
+	}
+	iters, err := seeker.Seek()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		for _, iter := range iters {
+			_ = iter.Close()
+		}
+	}()
+	for _, iter := range iters {
+		for iter.Next() {
+			if err = e.append(iter.Val()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (e *exporter) append(item tsdb.Item) error {
+	elementID, err := e.stream.ParseElementID(item)
+	if err != nil {
+		return err
+	}
+	e.builder.Field(0).(*array.StringBuilder).Append(elementID)
+	e.builder.Field(1).(*array.TimestampBuilder).Append(arrow.Timestamp(item.Time()))
+	col := 2
+	for _, familySpec := range e.stream.schema.GetTagFamilies() {
+		var tags []*modelv1.TagValue
+		raw, errFamily := item.Family(familySpec.GetName())
+		if errFamily != nil && !errors.Is(errFamily, kv.ErrKeyNotFound) {
+			return errFamily
+		}
+		if errFamily == nil {
+			family := &modelv1.TagFamilyForWrite{}
+			if err = proto.Unmarshal(raw, family); err != nil {
+				return err
+			}
+			tags = family.GetTags()
+		}
+		for i := range familySpec.GetTags() {
+			var tag *modelv1.TagValue
+			if i < len(tags) {
+				tag = tags[i]
+			}
+			appendTag(e.builder.Field(col), tag)
+			col++
+		}
+	}
+	e.rows++
+	if e.rows >= exportBatchSize {
+		return e.flush()
+	}
+	return nil
+}
+
+// appendTag appends a null if the tag is absent or its type doesn't match the column
+func appendTag(b array.Builder, tag *modelv1.TagValue) {
+	switch v := tag.GetValue().(type) {
+	case *modelv1.TagValue_Str:
+		if sb, ok := b.(*array.StringBuilder); ok {
+			sb.Append(v.Str.GetValue())
+			return
+		}
+	case *modelv1.TagValue_Int:
+		if ib, ok := b.(*array.Int64Builder); ok {
+			ib.Append(v.Int.GetValue())
+			return
+		}
+	case *modelv1.TagValue_BinaryData:
+		if bb, ok := b.(*array.BinaryBuilder); ok && v.BinaryData != nil {
+			bb.Append(v.BinaryData)
+			return
+		}
+	case *modelv1.TagValue_StrArray:
+		if lb, ok := b.(*array.ListBuilder); ok {
+			if sb, ok := lb.ValueBuilder().(*array.StringBuilder); ok {
+				lb.Append(true)
+				sb.AppendValues(v.StrArray.GetValue(), nil)
+				return
+			}
+		}
+	case *modelv1.TagValue_IntArray:
+		if lb, ok := b.(*array.ListBuilder); ok {
+			if ib, ok := lb.ValueBuilder().(*array.Int64Builder); ok {
+				lb.Append(true)
+				ib.AppendValues(v.IntArray.GetValue(), nil)
+				return
+			}
+		}
+	}
+	b.AppendNull()
+}
+
+func (e *exporter) flush() error {
+	if e.rows == 0 {
+		return nil
+	}
+	rec := e.builder.NewRecord()
+	defer rec.Release()
+	e.rows = 0
+	return e.writer.Write(rec)
+}
@@ -0,0 +1,129 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package stream
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/ipc"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/apache/skywalking-banyandb/api/common"
+	"github.com/apache/skywalking-banyandb/banyand/tsdb"
+)
+
+type exportedRow struct {
+	ts         int64
+	dataBinary []byte
+	traceID    string
+	state      int64
+	httpMethod *string
+}
+
+func Test_Stream_ExportColumnar(t *testing.T) {
+	tester := require.New(t)
+	s, deferFunc := setup(t)
+	defer deferFunc()
+	baseTime := setupQueryData(t, "multiple_shards.json", s)
+	var templates []struct {
+		Tags []json.RawMessage `json:"tags"`
+	}
+	content, err := dataFS.ReadFile("testdata/multiple_shards.json")
+	tester.NoError(err)
+	tester.NoError(json.Unmarshal(content, &templates))
+	bb, _ := base64.StdEncoding.DecodeString("YWJjMTIzIT8kKiYoKSctPUB+")
+
+	rows := make(map[string]exportedRow)
+	timeRange := tsdb.NewTimeRangeDuration(baseTime, time.Hour)
+	for i := uint32(0); i < s.schema.GetOpts().GetShardNum(); i++ {
+		var buf bytes.Buffer
+		tester.NoError(s.ExportColumnar(common.ShardID(i), timeRange, &buf, ColumnarFormatArrow))
+		if buf.Len() == 0 {
+			continue
+		}
+		r, errReader := ipc.NewReader(&buf)
+		tester.NoError(errReader)
+		schema := r.Schema()
+		column := func(name string) int {
+			indices := schema.FieldIndices(name)
+			tester.Len(indices, 1, name)
+			return indices[0]
+		}
+		tester.Equal(arrow.FixedWidthTypes.Timestamp_ns, schema.Field(column("timestamp")).Type)
+		tester.Equal(arrow.PrimitiveTypes.Int64, schema.Field(column("searchable.state")).Type)
+		for r.Next() {
+			rec := r.Record()
+			ids := rec.Column(column("element_id")).(*array.String)
+			timestamps := rec.Column(column("timestamp")).(*array.Timestamp)
+			dataBinary := rec.Column(column("data.data_binary")).(*array.Binary)
+			traceIDs := rec.Column(column("searchable.trace_id")).(*array.String)
+			states := rec.Column(column("searchable.state")).(*array.Int64)
+			httpMethods := rec.Column(column("searchable.http.method")).(*array.String)
+			for j := 0; j < int(rec.NumRows()); j++ {
+				row := exportedRow{
+					ts:         int64(timestamps.Value(j)),
+					dataBinary: append([]byte(nil), dataBinary.Value(j)...),
+					traceID:    traceIDs.Value(j),
+					state:      states.Value(j),
+				}
+				if httpMethods.IsValid(j) {
+					m := httpMethods.Value(j)
+					row.httpMethod = &m
+				}
+				tester.NotContains(rows, ids.Value(j))
+				rows[ids.Value(j)] = row
+			}
+		}
+		r.Release()
+	}
+
+	tester.Len(rows, len(templates))
+	for i, template := range templates {
+		row, ok := rows[strconv.Itoa(i)]
+		tester.True(ok, "element %d is not exported", i)
+		tester.Equal(baseTime.Add(500*time.Millisecond*time.Duration(i)).UnixNano(), row.ts)
+		tester.Equal(bb, row.dataBinary)
+		var traceID struct {
+			Str struct {
+				Value string `json:"value"`
+			} `json:"str"`
+		}
+		tester.NoError(json.Unmarshal(template.Tags[0], &traceID))
+		tester.Equal(traceID.Str.Value, row.traceID)
+		var state struct {
+			Int struct {
+				Value int64 `json:"value"`
+			} `json:"int"`
+		}
+		tester.NoError(json.Unmarshal(template.Tags[1], &state))
+		tester.Equal(state.Int.Value, row.state)
+		if len(template.Tags) <= 7 {
+			tester.Nil(row.httpMethod, "the absent tag is null")
+		}
+	}
+
+	tester.True(errors.Is(s.ExportColumnar(0, timeRange, &bytes.Buffer{}, ColumnarFormat(-1)), ErrUnsupportedFormat))
+}
@@ -0,0 +1,169 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package stream
+
+import (
+	"io"
+	"time"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/ipc"
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	modelv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/model/v1"
+	streamv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/stream/v1"
+)
+
+var ErrSchemaMismatch = errors.New("the schema of the file doesn't match the stream")
+
+// ImportColumnar writes the rows of the columnar file in r through the write path, which locates their shards
+// and indexes them. The file has the layout written by ExportColumnar, but the tag columns are optional.
+// It returns the number of the imported rows, and the errors of the rejected rows are combined.
+// A database without the partitioner rejects the rows older than its first segment.
+func (s *stream) ImportColumnar(r io.Reader, format ColumnarFormat) (int, error) {
+	if format != ColumnarFormatArrow {
+		return 0, errors.Wrapf(ErrUnsupportedFormat, "%d", format)
+	}
+	reader, err := ipc.NewReader(r)
+	if err != nil {
+		return 0, err
+	}
+	defer reader.Release()
+	columns, err := s.importColumns(reader.Schema())
+	if err != nil {
+		return 0, err
+	}
+	var imported, rowNum int
+	var rowErrs error
+	for reader.Next() {
+		rec := reader.Record()
+		for i := 0; i < int(rec.NumRows()); i++ {
+			errRow := s.Write(columns.element(rec, i))
+			if errRow != nil {
+				rowErrs = multierr.Append(rowErrs, errors.WithMessagef(errRow, "row %d", rowNum))
+			} else {
+				imported++
+			}
+			rowNum++
+		}
+	}
+	if err = reader.Err(); err != nil {
+		return imported, multierr.Append(rowErrs, err)
+	}
+	return imported, rowErrs
+}
+
+// importColumns is the column indices in the file. tags[family][tag] is -1 if the tag is absent.
+type importColumns struct {
+	elementID int
+	timestamp int
+	tags      [][]int
+}
+
+func (s *stream) importColumns(fileSchema *arrow.Schema) (*importColumns, error) {
+	want, err := s.exportSchema()
+	if err != nil {
+		return nil, err
+	}
+	index := make(map[string]int, len(fileSchema.Fields()))
+	for i, f := range fileSchema.Fields() {
+		wantIndices := want.FieldIndices(f.Name)
+		if len(wantIndices) == 0 {
+			return nil, errors.Wrapf(ErrSchemaMismatch, "column %s is unknown", f.Name)
+		}
+		if wantType := want.Field(wantIndices[0]).Type; !arrow.TypeEqual(f.Type, wantType) {
+			return nil, errors.Wrapf(ErrSchemaMismatch, "column %s is %s, but %s is expected", f.Name, f.Type, wantType)
+		}
+		index[f.Name] = i
+	}
+	columns := &importColumns{}
+	var ok bool
+	if columns.elementID, ok = index[exportColumnElementID]; !ok {
+		return nil, errors.Wrapf(ErrSchemaMismatch, "column %s is absent", exportColumnElementID)
+	}
+	if columns.timestamp, ok = index[exportColumnTimestamp]; !ok {
+		return nil, errors.Wrapf(ErrSchemaMismatch, "column %s is absent", exportColumnTimestamp)
+	}
+	for _, family := range s.schema.GetTagFamilies() {
+		tags := make([]int, len(family.GetTags()))
+		for i, tag := range family.GetTags() {
+			if tags[i], ok = index[family.GetName()+"."+tag.GetName()]; !ok {
+				tags[i] = -1
+			}
+		}
+		columns.tags = append(columns.tags, tags)
+	}
+	return columns, nil
+}
+
+func (c *importColumns) element(rec array.Record, row int) *streamv1.ElementValue {
+	ts := rec.Column(c.timestamp).(*array.Timestamp).Value(row)
+	e := &streamv1.ElementValue{
+		ElementId: rec.Column(c.elementID).(*array.String).Value(row),
+		Timestamp: timestamppb.New(time.Unix(0, int64(ts))),
+	}
+	for _, tags := range c.tags {
+		family := &modelv1.TagFamilyForWrite{}
+		for _, col := range tags {
+			tag := nullTag()
+			if col >= 0 {
+				tag = importTag(rec.Column(col), row)
+			}
+			family.Tags = append(family.Tags, tag)
+		}
+		e.TagFamilies = append(e.TagFamilies, family)
+	}
+	return e
+}
+
+func importTag(column array.Interface, row int) *modelv1.TagValue {
+	if column.IsNull(row) {
+		return nullTag()
+	}
+	switch a := column.(type) {
+	case *array.String:
+		return &modelv1.TagValue{Value: &modelv1.TagValue_Str{Str: &modelv1.Str{Value: a.Value(row)}}}
+	case *array.Int64:
+		return &modelv1.TagValue{Value: &modelv1.TagValue_Int{Int: &modelv1.Int{Value: a.Value(row)}}}
+	case *array.Binary:
+		return &modelv1.TagValue{Value: &modelv1.TagValue_BinaryData{BinaryData: append([]byte(nil), a.Value(row)...)}}
+	case *array.List:
+		offsets := a.Offsets()
+		start, end := int(offsets[row]), int(offsets[row+1])
+		switch values := a.ListValues().(type) {
+		case *array.String:
+			strs := make([]string, 0, end-start)
+			for i := start; i < end; i++ {
+				strs = append(strs, values.Value(i))
+			}
+			return &modelv1.TagValue{Value: &modelv1.TagValue_StrArray{StrArray: &modelv1.StrArray{Value: strs}}}
+		case *array.Int64:
+			return &modelv1.TagValue{Value: &modelv1.TagValue_IntArray{IntArray: &modelv1.IntArray{
+				Value: append([]int64(nil), values.Int64Values()[start:end]...),
+			}}}
+		}
+	}
+	return nullTag()
+}
+
+func nullTag() *modelv1.TagValue {
+	return &modelv1.TagValue{Value: &modelv1.TagValue_Null{}}
+}
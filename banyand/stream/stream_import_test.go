@@ -0,0 +1,136 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package stream
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/ipc"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/apache/skywalking-banyandb/api/common"
+	"github.com/apache/skywalking-banyandb/banyand/tsdb"
+	"github.com/apache/skywalking-banyandb/pkg/convert"
+)
+
+// exportAll exports all the shards, and returns the rows keyed by the element ids
+func exportAll(tester *require.Assertions, s *stream, timeRange tsdb.TimeRange) ([][]byte, map[string][]interface{}) {
+	var files [][]byte
+	rows := make(map[string][]interface{})
+	for i := uint32(0); i < s.schema.GetOpts().GetShardNum(); i++ {
+		var buf bytes.Buffer
+		tester.NoError(s.ExportColumnar(common.ShardID(i), timeRange, &buf, ColumnarFormatArrow))
+		if buf.Len() == 0 {
+			continue
+		}
+		files = append(files, append([]byte(nil), buf.Bytes()...))
+		r, err := ipc.NewReader(&buf)
+		tester.NoError(err)
+		for r.Next() {
+			rec := r.Record()
+			for j := 0; j < int(rec.NumRows()); j++ {
+				row := make([]interface{}, rec.NumCols())
+				for c, col := range rec.Columns() {
+					if col.IsNull(j) {
+						continue
+					}
+					switch a := col.(type) {
+					case *array.String:
+						row[c] = a.Value(j)
+					case *array.Timestamp:
+						row[c] = a.Value(j)
+					case *array.Int64:
+						row[c] = a.Value(j)
+					case *array.Binary:
+						row[c] = append([]byte(nil), a.Value(j)...)
+					default:
+						row[c] = col.DataType().Name()
+					}
+				}
+				rows[row[0].(string)] = row
+			}
+		}
+		r.Release()
+	}
+	return files, rows
+}
+
+func Test_Stream_ImportColumnar(t *testing.T) {
+	tester := require.New(t)
+	// the fresh database is opened before the data is written, so its first segment covers the imported data
+	dst, deferDst := setup(t)
+	defer deferDst()
+	src, deferSrc := setup(t)
+	defer deferSrc()
+	baseTime := setupQueryData(t, "multiple_shards.json", src)
+	timeRange := tsdb.NewTimeRangeDuration(baseTime, time.Hour)
+	files, want := exportAll(tester, src, timeRange)
+	tester.NotEmpty(want)
+
+	var imported int
+	for _, f := range files {
+		n, err := dst.ImportColumnar(bytes.NewReader(f), ColumnarFormatArrow)
+		tester.NoError(err)
+		imported += n
+	}
+	tester.Equal(len(want), imported)
+	_, got := exportAll(tester, dst, timeRange)
+	tester.Equal(want, got)
+	// the imported elements are located by their entities as the written ones
+	located, err := queryData(assert.New(t), dst, queryOpts{
+		entity:    tsdb.Entity{tsdb.Entry("webapp_id"), tsdb.Entry("10.0.0.1_id"), convert.Int64ToBytes(0)},
+		timeRange: timeRange,
+	})
+	tester.NoError(err)
+	tester.Len(located, 1)
+	tester.NotEmpty(located[0].elements)
+}
+
+func Test_Stream_ImportColumnar_SchemaMismatch(t *testing.T) {
+	tester := require.New(t)
+	s, deferFunc := setup(t)
+	defer deferFunc()
+	write := func(fields ...arrow.Field) []byte {
+		schema := arrow.NewSchema(fields, nil)
+		var buf bytes.Buffer
+		w := ipc.NewWriter(&buf, ipc.WithSchema(schema), ipc.WithAllocator(memory.NewGoAllocator()))
+		b := array.NewRecordBuilder(memory.NewGoAllocator(), schema)
+		defer b.Release()
+		rec := b.NewRecord()
+		tester.NoError(w.Write(rec))
+		rec.Release()
+		tester.NoError(w.Close())
+		return buf.Bytes()
+	}
+	elementID := arrow.Field{Name: "element_id", Type: arrow.BinaryTypes.String}
+	timestamp := arrow.Field{Name: "timestamp", Type: arrow.FixedWidthTypes.Timestamp_ns}
+	for name, f := range map[string][]byte{
+		"unknown column": write(elementID, timestamp, arrow.Field{Name: "searchable.unknown", Type: arrow.BinaryTypes.String}),
+		"wrong type":     write(elementID, timestamp, arrow.Field{Name: "searchable.state", Type: arrow.BinaryTypes.String}),
+		"no timestamp":   write(elementID),
+	} {
+		_, err := s.ImportColumnar(bytes.NewReader(f), ColumnarFormatArrow)
+		tester.ErrorIs(err, ErrSchemaMismatch, name)
+	}
+}
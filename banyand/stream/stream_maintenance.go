@@ -0,0 +1,119 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package stream
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	streamv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/stream/v1"
+	"github.com/apache/skywalking-banyandb/pkg/bus"
+	"github.com/apache/skywalking-banyandb/pkg/logger"
+)
+
+// maintenanceTimeout bounds an operation in case the requester is gone
+const maintenanceTimeout = 5 * time.Minute
+
+var (
+	ErrUnknownMaintenanceOp = errors.New("unknown maintenance op")
+	ErrMaintenanceMsg       = errors.New("invalid maintenance message")
+)
+
+// maintain applies an operation to the storage.
+// A flush generates the pending indices before syncing the data, so the data written before it are queryable afterward.
+func (s *stream) maintain(ctx context.Context, op streamv1.MaintenanceOp) error {
+	switch op {
+	case streamv1.MaintenanceOp_MAINTENANCE_OP_FLUSH:
+		if err := s.indexWriter.Flush(ctx); err != nil {
+			return err
+		}
+		return s.db.Flush()
+	case streamv1.MaintenanceOp_MAINTENANCE_OP_COMPACT:
+		return s.db.Compact()
+	}
+	return errors.Wrapf(ErrUnknownMaintenanceOp, "op:%s", op)
+}
+
+type maintenanceCallback struct {
+	l         *logger.Logger
+	schemaMap map[string]*stream
+}
+
+func setUpMaintenanceCallback(l *logger.Logger, schemaMap map[string]*stream) *maintenanceCallback {
+	return &maintenanceCallback{
+		l:         l,
+		schemaMap: schemaMap,
+	}
+}
+
+func (m *maintenanceCallback) Rev(message bus.Message) (resp bus.Message) {
+	if req, ok := message.Data().(*streamv1.StorageStatsRequest); ok {
+		return m.stats(message.ID(), req)
+	}
+	req, ok := message.Data().(*streamv1.MaintenanceRequest)
+	if !ok {
+		return bus.NewMessage(message.ID(), errors.WithStack(ErrMaintenanceMsg))
+	}
+	meta := req.GetMetadata()
+	sm, ok := m.schemaMap[formatStreamID(meta.GetName(), meta.GetGroup())]
+	if !ok {
+		return bus.NewMessage(message.ID(), errors.Wrapf(ErrStreamNotExist, "%s/%s", meta.GetGroup(), meta.GetName()))
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), maintenanceTimeout)
+	defer cancel()
+	start := time.Now()
+	if err := sm.maintain(ctx, req.GetOp()); err != nil {
+		m.l.Warn().Err(err).Str("stream", meta.GetName()).Str("op", req.GetOp().String()).Msg("failed to maintain")
+		return bus.NewMessage(message.ID(), err)
+	}
+	m.l.Info().Str("stream", meta.GetName()).Str("op", req.GetOp().String()).
+		Dur("elapsed", time.Since(start)).Msg("maintained")
+	return bus.NewMessage(message.ID(), &streamv1.MaintenanceResponse{})
+}
+
+// stats reports the footprint of the stream's storage, which is walked on each request
+func (m *maintenanceCallback) stats(id bus.MessageID, req *streamv1.StorageStatsRequest) bus.Message {
+	meta := req.GetMetadata()
+	sm, ok := m.schemaMap[formatStreamID(meta.GetName(), meta.GetGroup())]
+	if !ok {
+		return bus.NewMessage(id, errors.Wrapf(ErrStreamNotExist, "%s/%s", meta.GetGroup(), meta.GetName()))
+	}
+	stats, err := sm.db.Stats()
+	if err != nil {
+		m.l.Warn().Err(err).Str("stream", meta.GetName()).Msg("failed to collect the storage stats")
+		return bus.NewMessage(id, err)
+	}
+	resp := &streamv1.StorageStatsResponse{Shards: make([]*streamv1.ShardStorageStats, 0, len(stats.Shards))}
+	for _, s := range stats.Shards {
+		shard := &streamv1.ShardStorageStats{
+			Id:       uint64(s.ID),
+			Bytes:    s.Bytes,
+			Segments: uint32(s.Segments),
+			Blocks:   uint32(s.Blocks),
+		}
+		if !s.Oldest.IsZero() {
+			shard.Oldest = timestamppb.New(s.Oldest)
+			shard.Newest = timestamppb.New(s.Newest)
+		}
+		resp.Shards = append(resp.Shards, shard)
+	}
+	return bus.NewMessage(id, resp)
+}
@@ -18,6 +18,7 @@
 package stream
 
 import (
+	"context"
 	"io"
 
 	"github.com/golang/protobuf/proto"
@@ -29,7 +30,6 @@ import (
 	modelv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/model/v1"
 	streamv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/stream/v1"
 	"github.com/apache/skywalking-banyandb/banyand/tsdb"
-	"github.com/apache/skywalking-banyandb/pkg/partition"
 )
 
 var (
@@ -45,8 +45,16 @@ type Stream interface {
 	Write(value *streamv1.ElementValue) error
 	Shards(entity tsdb.Entity) ([]tsdb.Shard, error)
 	Shard(id common.ShardID) (tsdb.Shard, error)
+	// Snapshot pins the reads of all the shards to the current point in time
+	Snapshot() tsdb.Snapshot
 	ParseTagFamily(family string, item tsdb.Item) (*modelv1.TagFamily, error)
 	ParseElementID(item tsdb.Item) (string, error)
+	ExportColumnar(shardID common.ShardID, timeRange tsdb.TimeRange, w io.Writer, format ColumnarFormat) error
+	ImportColumnar(r io.Reader, format ColumnarFormat) (int, error)
+	// IndexRules returns the rules the indices are generated by, which the queries should filter with
+	IndexRules() []*databasev1.IndexRule
+	// Reindex rebuilds the indices against the rules in the background, and swaps them in once they're ready
+	Reindex(ctx context.Context, rules []*databasev1.IndexRule) (*ReindexJob, error)
 }
 
 var _ Stream = (*stream)(nil)
@@ -60,11 +68,11 @@ func (s *stream) Shards(entity tsdb.Entity) ([]tsdb.Shard, error) {
 			return s.db.Shards(), nil
 		}
 	}
-	shardID, err := partition.ShardID(entity.Marshal(), s.schema.GetOpts().GetShardNum())
+	shardID, err := s.shardOverrides.ShardID(entity, s.schema.GetOpts().GetShardNum(), s.shardingStrategy)
 	if err != nil {
 		return nil, err
 	}
-	shard, err := s.db.Shard(common.ShardID(shardID))
+	shard, err := s.db.Shard(shardID)
 	if err != nil {
 		return nil, err
 	}
@@ -75,6 +83,10 @@ func (s *stream) Shard(id common.ShardID) (tsdb.Shard, error) {
 	return s.db.Shard(id)
 }
 
+func (s *stream) Snapshot() tsdb.Snapshot {
+	return s.db.Snapshot()
+}
+
 func (s *stream) ParseTagFamily(family string, item tsdb.Item) (*modelv1.TagFamily, error) {
 	familyRawBytes, err := item.Family(family)
 	if err != nil {
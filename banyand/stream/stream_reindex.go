@@ -0,0 +1,277 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package stream
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+
+	databasev1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/database/v1"
+	modelv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/model/v1"
+	"github.com/apache/skywalking-banyandb/banyand/kv"
+	"github.com/apache/skywalking-banyandb/banyand/tsdb"
+	"github.com/apache/skywalking-banyandb/banyand/tsdb/index"
+	"github.com/apache/skywalking-banyandb/pkg/partition"
+)
+
+var (
+	ErrReindexInProgress = errors.New("another reindex of the stream is in progress")
+	// ErrReindexUnsupported is returned if the stream is opened without any index rule, whose blocks have no index stores
+	ErrReindexUnsupported = errors.New("the stream has no index store to reindex")
+)
+
+// ReindexProgress is a snapshot of the progress of a ReindexJob
+type ReindexProgress struct {
+	// TotalSeries is the number of the series whose items are indexed by the new rules
+	TotalSeries int
+	// Series is the number of the series done
+	Series int
+	// Items is the number of the items indexed
+	Items int
+	// Done is true once the new rules are swapped in, or the job fails
+	Done bool
+}
+
+// ReindexJob builds the indices of the new rules in the background
+type ReindexJob struct {
+	cancel context.CancelFunc
+	doneCh chan struct{}
+
+	mu       sync.RWMutex
+	progress ReindexProgress
+	err      error
+}
+
+func (j *ReindexJob) Progress() ReindexProgress {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+	return j.progress
+}
+
+// Cancel stops the job, which leaves the current rules serving
+func (j *ReindexJob) Cancel() {
+	j.cancel()
+}
+
+// Wait blocks until the job is done, and returns the reason if it fails
+func (j *ReindexJob) Wait() error {
+	<-j.doneCh
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+	return j.err
+}
+
+func (j *ReindexJob) update(fn func(p *ReindexProgress)) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	fn(&j.progress)
+}
+
+func (j *ReindexJob) finish(err error) {
+	j.mu.Lock()
+	j.err = err
+	j.progress.Done = true
+	j.mu.Unlock()
+	close(j.doneCh)
+}
+
+func (s *stream) IndexRules() []*databasev1.IndexRule {
+	s.rulesMu.RLock()
+	defer s.rulesMu.RUnlock()
+	return s.indexRules
+}
+
+// Reindex builds the indices of the rules for all the existing items in the background,
+// and swaps them in once it's done. Until then, the current rules keep serving the queries,
+// and the writes generate the indices of both. A failed or cancelled job leaves the current rules serving.
+// The job is cancelled along with ctx.
+func (s *stream) Reindex(ctx context.Context, rules []*databasev1.IndexRule) (*ReindexJob, error) {
+	s.rulesMu.Lock()
+	if s.reindexing {
+		s.rulesMu.Unlock()
+		return nil, errors.WithStack(ErrReindexInProgress)
+	}
+	if len(s.indexRules) < 1 {
+		s.rulesMu.Unlock()
+		return nil, errors.WithStack(ErrReindexUnsupported)
+	}
+	s.reindexing = true
+	current := s.indexRules
+	s.rulesMu.Unlock()
+
+	added := addedIndexRules(current, rules)
+	s.indexWriter.SetIndexRules(append(append([]*databasev1.IndexRule{}, current...), added...))
+	ctx, cancel := context.WithCancel(ctx)
+	j := &ReindexJob{
+		cancel: cancel,
+		doneCh: make(chan struct{}),
+	}
+	go func() {
+		defer cancel()
+		err := s.backfill(ctx, j, added)
+		s.rulesMu.Lock()
+		if err == nil {
+			s.indexRules = rules
+			s.indexWriter.SetIndexRules(rules)
+		} else {
+			s.indexWriter.SetIndexRules(current)
+		}
+		s.reindexing = false
+		s.rulesMu.Unlock()
+		if err != nil {
+			s.l.Warn().Err(err).Str("stream", s.name).Msg("failed to reindex")
+		} else {
+			s.l.Info().Str("stream", s.name).Int("rules", len(rules)).Msg("swapped the index rules in")
+		}
+		j.finish(err)
+	}()
+	return j, nil
+}
+
+// addedIndexRules returns the rules absent from or changed in the current ones
+func addedIndexRules(current, rules []*databasev1.IndexRule) (added []*databasev1.IndexRule) {
+	existing := make(map[uint32]*databasev1.IndexRule, len(current))
+	for _, rule := range current {
+		existing[rule.GetMetadata().GetId()] = rule
+	}
+	for _, rule := range rules {
+		if r, ok := existing[rule.GetMetadata().GetId()]; ok && proto.Equal(r, rule) {
+			continue
+		}
+		added = append(added, rule)
+	}
+	return added
+}
+
+// backfill generates the indices of the rules for the items written before the job starts.
+// The items written afterward might be indexed twice, which is harmless since an index entry is keyed by its item.
+func (s *stream) backfill(ctx context.Context, j *ReindexJob, rules []*databasev1.IndexRule) error {
+	if len(rules) < 1 {
+		return nil
+	}
+	locators := partition.ParseIndexRuleLocators(s.schema.GetTagFamilies(), rules)
+	path := tsdb.NewPath(make(tsdb.Entity, len(s.schema.GetEntity().GetTagNames())))
+	var seriesList []tsdb.Series
+	for _, shard := range s.db.Shards() {
+		list, err := shard.Series().List(path)
+		if err != nil {
+			return err
+		}
+		seriesList = append(seriesList, list...)
+	}
+	j.update(func(p *ReindexProgress) {
+		p.TotalSeries = len(seriesList)
+	})
+	for _, series := range seriesList {
+		if s.reindexHook != nil {
+			s.reindexHook()
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		n, err := s.backfillSeries(ctx, series, locators)
+		j.update(func(p *ReindexProgress) {
+			p.Items += n
+			p.Series++
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *stream) backfillSeries(ctx context.Context, series tsdb.Series, locators []*partition.IndexRuleLocator) (int, error) {
+	span, err := series.Span(tsdb.NewTimeRange(time.Unix(0, 0), time.Unix(0, math.MaxInt64)))
+	if err != nil {
+		if errors.Is(err, tsdb.ErrEmptySeriesSpan) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	defer func() {
+		_ = span.Close()
+	}()
+	seeker, err := span.SeekerBuilder().OrderByTime(modelv1.Sort_SORT_ASC).Build()
+	if err != nil {
+		return 0, err
+	}
+	iters, err := seeker.Seek()
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		for _, iter := range iters {
+			_ = iter.Close()
+		}
+	}()
+	var n int
+	for _, iter := range iters {
+		for iter.Next() {
+			if err = ctx.Err(); err != nil {
+				return n, err
+			}
+			if err = s.reindexItem(span, iter.Val(), locators); err != nil {
+				return n, err
+			}
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (s *stream) reindexItem(span tsdb.SeriesSpan, item tsdb.Item, locators []*partition.IndexRuleLocator) error {
+	families := make([]*modelv1.TagFamilyForWrite, len(s.schema.GetTagFamilies()))
+	for i, spec := range s.schema.GetTagFamilies() {
+		families[i] = &modelv1.TagFamilyForWrite{}
+		data, err := item.Family(spec.GetName())
+		if errors.Is(err, kv.ErrKeyNotFound) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if err = proto.Unmarshal(data, families[i]); err != nil {
+			return err
+		}
+	}
+	writer, err := span.IndexWriter(item.ID())
+	if err != nil {
+		return err
+	}
+	value := index.Value{
+		TagFamilies: families,
+		Timestamp:   time.Unix(0, int64(item.Time())),
+	}
+	for _, locator := range locators {
+		err = s.indexWriter.WriteIndex(writer, locator, value)
+		// the item doesn't have the tags of the rule
+		if errors.Is(err, partition.ErrInvalidFamilyOffset) || errors.Is(err, partition.ErrInvalidTagOffset) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,181 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package stream
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	commonv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/common/v1"
+	databasev1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/database/v1"
+	modelv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/model/v1"
+	streamv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/stream/v1"
+	"github.com/apache/skywalking-banyandb/banyand/tsdb"
+	"github.com/apache/skywalking-banyandb/pkg/convert"
+	"github.com/apache/skywalking-banyandb/pkg/index"
+)
+
+const reindexStartTime = 1622933202000000000
+
+func Test_Stream_Reindex(t *testing.T) {
+	tester := assert.New(t)
+	req := require.New(t)
+	s, deferFunc := setup(t)
+	defer deferFunc()
+	baseTime := setupQueryData(t, "multiple_shards.json", s)
+	timeRange := tsdb.NewTimeRangeDuration(baseTime, time.Hour)
+	currentRules := s.IndexRules()
+	var endpointRule *databasev1.IndexRule
+	for _, rule := range currentRules {
+		if rule.GetMetadata().GetName() == "endpoint_id" {
+			endpointRule = rule
+		}
+	}
+	req.NotNil(endpointRule)
+	startTimeRule := &databasev1.IndexRule{
+		Metadata: &commonv1.Metadata{
+			Name:  "start_time",
+			Group: "default",
+			Id:    20,
+		},
+		Tags:     []string{"start_time"},
+		Type:     databasev1.IndexRule_TYPE_TREE,
+		Location: databasev1.IndexRule_LOCATION_SERIES,
+	}
+	newRules := append(append([]*databasev1.IndexRule{}, currentRules...), startTimeRule)
+	count := func(rule *databasev1.IndexRule, tag string, value []byte) int {
+		got, err := queryData(tester, s, queryOpts{
+			entity:    tsdb.Entity{tsdb.AnyEntry, tsdb.AnyEntry, tsdb.AnyEntry},
+			timeRange: timeRange,
+			buildFn: func(builder tsdb.SeekerBuilder) {
+				builder.Filter(rule, tsdb.Condition{
+					tag: []index.ConditionValue{
+						{
+							Op:     modelv1.Condition_BINARY_OP_EQ,
+							Values: [][]byte{value},
+						},
+					},
+				})
+			},
+		})
+		req.NoError(err)
+		var n int
+		for _, shard := range got {
+			n += len(shard.elements)
+		}
+		return n
+	}
+	startedCh, resumeCh := make(chan struct{}), make(chan struct{})
+	var once sync.Once
+	s.reindexHook = func() {
+		once.Do(func() {
+			close(startedCh)
+			<-resumeCh
+		})
+	}
+
+	job, err := s.Reindex(context.Background(), newRules)
+	req.NoError(err)
+	<-startedCh
+	_, err = s.Reindex(context.Background(), newRules)
+	tester.ErrorIs(err, ErrReindexInProgress)
+	// the current rules keep serving the queries during the build
+	tester.Equal(currentRules, s.IndexRules())
+	tester.Equal(2, count(endpointRule, "endpoint_id", []byte("/home_id")))
+	progress := job.Progress()
+	tester.False(progress.Done)
+	tester.Zero(progress.Series)
+	tester.Greater(progress.TotalSeries, 0)
+	// the element written during the build is indexed by both the current and the new rules
+	req.NoError(s.Write(&streamv1.ElementValue{
+		ElementId: "6",
+		Timestamp: timestamppb.New(baseTime.Add(time.Minute)),
+		TagFamilies: []*modelv1.TagFamilyForWrite{
+			{},
+			{
+				Tags: []*modelv1.TagValue{
+					{Value: &modelv1.TagValue_Str{Str: &modelv1.Str{Value: "6"}}},
+					{Value: &modelv1.TagValue_Int{Int: &modelv1.Int{Value: 1}}},
+					{Value: &modelv1.TagValue_Str{Str: &modelv1.Str{Value: "webapp_id"}}},
+					{Value: &modelv1.TagValue_Str{Str: &modelv1.Str{Value: "10.0.0.9_id"}}},
+					{Value: &modelv1.TagValue_Str{Str: &modelv1.Str{Value: "/home_id"}}},
+					{Value: &modelv1.TagValue_Int{Int: &modelv1.Int{Value: 100}}},
+					{Value: &modelv1.TagValue_Int{Int: &modelv1.Int{Value: reindexStartTime}}},
+				},
+			},
+		},
+	}))
+	tester.Equal(3, count(endpointRule, "endpoint_id", []byte("/home_id")))
+	tester.Equal(1, count(startTimeRule, "start_time", convert.Int64ToBytes(reindexStartTime)),
+		"the new index is partial before the swap")
+	close(resumeCh)
+
+	req.NoError(job.Wait())
+	tester.Equal(newRules, s.IndexRules())
+	progress = job.Progress()
+	tester.True(progress.Done)
+	tester.Equal(progress.TotalSeries, progress.Series)
+	tester.GreaterOrEqual(progress.Items, 5)
+	tester.Equal(6, count(startTimeRule, "start_time", convert.Int64ToBytes(reindexStartTime)))
+}
+
+func Test_Stream_Reindex_Cancel(t *testing.T) {
+	tester := assert.New(t)
+	req := require.New(t)
+	s, deferFunc := setup(t)
+	defer deferFunc()
+	_ = setupQueryData(t, "multiple_shards.json", s)
+	currentRules := s.IndexRules()
+	startedCh, resumeCh := make(chan struct{}), make(chan struct{})
+	var once sync.Once
+	s.reindexHook = func() {
+		once.Do(func() {
+			close(startedCh)
+			<-resumeCh
+		})
+	}
+	job, err := s.Reindex(context.Background(), []*databasev1.IndexRule{{
+		Metadata: &commonv1.Metadata{
+			Name:  "start_time",
+			Group: "default",
+			Id:    20,
+		},
+		Tags:     []string{"start_time"},
+		Type:     databasev1.IndexRule_TYPE_TREE,
+		Location: databasev1.IndexRule_LOCATION_SERIES,
+	}})
+	req.NoError(err)
+	<-startedCh
+	job.Cancel()
+	close(resumeCh)
+	tester.ErrorIs(job.Wait(), context.Canceled)
+	tester.True(job.Progress().Done)
+	tester.Equal(currentRules, s.IndexRules(), "a cancelled job leaves the current rules serving")
+
+	// another job could start after the cancelled one
+	job, err = s.Reindex(context.Background(), currentRules)
+	req.NoError(err)
+	tester.NoError(job.Wait())
+	tester.Equal(currentRules, s.IndexRules())
+}
@@ -18,16 +18,20 @@
 package stream
 
 import (
+	"context"
+
 	"github.com/pkg/errors"
 	"google.golang.org/protobuf/proto"
 
 	"github.com/apache/skywalking-banyandb/api/common"
 	streamv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/stream/v1"
+	"github.com/apache/skywalking-banyandb/banyand/queue"
 	"github.com/apache/skywalking-banyandb/banyand/tsdb"
 	"github.com/apache/skywalking-banyandb/banyand/tsdb/index"
 	"github.com/apache/skywalking-banyandb/pkg/bus"
 	"github.com/apache/skywalking-banyandb/pkg/logger"
 	pbv1 "github.com/apache/skywalking-banyandb/pkg/pb/v1"
+	"github.com/apache/skywalking-banyandb/pkg/tracing"
 )
 
 var (
@@ -35,12 +39,15 @@ var (
 )
 
 func (s *stream) Write(value *streamv1.ElementValue) error {
-	entity, shardID, err := s.entityLocator.Locate(value.GetTagFamilies(), s.schema.GetOpts().GetShardNum())
+	entity, shardID, err := s.entityLocator.Locate(value.GetTagFamilies(), s.schema.GetOpts().GetShardNum(), s.shardOverrides,
+		s.shardingStrategy)
 	if err != nil {
 		return err
 	}
 	waitCh := make(chan struct{})
-	err = s.write(shardID, tsdb.HashEntity(entity), value, func() {
+	var errIndex error
+	err = s.write(shardID, tsdb.HashEntity(entity), value, false, func(errCb error) {
+		errIndex = errCb
 		close(waitCh)
 	})
 	if err != nil {
@@ -48,10 +55,13 @@ func (s *stream) Write(value *streamv1.ElementValue) error {
 		return err
 	}
 	<-waitCh
-	return nil
+	return errIndex
 }
 
-func (s *stream) write(shardID common.ShardID, seriesHashKey []byte, value *streamv1.ElementValue, cb index.CallbackFn) error {
+// write puts the element into the storage. If durable is true, the data is synced to the disk before generating indices.
+// cb is invoked after indices are generated.
+func (s *stream) write(shardID common.ShardID, seriesHashKey []byte, value *streamv1.ElementValue,
+	durable bool, cb index.CallbackFn) error {
 	sm := s.schema
 	fLen := len(value.GetTagFamilies())
 	if fLen < 1 {
@@ -64,9 +74,10 @@ func (s *stream) write(shardID common.ShardID, seriesHashKey []byte, value *stre
 	if err != nil {
 		return err
 	}
+	// the failures of the storage are marked to be counted by the breaker of the queue
 	series, err := shard.Series().GetByHashKey(seriesHashKey)
 	if err != nil {
-		return err
+		return queue.StorageFailure(err)
 	}
 	t := value.GetTimestamp().AsTime()
 	wp, err := series.Span(tsdb.NewTimeRangeDuration(t, 0))
@@ -74,7 +85,7 @@ func (s *stream) write(shardID common.ShardID, seriesHashKey []byte, value *stre
 		if wp != nil {
 			_ = wp.Close()
 		}
-		return err
+		return queue.StorageFailure(err)
 	}
 	writeFn := func() (tsdb.Writer, error) {
 		builder := wp.WriterBuilder().Time(t)
@@ -105,6 +116,10 @@ func (s *stream) write(shardID common.ShardID, seriesHashKey []byte, value *stre
 			return nil, errWrite
 		}
 		_, errWrite = writer.Write()
+		if errWrite == nil && durable {
+			errWrite = writer.Sync()
+		}
+		errWrite = queue.StorageFailure(errWrite)
 		s.l.Debug().
 			Time("ts", t).
 			Int("ts_nano", t.Nanosecond()).
@@ -153,9 +168,33 @@ func (w *writeCallback) Rev(message bus.Message) (resp bus.Message) {
 	}
 	sm := writeEvent.GetRequest().GetMetadata()
 	id := formatStreamID(sm.GetName(), sm.GetGroup())
-	err := w.schemaMap[id].write(common.ShardID(writeEvent.GetShardId()), writeEvent.GetSeriesHash(), writeEvent.GetRequest().GetElement(), nil)
+	durable := writeEvent.GetRequest().GetAckLevel() == streamv1.AckLevel_ACK_LEVEL_DURABLE
+	ctx, span := tracing.Start(tracing.Extract(context.Background(), message.Header()), "stream.write")
+	defer span.End()
+	var cb index.CallbackFn
+	var waitCh chan struct{}
+	var errIndex error
+	if durable {
+		// a durable write is flushed to the disk and indexed before being acknowledged
+		_, flushSpan := tracing.Start(ctx, "stream.flush")
+		defer flushSpan.End()
+		waitCh = make(chan struct{})
+		cb = func(errCb error) {
+			errIndex = errCb
+			close(waitCh)
+		}
+	}
+	err := w.schemaMap[id].write(common.ShardID(writeEvent.GetShardId()), writeEvent.GetSeriesHash(),
+		writeEvent.GetRequest().GetElement(), durable, cb)
+	if durable && err == nil {
+		<-waitCh
+		// the write discarded by the closed index writer isn't indexed
+		err = queue.StorageFailure(errIndex)
+	}
 	if err != nil {
-		w.l.Debug().Err(err)
+		w.l.Debug().Err(err).Str("trace_id", tracing.TraceID(ctx)).Msg("failed to write an element")
+		span.RecordError(err)
+		return bus.NewMessage(message.ID(), err)
 	}
 	return
 }
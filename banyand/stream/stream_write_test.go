@@ -21,17 +21,27 @@ import (
 	"context"
 	"encoding/base64"
 	"os"
+	"strconv"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	commonv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/common/v1"
+	databasev1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/database/v1"
 	modelv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/model/v1"
 	streamv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/stream/v1"
 	"github.com/apache/skywalking-banyandb/banyand/metadata"
+	"github.com/apache/skywalking-banyandb/banyand/tsdb"
+	"github.com/apache/skywalking-banyandb/pkg/bus"
+	"github.com/apache/skywalking-banyandb/pkg/index"
 	"github.com/apache/skywalking-banyandb/pkg/logger"
+	"github.com/apache/skywalking-banyandb/pkg/partition"
 	"github.com/apache/skywalking-banyandb/pkg/test"
 	teststream "github.com/apache/skywalking-banyandb/pkg/test/stream"
 )
@@ -184,6 +194,306 @@ func Test_Stream_Write(t *testing.T) {
 
 }
 
+func Test_Stream_DurableWrite(t *testing.T) {
+	tester := require.New(t)
+	s, deferFunc := setup(t)
+	defer deferFunc()
+
+	ele := getEle(
+		"trace_id-xxfff.111323",
+		0,
+		"webapp_id",
+		"10.0.0.1_id",
+		"/home_id",
+		300,
+		1622933202000000000,
+	)
+	ele.Timestamp = timestamppb.Now()
+	entity, shardID, err := s.entityLocator.Locate(ele.GetTagFamilies(), s.schema.GetOpts().GetShardNum(), s.shardOverrides,
+		s.shardingStrategy)
+	tester.NoError(err)
+	wcb := setUpWriteCallback(logger.GetLogger("test"), map[string]*stream{
+		formatStreamID(s.name, s.group): s,
+	})
+	resp := wcb.Rev(bus.NewMessage(bus.MessageID(1), &streamv1.InternalWriteRequest{
+		ShardId:    uint32(shardID),
+		SeriesHash: tsdb.HashEntity(entity),
+		Request: &streamv1.WriteRequest{
+			Metadata: s.schema.GetMetadata(),
+			Element:  ele,
+			AckLevel: streamv1.AckLevel_ACK_LEVEL_DURABLE,
+		},
+	}))
+	tester.Nil(resp.Data())
+}
+
+func Test_Stream_MaintenanceFlush(t *testing.T) {
+	tester := require.New(t)
+	s, deferFunc := setup(t)
+	defer deferFunc()
+
+	traceID := "trace_id-maintenance.flush"
+	ele := getEle(
+		traceID,
+		0,
+		"webapp_id",
+		"10.0.0.1_id",
+		"/home_id",
+		300,
+		1622933202000000000,
+	)
+	ele.Timestamp = timestamppb.Now()
+	entity, shardID, err := s.entityLocator.Locate(ele.GetTagFamilies(), s.schema.GetOpts().GetShardNum(), s.shardOverrides,
+		s.shardingStrategy)
+	tester.NoError(err)
+	schemaMap := map[string]*stream{
+		formatStreamID(s.name, s.group): s,
+	}
+	wcb := setUpWriteCallback(logger.GetLogger("test"), schemaMap)
+	resp := wcb.Rev(bus.NewMessage(bus.MessageID(1), &streamv1.InternalWriteRequest{
+		ShardId:    uint32(shardID),
+		SeriesHash: tsdb.HashEntity(entity),
+		Request: &streamv1.WriteRequest{
+			Metadata: s.schema.GetMetadata(),
+			Element:  ele,
+			AckLevel: streamv1.AckLevel_ACK_LEVEL_QUEUED,
+		},
+	}))
+	tester.Nil(resp.Data())
+
+	mcb := setUpMaintenanceCallback(logger.GetLogger("test"), schemaMap)
+	resp = mcb.Rev(bus.NewMessage(bus.MessageID(2), &streamv1.MaintenanceRequest{
+		Metadata: s.schema.GetMetadata(),
+		Op:       streamv1.MaintenanceOp_MAINTENANCE_OP_FLUSH,
+	}))
+	tester.IsType(&streamv1.MaintenanceResponse{}, resp.Data())
+
+	shard, err := s.Shard(shardID)
+	tester.NoError(err)
+	itemIDs, err := shard.Index().Seek(index.Field{
+		Key: index.FieldKey{
+			//trace_id
+			IndexRuleID: 10,
+		},
+		Term: []byte(traceID),
+	})
+	tester.NoError(err)
+	tester.Len(itemIDs, 1)
+
+	resp = mcb.Rev(bus.NewMessage(bus.MessageID(3), &streamv1.MaintenanceRequest{
+		Metadata: &commonv1.Metadata{Group: s.group, Name: "unknown"},
+		Op:       streamv1.MaintenanceOp_MAINTENANCE_OP_FLUSH,
+	}))
+	err, ok := resp.Data().(error)
+	tester.True(ok)
+	tester.ErrorIs(err, ErrStreamNotExist)
+
+	resp = mcb.Rev(bus.NewMessage(bus.MessageID(4), &streamv1.StorageStatsRequest{Metadata: s.schema.GetMetadata()}))
+	stats, ok := resp.Data().(*streamv1.StorageStatsResponse)
+	tester.True(ok)
+	tester.Len(stats.GetShards(), int(s.schema.GetOpts().GetShardNum()))
+	for _, shardStats := range stats.GetShards() {
+		tester.Greater(shardStats.GetBytes(), int64(0))
+		tester.NotNil(shardStats.GetOldest())
+	}
+}
+
+func Test_Stream_StorageEngine(t *testing.T) {
+	s, deferFunc := setup(t)
+	defer deferFunc()
+	tests := []struct {
+		engine databasev1.StorageEngine
+		want   []string
+	}{
+		{
+			engine: databasev1.StorageEngine_STORAGE_ENGINE_UNSPECIFIED,
+			want:   []string{"second", "third"},
+		},
+		{
+			engine: databasev1.StorageEngine_STORAGE_ENGINE_OVERWRITE,
+			want:   []string{"second", "third"},
+		},
+		{
+			engine: databasev1.StorageEngine_STORAGE_ENGINE_APPEND,
+			want:   []string{"first", "second", "third"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.engine.String(), func(t *testing.T) {
+			tester := require.New(t)
+			tempDir, deferSpace := test.Space(tester)
+			defer deferSpace()
+			schema := proto.Clone(s.schema).(*databasev1.Stream)
+			schema.Metadata.Group = strings.ToLower(tt.engine.String())
+			schema.Opts.StorageEngine = tt.engine
+			sm, err := openStream(tempDir, streamSpec{
+				schema:     schema,
+				indexRules: s.indexRules,
+			}, logger.GetLogger("test"))
+			tester.NoError(err)
+			defer func() {
+				_ = sm.Close()
+			}()
+
+			now := timestamppb.Now()
+			// the third one is written at the id the second one would be moved to
+			times := map[string]*timestamppb.Timestamp{
+				"first":  now,
+				"second": now,
+				"third":  timestamppb.New(now.AsTime().Add(time.Nanosecond)),
+			}
+			for _, id := range []string{"first", "second", "third"} {
+				ele := getEle("trace_id-xxfff.111323", 0, "webapp_id", "10.0.0.1_id", "/home_id", 300, 1622933202000000000)
+				ele.ElementId = id
+				ele.Timestamp = times[id]
+				tester.NoError(sm.Write(ele))
+			}
+
+			entity, shardID, err := sm.entityLocator.Locate(getEle("trace_id-xxfff.111323", 0, "webapp_id", "10.0.0.1_id").GetTagFamilies(),
+				schema.GetOpts().GetShardNum(), sm.shardOverrides, sm.shardingStrategy)
+			tester.NoError(err)
+			shard, err := sm.Shard(shardID)
+			tester.NoError(err)
+			series, err := shard.Series().Get(entity)
+			tester.NoError(err)
+			span, err := series.Span(tsdb.NewTimeRangeDuration(now.AsTime(), time.Second))
+			tester.NoError(err)
+			defer func() {
+				_ = span.Close()
+			}()
+			seeker, err := span.SeekerBuilder().OrderByTime(modelv1.Sort_SORT_ASC).Build()
+			tester.NoError(err)
+			iters, err := seeker.Seek()
+			tester.NoError(err)
+			var got []string
+			for _, iter := range iters {
+				for iter.Next() {
+					id, errID := sm.ParseElementID(iter.Val())
+					tester.NoError(errID)
+					got = append(got, id)
+					// the appended items keep the time they're written at
+					tester.Equal(uint64(times[id].AsTime().UnixNano()), iter.Val().Time())
+				}
+				tester.NoError(iter.Close())
+			}
+			tester.Equal(tt.want, got)
+		})
+	}
+}
+
+func Test_Stream_EncodingMethod(t *testing.T) {
+	s, deferFunc := setup(t)
+	defer deferFunc()
+	tester := require.New(t)
+	tempDir, deferSpace := test.Space(tester)
+	defer deferSpace()
+	schema := proto.Clone(s.schema).(*databasev1.Stream)
+	schema.Metadata.Group = "gorilla"
+	schema.Opts.EncodingMethod = databasev1.EncodingMethod_ENCODING_METHOD_GORILLA
+	sm, err := openStream(tempDir, streamSpec{
+		schema:     schema,
+		indexRules: s.indexRules,
+	}, logger.GetLogger("test"))
+	tester.NoError(err)
+	defer func() {
+		_ = sm.Close()
+	}()
+
+	now := timestamppb.Now()
+	ele := getEle("trace_id-xxfff.111323", 0, "webapp_id", "10.0.0.1_id", "/home_id", 300, 1622933202000000000)
+	ele.ElementId = "gorilla"
+	ele.Timestamp = now
+	tester.NoError(sm.Write(ele))
+	tester.NoError(sm.db.Flush())
+
+	entity, shardID, err := sm.entityLocator.Locate(ele.GetTagFamilies(),
+		schema.GetOpts().GetShardNum(), sm.shardOverrides, sm.shardingStrategy)
+	tester.NoError(err)
+	shard, err := sm.Shard(shardID)
+	tester.NoError(err)
+	series, err := shard.Series().Get(entity)
+	tester.NoError(err)
+	span, err := series.Span(tsdb.NewTimeRangeDuration(now.AsTime(), time.Second))
+	tester.NoError(err)
+	defer func() {
+		_ = span.Close()
+	}()
+	seeker, err := span.SeekerBuilder().OrderByTime(modelv1.Sort_SORT_ASC).Build()
+	tester.NoError(err)
+	iters, err := seeker.Seek()
+	tester.NoError(err)
+	var got []string
+	for _, iter := range iters {
+		for iter.Next() {
+			id, errID := sm.ParseElementID(iter.Val())
+			tester.NoError(errID)
+			got = append(got, id)
+		}
+		tester.NoError(iter.Close())
+	}
+	tester.Equal([]string{"gorilla"}, got)
+
+	// an unknown method is rejected
+	schema.Opts.EncodingMethod = databasev1.EncodingMethod(99)
+	_, err = openStream(tempDir+"-unknown", streamSpec{
+		schema:     schema,
+		indexRules: s.indexRules,
+	}, logger.GetLogger("test"))
+	tester.Error(err)
+}
+
+func Test_Stream_CloseDrainsIndices(t *testing.T) {
+	s, deferFunc := setup(t)
+	defer deferFunc()
+	tester := require.New(t)
+	tempDir, deferSpace := test.Space(tester)
+	defer deferSpace()
+	schema := proto.Clone(s.schema).(*databasev1.Stream)
+	schema.Metadata.Group = "drained"
+	sm, err := openStream(tempDir, streamSpec{
+		schema:     schema,
+		indexRules: s.indexRules,
+	}, logger.GetLogger("test"))
+	tester.NoError(err)
+
+	const num = 100
+	var indexed int64
+	now := time.Now()
+	for i := 0; i < num; i++ {
+		ele := getEle("trace_id-xxfff.111323", 0, "webapp_id", "10.0.0.1_id", "/home_id", 300, 1622933202000000000,
+			"GET", "200", "mysql", "10.0.0.2:3306", "queue", "topic", "broker")
+		ele.ElementId = strconv.Itoa(i)
+		ele.Timestamp = timestamppb.New(now.Add(time.Duration(i) * time.Millisecond))
+		entity, shardID, errLocate := sm.entityLocator.Locate(ele.GetTagFamilies(),
+			schema.GetOpts().GetShardNum(), sm.shardOverrides, sm.shardingStrategy)
+		tester.NoError(errLocate)
+		// the indices are generated in the background
+		tester.NoError(sm.write(shardID, tsdb.HashEntity(entity), ele, false, func(errIndex error) {
+			if errIndex == nil {
+				atomic.AddInt64(&indexed, 1)
+			}
+		}))
+	}
+	// all the pending indices are generated before the database is closed
+	tester.NoError(sm.Close())
+	tester.Equal(int64(num), atomic.LoadInt64(&indexed))
+}
+
+func Test_Stream_UnresolvedEntityTag(t *testing.T) {
+	s, deferFunc := setup(t)
+	defer deferFunc()
+	tester := require.New(t)
+	tempDir, deferSpace := test.Space(tester)
+	defer deferSpace()
+	schema := proto.Clone(s.schema).(*databasev1.Stream)
+	schema.Entity.TagNames = append(schema.Entity.TagNames, "absent")
+	_, err := openStream(tempDir, streamSpec{
+		schema:     schema,
+		indexRules: s.indexRules,
+	}, logger.GetLogger("test"))
+	tester.ErrorIs(err, partition.ErrInvalidEntityLocator)
+}
+
 func setup(t *testing.T) (*stream, func()) {
 	req := require.New(t)
 	req.NoError(logger.Init(logger.Logging{
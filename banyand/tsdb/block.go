@@ -20,10 +20,14 @@ package tsdb
 import (
 	"context"
 	"io"
+	"os"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/dgraph-io/ristretto/z"
 	"github.com/pkg/errors"
+	"go.uber.org/multierr"
 
 	"github.com/apache/skywalking-banyandb/api/common"
 	databasev1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/database/v1"
@@ -34,6 +38,9 @@ import (
 	"github.com/apache/skywalking-banyandb/pkg/logger"
 )
 
+// purgedStoreSuffix marks the data store replaced by a purge until it's removed
+const purgedStoreSuffix = ".purged"
+
 type block struct {
 	path string
 	l    *logger.Logger
@@ -46,23 +53,62 @@ type block struct {
 	closableLst   []io.Closer
 	endTime       time.Time
 	startTime     time.Time
+	sealedAt      time.Time
+	grace         time.Duration
 	segID         uint16
 	blockID       uint16
+	// segment is pinned by the readers of the block as well, which defers its removal
+	segment    *segment
+	sealLock   sync.RWMutex
+	appendOnly bool
+	// appendLock serializes the appending writes to find free timestamps
+	appendLock sync.Mutex
+	written    writtenRange
+	onFlush    flushHook
+	stats      *blockStats
+	snapshots  *shardSnapshots
+	// writeLock is shared by all the blocks of a shard
+	writeLock *writeLock
+	// syncOnWrite syncs the block before each write returns
+	syncOnWrite bool
+	sequence    *blockSequence
+	// shardSequence is shared by all the blocks of a shard as well
+	shardSequence *shardSequence
+
+	encodingMethod EncodingMethod
+	indexed        bool
+	// openLock guards the stores against offloading and fetching the block
+	openLock   sync.Mutex
+	blockStore *blockStoreRef
+	// offloaded blocks keep their files in the BlockStore only, and they're fetched on demand
+	offloaded bool
+	// uploaded is 1 if the BlockStore has a complete copy of the block, and the writes after uploading reset it
+	uploaded int32
+	// fetchedAt is when the block is fetched from the BlockStore last time
+	fetchedAt time.Time
+	// mergedInto is the block this one is merged into, which hands out the delegates instead of this one
+	mergedInto *block
 }
 
 type blockOpts struct {
-	segID   uint16
-	blockID uint16
-	path    string
+	segID     uint16
+	blockID   uint16
+	segment   *segment
+	path      string
+	startTime time.Time
+	// endTime bounds an unsealed block, and the zero one leaves it open
+	endTime time.Time
 }
 
 func newBlock(ctx context.Context, opts blockOpts) (b *block, err error) {
 	b = &block{
 		segID:     opts.segID,
 		blockID:   opts.blockID,
+		segment:   opts.segment,
 		path:      opts.path,
 		ref:       z.NewCloser(1),
-		startTime: time.Now(),
+		startTime: opts.startTime,
+		endTime:   opts.endTime,
 	}
 	parentLogger := ctx.Value(logger.ContextKey)
 	if parentLogger != nil {
@@ -70,51 +116,181 @@ func newBlock(ctx context.Context, opts blockOpts) (b *block, err error) {
 			b.l = pl.Named("block")
 		}
 	}
+	if hook, ok := ctx.Value(flushHookKey).(flushHook); ok {
+		b.onFlush = hook
+	}
+	if snapshots, ok := ctx.Value(snapshotsKey).(*shardSnapshots); ok {
+		b.snapshots = snapshots
+	}
+	if lock, ok := ctx.Value(writeLockKey).(*writeLock); ok {
+		b.writeLock = lock
+	}
+	if seq, ok := ctx.Value(sequenceKey).(*shardSequence); ok {
+		b.shardSequence = seq
+	}
+	if durability, ok := ctx.Value(durabilityKey).(Durability); ok {
+		b.syncOnWrite = durability.mode == durabilityPerWrite
+	}
+	if engine, ok := ctx.Value(storageEngineKey).(databasev1.StorageEngine); ok {
+		b.appendOnly = engine == databasev1.StorageEngine_STORAGE_ENGINE_APPEND
+	}
+	if ref, ok := ctx.Value(blockStoreKey).(*blockStoreRef); ok {
+		b.blockStore = ref
+	}
 	encodingMethodObject := ctx.Value(encodingMethodKey)
 	if encodingMethodObject == nil {
 		return nil, errors.Wrap(ErrEncodingMethodAbsent, "failed to create a block")
 	}
-	encodingMethod := encodingMethodObject.(EncodingMethod)
-	if b.store, err = kv.OpenTimeSeriesStore(
-		0,
-		b.path+"/store",
-		kv.TSSWithEncoding(encodingMethod.EncoderPool, encodingMethod.DecoderPool),
-		kv.TSSWithLogger(b.l),
-	); err != nil {
+	b.encodingMethod = encodingMethodObject.(EncodingMethod)
+	rules, ok := ctx.Value(indexRulesKey).([]*databasev1.IndexRule)
+	b.indexed = ok && len(rules) > 0
+	if err = b.open(); err != nil {
 		return nil, err
 	}
+	if size, ok := ctx.Value(preallocateKey).(int64); ok && size > 0 {
+		if err = preallocate(b.path+"/store", size); err != nil {
+			if !errors.Is(err, errPreallocateUnsupported) {
+				return nil, err
+			}
+			b.l.Warn().Err(err).Str("path", b.path).Msg("fall back to normal writes")
+		}
+	}
+	return b, nil
+}
+
+// open opens the stores and indexes of the block in its local directory
+func (b *block) open() (err error) {
+	if err = b.restoreStore(); err != nil {
+		return err
+	}
+	_, errStat := os.Stat(b.path + "/store")
+	if b.stats, err = openBlockStats(b.path, errors.Is(errStat, os.ErrNotExist)); err != nil {
+		return err
+	}
+	if b.sequence, err = openBlockSequence(b.path); err != nil {
+		return err
+	}
+	if b.shardSequence != nil {
+		b.shardSequence.observe(b.sequence.applied)
+	}
+	b.closableLst = nil
+	if b.store, err = kv.OpenTimeSeriesStore(0, b.path+"/store", b.storeOptions()...); err != nil {
+		return err
+	}
 	if b.primaryIndex, err = lsm.NewStore(lsm.StoreOpts{
 		Path:   b.path + "/primary",
 		Logger: b.l,
 	}); err != nil {
-		return nil, err
+		return err
 	}
 	b.closableLst = append(b.closableLst, b.store, b.primaryIndex)
-	rules, ok := ctx.Value(indexRulesKey).([]*databasev1.IndexRule)
-	if !ok || len(rules) == 0 {
-		return b, nil
+	if !b.indexed {
+		return nil
 	}
 	if b.invertedIndex, err = inverted.NewStore(inverted.StoreOpts{
 		Path:   b.path + "/inverted",
 		Logger: b.l,
 	}); err != nil {
-		return nil, err
+		return err
 	}
 	if b.lsmIndex, err = lsm.NewStore(lsm.StoreOpts{
 		Path:   b.path + "/lsm",
 		Logger: b.l,
 	}); err != nil {
-		return nil, err
+		return err
 	}
 	b.closableLst = append(b.closableLst, b.invertedIndex, b.lsmIndex)
-	return b, err
+	return nil
+}
+
+func (b *block) storeOptions() []kv.TimeSeriesOptions {
+	return []kv.TimeSeriesOptions{
+		kv.TSSWithEncoding(b.encodingMethod.EncoderPool, b.encodingMethod.DecoderPool),
+		kv.TSSWithLogger(b.l),
+	}
 }
 
-func (b *block) delegate() blockDelegate {
+// restoreStore moves the data store back if a purge crashed after moving it away,
+// otherwise it removes the one left behind by the purge
+func (b *block) restoreStore() error {
+	storePath := b.path + "/store"
+	if _, err := os.Stat(storePath); !errors.Is(err, os.ErrNotExist) {
+		return errors.Wrapf(os.RemoveAll(storePath+purgedStoreSuffix), "failed to remove the purged store of %s", b.path)
+	}
+	if _, err := os.Stat(storePath + purgedStoreSuffix); err != nil {
+		return nil
+	}
+	return errors.Wrapf(os.Rename(storePath+purgedStoreSuffix, storePath), "failed to restore the store of %s", b.path)
+}
+
+// purge rewrites the data store of the block without the values drop returns true for, and returns the number of
+// the dropped values. It waits for the readers to release the block, and skips the offloaded block.
+func (b *block) purge(drop func(key []byte, ts uint64) bool) (int, error) {
+	b.openLock.Lock()
+	defer b.openLock.Unlock()
+	if b.offloaded || b.mergedInto != nil {
+		return 0, nil
+	}
+	b.closeStores()
+	n, err := b.rewriteStore(drop)
+	if n > 0 {
+		atomic.StoreInt32(&b.uploaded, 0)
+	}
+	return n, multierr.Append(err, b.reopen())
+}
+
+func (b *block) rewriteStore(drop func(key []byte, ts uint64) bool) (int, error) {
+	storePath := b.path + "/store"
+	tmpPath := storePath + tempDirSuffix
+	if err := os.RemoveAll(tmpPath); err != nil {
+		return 0, errors.Wrapf(err, "failed to remove %s", tmpPath)
+	}
+	n, err := kv.RewriteTimeSeriesStore(storePath, tmpPath, drop, b.storeOptions()...)
+	if err != nil || n == 0 {
+		return 0, multierr.Append(err, os.RemoveAll(tmpPath))
+	}
+	if err = os.Rename(storePath, storePath+purgedStoreSuffix); err != nil {
+		return 0, multierr.Append(errors.Wrapf(err, "failed to move %s", storePath), os.RemoveAll(tmpPath))
+	}
+	if err = os.Rename(tmpPath, storePath); err != nil {
+		return 0, multierr.Append(errors.Wrapf(err, "failed to move %s", tmpPath), b.restoreStore())
+	}
+	return n, errors.Wrapf(os.RemoveAll(storePath+purgedStoreSuffix), "failed to remove the purged store of %s", b.path)
+}
+
+func (b *block) seal(endTime time.Time, grace time.Duration) {
+	b.sealLock.Lock()
+	defer b.sealLock.Unlock()
+	b.endTime = endTime
+	b.sealedAt = time.Now()
+	b.grace = grace
+}
+
+// delegate fetches the offloaded block from the BlockStore before handing it out,
+// and a merged block hands out the one it's merged into.
+// It fails with ErrExpiredItem if the segment of the block has been removed.
+func (b *block) delegate() (blockDelegate, error) {
+	if b.segment != nil && !b.segment.incRef() {
+		return nil, ErrExpiredItem
+	}
+	b.openLock.Lock()
+	if merged := b.mergedInto; merged != nil {
+		b.openLock.Unlock()
+		if err := b.releaseSegment(); err != nil {
+			return nil, err
+		}
+		return merged.delegate()
+	}
+	defer b.openLock.Unlock()
+	if b.offloaded {
+		if err := b.fetch(); err != nil {
+			return nil, multierr.Append(err, b.releaseSegment())
+		}
+	}
 	b.incRef()
 	return &bDelegate{
 		delegate: b,
-	}
+	}, nil
 }
 
 func (b *block) dscRef() {
@@ -125,18 +301,187 @@ func (b *block) incRef() {
 	b.ref.AddRunning(1)
 }
 
+// releaseSegment unpins the segment, which removes it if it's expired and this is the last reader
+func (b *block) releaseSegment() error {
+	if b.segment == nil {
+		return nil
+	}
+	return b.segment.dscRef()
+}
+
 func (b *block) close() {
+	b.openLock.Lock()
+	defer b.openLock.Unlock()
+	if b.offloaded || b.mergedInto != nil {
+		return
+	}
+	b.closeStores()
+}
+
+// closeStores waits for the readers to release the block, and then flushes and closes its stores
+func (b *block) closeStores() {
 	b.dscRef()
 	b.ref.SignalAndWait()
+	// the inverted index keeps the fields in the memory until they're flushed
+	if flusher, ok := b.invertedIndex.(interface{ Flush() error }); ok {
+		if err := flusher.Flush(); err != nil {
+			b.l.Warn().Err(err).Str("path", b.path).Msg("failed to flush the inverted index of the block")
+		}
+	}
 	for _, closer := range b.closableLst {
 		_ = closer.Close()
 	}
+	// closing the store flushes all the written data
+	if err := b.stats.persist(); err != nil {
+		b.l.Warn().Err(err).Str("path", b.path).Msg("failed to persist the statistics of the block")
+	}
+	if err := b.sequence.persist(); err != nil {
+		b.l.Warn().Err(err).Str("path", b.path).Msg("failed to persist the sequence of the block")
+	}
+	b.notifyFlush()
+}
+
+// offloadable reports whether the block is sealed and its grace period is over, which makes it immutable
+func (b *block) offloadable(now time.Time) bool {
+	b.sealLock.RLock()
+	defer b.sealLock.RUnlock()
+	return !b.sealedAt.IsZero() && !now.Before(b.sealedAt.Add(b.grace))
+}
+
+// endedBy reports whether all the data of the block is older than deadline
+func (b *block) endedBy(deadline time.Time) bool {
+	b.sealLock.RLock()
+	defer b.sealLock.RUnlock()
+	return !b.endTime.IsZero() && !b.endTime.After(deadline)
+}
+
+// offload moves the files of the block to the BlockStore, and returns false if the block stays local.
+// A block fetched within the cache duration of the BlockStore stays local as well.
+func (b *block) offload(ctx context.Context) (bool, error) {
+	if b.blockStore == nil {
+		return false, nil
+	}
+	b.openLock.Lock()
+	defer b.openLock.Unlock()
+	if b.offloaded || b.mergedInto != nil || b.blockStore.clock.Now().Before(b.fetchedAt.Add(b.blockStore.cacheDuration)) {
+		return false, nil
+	}
+	b.closeStores()
+	if atomic.LoadInt32(&b.uploaded) == 0 {
+		key, err := b.blockStore.key(b.path)
+		if err == nil {
+			err = uploadBlock(ctx, b.blockStore.store, key, b.path)
+		}
+		if err != nil {
+			return false, multierr.Append(errors.WithMessagef(err, "failed to upload block %s", b.path), b.reopen())
+		}
+		// the copy in the store stays valid after the block is fetched again, until the block is written
+		atomic.StoreInt32(&b.uploaded, 1)
+	}
+	if err := os.RemoveAll(b.path); err != nil {
+		return false, multierr.Append(errors.Wrapf(err, "failed to remove %s", b.path), b.reopen())
+	}
+	b.offloaded = true
+	b.l.Info().Str("path", b.path).Msg("offloaded a block")
+	return true, nil
+}
+
+// fetch downloads the offloaded block from the BlockStore and opens it
+func (b *block) fetch() error {
+	key, err := b.blockStore.key(b.path)
+	if err != nil {
+		return err
+	}
+	if err = downloadBlock(context.Background(), b.blockStore.store, key, b.path); err != nil {
+		return errors.WithMessagef(err, "failed to fetch block %s", b.path)
+	}
+	if err = b.reopen(); err != nil {
+		return err
+	}
+	b.offloaded = false
+	b.fetchedAt = b.blockStore.clock.Now()
+	b.l.Info().Str("path", b.path).Msg("fetched an offloaded block")
+	return nil
+}
+
+func (b *block) reopen() error {
+	b.ref = z.NewCloser(1)
+	return b.open()
+}
+
+// overlaps reports whether the block's time range intersects with timeRange, which is inclusive at both ends
+func (b *block) overlaps(timeRange TimeRange) bool {
+	b.sealLock.RLock()
+	defer b.sealLock.RUnlock()
+	if b.startTime.After(timeRange.End) {
+		return false
+	}
+	return b.endTime.IsZero() || b.endTime.After(timeRange.Start)
+}
+
+// window returns the time range of the block, whose End is zero if the block is open
+func (b *block) window() TimeRange {
+	b.sealLock.RLock()
+	defer b.sealLock.RUnlock()
+	return NewTimeRange(b.startTime, b.endTime)
+}
+
+func (b *block) contains(ts time.Time) bool {
+	b.sealLock.RLock()
+	defer b.sealLock.RUnlock()
+	greaterAndEqualStart := b.startTime.Equal(ts) || b.startTime.Before(ts)
+	if b.endTime.IsZero() {
+		return greaterAndEqualStart
+	}
+	return greaterAndEqualStart && b.endTime.After(ts)
+}
+
+func (b *block) isOffloaded() bool {
+	b.openLock.Lock()
+	defer b.openLock.Unlock()
+	return b.offloaded
+}
+
+func (b *block) notifyFlush() {
+	if b.onFlush == nil {
+		return
+	}
+	timeRange, ok := b.written.get()
+	if !ok {
+		return
+	}
+	b.onFlush(FlushEvent{
+		SegmentID: b.segID,
+		BlockID:   b.blockID,
+		TimeRange: timeRange,
+	})
+}
+
+// pinnedBlocks tells apart the blocks pinned by a list of delegates. The merged blocks of a list taken
+// before the merge hand out the same block they're merged into.
+type pinnedBlocks map[*block]struct{}
+
+// pin returns false if the block of d is pinned already
+func (p pinnedBlocks) pin(d blockDelegate) bool {
+	bd, ok := d.(*bDelegate)
+	if !ok {
+		return true
+	}
+	if _, ok = p[bd.delegate]; ok {
+		return false
+	}
+	p[bd.delegate] = struct{}{}
+	return true
 }
 
 type blockDelegate interface {
 	io.Closer
 	contains(ts time.Time) bool
+	overlaps(timeRange TimeRange) bool
+	writable() bool
 	write(key []byte, val []byte, ts time.Time) error
+	sync() error
+	compact() error
 	writePrimaryIndex(field index.Field, id common.ItemID) error
 	writeLSMIndex(field index.Field, id common.ItemID) error
 	writeInvertedIndex(field index.Field, id common.ItemID) error
@@ -145,7 +490,32 @@ type blockDelegate interface {
 	invertedIndexReader() index.Searcher
 	primaryIndexReader() index.Searcher
 	identity() (segID uint16, blockID uint16)
+	// appendOnly is true if the writes colliding with an item of the series are appended rather than overwriting it
+	appendOnly() bool
+	// lockAppend returns false if the block overwrites items, otherwise it locks the appending writes
+	lockAppend() bool
+	unlockAppend()
+	// lockWrite blocks the flushes of the shard until unlockWrite, and fails if the context is done during a flush
+	lockWrite(ctx context.Context) error
+	unlockWrite()
+	// track hides the new item from the active snapshots, it's called before writing the item.
+	// exists reports whether the item is written before, which keeps the overwritten item visible.
+	track(id GlobalItemID, exists func() bool)
 	startTime() time.Time
+	// timeRange returns the time range of the block, whose End is zero if the block is open
+	timeRange() TimeRange
+	// recordStats adds the numeric columns of an item to the statistics of the block before it's written
+	recordStats(seriesID common.SeriesID, ts time.Time, columns map[string]int64) error
+	// invalidateStats drops the statistics of a series whose item fails to be written
+	invalidateStats(seriesID common.SeriesID) error
+	stats() *blockStats
+	// assignSequence returns the next sequence of the shard if seq is zero.
+	// A replayed seq fails with ErrSequenceApplied if the block has applied it.
+	assignSequence(seq uint64) (uint64, error)
+	// applySequence records seq once its write succeeds
+	applySequence(seq uint64)
+	// syncOnWrite is true if the writes should sync the block before they return
+	syncOnWrite() bool
 }
 
 var _ blockDelegate = (*bDelegate)(nil)
@@ -174,12 +544,112 @@ func (d *bDelegate) startTime() time.Time {
 	return d.delegate.startTime
 }
 
+func (d *bDelegate) timeRange() TimeRange {
+	return d.delegate.window()
+}
+
+func (d *bDelegate) recordStats(seriesID common.SeriesID, ts time.Time, columns map[string]int64) error {
+	return d.delegate.stats.record(seriesID, ts, columns, !d.delegate.appendOnly)
+}
+
+func (d *bDelegate) invalidateStats(seriesID common.SeriesID) error {
+	return d.delegate.stats.invalidate(seriesID)
+}
+
+func (d *bDelegate) stats() *blockStats {
+	return d.delegate.stats
+}
+
+func (d *bDelegate) assignSequence(seq uint64) (uint64, error) {
+	if d.delegate.shardSequence == nil {
+		return seq, nil
+	}
+	if seq == 0 {
+		return d.delegate.shardSequence.next(), nil
+	}
+	if d.delegate.sequence.isApplied(seq) {
+		return seq, errors.WithMessagef(ErrSequenceApplied, "sequence:%d", seq)
+	}
+	d.delegate.shardSequence.observe(seq)
+	return seq, nil
+}
+
+func (d *bDelegate) applySequence(seq uint64) {
+	d.delegate.sequence.apply(seq)
+}
+
 func (d *bDelegate) identity() (segID uint16, blockID uint16) {
 	return d.delegate.segID, d.delegate.blockID
 }
 
 func (d *bDelegate) write(key []byte, val []byte, ts time.Time) error {
-	return d.delegate.store.Put(key, val, uint64(ts.UnixNano()))
+	if err := d.delegate.store.Put(key, val, uint64(ts.UnixNano())); err != nil {
+		return err
+	}
+	d.delegate.written.update(ts)
+	atomic.StoreInt32(&d.delegate.uploaded, 0)
+	return nil
+}
+
+func (d *bDelegate) appendOnly() bool {
+	return d.delegate.appendOnly
+}
+
+func (d *bDelegate) lockAppend() bool {
+	if !d.delegate.appendOnly {
+		return false
+	}
+	d.delegate.appendLock.Lock()
+	return true
+}
+
+func (d *bDelegate) unlockAppend() {
+	d.delegate.appendLock.Unlock()
+}
+
+func (d *bDelegate) lockWrite(ctx context.Context) error {
+	if d.delegate.writeLock == nil {
+		return nil
+	}
+	return d.delegate.writeLock.lockWrite(ctx)
+}
+
+func (d *bDelegate) unlockWrite() {
+	if d.delegate.writeLock != nil {
+		d.delegate.writeLock.unlockWrite()
+	}
+}
+
+func (d *bDelegate) track(id GlobalItemID, exists func() bool) {
+	if d.delegate.snapshots != nil {
+		d.delegate.snapshots.track(id, exists)
+	}
+}
+
+func (d *bDelegate) syncOnWrite() bool {
+	return d.delegate.syncOnWrite
+}
+
+func (d *bDelegate) sync() error {
+	if d.delegate.writeLock != nil {
+		d.delegate.writeLock.lockFlush()
+		defer d.delegate.writeLock.unlockFlush()
+	}
+	if err := d.delegate.store.Sync(); err != nil {
+		return err
+	}
+	if err := d.delegate.stats.persist(); err != nil {
+		return err
+	}
+	if err := d.delegate.sequence.persist(); err != nil {
+		return err
+	}
+	d.delegate.notifyFlush()
+	return nil
+}
+
+func (d *bDelegate) compact() error {
+	return d.delegate.store.Compact()
 }
 
 func (d *bDelegate) writePrimaryIndex(field index.Field, id common.ItemID) error {
@@ -200,15 +670,24 @@ func (d *bDelegate) writeInvertedIndex(field index.Field, id common.ItemID) erro
 	return d.delegate.invertedIndex.Write(field, id)
 }
 
-func (d *bDelegate) contains(ts time.Time) bool {
-	greaterAndEqualStart := d.delegate.startTime.Equal(ts) || d.delegate.startTime.Before(ts)
-	if d.delegate.endTime.IsZero() {
-		return greaterAndEqualStart
+func (d *bDelegate) writable() bool {
+	d.delegate.sealLock.RLock()
+	defer d.delegate.sealLock.RUnlock()
+	if d.delegate.sealedAt.IsZero() {
+		return true
 	}
-	return greaterAndEqualStart && d.delegate.endTime.After(ts)
+	return time.Now().Before(d.delegate.sealedAt.Add(d.delegate.grace))
+}
+
+func (d *bDelegate) contains(ts time.Time) bool {
+	return d.delegate.contains(ts)
+}
+
+func (d *bDelegate) overlaps(timeRange TimeRange) bool {
+	return d.delegate.overlaps(timeRange)
 }
 
 func (d *bDelegate) Close() error {
 	d.delegate.dscRef()
-	return nil
+	return d.delegate.releaseSegment()
 }
@@ -0,0 +1,226 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tsdb
+
+import (
+	"container/list"
+	"context"
+	"hash/crc32"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+)
+
+// blockCacheDirSuffix names the default location of the block cache after the database's location
+const blockCacheDirSuffix = "-block-cache"
+
+var ErrInvalidBlockCacheSize = errors.New("the size of the block cache should be positive")
+
+var _ BlockStore = (*BlockCache)(nil)
+
+// BlockCache keeps the files fetched from a BlockStore on the local disk, which saves the refetches of the
+// repeated queries on the offloaded blocks. The least recently used files are evicted once the total size
+// exceeds the capacity. A cached file is served only if it matches the checksum taken on fetching it.
+type BlockCache struct {
+	store    BlockStore
+	dir      string
+	capacity int64
+
+	mu      sync.Mutex
+	size    int64
+	lru     *list.List
+	entries map[string]*list.Element
+
+	hits   uint64
+	misses uint64
+}
+
+type blockCacheEntry struct {
+	name     string
+	size     int64
+	checksum uint32
+}
+
+// NewBlockCache caches the files of store under dir up to capacity bytes.
+// The entries left by the previous process are dropped since their checksums are unknown.
+func NewBlockCache(store BlockStore, dir string, capacity int64) (*BlockCache, error) {
+	if capacity <= 0 {
+		return nil, ErrInvalidBlockCacheSize
+	}
+	if err := os.RemoveAll(dir); err != nil {
+		return nil, err
+	}
+	if _, err := mkdir(dir); err != nil {
+		return nil, err
+	}
+	return &BlockCache{
+		store:    store,
+		dir:      dir,
+		capacity: capacity,
+		lru:      list.New(),
+		entries:  make(map[string]*list.Element),
+	}, nil
+}
+
+// Stats returns the number of the Gets served by the cache and the ones falling through to the BlockStore
+func (c *BlockCache) Stats() (hits, misses uint64) {
+	return atomic.LoadUint64(&c.hits), atomic.LoadUint64(&c.misses)
+}
+
+// Size returns the total size of the cached files
+func (c *BlockCache) Size() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.size
+}
+
+func (c *BlockCache) path(name string) string {
+	return filepath.Join(c.dir, filepath.FromSlash(name))
+}
+
+// Put writes through to the BlockStore, and drops the stale copy of the file
+func (c *BlockCache) Put(ctx context.Context, name string, r io.Reader) error {
+	c.mu.Lock()
+	c.removeLocked(name)
+	c.mu.Unlock()
+	return c.store.Put(ctx, name, r)
+}
+
+func (c *BlockCache) Get(ctx context.Context, name string) (io.ReadCloser, error) {
+	if f, ok := c.lookup(name); ok {
+		atomic.AddUint64(&c.hits, 1)
+		return f, nil
+	}
+	atomic.AddUint64(&c.misses, 1)
+	return c.populate(ctx, name)
+}
+
+func (c *BlockCache) Delete(ctx context.Context, prefix string) error {
+	c.mu.Lock()
+	for name := range c.entries {
+		if strings.HasPrefix(name, prefix) {
+			c.removeLocked(name)
+		}
+	}
+	c.mu.Unlock()
+	return c.store.Delete(ctx, prefix)
+}
+
+// lookup opens the cached file after verifying its checksum. A corrupt one is evicted.
+func (c *BlockCache) lookup(name string) (*os.File, bool) {
+	c.mu.Lock()
+	e, ok := c.entries[name]
+	if !ok {
+		c.mu.Unlock()
+		return nil, false
+	}
+	c.lru.MoveToFront(e)
+	entry := e.Value.(*blockCacheEntry)
+	c.mu.Unlock()
+	f, err := os.Open(c.path(name))
+	if err == nil {
+		var sum uint32
+		if sum, err = fileChecksum(f); err == nil && sum == entry.checksum {
+			if _, err = f.Seek(0, io.SeekStart); err == nil {
+				return f, true
+			}
+		}
+		_ = f.Close()
+	}
+	c.mu.Lock()
+	// the entry might be replaced concurrently
+	if e, ok = c.entries[name]; ok && e.Value.(*blockCacheEntry) == entry {
+		c.removeLocked(name)
+	}
+	c.mu.Unlock()
+	return nil, false
+}
+
+// populate fetches the file from the BlockStore into the cache, and then serves it from the cache.
+// A file larger than the capacity is served by the BlockStore directly.
+func (c *BlockCache) populate(ctx context.Context, name string) (io.ReadCloser, error) {
+	r, err := c.store.Get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	path := c.path(name)
+	if err = os.MkdirAll(filepath.Dir(path), dirPerm); err != nil {
+		return nil, err
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+"*"+tempDirSuffix)
+	if err != nil {
+		return nil, err
+	}
+	hash := crc32.NewIEEE()
+	size, err := io.Copy(io.MultiWriter(tmp, hash), r)
+	if err == nil {
+		_, err = tmp.Seek(0, io.SeekStart)
+	}
+	if err != nil {
+		return nil, multierr.Combine(err, tmp.Close(), os.Remove(tmp.Name()))
+	}
+	if size > c.capacity {
+		// the unlinked file is still readable until it's closed
+		return tmp, os.Remove(tmp.Name())
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.removeLocked(name)
+	if err = os.Rename(tmp.Name(), path); err != nil {
+		return nil, multierr.Combine(err, tmp.Close(), os.Remove(tmp.Name()))
+	}
+	c.entries[name] = c.lru.PushFront(&blockCacheEntry{
+		name:     name,
+		size:     size,
+		checksum: hash.Sum32(),
+	})
+	c.size += size
+	for c.size > c.capacity {
+		c.removeLocked(c.lru.Back().Value.(*blockCacheEntry).name)
+	}
+	return tmp, nil
+}
+
+func (c *BlockCache) removeLocked(name string) {
+	e, ok := c.entries[name]
+	if !ok {
+		return
+	}
+	entry := e.Value.(*blockCacheEntry)
+	c.lru.Remove(e)
+	delete(c.entries, name)
+	c.size -= entry.size
+	// the readers of the evicted file are able to go on reading it
+	_ = os.Remove(c.path(name))
+}
+
+func fileChecksum(f *os.File) (uint32, error) {
+	hash := crc32.NewIEEE()
+	if _, err := io.Copy(hash, f); err != nil {
+		return 0, err
+	}
+	return hash.Sum32(), nil
+}
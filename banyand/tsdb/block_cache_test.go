@@ -0,0 +1,157 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tsdb
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/apache/skywalking-banyandb/pkg/test"
+)
+
+func Test_BlockCache(t *testing.T) {
+	req := require.New(t)
+	tempDir, removeSpace := test.Space(req)
+	defer removeSpace()
+	ctx := context.Background()
+	store := newMemBlockStore()
+	for _, name := range []string{"shard-0/a", "shard-0/b", "shard-0/c"} {
+		req.NoError(store.Put(ctx, name, strings.NewReader(strings.Repeat(name[len(name)-1:], 10))))
+	}
+	req.NoError(store.Put(ctx, "shard-0/large", strings.NewReader(strings.Repeat("l", 30))))
+	cache, err := NewBlockCache(store, filepath.Join(tempDir, "cache"), 25)
+	req.NoError(err)
+	read := func(name string) string {
+		r, errGet := cache.Get(ctx, name)
+		req.NoError(errGet)
+		defer r.Close()
+		data, errRead := ioutil.ReadAll(r)
+		req.NoError(errRead)
+		return string(data)
+	}
+
+	// the second read is served locally
+	req.Equal(strings.Repeat("a", 10), read("shard-0/a"))
+	req.Equal(strings.Repeat("a", 10), read("shard-0/a"))
+	_, _, gets := store.counts()
+	req.Equal(1, gets)
+	hits, misses := cache.Stats()
+	req.Equal(uint64(1), hits)
+	req.Equal(uint64(1), misses)
+
+	// the least recently used file is evicted once the size exceeds the capacity
+	req.Equal(strings.Repeat("b", 10), read("shard-0/b"))
+	req.Equal(strings.Repeat("a", 10), read("shard-0/a"))
+	req.Equal(strings.Repeat("c", 10), read("shard-0/c"))
+	req.Equal(int64(20), cache.Size())
+	req.Equal(strings.Repeat("a", 10), read("shard-0/a"))
+	req.Equal(strings.Repeat("b", 10), read("shard-0/b"))
+	hits, misses = cache.Stats()
+	req.Equal(uint64(3), hits)
+	req.Equal(uint64(4), misses)
+	req.LessOrEqual(cache.Size(), int64(25))
+
+	// a file larger than the capacity is never cached
+	req.Equal(strings.Repeat("l", 30), read("shard-0/large"))
+	req.Equal(strings.Repeat("l", 30), read("shard-0/large"))
+	_, misses = cache.Stats()
+	req.Equal(uint64(6), misses)
+	req.LessOrEqual(cache.Size(), int64(25))
+
+	// a corrupt file is fetched again
+	req.NoError(ioutil.WriteFile(cache.path("shard-0/b"), []byte(strings.Repeat("x", 10)), 0600))
+	req.Equal(strings.Repeat("b", 10), read("shard-0/b"))
+	_, misses = cache.Stats()
+	req.Equal(uint64(7), misses)
+	req.Equal(strings.Repeat("b", 10), read("shard-0/b"))
+	_, moreMisses := cache.Stats()
+	req.Equal(misses, moreMisses)
+
+	// the writes and deletes drop the stale copies
+	req.NoError(cache.Put(ctx, "shard-0/b", strings.NewReader(strings.Repeat("B", 10))))
+	req.Equal(strings.Repeat("B", 10), read("shard-0/b"))
+	req.NoError(cache.Delete(ctx, "shard-0/"))
+	req.Zero(cache.Size())
+	_, err = cache.Get(ctx, "shard-0/b")
+	req.ErrorIs(err, ErrBlockFileNotFound)
+}
+
+func Test_Database_BlockCache(t *testing.T) {
+	tester := require.New(t)
+	store := newMemBlockStore()
+	var cacheLocation string
+	_, deferFunc, db := setUpWithOpts(tester, func(opts *DatabaseOpts) {
+		opts.BlockStore = store
+		opts.BlockCacheSize = 1 << 30
+		cacheLocation = opts.Location + blockCacheDirSuffix
+	})
+	defer func() {
+		deferFunc()
+		tester.NoError(os.RemoveAll(cacheLocation))
+	}()
+	s, err := db.Shard(0)
+	tester.NoError(err)
+	series, err := s.Series().Get(Entity{Entry("productpage"), Entry("10.0.0.1")})
+	tester.NoError(err)
+	now := time.Now()
+	span, err := series.Span(NewTimeRangeDuration(now, time.Hour))
+	tester.NoError(err)
+	writer, err := span.WriterBuilder().
+		Family([]byte("searchable"), []byte("cached")).
+		Time(now).
+		Build()
+	tester.NoError(err)
+	id, err := writer.Write()
+	tester.NoError(err)
+	tester.NoError(span.Close())
+	_, err = s.(*shard).segmentController.seal(now.Add(24 * time.Hour))
+	tester.NoError(err)
+	read := func() {
+		n, errOffload := db.Offload()
+		tester.NoError(errOffload)
+		tester.Equal(1, n)
+		item, closer, errGet := series.Get(id)
+		tester.NoError(errGet)
+		defer func() {
+			tester.NoError(closer.Close())
+		}()
+		v, errFamily := item.Family("searchable")
+		tester.NoError(errFamily)
+		tester.Equal([]byte("cached"), v)
+	}
+	cache := s.(*shard).segmentController.ctx.Value(blockStoreKey).(*blockStoreRef).store.(*BlockCache)
+
+	read()
+	_, _, gets := store.counts()
+	tester.NotZero(gets)
+	hits, _ := cache.Stats()
+	tester.Zero(hits)
+	// fetching the offloaded block again never reaches the remote store
+	read()
+	_, _, moreGets := store.counts()
+	tester.Equal(gets, moreGets)
+	hits, _ = cache.Stats()
+	tester.NotZero(hits)
+}
@@ -0,0 +1,261 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tsdb
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/apache/skywalking-banyandb/api/common"
+	"github.com/apache/skywalking-banyandb/banyand/kv"
+)
+
+// blockManifest is the file holding the statistics of a block, which is written on every flush.
+// It's removed before the block is written again, so the statistics are never staler than the data on the disk.
+const blockManifest = "manifest.json"
+
+// ColumnStats summarizes the values of a numeric column written into a block
+type ColumnStats struct {
+	Min   int64 `json:"min"`
+	Max   int64 `json:"max"`
+	Sum   int64 `json:"sum"`
+	Count int64 `json:"count"`
+}
+
+func (c *ColumnStats) add(v int64) {
+	if c.Count < 1 || v < c.Min {
+		c.Min = v
+	}
+	if c.Count < 1 || v > c.Max {
+		c.Max = v
+	}
+	c.Sum += v
+	c.Count++
+}
+
+// BlockStats is the statistics of a column of a series in a block
+type BlockStats struct {
+	// TimeRange is the part of the span served by the block
+	TimeRange TimeRange
+	// Written is the time range of the series' items in the block
+	Written TimeRange
+	// Column is nil if the statistics are unavailable, and the items in TimeRange have to be scanned instead
+	Column *ColumnStats
+}
+
+type seriesStats struct {
+	// Start and End bound the items' timestamps in nanoseconds, and End is exclusive
+	Start   int64                   `json:"start"`
+	End     int64                   `json:"end"`
+	Invalid bool                    `json:"invalid,omitempty"`
+	Columns map[string]*ColumnStats `json:"columns,omitempty"`
+}
+
+// blockStats tracks the statistics of the series in a block.
+// A block opened without a manifest leaves untracked true, whose items might be missing in the statistics.
+type blockStats struct {
+	sync.Mutex
+	path      string
+	untracked bool
+	persisted bool
+	Series    map[common.SeriesID]*seriesStats `json:"series"`
+}
+
+func openBlockStats(blockPath string, isNew bool) (*blockStats, error) {
+	s := &blockStats{
+		path:   blockPath + "/" + blockManifest,
+		Series: make(map[common.SeriesID]*seriesStats),
+	}
+	data, err := ioutil.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		s.untracked = !isNew
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(data, s); err != nil {
+		// the statistics are rebuilt from nothing, but the block is still readable
+		s.untracked = true
+		s.Series = make(map[common.SeriesID]*seriesStats)
+		return s, nil
+	}
+	s.persisted = true
+	return s, nil
+}
+
+// record adds an item to the statistics. In a block overwriting items, an item at or before the latest one
+// might replace an existing one, which invalidates the statistics of the series.
+func (s *blockStats) record(seriesID common.SeriesID, ts time.Time, columns map[string]int64, overwrites bool) error {
+	s.Lock()
+	defer s.Unlock()
+	if err := s.dirty(); err != nil {
+		return err
+	}
+	unixNano := ts.UnixNano()
+	ss, ok := s.Series[seriesID]
+	if !ok {
+		ss = &seriesStats{Start: unixNano, End: unixNano + 1}
+		s.Series[seriesID] = ss
+	} else if overwrites && unixNano < ss.End {
+		ss.Invalid = true
+	}
+	if unixNano < ss.Start {
+		ss.Start = unixNano
+	}
+	if unixNano >= ss.End {
+		ss.End = unixNano + 1
+	}
+	if ss.Invalid {
+		ss.Columns = nil
+		return nil
+	}
+	for name, v := range columns {
+		if ss.Columns == nil {
+			ss.Columns = make(map[string]*ColumnStats, len(columns))
+		}
+		c, ok := ss.Columns[name]
+		if !ok {
+			c = &ColumnStats{}
+			ss.Columns[name] = c
+		}
+		c.add(v)
+	}
+	return nil
+}
+
+func (s *blockStats) invalidate(seriesID common.SeriesID) error {
+	s.Lock()
+	defer s.Unlock()
+	if err := s.dirty(); err != nil {
+		return err
+	}
+	s.Series[seriesID] = &seriesStats{Invalid: true}
+	return nil
+}
+
+// dirty removes the persisted manifest before the block changes
+func (s *blockStats) dirty() error {
+	if !s.persisted {
+		return nil
+	}
+	if err := os.Remove(s.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	s.persisted = false
+	return nil
+}
+
+// persist writes the manifest after the data is flushed
+func (s *blockStats) persist() error {
+	s.Lock()
+	defer s.Unlock()
+	if s.persisted || s.untracked {
+		return nil
+	}
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	tmp := s.path + ".tmp"
+	if err = ioutil.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	if err = os.Rename(tmp, s.path); err != nil {
+		return err
+	}
+	s.persisted = true
+	return nil
+}
+
+// get returns the statistics of a column of a series. ok is false if they're unavailable.
+// A series absent from a tracked block has no items in it.
+func (s *blockStats) get(seriesID common.SeriesID, column string) (written TimeRange, stats ColumnStats, ok bool) {
+	s.Lock()
+	defer s.Unlock()
+	if s.untracked {
+		return TimeRange{}, ColumnStats{}, false
+	}
+	ss, exist := s.Series[seriesID]
+	if !exist {
+		return TimeRange{}, ColumnStats{}, true
+	}
+	if ss.Invalid {
+		return TimeRange{}, ColumnStats{}, false
+	}
+	written = NewTimeRange(time.Unix(0, ss.Start), time.Unix(0, ss.End))
+	if c, exist := ss.Columns[column]; exist {
+		stats = *c
+	}
+	return written, stats, true
+}
+
+// Stats returns the statistics of a column in the blocks overlapping the span.
+// The items deleted by tombstones make the statistics of their blocks unavailable.
+func (s *seriesSpan) Stats(column string) ([]BlockStats, error) {
+	result := make([]BlockStats, 0, len(s.blocks))
+	for _, b := range s.blocks {
+		if !b.overlaps(s.timeRange) {
+			continue
+		}
+		timeRange := b.timeRange()
+		if timeRange.Start.Before(s.timeRange.Start) {
+			timeRange.Start = s.timeRange.Start
+		}
+		if timeRange.End.IsZero() || timeRange.End.After(s.timeRange.End) {
+			timeRange.End = s.timeRange.End
+		}
+		if !timeRange.Start.Before(timeRange.End) {
+			continue
+		}
+		bs := BlockStats{TimeRange: timeRange}
+		written, stats, ok := b.stats().get(s.seriesID, column)
+		if ok && stats.Count > 0 {
+			deleted, err := s.hasTombstones(written)
+			if err != nil {
+				return nil, err
+			}
+			ok = !deleted
+		}
+		if ok {
+			bs.Written = written
+			bs.Column = &stats
+		}
+		result = append(result, bs)
+	}
+	return result, nil
+}
+
+func (s *seriesSpan) hasTombstones(timeRange TimeRange) (bool, error) {
+	var found bool
+	upper := tombstoneKey(s.seriesID, common.ItemID(timeRange.End.UnixNano()))
+	err := s.tombstones.Scan(tombstoneKey(s.seriesID, common.ItemID(timeRange.Start.UnixNano())), kv.ScanOpts{},
+		func(_ int, key []byte, _ func() ([]byte, error)) error {
+			if bytes.Compare(key, upper) < 0 {
+				found = true
+			}
+			return kv.ErrStopScan
+		})
+	return found, err
+}
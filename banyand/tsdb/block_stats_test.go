@@ -0,0 +1,104 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tsdb
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Database_BlockStats(t *testing.T) {
+	req := require.New(t)
+	ctx, opts, removeSpace := setUpOpts(req, nil)
+	defer removeSpace()
+	tempDir := opts.Location
+	db, err := OpenDatabase(ctx, opts)
+	req.NoError(err)
+	entity := Entity{Entry("productpage"), Entry("10.0.0.1")}
+	ts := time.Now()
+	timeRange := NewTimeRangeDuration(ts.Add(-time.Hour), 2*time.Hour)
+	stats := func(db Database) *ColumnStats {
+		shard, errShard := db.Shard(0)
+		req.NoError(errShard)
+		series, errSeries := shard.Series().Get(entity)
+		req.NoError(errSeries)
+		span, errSpan := series.Span(timeRange)
+		req.NoError(errSpan)
+		defer func() {
+			req.NoError(span.Close())
+		}()
+		blocks, errStats := span.Stats("latency")
+		req.NoError(errStats)
+		req.Len(blocks, 1)
+		return blocks[0].Column
+	}
+	shard, err := db.Shard(0)
+	req.NoError(err)
+	series, err := shard.Series().Get(entity)
+	req.NoError(err)
+	span, err := series.Span(timeRange)
+	req.NoError(err)
+	for i, latency := range []int64{30, 10, 20} {
+		writer, errWriter := span.WriterBuilder().
+			Family([]byte("latency"), []byte{byte(latency)}).
+			Stat("latency", latency).
+			Time(ts.Add(time.Duration(i) * time.Millisecond)).
+			Build()
+		req.NoError(errWriter)
+		_, errWriter = writer.Write()
+		req.NoError(errWriter)
+	}
+	req.NoError(span.Close())
+	expected := &ColumnStats{Min: 10, Max: 30, Sum: 60, Count: 3}
+	req.Equal(expected, stats(db))
+
+	// a deleted item makes the statistics unavailable
+	_, err = db.DeleteByQuery(context.TODO(), DeleteCriteria{
+		Entity:    entity,
+		TimeRange: NewTimeRangeDuration(ts, time.Millisecond),
+	})
+	req.NoError(err)
+	req.Nil(stats(db))
+
+	// closing the block persists the statistics into its manifest
+	req.NoError(db.Close())
+	blockPaths, err := filepath.Glob(filepath.Join(tempDir, "shard-0", "seg-*", "block-*"))
+	req.NoError(err)
+	req.Len(blockPaths, 1)
+	loaded, err := openBlockStats(blockPaths[0], false)
+	req.NoError(err)
+	req.False(loaded.untracked)
+	_, column, ok := loaded.get(series.ID(), "latency")
+	req.True(ok)
+	req.Equal(*expected, column)
+
+	// the manifest is removed before the block is written again
+	req.NoError(loaded.record(series.ID(), ts.Add(time.Second), map[string]int64{"latency": 40}, true))
+	_, err = os.Stat(filepath.Join(blockPaths[0], blockManifest))
+	req.True(errors.Is(err, os.ErrNotExist))
+	loaded, err = openBlockStats(blockPaths[0], false)
+	req.NoError(err)
+	_, _, ok = loaded.get(series.ID(), "latency")
+	req.False(ok, "a block without a manifest has to be scanned")
+}
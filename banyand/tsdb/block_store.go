@@ -0,0 +1,218 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tsdb
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+)
+
+// blockStoreManifest lists the files of a block in the BlockStore. It's put after all the files,
+// so a block without it is partially uploaded.
+const blockStoreManifest = "block.manifest"
+
+var ErrBlockFileNotFound = errors.New("the file of the block is not found in the block store")
+
+// BlockStore persists the files of the sealed blocks out of the local disk, e.g. in an object storage.
+// A file is named by its path relative to the location of the database, which is separated by slashes.
+type BlockStore interface {
+	Put(ctx context.Context, name string, r io.Reader) error
+	// Get fails with ErrBlockFileNotFound if the file is absent
+	Get(ctx context.Context, name string) (io.ReadCloser, error)
+	// Delete removes the files whose names start with prefix
+	Delete(ctx context.Context, prefix string) error
+}
+
+// blockStoreRef tells the blocks where to offload their files
+type blockStoreRef struct {
+	store    BlockStore
+	location string
+	// cacheDuration keeps a fetched block local for a while before offloading it again
+	cacheDuration time.Duration
+	clock         clockwork.Clock
+}
+
+// key returns the prefix of the files of the block at blockPath
+func (r *blockStoreRef) key(blockPath string) (string, error) {
+	rel, err := filepath.Rel(r.location, blockPath)
+	if err != nil {
+		return "", err
+	}
+	return filepath.ToSlash(rel), nil
+}
+
+var _ BlockStore = (*localBlockStore)(nil)
+
+type localBlockStore struct {
+	root string
+}
+
+// NewLocalBlockStore keeps the files of the offloaded blocks under root, which might be a mounted network file system
+func NewLocalBlockStore(root string) BlockStore {
+	return &localBlockStore{root: root}
+}
+
+func (l *localBlockStore) path(name string) string {
+	return filepath.Join(l.root, filepath.FromSlash(name))
+}
+
+func (l *localBlockStore) Put(_ context.Context, name string, r io.Reader) error {
+	path := l.path(name)
+	if err := os.MkdirAll(filepath.Dir(path), dirPerm); err != nil {
+		return err
+	}
+	tmp := path + tempDirSuffix
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(f, r); err != nil {
+		return multierr.Combine(err, f.Close())
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+func (l *localBlockStore) Get(_ context.Context, name string) (io.ReadCloser, error) {
+	f, err := os.Open(l.path(name))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, errors.Wrap(ErrBlockFileNotFound, name)
+	}
+	return f, err
+}
+
+func (l *localBlockStore) Delete(_ context.Context, prefix string) error {
+	path := l.path(prefix)
+	if strings.HasSuffix(prefix, "/") {
+		return os.RemoveAll(path)
+	}
+	matches, err := filepath.Glob(path + "*")
+	if err != nil {
+		return err
+	}
+	for _, m := range matches {
+		err = multierr.Append(err, os.RemoveAll(m))
+	}
+	return err
+}
+
+// uploadBlock puts all the files of the block in dir, and then the manifest of them
+func uploadBlock(ctx context.Context, store BlockStore, key string, dir string) error {
+	var files []string
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, errWalk error) error {
+		if errWalk != nil || info.IsDir() {
+			return errWalk
+		}
+		rel, errRel := filepath.Rel(dir, path)
+		if errRel != nil {
+			return errRel
+		}
+		f, errOpen := os.Open(path)
+		if errOpen != nil {
+			return errOpen
+		}
+		defer f.Close()
+		name := filepath.ToSlash(rel)
+		if errPut := store.Put(ctx, key+"/"+name, f); errPut != nil {
+			return errors.WithMessagef(errPut, "failed to upload %s", path)
+		}
+		files = append(files, name)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	manifest, err := json.Marshal(files)
+	if err != nil {
+		return err
+	}
+	return store.Put(ctx, key+"/"+blockStoreManifest, strings.NewReader(string(manifest)))
+}
+
+// downloadBlock gets the files of the block into dir, which is created from scratch.
+// The files are written into a temporary directory first, so dir is either complete or absent.
+// The temporary directory is staged until it's renamed, which keeps the orphan cleaner off it.
+func downloadBlock(ctx context.Context, store BlockStore, key string, dir string) error {
+	files, err := readBlockManifest(ctx, store, key)
+	if err != nil {
+		return err
+	}
+	tmp := dir + tempDirSuffix
+	defer stageTempDir(tmp)()
+	if err = os.RemoveAll(tmp); err != nil {
+		return err
+	}
+	for _, name := range files {
+		if err = downloadFile(ctx, store, key+"/"+name, filepath.Join(tmp, filepath.FromSlash(name))); err != nil {
+			return multierr.Append(err, os.RemoveAll(tmp))
+		}
+	}
+	// an empty block has no file at all
+	if err = os.MkdirAll(tmp, dirPerm); err != nil {
+		return err
+	}
+	return os.Rename(tmp, dir)
+}
+
+func readBlockManifest(ctx context.Context, store BlockStore, key string) ([]string, error) {
+	r, err := store.Get(ctx, key+"/"+blockStoreManifest)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	if err = json.Unmarshal(data, &files); err != nil {
+		return nil, errors.Wrapf(err, "malformed manifest of block %s", key)
+	}
+	return files, nil
+}
+
+func downloadFile(ctx context.Context, store BlockStore, name string, path string) error {
+	r, err := store.Get(ctx, name)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	if err = os.MkdirAll(filepath.Dir(path), dirPerm); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(f, r); err != nil {
+		return multierr.Combine(err, f.Close())
+	}
+	return f.Close()
+}
@@ -0,0 +1,405 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tsdb
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/require"
+
+	modelv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/model/v1"
+)
+
+var _ BlockStore = (*memBlockStore)(nil)
+
+type memBlockStore struct {
+	sync.Mutex
+	files map[string][]byte
+	puts  int
+	gets  int
+}
+
+func newMemBlockStore() *memBlockStore {
+	return &memBlockStore{files: make(map[string][]byte)}
+}
+
+func (m *memBlockStore) Put(_ context.Context, name string, r io.Reader) error {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	m.Lock()
+	defer m.Unlock()
+	m.files[name] = data
+	m.puts++
+	return nil
+}
+
+func (m *memBlockStore) Get(_ context.Context, name string) (io.ReadCloser, error) {
+	m.Lock()
+	defer m.Unlock()
+	data, ok := m.files[name]
+	if !ok {
+		return nil, ErrBlockFileNotFound
+	}
+	m.gets++
+	return ioutil.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (m *memBlockStore) Delete(_ context.Context, prefix string) error {
+	m.Lock()
+	defer m.Unlock()
+	for name := range m.files {
+		if strings.HasPrefix(name, prefix) {
+			delete(m.files, name)
+		}
+	}
+	return nil
+}
+
+func (m *memBlockStore) counts() (files, puts, gets int) {
+	m.Lock()
+	defer m.Unlock()
+	return len(m.files), m.puts, m.gets
+}
+
+func Test_Database_BlockStore(t *testing.T) {
+	tester := require.New(t)
+	store := newMemBlockStore()
+	_, deferFunc, db := setUpWithOpts(tester, func(opts *DatabaseOpts) {
+		opts.BlockStore = store
+	})
+	defer deferFunc()
+	s, err := db.Shard(0)
+	tester.NoError(err)
+	series, err := s.Series().Get(Entity{Entry("productpage"), Entry("10.0.0.1")})
+	tester.NoError(err)
+	now := time.Now()
+	span, err := series.Span(NewTimeRangeDuration(now, time.Hour))
+	tester.NoError(err)
+	writer, err := span.WriterBuilder().
+		Family([]byte("searchable"), []byte("sealed")).
+		Time(now).
+		Build()
+	tester.NoError(err)
+	id, err := writer.Write()
+	tester.NoError(err)
+	tester.NoError(span.Close())
+
+	// the active block stays local
+	n, err := db.Offload()
+	tester.NoError(err)
+	tester.Zero(n)
+	files, _, _ := store.counts()
+	tester.Zero(files)
+
+	// the block is immutable once it's sealed without any grace period
+	_, err = s.(*shard).segmentController.seal(now.Add(24 * time.Hour))
+	tester.NoError(err)
+	n, err = db.Offload()
+	tester.NoError(err)
+	tester.Equal(1, n)
+	files, puts, _ := store.counts()
+	tester.NotZero(files)
+	b := s.(*shard).segmentController.get(id.segID).blocks()[id.blockID]
+	_, err = os.Stat(b.path)
+	tester.ErrorIs(err, os.ErrNotExist)
+
+	// the spans out of the offloaded block's time range never fetch it
+	span, err = series.Span(NewTimeRangeDuration(now.Add(48*time.Hour), time.Hour))
+	tester.NoError(err)
+	tester.NoError(span.Close())
+	_, _, gets := store.counts()
+	tester.Zero(gets)
+	tester.True(b.isOffloaded())
+
+	item, closer, err := series.Get(id)
+	tester.NoError(err)
+	v, err := item.Family("searchable")
+	tester.NoError(err)
+	tester.Equal([]byte("sealed"), v)
+	tester.NoError(closer.Close())
+	_, _, gets = store.counts()
+	tester.NotZero(gets)
+	tester.False(b.isOffloaded())
+
+	// the copy in the store is reused when offloading the fetched block again
+	n, err = db.Offload()
+	tester.NoError(err)
+	tester.Equal(1, n)
+	_, morePuts, _ := store.counts()
+	tester.Equal(puts, morePuts)
+	tester.True(b.isOffloaded())
+}
+
+func Test_Database_Tiering(t *testing.T) {
+	req := require.New(t)
+	daily, err := NewIntervalPartitioner(24 * time.Hour)
+	req.NoError(err)
+	store := newMemBlockStore()
+	ctx, opts, removeSpace := setUpOpts(req, func(opts *DatabaseOpts) {
+		opts.Partitioner = daily
+		opts.BlockStore = store
+		opts.TieringAge = 48 * time.Hour
+		opts.TieringCheckInterval = time.Minute
+	})
+	defer removeSpace()
+	now := time.Now()
+	clock := clockwork.NewFakeClockAt(now)
+	db, err := OpenDatabase(context.WithValue(ctx, clockKey, clock), opts)
+	req.NoError(err)
+	defer func() {
+		req.NoError(db.Close())
+	}()
+	s, err := db.Shard(0)
+	req.NoError(err)
+	series, err := s.Series().Get(Entity{Entry("productpage"), Entry("10.0.0.1")})
+	req.NoError(err)
+	write := func(ts time.Time, val string) GlobalItemID {
+		span, errSpan := series.Span(NewTimeRangeDuration(ts, time.Hour))
+		req.NoError(errSpan)
+		defer func() {
+			req.NoError(span.Close())
+		}()
+		writer, errWriter := span.WriterBuilder().
+			Family([]byte("searchable"), []byte(val)).
+			Time(ts).
+			Build()
+		req.NoError(errWriter)
+		id, errWriter := writer.Write()
+		req.NoError(errWriter)
+		return id
+	}
+	read := func(id GlobalItemID) string {
+		item, closer, errGet := series.Get(id)
+		req.NoError(errGet)
+		defer func() {
+			req.NoError(closer.Close())
+		}()
+		v, errFamily := item.Family("searchable")
+		req.NoError(errFamily)
+		return string(v)
+	}
+	cold := write(now.Add(-72*time.Hour), "cold")
+	warm := write(now.Add(-36*time.Hour), "warm")
+	hot := write(now, "hot")
+	blockOf := func(id GlobalItemID) *block {
+		return s.(*shard).segmentController.get(id.segID).blocks()[id.blockID]
+	}
+	local := func(id GlobalItemID) bool {
+		_, errStat := os.Stat(blockOf(id).path)
+		return errStat == nil
+	}
+
+	// only the segment 3 days ago is older than the tiering age, while its global index stays local
+	clock.BlockUntil(1)
+	clock.Advance(time.Minute)
+	req.Eventually(func() bool {
+		return !local(cold)
+	}, 5*time.Second, 10*time.Millisecond)
+	req.True(blockOf(cold).isOffloaded())
+	req.True(local(warm))
+	req.True(local(hot))
+	files, puts, gets := store.counts()
+	req.NotZero(files)
+	req.Zero(gets)
+	_, err = os.Stat(filepath.Join(s.(*shard).segmentController.get(cold.segID).path, "index"))
+	req.NoError(err)
+
+	// reading the cold data fetches the block from the store transparently
+	req.Equal("cold", read(cold))
+	req.True(local(cold))
+	_, _, gets = store.counts()
+	req.NotZero(gets)
+
+	// the late data in the fetched block is uploaded again once it's offloaded
+	late := write(now.Add(-72*time.Hour+time.Minute), "late")
+	req.Equal(cold.segID, late.segID)
+	clock.BlockUntil(1)
+	clock.Advance(time.Minute)
+	req.Eventually(func() bool {
+		return !local(cold)
+	}, 5*time.Second, 10*time.Millisecond)
+	_, morePuts, _ := store.counts()
+	req.Greater(morePuts, puts)
+	req.Equal("cold", read(cold))
+	req.Equal("late", read(late))
+	req.Equal("warm", read(warm))
+	req.Equal("hot", read(hot))
+}
+
+func Test_Database_TieringCache(t *testing.T) {
+	req := require.New(t)
+	daily, err := NewIntervalPartitioner(24 * time.Hour)
+	req.NoError(err)
+	ctx, opts, removeSpace := setUpOpts(req, func(opts *DatabaseOpts) {
+		opts.Partitioner = daily
+		opts.BlockStore = newMemBlockStore()
+		opts.TieringAge = 48 * time.Hour
+		// the checks on schedule never run during the test
+		opts.TieringCheckInterval = 24 * time.Hour
+		opts.TieringCacheDuration = 90 * time.Minute
+	})
+	defer removeSpace()
+	now := time.Now()
+	clock := clockwork.NewFakeClockAt(now)
+	db, err := OpenDatabase(context.WithValue(ctx, clockKey, clock), opts)
+	req.NoError(err)
+	defer func() {
+		req.NoError(db.Close())
+	}()
+	s, err := db.Shard(0)
+	req.NoError(err)
+	series, err := s.Series().Get(Entity{Entry("productpage"), Entry("10.0.0.1")})
+	req.NoError(err)
+	ts := now.Add(-72 * time.Hour)
+	span, err := series.Span(NewTimeRangeDuration(ts, time.Hour))
+	req.NoError(err)
+	writer, err := span.WriterBuilder().
+		Family([]byte("searchable"), []byte("cold")).
+		Time(ts).
+		Build()
+	req.NoError(err)
+	id, err := writer.Write()
+	req.NoError(err)
+	req.NoError(span.Close())
+	b := s.(*shard).segmentController.get(id.segID).blocks()[id.blockID]
+
+	tier := db.(*database).tiering
+	n, err := tier.run()
+	req.NoError(err)
+	req.Equal(1, n)
+	_, closer, err := series.Get(id)
+	req.NoError(err)
+	req.NoError(closer.Close())
+	req.False(b.isOffloaded())
+
+	// the fetched block is cached locally until the cache duration is over
+	clock.Advance(time.Hour)
+	n, err = tier.run()
+	req.NoError(err)
+	req.Zero(n)
+	req.False(b.isOffloaded())
+	clock.Advance(time.Hour)
+	n, err = tier.run()
+	req.NoError(err)
+	req.Equal(1, n)
+	req.True(b.isOffloaded())
+}
+
+func Test_Database_TieringWithoutBlockStore(t *testing.T) {
+	tester := require.New(t)
+	ctx, opts, removeSpace := setUpOpts(tester, func(opts *DatabaseOpts) {
+		opts.TieringAge = time.Hour
+	})
+	defer removeSpace()
+	_, err := OpenDatabase(ctx, opts)
+	tester.ErrorIs(err, ErrBlockStoreAbsent)
+}
+
+func Test_Database_QueryOffloadedAndLocalBlocks(t *testing.T) {
+	tester := require.New(t)
+	store := newMemBlockStore()
+	_, deferFunc, db := setUpWithOpts(tester, func(opts *DatabaseOpts) {
+		opts.BlockStore = store
+	})
+	defer deferFunc()
+	s, err := db.Shard(0)
+	tester.NoError(err)
+	now := time.Now().Truncate(time.Millisecond)
+	timeRange := NewTimeRangeDuration(now.Add(-time.Hour), 48*time.Hour)
+	write := func(entity Entity, ts time.Time, val string) {
+		series, errSeries := s.Series().Get(entity)
+		tester.NoError(errSeries)
+		span, errSpan := series.Span(NewTimeRangeDuration(ts, time.Hour))
+		tester.NoError(errSpan)
+		defer func() {
+			tester.NoError(span.Close())
+		}()
+		writer, errWriter := span.WriterBuilder().
+			Family([]byte("searchable"), []byte(val)).
+			Time(ts).
+			Build()
+		tester.NoError(errWriter)
+		_, errWriter = writer.Write()
+		tester.NoError(errWriter)
+	}
+	query := func(entity Entity, order modelv1.Sort) (got []string) {
+		series, errSeries := s.Series().Get(entity)
+		tester.NoError(errSeries)
+		span, errSpan := series.Span(timeRange)
+		tester.NoError(errSpan)
+		defer func() {
+			tester.NoError(span.Close())
+		}()
+		seeker, errSeeker := span.SeekerBuilder().OrderByTime(order).Build()
+		tester.NoError(errSeeker)
+		iters, errSeeker := seeker.Seek()
+		tester.NoError(errSeeker)
+		for _, iter := range iters {
+			for iter.Next() {
+				v, errFamily := iter.Val().Family("searchable")
+				tester.NoError(errFamily)
+				got = append(got, string(v))
+			}
+			tester.NoError(iter.Close())
+		}
+		return got
+	}
+	productpage := Entity{Entry("productpage"), Entry("10.0.0.1")}
+	reviews := Entity{Entry("reviews"), Entry("10.0.0.2")}
+	for i := 0; i < 3; i++ {
+		write(productpage, now.Add(time.Duration(i)*time.Minute), fmt.Sprintf("cold-%d", i))
+	}
+	write(reviews, now.Add(time.Minute), "reviews-cold")
+	_, err = s.(*shard).segmentController.seal(now.Add(24 * time.Hour))
+	tester.NoError(err)
+	n, err := db.Offload()
+	tester.NoError(err)
+	tester.Equal(1, n)
+	// the recent data of the same series lands in the local active block
+	for i := 0; i < 2; i++ {
+		write(productpage, now.Add(24*time.Hour+time.Duration(i)*time.Minute), fmt.Sprintf("hot-%d", i))
+	}
+	write(reviews, now.Add(24*time.Hour), "reviews-hot")
+	_, _, gets := store.counts()
+	tester.Zero(gets)
+
+	tester.Equal([]string{"cold-0", "cold-1", "cold-2", "hot-0", "hot-1"}, query(productpage, modelv1.Sort_SORT_ASC))
+	_, _, gets = store.counts()
+	tester.NotZero(gets)
+	tester.Equal([]string{"hot-1", "hot-0", "cold-2", "cold-1", "cold-0"}, query(productpage, modelv1.Sort_SORT_DESC))
+	tester.Equal([]string{"reviews-cold", "reviews-hot"}, query(reviews, modelv1.Sort_SORT_ASC))
+
+	// the offloaded block is fetched again once it's offloaded after the query
+	n, err = db.Offload()
+	tester.NoError(err)
+	tester.Equal(1, n)
+	tester.Equal([]string{"reviews-hot", "reviews-cold"}, query(reviews, modelv1.Sort_SORT_DESC))
+}
@@ -0,0 +1,93 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tsdb
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/apache/skywalking-banyandb/pkg/encoding"
+)
+
+func Test_Database_ChunkSize(t *testing.T) {
+	req := require.New(t)
+	_, deferFunc, db := setUpWithOpts(req, func(opts *DatabaseOpts) {
+		opts.EncodingMethod = EncodingMethod{Codec: encoding.CodecGorilla}
+		opts.ChunkSize = 4 << 10
+	})
+	defer deferFunc()
+	s, err := db.Shard(0)
+	req.NoError(err)
+	series, err := s.Series().Get(Entity{Entry("productpage"), Entry("10.0.0.1")})
+	req.NoError(err)
+	now := time.Now()
+	span, err := series.Span(NewTimeRangeDuration(now, time.Hour))
+	req.NoError(err)
+	defer func() {
+		req.NoError(span.Close())
+	}()
+	writer, err := span.WriterBuilder().
+		Family([]byte("searchable"), []byte("200")).
+		Time(now).
+		Build()
+	req.NoError(err)
+	id, err := writer.Write()
+	req.NoError(err)
+	item, closer, err := series.Get(id)
+	req.NoError(err)
+	defer func() {
+		req.NoError(closer.Close())
+	}()
+	v, err := item.Family("searchable")
+	req.NoError(err)
+	req.Equal([]byte("200"), v)
+
+	// the encoder of a block is full once its values reach the chunk size
+	var method EncodingMethod
+	req.NoError(s.(*shard).forEachBlock(func(b blockDelegate) error {
+		method = b.(*bDelegate).delegate.encodingMethod
+		return nil
+	}))
+	encoder := method.EncoderPool.Get(nil)
+	defer method.EncoderPool.Put(encoder)
+	value := make([]byte, 1<<10)
+	for i := 0; i < 4; i++ {
+		req.False(encoder.IsFull())
+		encoder.Append(uint64(i), value)
+	}
+	req.True(encoder.IsFull())
+
+	for _, size := range []int{MinChunkSize >> 1, MaxChunkSize << 1, 3 << 10} {
+		_, err = OpenDatabase(context.Background(), DatabaseOpts{
+			Location:       filepath.Join(t.TempDir(), "invalid"),
+			ShardNum:       1,
+			EncodingMethod: EncodingMethod{Codec: encoding.CodecGorilla},
+			ChunkSize:      size,
+		})
+		req.ErrorIs(err, ErrInvalidChunkSize)
+	}
+	_, err = OpenDatabase(context.Background(), DatabaseOpts{
+		Location: filepath.Join(t.TempDir(), "absent"),
+		ShardNum: 1,
+	})
+	req.ErrorIs(err, ErrEncodingMethodAbsent)
+}
@@ -0,0 +1,175 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tsdb
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+
+	"github.com/apache/skywalking-banyandb/pkg/logger"
+)
+
+const (
+	// tempDirSuffix marks the directories created by the in-progress jobs, e.g. migrations and compactions.
+	// They become orphans once the job crashes or fails.
+	tempDirSuffix = ".tmp"
+	// cleanerMaxDepth covers the shard, segment and block levels
+	cleanerMaxDepth = 3
+)
+
+// stagedDirs are the temporary directories the in-progress jobs are writing, e.g. the fetched blocks.
+// They aren't orphans even though no live segment or block refers to them.
+var stagedDirs = struct {
+	sync.Mutex
+	paths map[string]int
+}{paths: make(map[string]int)}
+
+// stageTempDir keeps the orphan cleaners off the temporary directory until the returned function is called
+func stageTempDir(path string) func() {
+	path = filepath.Clean(path)
+	stagedDirs.Lock()
+	defer stagedDirs.Unlock()
+	stagedDirs.paths[path]++
+	return func() {
+		stagedDirs.Lock()
+		defer stagedDirs.Unlock()
+		if stagedDirs.paths[path]--; stagedDirs.paths[path] < 1 {
+			delete(stagedDirs.paths, path)
+		}
+	}
+}
+
+// removeOrphan removes the temporary directory unless it's staged, which is checked
+// while holding the staged ones to keep the directory from being staged during the removal
+func removeOrphan(path string) (removed bool, err error) {
+	stagedDirs.Lock()
+	defer stagedDirs.Unlock()
+	if _, ok := stagedDirs.paths[path]; ok {
+		return false, nil
+	}
+	return true, os.RemoveAll(path)
+}
+
+// orphanCleaner removes the orphan temporary directories under the database's location.
+// A directory is removed only if its name ends with tempDirSuffix,
+// no live segment or block refers to it, and it isn't staged by an in-progress job.
+type orphanCleaner struct {
+	l        *logger.Logger
+	location string
+	live     func() map[string]struct{}
+	stopCh   chan struct{}
+	wg       sync.WaitGroup
+}
+
+func newOrphanCleaner(l *logger.Logger, location string, live func() map[string]struct{}) *orphanCleaner {
+	return &orphanCleaner{
+		l:        l,
+		location: filepath.Clean(location),
+		live:     live,
+		stopCh:   make(chan struct{}),
+	}
+}
+
+func isTempDir(name string) bool {
+	return strings.HasSuffix(name, tempDirSuffix)
+}
+
+// clean removes the orphans and returns their paths
+func (c *orphanCleaner) clean() (reclaimed []string, err error) {
+	live := c.live()
+	walkErr := filepath.WalkDir(c.location, func(path string, d fs.DirEntry, errWalk error) error {
+		if errWalk != nil {
+			// the directory might be removed concurrently
+			if os.IsNotExist(errWalk) {
+				return nil
+			}
+			return errWalk
+		}
+		if !d.IsDir() || path == c.location {
+			return nil
+		}
+		if _, ok := live[path]; ok {
+			return nil
+		}
+		if isTempDir(d.Name()) {
+			size := dirSize(path)
+			removed, errRemove := removeOrphan(path)
+			if errRemove != nil {
+				err = multierr.Append(err, errors.Wrapf(errRemove, "failed to remove %s", path))
+				return fs.SkipDir
+			}
+			if !removed {
+				return fs.SkipDir
+			}
+			c.l.Info().Str("path", path).Int64("bytes", size).Msg("reclaimed an orphan directory")
+			reclaimed = append(reclaimed, path)
+			return fs.SkipDir
+		}
+		rel, _ := filepath.Rel(c.location, path)
+		if strings.Count(rel, string(filepath.Separator))+1 >= cleanerMaxDepth {
+			return fs.SkipDir
+		}
+		return nil
+	})
+	return reclaimed, multierr.Append(err, walkErr)
+}
+
+// start cleans orphans periodically until stop is called
+func (c *orphanCleaner) start(interval time.Duration) {
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if _, err := c.clean(); err != nil {
+					c.l.Warn().Err(err).Msg("failed to clean orphan directories")
+				}
+			case <-c.stopCh:
+				return
+			}
+		}
+	}()
+}
+
+func (c *orphanCleaner) stop() {
+	close(c.stopCh)
+	c.wg.Wait()
+}
+
+func dirSize(path string) (size int64) {
+	_ = filepath.WalkDir(path, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return nil
+		}
+		if info, errInfo := d.Info(); errInfo == nil {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size
+}
@@ -0,0 +1,159 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tsdb
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/apache/skywalking-banyandb/pkg/logger"
+	"github.com/apache/skywalking-banyandb/pkg/test"
+)
+
+func Test_Database_CleanOrphansOnOpen(t *testing.T) {
+	req := require.New(t)
+	tester := assert.New(t)
+	ctx, opts, removeSpace := setUpOpts(req, nil)
+	defer removeSpace()
+	tempDir := opts.Location
+	open := func() Database {
+		db, err := OpenDatabase(ctx, opts)
+		req.NoError(err)
+		return db
+	}
+	req.NoError(open().Close())
+
+	shardPath := fmt.Sprintf(shardTemplate, tempDir, 0)
+	segPath := fmt.Sprintf(segTemplate, shardPath, time.Now().Format(segFormat))
+	orphans := []string{
+		filepath.Join(tempDir, "migration"+tempDirSuffix),
+		filepath.Join(shardPath, "seg-20211201"+tempDirSuffix),
+		filepath.Join(segPath, "block-1200"+tempDirSuffix),
+	}
+	for _, orphan := range orphans {
+		req.NoError(os.MkdirAll(filepath.Join(orphan, "store"), dirPerm))
+		req.NoError(os.WriteFile(filepath.Join(orphan, "store", "000001.vlog"), []byte("data"), 0600))
+	}
+
+	db := open()
+	defer db.Close()
+	for _, orphan := range orphans {
+		_, err := os.Stat(orphan)
+		tester.True(os.IsNotExist(err), "the orphan %s is not removed", orphan)
+	}
+	validateDirectory(tester, segPath)
+	validateDirectory(tester, fmt.Sprintf(blockTemplate, segPath, time.Now().Format(blockFormat)))
+}
+
+func Test_Database_CleanOrphansPeriodically(t *testing.T) {
+	tester := assert.New(t)
+	tempDir, deferFunc, _ := setUpWithOpts(require.New(t), func(opts *DatabaseOpts) {
+		opts.OrphanCleanInterval = 10 * time.Millisecond
+	})
+	defer deferFunc()
+
+	shardPath := fmt.Sprintf(shardTemplate, tempDir, 0)
+	orphan := filepath.Join(shardPath, "seg-20211201"+tempDirSuffix)
+	tester.NoError(os.MkdirAll(orphan, dirPerm))
+	tester.Eventually(func() bool {
+		_, err := os.Stat(orphan)
+		return os.IsNotExist(err)
+	}, 5*time.Second, 10*time.Millisecond)
+	now := time.Now()
+	segPath := fmt.Sprintf(segTemplate, shardPath, now.Format(segFormat))
+	validateDirectory(tester, segPath)
+	validateDirectory(tester, fmt.Sprintf(blockTemplate, segPath, now.Format(blockFormat)))
+}
+
+// blockingBlockStore holds the gets of a file until it's released
+type blockingBlockStore struct {
+	BlockStore
+	blocked string
+	reached chan struct{}
+	release chan struct{}
+}
+
+func (b *blockingBlockStore) Get(ctx context.Context, name string) (io.ReadCloser, error) {
+	if name == b.blocked {
+		close(b.reached)
+		<-b.release
+	}
+	return b.BlockStore.Get(ctx, name)
+}
+
+func Test_OrphanCleaner_SkipsStagedDownload(t *testing.T) {
+	req := require.New(t)
+	req.NoError(logger.Init(logger.Logging{
+		Env:   "dev",
+		Level: "warn",
+	}))
+	tempDir, removeSpace := test.Space(req)
+	defer removeSpace()
+	src := filepath.Join(tempDir, "src")
+	req.NoError(os.MkdirAll(src, dirPerm))
+	for _, name := range []string{"a", "b"} {
+		req.NoError(os.WriteFile(filepath.Join(src, name), []byte(name), 0600))
+	}
+	store := &blockingBlockStore{
+		BlockStore: newMemBlockStore(),
+		blocked:    "seg/block/b",
+		reached:    make(chan struct{}),
+		release:    make(chan struct{}),
+	}
+	req.NoError(uploadBlock(context.Background(), store, "seg/block", src))
+
+	location := filepath.Join(tempDir, "db")
+	dir := filepath.Join(location, "shard-0", "seg-20211201", "block-1200")
+	downloaded := make(chan error)
+	go func() {
+		downloaded <- downloadBlock(context.Background(), store, "seg/block", dir)
+	}()
+	<-store.reached
+
+	// the block being fetched isn't live yet, while its temporary directory isn't an orphan
+	cleaner := newOrphanCleaner(logger.GetLogger("test"), location, func() map[string]struct{} {
+		return map[string]struct{}{}
+	})
+	reclaimed, err := cleaner.clean()
+	req.NoError(err)
+	req.Empty(reclaimed)
+	_, err = os.Stat(filepath.Join(dir+tempDirSuffix, "a"))
+	req.NoError(err)
+
+	close(store.release)
+	req.NoError(<-downloaded)
+	for _, name := range []string{"a", "b"} {
+		data, errRead := os.ReadFile(filepath.Join(dir, name))
+		req.NoError(errRead)
+		req.Equal(name, string(data))
+	}
+
+	// the temporary directory left behind by a crashed fetch is an orphan
+	req.NoError(os.MkdirAll(dir+tempDirSuffix, dirPerm))
+	reclaimed, err = cleaner.clean()
+	req.NoError(err)
+	req.Equal([]string{dir + tempDirSuffix}, reclaimed)
+}
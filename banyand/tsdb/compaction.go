@@ -0,0 +1,336 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tsdb
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+
+	"github.com/apache/skywalking-banyandb/banyand/kv"
+	"github.com/apache/skywalking-banyandb/pkg/index/inverted"
+	"github.com/apache/skywalking-banyandb/pkg/index/lsm"
+	"github.com/apache/skywalking-banyandb/pkg/logger"
+)
+
+// compaction merges the levels of the data files of the local blocks periodically, so a low-volume stream
+// flushing many small files doesn't slow the reads down. It drops the deleted items from the sealed blocks as well,
+// and merges the adjacent small blocks of a segment if DatabaseOpts.BlockMergeSize is set.
+type compaction struct {
+	l       *logger.Logger
+	clock   clockwork.Clock
+	compact func() error
+	stopCh  chan struct{}
+	wg      sync.WaitGroup
+}
+
+func newCompaction(l *logger.Logger, clock clockwork.Clock, compact func() error) *compaction {
+	return &compaction{
+		l:       l,
+		clock:   clock,
+		compact: compact,
+		stopCh:  make(chan struct{}),
+	}
+}
+
+// start runs the compaction periodically until stop is called
+func (c *compaction) start(interval time.Duration) {
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		ticker := c.clock.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.Chan():
+				if err := c.compact(); err != nil {
+					c.l.Warn().Err(err).Msg("failed to compact the blocks")
+				}
+			case <-c.stopCh:
+				return
+			}
+		}
+	}()
+}
+
+func (c *compaction) stop() {
+	close(c.stopCh)
+	c.wg.Wait()
+}
+
+// mergeBlocks merges the adjacent small blocks of all the segments, and returns the number of the merged ones
+func (s *shard) mergeBlocks(threshold int64, now time.Time) (count int, err error) {
+	for _, seg := range s.segmentController.segments() {
+		n, errMerge := seg.mergeBlocks(threshold, now)
+		count += n
+		err = multierr.Append(err, errMerge)
+	}
+	return count, err
+}
+
+// mergeBlocks merges the runs of the adjacent blocks smaller than threshold, whose windows are over by now.
+// A run stops before its size exceeds threshold. It returns the number of the merged blocks.
+func (s *segment) mergeBlocks(threshold int64, now time.Time) (count int, err error) {
+	// the segment is removed after the merge if it expires meanwhile
+	if !s.incRef() {
+		return 0, nil
+	}
+	defer func() {
+		err = multierr.Append(err, s.dscRef())
+	}()
+	var run []*block
+	var runSize int64
+	flush := func() {
+		if len(run) > 1 {
+			merged, errMerge := s.merge(run)
+			if merged != nil {
+				count += len(run)
+			}
+			err = multierr.Append(err, errMerge)
+		}
+		run, runSize = nil, 0
+	}
+	for _, b := range s.blocks() {
+		if !b.mergeable(now) {
+			flush()
+			continue
+		}
+		size, errSize := blockSize(b.path)
+		if errSize != nil {
+			err = multierr.Append(err, errSize)
+			flush()
+			continue
+		}
+		if size >= threshold {
+			flush()
+			continue
+		}
+		if runSize+size > threshold {
+			flush()
+		}
+		run = append(run, b)
+		runSize += size
+	}
+	flush()
+	return count, err
+}
+
+// mergeable reports whether the block is local and its window is over by now, which leaves it few late writes.
+// The block having a copy in the BlockStore stays as it is to keep the copy referred to.
+func (b *block) mergeable(now time.Time) bool {
+	if b.isOffloaded() || atomic.LoadInt32(&b.uploaded) == 1 {
+		return false
+	}
+	return b.endedBy(now)
+}
+
+// blockSize sums the level files of the block at path. The value logs and the memory tables are sparse files
+// allocated in advance, so the data in the latter are left out until they're flushed.
+func blockSize(path string) (size int64, err error) {
+	err = filepath.WalkDir(path, func(_ string, d fs.DirEntry, errWalk error) error {
+		if errWalk != nil {
+			return errWalk
+		}
+		if d.IsDir() || filepath.Ext(d.Name()) != ".sst" {
+			return nil
+		}
+		info, errInfo := d.Info()
+		if errInfo != nil {
+			return errInfo
+		}
+		size += info.Size()
+		return nil
+	})
+	return size, errors.Wrapf(err, "failed to measure the size of %s", path)
+}
+
+// merge merges the blocks ordered by their start time into a new one, which takes over their ids and their window.
+// It waits for the readers of each block to release it, and the later ones get the merged block instead.
+// It returns nil if any of the blocks has been merged or offloaded since it's picked.
+func (s *segment) merge(group []*block) (*block, error) {
+	// the readers pin the blocks in the order of their start time as well, which keeps them from a deadlock
+	for i, b := range group {
+		b.openLock.Lock()
+		defer b.openLock.Unlock()
+		if b.offloaded || b.mergedInto != nil {
+			return nil, reopenBlocks(group[:i])
+		}
+		b.closeStores()
+	}
+	s.Lock()
+	name := s.uniqueBlockName(group[0].startTime)
+	id := s.nextBlockID
+	s.nextBlockID++
+	s.Unlock()
+	blockPath := fmt.Sprintf(blockTemplate, s.path, name)
+	tmpPath := blockPath + tempDirSuffix
+	defer stageTempDir(tmpPath)()
+	err := mergeBlockFiles(group, tmpPath)
+	if err == nil {
+		err = errors.Wrapf(os.Rename(tmpPath, blockPath), "failed to move %s", tmpPath)
+	}
+	if err != nil {
+		return nil, multierr.Combine(err, os.RemoveAll(tmpPath), reopenBlocks(group))
+	}
+	last := group[len(group)-1].window()
+	merged, err := newBlock(s.ctx, blockOpts{
+		segID:     s.id,
+		blockID:   id,
+		segment:   s,
+		path:      blockPath,
+		startTime: group[0].startTime,
+		endTime:   last.End,
+	})
+	if err != nil {
+		return nil, multierr.Combine(err, os.RemoveAll(blockPath), reopenBlocks(group))
+	}
+	merged.sealedAt, merged.grace = sealedGroup(group)
+	if err = s.replaceBlocks(group, merged); err != nil {
+		merged.close()
+		return nil, multierr.Combine(err, os.RemoveAll(blockPath), reopenBlocks(group))
+	}
+	for _, b := range group {
+		b.mergedInto = merged
+		if errRemove := os.RemoveAll(b.path); errRemove != nil {
+			// the segment removes it once it's reopened
+			s.l.Warn().Err(errRemove).Str("path", b.path).Msg("failed to remove a merged block")
+		}
+	}
+	// the GlobalItemIDs taken before still refer to the merged blocks, which are the aliases of the new one
+	ids := make(map[uint16]struct{}, len(group))
+	for _, b := range group {
+		ids[b.blockID] = struct{}{}
+	}
+	_, err = kv.RewriteValues(s.globalIndex, func(_, val []byte) ([]byte, bool) {
+		itemID := &GlobalItemID{}
+		if itemID.UnMarshal(val) != nil {
+			return nil, false
+		}
+		if _, ok := ids[itemID.blockID]; !ok {
+			return nil, false
+		}
+		itemID.blockID = id
+		return itemID.Marshal(), true
+	})
+	s.l.Info().Str("path", blockPath).Int("blocks", len(group)).Msg("merged the blocks")
+	return merged, errors.WithMessagef(err, "failed to repoint the index at the merged block %s", blockPath)
+}
+
+// replaceBlocks puts merged in place of the blocks of group, whose ids become the aliases of merged
+func (s *segment) replaceBlocks(group []*block, merged *block) error {
+	s.Lock()
+	defer s.Unlock()
+	lst := s.lst
+	aliases := make(map[uint16]uint16, len(s.aliases)+len(group))
+	for alias, id := range s.aliases {
+		aliases[alias] = id
+	}
+	replaced := make(map[uint16]struct{}, len(group))
+	for _, b := range group {
+		replaced[b.blockID] = struct{}{}
+		aliases[b.blockID] = merged.blockID
+	}
+	for alias, id := range aliases {
+		if _, ok := replaced[id]; ok {
+			aliases[alias] = merged.blockID
+		}
+	}
+	s.lst = make([]*block, 0, len(lst))
+	for _, b := range lst {
+		if _, ok := replaced[b.blockID]; !ok {
+			s.lst = append(s.lst, b)
+		}
+	}
+	s.insertBlock(merged)
+	previous := s.aliases
+	s.aliases = aliases
+	if err := s.persistBlocks(); err != nil {
+		s.lst, s.aliases = lst, previous
+		return err
+	}
+	return nil
+}
+
+// mergeBlockFiles writes the stores and the indices of the closed blocks into a new block at path
+func mergeBlockFiles(group []*block, path string) error {
+	if err := os.MkdirAll(path, dirPerm); err != nil {
+		return errors.Wrapf(err, "failed to create %s", path)
+	}
+	first := group[0]
+	var storePaths []string
+	var primaryIndices, lsmIndices []lsm.StoreOpts
+	var invertedIndices []inverted.StoreOpts
+	sequence := &blockSequence{path: path + "/" + blockSequenceFile}
+	for _, b := range group {
+		storePaths = append(storePaths, b.path+"/store")
+		primaryIndices = append(primaryIndices, lsm.StoreOpts{Path: b.path + "/primary", Logger: b.l})
+		invertedIndices = append(invertedIndices, inverted.StoreOpts{Path: b.path + "/inverted", Logger: b.l})
+		lsmIndices = append(lsmIndices, lsm.StoreOpts{Path: b.path + "/lsm", Logger: b.l})
+		sequence.apply(b.sequence.applied)
+	}
+	if err := kv.MergeTimeSeriesStores(storePaths, path+"/store", first.storeOptions()...); err != nil {
+		return errors.WithMessage(err, "failed to merge the data stores")
+	}
+	if err := lsm.Merge(lsm.StoreOpts{Path: path + "/primary", Logger: first.l}, primaryIndices...); err != nil {
+		return errors.WithMessage(err, "failed to merge the primary indices")
+	}
+	if first.indexed {
+		if err := inverted.Merge(inverted.StoreOpts{Path: path + "/inverted", Logger: first.l}, invertedIndices...); err != nil {
+			return errors.WithMessage(err, "failed to merge the inverted indices")
+		}
+		if err := lsm.Merge(lsm.StoreOpts{Path: path + "/lsm", Logger: first.l}, lsmIndices...); err != nil {
+			return errors.WithMessage(err, "failed to merge the lsm indices")
+		}
+	}
+	return errors.Wrap(sequence.persist(), "failed to persist the sequence")
+}
+
+// sealedGroup returns the latest seal of the blocks, which is zero if any of them is unsealed
+func sealedGroup(group []*block) (sealedAt time.Time, grace time.Duration) {
+	for _, b := range group {
+		b.sealLock.RLock()
+		blockSealedAt, blockGrace := b.sealedAt, b.grace
+		b.sealLock.RUnlock()
+		if blockSealedAt.IsZero() {
+			return time.Time{}, 0
+		}
+		if blockSealedAt.After(sealedAt) {
+			sealedAt = blockSealedAt
+		}
+		if blockGrace > grace {
+			grace = blockGrace
+		}
+	}
+	return sealedAt, grace
+}
+
+// reopenBlocks opens the stores of the blocks closed by an aborted merge, whose open locks are held by the caller
+func reopenBlocks(blocks []*block) (err error) {
+	for _, b := range blocks {
+		err = multierr.Append(err, b.reopen())
+	}
+	return err
+}
@@ -0,0 +1,237 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tsdb
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/require"
+
+	commonv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/common/v1"
+	databasev1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/database/v1"
+	"github.com/apache/skywalking-banyandb/pkg/index"
+	"github.com/apache/skywalking-banyandb/pkg/logger"
+)
+
+func Test_Compaction(t *testing.T) {
+	req := require.New(t)
+	req.NoError(logger.Init(logger.Logging{
+		Env:   "dev",
+		Level: "warn",
+	}))
+	clock := clockwork.NewFakeClock()
+	var runs int32
+	c := newCompaction(logger.GetLogger("test"), clock, func() error {
+		atomic.AddInt32(&runs, 1)
+		return nil
+	})
+	c.start(time.Minute)
+	for i := int32(1); i <= 2; i++ {
+		clock.BlockUntil(1)
+		clock.Advance(time.Minute)
+		want := i
+		req.Eventually(func() bool {
+			return atomic.LoadInt32(&runs) == want
+		}, 5*time.Second, 10*time.Millisecond)
+	}
+	c.stop()
+}
+
+func Test_Database_Compaction(t *testing.T) {
+	req := require.New(t)
+	ctx, opts, removeSpace := setUpOpts(req, func(opts *DatabaseOpts) {
+		opts.CompactionInterval = time.Minute
+	})
+	defer removeSpace()
+	clock := clockwork.NewFakeClock()
+	db, err := OpenDatabase(context.WithValue(ctx, clockKey, clock), opts)
+	req.NoError(err)
+	defer func() {
+		req.NoError(db.Close())
+	}()
+	s, err := db.Shard(0)
+	req.NoError(err)
+	series, err := s.Series().Get(Entity{Entry("productpage"), Entry("10.0.0.1")})
+	req.NoError(err)
+	now := time.Now()
+	span, err := series.Span(NewTimeRangeDuration(now, time.Hour))
+	req.NoError(err)
+	defer func() {
+		req.NoError(span.Close())
+	}()
+
+	// every flush leaves a small file behind, and the compaction runs while they are read
+	var ids []GlobalItemID
+	for i := 0; i < 10; i++ {
+		writer, errWriter := span.WriterBuilder().
+			Family([]byte("searchable"), []byte(fmt.Sprintf("value-%d", i))).
+			Time(now.Add(time.Duration(i) * time.Millisecond)).
+			Build()
+		req.NoError(errWriter)
+		id, errWrite := writer.Write()
+		req.NoError(errWrite)
+		ids = append(ids, id)
+		req.NoError(db.Flush())
+		clock.BlockUntil(1)
+		clock.Advance(time.Minute)
+		for j, id := range ids {
+			item, closer, errGet := series.Get(id)
+			req.NoError(errGet)
+			v, errFamily := item.Family("searchable")
+			req.NoError(errFamily)
+			req.Equal(fmt.Sprintf("value-%d", j), string(v))
+			req.NoError(closer.Close())
+		}
+	}
+}
+
+func Test_Database_MergeBlocks(t *testing.T) {
+	tests := []struct {
+		name      string
+		mergeSize int64
+		merged    bool
+	}{
+		{
+			name: "disabled",
+		},
+		{
+			name:      "merge the small blocks",
+			mergeSize: 1 << 20,
+			merged:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := require.New(t)
+			rule := &databasev1.IndexRule{
+				Metadata: &commonv1.Metadata{
+					Name:  "status",
+					Group: "default",
+					Id:    1,
+				},
+				Tags:     []string{"status"},
+				Type:     databasev1.IndexRule_TYPE_INVERTED,
+				Location: databasev1.IndexRule_LOCATION_SERIES,
+			}
+			ctx, opts, removeSpace := setUpOpts(req, func(opts *DatabaseOpts) {
+				opts.IndexRules = []*databasev1.IndexRule{rule}
+				opts.BlockInterval = 5 * time.Millisecond
+				opts.BlockMergeSize = tt.mergeSize
+			})
+			defer removeSpace()
+			db, err := OpenDatabase(ctx, opts)
+			req.NoError(err)
+			s, err := db.Shard(0)
+			req.NoError(err)
+			series, err := s.Series().Get(Entity{Entry("productpage"), Entry("10.0.0.1")})
+			req.NoError(err)
+			now := time.Now()
+			timeRange := NewTimeRangeDuration(now.Add(-time.Hour), 2*time.Hour)
+			field := func(status string) index.Field {
+				return index.Field{
+					Key:  index.FieldKey{IndexRuleID: rule.GetMetadata().GetId()},
+					Term: []byte(status),
+				}
+			}
+
+			// every item lands in a block of its own
+			const num = 4
+			var ids []GlobalItemID
+			for i := 0; i < num; i++ {
+				span, errSpan := series.Span(timeRange)
+				req.NoError(errSpan)
+				ts := now.Add(time.Duration(i) * 5 * time.Millisecond)
+				writer, errWriter := span.WriterBuilder().
+					Family([]byte("searchable"), []byte(fmt.Sprintf("value-%d", i))).
+					Time(ts).
+					Build()
+				req.NoError(errWriter)
+				id, errWrite := writer.Write()
+				req.NoError(errWrite)
+				req.NoError(writer.WriteInvertedIndex(field("ok")))
+				indexWriter, errIndex := s.Index().WriterBuilder().Time(ts).GlobalItemID(id).Build()
+				req.NoError(errIndex)
+				req.NoError(indexWriter.WriteInvertedIndex(field("ok")))
+				req.NoError(span.Close())
+				ids = append(ids, id)
+			}
+			segments := s.(*shard).segmentController.segments()
+			req.Len(segments, 1)
+			before := len(segments[0].blocks())
+			req.GreaterOrEqual(before, num)
+
+			// the windows of the blocks are over
+			time.Sleep(time.Until(now.Add((num + 1) * 5 * time.Millisecond)))
+			req.NoError(db.Compact())
+			after := len(segments[0].blocks())
+			if !tt.merged {
+				req.Equal(before, after)
+				req.NoError(db.Close())
+				return
+			}
+			req.Less(after, before)
+			req.Equal(1, after)
+
+			verify := func(db Database) {
+				s, errShard := db.Shard(0)
+				req.NoError(errShard)
+				series, errSeries := s.Series().Get(Entity{Entry("productpage"), Entry("10.0.0.1")})
+				req.NoError(errSeries)
+				// the ids taken before the merge refer to the merged block
+				for i, id := range ids {
+					item, closer, errGet := series.Get(id)
+					req.NoError(errGet)
+					v, errFamily := item.Family("searchable")
+					req.NoError(errFamily)
+					req.Equal(fmt.Sprintf("value-%d", i), string(v))
+					req.NoError(closer.Close())
+				}
+				merged := s.(*shard).segmentController.segments()[0].blocks()[0]
+				found, errSeek := s.Index().Seek(field("ok"))
+				req.NoError(errSeek)
+				req.Len(found, num)
+				for _, id := range found {
+					req.Equal(merged.blockID, id.blockID)
+				}
+				searchers, closer, errSearch := s.Series().IndexSearchers(timeRange, databasev1.IndexRule_TYPE_INVERTED)
+				req.NoError(errSearch)
+				req.Len(searchers, 1)
+				f := field("ok")
+				f.Key.SeriesID = series.ID()
+				list, errMatch := searchers[0].MatchTerms(f)
+				req.NoError(errMatch)
+				req.Equal(num, list.Len())
+				req.NoError(closer.Close())
+			}
+			verify(db)
+
+			// the merged block and the aliases of its ids survive the reopening
+			req.NoError(db.Close())
+			db, err = OpenDatabase(ctx, opts)
+			req.NoError(err)
+			req.Len(db.(*database).sLst[0].(*shard).segmentController.segments()[0].blocks(), 1)
+			verify(db)
+			req.NoError(db.Close())
+		})
+	}
+}
@@ -0,0 +1,186 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tsdb
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/apache/skywalking-banyandb/api/common"
+	"github.com/apache/skywalking-banyandb/banyand/kv"
+)
+
+// ShardSize is the amount of data a shard holds
+type ShardSize struct {
+	ID common.ShardID
+	// Series is the number of the series, i.e. the distinct entities, routed to the shard
+	Series int
+	// Bytes is the size of the shard's files on the disk
+	Bytes int64
+}
+
+// ShardDistribution reports how the data is distributed across the shards, ordered by the shard ids
+type ShardDistribution []ShardSize
+
+// Skew is the ratio of the largest number of series in a shard to the average. 1 means the series are evenly distributed.
+func (d ShardDistribution) Skew() float64 {
+	if len(d) == 0 {
+		return 0
+	}
+	var total, max int
+	for _, s := range d {
+		total += s.Series
+		if s.Series > max {
+			max = s.Series
+		}
+	}
+	if total == 0 {
+		return 0
+	}
+	return float64(max) * float64(len(d)) / float64(total)
+}
+
+func (d ShardDistribution) String() string {
+	sizes := make([]string, 0, len(d))
+	for _, s := range d {
+		sizes = append(sizes, fmt.Sprintf("shard-%d: %d series, %d bytes", s.ID, s.Series, s.Bytes))
+	}
+	return fmt.Sprintf("skew %.2f: %s", d.Skew(), strings.Join(sizes, ","))
+}
+
+func (d *database) ShardSizes() (ShardDistribution, error) {
+	d.Lock()
+	shards := d.sLst
+	d.Unlock()
+	result := make(ShardDistribution, 0, len(shards))
+	for _, s := range shards {
+		sd, ok := s.(*shard)
+		if !ok {
+			continue
+		}
+		size, err := sd.size()
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, size)
+	}
+	return result, nil
+}
+
+func (s *shard) size() (ShardSize, error) {
+	size := ShardSize{ID: s.id}
+	if sdb, ok := s.seriesDatabase.(*seriesDB); ok {
+		series, err := sdb.count()
+		if err != nil {
+			return size, errors.WithMessagef(err, "failed to count the series of shard %d", s.id)
+		}
+		size.Series = series
+	}
+	err := filepath.Walk(s.location, func(_ string, info os.FileInfo, err error) error {
+		if err != nil {
+			// the files might be removed by a compaction or the retention during the walk
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if !info.IsDir() {
+			size.Bytes += info.Size()
+		}
+		return nil
+	})
+	if err != nil {
+		return size, errors.Wrapf(err, "failed to measure the size of shard %d", s.id)
+	}
+	return size, nil
+}
+
+// count returns the number of the series registered in the shard
+func (s *seriesDB) count() (int, error) {
+	var num int
+	err := s.seriesMetadata.Scan(nil, kv.ScanOpts{PrefetchSize: kv.DefaultScanOpts.PrefetchSize},
+		func(_ int, _ []byte, _ func() ([]byte, error)) error {
+			num++
+			return nil
+		})
+	return num, err
+}
+
+var (
+	shardSeriesDesc = prometheus.NewDesc("banyandb_tsdb_shard_series",
+		"The number of the series in the shard", []string{"location", "shard"}, nil)
+	shardBytesDesc = prometheus.NewDesc("banyandb_tsdb_shard_bytes",
+		"The size of the shard's files on the disk", []string{"location", "shard"}, nil)
+)
+
+// distributionCollector exports the shard sizes of all the open databases
+type distributionCollector struct {
+	sync.Mutex
+	databases map[*database]struct{}
+}
+
+var distribution = &distributionCollector{databases: make(map[*database]struct{})}
+
+func init() {
+	prometheus.MustRegister(distribution)
+}
+
+func (c *distributionCollector) add(db *database) {
+	c.Lock()
+	defer c.Unlock()
+	c.databases[db] = struct{}{}
+}
+
+func (c *distributionCollector) remove(db *database) {
+	c.Lock()
+	defer c.Unlock()
+	delete(c.databases, db)
+}
+
+func (c *distributionCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- shardSeriesDesc
+	ch <- shardBytesDesc
+}
+
+func (c *distributionCollector) Collect(ch chan<- prometheus.Metric) {
+	c.Lock()
+	dbs := make([]*database, 0, len(c.databases))
+	for db := range c.databases {
+		dbs = append(dbs, db)
+	}
+	c.Unlock()
+	for _, db := range dbs {
+		sizes, err := db.ShardSizes()
+		if err != nil {
+			db.logger.Warn().Err(err).Msg("failed to collect the shard sizes")
+			continue
+		}
+		for _, s := range sizes {
+			shardID := strconv.Itoa(int(s.ID))
+			ch <- prometheus.MustNewConstMetric(shardSeriesDesc, prometheus.GaugeValue, float64(s.Series), db.location, shardID)
+			ch <- prometheus.MustNewConstMetric(shardBytesDesc, prometheus.GaugeValue, float64(s.Bytes), db.location, shardID)
+		}
+	}
+}
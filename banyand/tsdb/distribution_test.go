@@ -0,0 +1,75 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tsdb
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/require"
+
+	"github.com/apache/skywalking-banyandb/api/common"
+)
+
+func Test_Database_ShardSizes(t *testing.T) {
+	tester := require.New(t)
+	tempDir, deferFunc, db := setUpWithOpts(tester, func(opts *DatabaseOpts) {
+		opts.ShardNum = 4
+	})
+	defer deferFunc()
+	// the entities are skewed to the first shard
+	placement := map[common.ShardID]int{0: 12, 1: 1, 2: 1, 3: 1}
+	for id, num := range placement {
+		s, err := db.Shard(id)
+		tester.NoError(err)
+		for i := 0; i < num; i++ {
+			_, err = s.Series().Get(Entity{Entry(fmt.Sprintf("svc-%d-%d", id, i))})
+			tester.NoError(err)
+		}
+	}
+	sizes, err := db.ShardSizes()
+	tester.NoError(err)
+	tester.Len(sizes, 4)
+	for i, size := range sizes {
+		tester.Equal(common.ShardID(i), size.ID)
+		tester.Equal(placement[size.ID], size.Series)
+		tester.Greater(size.Bytes, int64(0))
+	}
+	tester.InDelta(3.2, sizes.Skew(), 0.001)
+
+	ch := make(chan prometheus.Metric, 64)
+	distribution.Collect(ch)
+	close(ch)
+	collected := make(map[string]float64)
+	for m := range ch {
+		var pb dto.Metric
+		tester.NoError(m.Write(&pb))
+		labels := make(map[string]string)
+		for _, l := range pb.GetLabel() {
+			labels[l.GetName()] = l.GetValue()
+		}
+		if labels["location"] != tempDir || !strings.Contains(m.Desc().String(), "banyandb_tsdb_shard_series") {
+			continue
+		}
+		collected[labels["shard"]] = pb.GetGauge().GetValue()
+	}
+	tester.Equal(map[string]float64{"0": 12, "1": 1, "2": 1, "3": 1}, collected)
+}
@@ -0,0 +1,124 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tsdb
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/pkg/errors"
+
+	"github.com/apache/skywalking-banyandb/pkg/logger"
+)
+
+var ErrInvalidDurability = errors.New("the interval of the periodic durability should be positive")
+
+type durabilityMode int
+
+const (
+	durabilityOnClose durabilityMode = iota
+	durabilityPerWrite
+	durabilityPeriodic
+)
+
+// Durability decides when the data written into the blocks are synced to the disk, which trades the writes
+// lost in a crash for the throughput:
+//   - PerWrite syncs the block before a write returns, so no acknowledged write is lost, but every write pays for an fsync.
+//   - Periodic syncs all the blocks at the interval, which loses the writes since the last sync at most.
+//   - OnClose syncs a block only when it's closed or Database.Flush is called, which is the fastest and
+//     loses all the writes since the last flush.
+//
+// The zero value is OnClose. It covers the data of the items only, and the indices are synced on close in any mode.
+type Durability struct {
+	mode     durabilityMode
+	interval time.Duration
+}
+
+func PerWrite() Durability {
+	return Durability{mode: durabilityPerWrite}
+}
+
+func Periodic(interval time.Duration) Durability {
+	return Durability{mode: durabilityPeriodic, interval: interval}
+}
+
+func OnClose() Durability {
+	return Durability{mode: durabilityOnClose}
+}
+
+func (d Durability) validate() error {
+	if d.mode == durabilityPeriodic && d.interval <= 0 {
+		return errors.WithMessagef(ErrInvalidDurability, "invalid interval %s", d.interval)
+	}
+	return nil
+}
+
+func (d Durability) String() string {
+	switch d.mode {
+	case durabilityPerWrite:
+		return "per-write"
+	case durabilityPeriodic:
+		return fmt.Sprintf("periodic(%s)", d.interval)
+	}
+	return "on-close"
+}
+
+// flusher syncs all the blocks periodically for the Periodic durability
+type flusher struct {
+	l      *logger.Logger
+	clock  clockwork.Clock
+	flush  func() error
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+}
+
+func newFlusher(l *logger.Logger, clock clockwork.Clock, flush func() error) *flusher {
+	return &flusher{
+		l:      l,
+		clock:  clock,
+		flush:  flush,
+		stopCh: make(chan struct{}),
+	}
+}
+
+// start runs the flush periodically until stop is called
+func (f *flusher) start(interval time.Duration) {
+	f.wg.Add(1)
+	go func() {
+		defer f.wg.Done()
+		ticker := f.clock.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.Chan():
+				if err := f.flush(); err != nil {
+					f.l.Warn().Err(err).Msg("failed to flush the blocks")
+				}
+			case <-f.stopCh:
+				return
+			}
+		}
+	}()
+}
+
+func (f *flusher) stop() {
+	close(f.stopCh)
+	f.wg.Wait()
+}
@@ -0,0 +1,176 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build linux
+// +build linux
+
+package tsdb
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/apache/skywalking-banyandb/pkg/test"
+)
+
+func Test_Database_PeriodicDurability(t *testing.T) {
+	req := require.New(t)
+	ctx, opts, removeSpace := setUpOpts(req, func(opts *DatabaseOpts) {
+		// the reopened shard loads the same block
+		opts.TimestampPrecision = 24 * time.Hour
+		opts.Durability = Periodic(time.Second)
+	})
+	defer removeSpace()
+	tempDir := opts.Location
+	crashDir, removeCrash := test.Space(req)
+	defer removeCrash()
+	clock := clockwork.NewFakeClock()
+	db, err := OpenDatabase(context.WithValue(ctx, clockKey, clock), opts)
+	req.NoError(err)
+	closed := false
+	defer func() {
+		if !closed {
+			req.NoError(db.Close())
+		}
+	}()
+	entity := Entity{Entry("productpage"), Entry("10.0.0.1")}
+	ts := time.Now()
+	timeRange := NewTimeRangeDuration(ts.Add(-time.Hour), 2*time.Hour)
+	write := func(shard Shard, seq uint64, i int) error {
+		series, errSeries := shard.Series().Get(entity)
+		req.NoError(errSeries)
+		span, errSpan := series.Span(timeRange)
+		req.NoError(errSpan)
+		defer func() {
+			req.NoError(span.Close())
+		}()
+		writer, errWriter := span.WriterBuilder().
+			Family([]byte("val"), []byte{byte(i)}).
+			Time(ts.Add(time.Duration(i) * time.Millisecond)).
+			Sequence(seq).
+			Build()
+		req.NoError(errWriter)
+		_, errWriter = writer.Write()
+		return errWriter
+	}
+
+	shard, err := db.Shard(0)
+	req.NoError(err)
+	for i := 1; i <= 3; i++ {
+		req.NoError(write(shard, 0, i))
+	}
+	clock.BlockUntil(1)
+	clock.Advance(time.Second)
+	select {
+	case <-db.FlushNotify():
+	case <-time.After(5 * time.Second):
+		req.Fail("the periodic flush didn't happen")
+	}
+	// the writes after the last flush might be lost in the crash
+	for i := 4; i <= 5; i++ {
+		req.NoError(write(shard, 0, i))
+	}
+
+	// the crash leaves the files as they are, so the copy of them is what a restart would find
+	req.NoError(copyDir(filepath.Join(tempDir, "shard-0"), filepath.Join(crashDir, "shard-0")))
+	req.NoError(db.Close())
+	closed = true
+
+	shardCtx := context.WithValue(ctx, encodingMethodKey, opts.EncodingMethod)
+	shardCtx = context.WithValue(shardCtx, precisionKey, opts.TimestampPrecision)
+	shard, err = newShard(shardCtx, 0, filepath.Join(crashDir, "shard-0"))
+	req.NoError(err)
+	defer shard.Close()
+	// the flushed writes survive, so replaying them is rejected
+	for i := 1; i <= 3; i++ {
+		req.True(errors.Is(write(shard, uint64(i), i), ErrSequenceApplied))
+	}
+}
+
+// seekData and seekHole are SEEK_DATA and SEEK_HOLE, which are absent from the syscall package
+const (
+	seekData = 3
+	seekHole = 4
+)
+
+// copyDir copies the data regions of the files only, since badger pre-allocates sparse files of gigabytes
+func copyDir(src, dst string) error {
+	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		if info.IsDir() {
+			return os.MkdirAll(target, info.Mode())
+		}
+		in, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer in.Close()
+		out, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, info.Mode())
+		if err != nil {
+			return err
+		}
+		if err = copySparse(in, out, info.Size()); err != nil {
+			_ = out.Close()
+			return err
+		}
+		return out.Close()
+	})
+}
+
+func copySparse(in, out *os.File, size int64) error {
+	for off := int64(0); off < size; {
+		start, err := in.Seek(off, seekData)
+		if errors.Is(err, syscall.ENXIO) {
+			// no data is left after off
+			break
+		}
+		if err != nil {
+			return err
+		}
+		end, err := in.Seek(start, seekHole)
+		if err != nil {
+			return err
+		}
+		if _, err = in.Seek(start, io.SeekStart); err != nil {
+			return err
+		}
+		if _, err = out.Seek(start, io.SeekStart); err != nil {
+			return err
+		}
+		if _, err = io.CopyN(out, in, end-start); err != nil {
+			return err
+		}
+		off = end
+	}
+	return out.Truncate(size)
+}
@@ -0,0 +1,62 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tsdb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Database_InvalidDurability(t *testing.T) {
+	req := require.New(t)
+	ctx, opts, removeSpace := setUpOpts(req, func(opts *DatabaseOpts) {
+		opts.Durability = Periodic(0)
+	})
+	defer removeSpace()
+	_, err := OpenDatabase(ctx, opts)
+	req.ErrorIs(err, ErrInvalidDurability)
+}
+
+func Test_Database_PerWriteDurability(t *testing.T) {
+	req := require.New(t)
+	_, deferFunc, db := setUpWithOpts(req, func(opts *DatabaseOpts) {
+		opts.Durability = PerWrite()
+	})
+	defer deferFunc()
+	s, err := db.Shard(0)
+	req.NoError(err)
+	series, err := s.Series().Get(Entity{Entry("productpage")})
+	req.NoError(err)
+	span, err := series.Span(NewTimeRangeDuration(time.Now().Add(-time.Hour), 2*time.Hour))
+	req.NoError(err)
+	defer func() {
+		req.NoError(span.Close())
+	}()
+	writer, err := span.WriterBuilder().Family([]byte("val"), []byte{1}).Time(time.Now()).Build()
+	req.NoError(err)
+	_, err = writer.Write()
+	req.NoError(err)
+	// the write has synced the block without flushing the database
+	select {
+	case <-db.FlushNotify():
+	case <-time.After(5 * time.Second):
+		req.Fail("the write didn't sync the block")
+	}
+}
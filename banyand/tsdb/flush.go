@@ -0,0 +1,108 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tsdb
+
+import (
+	"sync"
+	"time"
+
+	"github.com/apache/skywalking-banyandb/api/common"
+)
+
+// FlushEventBufferSize bounds the events held for a slow consumer, the oldest ones are dropped beyond it
+const FlushEventBufferSize = 64
+
+// FlushEvent tells that the data of a block within TimeRange is durable
+type FlushEvent struct {
+	ShardID   common.ShardID
+	SegmentID uint16
+	BlockID   uint16
+	// TimeRange covers the items written into the block so far, its end is exclusive
+	TimeRange TimeRange
+}
+
+type flushHook func(event FlushEvent)
+
+type flushNotifier struct {
+	ch     chan FlushEvent
+	closed bool
+	sync.Mutex
+}
+
+func newFlushNotifier(size int) *flushNotifier {
+	return &flushNotifier{
+		ch: make(chan FlushEvent, size),
+	}
+}
+
+// notify never blocks the flushing block, it drops the oldest event if the channel is full
+func (n *flushNotifier) notify(event FlushEvent) {
+	n.Lock()
+	defer n.Unlock()
+	if n.closed {
+		return
+	}
+	for {
+		select {
+		case n.ch <- event:
+			return
+		default:
+		}
+		select {
+		case <-n.ch:
+		default:
+		}
+	}
+}
+
+func (n *flushNotifier) close() {
+	n.Lock()
+	defer n.Unlock()
+	if n.closed {
+		return
+	}
+	n.closed = true
+	close(n.ch)
+}
+
+// writtenRange tracks the time range of the items written into a block
+type writtenRange struct {
+	start time.Time
+	end   time.Time
+	sync.Mutex
+}
+
+func (r *writtenRange) update(ts time.Time) {
+	r.Lock()
+	defer r.Unlock()
+	if r.start.IsZero() || ts.Before(r.start) {
+		r.start = ts
+	}
+	if !ts.Before(r.end) {
+		r.end = ts.Add(time.Nanosecond)
+	}
+}
+
+func (r *writtenRange) get() (TimeRange, bool) {
+	r.Lock()
+	defer r.Unlock()
+	if r.start.IsZero() {
+		return TimeRange{}, false
+	}
+	return NewTimeRange(r.start, r.end), true
+}
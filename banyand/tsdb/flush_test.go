@@ -0,0 +1,76 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tsdb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/apache/skywalking-banyandb/api/common"
+)
+
+func Test_Database_FlushNotify(t *testing.T) {
+	tester := assert.New(t)
+	_, deferFunc, db := setUp(require.New(t))
+	defer deferFunc()
+	flushCh := db.FlushNotify()
+	s, err := db.Shard(0)
+	tester.NoError(err)
+	series, err := s.Series().Get(Entity{Entry("productpage"), Entry("10.0.0.1")})
+	tester.NoError(err)
+	ts := time.Now()
+	span, err := series.Span(NewTimeRangeDuration(ts.Add(-time.Hour), 2*time.Hour))
+	tester.NoError(err)
+	defer func() {
+		tester.NoError(span.Close())
+	}()
+	writer, err := span.WriterBuilder().
+		Family([]byte("searchable"), []byte("200")).
+		Time(ts).
+		Build()
+	tester.NoError(err)
+	_, err = writer.Write()
+	tester.NoError(err)
+	tester.NoError(writer.Sync())
+
+	select {
+	case event := <-flushCh:
+		tester.Equal(common.ShardID(0), event.ShardID)
+		tester.True(event.TimeRange.contains(uint64(ts.UnixNano())))
+	case <-time.After(5 * time.Second):
+		tester.Fail("no flush event")
+	}
+}
+
+func Test_FlushNotifier_DropOldest(t *testing.T) {
+	tester := assert.New(t)
+	n := newFlushNotifier(2)
+	for i := uint16(0); i < 3; i++ {
+		n.notify(FlushEvent{BlockID: i})
+	}
+	n.close()
+	n.notify(FlushEvent{BlockID: 3})
+	var got []uint16
+	for event := range n.ch {
+		got = append(got, event.BlockID)
+	}
+	tester.Equal([]uint16{1, 2}, got)
+}
@@ -0,0 +1,99 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package index
+
+import (
+	"sync/atomic"
+
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+
+	"github.com/apache/skywalking-banyandb/api/common"
+	databasev1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/database/v1"
+	"github.com/apache/skywalking-banyandb/banyand/tsdb"
+	"github.com/apache/skywalking-banyandb/pkg/index"
+	"github.com/apache/skywalking-banyandb/pkg/index/posting"
+	"github.com/apache/skywalking-banyandb/pkg/index/posting/roaring"
+)
+
+var ErrUnsupportedIndexRule = errors.New("unsupported index rule")
+
+// Reader resolves the posting lists of series-local indices.
+type Reader struct {
+	series tsdb.SeriesDatabase
+	// segmentReads counts the index segments opened, one per block visited
+	segmentReads uint64
+}
+
+func NewReader(series tsdb.SeriesDatabase) *Reader {
+	return &Reader{
+		series: series,
+	}
+}
+
+// Lookup returns the items of a single series whose indexed value of rule equals term.
+func (r *Reader) Lookup(seriesID common.SeriesID, rule *databasev1.IndexRule, term []byte,
+	timeRange tsdb.TimeRange) (posting.List, error) {
+	result, err := r.LookupBatch([]common.SeriesID{seriesID}, rule, term, timeRange)
+	if err != nil {
+		return nil, err
+	}
+	return result[seriesID], nil
+}
+
+// LookupBatch resolves the posting lists of many series in one pass over the index segments.
+// Every series in seriesIDs has an entry in the result, which is empty if nothing matches.
+func (r *Reader) LookupBatch(seriesIDs []common.SeriesID, rule *databasev1.IndexRule, term []byte,
+	timeRange tsdb.TimeRange) (result map[common.SeriesID]posting.List, err error) {
+	if rule.GetLocation() != databasev1.IndexRule_LOCATION_SERIES {
+		return nil, errors.Wrapf(ErrUnsupportedIndexRule, "%s is not a series index", rule.GetMetadata().GetName())
+	}
+	searchers, closer, err := r.series.IndexSearchers(timeRange, rule.GetType())
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = multierr.Append(err, closer.Close())
+	}()
+	result = make(map[common.SeriesID]posting.List, len(seriesIDs))
+	for _, id := range seriesIDs {
+		result[id] = roaring.NewPostingList()
+	}
+	for _, searcher := range searchers {
+		atomic.AddUint64(&r.segmentReads, 1)
+		for _, id := range seriesIDs {
+			list, errMatch := searcher.MatchTerms(index.Field{
+				Key: index.FieldKey{
+					SeriesID:    id,
+					IndexRuleID: rule.GetMetadata().GetId(),
+				},
+				Term: term,
+			})
+			if errMatch != nil {
+				return nil, errMatch
+			}
+			if list == nil {
+				continue
+			}
+			if errUnion := result[id].Union(list); errUnion != nil {
+				return nil, errUnion
+			}
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,110 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package index
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/apache/skywalking-banyandb/api/common"
+	commonv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/common/v1"
+	databasev1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/database/v1"
+	"github.com/apache/skywalking-banyandb/banyand/tsdb"
+	"github.com/apache/skywalking-banyandb/pkg/encoding"
+	"github.com/apache/skywalking-banyandb/pkg/index"
+	"github.com/apache/skywalking-banyandb/pkg/logger"
+	"github.com/apache/skywalking-banyandb/pkg/test"
+)
+
+func TestReader_LookupBatch(t *testing.T) {
+	req := require.New(t)
+	req.NoError(logger.Init(logger.Logging{
+		Env:   "dev",
+		Level: "warn",
+	}))
+	tempDir, deferFunc := test.Space(req)
+	defer deferFunc()
+	rule := &databasev1.IndexRule{
+		Metadata: &commonv1.Metadata{
+			Name:  "status",
+			Group: "default",
+			Id:    1,
+		},
+		Tags:     []string{"status"},
+		Type:     databasev1.IndexRule_TYPE_INVERTED,
+		Location: databasev1.IndexRule_LOCATION_SERIES,
+	}
+	db, err := tsdb.OpenDatabase(
+		context.WithValue(context.Background(), logger.ContextKey, logger.GetLogger("test")),
+		tsdb.DatabaseOpts{
+			Location:   tempDir,
+			ShardNum:   1,
+			IndexRules: []*databasev1.IndexRule{rule},
+			EncodingMethod: tsdb.EncodingMethod{
+				EncoderPool: encoding.NewPlainEncoderPool(0),
+				DecoderPool: encoding.NewPlainDecoderPool(0),
+			},
+		})
+	req.NoError(err)
+	defer db.Close()
+	shard, err := db.Shard(0)
+	req.NoError(err)
+
+	now := time.Now()
+	timeRange := tsdb.NewTimeRangeDuration(now.Add(-time.Hour), 2*time.Hour)
+	var seriesIDs []common.SeriesID
+	for i := 0; i < 4; i++ {
+		series, errSeries := shard.Series().Get(tsdb.Entity{tsdb.Entry("productpage"), tsdb.Entry(fmt.Sprintf("10.0.0.%d", i))})
+		req.NoError(errSeries)
+		seriesIDs = append(seriesIDs, series.ID())
+		span, errSpan := series.Span(timeRange)
+		req.NoError(errSpan)
+		for j, status := range []string{"ok", "error", "error"}[:i%3+1] {
+			writer, errWriter := span.WriterBuilder().
+				Family([]byte("searchable"), []byte(status)).
+				Time(now.Add(time.Duration(i*10+j) * time.Millisecond)).
+				Build()
+			req.NoError(errWriter)
+			_, errWriter = writer.Write()
+			req.NoError(errWriter)
+			req.NoError(writer.WriteInvertedIndex(index.Field{
+				Key:  index.FieldKey{IndexRuleID: rule.GetMetadata().GetId()},
+				Term: []byte(status),
+			}))
+		}
+		req.NoError(span.Close())
+	}
+
+	individual := NewReader(shard.Series())
+	batch := NewReader(shard.Series())
+	got, err := batch.LookupBatch(seriesIDs, rule, []byte("error"), timeRange)
+	req.NoError(err)
+	req.Len(got, len(seriesIDs))
+	for i, id := range seriesIDs {
+		want, errLookup := individual.Lookup(id, rule, []byte("error"), timeRange)
+		req.NoError(errLookup)
+		req.Equal(i%3, want.Len())
+		req.True(want.Equal(got[id]), "series %d", id)
+	}
+	req.Greater(individual.segmentReads, uint64(0))
+	req.Less(batch.segmentReads, individual.segmentReads)
+}
@@ -20,6 +20,8 @@ package index
 import (
 	"context"
 	"io"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/pkg/errors"
@@ -35,13 +37,28 @@ import (
 	pbv1 "github.com/apache/skywalking-banyandb/pkg/pb/v1"
 )
 
-type CallbackFn func()
+// flushCheckInterval is how often Flush checks the pending messages
+const flushCheckInterval = 10 * time.Millisecond
+
+// closeTimeout bounds how long Close waits for the pending messages
+const closeTimeout = 30 * time.Second
+
+// queueSize bounds the messages waiting for the generator, beyond which Write blocks
+const queueSize = 1024
+
+// ErrWriterClosed is passed to the callback of the message written after the writer is closed
+var ErrWriterClosed = errors.New("the index writer is closed")
+
+// CallbackFn is invoked once the indices of a message are generated, or with the error discarding the message
+type CallbackFn func(err error)
 
 type Message struct {
 	Value       Value
 	LocalWriter tsdb.Writer
 	BlockCloser io.Closer
 	Cb          CallbackFn
+	// seq is the order the message is enqueued in
+	seq uint64
 }
 
 type Value struct {
@@ -57,11 +74,20 @@ type WriterOptions struct {
 }
 
 type Writer struct {
-	l              *logger.Logger
-	db             tsdb.Database
-	shardNum       uint32
-	ch             chan Message
-	indexRuleIndex []*partition.IndexRuleLocator
+	// enqueued is the sequence of the last enqueued message, and indexed is the one of the last indexed message
+	enqueued uint64
+	indexed  uint64
+	l        *logger.Logger
+	db       tsdb.Database
+	shardNum uint32
+	ch       chan Message
+	done     chan struct{}
+	// closed rejects the messages written after Close, and mu keeps the messages enqueued in the order of their sequences
+	closed   bool
+	mu       sync.Mutex
+	families []*databasev1.TagFamilySpec
+	// indexRuleIndex holds the []*partition.IndexRuleLocator the messages are indexed by
+	indexRuleIndex atomic.Value
 }
 
 func NewWriter(ctx context.Context, options WriterOptions) *Writer {
@@ -74,57 +100,103 @@ func NewWriter(ctx context.Context, options WriterOptions) *Writer {
 	}
 	w.shardNum = options.ShardNum
 	w.db = options.DB
-	w.indexRuleIndex = partition.ParseIndexRuleLocators(options.Families, options.IndexRules)
-	w.ch = make(chan Message)
+	w.families = options.Families
+	w.SetIndexRules(options.IndexRules)
+	w.ch = make(chan Message, queueSize)
+	w.done = make(chan struct{})
 	w.bootIndexGenerator()
 	return w
 }
 
+// SetIndexRules replaces the rules the messages are indexed by, which applies to the messages not indexed yet
+func (s *Writer) SetIndexRules(rules []*databasev1.IndexRule) {
+	s.indexRuleIndex.Store(partition.ParseIndexRuleLocators(s.families, rules))
+}
+
+// WriteIndex generates the index of a rule for an item
+func (s *Writer) WriteIndex(writer tsdb.ItemIndexWriter, ruleIndex *partition.IndexRuleLocator, value Value) error {
+	switch ruleIndex.Rule.GetLocation() {
+	case databasev1.IndexRule_LOCATION_SERIES:
+		return writeLocalIndex(writer, ruleIndex, value)
+	case databasev1.IndexRule_LOCATION_GLOBAL:
+		return s.writeGlobalIndex(ruleIndex, writer.ItemID(), value)
+	}
+	return nil
+}
+
+// Write enqueues the message, whose indices are generated in the order of writing.
+// It blocks while the queue is full, and the message written after Close is discarded.
 func (s *Writer) Write(value Message) {
-	go func(m Message) {
-		defer func() {
-			if recover() != nil {
-				_ = m.BlockCloser.Close()
-			}
-		}()
-		s.ch <- m
-	}(value)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		err := multierr.Append(ErrWriterClosed, value.BlockCloser.Close())
+		if value.Cb != nil {
+			value.Cb(err)
+		}
+		return
+	}
+	value.seq = s.enqueued + 1
+	s.ch <- value
+	atomic.StoreUint64(&s.enqueued, value.seq)
+}
+
+// Flush waits for the indices of the messages written before it's called to be generated.
+// The messages written after that don't hold it back.
+func (s *Writer) Flush(ctx context.Context) error {
+	watermark := atomic.LoadUint64(&s.enqueued)
+	ticker := time.NewTicker(flushCheckInterval)
+	defer ticker.Stop()
+	for atomic.LoadUint64(&s.indexed) < watermark {
+		select {
+		case <-ctx.Done():
+			return errors.WithMessagef(ctx.Err(), "%d messages are pending", watermark-atomic.LoadUint64(&s.indexed))
+		case <-ticker.C:
+		}
+	}
+	return nil
 }
 
+// Close drains the pending messages before stopping the generator, which keeps their indices from being dropped
+// if the database is closed right after. The messages written after Close are discarded.
 func (s *Writer) Close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), closeTimeout)
+	defer cancel()
+	err := s.Flush(ctx)
+	s.mu.Lock()
+	s.closed = true
 	close(s.ch)
-	return nil
+	s.mu.Unlock()
+	<-s.done
+	return err
 }
 
 func (s *Writer) bootIndexGenerator() {
 	go func() {
+		defer close(s.done)
 		for {
 			m, more := <-s.ch
 			if !more {
 				return
 			}
 			var err error
-			for _, ruleIndex := range s.indexRuleIndex {
-				rule := ruleIndex.Rule
-				switch rule.GetLocation() {
-				case databasev1.IndexRule_LOCATION_SERIES:
-					err = multierr.Append(err, writeLocalIndex(m.LocalWriter, ruleIndex, m.Value))
-				case databasev1.IndexRule_LOCATION_GLOBAL:
-					err = multierr.Append(err, s.writeGlobalIndex(ruleIndex, m.LocalWriter.ItemID(), m.Value))
-				}
+			for _, ruleIndex := range s.indexRuleIndex.Load().([]*partition.IndexRuleLocator) {
+				err = multierr.Append(err, s.WriteIndex(m.LocalWriter, ruleIndex, m.Value))
 			}
 			err = multierr.Append(err, m.BlockCloser.Close())
 			if err != nil {
 				s.l.Error().Err(err).Msg("encounter some errors when generating indices")
 			}
+			// the failures of the indices are logged rather than failing the written data
 			if m.Cb != nil {
-				m.Cb()
+				m.Cb(nil)
 			}
+			atomic.StoreUint64(&s.indexed, m.seq)
 		}
 	}()
 }
 
-//TODO: should listen to pipeline in a distributed cluster
+// TODO: should listen to pipeline in a distributed cluster
 func (s *Writer) writeGlobalIndex(ruleIndex *partition.IndexRuleLocator, ref tsdb.GlobalItemID, value Value) error {
 	val, _, err := getIndexValue(ruleIndex, value)
 	if err != nil {
@@ -166,7 +238,7 @@ func (s *Writer) writeGlobalIndex(ruleIndex *partition.IndexRuleLocator, ref tsd
 	return err
 }
 
-func writeLocalIndex(writer tsdb.Writer, ruleIndex *partition.IndexRuleLocator, value Value) (err error) {
+func writeLocalIndex(writer tsdb.IndexWriter, ruleIndex *partition.IndexRuleLocator, value Value) (err error) {
 	val, _, err := getIndexValue(ruleIndex, value)
 	if err != nil {
 		return err
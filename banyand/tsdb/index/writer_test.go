@@ -0,0 +1,122 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package index
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/apache/skywalking-banyandb/pkg/logger"
+)
+
+type countingCloser struct {
+	closed int32
+}
+
+func (c *countingCloser) Close() error {
+	atomic.AddInt32(&c.closed, 1)
+	return nil
+}
+
+func TestWriter_Ordered(t *testing.T) {
+	req := require.New(t)
+	req.NoError(logger.Init(logger.Logging{
+		Env:   "dev",
+		Level: "warn",
+	}))
+	w := NewWriter(context.WithValue(context.Background(), logger.ContextKey, logger.GetLogger("test")), WriterOptions{})
+
+	// the messages beyond the queue size are blocked rather than piling up, and they are indexed in order
+	const num = 3 * queueSize
+	var indexed []int
+	closer := &countingCloser{}
+	for i := 0; i < num; i++ {
+		i := i
+		w.Write(Message{
+			BlockCloser: closer,
+			Cb: func(err error) {
+				if err == nil {
+					indexed = append(indexed, i)
+				}
+			},
+		})
+	}
+	req.NoError(w.Close())
+	req.Len(indexed, num)
+	for i, v := range indexed {
+		req.Equal(i, v)
+	}
+	req.Equal(int32(num), atomic.LoadInt32(&closer.closed))
+
+	// the message written after Close is discarded, and the callback is told so
+	var errCb error
+	w.Write(Message{
+		BlockCloser: closer,
+		Cb: func(err error) {
+			errCb = err
+		},
+	})
+	req.ErrorIs(errCb, ErrWriterClosed)
+	req.Equal(int32(num+1), atomic.LoadInt32(&closer.closed))
+}
+
+func TestWriter_FlushWatermark(t *testing.T) {
+	req := require.New(t)
+	req.NoError(logger.Init(logger.Logging{
+		Env:   "dev",
+		Level: "warn",
+	}))
+	w := NewWriter(context.WithValue(context.Background(), logger.ContextKey, logger.GetLogger("test")), WriterOptions{})
+	blockedWrite := func(gate chan struct{}) {
+		w.Write(Message{
+			BlockCloser: &countingCloser{},
+			Cb: func(error) {
+				<-gate
+			},
+		})
+	}
+	first, second := make(chan struct{}), make(chan struct{})
+	blockedWrite(first)
+
+	// the message written before the flush holds it back
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	req.ErrorIs(w.Flush(ctx), context.DeadlineExceeded)
+
+	flushed := make(chan error)
+	go func() {
+		flushed <- w.Flush(context.Background())
+	}()
+	// the flush takes its watermark before the second message is written
+	time.Sleep(2 * flushCheckInterval)
+	blockedWrite(second)
+	close(first)
+	// the second message is still pending, which doesn't hold the flush back
+	select {
+	case err := <-flushed:
+		req.NoError(err)
+	case <-time.After(5 * time.Second):
+		req.FailNow("the flush waits for the message written after it")
+	}
+	close(second)
+	req.NoError(w.Close())
+}
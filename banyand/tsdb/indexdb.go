@@ -51,7 +51,7 @@ var _ IndexDatabase = (*indexDB)(nil)
 
 type indexDB struct {
 	shardID common.ShardID
-	lst     []*segment
+	segCtrl *segmentController
 }
 
 func (i *indexDB) Seek(field index.Field) ([]GlobalItemID, error) {
@@ -60,29 +60,31 @@ func (i *indexDB) Seek(field index.Field) ([]GlobalItemID, error) {
 	if err != nil {
 		return nil, err
 	}
-	err = i.lst[0].globalIndex.GetAll(f, func(rawBytes []byte) error {
-		id := &GlobalItemID{}
-		errUnMarshal := id.UnMarshal(rawBytes)
-		if errUnMarshal != nil {
-			return errUnMarshal
+	for _, s := range i.segCtrl.segments() {
+		err = s.globalIndex.GetAll(f, func(rawBytes []byte) error {
+			id := &GlobalItemID{}
+			errUnMarshal := id.UnMarshal(rawBytes)
+			if errUnMarshal != nil {
+				return errUnMarshal
+			}
+			result = append(result, *id)
+			return nil
+		})
+		if err != nil && err != kv.ErrKeyNotFound {
+			return result, err
 		}
-		result = append(result, *id)
-		return nil
-	})
-	if err == kv.ErrKeyNotFound {
-		return result, nil
 	}
-	return result, err
+	return result, nil
 }
 
 func (i *indexDB) WriterBuilder() IndexWriterBuilder {
-	return newIndexWriterBuilder(i.lst)
+	return newIndexWriterBuilder(i.segCtrl.segments())
 }
 
-func newIndexDatabase(_ context.Context, id common.ShardID, lst []*segment) (IndexDatabase, error) {
+func newIndexDatabase(_ context.Context, id common.ShardID, segCtrl *segmentController) (IndexDatabase, error) {
 	return &indexDB{
 		shardID: id,
-		lst:     lst,
+		segCtrl: segCtrl,
 	}, nil
 }
 
@@ -0,0 +1,122 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tsdb
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrInvalidPartition        = errors.New("invalid partition")
+	ErrSegmentIntervalConflict = errors.New("either the segment interval or the partitioner is allowed")
+)
+
+// SegmentInterval is the window covered by a segment. The segments rotate at the multiples of the interval in UTC,
+// which trades the number of the directories for the granularity of the retention.
+type SegmentInterval int
+
+const (
+	// SegmentIntervalUnspecified leaves the segments to DatabaseOpts.Partitioner, and keeps a single open segment without it
+	SegmentIntervalUnspecified SegmentInterval = iota
+	SegmentIntervalHour
+	SegmentIntervalDay
+	SegmentIntervalWeek
+)
+
+func (i SegmentInterval) Duration() time.Duration {
+	switch i {
+	case SegmentIntervalHour:
+		return time.Hour
+	case SegmentIntervalDay:
+		return 24 * time.Hour
+	case SegmentIntervalWeek:
+		return 7 * 24 * time.Hour
+	}
+	return 0
+}
+
+func (i SegmentInterval) String() string {
+	switch i {
+	case SegmentIntervalHour:
+		return "hour"
+	case SegmentIntervalDay:
+		return "day"
+	case SegmentIntervalWeek:
+		return "week"
+	}
+	return "unspecified"
+}
+
+// partitioner returns the Partitioner rotating the segments at the interval
+func (i SegmentInterval) partitioner() (Partitioner, error) {
+	if i.Duration() == 0 {
+		return nil, errors.WithMessagef(ErrInvalidPartition, "unknown segment interval %d", i)
+	}
+	return NewIntervalPartitioner(i.Duration())
+}
+
+// Partitioner maps a timestamp to the time range of the partition holding it.
+// The segments are created on demand to cover the partitions, so the data of a partition never spans segments.
+type Partitioner interface {
+	Partition(ts time.Time) TimeRange
+}
+
+var (
+	_ Partitioner = (*intervalPartitioner)(nil)
+	_ Partitioner = (*monthPartitioner)(nil)
+)
+
+// intervalPartitioner aligns the partitions to the multiples of the interval in UTC.
+// Since the zero time is a Monday, the weekly partitions start on Mondays.
+type intervalPartitioner struct {
+	interval time.Duration
+}
+
+func NewIntervalPartitioner(interval time.Duration) (Partitioner, error) {
+	if interval <= 0 {
+		return nil, errors.WithMessagef(ErrInvalidPartition, "interval %s should be positive", interval)
+	}
+	return &intervalPartitioner{interval: interval}, nil
+}
+
+func (p *intervalPartitioner) Partition(ts time.Time) TimeRange {
+	start := ts.UTC().Truncate(p.interval)
+	return NewTimeRangeDuration(start, p.interval)
+}
+
+// monthPartitioner aligns the partitions to the calendar months in UTC
+type monthPartitioner struct {
+	months int
+}
+
+func NewMonthPartitioner(months int) (Partitioner, error) {
+	if months <= 0 {
+		return nil, errors.WithMessagef(ErrInvalidPartition, "months %d should be positive", months)
+	}
+	return &monthPartitioner{months: months}, nil
+}
+
+func (p *monthPartitioner) Partition(ts time.Time) TimeRange {
+	ts = ts.UTC()
+	index := ts.Year()*12 + int(ts.Month()) - 1
+	index -= index % p.months
+	start := time.Date(index/12, time.Month(index%12+1), 1, 0, 0, 0, 0, time.UTC)
+	return NewTimeRange(start, start.AddDate(0, p.months, 0))
+}
@@ -0,0 +1,228 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tsdb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Partitioner(t *testing.T) {
+	ts := time.Date(2021, 11, 17, 10, 59, 59, 999, time.UTC)
+	hourly, err := NewIntervalPartitioner(time.Hour)
+	require.NoError(t, err)
+	weekly, err := NewIntervalPartitioner(7 * 24 * time.Hour)
+	require.NoError(t, err)
+	quarterly, err := NewMonthPartitioner(3)
+	require.NoError(t, err)
+	tests := []struct {
+		name        string
+		partitioner Partitioner
+		want        TimeRange
+	}{
+		{
+			name:        "hour",
+			partitioner: hourly,
+			want: NewTimeRange(time.Date(2021, 11, 17, 10, 0, 0, 0, time.UTC),
+				time.Date(2021, 11, 17, 11, 0, 0, 0, time.UTC)),
+		},
+		{
+			name:        "week starting on Monday",
+			partitioner: weekly,
+			want: NewTimeRange(time.Date(2021, 11, 15, 0, 0, 0, 0, time.UTC),
+				time.Date(2021, 11, 22, 0, 0, 0, 0, time.UTC)),
+		},
+		{
+			name:        "quarter",
+			partitioner: quarterly,
+			want: NewTimeRange(time.Date(2021, 10, 1, 0, 0, 0, 0, time.UTC),
+				time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.partitioner.Partition(ts)
+			assert.True(t, tt.want.Start.Equal(got.Start), "start: want %s, got %s", tt.want.Start, got.Start)
+			assert.True(t, tt.want.End.Equal(got.End), "end: want %s, got %s", tt.want.End, got.End)
+		})
+	}
+	_, err = NewIntervalPartitioner(0)
+	assert.ErrorIs(t, err, ErrInvalidPartition)
+}
+
+func Test_Database_Partitioner(t *testing.T) {
+	tester := assert.New(t)
+	partitioner, err := NewIntervalPartitioner(time.Hour)
+	tester.NoError(err)
+	_, deferFunc, db := setUpWithOpts(require.New(t), func(opts *DatabaseOpts) {
+		opts.Partitioner = partitioner
+	})
+	defer deferFunc()
+	s, err := db.Shard(0)
+	tester.NoError(err)
+	series, err := s.Series().Get(Entity{Entry("productpage"), Entry("10.0.0.1")})
+	tester.NoError(err)
+	// the partitions are out of the current one created along with the shard
+	boundary := time.Now().Add(-24 * time.Hour).Truncate(time.Hour)
+	span, err := series.Span(NewTimeRangeDuration(boundary.Add(-time.Hour), 2*time.Hour))
+	tester.NoError(err)
+	defer func() {
+		tester.NoError(span.Close())
+	}()
+	segIDs := make(map[time.Time]uint16)
+	for _, ts := range []time.Time{boundary.Add(-time.Second), boundary, boundary.Add(time.Second)} {
+		writer, errWriter := span.WriterBuilder().
+			Family([]byte("searchable"), []byte(ts.String())).
+			Time(ts).
+			Build()
+		tester.NoError(errWriter)
+		id, errWriter := writer.Write()
+		tester.NoError(errWriter)
+		segIDs[ts] = id.segID
+	}
+	tester.NotEqual(segIDs[boundary.Add(-time.Second)], segIDs[boundary])
+	tester.Equal(segIDs[boundary], segIDs[boundary.Add(time.Second)])
+
+	sc := s.(*shard).segmentController
+	for ts, segID := range segIDs {
+		seg := sc.get(segID)
+		tester.True(seg.startTime.Equal(ts.Truncate(time.Hour)))
+		tester.True(seg.endTime.Equal(ts.Truncate(time.Hour).Add(time.Hour)))
+	}
+	tester.Len(sc.segments(), 3)
+}
+
+func Test_Database_SegmentInterval(t *testing.T) {
+	for _, interval := range []SegmentInterval{SegmentIntervalHour, SegmentIntervalDay} {
+		t.Run(interval.String(), func(t *testing.T) {
+			tester := require.New(t)
+			_, deferFunc, db := setUpWithOpts(tester, func(opts *DatabaseOpts) {
+				opts.SegmentInterval = interval
+			})
+			defer deferFunc()
+			s, err := db.Shard(0)
+			tester.NoError(err)
+			series, err := s.Series().Get(Entity{Entry("productpage"), Entry("10.0.0.1")})
+			tester.NoError(err)
+			boundary := time.Now().UTC().Add(-7 * 24 * time.Hour).Truncate(interval.Duration())
+			segIDs := make(map[time.Time]uint16)
+			for _, ts := range []time.Time{boundary.Add(-time.Second), boundary, boundary.Add(time.Second)} {
+				segIDs[ts] = writeAt(tester, series, ts).segID
+			}
+			tester.NotEqual(segIDs[boundary.Add(-time.Second)], segIDs[boundary])
+			tester.Equal(segIDs[boundary], segIDs[boundary.Add(time.Second)])
+			sc := s.(*shard).segmentController
+			for ts, segID := range segIDs {
+				timeRange := sc.get(segID).TimeRange()
+				tester.True(timeRange.Start.Equal(ts.Truncate(interval.Duration())))
+				tester.Equal(interval.Duration(), timeRange.End.Sub(timeRange.Start))
+			}
+		})
+	}
+}
+
+func Test_Database_InvalidSegmentInterval(t *testing.T) {
+	tester := require.New(t)
+	partitioner, err := NewIntervalPartitioner(time.Hour)
+	tester.NoError(err)
+	ctx, opts, removeSpace := setUpOpts(tester, func(opts *DatabaseOpts) {
+		opts.SegmentInterval = SegmentIntervalHour
+		opts.Partitioner = partitioner
+	})
+	defer removeSpace()
+	_, err = OpenDatabase(ctx, opts)
+	tester.ErrorIs(err, ErrSegmentIntervalConflict)
+	opts.Partitioner = nil
+	opts.SegmentInterval = SegmentIntervalWeek + 1
+	_, err = OpenDatabase(ctx, opts)
+	tester.ErrorIs(err, ErrInvalidPartition)
+}
+
+func Test_Database_ChangeSegmentInterval(t *testing.T) {
+	tester := require.New(t)
+	ctx, opts, removeSpace := setUpOpts(tester, func(opts *DatabaseOpts) {
+		opts.SegmentInterval = SegmentIntervalHour
+	})
+	defer removeSpace()
+	entity := Entity{Entry("productpage"), Entry("10.0.0.1")}
+	day := time.Now().UTC().Add(-48 * time.Hour).Truncate(24 * time.Hour)
+
+	db, err := OpenDatabase(ctx, opts)
+	tester.NoError(err)
+	s, err := db.Shard(0)
+	tester.NoError(err)
+	series, err := s.Series().Get(entity)
+	tester.NoError(err)
+	ids := map[GlobalItemID]time.Time{}
+	for _, ts := range []time.Time{day.Add(30 * time.Minute), day.Add(5*time.Hour + 30*time.Minute)} {
+		ids[writeAt(tester, series, ts)] = ts
+	}
+	tester.NoError(db.Close())
+
+	// the hourly segments are read as they are, and the daily ones fill the gaps around them
+	opts.SegmentInterval = SegmentIntervalDay
+	db, err = OpenDatabase(ctx, opts)
+	tester.NoError(err)
+	defer func() {
+		tester.NoError(db.Close())
+	}()
+	s, err = db.Shard(0)
+	tester.NoError(err)
+	series, err = s.Series().Get(entity)
+	tester.NoError(err)
+	between := day.Add(3 * time.Hour)
+	id := writeAt(tester, series, between)
+	ids[id] = between
+	timeRange := s.(*shard).segmentController.get(id.segID).TimeRange()
+	tester.True(timeRange.Start.Equal(day.Add(time.Hour)))
+	tester.True(timeRange.End.Equal(day.Add(5 * time.Hour)))
+	before := day.Add(-12 * time.Hour)
+	id = writeAt(tester, series, before)
+	ids[id] = before
+	timeRange = s.(*shard).segmentController.get(id.segID).TimeRange()
+	tester.True(timeRange.Start.Equal(day.Add(-24 * time.Hour)))
+	tester.True(timeRange.End.Equal(day))
+
+	for id, ts := range ids {
+		item, closer, errGet := series.Get(id)
+		tester.NoError(errGet)
+		v, errFamily := item.Family("searchable")
+		tester.NoError(errFamily)
+		tester.Equal(ts.String(), string(v))
+		tester.NoError(closer.Close())
+	}
+}
+
+func writeAt(tester *require.Assertions, series Series, ts time.Time) GlobalItemID {
+	span, err := series.Span(NewTimeRangeDuration(ts, time.Nanosecond))
+	tester.NoError(err)
+	defer func() {
+		tester.NoError(span.Close())
+	}()
+	writer, err := span.WriterBuilder().
+		Family([]byte("searchable"), []byte(ts.String())).
+		Time(ts).
+		Build()
+	tester.NoError(err)
+	id, err := writer.Write()
+	tester.NoError(err)
+	return id
+}
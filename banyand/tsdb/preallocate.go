@@ -0,0 +1,62 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tsdb
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+var errPreallocateUnsupported = errors.New("pre-allocation is not supported")
+
+// preallocate reserves disk space for the files which take appends in the dir,
+// e.g. the value log and the memtable of the kv store.
+// The file size is untouched, only the disk blocks are allocated in advance.
+func preallocate(dir string, size int64) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read %s", dir)
+	}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		switch filepath.Ext(entry.Name()) {
+		case ".vlog", ".mem":
+		default:
+			continue
+		}
+		if err = preallocateFile(filepath.Join(dir, entry.Name()), size); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func preallocateFile(path string, size int64) error {
+	f, err := os.OpenFile(path, os.O_RDWR, 0)
+	if err != nil {
+		return errors.Wrapf(err, "failed to open %s", path)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	return fallocate(f, size)
+}
@@ -0,0 +1,68 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build linux
+// +build linux
+
+package tsdb
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Block_Preallocate(t *testing.T) {
+	const size int64 = 8 << 20
+	tests := []struct {
+		name        string
+		preallocate int64
+		wantSized   bool
+	}{
+		{
+			name:        "pre-allocate",
+			preallocate: size,
+			wantSized:   true,
+		},
+		{
+			name: "no pre-allocation",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tester := assert.New(t)
+			tempDir, deferFunc, _ := setUpWithOpts(require.New(t), func(opts *DatabaseOpts) {
+				opts.PreallocateBytes = tt.preallocate
+			})
+			defer deferFunc()
+			segPath := fmt.Sprintf(segTemplate, fmt.Sprintf(shardTemplate, tempDir, 0), time.Now().Format(segFormat))
+			info, err := os.Stat(fmt.Sprintf(blockTemplate, segPath, time.Now().Format(blockFormat)) + "/store/000001.vlog")
+			tester.NoError(err)
+			allocated := info.Sys().(*syscall.Stat_t).Blocks * 512
+			if tt.wantSized {
+				tester.GreaterOrEqual(allocated, size)
+				return
+			}
+			tester.Less(allocated, size)
+		})
+	}
+}
@@ -0,0 +1,185 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tsdb
+
+import (
+	"context"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+
+	"github.com/apache/skywalking-banyandb/pkg/logger"
+)
+
+var ErrExpiredItem = errors.New("the segment holding the item has expired")
+
+// maxTime is later than the end of any sealed segment
+var maxTime = time.Unix(0, math.MaxInt64)
+
+// DefaultRetentionCheckInterval is how often the expired segments are removed if DatabaseOpts.RetentionCheckInterval is absent
+const DefaultRetentionCheckInterval = 10 * time.Minute
+
+// retention removes the segments whose data are all older than the ttl, and the oldest ones beyond maxSegments.
+// A zero ttl or maxSegments disables the corresponding check.
+type retention struct {
+	l           *logger.Logger
+	ttl         time.Duration
+	maxSegments uint32
+	clock       clockwork.Clock
+	reap        func(deadline time.Time, maxSegments uint32) (int, error)
+	stopCh      chan struct{}
+	wg          sync.WaitGroup
+}
+
+func newRetention(l *logger.Logger, ttl time.Duration, maxSegments uint32, clock clockwork.Clock,
+	reap func(deadline time.Time, maxSegments uint32) (int, error)) *retention {
+	return &retention{
+		l:           l,
+		ttl:         ttl,
+		maxSegments: maxSegments,
+		clock:       clock,
+		reap:        reap,
+		stopCh:      make(chan struct{}),
+	}
+}
+
+// run removes the expired segments and returns the number of them
+func (r *retention) run() (int, error) {
+	var deadline time.Time
+	if r.ttl > 0 {
+		deadline = r.clock.Now().Add(-r.ttl)
+	}
+	return r.reap(deadline, r.maxSegments)
+}
+
+// start runs the retention periodically until stop is called
+func (r *retention) start(interval time.Duration) {
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		ticker := r.clock.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.Chan():
+				if _, err := r.run(); err != nil {
+					r.l.Warn().Err(err).Msg("failed to remove the expired segments")
+				}
+			case <-r.stopCh:
+				return
+			}
+		}
+	}()
+}
+
+func (r *retention) stop() {
+	close(r.stopCh)
+	r.wg.Wait()
+}
+
+// retain removes the sealed segments ending at or before the deadline, and then the oldest sealed ones beyond
+// maxSegments. A zero deadline or maxSegments skips the corresponding check.
+// Their ids are kept, so the ids of the others stay the positions in the list.
+func (sc *segmentController) retain(deadline time.Time, maxSegments uint32) (int, error) {
+	var expired []*segment
+	sc.Lock()
+	live := make([]*segment, 0, len(sc.lst))
+	for i, seg := range sc.lst {
+		if seg == nil {
+			continue
+		}
+		if deadline.IsZero() || !seg.endedBy(deadline) {
+			live = append(live, seg)
+			continue
+		}
+		expired = append(expired, seg)
+		sc.lst[i] = nil
+	}
+	if maxSegments > 0 && len(live) > int(maxSegments) {
+		// the late data might create a segment older than the ones created before it
+		sort.SliceStable(live, func(i, j int) bool {
+			return live[i].startTime.Before(live[j].startTime)
+		})
+		excess := len(live) - int(maxSegments)
+		for _, seg := range live {
+			if excess == 0 {
+				break
+			}
+			// the open segment is always kept
+			if !seg.endedBy(maxTime) {
+				continue
+			}
+			expired = append(expired, seg)
+			sc.lst[seg.id] = nil
+			excess--
+		}
+	}
+	var err error
+	if len(expired) > 0 {
+		err = sc.persistManifest()
+	}
+	sc.Unlock()
+	for _, seg := range expired {
+		// the segment read at the moment is removed once its readers release it
+		err = multierr.Append(err, seg.expire(sc.removed))
+	}
+	return len(expired), err
+}
+
+// removed cleans up the data out of the expired segment, which is removed already
+func (sc *segmentController) removed(seg *segment) error {
+	err := sc.deleteOffloaded(seg)
+	if sc.onRemove != nil {
+		err = multierr.Append(err, sc.onRemove(seg))
+	}
+	return err
+}
+
+// deleteOffloaded removes the blocks of the expired segment from the BlockStore
+func (sc *segmentController) deleteOffloaded(seg *segment) error {
+	ref, ok := sc.ctx.Value(blockStoreKey).(*blockStoreRef)
+	if !ok {
+		return nil
+	}
+	key, err := ref.key(seg.path)
+	if err != nil {
+		return err
+	}
+	return errors.Wrapf(ref.store.Delete(context.Background(), key+"/"), "failed to remove %s from the block store", seg.path)
+}
+
+func (d *database) Retain() (int, error) {
+	if d.retention == nil {
+		return 0, nil
+	}
+	return d.retention.run()
+}
+
+func (d *database) reapSegments(deadline time.Time, maxSegments uint32) (count int, err error) {
+	for _, s := range d.sLst {
+		n, errShard := s.(*shard).segmentController.retain(deadline, maxSegments)
+		count += n
+		err = multierr.Append(err, errShard)
+	}
+	return count, err
+}
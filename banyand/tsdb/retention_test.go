@@ -0,0 +1,215 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tsdb
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Database_Retention(t *testing.T) {
+	req := require.New(t)
+	daily, err := NewIntervalPartitioner(24 * time.Hour)
+	req.NoError(err)
+	ctx, opts, removeSpace := setUpOpts(req, func(opts *DatabaseOpts) {
+		opts.Partitioner = daily
+		opts.TTL = 48 * time.Hour
+		opts.RetentionCheckInterval = time.Minute
+	})
+	defer removeSpace()
+	tempDir := opts.Location
+	now := time.Now()
+	clock := clockwork.NewFakeClockAt(now)
+	db, err := OpenDatabase(context.WithValue(ctx, clockKey, clock), opts)
+	req.NoError(err)
+	defer func() {
+		req.NoError(db.Close())
+	}()
+	s, err := db.Shard(0)
+	req.NoError(err)
+	series, err := s.Series().Get(Entity{Entry("productpage"), Entry("10.0.0.1")})
+	req.NoError(err)
+	for _, ts := range []time.Time{now.Add(-72 * time.Hour), now.Add(-36 * time.Hour), now} {
+		span, errSpan := series.Span(NewTimeRangeDuration(ts, time.Hour))
+		req.NoError(errSpan)
+		writer, errWriter := span.WriterBuilder().
+			Family([]byte("searchable"), []byte("200")).
+			Time(ts).
+			Build()
+		req.NoError(errWriter)
+		_, errWriter = writer.Write()
+		req.NoError(errWriter)
+		req.NoError(span.Close())
+	}
+	segments := func() []string {
+		paths, errGlob := filepath.Glob(filepath.Join(tempDir, "shard-0", "seg-*"))
+		req.NoError(errGlob)
+		return paths
+	}
+	req.Len(segments(), 3)
+
+	// the segment 3 days ago has expired, which is removed by the manual trigger
+	n, err := db.Retain()
+	req.NoError(err)
+	req.Equal(1, n)
+	req.Len(segments(), 2)
+
+	// the segment 36 hours ago expires within the next 36 hours, and the check on schedule removes it
+	clock.BlockUntil(1)
+	clock.Advance(36 * time.Hour)
+	req.Eventually(func() bool {
+		return len(segments()) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	n, err = db.Retain()
+	req.NoError(err)
+	req.Equal(0, n)
+	req.Len(s.(*shard).segmentController.segments(), 1)
+}
+
+func Test_Database_MaxSegments(t *testing.T) {
+	req := require.New(t)
+	daily, err := NewIntervalPartitioner(24 * time.Hour)
+	req.NoError(err)
+	tempDir, deferFunc, db := setUpWithOpts(req, func(opts *DatabaseOpts) {
+		opts.Partitioner = daily
+		opts.MaxSegments = 2
+	})
+	defer deferFunc()
+	s, err := db.Shard(0)
+	req.NoError(err)
+	series, err := s.Series().Get(Entity{Entry("productpage"), Entry("10.0.0.1")})
+	req.NoError(err)
+	now := time.Now()
+	ids := make(map[time.Duration]GlobalItemID)
+	// the late data creates the oldest segment after the others
+	for _, ago := range []time.Duration{0, 72 * time.Hour, 36 * time.Hour} {
+		ts := now.Add(-ago)
+		span, errSpan := series.Span(NewTimeRangeDuration(ts, time.Hour))
+		req.NoError(errSpan)
+		writer, errWriter := span.WriterBuilder().
+			Family([]byte("searchable"), []byte("200")).
+			Time(ts).
+			Build()
+		req.NoError(errWriter)
+		ids[ago], errWriter = writer.Write()
+		req.NoError(errWriter)
+		req.NoError(span.Close())
+	}
+	segments := func() []string {
+		paths, errGlob := filepath.Glob(filepath.Join(tempDir, "shard-0", "seg-*"))
+		req.NoError(errGlob)
+		return paths
+	}
+	req.Len(segments(), 3)
+
+	// the oldest segment is being read, and its removal waits for the reader
+	item, closer, err := series.Get(ids[72*time.Hour])
+	req.NoError(err)
+	retained := make(chan int)
+	go func() {
+		n, errRetain := db.Retain()
+		req.NoError(errRetain)
+		retained <- n
+	}()
+	v, err := item.Family("searchable")
+	req.NoError(err)
+	req.Equal([]byte("200"), v)
+	req.NoError(closer.Close())
+	req.Equal(1, <-retained)
+	req.Len(segments(), 2)
+	_, _, err = series.Get(ids[72*time.Hour])
+	req.Error(err)
+	for _, ago := range []time.Duration{0, 36 * time.Hour} {
+		_, closer, err = series.Get(ids[ago])
+		req.NoError(err)
+		req.NoError(closer.Close())
+	}
+
+	n, err := db.Retain()
+	req.NoError(err)
+	req.Zero(n)
+}
+
+func Test_Database_RetainPinnedSegment(t *testing.T) {
+	req := require.New(t)
+	daily, err := NewIntervalPartitioner(24 * time.Hour)
+	req.NoError(err)
+	ctx, opts, removeSpace := setUpOpts(req, func(opts *DatabaseOpts) {
+		opts.Partitioner = daily
+		opts.TTL = 48 * time.Hour
+	})
+	defer removeSpace()
+	now := time.Now()
+	db, err := OpenDatabase(ctx, opts)
+	req.NoError(err)
+	defer func() {
+		req.NoError(db.Close())
+	}()
+	s, err := db.Shard(0)
+	req.NoError(err)
+	series, err := s.Series().Get(Entity{Entry("productpage"), Entry("10.0.0.1")})
+	req.NoError(err)
+	old := now.Add(-72 * time.Hour)
+	span, err := series.Span(NewTimeRangeDuration(old, time.Hour))
+	req.NoError(err)
+	writer, err := span.WriterBuilder().
+		Family([]byte("searchable"), []byte("200")).
+		Time(old).
+		Build()
+	req.NoError(err)
+	id, err := writer.Write()
+	req.NoError(err)
+	req.NoError(span.Close())
+
+	segments := s.SegmentsInRange(old, old.Add(time.Hour))
+	req.Len(segments, 1)
+	path := s.(*shard).segmentController.get(segments[0].ID()).path
+	closer, err := segments[0].OpenBlockForRead(0)
+	req.NoError(err)
+	_, err = segments[0].OpenBlockForRead(1)
+	req.ErrorIs(err, ErrBlockAbsent)
+
+	// the expired segment leaves the shard at once, while its files survive until the reader releases them
+	removed := make(chan error)
+	go func() {
+		_, errRetain := db.Retain()
+		removed <- errRetain
+	}()
+	select {
+	case err = <-removed:
+		req.NoError(err)
+	case <-time.After(5 * time.Second):
+		req.FailNow("the retention waits for the reader")
+	}
+	req.Empty(s.SegmentsInRange(old, old.Add(time.Hour)))
+	_, _, err = series.Get(id)
+	req.ErrorIs(err, ErrExpiredItem)
+	_, err = os.Stat(path)
+	req.NoError(err)
+	req.NoError(closer.Close())
+	_, err = os.Stat(path)
+	req.ErrorIs(err, os.ErrNotExist)
+	_, err = segments[0].OpenBlockForRead(0)
+	req.ErrorIs(err, ErrExpiredItem)
+}
@@ -19,25 +19,146 @@ package tsdb
 
 import (
 	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+
 	"github.com/apache/skywalking-banyandb/banyand/kv"
 	"github.com/apache/skywalking-banyandb/pkg/logger"
 )
 
+var (
+	ErrSegmentImmutable = errors.New("the segment is sealed and its grace period is over")
+	ErrBlockAbsent      = errors.New("the block is absent in the segment")
+)
+
+// Segment is a directory of a shard holding the data of a time window
+type Segment interface {
+	ID() uint16
+	// TimeRange returns the window covered by the segment, whose end is zero if the segment is open
+	TimeRange() TimeRange
+	// OpenBlockForRead pins the block, whose files survive the removal of the segment until the closer is closed.
+	// It fails with ErrExpiredItem if the segment has been removed.
+	OpenBlockForRead(blockID uint16) (io.Closer, error)
+}
+
+var _ Segment = (*segment)(nil)
+
 type segment struct {
+	id   uint16
 	path string
+	// ctx creates the blocks of the segment
+	ctx context.Context
 
-	lst         []*block
-	globalIndex kv.Store
+	// lst holds the blocks ordered by their start time
+	lst []*block
+	// aliases map the ids of the merged blocks to the id of the one they're merged into
+	aliases       map[uint16]uint16
+	nextBlockID   uint16
+	blockInterval time.Duration
+	globalIndex   kv.Store
 	sync.Mutex
 	l         *logger.Logger
 	startTime time.Time
 	endTime   time.Time
+	// refs counts the controller holding the segment and the readers of its blocks.
+	// The segment is removed once the controller expires it and all the readers release it.
+	refs     int32
+	onRemove func(seg *segment) error
+}
+
+func (s *segment) ID() uint16 {
+	return s.id
+}
+
+func (s *segment) TimeRange() TimeRange {
+	s.Lock()
+	defer s.Unlock()
+	return NewTimeRange(s.startTime, s.endTime)
+}
+
+func (s *segment) OpenBlockForRead(blockID uint16) (io.Closer, error) {
+	b := s.block(blockID)
+	if b == nil {
+		return nil, errors.Wrapf(ErrBlockAbsent, "block %d of segment %d", blockID, s.id)
+	}
+	return b.delegate()
+}
+
+// block returns the block of id, which might be the one a block of id is merged into. It's nil if the block is absent.
+func (s *segment) block(id uint16) *block {
+	s.Lock()
+	defer s.Unlock()
+	if alias, ok := s.aliases[id]; ok {
+		id = alias
+	}
+	for _, b := range s.lst {
+		if b.blockID == id {
+			return b
+		}
+	}
+	return nil
+}
+
+// incRef pins the segment, which fails if the segment has been removed
+func (s *segment) incRef() bool {
+	for {
+		refs := atomic.LoadInt32(&s.refs)
+		if refs <= 0 {
+			return false
+		}
+		if atomic.CompareAndSwapInt32(&s.refs, refs, refs+1) {
+			return true
+		}
+	}
+}
+
+// dscRef unpins the segment, and the last one removes the expired segment
+func (s *segment) dscRef() error {
+	if atomic.AddInt32(&s.refs, -1) > 0 {
+		return nil
+	}
+	return s.remove()
+}
+
+// expire drops the reference of the controller, so the segment is removed once its readers release it
+func (s *segment) expire(onRemove func(seg *segment) error) error {
+	s.Lock()
+	s.onRemove = onRemove
+	s.Unlock()
+	return s.dscRef()
+}
+
+func (s *segment) remove() error {
+	s.Lock()
+	onRemove := s.onRemove
+	s.Unlock()
+	s.close()
+	if err := os.RemoveAll(s.path); err != nil {
+		return errors.Wrapf(err, "failed to remove %s", s.path)
+	}
+	if onRemove != nil {
+		if err := onRemove(s); err != nil {
+			return err
+		}
+	}
+	s.l.Info().Str("path", s.path).Time("end", s.endTime).Msg("removed an expired segment")
+	return nil
 }
 
 func (s *segment) contains(ts time.Time) bool {
+	s.Lock()
+	defer s.Unlock()
 	greaterAndEqualStart := s.startTime.Equal(ts) || s.startTime.Before(ts)
 	if s.endTime.IsZero() {
 		return greaterAndEqualStart
@@ -45,10 +166,15 @@ func (s *segment) contains(ts time.Time) bool {
 	return greaterAndEqualStart && s.endTime.After(ts)
 }
 
-func newSegment(ctx context.Context, path string) (s *segment, err error) {
+// newSegment creates a segment starting from startTime, and a zero endTime leaves it open
+func newSegment(ctx context.Context, id uint16, path string, startTime, endTime time.Time) (s *segment, err error) {
 	s = &segment{
+		id:        id,
 		path:      path,
-		startTime: time.Now(),
+		startTime: startTime,
+		endTime:   endTime,
+		refs:      1,
+		aliases:   make(map[uint16]uint16),
 	}
 	parentLogger := ctx.Value(logger.ContextKey)
 	if parentLogger != nil {
@@ -56,6 +182,10 @@ func newSegment(ctx context.Context, path string) (s *segment, err error) {
 			s.l = pl.Named("segment")
 		}
 	}
+	if interval, ok := ctx.Value(blockIntervalKey).(time.Duration); ok {
+		s.blockInterval = interval
+	}
+	s.ctx = context.WithValue(ctx, logger.ContextKey, s.l)
 	indexPath, err := mkdir(globalIndexTemplate, path)
 	if err != nil {
 		return nil, err
@@ -63,22 +193,236 @@ func newSegment(ctx context.Context, path string) (s *segment, err error) {
 	if s.globalIndex, err = kv.OpenStore(0, indexPath, kv.StoreWithLogger(s.l)); err != nil {
 		return nil, err
 	}
-	blockPath, err := mkdir(blockTemplate, path, time.Now().Format(blockFormat))
+	windows, err := readBlocksManifest(path)
 	if err != nil {
 		return nil, err
 	}
-	var b *block
-	if b, err = newBlock(context.WithValue(ctx, logger.ContextKey, s.l), blockOpts{
-		path: blockPath,
-	}); err != nil {
+	s.Lock()
+	defer s.Unlock()
+	if windows == nil {
+		err = s.createFirstBlock()
+	} else {
+		err = s.loadBlocks(windows)
+	}
+	if err != nil {
 		return nil, err
 	}
-	{
-		s.Lock()
-		defer s.Unlock()
+	return s, s.persistBlocks()
+}
+
+// createFirstBlock creates the block at the start of the segment. The block left by a segment without the blocks
+// manifest covers the whole segment. The caller should hold the lock of s.
+func (s *segment) createFirstBlock() error {
+	name := s.startTime.Format(blockFormat)
+	endTime := s.endTime
+	if _, err := os.Stat(fmt.Sprintf(blockTemplate, s.path, name)); errors.Is(err, os.ErrNotExist) {
+		endTime = s.blockEnd(s.startTime)
+	}
+	_, err := s.createBlock(name, s.startTime, endTime)
+	return err
+}
+
+// loadBlocks reopens the blocks recorded by the manifest, and removes the directories of the other blocks,
+// which are left by a crashed merge. The caller should hold the lock of s.
+func (s *segment) loadBlocks(windows []blockWindow) error {
+	dirs := make(map[string]struct{}, len(windows))
+	for _, w := range windows {
+		var endTime time.Time
+		if w.End != 0 {
+			endTime = time.Unix(0, w.End)
+		}
+		// the quarantined block is reopened empty
+		blockPath := filepath.Join(s.path, w.Dir)
+		if err := os.MkdirAll(blockPath, dirPerm); err != nil {
+			return errors.Wrapf(err, "failed to create %s", blockPath)
+		}
+		b, err := newBlock(s.ctx, blockOpts{
+			segID:     s.id,
+			blockID:   w.ID,
+			segment:   s,
+			path:      blockPath,
+			startTime: time.Unix(0, w.Start),
+			endTime:   endTime,
+		})
+		if err != nil {
+			return errors.WithMessagef(err, "failed to load the block %s", w.Dir)
+		}
 		s.lst = append(s.lst, b)
+		dirs[w.Dir] = struct{}{}
+		for _, alias := range append(w.Aliases, w.ID) {
+			if alias >= s.nextBlockID {
+				s.nextBlockID = alias + 1
+			}
+			if alias != w.ID {
+				s.aliases[alias] = w.ID
+			}
+		}
+	}
+	sort.Slice(s.lst, func(i, j int) bool {
+		return s.lst[i].startTime.Before(s.lst[j].startTime)
+	})
+	entries, err := ioutil.ReadDir(s.path)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read %s", s.path)
+	}
+	for _, e := range entries {
+		if _, ok := dirs[e.Name()]; ok || !e.IsDir() || !strings.HasPrefix(e.Name(), "block-") || isTempDir(e.Name()) {
+			continue
+		}
+		if err = os.RemoveAll(filepath.Join(s.path, e.Name())); err != nil {
+			return errors.Wrapf(err, "failed to remove the stale block %s", e.Name())
+		}
+		s.l.Info().Str("path", s.path).Str("block", e.Name()).Msg("removed a stale block")
+	}
+	return nil
+}
+
+// createBlock creates the block of [startTime, endTime) in the directory of name, whose id is the next one of
+// the segment. The caller should hold the lock of s.
+func (s *segment) createBlock(name string, startTime, endTime time.Time) (*block, error) {
+	blockPath, err := mkdir(blockTemplate, s.path, name)
+	if err != nil {
+		return nil, err
+	}
+	b, err := newBlock(s.ctx, blockOpts{
+		segID:     s.id,
+		blockID:   s.nextBlockID,
+		segment:   s,
+		path:      blockPath,
+		startTime: startTime,
+		endTime:   endTime,
+	})
+	if err != nil {
+		return nil, err
 	}
-	return s, nil
+	s.nextBlockID++
+	s.insertBlock(b)
+	return b, nil
+}
+
+// insertBlock keeps the blocks ordered by their start time. The caller should hold the lock of s.
+func (s *segment) insertBlock(b *block) {
+	i := sort.Search(len(s.lst), func(i int) bool {
+		return s.lst[i].startTime.After(b.startTime)
+	})
+	s.lst = append(s.lst, nil)
+	copy(s.lst[i+1:], s.lst[i:])
+	s.lst[i] = b
+}
+
+// blockEnd returns the end of the block starting from startTime, which is bounded by the end of the segment.
+// The caller should hold the lock of s.
+func (s *segment) blockEnd(startTime time.Time) time.Time {
+	if s.blockInterval <= 0 {
+		return s.endTime
+	}
+	endTime := startTime.Add(s.blockInterval)
+	if !s.endTime.IsZero() && endTime.After(s.endTime) {
+		return s.endTime
+	}
+	return endTime
+}
+
+// ensureBlock returns the block containing ts. If there is none, it creates the one of the interval holding ts,
+// which is shrunk to the gap between the existing blocks. It returns nil if ts is out of the segment.
+func (s *segment) ensureBlock(ts time.Time) (*block, error) {
+	s.Lock()
+	defer s.Unlock()
+	for _, b := range s.lst {
+		if b.contains(ts) {
+			return b, nil
+		}
+	}
+	if s.blockInterval <= 0 || ts.Before(s.startTime) {
+		return nil, nil
+	}
+	startTime := s.startTime.Add(ts.Sub(s.startTime).Truncate(s.blockInterval))
+	endTime := s.blockEnd(startTime)
+	for _, b := range s.lst {
+		timeRange := b.window()
+		if !timeRange.End.IsZero() && !timeRange.End.After(ts) && timeRange.End.After(startTime) {
+			startTime = timeRange.End
+		}
+		if timeRange.Start.After(ts) && timeRange.Start.Before(endTime) {
+			endTime = timeRange.Start
+		}
+	}
+	b, err := s.createBlock(s.uniqueBlockName(startTime), startTime, endTime)
+	if err != nil {
+		return nil, err
+	}
+	return b, s.persistBlocks()
+}
+
+// uniqueBlockName suffixes the name of the block starting from startTime with a sequence if a directory has taken it.
+// The caller should hold the lock of s.
+func (s *segment) uniqueBlockName(startTime time.Time) string {
+	name := startTime.Format(intervalBlockFormat)
+	candidate := name
+	for seq := 1; ; seq++ {
+		if _, err := os.Stat(fmt.Sprintf(blockTemplate, s.path, candidate)); errors.Is(err, os.ErrNotExist) {
+			if _, err = os.Stat(fmt.Sprintf(blockTemplate, s.path, candidate) + tempDirSuffix); errors.Is(err, os.ErrNotExist) {
+				return candidate
+			}
+		}
+		candidate = fmt.Sprintf("%s%s%d", name, segSeqSeparator, seq)
+	}
+}
+
+// persistBlocks writes the windows of the blocks, which the caller should hold the lock of s for
+func (s *segment) persistBlocks() error {
+	aliases := make(map[uint16][]uint16)
+	for alias, id := range s.aliases {
+		aliases[id] = append(aliases[id], alias)
+	}
+	windows := make([]blockWindow, 0, len(s.lst))
+	for _, b := range s.lst {
+		timeRange := b.window()
+		w := blockWindow{
+			ID:      b.blockID,
+			Dir:     filepath.Base(b.path),
+			Start:   timeRange.Start.UnixNano(),
+			Aliases: aliases[b.blockID],
+		}
+		if !timeRange.End.IsZero() {
+			w.End = timeRange.End.UnixNano()
+		}
+		sort.Slice(w.Aliases, func(i, j int) bool {
+			return w.Aliases[i] < w.Aliases[j]
+		})
+		windows = append(windows, w)
+	}
+	return errors.Wrapf(writeManifest(filepath.Join(s.path, blocksManifest), windows),
+		"failed to write the blocks manifest of %s", s.path)
+}
+
+// seal stops the segment from accepting the data later than endTime.
+// Late data is still appended to the segment within the grace period.
+func (s *segment) seal(endTime time.Time, grace time.Duration) error {
+	s.Lock()
+	defer s.Unlock()
+	s.endTime = endTime
+	for _, b := range s.lst {
+		blockEnd := endTime
+		if timeRange := b.window(); !timeRange.End.IsZero() && timeRange.End.Before(endTime) {
+			blockEnd = timeRange.End
+		}
+		b.seal(blockEnd, grace)
+	}
+	return s.persistBlocks()
+}
+
+// endedBy reports whether all the data of the segment is older than deadline
+func (s *segment) endedBy(deadline time.Time) bool {
+	s.Lock()
+	defer s.Unlock()
+	return !s.endTime.IsZero() && !s.endTime.After(deadline)
+}
+
+func (s *segment) blocks() []*block {
+	s.Lock()
+	defer s.Unlock()
+	return append([]*block(nil), s.lst...)
 }
 
 func (s *segment) close() {
@@ -87,4 +431,197 @@ func (s *segment) close() {
 	for _, b := range s.lst {
 		b.close()
 	}
+	_ = s.globalIndex.Close()
+}
+
+// segmentController holds the segments of a shard in creation order.
+// A segment's id is its position in the list, and an expired segment leaves a nil in its position.
+type segmentController struct {
+	sync.RWMutex
+	ctx         context.Context
+	location    string
+	grace       time.Duration
+	precision   time.Duration
+	partitioner Partitioner
+	lst         []*segment
+	// onRemove is called once an expired segment is removed
+	onRemove func(seg *segment) error
+}
+
+func newSegmentController(ctx context.Context, location string) *segmentController {
+	sc := &segmentController{
+		ctx:      ctx,
+		location: location,
+	}
+	if grace, ok := ctx.Value(segmentGraceKey).(time.Duration); ok {
+		sc.grace = grace
+	}
+	if precision, ok := ctx.Value(precisionKey).(time.Duration); ok {
+		sc.precision = precision
+	}
+	if partitioner, ok := ctx.Value(partitionerKey).(Partitioner); ok {
+		sc.partitioner = partitioner
+	}
+	return sc
+}
+
+// segments returns the live segments, which skips the expired ones
+func (sc *segmentController) segments() []*segment {
+	sc.RLock()
+	defer sc.RUnlock()
+	result := make([]*segment, 0, len(sc.lst))
+	for _, seg := range sc.lst {
+		if seg != nil {
+			result = append(result, seg)
+		}
+	}
+	return result
+}
+
+// get returns nil if the segment is absent or expired
+func (sc *segmentController) get(id uint16) *segment {
+	sc.RLock()
+	defer sc.RUnlock()
+	if int(id) >= len(sc.lst) {
+		return nil
+	}
+	return sc.lst[id]
+}
+
+func (sc *segmentController) create(startTime time.Time) (*segment, error) {
+	sc.Lock()
+	defer sc.Unlock()
+	return sc.createLocked(startTime)
+}
+
+// ensure returns the segment containing ts. If there is none, it creates the one of the partition holding ts.
+// Without a partitioner, it only looks up the existing segments.
+func (sc *segmentController) ensure(ts time.Time) (*segment, error) {
+	if seg := sc.find(ts); seg != nil || sc.partitioner == nil {
+		return seg, nil
+	}
+	sc.Lock()
+	defer sc.Unlock()
+	for _, seg := range sc.lst {
+		if seg != nil && seg.contains(ts) {
+			return seg, nil
+		}
+	}
+	return sc.createLocked(ts)
+}
+
+func (sc *segmentController) find(ts time.Time) *segment {
+	sc.RLock()
+	defer sc.RUnlock()
+	for _, seg := range sc.lst {
+		if seg != nil && seg.contains(ts) {
+			return seg
+		}
+	}
+	return nil
+}
+
+func (sc *segmentController) createLocked(startTime time.Time) (*segment, error) {
+	var endTime time.Time
+	format := segFormat
+	if sc.partitioner != nil {
+		partition := sc.clip(startTime, sc.partitioner.Partition(startTime))
+		startTime, endTime = partition.Start, partition.End
+		format = partitionSegFormat
+	} else {
+		startTime = sc.bucket(startTime)
+	}
+	segPath, err := mkdir(segTemplate, sc.location, sc.uniqueName(startTime.Format(format)))
+	if err != nil {
+		return nil, err
+	}
+	seg, err := newSegment(sc.ctx, uint16(len(sc.lst)), segPath, startTime, endTime)
+	if err != nil {
+		return nil, err
+	}
+	sc.lst = append(sc.lst, seg)
+	return seg, sc.persistManifest()
+}
+
+// uniqueName suffixes name with a sequence if a segment, live or not, has taken it, which happens
+// when the segments are sealed more than once within a day. The caller should hold the lock of sc.
+func (sc *segmentController) uniqueName(name string) string {
+	candidate := name
+	for seq := 1; ; seq++ {
+		if _, err := os.Stat(fmt.Sprintf(segTemplate, sc.location, candidate)); errors.Is(err, os.ErrNotExist) {
+			return candidate
+		}
+		candidate = fmt.Sprintf("%s%s%d", name, segSeqSeparator, seq)
+	}
+}
+
+// clip shrinks the partition holding ts to the gap between the existing segments, which were created by
+// another partitioner if they overlap it. The caller should hold the lock of sc.
+func (sc *segmentController) clip(ts time.Time, partition TimeRange) TimeRange {
+	for _, seg := range sc.lst {
+		if seg == nil {
+			continue
+		}
+		timeRange := seg.TimeRange()
+		if !timeRange.End.IsZero() && !timeRange.End.After(ts) && timeRange.End.After(partition.Start) {
+			partition.Start = timeRange.End.In(partition.Start.Location())
+		}
+		if timeRange.Start.After(ts) && timeRange.Start.Before(partition.End) {
+			partition.End = timeRange.Start.In(partition.End.Location())
+		}
+	}
+	return partition
+}
+
+// seal seals the latest segment at endTime and opens a new one starting from there
+func (sc *segmentController) seal(endTime time.Time) (*segment, error) {
+	sc.Lock()
+	defer sc.Unlock()
+	endTime = sc.bucket(endTime)
+	if len(sc.lst) > 0 && sc.lst[len(sc.lst)-1] != nil {
+		if err := sc.lst[len(sc.lst)-1].seal(endTime, sc.grace); err != nil {
+			return nil, err
+		}
+	}
+	return sc.createLocked(endTime)
+}
+
+// bucket aligns t to the timestamp precision, which keeps the boundaries between segments identical
+func (sc *segmentController) bucket(t time.Time) time.Time {
+	if sc.precision <= 0 {
+		return t
+	}
+	return t.Truncate(sc.precision)
+}
+
+// offload moves the blocks of the segments to the BlockStore, and returns the number of the offloaded ones.
+// A zero deadline picks the sealed blocks past their grace period, otherwise the segments ending by deadline are picked.
+func (sc *segmentController) offload(ctx context.Context, deadline time.Time) (count int, err error) {
+	now := time.Now()
+	for _, seg := range sc.segments() {
+		if !deadline.IsZero() && !seg.endedBy(deadline) {
+			continue
+		}
+		for _, b := range seg.blocks() {
+			if deadline.IsZero() && !b.offloadable(now) || !deadline.IsZero() && !b.endedBy(deadline) {
+				continue
+			}
+			offloaded, errOffload := b.offload(ctx)
+			if offloaded {
+				count++
+			}
+			err = multierr.Append(err, errOffload)
+		}
+	}
+	return count, err
+}
+
+func (sc *segmentController) close() {
+	sc.Lock()
+	defer sc.Unlock()
+	for _, s := range sc.lst {
+		if s != nil {
+			s.close()
+		}
+	}
 }
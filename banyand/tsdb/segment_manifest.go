@@ -0,0 +1,239 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tsdb
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+)
+
+// segmentManifest is the file of a shard mapping the directories of its segments to the windows they cover.
+// It's rewritten whenever a segment is created, sealed or removed, and rebuilt from the names of the directories
+// if it's absent.
+const segmentManifest = "segments.json"
+
+// blocksManifest is the file of a segment mapping the directories of its blocks to the windows they cover.
+// It's rewritten whenever a block is created, sealed or merged. A segment without it has a single block,
+// which covers the whole segment.
+const blocksManifest = "blocks.json"
+
+// segmentWindow is an entry of the manifest. ID is the position of the segment in its controller, which the
+// GlobalItemIDs refer to. Start and End are in nanoseconds, and a zero End leaves the segment open.
+type segmentWindow struct {
+	ID    uint16 `json:"id"`
+	Dir   string `json:"dir"`
+	Start int64  `json:"start"`
+	End   int64  `json:"end,omitempty"`
+}
+
+// blockWindow is an entry of the blocks manifest. ID is the one the GlobalItemIDs refer to, and Aliases are the ids
+// of the blocks merged into the block, which are held by the GlobalItemIDs taken before the merge.
+// Start and End are in nanoseconds, and a zero End leaves the block open.
+type blockWindow struct {
+	ID      uint16   `json:"id"`
+	Dir     string   `json:"dir"`
+	Start   int64    `json:"start"`
+	End     int64    `json:"end,omitempty"`
+	Aliases []uint16 `json:"aliases,omitempty"`
+}
+
+// writeSegmentManifest replaces the manifest of the shard at location with the windows
+func writeSegmentManifest(location string, windows []segmentWindow) error {
+	return writeManifest(filepath.Join(location, segmentManifest), windows)
+}
+
+// writeManifest replaces the file at path with the json of v
+func writeManifest(path string, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err = ioutil.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// ensureSegmentManifest rebuilds the manifest of the shard at location from the names of its segments
+// if it's absent. A segment ends where the next one starts, and the latest one is open.
+func ensureSegmentManifest(location string) error {
+	if _, err := os.Stat(filepath.Join(location, segmentManifest)); err == nil || !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	entries, err := ioutil.ReadDir(location)
+	if err != nil {
+		return err
+	}
+	var windows []segmentWindow
+	for _, e := range entries {
+		if !e.IsDir() || !strings.HasPrefix(e.Name(), "seg-") {
+			continue
+		}
+		start, errParse := parseSegmentStart(strings.TrimPrefix(e.Name(), "seg-"))
+		if errParse != nil {
+			err = multierr.Append(err, errors.Wrapf(errParse, "failed to parse the segment %s", e.Name()))
+			continue
+		}
+		windows = append(windows, segmentWindow{Dir: e.Name(), Start: start.UnixNano()})
+	}
+	if err != nil {
+		return err
+	}
+	sort.Slice(windows, func(i, j int) bool {
+		return windows[i].Start < windows[j].Start
+	})
+	for i := range windows {
+		windows[i].ID = uint16(i)
+		if i+1 < len(windows) {
+			windows[i].End = windows[i+1].Start
+		}
+	}
+	return writeSegmentManifest(location, windows)
+}
+
+// parseSegmentStart parses the name of a segment created either by the partitioner or without it,
+// ignoring the sequence which tells apart the segments starting in the same interval
+func parseSegmentStart(name string) (time.Time, error) {
+	if i := strings.Index(name, segSeqSeparator); i >= 0 {
+		name = name[:i]
+	}
+	format := segFormat
+	if len(name) == len(partitionSegFormat) {
+		format = partitionSegFormat
+	}
+	return time.ParseInLocation(format, name, time.Local)
+}
+
+// readSegmentManifest returns the windows of the shard at location, which are absent if the shard is new
+func readSegmentManifest(location string) ([]segmentWindow, error) {
+	var windows []segmentWindow
+	if err := readManifest(filepath.Join(location, segmentManifest), &windows); err != nil {
+		return nil, errors.WithMessagef(err, "failed to decode the segment manifest of %s", location)
+	}
+	return windows, nil
+}
+
+// readBlocksManifest returns the windows of the blocks of the segment at path, which are absent if the segment is new
+// or created without the manifest
+func readBlocksManifest(path string) ([]blockWindow, error) {
+	var windows []blockWindow
+	if err := readManifest(filepath.Join(path, blocksManifest), &windows); err != nil {
+		return nil, errors.WithMessagef(err, "failed to decode the blocks manifest of %s", path)
+	}
+	return windows, nil
+}
+
+// readManifest decodes the json file at path into v, which is left untouched if the file is absent
+func readManifest(path string, v interface{}) error {
+	data, err := ioutil.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// parseBlockStart parses the name of a block of the segment starting from segStart, ignoring the sequence which
+// tells apart the blocks starting in the same minute. The first block of a segment is named after its time of day.
+func parseBlockStart(segStart time.Time, name string) (time.Time, error) {
+	if i := strings.Index(name, segSeqSeparator); i >= 0 {
+		name = name[:i]
+	}
+	if len(name) != len(blockFormat) {
+		return time.ParseInLocation(intervalBlockFormat, name, time.Local)
+	}
+	blockStart, err := time.ParseInLocation(blockFormat, name, time.Local)
+	if err != nil {
+		return blockStart, err
+	}
+	return time.Date(segStart.Year(), segStart.Month(), segStart.Day(),
+		blockStart.Hour(), blockStart.Minute(), 0, 0, time.Local), nil
+}
+
+// load reopens the segments recorded by the manifest at their ids, and the expired ones leave nils in their positions
+func (sc *segmentController) load() error {
+	windows, err := readSegmentManifest(sc.location)
+	if err != nil {
+		return err
+	}
+	sc.Lock()
+	defer sc.Unlock()
+	for _, w := range windows {
+		var endTime time.Time
+		if w.End != 0 {
+			endTime = time.Unix(0, w.End)
+		}
+		seg, errSeg := newSegment(sc.ctx, w.ID, filepath.Join(sc.location, w.Dir), time.Unix(0, w.Start), endTime)
+		if errSeg != nil {
+			return errors.WithMessagef(errSeg, "failed to load the segment %s", w.Dir)
+		}
+		for int(w.ID) >= len(sc.lst) {
+			sc.lst = append(sc.lst, nil)
+		}
+		sc.lst[w.ID] = seg
+	}
+	return nil
+}
+
+// persistManifest writes the windows of the live segments, which the caller should hold the lock of sc for
+func (sc *segmentController) persistManifest() error {
+	windows := make([]segmentWindow, 0, len(sc.lst))
+	for _, seg := range sc.lst {
+		if seg == nil {
+			continue
+		}
+		timeRange := seg.TimeRange()
+		w := segmentWindow{ID: seg.id, Dir: filepath.Base(seg.path), Start: timeRange.Start.UnixNano()}
+		if !timeRange.End.IsZero() {
+			w.End = timeRange.End.UnixNano()
+		}
+		windows = append(windows, w)
+	}
+	return errors.Wrap(writeSegmentManifest(sc.location, windows), "failed to write the segment manifest")
+}
+
+// inRange returns the live segments overlapping [start, end) ordered by their start time
+func (sc *segmentController) inRange(start, end time.Time) []Segment {
+	var result []*segment
+	for _, seg := range sc.segments() {
+		timeRange := seg.TimeRange()
+		if !timeRange.Start.Before(end) || !timeRange.End.IsZero() && !timeRange.End.After(start) {
+			continue
+		}
+		result = append(result, seg)
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].TimeRange().Start.Before(result[j].TimeRange().Start)
+	})
+	segments := make([]Segment, 0, len(result))
+	for _, seg := range result {
+		segments = append(segments, seg)
+	}
+	return segments
+}
@@ -0,0 +1,270 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tsdb
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	modelv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/model/v1"
+)
+
+func Test_Segment_GracePeriod(t *testing.T) {
+	tests := []struct {
+		name    string
+		grace   time.Duration
+		wantErr error
+	}{
+		{
+			name:  "within the grace period",
+			grace: time.Hour,
+		},
+		{
+			name:    "out of the grace period",
+			wantErr: ErrSegmentImmutable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tester := assert.New(t)
+			_, deferFunc, db := setUpWithOpts(require.New(t), func(opts *DatabaseOpts) {
+				opts.SegmentGracePeriod = tt.grace
+			})
+			defer deferFunc()
+			s, err := db.Shard(0)
+			tester.NoError(err)
+			now := time.Now()
+			sealTime := now.Add(time.Minute)
+			_, err = s.(*shard).segmentController.seal(sealTime)
+			tester.NoError(err)
+
+			series, err := s.Series().Get(Entity{Entry("productpage"), Entry("10.0.0.1")})
+			tester.NoError(err)
+			span, err := series.Span(NewTimeRangeDuration(now, 48*time.Hour))
+			tester.NoError(err)
+			defer func() {
+				tester.NoError(span.Close())
+			}()
+			lateWriter, err := span.WriterBuilder().
+				Family([]byte("searchable"), []byte("late")).
+				Time(sealTime.Add(-time.Millisecond)).
+				Build()
+			if tt.wantErr != nil {
+				tester.ErrorIs(err, tt.wantErr)
+			} else {
+				tester.NoError(err)
+				id, errWrite := lateWriter.Write()
+				tester.NoError(errWrite)
+				tester.Equal(uint16(0), id.segID)
+				item, closer, errGet := series.Get(id)
+				tester.NoError(errGet)
+				v, errFamily := item.Family("searchable")
+				tester.NoError(errFamily)
+				tester.Equal([]byte("late"), v)
+				tester.NoError(closer.Close())
+			}
+
+			writer, err := span.WriterBuilder().
+				Family([]byte("searchable"), []byte("on time")).
+				Time(sealTime.Add(time.Millisecond)).
+				Build()
+			tester.NoError(err)
+			id, err := writer.Write()
+			tester.NoError(err)
+			tester.Equal(uint16(1), id.segID)
+		})
+	}
+}
+
+func Test_Segment_InRange(t *testing.T) {
+	tester := require.New(t)
+	_, deferFunc, db := setUpWithOpts(tester, nil)
+	defer deferFunc()
+	s, err := db.Shard(0)
+	tester.NoError(err)
+	now := time.Now()
+	sealTime := now.Add(24 * time.Hour)
+	_, err = s.(*shard).segmentController.seal(sealTime)
+	tester.NoError(err)
+
+	ids := func(segments []Segment) []uint16 {
+		result := make([]uint16, 0, len(segments))
+		for _, seg := range segments {
+			result = append(result, seg.ID())
+		}
+		return result
+	}
+	tester.Equal([]uint16{0}, ids(s.SegmentsInRange(now, now.Add(time.Hour))))
+	tester.Equal([]uint16{1}, ids(s.SegmentsInRange(sealTime, sealTime.Add(time.Hour))))
+	// the open segment covers the future
+	tester.Equal([]uint16{1}, ids(s.SegmentsInRange(sealTime.Add(time.Hour*24*365), sealTime.Add(time.Hour*24*366))))
+	tester.Equal([]uint16{0, 1}, ids(s.SegmentsInRange(now.Add(-time.Hour), sealTime.Add(time.Hour))))
+	tester.Empty(s.SegmentsInRange(now.Add(-2*time.Hour), now.Add(-time.Hour)))
+
+	// the manifest follows the segments
+	data, err := ioutil.ReadFile(filepath.Join(s.(*shard).location, segmentManifest))
+	tester.NoError(err)
+	var windows []segmentWindow
+	tester.NoError(json.Unmarshal(data, &windows))
+	tester.Len(windows, 2)
+	tester.Equal(sealTime.UnixNano(), windows[0].End)
+	tester.Equal(sealTime.UnixNano(), windows[1].Start)
+	tester.Zero(windows[1].End)
+}
+
+func Test_Segment_RebuildManifest(t *testing.T) {
+	tester := require.New(t)
+	ctx, opts, removeSpace := setUpOpts(tester, nil)
+	defer removeSpace()
+	shardPath := filepath.Join(opts.Location, "shard-0")
+	for _, name := range []string{"seg-202201021200", "seg-20220101", "series"} {
+		tester.NoError(os.MkdirAll(filepath.Join(shardPath, name), dirPerm))
+	}
+
+	db, err := OpenDatabase(ctx, opts)
+	tester.NoError(err)
+	defer db.Close()
+	data, err := ioutil.ReadFile(filepath.Join(shardPath, segmentManifest))
+	tester.NoError(err)
+	var windows []segmentWindow
+	tester.NoError(json.Unmarshal(data, &windows))
+	first := time.Date(2022, 1, 1, 0, 0, 0, 0, time.Local).UnixNano()
+	second := time.Date(2022, 1, 2, 12, 0, 0, 0, time.Local).UnixNano()
+	tester.Equal([]segmentWindow{
+		{ID: 0, Dir: "seg-20220101", Start: first, End: second},
+		{ID: 1, Dir: "seg-202201021200", Start: second},
+	}, windows)
+}
+
+func Test_Segment_SealWithinDay(t *testing.T) {
+	tester := require.New(t)
+	_, deferFunc, db := setUpWithOpts(tester, nil)
+	defer deferFunc()
+	s, err := db.Shard(0)
+	tester.NoError(err)
+	series, err := s.Series().Get(Entity{Entry("productpage"), Entry("10.0.0.1")})
+	tester.NoError(err)
+	now := time.Now()
+
+	var ids []GlobalItemID
+	write := func(ts time.Time, value string) {
+		span, errSpan := series.Span(NewTimeRangeDuration(ts, time.Millisecond))
+		tester.NoError(errSpan)
+		defer func() {
+			tester.NoError(span.Close())
+		}()
+		writer, errBuild := span.WriterBuilder().
+			Family([]byte("searchable"), []byte(value)).
+			Time(ts).
+			Build()
+		tester.NoError(errBuild)
+		id, errWrite := writer.Write()
+		tester.NoError(errWrite)
+		ids = append(ids, id)
+	}
+	write(now, "0")
+	for i := 1; i <= 2; i++ {
+		sealTime := now.Add(time.Duration(i) * time.Second)
+		_, err = s.(*shard).segmentController.seal(sealTime)
+		tester.NoError(err)
+		write(sealTime.Add(time.Millisecond), strconv.Itoa(i))
+	}
+
+	// every segment has its own directory, even though they start on the same day
+	dirs := make(map[string]struct{})
+	for _, seg := range s.(*shard).segmentController.segments() {
+		dirs[seg.path] = struct{}{}
+	}
+	tester.Len(dirs, 3)
+	for i, id := range ids {
+		tester.Equal(uint16(i), id.segID)
+		item, closer, errGet := series.Get(id)
+		tester.NoError(errGet)
+		v, errFamily := item.Family("searchable")
+		tester.NoError(errFamily)
+		tester.Equal([]byte(strconv.Itoa(i)), v)
+		tester.NoError(closer.Close())
+	}
+
+	// the manifest is rebuilt from the names with the sequences
+	tester.NoError(os.Remove(filepath.Join(s.(*shard).location, segmentManifest)))
+	tester.NoError(ensureSegmentManifest(s.(*shard).location))
+	windows, err := readSegmentManifest(s.(*shard).location)
+	tester.NoError(err)
+	tester.Len(windows, 3)
+}
+
+func Test_Database_NanosecondPrecision(t *testing.T) {
+	tester := assert.New(t)
+	_, deferFunc, db := setUpWithOpts(require.New(t), func(opts *DatabaseOpts) {
+		opts.TimestampPrecision = time.Hour
+	})
+	defer deferFunc()
+	s, err := db.Shard(0)
+	tester.NoError(err)
+	for _, seg := range s.(*shard).segmentController.segments() {
+		tester.True(seg.startTime.Equal(seg.startTime.Truncate(time.Hour)))
+	}
+
+	base := time.Now().Add(time.Minute)
+	series, err := s.Series().Get(Entity{Entry("productpage"), Entry("10.0.0.1")})
+	tester.NoError(err)
+	span, err := series.Span(NewTimeRangeDuration(base.Add(-time.Hour), 2*time.Hour))
+	tester.NoError(err)
+	defer func() {
+		tester.NoError(span.Close())
+	}()
+	// write the later one first to verify the order comes from the timestamps
+	for _, d := range []time.Duration{3*time.Microsecond + 7, time.Microsecond} {
+		writer, errWriter := span.WriterBuilder().
+			Family([]byte("searchable"), []byte(d.String())).
+			Time(base.Add(d)).
+			Build()
+		tester.NoError(errWriter)
+		_, errWriter = writer.Write()
+		tester.NoError(errWriter)
+	}
+	seeker, err := span.SeekerBuilder().OrderByTime(modelv1.Sort_SORT_ASC).Build()
+	tester.NoError(err)
+	iters, err := seeker.Seek()
+	tester.NoError(err)
+	var got []uint64
+	var values []string
+	for _, iter := range iters {
+		for iter.Next() {
+			got = append(got, iter.Val().Time())
+			v, errFamily := iter.Val().Family("searchable")
+			tester.NoError(errFamily)
+			values = append(values, string(v))
+		}
+		tester.NoError(iter.Close())
+	}
+	tester.Equal([]uint64{
+		uint64(base.Add(time.Microsecond).UnixNano()),
+		uint64(base.Add(3*time.Microsecond + 7).UnixNano()),
+	}, got)
+	tester.Equal([]string{time.Microsecond.String(), (3*time.Microsecond + 7).String()}, values)
+}
@@ -0,0 +1,117 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tsdb
+
+import (
+	"io/ioutil"
+	"os"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/apache/skywalking-banyandb/pkg/convert"
+)
+
+// blockSequenceFile holds the last sequence applied to a block, which is written after the data is flushed
+const blockSequenceFile = "sequence"
+
+// ErrSequenceApplied tells a replayed write is skipped, because the block has applied its sequence before
+var ErrSequenceApplied = errors.New("the sequence of the write is applied")
+
+// shardSequence assigns the writes to a shard monotonic sequences. It's shared by all the blocks of the shard,
+// and starts from the largest sequence applied to them.
+type shardSequence struct {
+	sync.Mutex
+	last uint64
+}
+
+func (s *shardSequence) next() uint64 {
+	s.Lock()
+	defer s.Unlock()
+	s.last++
+	return s.last
+}
+
+// observe keeps the sequences assigned later beyond seq
+func (s *shardSequence) observe(seq uint64) {
+	s.Lock()
+	defer s.Unlock()
+	if seq > s.last {
+		s.last = seq
+	}
+}
+
+// blockSequence tracks the last sequence applied to a block
+type blockSequence struct {
+	sync.Mutex
+	path      string
+	applied   uint64
+	persisted uint64
+}
+
+func openBlockSequence(blockPath string) (*blockSequence, error) {
+	s := &blockSequence{
+		path: blockPath + "/" + blockSequenceFile,
+	}
+	data, err := ioutil.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(data) != 8 {
+		return nil, errors.Errorf("malformed sequence file %s", s.path)
+	}
+	s.applied = convert.BytesToUint64(data)
+	s.persisted = s.applied
+	return s, nil
+}
+
+func (s *blockSequence) isApplied(seq uint64) bool {
+	s.Lock()
+	defer s.Unlock()
+	return seq <= s.applied
+}
+
+func (s *blockSequence) apply(seq uint64) {
+	s.Lock()
+	defer s.Unlock()
+	if seq > s.applied {
+		s.applied = seq
+	}
+}
+
+// persist writes the applied sequence after the data is flushed.
+// The writes after the persisted one are replayed if the block crashes before the next flush.
+func (s *blockSequence) persist() error {
+	s.Lock()
+	defer s.Unlock()
+	if s.applied == s.persisted {
+		return nil
+	}
+	tmp := s.path + ".tmp"
+	if err := ioutil.WriteFile(tmp, convert.Uint64ToBytes(s.applied), 0600); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return err
+	}
+	s.persisted = s.applied
+	return nil
+}
@@ -0,0 +1,129 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tsdb
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	databasev1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/database/v1"
+	modelv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/model/v1"
+)
+
+func Test_Database_ReplaySequence(t *testing.T) {
+	req := require.New(t)
+	ctx, opts, removeSpace := setUpOpts(req, func(opts *DatabaseOpts) {
+		// the appended items make a double-applied write visible
+		opts.StorageEngine = databasev1.StorageEngine_STORAGE_ENGINE_APPEND
+		// the reopened database loads the same block
+		opts.TimestampPrecision = 24 * time.Hour
+	})
+	defer removeSpace()
+	tempDir := opts.Location
+	entity := Entity{Entry("productpage"), Entry("10.0.0.1")}
+	ts := time.Now()
+	timeRange := NewTimeRangeDuration(ts.Add(-time.Hour), 2*time.Hour)
+	type walEntry struct {
+		seq uint64
+		ts  time.Time
+	}
+	// write returns the sequence assigned to a new write if seq is zero
+	write := func(shard Shard, entry walEntry) (uint64, error) {
+		series, errSeries := shard.Series().Get(entity)
+		req.NoError(errSeries)
+		span, errSpan := series.Span(timeRange)
+		req.NoError(errSpan)
+		defer func() {
+			req.NoError(span.Close())
+		}()
+		writer, errWriter := span.WriterBuilder().
+			Family([]byte("val"), []byte{1}).
+			Time(entry.ts).
+			Sequence(entry.seq).
+			Build()
+		req.NoError(errWriter)
+		_, errWriter = writer.Write()
+		return writer.Sequence(), errWriter
+	}
+	count := func(shard Shard) int {
+		series, errSeries := shard.Series().Get(entity)
+		req.NoError(errSeries)
+		span, errSpan := series.Span(timeRange)
+		req.NoError(errSpan)
+		defer func() {
+			req.NoError(span.Close())
+		}()
+		seeker, errSeeker := span.SeekerBuilder().OrderByTime(modelv1.Sort_SORT_ASC).Build()
+		req.NoError(errSeeker)
+		iters, errSeek := seeker.Seek()
+		req.NoError(errSeek)
+		var n int
+		for _, iter := range iters {
+			for iter.Next() {
+				n++
+			}
+			req.NoError(iter.Close())
+		}
+		return n
+	}
+
+	db, err := OpenDatabase(ctx, opts)
+	req.NoError(err)
+	shard, err := db.Shard(0)
+	req.NoError(err)
+	var wal []walEntry
+	for i := 0; i < 3; i++ {
+		entry := walEntry{ts: ts.Add(time.Duration(i) * time.Millisecond)}
+		entry.seq, err = write(shard, entry)
+		req.NoError(err)
+		wal = append(wal, entry)
+	}
+	req.Equal(uint64(1), wal[0].seq)
+	req.Equal(uint64(3), wal[2].seq)
+	// closing the database flushes the writes and their sequences
+	req.NoError(db.Close())
+
+	// the wal has the writes lost in the crash after the flushed ones, which are replayed into the reopened shard
+	wal = append(wal, walEntry{seq: 4, ts: ts.Add(3 * time.Millisecond)}, walEntry{seq: 5, ts: ts.Add(4 * time.Millisecond)})
+	ctx = context.WithValue(ctx, encodingMethodKey, opts.EncodingMethod)
+	ctx = context.WithValue(ctx, storageEngineKey, opts.StorageEngine)
+	ctx = context.WithValue(ctx, precisionKey, opts.TimestampPrecision)
+	shard, err = newShard(ctx, 0, filepath.Join(tempDir, "shard-0"))
+	req.NoError(err)
+	defer shard.Close()
+	for _, entry := range wal {
+		_, err = write(shard, entry)
+		if entry.seq <= 3 {
+			req.True(errors.Is(err, ErrSequenceApplied))
+			continue
+		}
+		req.NoError(err)
+	}
+	req.Equal(5, count(shard))
+
+	// the sequences assigned after replaying go on from the replayed ones
+	seq, err := write(shard, walEntry{ts: ts.Add(5 * time.Millisecond)})
+	req.NoError(err)
+	req.Equal(uint64(6), seq)
+	req.Equal(6, count(shard))
+}
@@ -27,6 +27,8 @@ import (
 	"go.uber.org/multierr"
 
 	"github.com/apache/skywalking-banyandb/api/common"
+	databasev1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/database/v1"
+	"github.com/apache/skywalking-banyandb/banyand/kv"
 	"github.com/apache/skywalking-banyandb/pkg/convert"
 	"github.com/apache/skywalking-banyandb/pkg/logger"
 )
@@ -108,6 +110,15 @@ type SeriesSpan interface {
 	io.Closer
 	WriterBuilder() WriterBuilder
 	SeekerBuilder() SeekerBuilder
+	// Cardinality estimates the number of items in the span matching all the conditions.
+	// It's resolved from the indices of the blocks without reading the data.
+	Cardinality(conditions map[*databasev1.IndexRule]Condition) (int, error)
+	// BlockNum returns the number of blocks whose time ranges overlap the span
+	BlockNum() int
+	// Stats returns the statistics of a numeric column in each block overlapping the span, without reading the items
+	Stats(column string) ([]BlockStats, error)
+	// IndexWriter generates the indices of an existing item in the span, whose data are left untouched
+	IndexWriter(id common.ItemID) (ItemIndexWriter, error)
 }
 
 var _ Series = (*series)(nil)
@@ -120,11 +131,18 @@ type series struct {
 }
 
 func (s *series) Get(id GlobalItemID) (Item, io.Closer, error) {
-	b := s.blockDB.block(id)
+	b, err := s.blockDB.block(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if b == nil {
+		return nil, nil, errors.WithStack(ErrExpiredItem)
+	}
 	return &item{
-		data:     b.dataReader(),
-		itemID:   id.ID,
-		seriesID: s.id,
+		data:       b.dataReader(),
+		itemID:     id.ID,
+		seriesID:   s.id,
+		appendOnly: b.appendOnly(),
 	}, b, nil
 }
 
@@ -133,14 +151,17 @@ func (s *series) ID() common.SeriesID {
 }
 
 func (s *series) Span(timeRange TimeRange) (SeriesSpan, error) {
-	blocks := s.blockDB.span(timeRange)
+	blocks, err := s.blockDB.span(timeRange)
+	if err != nil {
+		return nil, err
+	}
 	if len(blocks) < 1 {
 		return nil, ErrEmptySeriesSpan
 	}
 	s.l.Debug().
 		Times("time_range", []time.Time{timeRange.Start, timeRange.End}).
 		Msg("select series span")
-	return newSeriesSpan(context.WithValue(context.Background(), logger.ContextKey, s.l), timeRange, blocks, s.id, s.shardID), nil
+	return newSeriesSpan(context.WithValue(context.Background(), logger.ContextKey, s.l), timeRange, blocks, s.blockDB, s.id), nil
 }
 
 func newSeries(ctx context.Context, id common.SeriesID, blockDB blockDatabase) *series {
@@ -161,11 +182,13 @@ func newSeries(ctx context.Context, id common.SeriesID, blockDB blockDatabase) *
 var _ SeriesSpan = (*seriesSpan)(nil)
 
 type seriesSpan struct {
-	blocks    []blockDelegate
-	seriesID  common.SeriesID
-	shardID   common.ShardID
-	timeRange TimeRange
-	l         *logger.Logger
+	blocks     []blockDelegate
+	blockDB    blockDatabase
+	tombstones kv.Store
+	seriesID   common.SeriesID
+	shardID    common.ShardID
+	timeRange  TimeRange
+	l          *logger.Logger
 }
 
 func (s *seriesSpan) Close() (err error) {
@@ -175,6 +198,17 @@ func (s *seriesSpan) Close() (err error) {
 	return err
 }
 
+// blockAt finds the block containing ts out of the span in a partitioned database.
+// The block is released along with the span.
+func (s *seriesSpan) blockAt(ts time.Time) (blockDelegate, error) {
+	b, err := s.blockDB.blockAt(ts)
+	if err != nil || b == nil {
+		return nil, err
+	}
+	s.blocks = append(s.blocks, b)
+	return b, nil
+}
+
 func (s *seriesSpan) WriterBuilder() WriterBuilder {
 	return newWriterBuilder(s)
 }
@@ -183,12 +217,15 @@ func (s *seriesSpan) SeekerBuilder() SeekerBuilder {
 	return newSeekerBuilder(s)
 }
 
-func newSeriesSpan(ctx context.Context, timeRange TimeRange, blocks []blockDelegate, id common.SeriesID, shardID common.ShardID) *seriesSpan {
+func newSeriesSpan(ctx context.Context, timeRange TimeRange, blocks []blockDelegate, blockDB blockDatabase,
+	id common.SeriesID) *seriesSpan {
 	s := &seriesSpan{
-		blocks:    blocks,
-		seriesID:  id,
-		shardID:   shardID,
-		timeRange: timeRange,
+		blocks:     blocks,
+		blockDB:    blockDB,
+		tombstones: blockDB.tombstone(),
+		seriesID:   id,
+		shardID:    blockDB.shardID(),
+		timeRange:  timeRange,
 	}
 	parentLogger := ctx.Value(logger.ContextKey)
 	if pl, ok := parentLogger.(*logger.Logger); ok {
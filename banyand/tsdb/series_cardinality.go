@@ -0,0 +1,105 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tsdb
+
+import (
+	"github.com/pkg/errors"
+
+	databasev1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/database/v1"
+	"github.com/apache/skywalking-banyandb/pkg/convert"
+	"github.com/apache/skywalking-banyandb/pkg/index"
+	"github.com/apache/skywalking-banyandb/pkg/index/posting"
+)
+
+func (s *seriesSpan) BlockNum() int {
+	var num int
+	for _, b := range s.blocks {
+		if b.overlaps(s.timeRange) {
+			num++
+		}
+	}
+	return num
+}
+
+func (s *seriesSpan) Cardinality(conditions map[*databasev1.IndexRule]Condition) (int, error) {
+	var total int
+	termRange := index.RangeOpts{
+		Lower:         convert.Int64ToBytes(s.timeRange.Start.UnixNano()),
+		Upper:         convert.Int64ToBytes(s.timeRange.End.UnixNano()),
+		IncludesLower: true,
+	}
+	for _, b := range s.blocks {
+		if !b.overlaps(s.timeRange) {
+			continue
+		}
+		list, err := b.primaryIndexReader().Range(index.FieldKey{SeriesID: s.seriesID}, termRange)
+		if err != nil {
+			return 0, err
+		}
+		for rule, condition := range conditions {
+			if list.IsEmpty() {
+				break
+			}
+			matched, err := s.matchInBlock(b, rule, condition)
+			if err != nil {
+				return 0, err
+			}
+			if matched == nil {
+				continue
+			}
+			if err = list.Intersect(matched); err != nil {
+				return 0, err
+			}
+		}
+		total += list.Len()
+	}
+	return total, nil
+}
+
+// matchInBlock returns the items matching the condition in a block, or nil if the index can't serve it
+func (s *seriesSpan) matchInBlock(b blockDelegate, rule *databasev1.IndexRule, condition Condition) (posting.List, error) {
+	if len(condition) > 1 {
+		//TODO:// should support composite index rule
+		return nil, ErrUnsupportedIndexRule
+	}
+	var searcher index.Searcher
+	switch rule.GetType() {
+	case databasev1.IndexRule_TYPE_INVERTED:
+		searcher = b.invertedIndexReader()
+	case databasev1.IndexRule_TYPE_TREE:
+		searcher = b.lsmIndexReader()
+	default:
+		return nil, ErrUnsupportedIndexRule
+	}
+	cond := make(index.Condition)
+	for _, c := range condition {
+		cond[index.FieldKey{
+			SeriesID:    s.seriesID,
+			IndexRuleID: rule.GetMetadata().GetId(),
+		}] = c
+	}
+	tree, err := index.BuildTree(searcher, cond)
+	if err != nil {
+		return nil, err
+	}
+	list, err := tree.Execute()
+	if errors.Is(err, index.ErrEmptyTree) {
+		return nil, nil
+	}
+	return list, err
+}
@@ -22,6 +22,7 @@ import (
 	databasev1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/database/v1"
 	modelv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/model/v1"
 	"github.com/apache/skywalking-banyandb/banyand/kv"
+	"github.com/apache/skywalking-banyandb/pkg/convert"
 	"github.com/apache/skywalking-banyandb/pkg/index"
 )
 
@@ -41,8 +42,14 @@ type Item interface {
 
 type SeekerBuilder interface {
 	Filter(indexRule *databasev1.IndexRule, condition Condition) SeekerBuilder
+	// FilterAny requires the items to match any of the filters. It's combined with the others by AND.
+	FilterAny(filters ...IndexFilter) SeekerBuilder
 	OrderByIndex(indexRule *databasev1.IndexRule, order modelv1.Sort) SeekerBuilder
 	OrderByTime(order modelv1.Sort) SeekerBuilder
+	// TagFilter pushes the predicate down to the seeking. Several predicates are all required to match.
+	TagFilter(predicate TagPredicate) SeekerBuilder
+	// Snapshot skips the items written after the snapshot is taken
+	Snapshot(snapshot Snapshot) SeekerBuilder
 	Build() (Seeker, error)
 }
 
@@ -63,6 +70,23 @@ type seekerBuilder struct {
 	order               modelv1.Sort
 	indexRuleForSorting *databasev1.IndexRule
 	rangeOptsForSorting index.RangeOpts
+	snapshot            Snapshot
+	tagPredicates       []TagPredicate
+	anyFilters          [][]IndexFilter
+}
+
+func (s *seekerBuilder) Snapshot(snapshot Snapshot) SeekerBuilder {
+	s.snapshot = snapshot
+	return s
+}
+
+// filters returns the filters every item is checked against
+func (s *seekerBuilder) filters() []filterFn {
+	filters := []filterFn{tombstoneFilter(s.seriesSpan.tombstones, s.seriesSpan.seriesID)}
+	if s.snapshot != nil {
+		filters = append(filters, snapshotFilter(s.snapshot, s.seriesSpan.shardID, s.seriesSpan.seriesID))
+	}
+	return filters
 }
 
 func (s *seekerBuilder) Build() (Seeker, error) {
@@ -73,7 +97,11 @@ func (s *seekerBuilder) Build() (Seeker, error) {
 	if err != nil {
 		return nil, err
 	}
-	se, err := s.buildSeries(conditions)
+	disjunctions, err := s.buildDisjunctions()
+	if err != nil {
+		return nil, err
+	}
+	se, err := s.buildSeries(conditions, disjunctions)
 	if err != nil {
 		return nil, err
 	}
@@ -109,9 +137,16 @@ type item struct {
 	data        kv.TimeSeriesReader
 	seriesID    common.SeriesID
 	sortedField []byte
+	// appendOnly items might be moved off their time by the colliding ones, see timeBucket
+	appendOnly bool
 }
 
 func (i *item) Time() uint64 {
+	if i.appendOnly {
+		if ts, err := i.data.Get(timeBucket(i.seriesID), uint64(i.itemID)); err == nil && len(ts) == 8 {
+			return uint64(convert.BytesToInt64(ts))
+		}
+	}
 	return uint64(i.itemID)
 }
 
@@ -29,6 +29,9 @@ var ErrUnsupportedIndexRule = errors.New("the index rule is not supported")
 
 type Condition map[string][]index.ConditionValue
 
+// IndexFilter is the conjunction of the conditions on several index rules
+type IndexFilter map[*databasev1.IndexRule]Condition
+
 func (s *seekerBuilder) Filter(indexRule *databasev1.IndexRule, condition Condition) SeekerBuilder {
 	s.conditions = append(s.conditions, struct {
 		indexRuleType databasev1.IndexRule_Type
@@ -42,48 +45,102 @@ func (s *seekerBuilder) Filter(indexRule *databasev1.IndexRule, condition Condit
 	return s
 }
 
+func (s *seekerBuilder) FilterAny(filters ...IndexFilter) SeekerBuilder {
+	if len(filters) > 0 {
+		s.anyFilters = append(s.anyFilters, filters)
+	}
+	return s
+}
+
 type condWithIRT struct {
 	indexRuleType databasev1.IndexRule_Type
 	condition     index.Condition
 }
 
+// disjunction holds the branches of a FilterAny, each of which is a conjunction of conditions
+type disjunction [][]condWithIRT
+
 func (s *seekerBuilder) buildConditions() ([]condWithIRT, error) {
 	if len(s.conditions) < 1 {
 		return nil, nil
 	}
 	conditions := make([]condWithIRT, 0, len(s.conditions))
 	for _, condition := range s.conditions {
-		if len(condition.condition) > 1 {
-			//TODO:// should support composite index rule
-			return nil, ErrUnsupportedIndexRule
-		}
-		cond := make(index.Condition)
-		term := index.FieldKey{
-			SeriesID:    s.seriesSpan.seriesID,
-			IndexRuleID: condition.indexRuleID,
-		}
-		for _, c := range condition.condition {
-			cond[term] = c
-			break
+		cond, err := s.buildCondition(condition.indexRuleType, condition.indexRuleID, condition.condition)
+		if err != nil {
+			return nil, err
 		}
-		conditions = append(conditions, condWithIRT{indexRuleType: condition.indexRuleType, condition: cond})
+		conditions = append(conditions, cond)
 	}
 	return conditions, nil
 }
 
-func (s *seekerBuilder) buildIndexFilter(block blockDelegate, conditions []condWithIRT) (filterFn, error) {
+func (s *seekerBuilder) buildDisjunctions() ([]disjunction, error) {
+	if len(s.anyFilters) < 1 {
+		return nil, nil
+	}
+	disjunctions := make([]disjunction, 0, len(s.anyFilters))
+	for _, filters := range s.anyFilters {
+		d := make(disjunction, 0, len(filters))
+		for _, filter := range filters {
+			branch := make([]condWithIRT, 0, len(filter))
+			for indexRule, condition := range filter {
+				cond, err := s.buildCondition(indexRule.GetType(), indexRule.GetMetadata().GetId(), condition)
+				if err != nil {
+					return nil, err
+				}
+				branch = append(branch, cond)
+			}
+			d = append(d, branch)
+		}
+		disjunctions = append(disjunctions, d)
+	}
+	return disjunctions, nil
+}
+
+func (s *seekerBuilder) buildCondition(indexRuleType databasev1.IndexRule_Type, indexRuleID uint32,
+	condition Condition) (condWithIRT, error) {
+	if len(condition) > 1 {
+		//TODO:// should support composite index rule
+		return condWithIRT{}, ErrUnsupportedIndexRule
+	}
+	cond := make(index.Condition)
+	term := index.FieldKey{
+		SeriesID:    s.seriesSpan.seriesID,
+		IndexRuleID: indexRuleID,
+	}
+	for _, c := range condition {
+		cond[term] = c
+		break
+	}
+	return condWithIRT{indexRuleType: indexRuleType, condition: cond}, nil
+}
+
+func (s *seekerBuilder) buildIndexFilter(block blockDelegate, conditions []condWithIRT, disjunctions []disjunction) (filterFn, error) {
 	var allItemIDs posting.List
-	addIDs := func(allList posting.List, searcher index.Searcher, cond index.Condition) (posting.List, bool, error) {
+	searcher := func(indexRuleType databasev1.IndexRule_Type) (index.Searcher, error) {
+		switch indexRuleType {
+		case databasev1.IndexRule_TYPE_INVERTED:
+			return block.invertedIndexReader(), nil
+		case databasev1.IndexRule_TYPE_TREE:
+			return block.lsmIndexReader(), nil
+		}
+		return nil, ErrUnsupportedIndexRule
+	}
+	// the range on the sorting index is trimmed to seek the index, which only applies to the conditions combined by AND
+	addIDs := func(allList posting.List, searcher index.Searcher, cond index.Condition, trim bool) (posting.List, bool, error) {
 		tree, err := index.BuildTree(searcher, cond)
 		if err != nil {
 			return nil, false, err
 		}
-		rangeOpts, found := tree.TrimRangeLeaf(index.FieldKey{
-			SeriesID:    s.seriesSpan.seriesID,
-			IndexRuleID: s.indexRuleForSorting.GetMetadata().GetId(),
-		})
-		if found {
-			s.rangeOptsForSorting = rangeOpts
+		if trim {
+			rangeOpts, found := tree.TrimRangeLeaf(index.FieldKey{
+				SeriesID:    s.seriesSpan.seriesID,
+				IndexRuleID: s.indexRuleForSorting.GetMetadata().GetId(),
+			})
+			if found {
+				s.rangeOptsForSorting = rangeOpts
+			}
 		}
 		list, err := tree.Execute()
 		if errors.Is(err, index.ErrEmptyTree) {
@@ -104,16 +161,12 @@ func (s *seekerBuilder) buildIndexFilter(block blockDelegate, conditions []condW
 	}
 	allInvalid := true
 	for i, condition := range conditions {
-		var valid bool
-		var err error
-		switch condition.indexRuleType {
-		case databasev1.IndexRule_TYPE_INVERTED:
-			allItemIDs, valid, err = addIDs(allItemIDs, block.invertedIndexReader(), condition.condition)
-		case databasev1.IndexRule_TYPE_TREE:
-			allItemIDs, valid, err = addIDs(allItemIDs, block.lsmIndexReader(), condition.condition)
-		default:
-			return nil, ErrUnsupportedIndexRule
+		sr, err := searcher(condition.indexRuleType)
+		if err != nil {
+			return nil, err
 		}
+		var valid bool
+		allItemIDs, valid, err = addIDs(allItemIDs, sr, condition.condition, true)
 		if err != nil {
 			return nil, err
 		}
@@ -124,6 +177,45 @@ func (s *seekerBuilder) buildIndexFilter(block blockDelegate, conditions []condW
 		}
 		allInvalid = allInvalid && !valid
 	}
+	// a disjunction is skipped if any of its branches can't be served by the index, since that branch might match all
+	for _, d := range disjunctions {
+		var anyItemIDs posting.List
+		valid := true
+		for _, branch := range d {
+			var branchItemIDs posting.List
+			branchValid := false
+			for _, condition := range branch {
+				sr, err := searcher(condition.indexRuleType)
+				if err != nil {
+					return nil, err
+				}
+				var condValid bool
+				branchItemIDs, condValid, err = addIDs(branchItemIDs, sr, condition.condition, false)
+				if err != nil {
+					return nil, err
+				}
+				branchValid = branchValid || condValid
+			}
+			if !branchValid {
+				valid = false
+				break
+			}
+			if anyItemIDs == nil {
+				anyItemIDs = branchItemIDs
+			} else if err := anyItemIDs.Union(branchItemIDs); err != nil {
+				return nil, err
+			}
+		}
+		if !valid {
+			continue
+		}
+		if allItemIDs == nil {
+			allItemIDs = anyItemIDs
+		} else if err := allItemIDs.Intersect(anyItemIDs); err != nil {
+			return nil, err
+		}
+		allInvalid = false
+	}
 
 	if allInvalid {
 		return nil, nil
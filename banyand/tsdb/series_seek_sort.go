@@ -33,8 +33,6 @@ import (
 	"github.com/apache/skywalking-banyandb/pkg/logger"
 )
 
-var emptyFilters = make([]filterFn, 0)
-
 func (s *seekerBuilder) OrderByIndex(indexRule *databasev1.IndexRule, order modelv1.Sort) SeekerBuilder {
 	s.indexRuleForSorting = indexRule
 	s.order = order
@@ -47,14 +45,14 @@ func (s *seekerBuilder) OrderByTime(order modelv1.Sort) SeekerBuilder {
 	return s
 }
 
-func (s *seekerBuilder) buildSeries(conditions []condWithIRT) ([]Iterator, error) {
+func (s *seekerBuilder) buildSeries(conditions []condWithIRT, disjunctions []disjunction) ([]Iterator, error) {
 	if s.indexRuleForSorting == nil {
-		return s.buildSeriesByTime(conditions)
+		return s.buildSeriesByTime(conditions, disjunctions)
 	}
-	return s.buildSeriesByIndex(conditions)
+	return s.buildSeriesByIndex(conditions, disjunctions)
 }
 
-func (s *seekerBuilder) buildSeriesByIndex(conditions []condWithIRT) (series []Iterator, err error) {
+func (s *seekerBuilder) buildSeriesByIndex(conditions []condWithIRT, disjunctions []disjunction) (series []Iterator, err error) {
 	timeFilter := func(item Item) bool {
 		valid := s.seriesSpan.timeRange.contains(item.Time())
 		timeRange := s.seriesSpan.timeRange
@@ -70,14 +68,17 @@ func (s *seekerBuilder) buildSeriesByIndex(conditions []condWithIRT) (series []I
 			SeriesID:    s.seriesSpan.seriesID,
 			IndexRuleID: s.indexRuleForSorting.GetMetadata().GetId(),
 		}
-		filters := []filterFn{timeFilter}
-		filter, err := s.buildIndexFilter(b, conditions)
+		filters := append([]filterFn{timeFilter}, s.filters()...)
+		filter, err := s.buildIndexFilter(b, conditions, disjunctions)
 		if err != nil {
 			return nil, err
 		}
 		if filter != nil {
 			filters = append(filters, filter)
 		}
+		if tagFilter := s.tagFilter(); tagFilter != nil {
+			filters = append(filters, tagFilter)
+		}
 		switch s.indexRuleForSorting.GetType() {
 		case databasev1.IndexRule_TYPE_TREE:
 			inner, err = b.lsmIndexReader().Iterator(fieldKey, s.rangeOptsForSorting, s.order)
@@ -88,13 +89,13 @@ func (s *seekerBuilder) buildSeriesByIndex(conditions []condWithIRT) (series []I
 			return nil, err
 		}
 		if inner != nil {
-			series = append(series, newSearcherIterator(s.seriesSpan.l, inner, b.dataReader(), s.seriesSpan.seriesID, filters))
+			series = append(series, newSearcherIterator(s.seriesSpan.l, inner, b.dataReader(), b.appendOnly(), s.seriesSpan.seriesID, filters))
 		}
 	}
 	return
 }
 
-func (s *seekerBuilder) buildSeriesByTime(conditions []condWithIRT) ([]Iterator, error) {
+func (s *seekerBuilder) buildSeriesByTime(conditions []condWithIRT, disjunctions []disjunction) ([]Iterator, error) {
 	bb := s.seriesSpan.blocks
 	switch s.order {
 	case modelv1.Sort_SORT_ASC, modelv1.Sort_SORT_UNSPECIFIED:
@@ -129,15 +130,18 @@ func (s *seekerBuilder) buildSeriesByTime(conditions []condWithIRT) ([]Iterator,
 			return nil, err
 		}
 		if inner != nil {
-			filter, err := s.buildIndexFilter(b, conditions)
+			filter, err := s.buildIndexFilter(b, conditions, disjunctions)
 			if err != nil {
 				return nil, err
 			}
-			if filter == nil {
-				delegated = append(delegated, newSearcherIterator(s.seriesSpan.l, inner, b.dataReader(), s.seriesSpan.seriesID, emptyFilters))
-			} else {
-				delegated = append(delegated, newSearcherIterator(s.seriesSpan.l, inner, b.dataReader(), s.seriesSpan.seriesID, []filterFn{filter}))
+			filters := s.filters()
+			if filter != nil {
+				filters = append(filters, filter)
 			}
+			if tagFilter := s.tagFilter(); tagFilter != nil {
+				filters = append(filters, tagFilter)
+			}
+			delegated = append(delegated, newSearcherIterator(s.seriesSpan.l, inner, b.dataReader(), b.appendOnly(), s.seriesSpan.seriesID, filters))
 		}
 	}
 	s.seriesSpan.l.Debug().
@@ -146,7 +150,7 @@ func (s *seekerBuilder) buildSeriesByTime(conditions []condWithIRT) ([]Iterator,
 		Uint64("series_id", uint64(s.seriesSpan.seriesID)).
 		Int("shard_id", int(s.seriesSpan.shardID)).
 		Msg("seek series by time")
-	return []Iterator{newMergedIterator(delegated)}, nil
+	return []Iterator{newMergedIterator(delegated, s.order)}, nil
 }
 
 var _ Iterator = (*searcherIterator)(nil)
@@ -156,6 +160,7 @@ type searcherIterator struct {
 	curKey        []byte
 	cur           posting.Iterator
 	data          kv.TimeSeriesReader
+	appendOnly    bool
 	seriesID      common.SeriesID
 	filters       []filterFn
 	l             *logger.Logger
@@ -193,6 +198,7 @@ func (s *searcherIterator) Val() Item {
 		itemID:      s.cur.Current(),
 		data:        s.data,
 		seriesID:    s.seriesID,
+		appendOnly:  s.appendOnly,
 	}
 }
 
@@ -200,11 +206,12 @@ func (s *searcherIterator) Close() error {
 	return s.fieldIterator.Close()
 }
 
-func newSearcherIterator(l *logger.Logger, fieldIterator index.FieldIterator, data kv.TimeSeriesReader,
+func newSearcherIterator(l *logger.Logger, fieldIterator index.FieldIterator, data kv.TimeSeriesReader, appendOnly bool,
 	seriesID common.SeriesID, filters []filterFn) Iterator {
 	return &searcherIterator{
 		fieldIterator: fieldIterator,
 		data:          data,
+		appendOnly:    appendOnly,
 		seriesID:      seriesID,
 		filters:       filters,
 		l:             l,
@@ -213,30 +220,59 @@ func newSearcherIterator(l *logger.Logger, fieldIterator index.FieldIterator, da
 
 var _ Iterator = (*mergedIterator)(nil)
 
+// mergedIterator merges the items of the blocks in the order of their time, which unions the local blocks
+// and the ones fetched from the BlockStore no matter whether their time ranges overlap
 type mergedIterator struct {
-	curr      Iterator
-	index     int
+	curr      Item
+	heads     []Item
+	init      bool
+	order     modelv1.Sort
 	delegated []Iterator
 }
 
 func (m *mergedIterator) Next() bool {
-	if m.curr == nil {
-		m.index++
-		if m.index >= len(m.delegated) {
-			return false
+	if !m.init {
+		m.init = true
+		for i := range m.delegated {
+			m.advance(i)
+		}
+	}
+	headIndex := -1
+	for i, head := range m.heads {
+		if head == nil {
+			continue
+		}
+		if headIndex < 0 || m.before(head, m.heads[headIndex]) {
+			headIndex = i
 		}
-		m.curr = m.delegated[m.index]
 	}
-	hasNext := m.curr.Next()
-	if !hasNext {
+	if headIndex < 0 {
 		m.curr = nil
-		return m.Next()
+		return false
 	}
+	m.curr = m.heads[headIndex]
+	m.advance(headIndex)
 	return true
 }
 
+// before keeps the items at the same time in the order of their blocks
+func (m *mergedIterator) before(a, b Item) bool {
+	if m.order == modelv1.Sort_SORT_DESC {
+		return a.Time() > b.Time()
+	}
+	return a.Time() < b.Time()
+}
+
+func (m *mergedIterator) advance(index int) {
+	if m.delegated[index].Next() {
+		m.heads[index] = m.delegated[index].Val()
+		return
+	}
+	m.heads[index] = nil
+}
+
 func (m *mergedIterator) Val() Item {
-	return m.curr.Val()
+	return m.curr
 }
 
 func (m *mergedIterator) Close() error {
@@ -247,9 +283,10 @@ func (m *mergedIterator) Close() error {
 	return err
 }
 
-func newMergedIterator(delegated []Iterator) Iterator {
+func newMergedIterator(delegated []Iterator, order modelv1.Sort) Iterator {
 	return &mergedIterator{
-		index:     -1,
+		heads:     make([]Item, len(delegated)),
+		order:     order,
 		delegated: delegated,
 	}
 }
@@ -0,0 +1,109 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tsdb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/apache/skywalking-banyandb/api/common"
+	modelv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/model/v1"
+)
+
+type sliceIterator struct {
+	items  []common.ItemID
+	index  int
+	closed bool
+}
+
+func (s *sliceIterator) Next() bool {
+	s.index++
+	return s.index < len(s.items)
+}
+
+func (s *sliceIterator) Val() Item {
+	return &item{itemID: s.items[s.index]}
+}
+
+func (s *sliceIterator) Close() error {
+	s.closed = true
+	return nil
+}
+
+func newSliceIterator(items ...common.ItemID) *sliceIterator {
+	return &sliceIterator{items: items, index: -1}
+}
+
+func Test_MergedIterator(t *testing.T) {
+	tests := []struct {
+		name  string
+		order modelv1.Sort
+		iters [][]common.ItemID
+		want  []uint64
+	}{
+		{
+			name:  "disjoint in ascending order",
+			order: modelv1.Sort_SORT_ASC,
+			iters: [][]common.ItemID{{1, 2}, {3, 4}},
+			want:  []uint64{1, 2, 3, 4},
+		},
+		{
+			name:  "overlapping in ascending order",
+			order: modelv1.Sort_SORT_ASC,
+			iters: [][]common.ItemID{{1, 4, 5}, {}, {2, 3, 6}},
+			want:  []uint64{1, 2, 3, 4, 5, 6},
+		},
+		{
+			name:  "overlapping in descending order",
+			order: modelv1.Sort_SORT_DESC,
+			iters: [][]common.ItemID{{6, 3, 2}, {5, 4, 1}},
+			want:  []uint64{6, 5, 4, 3, 2, 1},
+		},
+		{
+			name:  "the same time in both",
+			order: modelv1.Sort_SORT_ASC,
+			iters: [][]common.ItemID{{1, 2}, {2, 3}},
+			want:  []uint64{1, 2, 2, 3},
+		},
+		{
+			name:  "empty",
+			order: modelv1.Sort_SORT_ASC,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := require.New(t)
+			delegated := make([]Iterator, 0, len(tt.iters))
+			for _, items := range tt.iters {
+				delegated = append(delegated, newSliceIterator(items...))
+			}
+			merged := newMergedIterator(delegated, tt.order)
+			var got []uint64
+			for merged.Next() {
+				got = append(got, merged.Val().Time())
+			}
+			req.False(merged.Next())
+			req.Equal(tt.want, got)
+			req.NoError(merged.Close())
+			for _, d := range delegated {
+				req.True(d.(*sliceIterator).closed)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tsdb
+
+// TagPredicate decides whether the seeker yields an item.
+// It's evaluated against the tag families of each item during the seeking, after the time, tombstone and index filters.
+// The families are loaded on demand, so the ones the predicate doesn't read are never loaded or decoded.
+type TagPredicate func(families TagFamilies) (bool, error)
+
+// TagFamilies gives the raw tag families of an item
+type TagFamilies interface {
+	Family(name string) ([]byte, error)
+}
+
+var _ TagFamilies = (*lazyTagFamilies)(nil)
+
+// lazyTagFamilies loads a family once no matter how many times the predicates read it
+type lazyTagFamilies struct {
+	item   Item
+	loaded map[string][]byte
+}
+
+func (l *lazyTagFamilies) Family(name string) ([]byte, error) {
+	if v, ok := l.loaded[name]; ok {
+		return v, nil
+	}
+	v, err := l.item.Family(name)
+	if err != nil {
+		return nil, err
+	}
+	if l.loaded == nil {
+		l.loaded = make(map[string][]byte)
+	}
+	l.loaded[name] = v
+	return v, nil
+}
+
+func (s *seekerBuilder) TagFilter(predicate TagPredicate) SeekerBuilder {
+	s.tagPredicates = append(s.tagPredicates, predicate)
+	return s
+}
+
+// tagFilter returns nil if there's no tag predicate.
+// An item failing to be evaluated is skipped.
+func (s *seekerBuilder) tagFilter() filterFn {
+	if len(s.tagPredicates) == 0 {
+		return nil
+	}
+	return func(item Item) bool {
+		families := &lazyTagFamilies{item: item}
+		for _, predicate := range s.tagPredicates {
+			matched, err := predicate(families)
+			if err != nil {
+				s.seriesSpan.l.Warn().Err(err).Uint64("series_id", uint64(s.seriesSpan.seriesID)).
+					Uint64("item_id", uint64(item.ID())).Msg("failed to evaluate the tag predicate")
+				return false
+			}
+			if !matched {
+				return false
+			}
+		}
+		return true
+	}
+}
@@ -0,0 +1,88 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tsdb
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	modelv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/model/v1"
+)
+
+func Test_Database_TagFilter(t *testing.T) {
+	tester := assert.New(t)
+	_, deferFunc, db := setUp(require.New(t))
+	defer deferFunc()
+	shard, err := db.Shard(0)
+	tester.NoError(err)
+	now := time.Now()
+	timeRange := NewTimeRangeDuration(now.Add(-time.Hour), 2*time.Hour)
+	series, err := shard.Series().Get(Entity{Entry("productpage"), Entry("10.0.0.1")})
+	tester.NoError(err)
+	span, err := series.Span(timeRange)
+	tester.NoError(err)
+	defer span.Close()
+	statuses := []string{"ok", "error", "ok", "ok", "error", "ok"}
+	for i, status := range statuses {
+		writer, errWriter := span.WriterBuilder().
+			Family([]byte("searchable"), []byte(status)).
+			Family([]byte("data"), []byte(fmt.Sprintf("payload-%d", i))).
+			Time(now.Add(time.Duration(i) * time.Millisecond)).
+			Build()
+		tester.NoError(errWriter)
+		_, errWriter = writer.Write()
+		tester.NoError(errWriter)
+	}
+
+	var evaluated, skipped int
+	seeker, err := span.SeekerBuilder().
+		OrderByTime(modelv1.Sort_SORT_ASC).
+		TagFilter(func(families TagFamilies) (bool, error) {
+			evaluated++
+			status, errFamily := families.Family("searchable")
+			if errFamily != nil {
+				return false, errFamily
+			}
+			if string(status) != "error" {
+				skipped++
+				return false, nil
+			}
+			return true, nil
+		}).
+		Build()
+	tester.NoError(err)
+	iters, err := seeker.Seek()
+	tester.NoError(err)
+	var got []string
+	for _, iter := range iters {
+		for iter.Next() {
+			data, errFamily := iter.Val().Family("data")
+			tester.NoError(errFamily)
+			got = append(got, string(data))
+		}
+		tester.NoError(iter.Close())
+	}
+	tester.Equal([]string{"payload-1", "payload-4"}, got)
+	// the non-matching items are skipped during the seeking before reaching the caller
+	tester.Equal(len(statuses), evaluated)
+	tester.Equal(4, skipped)
+}
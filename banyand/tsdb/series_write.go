@@ -19,9 +19,11 @@ package tsdb
 
 import (
 	"bytes"
+	"context"
 	"time"
 
 	"github.com/pkg/errors"
+	"go.uber.org/multierr"
 
 	"github.com/apache/skywalking-banyandb/api/common"
 	"github.com/apache/skywalking-banyandb/pkg/convert"
@@ -32,12 +34,29 @@ type WriterBuilder interface {
 	Family(name []byte, val []byte) WriterBuilder
 	Time(ts time.Time) WriterBuilder
 	Val(val []byte) WriterBuilder
+	// Stat adds a numeric column of the item to the statistics of the block, which serve the aggregations
+	Stat(column string, value int64) WriterBuilder
+	// Context bounds waiting for the flush of the shard when writing
+	Context(ctx context.Context) WriterBuilder
+	// Sequence replays a write with the sequence assigned before, e.g. from a write-ahead log.
+	// The write is skipped with ErrSequenceApplied if the block has applied it.
+	Sequence(seq uint64) WriterBuilder
 	Build() (Writer, error)
 }
 
 type Writer interface {
 	IndexWriter
 	Write() (GlobalItemID, error)
+	// Sync flushes the data written into the block to the disk
+	Sync() error
+	ItemID() GlobalItemID
+	// Sequence is the monotonic sequence of the write in its shard, which is assigned by Write if it's not replayed
+	Sequence() uint64
+}
+
+// ItemIndexWriter generates the indices of an item
+type ItemIndexWriter interface {
+	IndexWriter
 	ItemID() GlobalItemID
 }
 
@@ -50,8 +69,12 @@ type writerBuilder struct {
 		family []byte
 		val    []byte
 	}
+	stats         map[string]int64
 	ts            time.Time
 	seriesIDBytes []byte
+	err           error
+	ctx           context.Context
+	seq           uint64
 }
 
 func (w *writerBuilder) Family(name []byte, val []byte) WriterBuilder {
@@ -64,12 +87,16 @@ func (w *writerBuilder) Family(name []byte, val []byte) WriterBuilder {
 
 func (w *writerBuilder) Time(ts time.Time) WriterBuilder {
 	w.ts = ts
+	w.block = nil
 	for _, b := range w.series.blocks {
 		if b.contains(ts) {
 			w.block = b
 			break
 		}
 	}
+	if w.block == nil {
+		w.block, w.err = w.series.blockAt(ts)
+	}
 	return w
 }
 
@@ -81,15 +108,63 @@ func (w *writerBuilder) Val(val []byte) WriterBuilder {
 	return w
 }
 
+func (w *writerBuilder) Stat(column string, value int64) WriterBuilder {
+	if w.stats == nil {
+		w.stats = make(map[string]int64)
+	}
+	w.stats[column] = value
+	return w
+}
+
+func (w *writerBuilder) Context(ctx context.Context) WriterBuilder {
+	w.ctx = ctx
+	return w
+}
+
+func (w *writerBuilder) Sequence(seq uint64) WriterBuilder {
+	w.seq = seq
+	return w
+}
+
+var ErrItemOutOfSpan = errors.New("the item is out of the span")
+
+func (s *seriesSpan) IndexWriter(id common.ItemID) (ItemIndexWriter, error) {
+	ts := time.Unix(0, int64(id))
+	for _, b := range s.blocks {
+		if !b.contains(ts) {
+			continue
+		}
+		segID, blockID := b.identity()
+		return &writer{
+			block: b,
+			ts:    ts,
+			itemID: &GlobalItemID{
+				ShardID:  s.shardID,
+				segID:    segID,
+				blockID:  blockID,
+				SeriesID: s.seriesID,
+				ID:       id,
+			},
+		}, nil
+	}
+	return nil, errors.Wrapf(ErrItemOutOfSpan, "item:%d", id)
+}
+
 var ErrNoTime = errors.New("no time specified")
 var ErrNoVal = errors.New("no value specified")
 
 var ErrDuplicatedFamily = errors.New("duplicated family")
 
 func (w *writerBuilder) Build() (Writer, error) {
+	if w.err != nil {
+		return nil, w.err
+	}
 	if w.block == nil {
 		return nil, errors.WithStack(ErrNoTime)
 	}
+	if !w.block.writable() {
+		return nil, errors.WithStack(ErrSegmentImmutable)
+	}
 	if len(w.values) < 1 {
 		return nil, errors.WithStack(ErrNoVal)
 	}
@@ -115,6 +190,9 @@ func (w *writerBuilder) Build() (Writer, error) {
 			ID:       common.ItemID(uint64(w.ts.UnixNano())),
 		},
 		columns: w.values,
+		stats:   w.stats,
+		ctx:     w.ctx,
+		seq:     w.seq,
 	}, nil
 }
 
@@ -122,6 +200,7 @@ func newWriterBuilder(seriesSpan *seriesSpan) WriterBuilder {
 	return &writerBuilder{
 		series:        seriesSpan,
 		seriesIDBytes: seriesSpan.seriesID.Marshal(),
+		ctx:           context.Background(),
 	}
 }
 
@@ -134,13 +213,20 @@ type writer struct {
 		family []byte
 		val    []byte
 	}
+	stats  map[string]int64
 	itemID *GlobalItemID
+	ctx    context.Context
+	seq    uint64
 }
 
 func (w *writer) ItemID() GlobalItemID {
 	return *w.itemID
 }
 
+func (w *writer) Sequence() uint64 {
+	return w.seq
+}
+
 func (w *writer) WriteLSMIndex(field index.Field) error {
 	field.Key.SeriesID = w.itemID.SeriesID
 	return w.block.writeLSMIndex(field, w.itemID.ID)
@@ -166,22 +252,125 @@ func (d dataBucket) marshal() []byte {
 	}, nil)
 }
 
+// timeBucketMarker follows the series id in the key of the time bucket, which is shorter than the keys of
+// the data buckets with families and longer than the ones without.
+const timeBucketMarker byte = 0xff
+
+// timeBucket keeps the written time of the items in an append-only block whose ids are moved off their time
+// by the colliding items of the series. It's keyed by the ids of the moved items.
+func timeBucket(seriesID common.SeriesID) []byte {
+	return append(seriesID.Marshal(), timeBucketMarker)
+}
+
+// Write syncs the block after writing the item if the Durability is PerWrite,
+// which happens out of the write lock since the sync blocks the writes of the shard
 func (w *writer) Write() (GlobalItemID, error) {
+	id, err := w.writeLocked()
+	if err != nil || !w.block.syncOnWrite() {
+		return id, err
+	}
+	return id, w.block.sync()
+}
+
+func (w *writer) writeLocked() (GlobalItemID, error) {
+	if err := w.block.lockWrite(w.ctx); err != nil {
+		return w.ItemID(), err
+	}
+	defer w.block.unlockWrite()
+	seq, err := w.block.assignSequence(w.seq)
+	if err != nil {
+		return w.ItemID(), err
+	}
+	w.seq = seq
+	if w.block.lockAppend() {
+		defer w.block.unlockAppend()
+		if err := w.allocateID(); err != nil {
+			return w.ItemID(), err
+		}
+	}
 	id := w.ItemID()
+	w.block.track(id, func() bool {
+		// the append-only block never overwrites an item
+		if w.block.appendOnly() {
+			return false
+		}
+		indexed, errIndexed := w.indexed(id.ID)
+		return errIndexed == nil && indexed
+	})
+	if err := w.block.recordStats(id.SeriesID, w.ts, w.stats); err != nil {
+		return id, err
+	}
+	if err := w.write(id); err != nil {
+		return id, multierr.Append(err, w.block.invalidateStats(id.SeriesID))
+	}
+	w.block.applySequence(seq)
+	return id, nil
+}
+
+func (w *writer) write(id GlobalItemID) error {
+	at := time.Unix(0, int64(id.ID))
 	for _, c := range w.columns {
 		err := w.block.write(dataBucket{
 			seriesID: w.itemID.SeriesID,
 			family:   c.family,
 		}.marshal(),
-			c.val, w.ts)
+			c.val, at)
 		if err != nil {
-			return id, err
+			return err
 		}
 	}
-	return id, w.block.writePrimaryIndex(index.Field{
+	if !at.Equal(w.ts) {
+		if err := w.block.write(timeBucket(id.SeriesID), convert.Int64ToBytes(w.ts.UnixNano()), at); err != nil {
+			return err
+		}
+	}
+	return w.block.writePrimaryIndex(index.Field{
 		Key: index.FieldKey{
 			SeriesID: id.SeriesID,
 		},
 		Term: convert.Int64ToBytes(w.ts.UnixNano()),
 	}, id.ID)
 }
+
+// allocateID picks the first id from the time of the item that no item of the series holds, which keeps every item
+// in an append-only block. The item is indexed at the time it's written, and the time bucket maps a moved id back to it.
+func (w *writer) allocateID() error {
+	for id := common.ItemID(w.ts.UnixNano()); ; id++ {
+		taken, err := w.idTaken(id)
+		if err != nil {
+			return err
+		}
+		if !taken {
+			w.itemID.ID = id
+			return nil
+		}
+	}
+}
+
+// idTaken checks whether an item written at the time of id holds it, or a moved item does
+func (w *writer) idTaken(id common.ItemID) (bool, error) {
+	indexed, err := w.indexed(id)
+	if err != nil || indexed {
+		return indexed, err
+	}
+	ts, err := w.block.dataReader().Get(timeBucket(w.itemID.SeriesID), uint64(id))
+	return err == nil && len(ts) > 0, nil
+}
+
+// indexed checks whether an item written at the time of id holds it
+func (w *writer) indexed(id common.ItemID) (bool, error) {
+	list, err := w.block.primaryIndexReader().MatchTerms(index.Field{
+		Key: index.FieldKey{
+			SeriesID: w.itemID.SeriesID,
+		},
+		Term: convert.Int64ToBytes(int64(id)),
+	})
+	if err != nil {
+		return false, err
+	}
+	return list != nil && list.Contains(id), nil
+}
+
+func (w *writer) Sync() error {
+	return w.block.sync()
+}
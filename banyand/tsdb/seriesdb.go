@@ -23,12 +23,16 @@ import (
 	"io"
 	"math"
 	"sync"
+	"time"
 
+	"github.com/pkg/errors"
 	"go.uber.org/multierr"
 
 	"github.com/apache/skywalking-banyandb/api/common"
+	databasev1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/database/v1"
 	"github.com/apache/skywalking-banyandb/banyand/kv"
 	"github.com/apache/skywalking-banyandb/pkg/convert"
+	"github.com/apache/skywalking-banyandb/pkg/index"
 	"github.com/apache/skywalking-banyandb/pkg/logger"
 )
 
@@ -91,12 +95,18 @@ type SeriesDatabase interface {
 	Get(entity Entity) (Series, error)
 	GetByHashKey(key []byte) (Series, error)
 	List(path Path) (SeriesList, error)
+	// IndexSearchers returns the local index searchers of indexType in the blocks that overlap timeRange.
+	// Closing the returned io.Closer releases these blocks.
+	IndexSearchers(timeRange TimeRange, indexType databasev1.IndexRule_Type) ([]index.Searcher, io.Closer, error)
 }
 
 type blockDatabase interface {
 	shardID() common.ShardID
-	span(timeRange TimeRange) []blockDelegate
-	block(id GlobalItemID) blockDelegate
+	span(timeRange TimeRange) ([]blockDelegate, error)
+	block(id GlobalItemID) (blockDelegate, error)
+	// blockAt returns the block of the partition holding ts, it's nil if the database isn't partitioned
+	blockAt(ts time.Time) (blockDelegate, error)
+	tombstone() kv.Store
 }
 
 var _ SeriesDatabase = (*seriesDB)(nil)
@@ -106,8 +116,9 @@ type seriesDB struct {
 	sync.Mutex
 	l *logger.Logger
 
-	lst            []*segment
+	segCtrl        *segmentController
 	seriesMetadata kv.Store
+	tombstones     kv.Store
 	sID            common.ShardID
 }
 
@@ -133,14 +144,40 @@ func (s *seriesDB) GetByID(id common.SeriesID) (Series, error) {
 	return newSeries(s.context(), id, s), nil
 }
 
-func (s *seriesDB) block(id GlobalItemID) blockDelegate {
-	return s.lst[id.segID].lst[id.blockID].delegate()
+// block returns nil if the segment holding the item has expired
+func (s *seriesDB) block(id GlobalItemID) (blockDelegate, error) {
+	seg := s.segCtrl.get(id.segID)
+	if seg == nil {
+		return nil, nil
+	}
+	b := seg.block(id.blockID)
+	if b == nil {
+		return nil, errors.Wrapf(ErrBlockAbsent, "block %d of segment %d", id.blockID, id.segID)
+	}
+	return b.delegate()
+}
+
+func (s *seriesDB) blockAt(ts time.Time) (blockDelegate, error) {
+	seg, err := s.segCtrl.ensure(ts)
+	if err != nil || seg == nil {
+		return nil, err
+	}
+	// only the block containing ts is fetched if it's offloaded
+	b, err := seg.ensureBlock(ts)
+	if err != nil || b == nil {
+		return nil, err
+	}
+	return b.delegate()
 }
 
 func (s *seriesDB) shardID() common.ShardID {
 	return s.sID
 }
 
+func (s *seriesDB) tombstone() kv.Store {
+	return s.tombstones
+}
+
 func (s *seriesDB) Get(entity Entity) (Series, error) {
 	key := HashEntity(entity)
 	return s.GetByHashKey(key)
@@ -191,13 +228,62 @@ func (s *seriesDB) List(path Path) (SeriesList, error) {
 	return result, err
 }
 
-func (s *seriesDB) span(_ TimeRange) []blockDelegate {
+// span skips the offloaded blocks out of timeRange, so only the overlapping ones are fetched from the BlockStore
+func (s *seriesDB) span(timeRange TimeRange) ([]blockDelegate, error) {
 	//TODO: return correct blocks
-	result := make([]blockDelegate, 0, len(s.lst[0].lst))
-	for _, b := range s.lst[0].lst {
-		result = append(result, b.delegate())
+	result := make([]blockDelegate, 0)
+	pinned := make(pinnedBlocks)
+	for _, seg := range s.segCtrl.segments() {
+		for _, b := range seg.blocks() {
+			if b.isOffloaded() && !b.overlaps(timeRange) {
+				continue
+			}
+			d, err := b.delegate()
+			if errors.Is(err, ErrExpiredItem) {
+				// the segment is removed after the list is taken
+				break
+			}
+			if err != nil {
+				return nil, multierr.Append(err, blockCloser(result).Close())
+			}
+			if !pinned.pin(d) {
+				_ = d.Close()
+				continue
+			}
+			result = append(result, d)
+		}
 	}
-	return result
+	return result, nil
+}
+
+func (s *seriesDB) IndexSearchers(timeRange TimeRange, indexType databasev1.IndexRule_Type) ([]index.Searcher, io.Closer, error) {
+	blocks, err := s.span(timeRange)
+	if err != nil {
+		return nil, nil, err
+	}
+	searchers := make([]index.Searcher, 0, len(blocks))
+	for _, b := range blocks {
+		var searcher index.Searcher
+		switch indexType {
+		case databasev1.IndexRule_TYPE_INVERTED:
+			searcher = b.invertedIndexReader()
+		case databasev1.IndexRule_TYPE_TREE:
+			searcher = b.lsmIndexReader()
+		}
+		if searcher != nil {
+			searchers = append(searchers, searcher)
+		}
+	}
+	return searchers, blockCloser(blocks), nil
+}
+
+type blockCloser []blockDelegate
+
+func (bc blockCloser) Close() (err error) {
+	for _, b := range bc {
+		err = multierr.Append(err, b.Close())
+	}
+	return err
 }
 
 func (s *seriesDB) context() context.Context {
@@ -205,16 +291,14 @@ func (s *seriesDB) context() context.Context {
 }
 
 func (s *seriesDB) Close() error {
-	for _, seg := range s.lst {
-		seg.close()
-	}
-	return s.seriesMetadata.Close()
+	s.segCtrl.close()
+	return multierr.Combine(s.seriesMetadata.Close(), s.tombstones.Close())
 }
 
-func newSeriesDataBase(ctx context.Context, shardID common.ShardID, path string, segLst []*segment) (SeriesDatabase, error) {
+func newSeriesDataBase(ctx context.Context, shardID common.ShardID, path string, segCtrl *segmentController) (SeriesDatabase, error) {
 	sdb := &seriesDB{
-		sID: shardID,
-		lst: segLst,
+		sID:     shardID,
+		segCtrl: segCtrl,
 	}
 	parentLogger := ctx.Value(logger.ContextKey)
 	if parentLogger == nil {
@@ -228,6 +312,13 @@ func newSeriesDataBase(ctx context.Context, shardID common.ShardID, path string,
 	if err != nil {
 		return nil, err
 	}
+	sdb.tombstones, err = kv.OpenStore(0, path+"/tombstone", kv.StoreWithNamedLogger("tombstone", sdb.l))
+	if err != nil {
+		return nil, err
+	}
+	if segCtrl != nil {
+		segCtrl.onRemove = sdb.dropTombstones
+	}
 	return sdb, nil
 }
 
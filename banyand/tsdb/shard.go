@@ -22,6 +22,9 @@ import (
 	"sync"
 	"time"
 
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+
 	"github.com/apache/skywalking-banyandb/api/common"
 )
 
@@ -31,10 +34,11 @@ type shard struct {
 	sync.Mutex
 	id common.ShardID
 
-	location       string
-	seriesDatabase SeriesDatabase
-	indexDatabase  IndexDatabase
-	lst            []*segment
+	location          string
+	seriesDatabase    SeriesDatabase
+	indexDatabase     IndexDatabase
+	segmentController *segmentController
+	writeLock         *writeLock
 }
 
 func (s *shard) ID() common.ShardID {
@@ -49,34 +53,49 @@ func (s *shard) Index() IndexDatabase {
 	return s.indexDatabase
 }
 
+func (s *shard) SegmentsInRange(start, end time.Time) []Segment {
+	return s.segmentController.inRange(start, end)
+}
+
 func newShard(ctx context.Context, id common.ShardID, location string) (*shard, error) {
-	s := &shard{
-		id:       id,
-		location: location,
+	if hook, ok := ctx.Value(flushHookKey).(flushHook); ok {
+		ctx = context.WithValue(ctx, flushHookKey, flushHook(func(event FlushEvent) {
+			event.ShardID = id
+			hook(event)
+		}))
 	}
-	segPath, err := mkdir(segTemplate, location, time.Now().Format(segFormat))
-	if err != nil {
-		return nil, err
+	if snapshots, ok := ctx.Value(snapshotsKey).(*snapshotTracker); ok {
+		ctx = context.WithValue(ctx, snapshotsKey, snapshots.shard(id))
 	}
-	seg, err := newSegment(ctx, segPath)
-	if err != nil {
+	timeout, _ := ctx.Value(writeLockTimeout).(time.Duration)
+	lock := newWriteLock(id, timeout)
+	ctx = context.WithValue(ctx, writeLockKey, lock)
+	ctx = context.WithValue(ctx, sequenceKey, &shardSequence{})
+	s := &shard{
+		id:                id,
+		location:          location,
+		segmentController: newSegmentController(ctx, location),
+		writeLock:         lock,
+	}
+	if err := s.segmentController.load(); err != nil {
 		return nil, err
 	}
-	{
-		s.Lock()
-		defer s.Unlock()
-		s.lst = append(s.lst, seg)
+	// the shard reopened within the window of one of its segments goes on writing into it
+	if now := time.Now(); s.segmentController.find(now) == nil {
+		if _, err := s.segmentController.create(now); err != nil {
+			return nil, err
+		}
 	}
 	seriesPath, err := mkdir(seriesTemplate, s.location)
 	if err != nil {
 		return nil, err
 	}
-	sdb, err := newSeriesDataBase(ctx, s.id, seriesPath, s.lst)
+	sdb, err := newSeriesDataBase(ctx, s.id, seriesPath, s.segmentController)
 	if err != nil {
 		return nil, err
 	}
 	s.seriesDatabase = sdb
-	idb, err := newIndexDatabase(ctx, s.id, s.lst)
+	idb, err := newIndexDatabase(ctx, s.id, s.segmentController)
 	if err != nil {
 		return nil, err
 	}
@@ -84,6 +103,31 @@ func newShard(ctx context.Context, id common.ShardID, location string) (*shard,
 	return s, nil
 }
 
+// forEachBlock applies fn to the local blocks of all the segments, which skips the offloaded ones
+func (s *shard) forEachBlock(fn func(b blockDelegate) error) (err error) {
+	pinned := make(pinnedBlocks)
+	for _, seg := range s.segmentController.segments() {
+		for _, b := range seg.blocks() {
+			if b.isOffloaded() {
+				continue
+			}
+			d, errDelegate := b.delegate()
+			if errors.Is(errDelegate, ErrExpiredItem) {
+				break
+			}
+			if errDelegate != nil {
+				err = multierr.Append(err, errDelegate)
+				continue
+			}
+			if pinned.pin(d) {
+				err = multierr.Append(err, fn(d))
+			}
+			_ = d.Close()
+		}
+	}
+	return err
+}
+
 func (s *shard) Close() error {
 	return s.seriesDatabase.Close()
 }
@@ -0,0 +1,133 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tsdb
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+
+	"github.com/apache/skywalking-banyandb/api/common"
+	modelv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/model/v1"
+	"github.com/apache/skywalking-banyandb/banyand/kv"
+)
+
+// SeriesIterator scans the values of a series in the order of their time.
+// Next returns false once the values run out or reading a value fails, and Close reports the failure.
+type SeriesIterator interface {
+	Next() bool
+	// Val returns the value and the time of the current item. The value is nil if the item is written without one.
+	Val() ([]byte, time.Time)
+	Close() error
+}
+
+func (s *shard) Iterator(seriesID common.SeriesID, start, end time.Time, order modelv1.Sort) (SeriesIterator, error) {
+	sdb, ok := s.seriesDatabase.(*seriesDB)
+	if !ok {
+		return nil, errors.Errorf("shard %d doesn't support the iterator", s.id)
+	}
+	timeRange := NewTimeRange(start, end)
+	blocks, err := s.blocksInRange(timeRange)
+	if err != nil {
+		return nil, err
+	}
+	span := newSeriesSpan(context.Background(), timeRange, blocks, sdb, seriesID)
+	seeker, err := span.SeekerBuilder().OrderByTime(order).Build()
+	if err != nil {
+		return nil, multierr.Append(err, span.Close())
+	}
+	iters, err := seeker.Seek()
+	if err != nil {
+		return nil, multierr.Append(err, span.Close())
+	}
+	return &seriesIterator{span: span, delegated: iters}, nil
+}
+
+// blocksInRange pins the blocks overlapping timeRange out of the live segments, which are released along with the span
+func (s *shard) blocksInRange(timeRange TimeRange) ([]blockDelegate, error) {
+	result := make([]blockDelegate, 0)
+	pinned := make(pinnedBlocks)
+	for _, seg := range s.segmentController.segments() {
+		for _, b := range seg.blocks() {
+			if !b.overlaps(timeRange) {
+				continue
+			}
+			d, err := b.delegate()
+			if errors.Is(err, ErrExpiredItem) {
+				// the segment is removed after the list is taken
+				break
+			}
+			if err != nil {
+				return nil, multierr.Append(err, blockCloser(result).Close())
+			}
+			if !pinned.pin(d) {
+				_ = d.Close()
+				continue
+			}
+			result = append(result, d)
+		}
+	}
+	return result, nil
+}
+
+var _ SeriesIterator = (*seriesIterator)(nil)
+
+type seriesIterator struct {
+	span      *seriesSpan
+	delegated []Iterator
+	index     int
+	val       []byte
+	ts        time.Time
+	err       error
+}
+
+func (i *seriesIterator) Next() bool {
+	if i.err != nil {
+		return false
+	}
+	for ; i.index < len(i.delegated); i.index++ {
+		iter := i.delegated[i.index]
+		if !iter.Next() {
+			continue
+		}
+		item := iter.Val()
+		val, err := item.Val()
+		if err != nil && !errors.Is(err, kv.ErrKeyNotFound) {
+			i.err = errors.WithMessagef(err, "failed to read item %d", item.ID())
+			return false
+		}
+		i.val = val
+		i.ts = time.Unix(0, int64(item.Time()))
+		return true
+	}
+	return false
+}
+
+func (i *seriesIterator) Val() ([]byte, time.Time) {
+	return i.val, i.ts
+}
+
+func (i *seriesIterator) Close() error {
+	err := i.err
+	for _, iter := range i.delegated {
+		err = multierr.Append(err, iter.Close())
+	}
+	return multierr.Append(err, i.span.Close())
+}
@@ -0,0 +1,90 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tsdb
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	modelv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/model/v1"
+)
+
+func Test_Shard_Iterator(t *testing.T) {
+	tester := require.New(t)
+	_, deferFunc, db := setUpWithOpts(tester, func(opts *DatabaseOpts) {
+		opts.SegmentInterval = SegmentIntervalHour
+	})
+	defer deferFunc()
+	s, err := db.Shard(0)
+	tester.NoError(err)
+	series, err := s.Series().Get(Entity{Entry("productpage"), Entry("10.0.0.1")})
+	tester.NoError(err)
+	// the items spread over three hourly segments
+	base := time.Now().Add(-24 * time.Hour).Truncate(time.Hour)
+	var written []time.Time
+	for i := 0; i < 6; i++ {
+		ts := base.Add(time.Duration(i) * 30 * time.Minute)
+		span, errSpan := series.Span(NewTimeRangeDuration(ts, time.Nanosecond))
+		tester.NoError(errSpan)
+		writer, errBuild := span.WriterBuilder().Val([]byte(fmt.Sprintf("val-%d", i))).Time(ts).Build()
+		tester.NoError(errBuild)
+		_, err = writer.Write()
+		tester.NoError(err)
+		tester.NoError(span.Close())
+		written = append(written, ts)
+	}
+	tester.Len(s.SegmentsInRange(base, base.Add(3*time.Hour)), 3)
+
+	scan := func(start, end time.Time, order modelv1.Sort) (vals []string, times []time.Time) {
+		iter, errIter := s.Iterator(series.ID(), start, end, order)
+		tester.NoError(errIter)
+		for iter.Next() {
+			val, ts := iter.Val()
+			vals = append(vals, string(val))
+			times = append(times, ts)
+		}
+		tester.NoError(iter.Close())
+		return vals, times
+	}
+
+	vals, times := scan(base, base.Add(3*time.Hour), modelv1.Sort_SORT_ASC)
+	tester.Equal([]string{"val-0", "val-1", "val-2", "val-3", "val-4", "val-5"}, vals)
+	for i, ts := range times {
+		tester.True(written[i].Equal(ts))
+	}
+	vals, _ = scan(base, base.Add(3*time.Hour), modelv1.Sort_SORT_DESC)
+	tester.Equal([]string{"val-5", "val-4", "val-3", "val-2", "val-1", "val-0"}, vals)
+	vals, _ = scan(base, base.Add(3*time.Hour), modelv1.Sort_SORT_UNSPECIFIED)
+	tester.Equal([]string{"val-0", "val-1", "val-2", "val-3", "val-4", "val-5"}, vals)
+
+	// the start is inclusive and the end is exclusive
+	vals, _ = scan(written[1], written[4], modelv1.Sort_SORT_DESC)
+	tester.Equal([]string{"val-3", "val-2", "val-1"}, vals)
+
+	vals, _ = scan(base.Add(-time.Hour), base, modelv1.Sort_SORT_ASC)
+	tester.Empty(vals)
+	other, err := s.Series().Get(Entity{Entry("productpage"), Entry("10.0.0.2")})
+	tester.NoError(err)
+	iter, err := s.Iterator(other.ID(), base, base.Add(3*time.Hour), modelv1.Sort_SORT_ASC)
+	tester.NoError(err)
+	tester.False(iter.Next())
+	tester.NoError(iter.Close())
+}
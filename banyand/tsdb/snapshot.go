@@ -0,0 +1,186 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tsdb
+
+import (
+	"io"
+	"sync"
+
+	"github.com/apache/skywalking-banyandb/api/common"
+)
+
+// Snapshot pins the reads to the items written before it's taken, so the seekers of every shard
+// see the database at a single point in time. It should be closed once the reads are done.
+// An item overwritten after the snapshot is taken stays visible to it, whose latest data is read.
+type Snapshot interface {
+	io.Closer
+	// Visible reports whether the item is written before the snapshot is taken
+	Visible(id GlobalItemID) bool
+}
+
+type writeKey struct {
+	seriesID common.SeriesID
+	itemID   common.ItemID
+}
+
+type writeRecord struct {
+	key writeKey
+	seq uint64
+}
+
+// snapshotTracker holds the trackers of the shards. A snapshot pins the sequence of every shard,
+// since the items of a shard are independent of the others'.
+type snapshotTracker struct {
+	shards map[common.ShardID]*shardSnapshots
+	sync.Mutex
+}
+
+func newSnapshotTracker() *snapshotTracker {
+	return &snapshotTracker{
+		shards: make(map[common.ShardID]*shardSnapshots),
+	}
+}
+
+// shard returns the tracker of the shard, which creates it if it's absent
+func (t *snapshotTracker) shard(id common.ShardID) *shardSnapshots {
+	t.Lock()
+	defer t.Unlock()
+	s, ok := t.shards[id]
+	if !ok {
+		s = &shardSnapshots{
+			written: make(map[writeKey]uint64),
+		}
+		t.shards[id] = s
+	}
+	return s
+}
+
+func (t *snapshotTracker) snapshot() Snapshot {
+	t.Lock()
+	defer t.Unlock()
+	s := &snapshot{
+		shards: make(map[common.ShardID]*shardSnapshots, len(t.shards)),
+		seqs:   make(map[common.ShardID]uint64, len(t.shards)),
+	}
+	for id, shard := range t.shards {
+		s.shards[id] = shard
+		s.seqs[id] = shard.acquire()
+	}
+	return s
+}
+
+// shardSnapshots sequences the writes of a shard. Only the items created while a snapshot is active are recorded,
+// since the others are visible to all the snapshots. A record is dropped once all the active snapshots can see it.
+// An overwritten item stays visible to the snapshots taken after its creation.
+type shardSnapshots struct {
+	seq uint64
+	// active holds the sequences of the active snapshots in ascending order
+	active  []uint64
+	written map[writeKey]uint64
+	// records holds the written items in the order of their sequences, which drops them from written
+	records []writeRecord
+	sync.Mutex
+}
+
+// track has to be called before the item is written to make it invisible to the active snapshots.
+// exists reports whether the item is written before, which is only called if there's an active snapshot.
+func (s *shardSnapshots) track(id GlobalItemID, exists func() bool) {
+	s.Lock()
+	defer s.Unlock()
+	s.seq++
+	if len(s.active) == 0 {
+		return
+	}
+	key := writeKey{seriesID: id.SeriesID, itemID: id.ID}
+	if _, ok := s.written[key]; ok || exists() {
+		return
+	}
+	s.written[key] = s.seq
+	s.records = append(s.records, writeRecord{key: key, seq: s.seq})
+}
+
+func (s *shardSnapshots) acquire() uint64 {
+	s.Lock()
+	defer s.Unlock()
+	s.active = append(s.active, s.seq)
+	return s.seq
+}
+
+func (s *shardSnapshots) visible(id GlobalItemID, seq uint64) bool {
+	s.Lock()
+	defer s.Unlock()
+	created, ok := s.written[writeKey{seriesID: id.SeriesID, itemID: id.ID}]
+	return !ok || created <= seq
+}
+
+func (s *shardSnapshots) release(seq uint64) {
+	s.Lock()
+	defer s.Unlock()
+	for i, active := range s.active {
+		if active == seq {
+			s.active = append(s.active[:i], s.active[i+1:]...)
+			break
+		}
+	}
+	if len(s.active) == 0 {
+		s.written = make(map[writeKey]uint64)
+		s.records = nil
+		return
+	}
+	// the records up to the oldest active snapshot are visible to all the active ones
+	oldest := s.active[0]
+	n := 0
+	for ; n < len(s.records) && s.records[n].seq <= oldest; n++ {
+		delete(s.written, s.records[n].key)
+	}
+	s.records = s.records[n:]
+}
+
+var _ Snapshot = (*snapshot)(nil)
+
+type snapshot struct {
+	shards map[common.ShardID]*shardSnapshots
+	seqs   map[common.ShardID]uint64
+	once   sync.Once
+}
+
+func (s *snapshot) Visible(id GlobalItemID) bool {
+	shard, ok := s.shards[id.ShardID]
+	if !ok {
+		return true
+	}
+	return shard.visible(id, s.seqs[id.ShardID])
+}
+
+func (s *snapshot) Close() error {
+	s.once.Do(func() {
+		for id, shard := range s.shards {
+			shard.release(s.seqs[id])
+		}
+	})
+	return nil
+}
+func snapshotFilter(s Snapshot, shardID common.ShardID, seriesID common.SeriesID) filterFn {
+	return func(item Item) bool {
+		return s.Visible(GlobalItemID{
+			ShardID:  shardID,
+			SeriesID: seriesID,
+			ID:       item.ID(),
+		})
+	}
+}
@@ -0,0 +1,137 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tsdb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/apache/skywalking-banyandb/api/common"
+	modelv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/model/v1"
+)
+
+func Test_SnapshotTracker_Overlapping(t *testing.T) {
+	req := require.New(t)
+	tracker := newSnapshotTracker()
+	shard := tracker.shard(0)
+	item := func(id common.ItemID) GlobalItemID {
+		return GlobalItemID{SeriesID: 1, ID: id}
+	}
+	created := func() bool { return false }
+	overwritten := func() bool { return true }
+
+	shard.track(item(1), created)
+	s1 := tracker.snapshot()
+	shard.track(item(2), created)
+	// the item existing before the snapshot is visible after it's overwritten
+	shard.track(item(1), overwritten)
+	s2 := tracker.snapshot()
+	shard.track(item(3), created)
+	req.True(s1.Visible(item(1)))
+	req.False(s1.Visible(item(2)))
+	req.False(s1.Visible(item(3)))
+	req.True(s2.Visible(item(2)))
+	req.False(s2.Visible(item(3)))
+
+	// the snapshots overlap, and the records visible to all the active ones are dropped
+	req.NoError(s1.Close())
+	req.Len(shard.written, 1)
+	s3 := tracker.snapshot()
+	req.NoError(s2.Close())
+	req.Empty(shard.written)
+	req.Empty(shard.records)
+	shard.track(item(3), overwritten)
+	req.True(s3.Visible(item(3)))
+	req.NoError(s3.Close())
+	req.Empty(shard.active)
+}
+
+func Test_Database_Snapshot(t *testing.T) {
+	tester := assert.New(t)
+	_, deferFunc, db := setUpWithOpts(require.New(t), func(opts *DatabaseOpts) {
+		opts.ShardNum = 2
+	})
+	defer deferFunc()
+	base := time.Now()
+	timeRange := NewTimeRangeDuration(base.Add(-time.Hour), 2*time.Hour)
+	write := func(s Shard, ts time.Time) {
+		series, err := s.Series().Get(Entity{Entry("productpage"), Entry("10.0.0.1")})
+		tester.NoError(err)
+		span, err := series.Span(timeRange)
+		tester.NoError(err)
+		defer func() {
+			tester.NoError(span.Close())
+		}()
+		writer, err := span.WriterBuilder().
+			Family([]byte("searchable"), []byte(ts.String())).
+			Time(ts).
+			Build()
+		tester.NoError(err)
+		_, err = writer.Write()
+		tester.NoError(err)
+	}
+	read := func(snapshot Snapshot) (got []uint64) {
+		for _, s := range db.Shards() {
+			series, err := s.Series().Get(Entity{Entry("productpage"), Entry("10.0.0.1")})
+			tester.NoError(err)
+			span, err := series.Span(timeRange)
+			tester.NoError(err)
+			builder := span.SeekerBuilder().OrderByTime(modelv1.Sort_SORT_ASC)
+			if snapshot != nil {
+				builder.Snapshot(snapshot)
+			}
+			seeker, err := builder.Build()
+			tester.NoError(err)
+			iters, err := seeker.Seek()
+			tester.NoError(err)
+			for _, iter := range iters {
+				for iter.Next() {
+					got = append(got, iter.Val().Time())
+				}
+				tester.NoError(iter.Close())
+			}
+			tester.NoError(span.Close())
+		}
+		return got
+	}
+	for i, s := range db.Shards() {
+		write(s, base.Add(time.Duration(i)*time.Millisecond))
+	}
+	snapshot := db.Snapshot()
+	// the writes arrive in the middle of the query, including a late one and an overwrite of an existing item
+	for i, s := range db.Shards() {
+		write(s, base.Add(time.Duration(i)*time.Millisecond))
+		write(s, base.Add(time.Duration(i+10)*time.Millisecond))
+		write(s, base.Add(-time.Duration(i+10)*time.Millisecond))
+	}
+	tester.Equal([]uint64{
+		uint64(base.UnixNano()),
+		uint64(base.Add(time.Millisecond).UnixNano()),
+	}, read(snapshot))
+	tester.Len(read(nil), 6)
+	tester.NoError(snapshot.Close())
+
+	snapshot = db.Snapshot()
+	defer func() {
+		tester.NoError(snapshot.Close())
+	}()
+	tester.Len(read(snapshot), 6)
+}
@@ -0,0 +1,130 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tsdb
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/apache/skywalking-banyandb/api/common"
+)
+
+// ShardStats is the footprint of a shard on the disk
+type ShardStats struct {
+	ID common.ShardID
+	// Bytes is the size of all the files under the shard's directory, including the series and the global index
+	Bytes    int64
+	Segments int
+	Blocks   int
+	// Oldest is the start of the earliest segment, and Newest is the start of the latest segment or block.
+	// Both are zero if the shard has no segment.
+	Oldest time.Time
+	Newest time.Time
+}
+
+// DatabaseStats reports the footprint of each shard, ordered by the shard ids
+type DatabaseStats struct {
+	Shards []ShardStats
+}
+
+// Bytes is the size of all the shards
+func (s DatabaseStats) Bytes() int64 {
+	var total int64
+	for _, sh := range s.Shards {
+		total += sh.Bytes
+	}
+	return total
+}
+
+func (d *database) Stats() (DatabaseStats, error) {
+	d.Lock()
+	shards := d.sLst
+	d.Unlock()
+	var result DatabaseStats
+	for _, s := range shards {
+		sd, ok := s.(*shard)
+		if !ok {
+			continue
+		}
+		stats, err := walkShard(sd.id, sd.location)
+		if err != nil {
+			return result, err
+		}
+		result.Shards = append(result.Shards, stats)
+	}
+	return result, nil
+}
+
+// walkShard measures the shard at location from its directory tree, so the segments and the blocks
+// are counted whether they're open or not
+func walkShard(id common.ShardID, location string) (ShardStats, error) {
+	stats := ShardStats{ID: id}
+	err := filepath.Walk(location, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			// the files might be removed by a compaction or the retention during the walk
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if !info.IsDir() {
+			stats.Bytes += info.Size()
+			return nil
+		}
+		parent := filepath.Dir(path)
+		switch {
+		case parent == location && strings.HasPrefix(info.Name(), "seg-"):
+			start, errParse := parseSegmentStart(strings.TrimPrefix(info.Name(), "seg-"))
+			if errParse != nil {
+				return errors.Wrapf(errParse, "failed to parse the segment %s", info.Name())
+			}
+			stats.Segments++
+			stats.observe(start)
+		case filepath.Dir(parent) == location && strings.HasPrefix(filepath.Base(parent), "seg-") &&
+			strings.HasPrefix(info.Name(), "block-") && !isTempDir(info.Name()):
+			segStart, errParse := parseSegmentStart(strings.TrimPrefix(filepath.Base(parent), "seg-"))
+			if errParse != nil {
+				return errors.Wrapf(errParse, "failed to parse the segment %s", filepath.Base(parent))
+			}
+			blockStart, errParse := parseBlockStart(segStart, strings.TrimPrefix(info.Name(), "block-"))
+			if errParse != nil {
+				return errors.Wrapf(errParse, "failed to parse the block %s", info.Name())
+			}
+			stats.Blocks++
+			stats.observe(blockStart)
+		}
+		return nil
+	})
+	if err != nil {
+		return stats, errors.Wrapf(err, "failed to walk shard %d", id)
+	}
+	return stats, nil
+}
+
+func (s *ShardStats) observe(t time.Time) {
+	if s.Oldest.IsZero() || t.Before(s.Oldest) {
+		s.Oldest = t
+	}
+	if t.After(s.Newest) {
+		s.Newest = t
+	}
+}
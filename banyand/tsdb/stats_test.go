@@ -0,0 +1,60 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tsdb
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/apache/skywalking-banyandb/api/common"
+)
+
+func Test_Database_Stats(t *testing.T) {
+	tester := require.New(t)
+	tempDir, deferFunc, db := setUpWithOpts(tester, func(opts *DatabaseOpts) {
+		opts.ShardNum = 2
+	})
+	defer deferFunc()
+	// an old segment left on the disk is counted even though it isn't open
+	blockPath := filepath.Join(tempDir, "shard-1", "seg-20200101", "block-0300")
+	tester.NoError(os.MkdirAll(blockPath, dirPerm))
+	tester.NoError(ioutil.WriteFile(filepath.Join(blockPath, "data"), make([]byte, 100), 0600))
+
+	stats, err := db.Stats()
+	tester.NoError(err)
+	tester.Len(stats.Shards, 2)
+	var total int64
+	for i, s := range stats.Shards {
+		tester.Equal(common.ShardID(i), s.ID)
+		tester.Greater(s.Bytes, int64(0))
+		tester.False(s.Newest.Before(s.Oldest))
+		total += s.Bytes
+	}
+	tester.Equal(total, stats.Bytes())
+	live, old := stats.Shards[0], stats.Shards[1]
+	tester.Equal(live.Segments+1, old.Segments)
+	tester.Equal(live.Blocks+1, old.Blocks)
+	tester.GreaterOrEqual(old.Bytes, int64(100))
+	tester.Equal(time.Date(2020, 1, 1, 0, 0, 0, 0, time.Local), old.Oldest)
+	tester.Equal(live.Newest, old.Newest)
+}
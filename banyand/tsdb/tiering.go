@@ -0,0 +1,96 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tsdb
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+
+	"github.com/apache/skywalking-banyandb/pkg/logger"
+)
+
+var ErrBlockStoreAbsent = errors.New("the block store is absent")
+
+// DefaultTieringCheckInterval is how often the cold segments are offloaded if DatabaseOpts.TieringCheckInterval is absent
+const DefaultTieringCheckInterval = 10 * time.Minute
+
+// tiering offloads the segments whose data are all older than the age to the BlockStore.
+// Their global indexes stay local, and the blocks are fetched back once a query reaches them.
+type tiering struct {
+	l       *logger.Logger
+	age     time.Duration
+	clock   clockwork.Clock
+	offload func(deadline time.Time) (int, error)
+	stopCh  chan struct{}
+	wg      sync.WaitGroup
+}
+
+func newTiering(l *logger.Logger, age time.Duration, clock clockwork.Clock,
+	offload func(deadline time.Time) (int, error)) *tiering {
+	return &tiering{
+		l:       l,
+		age:     age,
+		clock:   clock,
+		offload: offload,
+		stopCh:  make(chan struct{}),
+	}
+}
+
+// run offloads the cold segments and returns the number of the offloaded blocks
+func (t *tiering) run() (int, error) {
+	return t.offload(t.clock.Now().Add(-t.age))
+}
+
+// start runs the tiering periodically until stop is called
+func (t *tiering) start(interval time.Duration) {
+	t.wg.Add(1)
+	go func() {
+		defer t.wg.Done()
+		ticker := t.clock.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.Chan():
+				if _, err := t.run(); err != nil {
+					t.l.Warn().Err(err).Msg("failed to offload the cold segments")
+				}
+			case <-t.stopCh:
+				return
+			}
+		}
+	}()
+}
+
+func (t *tiering) stop() {
+	close(t.stopCh)
+	t.wg.Wait()
+}
+
+func (d *database) offloadSegments(deadline time.Time) (count int, err error) {
+	for _, s := range d.sLst {
+		n, errShard := s.(*shard).segmentController.offload(context.Background(), deadline)
+		count += n
+		err = multierr.Append(err, errShard)
+	}
+	return count, err
+}
@@ -0,0 +1,217 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tsdb
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+
+	"github.com/apache/skywalking-banyandb/api/common"
+	databasev1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/database/v1"
+	modelv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/model/v1"
+	"github.com/apache/skywalking-banyandb/banyand/kv"
+	"github.com/apache/skywalking-banyandb/pkg/convert"
+)
+
+var (
+	ErrReadOnly = errors.New("the database is read-only")
+
+	tombstoneMark = []byte{1}
+	// purgedMark replaces tombstoneMark once the data of the item is dropped.
+	// The tombstone stays to hide the item from the indices of its block.
+	purgedMark = []byte{2}
+)
+
+// DeleteCriteria selects items to be deleted.
+// Entity supports AnyEntry as a wildcard, the same as the one passed to SeriesDatabase.List.
+// IndexRule and Condition are optional; all the items of the matched series in TimeRange
+// are deleted if they are absent.
+type DeleteCriteria struct {
+	Entity    Entity
+	TimeRange TimeRange
+	IndexRule *databasev1.IndexRule
+	Condition Condition
+}
+
+func tombstoneKey(seriesID common.SeriesID, itemID common.ItemID) []byte {
+	return bytes.Join([][]byte{
+		seriesID.Marshal(),
+		convert.Uint64ToBytes(uint64(itemID)),
+	}, nil)
+}
+
+// tombstoneFilter excludes items marked as deleted
+func tombstoneFilter(store kv.Store, seriesID common.SeriesID) filterFn {
+	return func(item Item) bool {
+		_, err := store.Get(tombstoneKey(seriesID, item.ID()))
+		return errors.Is(err, kv.ErrKeyNotFound)
+	}
+}
+
+// DeleteByQuery marks items matching the criteria as deleted.
+// Tombstones hide items from seekers immediately. The underlying data is dropped
+// once the block holding them is sealed and compacted, and the tombstones are removed with the segment.
+func (d *database) DeleteByQuery(_ context.Context, criteria DeleteCriteria) (int, error) {
+	if d.readOnly {
+		return 0, errors.WithStack(ErrReadOnly)
+	}
+	var count int
+	var err error
+	for _, s := range d.sLst {
+		n, errDelete := s.(*shard).deleteByQuery(criteria)
+		count += n
+		err = multierr.Append(err, errDelete)
+	}
+	return count, err
+}
+
+func (s *shard) deleteByQuery(criteria DeleteCriteria) (int, error) {
+	sdb := s.seriesDatabase.(*seriesDB)
+	seriesList, err := sdb.List(NewPath(criteria.Entity))
+	if err != nil {
+		return 0, err
+	}
+	var count int
+	for _, series := range seriesList {
+		n, errSeries := sdb.deleteSeriesItems(series, criteria)
+		count += n
+		if errSeries != nil {
+			return count, errSeries
+		}
+	}
+	return count, nil
+}
+
+func (s *seriesDB) deleteSeriesItems(series Series, criteria DeleteCriteria) (int, error) {
+	span, err := series.Span(criteria.TimeRange)
+	if errors.Is(err, ErrEmptySeriesSpan) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		_ = span.Close()
+	}()
+	builder := span.SeekerBuilder()
+	if criteria.IndexRule != nil {
+		builder.Filter(criteria.IndexRule, criteria.Condition)
+	}
+	seeker, err := builder.OrderByTime(modelv1.Sort_SORT_ASC).Build()
+	if err != nil {
+		return 0, err
+	}
+	iters, err := seeker.Seek()
+	if err != nil {
+		return 0, err
+	}
+	var count int
+	for _, iter := range iters {
+		for iter.Next() {
+			if err = s.tombstones.Put(tombstoneKey(series.ID(), iter.Val().ID()), tombstoneMark); err != nil {
+				break
+			}
+			count++
+		}
+		err = multierr.Append(err, iter.Close())
+		if err != nil {
+			return count, err
+		}
+	}
+	s.l.Debug().Uint64("series_id", uint64(series.ID())).Int("count", count).Msg("delete items")
+	return count, nil
+}
+
+// purgeTombstones drops the data of the tombstoned items from the blocks past their grace period,
+// which accept no write, and returns the number of the dropped values.
+func (s *seriesDB) purgeTombstones(now time.Time) (int, error) {
+	pending := make(map[*block]map[string]struct{})
+	err := s.tombstones.Scan(nil, kv.DefaultScanOpts, func(_ int, key []byte, getVal func() ([]byte, error)) error {
+		val, errVal := getVal()
+		if errVal != nil {
+			return errVal
+		}
+		if !bytes.Equal(val, tombstoneMark) {
+			return nil
+		}
+		ts := tombstoneTime(key)
+		seg := s.segCtrl.find(ts)
+		if seg == nil {
+			return nil
+		}
+		for _, b := range seg.blocks() {
+			if !b.contains(ts) || !b.offloadable(now) {
+				continue
+			}
+			if pending[b] == nil {
+				pending[b] = make(map[string]struct{})
+			}
+			pending[b][string(key)] = struct{}{}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	var count int
+	for b, keys := range pending {
+		n, errPurge := b.purge(func(key []byte, ts uint64) bool {
+			if len(key) < 8 {
+				return false
+			}
+			_, ok := keys[string(tombstoneKey(bytesConvSeriesID(key[:8]), common.ItemID(ts)))]
+			return ok
+		})
+		count += n
+		if errPurge != nil {
+			err = multierr.Append(err, errPurge)
+			continue
+		}
+		for key := range keys {
+			err = multierr.Append(err, s.tombstones.Put([]byte(key), purgedMark))
+		}
+	}
+	if count > 0 {
+		s.l.Info().Int("count", count).Msg("purged the data of the deleted items")
+	}
+	return count, err
+}
+
+// dropTombstones removes the tombstones of the items in the removed segment
+func (s *seriesDB) dropTombstones(seg *segment) error {
+	timeRange := seg.TimeRange()
+	var keys [][]byte
+	err := s.tombstones.Scan(nil, kv.DefaultScanOpts, func(_ int, key []byte, _ func() ([]byte, error)) error {
+		if ts := tombstoneTime(key); !ts.Before(timeRange.Start) && (timeRange.End.IsZero() || ts.Before(timeRange.End)) {
+			keys = append(keys, append([]byte(nil), key...))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return errors.WithMessagef(s.tombstones.Delete(keys...), "failed to drop the tombstones of %s", seg.path)
+}
+
+func tombstoneTime(key []byte) time.Time {
+	return time.Unix(0, int64(convert.BytesToUint64(key[len(key)-8:])))
+}
@@ -0,0 +1,198 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tsdb
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	commonv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/common/v1"
+	databasev1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/database/v1"
+	modelv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/model/v1"
+	"github.com/apache/skywalking-banyandb/banyand/kv"
+	"github.com/apache/skywalking-banyandb/pkg/index"
+)
+
+func Test_Database_DeleteByQuery(t *testing.T) {
+	tester := assert.New(t)
+	rule := &databasev1.IndexRule{
+		Metadata: &commonv1.Metadata{
+			Name:  "status",
+			Group: "default",
+			Id:    1,
+		},
+		Tags:     []string{"status"},
+		Type:     databasev1.IndexRule_TYPE_INVERTED,
+		Location: databasev1.IndexRule_LOCATION_SERIES,
+	}
+	_, deferFunc, db := setUpWithOpts(require.New(t), func(opts *DatabaseOpts) {
+		opts.IndexRules = []*databasev1.IndexRule{rule}
+	})
+	defer deferFunc()
+	shard, err := db.Shard(0)
+	tester.NoError(err)
+	now := time.Now()
+	timeRange := NewTimeRangeDuration(now.Add(-time.Hour), 2*time.Hour)
+	for i, status := range []string{"ok", "error", "ok", "error", "error"} {
+		series, errSeries := shard.Series().Get(Entity{Entry("productpage"), Entry(fmt.Sprintf("10.0.0.%d", i))})
+		tester.NoError(errSeries)
+		span, errSpan := series.Span(timeRange)
+		tester.NoError(errSpan)
+		writer, errWriter := span.WriterBuilder().
+			Family([]byte("searchable"), []byte(status)).
+			Time(now.Add(time.Duration(i) * time.Millisecond)).
+			Build()
+		tester.NoError(errWriter)
+		_, errWriter = writer.Write()
+		tester.NoError(errWriter)
+		tester.NoError(writer.WriteInvertedIndex(index.Field{
+			Key:  index.FieldKey{IndexRuleID: rule.GetMetadata().GetId()},
+			Term: []byte(status),
+		}))
+		tester.NoError(span.Close())
+	}
+	criteria := DeleteCriteria{
+		Entity:    Entity{Entry("productpage"), AnyEntry},
+		TimeRange: timeRange,
+		IndexRule: rule,
+		Condition: Condition{
+			"status": []index.ConditionValue{
+				{
+					Op:     modelv1.Condition_BINARY_OP_EQ,
+					Values: [][]byte{[]byte("error")},
+				},
+			},
+		},
+	}
+	count, err := db.DeleteByQuery(context.TODO(), criteria)
+	tester.NoError(err)
+	tester.Equal(3, count)
+	count, err = db.DeleteByQuery(context.TODO(), criteria)
+	tester.NoError(err)
+	tester.Equal(0, count)
+
+	seriesList, err := shard.Series().List(NewPath(Entity{Entry("productpage"), AnyEntry}))
+	tester.NoError(err)
+	var got []string
+	for _, series := range seriesList {
+		span, errSpan := series.Span(timeRange)
+		tester.NoError(errSpan)
+		seeker, errSeeker := span.SeekerBuilder().OrderByTime(modelv1.Sort_SORT_ASC).Build()
+		tester.NoError(errSeeker)
+		iters, errSeeker := seeker.Seek()
+		tester.NoError(errSeeker)
+		for _, iter := range iters {
+			for iter.Next() {
+				v, errFamily := iter.Val().Family("searchable")
+				tester.NoError(errFamily)
+				got = append(got, string(v))
+			}
+			tester.NoError(iter.Close())
+		}
+		tester.NoError(span.Close())
+	}
+	tester.Equal([]string{"ok", "ok"}, got)
+}
+
+func Test_Database_DeleteByQuery_ReadOnly(t *testing.T) {
+	_, deferFunc, db := setUpWithOpts(require.New(t), func(opts *DatabaseOpts) {
+		opts.ReadOnly = true
+	})
+	defer deferFunc()
+	_, err := db.DeleteByQuery(context.TODO(), DeleteCriteria{Entity: Entity{AnyEntry}})
+	assert.ErrorIs(t, err, ErrReadOnly)
+}
+
+func Test_Database_PurgeDeleted(t *testing.T) {
+	req := require.New(t)
+	_, deferFunc, db := setUp(req)
+	defer deferFunc()
+	s, err := db.Shard(0)
+	req.NoError(err)
+	series, err := s.Series().Get(Entity{Entry("productpage"), Entry("10.0.0.1")})
+	req.NoError(err)
+	now := time.Now()
+	span, err := series.Span(NewTimeRangeDuration(now, time.Hour))
+	req.NoError(err)
+	var ids []GlobalItemID
+	for i := 0; i < 3; i++ {
+		writer, errWriter := span.WriterBuilder().
+			Family([]byte("searchable"), []byte(fmt.Sprintf("value-%d", i))).
+			Time(now.Add(time.Duration(i) * time.Millisecond)).
+			Build()
+		req.NoError(errWriter)
+		id, errWrite := writer.Write()
+		req.NoError(errWrite)
+		ids = append(ids, id)
+	}
+	req.NoError(span.Close())
+	sc := s.(*shard).segmentController
+	_, err = sc.seal(now.Add(time.Minute))
+	req.NoError(err)
+
+	count, err := db.DeleteByQuery(context.TODO(), DeleteCriteria{
+		Entity:    Entity{Entry("productpage"), AnyEntry},
+		TimeRange: NewTimeRange(now.Add(time.Millisecond/2), now.Add(3*time.Millisecond/2)),
+	})
+	req.NoError(err)
+	req.Equal(1, count)
+	key := dataBucket{seriesID: series.ID(), family: []byte("searchable")}.marshal()
+	read := func() (got []string) {
+		b, errBlock := s.(*shard).seriesDatabase.(*seriesDB).block(ids[0])
+		req.NoError(errBlock)
+		defer func() {
+			req.NoError(b.Close())
+		}()
+		for _, id := range ids {
+			v, errGet := b.dataReader().Get(key, uint64(id.ID))
+			if errGet == nil && v != nil {
+				got = append(got, string(v))
+			}
+		}
+		return got
+	}
+	// the tombstone hides the item, whose data is kept until the compaction
+	req.Equal([]string{"value-0", "value-1", "value-2"}, read())
+	req.NoError(db.Compact())
+	req.Equal([]string{"value-0", "value-2"}, read())
+	tombstones := s.(*shard).seriesDatabase.(*seriesDB).tombstones
+	tombstone := tombstoneKey(series.ID(), ids[1].ID)
+	mark, err := tombstones.Get(tombstone)
+	req.NoError(err)
+	req.Equal(purgedMark, mark)
+	for _, i := range []int{0, 2} {
+		item, closer, errGet := series.Get(ids[i])
+		req.NoError(errGet)
+		v, errFamily := item.Family("searchable")
+		req.NoError(errFamily)
+		req.Equal(fmt.Sprintf("value-%d", i), string(v))
+		req.NoError(closer.Close())
+	}
+
+	// the retention removes the tombstones along with the segment
+	n, err := sc.retain(now.Add(time.Hour), 0)
+	req.NoError(err)
+	req.Equal(1, n)
+	_, err = tombstones.Get(tombstone)
+	req.ErrorIs(err, kv.ErrKeyNotFound)
+}
@@ -24,13 +24,17 @@ import (
 	"io/fs"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"sync"
+	"time"
 
+	"github.com/jonboulle/clockwork"
 	"github.com/pkg/errors"
 	"go.uber.org/multierr"
 
 	"github.com/apache/skywalking-banyandb/api/common"
 	databasev1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/database/v1"
+	modelv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/model/v1"
 	"github.com/apache/skywalking-banyandb/pkg/encoding"
 	"github.com/apache/skywalking-banyandb/pkg/logger"
 )
@@ -42,8 +46,15 @@ const (
 	blockTemplate       = "%s/block-%s"
 	globalIndexTemplate = "%s/index"
 
-	segFormat   = "20060102"
-	blockFormat = "1504"
+	segFormat = "20060102"
+	// partitionSegFormat names the segments of the partitions, which might be shorter than a day
+	partitionSegFormat = "200601021504"
+	// segSeqSeparator separates the name of a segment from the sequence making it unique
+	segSeqSeparator = "-"
+	blockFormat     = "1504"
+	// intervalBlockFormat names the blocks split by DatabaseOpts.BlockInterval and the merged ones,
+	// which might start in another day of the segment
+	intervalBlockFormat = "200601021504"
 
 	dirPerm = 0700
 )
@@ -51,18 +62,77 @@ const (
 var (
 	ErrInvalidShardID       = errors.New("invalid shard id")
 	ErrEncodingMethodAbsent = errors.New("encoding method is absent")
+	ErrInvalidChunkSize     = errors.Errorf("the chunk size should be a power of two between %d and %d", MinChunkSize, MaxChunkSize)
+	// ErrShardNumMismatch means the shards found on the disk don't match DatabaseOpts.ShardNum.
+	// Changing the number of the shards needs the data to be migrated, since the series are routed by it.
+	ErrShardNumMismatch = errors.New("the shards on the disk mismatch the number of shards")
 
 	indexRulesKey     = contextIndexRulesKey{}
 	encodingMethodKey = contextEncodingMethodKey{}
+	preallocateKey    = contextPreallocateKey{}
+	segmentGraceKey   = contextSegmentGraceKey{}
+	precisionKey      = contextPrecisionKey{}
+	storageEngineKey  = contextStorageEngineKey{}
+	flushHookKey      = contextFlushHookKey{}
+	snapshotsKey      = contextSnapshotsKey{}
+	partitionerKey    = contextPartitionerKey{}
+	writeLockTimeout  = contextWriteLockTimeoutKey{}
+	writeLockKey      = contextWriteLockKey{}
+	clockKey          = contextClockKey{}
+	sequenceKey       = contextSequenceKey{}
+	blockStoreKey     = contextBlockStoreKey{}
+	durabilityKey     = contextDurabilityKey{}
+	blockIntervalKey  = contextBlockIntervalKey{}
 )
 
 type contextIndexRulesKey struct{}
 type contextEncodingMethodKey struct{}
+type contextPreallocateKey struct{}
+type contextSegmentGraceKey struct{}
+type contextPrecisionKey struct{}
+type contextStorageEngineKey struct{}
+type contextFlushHookKey struct{}
+type contextSnapshotsKey struct{}
+type contextPartitionerKey struct{}
+type contextWriteLockTimeoutKey struct{}
+type contextWriteLockKey struct{}
+type contextClockKey struct{}
+type contextSequenceKey struct{}
+type contextBlockStoreKey struct{}
+type contextDurabilityKey struct{}
+type contextBlockIntervalKey struct{}
 
 type Database interface {
 	io.Closer
+	// Shards returns all the shards ordered by their ids
 	Shards() []Shard
+	// Shard returns ErrInvalidShardID if the id is out of range
 	Shard(id common.ShardID) (Shard, error)
+	DeleteByQuery(ctx context.Context, criteria DeleteCriteria) (int, error)
+	// FlushNotify emits an event each time a block flushes its data to the disk.
+	// The channel is bounded and closed when the database is closed.
+	FlushNotify() <-chan FlushEvent
+	// VerifyReport returns the result of verifying the blocks when opening the database.
+	// It's nil if DatabaseOpts.VerifyOnOpen is false.
+	VerifyReport() *VerifyReport
+	// Snapshot captures the current point in time for reading all the shards consistently
+	Snapshot() Snapshot
+	// Flush syncs the data written into all the blocks to the disk
+	Flush() error
+	// Compact merges the levels of the data files in all the blocks, and drops the deleted items from the sealed ones.
+	// It merges the adjacent small blocks of the segments as well, see DatabaseOpts.BlockMergeSize.
+	Compact() error
+	// Retain removes the segments expired by DatabaseOpts.TTL or beyond DatabaseOpts.MaxSegments at once
+	// instead of waiting for the next check.
+	// It returns the number of the removed segments.
+	Retain() (int, error)
+	// Offload moves the sealed blocks past their grace period to DatabaseOpts.BlockStore, which are fetched back on demand.
+	// It returns the number of the offloaded blocks.
+	Offload() (int, error)
+	// ShardSizes reports the number of series and bytes in each shard, which makes the skew of the entities visible
+	ShardSizes() (ShardDistribution, error)
+	// Stats walks the directory of each shard to report its size, its segments and blocks, and the time they span
+	Stats() (DatabaseStats, error)
 }
 
 type Shard interface {
@@ -70,6 +140,12 @@ type Shard interface {
 	ID() common.ShardID
 	Series() SeriesDatabase
 	Index() IndexDatabase
+	// SegmentsInRange returns the live segments whose windows overlap [start, end) ordered by their start time,
+	// which prunes the segments before reading any of their blocks
+	SegmentsInRange(start, end time.Time) []Segment
+	// Iterator scans the values of the series in [start, end) across the segments and the blocks
+	// in the order of their time. An unspecified order is ascending.
+	Iterator(seriesID common.SeriesID, start, end time.Time, order modelv1.Sort) (SeriesIterator, error)
 }
 
 var _ Database = (*database)(nil)
@@ -79,17 +155,132 @@ type DatabaseOpts struct {
 	ShardNum       uint32
 	IndexRules     []*databasev1.IndexRule
 	EncodingMethod EncodingMethod
+	// ChunkSize bounds the values of a block encoded by the pools of EncodingMethod.Codec in bytes,
+	// which trades the reads of a single data point for the compression ratio. Zero falls back to DefaultChunkSize.
+	ChunkSize int
+	// ReadOnly rejects the operations that mutate existing data, e.g. DeleteByQuery
+	ReadOnly bool
+	// PreallocateBytes reserves disk space for a new block's files to reduce fragmentation.
+	// Zero disables the pre-allocation.
+	PreallocateBytes int64
+	// SegmentGracePeriod keeps a sealed segment open for late data for a while
+	SegmentGracePeriod time.Duration
+	// OrphanCleanInterval is the interval to remove orphan temporary directories in the background.
+	// They are always removed when opening the database, and zero disables the periodic cleaning.
+	OrphanCleanInterval time.Duration
+	// TimestampPrecision aligns the start time of segments and blocks to its multiples.
+	// Data points are always stored and compared at nanosecond precision, and zero disables the alignment.
+	TimestampPrecision time.Duration
+	// StorageEngine decides whether a write replaces the item of a series at the same timestamp or is appended.
+	// The unspecified one overwrites the item.
+	StorageEngine databasev1.StorageEngine
+	// VerifyOnOpen verifies the checksums of all the blocks before opening the database.
+	// The corrupt ones are reported by Database.VerifyReport, and they're moved aside as "corrupt-block-*" directories,
+	// so their segments reopen them empty.
+	VerifyOnOpen bool
+	// FailOnCorruptBlocks fails the opening if VerifyOnOpen finds a corrupt block
+	FailOnCorruptBlocks bool
+	// Partitioner aligns the segments to its partitions, which are created once the data arrives.
+	// A nil one keeps a single open segment.
+	Partitioner Partitioner
+	// SegmentInterval rotates the segments at the hours, days or weeks, which conflicts with Partitioner.
+	// It applies to the new segments only, so the existing ones keep their windows and stay readable.
+	SegmentInterval SegmentInterval
+	// WriteLockTimeout bounds how long a write waits for the flush of its shard if the write's context has no deadline.
+	// The write fails with the retriable ErrWriteLockTimeout then, and a non-positive timeout waits forever.
+	WriteLockTimeout time.Duration
+	// TTL removes a sealed segment once all of its data is older than TTL. Zero keeps the data forever.
+	TTL time.Duration
+	// MaxSegments removes the oldest sealed segments of a shard beyond the number. Zero keeps all the segments.
+	MaxSegments uint32
+	// RetentionCheckInterval is how often the segments are checked against TTL and MaxSegments.
+	// A non-positive one falls back to DefaultRetentionCheckInterval.
+	RetentionCheckInterval time.Duration
+	// BlockStore keeps the files of the offloaded blocks, while the active blocks always stay under Location.
+	// A nil one keeps all the blocks local.
+	BlockStore BlockStore
+	// TieringAge offloads a segment to BlockStore once all of its data is older than TieringAge. Zero disables the tiering.
+	TieringAge time.Duration
+	// TieringCheckInterval is how often the segments are checked against TieringAge.
+	// A non-positive one falls back to DefaultTieringCheckInterval.
+	TieringCheckInterval time.Duration
+	// TieringCacheDuration keeps a block fetched from BlockStore local for a while, which saves the fetches of
+	// the repeated queries. Zero offloads it again at the next check.
+	TieringCacheDuration time.Duration
+	// BlockCacheSize bounds the local disk cache of the files fetched from BlockStore in bytes. Zero disables the cache.
+	BlockCacheSize int64
+	// BlockCacheLocation is where the cached files are kept, which falls back to a sibling directory of Location.
+	BlockCacheLocation string
+	// CompactionInterval is how often the levels of the data files in all the local blocks are merged in the background.
+	// Zero disables the compaction, which is still available through Database.Compact.
+	CompactionInterval time.Duration
+	// Durability decides when the written data are synced to the disk, see Durability for the tradeoff.
+	// The zero value syncs them on close.
+	Durability Durability
+	// BlockInterval splits a segment into the blocks of the interval, which are created once the data arrives.
+	// Zero keeps a single block per segment.
+	BlockInterval time.Duration
+	// BlockMergeSize merges the adjacent blocks of a segment smaller than it in bytes when compacting,
+	// once their windows are over. The merged blocks are bounded by it as well, and zero disables the merge.
+	BlockMergeSize int64
 }
 
+// The bounds of DatabaseOpts.ChunkSize
+const (
+	DefaultChunkSize = 1 << 20
+	MinChunkSize     = 1 << 12
+	MaxChunkSize     = 1 << 26
+)
+
+// EncodingMethod encodes the data points of a series in a block by either the explicit pools or the ones of Codec
 type EncodingMethod struct {
 	EncoderPool encoding.SeriesEncoderPool
 	DecoderPool encoding.SeriesDecoderPool
+	// Codec constructs the absent pools, whose blocks are full once their values reach DatabaseOpts.ChunkSize
+	Codec encoding.Codec
+}
+
+// ValidateChunkSize checks the chunk size is a power of two between MinChunkSize and MaxChunkSize
+func ValidateChunkSize(size int) error {
+	if size < MinChunkSize || size > MaxChunkSize || size&(size-1) != 0 {
+		return errors.WithMessagef(ErrInvalidChunkSize, "invalid chunk size %d", size)
+	}
+	return nil
+}
+
+func (m EncodingMethod) withPools(chunkSize int) (EncodingMethod, error) {
+	if m.EncoderPool != nil && m.DecoderPool != nil {
+		return m, nil
+	}
+	if m.Codec == 0 {
+		return m, ErrEncodingMethodAbsent
+	}
+	if chunkSize == 0 {
+		chunkSize = DefaultChunkSize
+	}
+	if err := ValidateChunkSize(chunkSize); err != nil {
+		return m, err
+	}
+	var err error
+	m.EncoderPool, m.DecoderPool, err = encoding.NewPools(m.Codec, chunkSize)
+	return m, err
 }
 
 type database struct {
-	logger   *logger.Logger
-	location string
-	shardNum uint32
+	logger    *logger.Logger
+	location  string
+	shardNum  uint32
+	readOnly  bool
+	cleaner   *orphanCleaner
+	retention *retention
+	tiering   *tiering
+	compactor *compaction
+	flusher   *flusher
+	notifier  *flushNotifier
+	snapshots *snapshotTracker
+	report    *VerifyReport
+	// blockMergeSize is DatabaseOpts.BlockMergeSize
+	blockMergeSize int64
 
 	sLst []Shard
 	sync.Mutex
@@ -106,17 +297,76 @@ func (d *database) Shard(id common.ShardID) (Shard, error) {
 	return d.sLst[id], nil
 }
 
+func (d *database) FlushNotify() <-chan FlushEvent {
+	return d.notifier.ch
+}
+
+func (d *database) VerifyReport() *VerifyReport {
+	return d.report
+}
+
+func (d *database) Snapshot() Snapshot {
+	return d.snapshots.snapshot()
+}
+
+func (d *database) Flush() (err error) {
+	for _, s := range d.sLst {
+		err = multierr.Append(err, s.(*shard).forEachBlock(blockDelegate.sync))
+	}
+	return err
+}
+
+func (d *database) Compact() (err error) {
+	now := time.Now()
+	for _, s := range d.sLst {
+		// the rewritten blocks are compacted as well
+		_, errPurge := s.(*shard).seriesDatabase.(*seriesDB).purgeTombstones(now)
+		err = multierr.Append(err, errPurge)
+		if d.blockMergeSize > 0 {
+			_, errMerge := s.(*shard).mergeBlocks(d.blockMergeSize, now)
+			err = multierr.Append(err, errMerge)
+		}
+		err = multierr.Append(err, s.(*shard).forEachBlock(blockDelegate.compact))
+	}
+	return err
+}
+
+func (d *database) Offload() (int, error) {
+	return d.offloadSegments(time.Time{})
+}
+
 func (d *database) Close() error {
+	if d.cleaner != nil {
+		d.cleaner.stop()
+	}
+	if d.retention != nil {
+		d.retention.stop()
+	}
+	if d.tiering != nil {
+		d.tiering.stop()
+	}
+	if d.compactor != nil {
+		d.compactor.stop()
+	}
+	if d.flusher != nil {
+		d.flusher.stop()
+	}
+	distribution.remove(d)
 	for _, s := range d.sLst {
 		_ = s.Close()
 	}
+	d.notifier.close()
 	return nil
 }
 
 func OpenDatabase(ctx context.Context, opts DatabaseOpts) (Database, error) {
 	db := &database{
-		location: opts.Location,
-		shardNum: opts.ShardNum,
+		location:       opts.Location,
+		shardNum:       opts.ShardNum,
+		readOnly:       opts.ReadOnly,
+		notifier:       newFlushNotifier(FlushEventBufferSize),
+		snapshots:      newSnapshotTracker(),
+		blockMergeSize: opts.BlockMergeSize,
 	}
 	parentLogger := ctx.Value(logger.ContextKey)
 	if parentLogger != nil {
@@ -124,25 +374,148 @@ func OpenDatabase(ctx context.Context, opts DatabaseOpts) (Database, error) {
 			db.logger = pl.Named("tsdb")
 		}
 	}
-	if opts.EncodingMethod.EncoderPool == nil || opts.EncodingMethod.DecoderPool == nil {
-		return nil, errors.Wrap(ErrEncodingMethodAbsent, "failed to open database")
+	var err error
+	if opts.EncodingMethod, err = opts.EncodingMethod.withPools(opts.ChunkSize); err != nil {
+		return nil, errors.Wrap(err, "failed to open database")
+	}
+	if opts.TieringAge > 0 && opts.BlockStore == nil {
+		return nil, errors.Wrap(ErrBlockStoreAbsent, "failed to enable the tiering")
+	}
+	if opts.BlockCacheSize > 0 && opts.BlockStore == nil {
+		return nil, errors.Wrap(ErrBlockStoreAbsent, "failed to enable the block cache")
+	}
+	if opts.SegmentInterval != SegmentIntervalUnspecified {
+		if opts.Partitioner != nil {
+			return nil, errors.WithStack(ErrSegmentIntervalConflict)
+		}
+		if opts.Partitioner, err = opts.SegmentInterval.partitioner(); err != nil {
+			return nil, errors.Wrap(err, "failed to open database")
+		}
+	}
+	if err = opts.Durability.validate(); err != nil {
+		return nil, errors.Wrap(err, "failed to open database")
+	}
+	clock, ok := ctx.Value(clockKey).(clockwork.Clock)
+	if !ok {
+		clock = clockwork.NewRealClock()
 	}
 	if _, err := mkdir(opts.Location); err != nil {
 		return nil, err
 	}
 	db.logger.Info().Str("path", opts.Location).Msg("initialized")
+	cleaner := newOrphanCleaner(db.logger, opts.Location, db.livePaths)
+	if _, err := cleaner.clean(); err != nil {
+		db.logger.Warn().Err(err).Msg("failed to clean orphan directories")
+	}
+	if opts.VerifyOnOpen {
+		report, err := verifyBlocks(db.logger, opts.Location)
+		if err != nil {
+			return nil, err
+		}
+		db.report = report
+		db.logger.Info().Str("report", report.String()).Msg("verified blocks")
+		if len(report.Corrupt) > 0 && opts.FailOnCorruptBlocks {
+			return nil, errors.WithMessage(ErrCorruptBlocks, report.String())
+		}
+		if err = quarantine(db.logger, report); err != nil {
+			return nil, err
+		}
+	}
 	var entries []fs.FileInfo
-	var err error
 	if entries, err = ioutil.ReadDir(opts.Location); err != nil {
 		return nil, errors.Wrap(err, "failed to read directory contents failed")
 	}
 	thisContext := context.WithValue(ctx, logger.ContextKey, db.logger)
 	thisContext = context.WithValue(thisContext, indexRulesKey, opts.IndexRules)
 	thisContext = context.WithValue(thisContext, encodingMethodKey, opts.EncodingMethod)
+	thisContext = context.WithValue(thisContext, preallocateKey, opts.PreallocateBytes)
+	thisContext = context.WithValue(thisContext, segmentGraceKey, opts.SegmentGracePeriod)
+	thisContext = context.WithValue(thisContext, precisionKey, opts.TimestampPrecision)
+	thisContext = context.WithValue(thisContext, storageEngineKey, opts.StorageEngine)
+	thisContext = context.WithValue(thisContext, flushHookKey, flushHook(db.notifier.notify))
+	thisContext = context.WithValue(thisContext, snapshotsKey, db.snapshots)
+	thisContext = context.WithValue(thisContext, writeLockTimeout, opts.WriteLockTimeout)
+	thisContext = context.WithValue(thisContext, durabilityKey, opts.Durability)
+	thisContext = context.WithValue(thisContext, blockIntervalKey, opts.BlockInterval)
+	if opts.Partitioner != nil {
+		thisContext = context.WithValue(thisContext, partitionerKey, opts.Partitioner)
+	}
+	if opts.BlockStore != nil {
+		store := opts.BlockStore
+		if opts.BlockCacheSize > 0 {
+			cacheLocation := opts.BlockCacheLocation
+			if cacheLocation == "" {
+				cacheLocation = filepath.Clean(opts.Location) + blockCacheDirSuffix
+			}
+			if store, err = NewBlockCache(store, cacheLocation, opts.BlockCacheSize); err != nil {
+				return nil, errors.WithMessage(err, "failed to create the block cache")
+			}
+		}
+		thisContext = context.WithValue(thisContext, blockStoreKey, &blockStoreRef{
+			store:         store,
+			location:      opts.Location,
+			cacheDuration: opts.TieringCacheDuration,
+			clock:         clock,
+		})
+	}
+	var database Database
 	if len(entries) > 0 {
-		return loadDatabase(thisContext, db)
+		database, err = loadDatabase(thisContext, db)
+	} else {
+		database, err = createDatabase(thisContext, db)
+	}
+	if err == nil && opts.OrphanCleanInterval > 0 {
+		db.cleaner = cleaner
+		cleaner.start(opts.OrphanCleanInterval)
+	}
+	if err == nil && (opts.TTL > 0 || opts.MaxSegments > 0) {
+		interval := opts.RetentionCheckInterval
+		if interval <= 0 {
+			interval = DefaultRetentionCheckInterval
+		}
+		db.retention = newRetention(db.logger, opts.TTL, opts.MaxSegments, clock, db.reapSegments)
+		db.retention.start(interval)
+	}
+	if err == nil && opts.TieringAge > 0 {
+		interval := opts.TieringCheckInterval
+		if interval <= 0 {
+			interval = DefaultTieringCheckInterval
+		}
+		db.tiering = newTiering(db.logger, opts.TieringAge, clock, db.offloadSegments)
+		db.tiering.start(interval)
+	}
+	if err == nil && opts.CompactionInterval > 0 {
+		db.compactor = newCompaction(db.logger, clock, db.Compact)
+		db.compactor.start(opts.CompactionInterval)
+	}
+	if err == nil && opts.Durability.mode == durabilityPeriodic {
+		db.flusher = newFlusher(db.logger, clock, db.Flush)
+		db.flusher.start(opts.Durability.interval)
+	}
+	if err == nil {
+		distribution.add(db)
+	}
+	return database, err
+}
+
+// livePaths returns the directories of live segments and blocks, which the orphan cleaner never touches
+func (d *database) livePaths() map[string]struct{} {
+	d.Lock()
+	defer d.Unlock()
+	paths := make(map[string]struct{})
+	for _, s := range d.sLst {
+		sd, ok := s.(*shard)
+		if !ok {
+			continue
+		}
+		for _, seg := range sd.segmentController.segments() {
+			paths[filepath.Clean(seg.path)] = struct{}{}
+			for _, b := range seg.blocks() {
+				paths[filepath.Clean(b.path)] = struct{}{}
+			}
+		}
 	}
-	return createDatabase(thisContext, db)
+	return paths
 }
 
 func createDatabase(ctx context.Context, db *database) (Database, error) {
@@ -165,11 +538,60 @@ func createDatabase(ctx context.Context, db *database) (Database, error) {
 	return db, err
 }
 
+// loadDatabase reopens the shards found under the location, which rebuilds their segments and blocks.
+// A location holding no shard is initialized like a new one.
 func loadDatabase(ctx context.Context, db *database) (Database, error) {
-	//TODO: load the existing database
+	num, err := countShards(db.location)
+	if err != nil {
+		return nil, err
+	}
+	if num == 0 {
+		return createDatabase(ctx, db)
+	}
+	if num != db.shardNum {
+		return nil, errors.WithMessagef(ErrShardNumMismatch, "%s has %d shards, but ShardNum is %d", db.location, num, db.shardNum)
+	}
+	db.Lock()
+	defer db.Unlock()
+	for i := uint32(0); i < db.shardNum; i++ {
+		shardLocation := fmt.Sprintf(shardTemplate, db.location, i)
+		if errManifest := ensureSegmentManifest(shardLocation); errManifest != nil {
+			return nil, errors.Wrapf(errManifest, "failed to rebuild the segment manifest of %s", shardLocation)
+		}
+		so, errShard := newShard(ctx, common.ShardID(i), shardLocation)
+		if errShard != nil {
+			return nil, errors.WithMessagef(errShard, "failed to load %s", shardLocation)
+		}
+		db.sLst = append(db.sLst, so)
+	}
 	return db, nil
 }
 
+// countShards returns the number of the shards under the location, whose ids should be contiguous from zero
+func countShards(location string) (uint32, error) {
+	entries, err := ioutil.ReadDir(location)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to read %s", location)
+	}
+	ids := make(map[uint32]struct{})
+	for _, e := range entries {
+		var id uint32
+		if !e.IsDir() {
+			continue
+		}
+		if _, errScan := fmt.Sscanf(e.Name(), "shard-%d", &id); errScan != nil {
+			continue
+		}
+		ids[id] = struct{}{}
+	}
+	for id := uint32(0); id < uint32(len(ids)); id++ {
+		if _, ok := ids[id]; !ok {
+			return 0, errors.WithMessagef(ErrShardNumMismatch, "shard-%d is absent from %s", id, location)
+		}
+	}
+	return uint32(len(ids)), nil
+}
+
 func mkdir(format string, a ...interface{}) (path string, err error) {
 	path = fmt.Sprintf(format, a...)
 	if err = os.MkdirAll(path, dirPerm); err != nil {
@@ -24,9 +24,11 @@ import (
 	"testing"
 	"time"
 
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/apache/skywalking-banyandb/api/common"
 	"github.com/apache/skywalking-banyandb/pkg/encoding"
 	"github.com/apache/skywalking-banyandb/pkg/logger"
 	"github.com/apache/skywalking-banyandb/pkg/test"
@@ -46,25 +48,137 @@ func TestOpenDatabase(t *testing.T) {
 	validateDirectory(tester, fmt.Sprintf(blockTemplate, segPath, now.Format(blockFormat)))
 }
 
+func Test_Database_Shards(t *testing.T) {
+	tester := assert.New(t)
+	_, deferFunc, db := setUpWithOpts(require.New(t), func(opts *DatabaseOpts) {
+		opts.ShardNum = 3
+	})
+	defer deferFunc()
+	shards := db.Shards()
+	tester.Len(shards, 3)
+	for i, s := range shards {
+		tester.Equal(common.ShardID(i), s.ID())
+		got, err := db.Shard(s.ID())
+		tester.NoError(err)
+		tester.Equal(s, got)
+	}
+	_, err := db.Shard(common.ShardID(3))
+	tester.ErrorIs(err, ErrInvalidShardID)
+}
+
+func Test_Database_Reopen(t *testing.T) {
+	req := require.New(t)
+	daily, err := NewIntervalPartitioner(24 * time.Hour)
+	req.NoError(err)
+	ctx, opts, removeSpace := setUpOpts(req, func(opts *DatabaseOpts) {
+		opts.ShardNum = 2
+		opts.Partitioner = daily
+		opts.MaxSegments = 2
+	})
+	defer removeSpace()
+	entity := Entity{Entry("productpage"), Entry("10.0.0.1")}
+	now := time.Now()
+	timeRange := NewTimeRangeDuration(now.Add(-96*time.Hour), 120*time.Hour)
+
+	db, err := OpenDatabase(ctx, opts)
+	req.NoError(err)
+	s, err := db.Shard(1)
+	req.NoError(err)
+	series, err := s.Series().Get(entity)
+	req.NoError(err)
+	// every item lands in a segment of its own
+	ids := make(map[GlobalItemID]string)
+	for i := 3; i >= 0; i-- {
+		ts := now.Add(-time.Duration(i) * 24 * time.Hour)
+		span, errSpan := series.Span(NewTimeRangeDuration(ts, time.Hour))
+		req.NoError(errSpan)
+		value := fmt.Sprintf("value-%d", i)
+		writer, errWriter := span.WriterBuilder().
+			Family([]byte("searchable"), []byte(value)).
+			Time(ts).
+			Build()
+		req.NoError(errWriter)
+		id, errWrite := writer.Write()
+		req.NoError(errWrite)
+		req.NoError(span.Close())
+		ids[id] = value
+	}
+	// the oldest segments expire, which leaves the ids of the rest unchanged
+	n, err := db.Retain()
+	req.NoError(err)
+	req.Greater(n, 0)
+	for id := range ids {
+		_, closer, errGet := series.Get(id)
+		if errors.Is(errGet, ErrExpiredItem) {
+			delete(ids, id)
+			continue
+		}
+		req.NoError(errGet)
+		req.NoError(closer.Close())
+	}
+	req.Len(ids, 4-n)
+	req.NoError(db.Close())
+
+	db, err = OpenDatabase(ctx, opts)
+	req.NoError(err)
+	defer func() {
+		req.NoError(db.Close())
+	}()
+	req.Len(db.Shards(), 2)
+	s, err = db.Shard(1)
+	req.NoError(err)
+	req.Len(s.SegmentsInRange(timeRange.Start, timeRange.End), len(ids))
+	series, err = s.Series().Get(entity)
+	req.NoError(err)
+	for id, value := range ids {
+		item, closer, errGet := series.Get(id)
+		req.NoError(errGet)
+		v, errFamily := item.Family("searchable")
+		req.NoError(errFamily)
+		req.Equal(value, string(v))
+		req.NoError(closer.Close())
+	}
+
+	opts.ShardNum = 3
+	_, err = OpenDatabase(ctx, opts)
+	req.ErrorIs(err, ErrShardNumMismatch)
+}
+
 func setUp(t *require.Assertions) (tempDir string, deferFunc func(), db Database) {
+	return setUpWithOpts(t, nil)
+}
+
+func setUpWithOpts(t *require.Assertions, optsFn func(opts *DatabaseOpts)) (tempDir string, deferFunc func(), db Database) {
+	ctx, opts, removeSpace := setUpOpts(t, optsFn)
+	db, err := OpenDatabase(ctx, opts)
+	t.NoError(err)
+	t.NotNil(db)
+	return opts.Location, func() {
+		_ = db.Close()
+		removeSpace()
+	}, db
+}
+
+// setUpOpts returns the options of a database located in a new space, which is removed by removeSpace,
+// along with the context to open it. optsFn overrides the options if it isn't nil.
+func setUpOpts(t *require.Assertions, optsFn func(opts *DatabaseOpts)) (ctx context.Context, opts DatabaseOpts, removeSpace func()) {
 	t.NoError(logger.Init(logger.Logging{
 		Env:   "dev",
 		Level: "warn",
 	}))
-	tempDir, deferFunc = test.Space(t)
-	db, err := OpenDatabase(
-		context.WithValue(context.Background(), logger.ContextKey, logger.GetLogger("test")),
-		DatabaseOpts{
-			Location: tempDir,
-			ShardNum: 1,
-			EncodingMethod: EncodingMethod{
-				EncoderPool: encoding.NewPlainEncoderPool(0),
-				DecoderPool: encoding.NewPlainDecoderPool(0),
-			},
-		})
-	t.NoError(err)
-	t.NotNil(db)
-	return tempDir, deferFunc, db
+	tempDir, removeSpace := test.Space(t)
+	opts = DatabaseOpts{
+		Location: tempDir,
+		ShardNum: 1,
+		EncodingMethod: EncodingMethod{
+			EncoderPool: encoding.NewPlainEncoderPool(0),
+			DecoderPool: encoding.NewPlainDecoderPool(0),
+		},
+	}
+	if optsFn != nil {
+		optsFn(&opts)
+	}
+	return context.WithValue(context.Background(), logger.ContextKey, logger.GetLogger("test")), opts, removeSpace
 }
 
 func validateDirectory(t *assert.Assertions, dir string) {
@@ -0,0 +1,109 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tsdb
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+
+	"github.com/apache/skywalking-banyandb/banyand/kv"
+	"github.com/apache/skywalking-banyandb/pkg/logger"
+)
+
+var ErrCorruptBlocks = errors.New("corrupt blocks are found")
+
+// VerifyReport is the result of verifying the checksums of all the blocks on the disk
+type VerifyReport struct {
+	// Checked is the number of the verified blocks
+	Checked int
+	Corrupt []CorruptBlock
+}
+
+type CorruptBlock struct {
+	Path string
+	Err  error
+}
+
+func (r *VerifyReport) String() string {
+	if len(r.Corrupt) == 0 {
+		return fmt.Sprintf("%d blocks are verified", r.Checked)
+	}
+	paths := make([]string, 0, len(r.Corrupt))
+	for _, c := range r.Corrupt {
+		paths = append(paths, c.Path)
+	}
+	return fmt.Sprintf("%d of %d blocks are corrupt: %s", len(r.Corrupt), r.Checked, strings.Join(paths, ","))
+}
+
+// verifyBlocks checks every store of the blocks under location. A block fails if any of its stores fails.
+func verifyBlocks(l *logger.Logger, location string) (*VerifyReport, error) {
+	blockPaths, err := filepath.Glob(filepath.Join(location, "shard-*", "seg-*", "block-*"))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list blocks")
+	}
+	report := &VerifyReport{}
+	for _, blockPath := range blockPaths {
+		report.Checked++
+		if errBlock := verifyBlock(l, blockPath); errBlock != nil {
+			l.Warn().Err(errBlock).Str("path", blockPath).Msg("found a corrupt block")
+			report.Corrupt = append(report.Corrupt, CorruptBlock{
+				Path: blockPath,
+				Err:  errBlock,
+			})
+		}
+	}
+	return report, nil
+}
+
+func verifyBlock(l *logger.Logger, blockPath string) error {
+	return filepath.Walk(blockPath, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		// a store's directory holds a MANIFEST
+		if info.IsDir() || info.Name() != "MANIFEST" {
+			return nil
+		}
+		storePath := filepath.Dir(path)
+		if errStore := kv.VerifyChecksum(storePath, l); errStore != nil {
+			return errors.WithMessagef(errStore, "store %s", storePath)
+		}
+		return nil
+	})
+}
+
+// corruptBlockPrefix renames the corrupt blocks, which keeps them for the inspection but out of the segments
+const corruptBlockPrefix = "corrupt-"
+
+// quarantine moves the corrupt blocks of the report aside, so the segments reopen them as empty blocks
+func quarantine(l *logger.Logger, report *VerifyReport) (err error) {
+	for _, c := range report.Corrupt {
+		target := filepath.Join(filepath.Dir(c.Path), corruptBlockPrefix+filepath.Base(c.Path))
+		if errRename := os.Rename(c.Path, target); errRename != nil {
+			err = multierr.Append(err, errors.Wrapf(errRename, "failed to move %s aside", c.Path))
+			continue
+		}
+		l.Warn().Str("path", target).Msg("moved a corrupt block aside")
+	}
+	return err
+}
@@ -0,0 +1,92 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tsdb
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Database_VerifyOnOpen(t *testing.T) {
+	req := require.New(t)
+	ctx, opts, removeSpace := setUpOpts(req, func(opts *DatabaseOpts) {
+		opts.ShardNum = 2
+	})
+	defer removeSpace()
+	tempDir := opts.Location
+	db, err := OpenDatabase(ctx, opts)
+	req.NoError(err)
+	ts := time.Now()
+	for _, s := range db.Shards() {
+		series, errSeries := s.Series().Get(Entity{Entry("productpage"), Entry("10.0.0.1")})
+		req.NoError(errSeries)
+		span, errSpan := series.Span(NewTimeRangeDuration(ts.Add(-time.Hour), 2*time.Hour))
+		req.NoError(errSpan)
+		writer, errWriter := span.WriterBuilder().
+			Family([]byte("searchable"), []byte("200")).
+			Time(ts).
+			Build()
+		req.NoError(errWriter)
+		_, errWriter = writer.Write()
+		req.NoError(errWriter)
+		req.NoError(span.Close())
+	}
+	// closing the stores flushes the data into tables
+	req.NoError(db.Close())
+
+	tables, err := filepath.Glob(filepath.Join(tempDir, "shard-0", "seg-*", "block-*", "store", "*.sst"))
+	req.NoError(err)
+	req.NotEmpty(tables)
+	corrupt(req, tables[0])
+	corruptBlock := filepath.Dir(filepath.Dir(tables[0]))
+
+	opts.VerifyOnOpen = true
+	opts.FailOnCorruptBlocks = true
+	_, err = OpenDatabase(ctx, opts)
+	req.ErrorIs(err, ErrCorruptBlocks)
+
+	opts.FailOnCorruptBlocks = false
+	db, err = OpenDatabase(ctx, opts)
+	req.NoError(err)
+	report := db.VerifyReport()
+	req.NoError(db.Close())
+	req.NotNil(report)
+	req.Equal(2, report.Checked)
+	req.Len(report.Corrupt, 1)
+	req.Equal(corruptBlock, report.Corrupt[0].Path)
+	req.Error(report.Corrupt[0].Err)
+	// the corrupt block is kept aside, and the segment reopens an empty one
+	validateDirectory(assert.New(t), filepath.Join(filepath.Dir(corruptBlock), corruptBlockPrefix+filepath.Base(corruptBlock)))
+	validateDirectory(assert.New(t), corruptBlock)
+}
+
+// corrupt flips the bytes in the middle of the file
+func corrupt(t *require.Assertions, path string) {
+	data, err := ioutil.ReadFile(path)
+	t.NoError(err)
+	t.NotEmpty(data)
+	for i := len(data) / 4; i < len(data)/2; i++ {
+		data[i] = ^data[i]
+	}
+	t.NoError(ioutil.WriteFile(path, data, 0600))
+}
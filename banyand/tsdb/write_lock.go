@@ -0,0 +1,117 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tsdb
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/apache/skywalking-banyandb/api/common"
+)
+
+// ErrWriteLockTimeout is retriable: the shard was flushing longer than the write could wait
+var ErrWriteLockTimeout = errors.New("timed out waiting for the flush of the shard")
+
+// writeLock is shared by the writes to a shard, and held exclusively by a flush.
+// A pending flush blocks the new writes, which could give up by their contexts.
+type writeLock struct {
+	shardID common.ShardID
+	// timeout bounds the waiting of a write whose context has no deadline
+	timeout time.Duration
+
+	flushMu  sync.Mutex
+	mu       sync.Mutex
+	writers  int
+	flushing bool
+	// released is closed once the flush is done
+	released chan struct{}
+	// drained is closed once the last write leaves during a flush
+	drained chan struct{}
+}
+
+func newWriteLock(shardID common.ShardID, timeout time.Duration) *writeLock {
+	return &writeLock{
+		shardID: shardID,
+		timeout: timeout,
+	}
+}
+
+func (l *writeLock) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.Deadline(); ok || l.timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, l.timeout)
+}
+
+// lockWrite returns ErrWriteLockTimeout if the context is done before the flush finishes
+func (l *writeLock) lockWrite(ctx context.Context) error {
+	ctx, cancel := l.withTimeout(ctx)
+	defer cancel()
+	for {
+		l.mu.Lock()
+		if !l.flushing {
+			l.writers++
+			l.mu.Unlock()
+			return nil
+		}
+		released := l.released
+		l.mu.Unlock()
+		select {
+		case <-released:
+		case <-ctx.Done():
+			return errors.WithMessagef(ErrWriteLockTimeout, "shard %d: %v", l.shardID, ctx.Err())
+		}
+	}
+}
+
+func (l *writeLock) unlockWrite() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.writers--
+	if l.writers == 0 && l.drained != nil {
+		close(l.drained)
+		l.drained = nil
+	}
+}
+
+// lockFlush waits for the ongoing writes to leave, and blocks the new ones
+func (l *writeLock) lockFlush() {
+	l.flushMu.Lock()
+	l.mu.Lock()
+	l.flushing = true
+	l.released = make(chan struct{})
+	for l.writers > 0 {
+		drained := make(chan struct{})
+		l.drained = drained
+		l.mu.Unlock()
+		<-drained
+		l.mu.Lock()
+	}
+	l.mu.Unlock()
+}
+
+func (l *writeLock) unlockFlush() {
+	l.mu.Lock()
+	l.flushing = false
+	close(l.released)
+	l.mu.Unlock()
+	l.flushMu.Unlock()
+}
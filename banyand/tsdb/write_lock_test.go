@@ -0,0 +1,104 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tsdb
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Database_WriteLockTimeout(t *testing.T) {
+	req := require.New(t)
+	_, deferFunc, db := setUpWithOpts(req, func(opts *DatabaseOpts) {
+		opts.WriteLockTimeout = 20 * time.Millisecond
+	})
+	defer deferFunc()
+	s, err := db.Shard(0)
+	req.NoError(err)
+	series, err := s.Series().Get(Entity{Entry("productpage"), Entry("10.0.0.1")})
+	req.NoError(err)
+	base := time.Now()
+	span, err := series.Span(NewTimeRangeDuration(base.Add(-time.Hour), 2*time.Hour))
+	req.NoError(err)
+	defer func() {
+		req.NoError(span.Close())
+	}()
+
+	var seq, succeeded, timedOut int64
+	var wg sync.WaitGroup
+	stopCh := make(chan struct{})
+	errCh := make(chan error, 8)
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stopCh:
+					return
+				default:
+				}
+				writer, errBuild := span.WriterBuilder().
+					Family([]byte("searchable"), []byte("200")).
+					Time(base.Add(time.Duration(atomic.AddInt64(&seq, 1)))).
+					Build()
+				if errBuild != nil {
+					errCh <- errBuild
+					return
+				}
+				_, errWrite := writer.Write()
+				switch {
+				case errWrite == nil:
+					atomic.AddInt64(&succeeded, 1)
+				case errors.Is(errWrite, ErrWriteLockTimeout):
+					atomic.AddInt64(&timedOut, 1)
+				default:
+					errCh <- errWrite
+					return
+				}
+				if atomic.LoadInt64(&seq)%50 == 0 {
+					if errSync := writer.Sync(); errSync != nil {
+						errCh <- errSync
+						return
+					}
+				}
+			}
+		}()
+	}
+	// simulate slow flushes
+	lock := s.(*shard).writeLock
+	for i := 0; i < 3; i++ {
+		time.Sleep(20 * time.Millisecond)
+		lock.lockFlush()
+		time.Sleep(100 * time.Millisecond)
+		lock.unlockFlush()
+	}
+	close(stopCh)
+	wg.Wait()
+	close(errCh)
+	for err := range errCh {
+		req.NoError(err)
+	}
+	req.Greater(atomic.LoadInt64(&succeeded), int64(0))
+	req.Greater(atomic.LoadInt64(&timedOut), int64(0))
+}
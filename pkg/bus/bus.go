@@ -37,6 +37,27 @@ type Future interface {
 type Message struct {
 	id      MessageID
 	payload Payload
+	header  Header
+}
+
+// Header carries the metadata of a message besides its payload, e.g. the trace context of the sender.
+// It's a propagation.TextMapCarrier of OpenTelemetry.
+type Header map[string]string
+
+func (h Header) Get(key string) string {
+	return h[key]
+}
+
+func (h Header) Set(key string, value string) {
+	h[key] = value
+}
+
+func (h Header) Keys() []string {
+	keys := make([]string, 0, len(h))
+	for k := range h {
+		keys = append(keys, k)
+	}
+	return keys
 }
 
 func (m Message) ID() MessageID {
@@ -47,10 +68,19 @@ func (m Message) Data() interface{} {
 	return m.payload
 }
 
+// Header returns nil if the message is created without a header
+func (m Message) Header() Header {
+	return m.header
+}
+
 func NewMessage(id MessageID, data interface{}) Message {
 	return Message{id: id, payload: data}
 }
 
+func NewMessageWithHeader(id MessageID, data interface{}, header Header) Message {
+	return Message{id: id, payload: data, header: header}
+}
+
 //MessageListener is the signature of functions that can handle an EventMessage.
 type MessageListener interface {
 	Rev(message Message) Message
@@ -17,9 +17,61 @@
 
 package encoding
 
-import "github.com/pkg/errors"
+import (
+	"fmt"
 
-var ErrEncodeEmpty = errors.New("encode an empty value")
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrEncodeEmpty = errors.New("encode an empty value")
+	// ErrUnsupportedEncoding means the binary is encoded by a codec the decoder can't read,
+	// e.g. a block written by a newer version is read after a downgrade
+	ErrUnsupportedEncoding = errors.New("unsupported encoding")
+)
+
+// Codec is the marker of the encoding method, which is the last byte of an encoded binary
+type Codec byte
+
+const (
+	CodecPlain Codec = iota + 1
+	CodecInt
+	CodecGorilla
+)
+
+func (c Codec) String() string {
+	switch c {
+	case CodecPlain:
+		return "plain"
+	case CodecInt:
+		return "int"
+	case CodecGorilla:
+		return "gorilla"
+	}
+	return fmt.Sprintf("unknown(0x%02x)", byte(c))
+}
+
+// unmark strips the marker from the encoded binary, which should be encoded by the expected codec
+func unmark(data []byte, expected Codec) ([]byte, error) {
+	if len(data) < 1 {
+		return nil, errors.WithMessage(ErrUnsupportedEncoding, "the encoding marker is absent")
+	}
+	if c := Codec(data[len(data)-1]); c != expected {
+		return nil, errors.WithMessagef(ErrUnsupportedEncoding, "codec %s can't be read by the %s decoder", c, expected)
+	}
+	return data[:len(data)-1], nil
+}
+
+// NewPools constructs the pools of the codec, whose blocks are full once their values reach size
+func NewPools(codec Codec, size int) (SeriesEncoderPool, SeriesDecoderPool, error) {
+	switch codec {
+	case CodecPlain:
+		return NewPlainEncoderPool(size), NewPlainDecoderPool(size), nil
+	case CodecGorilla:
+		return NewGorillaEncoderPool(size), NewGorillaDecoderPool(size), nil
+	}
+	return nil, nil, errors.WithMessagef(ErrUnsupportedEncoding, "codec %s has no pool", codec)
+}
 
 type SeriesEncoderPool interface {
 	Get(metadata []byte) SeriesEncoder
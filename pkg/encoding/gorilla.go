@@ -0,0 +1,350 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package encoding
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"sync"
+
+	"github.com/apache/skywalking-banyandb/pkg/bit"
+	"github.com/apache/skywalking-banyandb/pkg/buffer"
+)
+
+// The values of a block are compressed by XOR if all of them have 8 bytes, e.g. the float64 or int64 ones,
+// otherwise they are kept as they are.
+const (
+	gorillaValuesXOR byte = iota
+	gorillaValuesRaw
+)
+
+// gorillaFooterLen equals num(uint32) + raw size(uint32) + values mode(byte)
+const gorillaFooterLen = 4 + 4 + 1
+
+var (
+	gorillaEncoders = sync.Pool{
+		New: func() interface{} {
+			return &gorillaEncoder{}
+		},
+	}
+	gorillaDecoders = sync.Pool{
+		New: func() interface{} {
+			return &gorillaDecoder{}
+		},
+	}
+	_ SeriesEncoder = (*gorillaEncoder)(nil)
+	_ SeriesDecoder = (*gorillaDecoder)(nil)
+)
+
+type gorillaEncoderPool struct {
+	pool *sync.Pool
+	size int
+}
+
+// NewGorillaEncoderPool compresses the timestamps by delta-of-delta and the 8-byte values by XOR,
+// as described in http://www.vldb.org/pvldb/vol8/p1816-teller.pdf. A block is full once its raw values reach size.
+func NewGorillaEncoderPool(size int) SeriesEncoderPool {
+	return &gorillaEncoderPool{
+		pool: &gorillaEncoders,
+		size: size,
+	}
+}
+
+func (p *gorillaEncoderPool) Get(metadata []byte) SeriesEncoder {
+	encoder := p.pool.Get().(*gorillaEncoder)
+	encoder.Reset(metadata)
+	encoder.valueSize = p.size
+	return encoder
+}
+
+func (p *gorillaEncoderPool) Put(encoder SeriesEncoder) {
+	p.pool.Put(encoder)
+}
+
+type gorillaDecoderPool struct {
+	pool *sync.Pool
+	size int
+}
+
+// NewGorillaDecoderPool decodes the blocks encoded by the pool of NewGorillaEncoderPool
+func NewGorillaDecoderPool(size int) SeriesDecoderPool {
+	return &gorillaDecoderPool{
+		pool: &gorillaDecoders,
+		size: size,
+	}
+}
+
+func (p *gorillaDecoderPool) Get(_ []byte) SeriesDecoder {
+	decoder := p.pool.Get().(*gorillaDecoder)
+	decoder.valueSize = p.size
+	return decoder
+}
+
+func (p *gorillaDecoderPool) Put(decoder SeriesDecoder) {
+	p.pool.Put(decoder)
+}
+
+// gorillaEncoder keeps the data points in the order of appending, which might be either ascending or descending
+type gorillaEncoder struct {
+	ts        []uint64
+	vals      [][]byte
+	rawSize   int
+	startTime uint64
+	valueSize int
+}
+
+func (e *gorillaEncoder) Append(ts uint64, value []byte) {
+	if e.startTime == 0 || e.startTime > ts {
+		e.startTime = ts
+	}
+	e.ts = append(e.ts, ts)
+	e.vals = append(e.vals, append([]byte(nil), value...))
+	e.rawSize += len(value)
+}
+
+func (e *gorillaEncoder) IsFull() bool {
+	return e.rawSize >= e.valueSize
+}
+
+func (e *gorillaEncoder) Reset(_ []byte) {
+	e.ts = e.ts[:0]
+	e.vals = e.vals[:0]
+	e.rawSize = 0
+	e.startTime = 0
+}
+
+func (e *gorillaEncoder) Encode() ([]byte, error) {
+	if len(e.ts) < 1 {
+		return nil, ErrEncodeEmpty
+	}
+	buff := &bytes.Buffer{}
+	bw := bit.NewWriter(buff)
+	writeTimestamps(bw, e.ts)
+	mode := gorillaValuesXOR
+	for _, v := range e.vals {
+		if len(v) != 8 {
+			mode = gorillaValuesRaw
+			break
+		}
+	}
+	if mode == gorillaValuesXOR {
+		values := NewXOREncoder(bw)
+		for _, v := range e.vals {
+			values.Write(binary.LittleEndian.Uint64(v))
+		}
+	} else {
+		for _, v := range e.vals {
+			bw.WriteBits(uint64(len(v)), 32)
+			for _, b := range v {
+				bw.WriteByte(b)
+			}
+		}
+	}
+	bw.Flush()
+	result := buffer.NewBufferWriter(buff)
+	result.PutUint32(uint32(len(e.ts)))
+	result.PutUint32(uint32(e.rawSize))
+	result.Write([]byte{mode, byte(CodecGorilla)})
+	return result.Bytes(), nil
+}
+
+func (e *gorillaEncoder) StartTime() uint64 {
+	return e.startTime
+}
+
+// The buckets of the zigzag-encoded delta-of-delta, each of which is led by its control bits
+var dodBuckets = []struct {
+	ctrl     uint64
+	ctrlBits int
+	bits     int
+}{
+	{ctrl: 0x2, ctrlBits: 2, bits: 7},
+	{ctrl: 0x6, ctrlBits: 3, bits: 9},
+	{ctrl: 0xe, ctrlBits: 4, bits: 12},
+	{ctrl: 0x1e, ctrlBits: 5, bits: 32},
+	{ctrl: 0x1f, ctrlBits: 5, bits: 64},
+}
+
+// writeTimestamps writes the first timestamp as it is, and the delta-of-delta of the others.
+// A zero delta-of-delta, which is common for the regular intervals, takes a single bit.
+func writeTimestamps(bw *bit.Writer, ts []uint64) {
+	bw.WriteBits(ts[0], 64)
+	var prevDelta int64
+	for i := 1; i < len(ts); i++ {
+		delta := int64(ts[i] - ts[i-1])
+		dod := delta - prevDelta
+		prevDelta = delta
+		if dod == 0 {
+			bw.WriteBool(false)
+			continue
+		}
+		zigzag := uint64((dod << 1) ^ (dod >> 63))
+		for _, b := range dodBuckets {
+			if b.bits == 64 || zigzag < 1<<uint(b.bits) {
+				bw.WriteBits(b.ctrl, b.ctrlBits)
+				bw.WriteBits(zigzag, b.bits)
+				break
+			}
+		}
+	}
+}
+
+func readTimestamps(br *bit.Reader, num int) ([]uint64, error) {
+	ts := make([]uint64, num)
+	first, err := br.ReadBits(64)
+	if err != nil {
+		return nil, err
+	}
+	ts[0] = first
+	var prevDelta int64
+	for i := 1; i < num; i++ {
+		bucket := -1
+		for ; bucket < len(dodBuckets)-1; bucket++ {
+			b, errRead := br.ReadBool()
+			if errRead != nil {
+				return nil, errRead
+			}
+			if !b {
+				break
+			}
+		}
+		var dod int64
+		if bucket >= 0 {
+			zigzag, errRead := br.ReadBits(dodBuckets[bucket].bits)
+			if errRead != nil {
+				return nil, errRead
+			}
+			dod = int64(zigzag>>1) ^ -int64(zigzag&1)
+		}
+		prevDelta += dod
+		ts[i] = ts[i-1] + uint64(prevDelta)
+	}
+	return ts, nil
+}
+
+// gorillaDecoder decodes all the data points of a block at once
+type gorillaDecoder struct {
+	ts        []uint64
+	vals      [][]byte
+	rawSize   int
+	valueSize int
+}
+
+func (d *gorillaDecoder) Decode(_, data []byte) (err error) {
+	if data, err = unmark(data, CodecGorilla); err != nil {
+		return err
+	}
+	if len(data) < gorillaFooterLen {
+		return ErrInvalidValue
+	}
+	footer := data[len(data)-gorillaFooterLen:]
+	num := int(binary.LittleEndian.Uint32(footer[:4]))
+	rawSize := int(binary.LittleEndian.Uint32(footer[4:8]))
+	mode := footer[8]
+	if num < 1 {
+		return ErrInvalidValue
+	}
+	br := bit.NewReader(bytes.NewReader(data[:len(data)-gorillaFooterLen]))
+	ts, err := readTimestamps(br, num)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidValue, err)
+	}
+	vals := make([][]byte, num)
+	switch mode {
+	case gorillaValuesXOR:
+		values := NewXORDecoder(br)
+		for i := range vals {
+			if !values.Next() {
+				return fmt.Errorf("%w: %v", ErrInvalidValue, values.Err())
+			}
+			vals[i] = make([]byte, 8)
+			binary.LittleEndian.PutUint64(vals[i], values.Value())
+		}
+	case gorillaValuesRaw:
+		for i := range vals {
+			l, errRead := br.ReadBits(32)
+			if errRead != nil {
+				return fmt.Errorf("%w: %v", ErrInvalidValue, errRead)
+			}
+			if l > uint64(rawSize) {
+				return ErrInvalidValue
+			}
+			vals[i] = make([]byte, l)
+			for j := range vals[i] {
+				if vals[i][j], errRead = br.ReadByte(); errRead != nil {
+					return fmt.Errorf("%w: %v", ErrInvalidValue, errRead)
+				}
+			}
+		}
+	default:
+		return ErrInvalidValue
+	}
+	d.ts, d.vals, d.rawSize = ts, vals, rawSize
+	return nil
+}
+
+func (d *gorillaDecoder) Len() int {
+	return len(d.ts)
+}
+
+func (d *gorillaDecoder) IsFull() bool {
+	return d.rawSize >= d.valueSize
+}
+
+func (d *gorillaDecoder) Get(ts uint64) ([]byte, error) {
+	for i, t := range d.ts {
+		if t == ts {
+			return d.vals[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%d doesn't exist", ts)
+}
+
+func (d *gorillaDecoder) Iterator() SeriesIterator {
+	return &gorillaIterator{
+		ts:   d.ts,
+		vals: d.vals,
+		idx:  -1,
+	}
+}
+
+var _ SeriesIterator = (*gorillaIterator)(nil)
+
+type gorillaIterator struct {
+	ts   []uint64
+	vals [][]byte
+	idx  int
+}
+
+func (i *gorillaIterator) Next() bool {
+	i.idx++
+	return i.idx < len(i.ts)
+}
+
+func (i *gorillaIterator) Val() []byte {
+	return i.vals[i.idx]
+}
+
+func (i *gorillaIterator) Time() uint64 {
+	return i.ts[i.idx]
+}
+
+func (i *gorillaIterator) Error() error {
+	return nil
+}
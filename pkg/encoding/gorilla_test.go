@@ -0,0 +1,178 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package encoding
+
+import (
+	"encoding/binary"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+type dataPoint struct {
+	ts  uint64
+	val []byte
+}
+
+func float64Value(v float64) []byte {
+	b := make([]byte, 8)
+	binary.LittleEndian.PutUint64(b, math.Float64bits(v))
+	return b
+}
+
+// monotonicPoints emulates the regular samples, which are appended from the latest to the earliest
+func monotonicPoints(num int) []dataPoint {
+	points := make([]dataPoint, num)
+	start := uint64(1640995200000000000)
+	for i := range points {
+		points[i] = dataPoint{
+			ts:  start + uint64(num-i)*uint64(15e9),
+			val: float64Value(float64(1000 - i)),
+		}
+	}
+	return points
+}
+
+func randomPoints(r *rand.Rand, num int) []dataPoint {
+	points := make([]dataPoint, num)
+	ts := r.Uint64() >> 1
+	for i := range points {
+		ts -= uint64(r.Int63n(1 << 40))
+		points[i] = dataPoint{
+			ts:  ts,
+			val: float64Value(r.NormFloat64() * 1e6),
+		}
+	}
+	return points
+}
+
+func encode(tester *require.Assertions, pool SeriesEncoderPool, points []dataPoint) []byte {
+	encoder := pool.Get(nil)
+	defer pool.Put(encoder)
+	for _, p := range points {
+		encoder.Append(p.ts, p.val)
+	}
+	data, err := encoder.Encode()
+	tester.NoError(err)
+	return data
+}
+
+func assertRoundTrip(tester *require.Assertions, points []dataPoint) {
+	data := encode(tester, NewGorillaEncoderPool(1024), points)
+	decoderPool := NewGorillaDecoderPool(1024)
+	decoder := decoderPool.Get(nil)
+	defer decoderPool.Put(decoder)
+	tester.NoError(decoder.Decode(nil, data))
+	tester.Equal(len(points), decoder.Len())
+	for _, p := range points {
+		val, err := decoder.Get(p.ts)
+		tester.NoError(err)
+		tester.Equal(p.val, val)
+	}
+	iter := decoder.Iterator()
+	for _, p := range points {
+		tester.True(iter.Next())
+		tester.Equal(p.ts, iter.Time())
+		tester.Equal(p.val, iter.Val())
+	}
+	tester.False(iter.Next())
+	tester.NoError(iter.Error())
+}
+
+func TestGorilla_RoundTrip(t *testing.T) {
+	tester := require.New(t)
+	r := rand.New(rand.NewSource(42))
+	for _, num := range []int{1, 2, 3, 100, 1000} {
+		assertRoundTrip(tester, monotonicPoints(num))
+		assertRoundTrip(tester, randomPoints(r, num))
+	}
+	// the timestamps jumping back and forth cover all the buckets of the delta-of-delta
+	assertRoundTrip(tester, []dataPoint{
+		{ts: 0, val: float64Value(0)},
+		{ts: math.MaxUint64, val: float64Value(math.Inf(1))},
+		{ts: 1, val: float64Value(math.NaN())},
+		{ts: 1 << 8, val: float64Value(-1)},
+		{ts: 1 << 12, val: float64Value(1)},
+		{ts: 1 << 20, val: float64Value(math.SmallestNonzeroFloat64)},
+		{ts: 1 << 40, val: float64Value(math.MaxFloat64)},
+	})
+	// the values not having 8 bytes are kept as they are
+	assertRoundTrip(tester, []dataPoint{
+		{ts: 300, val: []byte("baz")},
+		{ts: 200, val: []byte{}},
+		{ts: 100, val: float64Value(1)},
+	})
+}
+
+func TestGorilla_Decode(t *testing.T) {
+	tester := require.New(t)
+	encoder := NewGorillaEncoderPool(16).Get(nil)
+	_, err := encoder.Encode()
+	tester.ErrorIs(err, ErrEncodeEmpty)
+	encoder.Append(200, float64Value(2))
+	tester.False(encoder.IsFull())
+	encoder.Append(100, float64Value(1))
+	tester.True(encoder.IsFull())
+	tester.Equal(uint64(100), encoder.StartTime())
+	data, err := encoder.Encode()
+	tester.NoError(err)
+
+	decoder := NewGorillaDecoderPool(16).Get(nil)
+	tester.NoError(decoder.Decode(nil, data))
+	tester.True(decoder.IsFull())
+	_, err = decoder.Get(150)
+	tester.Error(err)
+
+	tester.True(errors.Is(decoder.Decode(nil, data[len(data)-3:]), ErrInvalidValue))
+	tester.True(errors.Is(decoder.Decode(nil, data[3:]), ErrInvalidValue))
+	plain := encode(tester, NewPlainEncoderPool(16), []dataPoint{{ts: 100, val: float64Value(1)}})
+	err = decoder.Decode(nil, plain)
+	tester.True(errors.Is(err, ErrUnsupportedEncoding))
+	tester.Contains(err.Error(), "codec plain")
+}
+
+func BenchmarkGorillaSize(b *testing.B) {
+	r := rand.New(rand.NewSource(42))
+	for _, bc := range []struct {
+		name   string
+		points []dataPoint
+	}{
+		{name: "monotonic", points: monotonicPoints(1000)},
+		{name: "random", points: randomPoints(r, 1000)},
+	} {
+		for _, codec := range []struct {
+			name string
+			pool SeriesEncoderPool
+		}{
+			{name: "plain", pool: NewPlainEncoderPool(1 << 20)},
+			{name: "gorilla", pool: NewGorillaEncoderPool(1 << 20)},
+		} {
+			b.Run(bc.name+"/"+codec.name, func(b *testing.B) {
+				tester := require.New(b)
+				var size int
+				for i := 0; i < b.N; i++ {
+					size = len(encode(tester, codec.pool, bc.points))
+				}
+				b.ReportMetric(float64(size)/float64(len(bc.points)), "bytes/point")
+			})
+		}
+	}
+}
@@ -91,6 +91,7 @@ func (ie *intEncoder) Encode() ([]byte, error) {
 	buffWriter := buffer.NewBufferWriter(ie.buff)
 	buffWriter.PutUint64(ie.startTime)
 	buffWriter.PutUint16(uint16(ie.size))
+	buffWriter.Write([]byte{byte(CodecInt)})
 	return ie.buff.Bytes(), nil
 }
 
@@ -117,6 +118,13 @@ func NewIntDecoder(size int, fn ParseInterval) SeriesDecoder {
 }
 
 func (i intDecoder) Decode(key, data []byte) error {
+	data, err := unmark(data, CodecInt)
+	if err != nil {
+		return err
+	}
+	if len(data) < 10 {
+		return ErrInvalidValue
+	}
 	i.interval = i.fn(key)
 	i.startTime = binary.LittleEndian.Uint64(data[len(data)-10 : len(data)-2])
 	i.num = int(binary.LittleEndian.Uint16(data[len(data)-2:]))
@@ -149,9 +149,10 @@ func (t *plainEncoder) Encode() ([]byte, error) {
 	l := len(data)
 	dst := make([]byte, 0, compressBound(l))
 	dst = zstdEncoder.EncodeAll(data, dst)
-	result := buffer.NewBufferWriter(bytes.NewBuffer(make([]byte, len(dst)+2)))
+	result := buffer.NewBufferWriter(bytes.NewBuffer(make([]byte, 0, len(dst)+3)))
 	result.Write(dst)
 	result.PutUint16(uint16(l))
+	result.Write([]byte{byte(CodecPlain)})
 	return result.Bytes(), nil
 }
 
@@ -184,6 +185,12 @@ func (t *plainDecoder) Len() int {
 }
 
 func (t *plainDecoder) Decode(_, rawData []byte) (err error) {
+	if rawData, err = unmark(rawData, CodecPlain); err != nil {
+		return err
+	}
+	if len(rawData) < 2 {
+		return ErrInvalidValue
+	}
 	var data []byte
 	size := binary.LittleEndian.Uint16(rawData[len(rawData)-2:])
 	if data, err = zstdDecoder.DecodeAll(rawData[:len(rawData)-2], make([]byte, 0, size)); err != nil {
@@ -0,0 +1,60 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package encoding
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlainDecoder_UnsupportedEncoding(t *testing.T) {
+	tester := require.New(t)
+	encoderPool := NewPlainEncoderPool(1024)
+	decoderPool := NewPlainDecoderPool(1024)
+	encoder := encoderPool.Get(nil)
+	defer encoderPool.Put(encoder)
+	// the data points are appended from the latest to the earliest
+	encoder.Append(uint64(200), []byte("bar"))
+	encoder.Append(uint64(100), []byte("foo"))
+	data, err := encoder.Encode()
+	tester.NoError(err)
+
+	decoder := decoderPool.Get(nil)
+	defer decoderPool.Put(decoder)
+	tester.NoError(decoder.Decode(nil, data))
+	tester.Equal(2, decoder.Len())
+	val, err := decoder.Get(uint64(200))
+	tester.NoError(err)
+	tester.Equal([]byte("bar"), val)
+
+	// a block written by a future codec
+	future := append([]byte{}, data...)
+	future[len(future)-1] = 0x7f
+	err = decoder.Decode(nil, future)
+	tester.True(errors.Is(err, ErrUnsupportedEncoding))
+	tester.Contains(err.Error(), "unknown(0x7f)")
+
+	future[len(future)-1] = byte(CodecInt)
+	err = decoder.Decode(nil, future)
+	tester.True(errors.Is(err, ErrUnsupportedEncoding))
+	tester.Contains(err.Error(), "codec int")
+
+	tester.True(errors.Is(decoder.Decode(nil, nil), ErrUnsupportedEncoding))
+}
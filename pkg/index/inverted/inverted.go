@@ -19,6 +19,7 @@ package inverted
 
 import (
 	"bytes"
+	"sort"
 	"sync"
 
 	"github.com/pkg/errors"
@@ -69,7 +70,7 @@ func NewStore(opts StoreOpts) (index.Store, error) {
 }
 
 func (s *store) Close() error {
-	return s.diskTable.Close()
+	return multierr.Combine(s.diskTable.Close(), s.termMetadata.Close())
 }
 
 func (s *store) Write(field index.Field, chunkID common.ItemID) error {
@@ -92,6 +93,113 @@ func (s *store) Flush() error {
 	return nil
 }
 
+// Merge writes the posting lists of the closed stores at srcs into a new store at dst, which unions the lists
+// of a term into one. The stores share the ids of the terms, which are hashed from the terms.
+func Merge(dst StoreOpts, srcs ...StoreOpts) error {
+	tmdPaths := make([]string, 0, len(srcs))
+	for _, src := range srcs {
+		tmdPaths = append(tmdPaths, src.Path+"/tmd")
+	}
+	if err := kv.MergeStores(tmdPaths, dst.Path+"/tmd", kv.StoreWithNamedLogger("term_metadata", dst.Logger)); err != nil {
+		return err
+	}
+	lists := make(map[string]posting.List)
+	for _, src := range srcs {
+		if err := readTable(src, lists); err != nil {
+			return err
+		}
+	}
+	// the keys are handed over as they are, so the terms don't have to be decoded
+	keys := make([]string, 0, len(lists))
+	for k := range lists {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	diskTable, err := kv.OpenIndexStore(0, dst.Path+"/table", kv.IndexWithLogger(dst.Logger))
+	if err != nil {
+		return err
+	}
+	return multierr.Append(diskTable.Handover(&listIterator{keys: keys, lists: lists}), diskTable.Close())
+}
+
+var _ kv.Iterator = (*listIterator)(nil)
+
+// listIterator hands the posting lists over in the order of keys
+type listIterator struct {
+	keys  []string
+	lists map[string]posting.List
+	idx   int
+	value []byte
+	err   error
+}
+
+func (i *listIterator) Next() {
+	i.idx++
+	i.setCurr()
+}
+
+func (i *listIterator) Rewind() {
+	i.idx = 0
+	i.setCurr()
+}
+
+func (i *listIterator) Seek(_ []byte) {
+	panic("unsupported")
+}
+
+func (i *listIterator) Key() []byte {
+	return []byte(i.keys[i.idx])
+}
+
+func (i *listIterator) Val() []byte {
+	return i.value
+}
+
+func (i *listIterator) Valid() bool {
+	return i.err == nil && i.idx < len(i.keys)
+}
+
+func (i *listIterator) Close() error {
+	return i.err
+}
+
+func (i *listIterator) setCurr() {
+	if i.idx >= len(i.keys) {
+		return
+	}
+	i.value, i.err = i.lists[i.keys[i.idx]].Marshall()
+}
+
+// readTable unions the posting lists in the disk table of the store at src into lists
+func readTable(src StoreOpts, lists map[string]posting.List) (err error) {
+	diskTable, err := kv.OpenIndexStore(0, src.Path+"/table", kv.IndexWithLogger(src.Logger))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = multierr.Append(err, diskTable.Close())
+	}()
+	iter := diskTable.NewIterator(kv.ScanOpts{})
+	defer func() {
+		err = multierr.Append(err, iter.Close())
+	}()
+	for iter.Rewind(); iter.Valid(); iter.Next() {
+		list := roaring.NewPostingList()
+		if err = list.Unmarshall(iter.Val()); err != nil {
+			return err
+		}
+		key := string(iter.Key())
+		if existing, ok := lists[key]; ok {
+			if err = existing.Union(list); err != nil {
+				return err
+			}
+			continue
+		}
+		lists[key] = list
+	}
+	return nil
+}
+
 func (s *store) MatchField(fieldKey index.FieldKey) (posting.List, error) {
 	return s.Range(fieldKey, index.RangeOpts{})
 }
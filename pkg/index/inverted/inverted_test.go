@@ -133,6 +133,32 @@ func TestStore_Iterator_Hybrid(t *testing.T) {
 	testcases.RunDuration(t, data, s)
 }
 
+func TestStore_Merge(t *testing.T) {
+	tester := assert.New(t)
+	path, fn := setUp(require.New(t))
+	defer fn()
+	srcs := []StoreOpts{
+		{Path: path + "/a", Logger: logger.GetLogger("test")},
+		{Path: path + "/b", Logger: logger.GetLogger("test")},
+	}
+	// the items of gateway are split into both stores
+	for i, r := range [][2]int{{0, 30}, {30, 100}} {
+		s, err := NewStore(srcs[i])
+		tester.NoError(err)
+		testcases.SetUpRange(tester, s, r[0], r[1])
+		tester.NoError(s.(*store).Flush())
+		tester.NoError(s.Close())
+	}
+	dst := StoreOpts{Path: path + "/merged", Logger: logger.GetLogger("test")}
+	tester.NoError(Merge(dst, srcs...))
+	s, err := NewStore(dst)
+	tester.NoError(err)
+	defer func() {
+		tester.NoError(s.Close())
+	}()
+	testcases.RunServiceName(t, s)
+}
+
 func setUp(t *require.Assertions) (tempDir string, deferFunc func()) {
 	t.NoError(logger.Init(logger.Logging{
 		Env:   "dev",
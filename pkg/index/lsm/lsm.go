@@ -18,6 +18,8 @@
 package lsm
 
 import (
+	"go.uber.org/multierr"
+
 	"github.com/apache/skywalking-banyandb/api/common"
 	"github.com/apache/skywalking-banyandb/banyand/kv"
 	"github.com/apache/skywalking-banyandb/pkg/convert"
@@ -34,7 +36,7 @@ type store struct {
 }
 
 func (s *store) Close() error {
-	return s.lsm.Close()
+	return multierr.Combine(s.lsm.Close(), s.termMetadata.Close())
 }
 
 func (s *store) Write(field index.Field, itemID common.ItemID) error {
@@ -69,3 +71,18 @@ func NewStore(opts StoreOpts) (index.Store, error) {
 		termMetadata: md,
 	}, nil
 }
+
+// Merge copies the fields of the closed stores at srcs to a new store at dst.
+// The stores share the ids of the terms, which are hashed from the terms.
+func Merge(dst StoreOpts, srcs ...StoreOpts) error {
+	lsmPaths := make([]string, 0, len(srcs))
+	tmdPaths := make([]string, 0, len(srcs))
+	for _, src := range srcs {
+		lsmPaths = append(lsmPaths, src.Path+"/lsm")
+		tmdPaths = append(tmdPaths, src.Path+"/tmd")
+	}
+	if err := kv.MergeStores(lsmPaths, dst.Path+"/lsm", kv.StoreWithLogger(dst.Logger)); err != nil {
+		return err
+	}
+	return kv.MergeStores(tmdPaths, dst.Path+"/tmd", kv.StoreWithNamedLogger("term_metadata", dst.Logger))
+}
@@ -18,6 +18,8 @@
 package metadata
 
 import (
+	"io"
+
 	"github.com/pkg/errors"
 
 	"github.com/apache/skywalking-banyandb/banyand/kv"
@@ -26,6 +28,7 @@ import (
 )
 
 type Term interface {
+	io.Closer
 	ID(term []byte) (id []byte, err error)
 	Literal(id []byte) (term []byte, err error)
 }
@@ -64,3 +67,7 @@ func (t *term) ID(term []byte) (id []byte, err error) {
 func (t *term) Literal(id []byte) (term []byte, err error) {
 	return t.store.Get(id)
 }
+
+func (t *term) Close() error {
+	return t.store.Close()
+}
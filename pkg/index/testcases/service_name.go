@@ -86,7 +86,12 @@ func RunServiceName(t *testing.T, store SimpleStore) {
 }
 
 func SetUp(t *assert.Assertions, store SimpleStore) {
-	for i := 0; i < 100; i++ {
+	SetUpRange(t, store, 0, 100)
+}
+
+// SetUpRange writes the items in [start, end) of the ones written by SetUp
+func SetUpRange(t *assert.Assertions, store SimpleStore, start, end int) {
+	for i := start; i < end; i++ {
 		if i < 100/2 {
 			t.NoError(store.Write(index.Field{
 				Key:  serviceName,
@@ -87,17 +87,19 @@ func BuildTree(searcher Searcher, condMap Condition) (Tree, error) {
 				root.addEq(key, cond.Values)
 			case modelv1.Condition_BINARY_OP_NE:
 				root.addNot(key, root.newEq(key, cond.Values))
-			case modelv1.Condition_BINARY_OP_HAVING:
+			case modelv1.Condition_BINARY_OP_HAVING, modelv1.Condition_BINARY_OP_IN:
 				n := root.addOrNode(len(cond.Values))
 				for _, v := range cond.Values {
 					n.addEq(key, [][]byte{v})
 				}
-			case modelv1.Condition_BINARY_OP_NOT_HAVING:
+			case modelv1.Condition_BINARY_OP_NOT_HAVING, modelv1.Condition_BINARY_OP_NOT_IN:
 				n := root.newOrNode(len(cond.Values))
 				for _, v := range cond.Values {
 					n.addEq(key, [][]byte{v})
 				}
 				root.addNot(key, n)
+			case modelv1.Condition_BINARY_OP_EXISTS:
+				root.addExists(key)
 			}
 		}
 	}
@@ -140,6 +142,15 @@ func (n *node) addEq(key FieldKey, values [][]byte) {
 	n.SubNodes = append(n.SubNodes, n.newEq(key, values))
 }
 
+func (n *node) addExists(key FieldKey) {
+	n.SubNodes = append(n.SubNodes, &exists{
+		leaf: &leaf{
+			Key:      key,
+			searcher: n.searcher,
+		},
+	})
+}
+
 func (n *node) addNot(key FieldKey, inner Executor) {
 	n.SubNodes = append(n.SubNodes, &not{
 		Key:      key,
@@ -313,6 +324,21 @@ func (eq *eq) MarshalJSON() ([]byte, error) {
 	return json.Marshal(data)
 }
 
+// exists matches all items having the field
+type exists struct {
+	*leaf
+}
+
+func (e *exists) Execute() (posting.List, error) {
+	return e.searcher.MatchField(e.Key)
+}
+
+func (e *exists) MarshalJSON() ([]byte, error) {
+	data := make(map[string]interface{}, 1)
+	data["exists"] = e.leaf
+	return json.Marshal(data)
+}
+
 type rangeOp struct {
 	*leaf
 	Opts *RangeOpts
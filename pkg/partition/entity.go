@@ -29,8 +29,33 @@ import (
 
 var (
 	ErrMalformedElement = errors.New("element is malformed")
+
+	// The reasons why Find fails, all of which are ErrMalformedElement
+	ErrInvalidFamilyOffset = errors.WithMessage(ErrMalformedElement, "tag family offset is invalid")
+	ErrInvalidTagOffset    = errors.WithMessage(ErrMalformedElement, "tag offset is invalid")
+	ErrMarshalEntity       = errors.WithMessage(ErrMalformedElement, "failed to marshal the entity")
+	// ErrEmptyEntity means no entity tag is resolved, which would route every element to a single shard
+	ErrEmptyEntity = errors.WithMessage(ErrMalformedElement, "none of the entity tags is resolved")
+
+	// ErrInvalidEntityLocator means the entity of a schema can't identify its series
+	ErrInvalidEntityLocator = errors.New("entity locator is invalid")
 )
 
+// FailureReason names the reason of an error returned by Find, which is used to label metrics
+func FailureReason(err error) string {
+	switch {
+	case errors.Is(err, ErrInvalidFamilyOffset):
+		return "bad_family_offset"
+	case errors.Is(err, ErrInvalidTagOffset):
+		return "bad_tag_offset"
+	case errors.Is(err, ErrMarshalEntity):
+		return "marshal_error"
+	case errors.Is(err, ErrEmptyEntity):
+		return "empty_entity"
+	}
+	return "unknown"
+}
+
 type EntityLocator []TagLocator
 
 type TagLocator struct {
@@ -38,18 +63,45 @@ type TagLocator struct {
 	TagOffset    int
 }
 
-func NewEntityLocator(families []*databasev1.TagFamilySpec, entity *databasev1.Entity) EntityLocator {
+// NewEntityLocator locates the tags of the entity in the order of their names, which composes the series identity.
+// Every tag should be found in the families, otherwise a shorter locator would identify other series.
+func NewEntityLocator(families []*databasev1.TagFamilySpec, entity *databasev1.Entity) (EntityLocator, error) {
 	locator := make(EntityLocator, 0, len(entity.GetTagNames()))
 	for _, tagInEntity := range entity.GetTagNames() {
 		fIndex, tIndex, tag := pbv1.FindTagByName(families, tagInEntity)
-		if tag != nil {
-			locator = append(locator, TagLocator{FamilyOffset: fIndex, TagOffset: tIndex})
+		if tag == nil {
+			return nil, errors.WithMessagef(ErrInvalidEntityLocator, "the entity tag %s is absent", tagInEntity)
+		}
+		locator = append(locator, TagLocator{FamilyOffset: fIndex, TagOffset: tIndex})
+	}
+	if err := locator.Validate(); err != nil {
+		return nil, err
+	}
+	return locator, nil
+}
+
+// Validate checks the locator has at least one tag, and none of its tags is located twice
+func (e EntityLocator) Validate() error {
+	if len(e) == 0 {
+		return errors.WithMessage(ErrInvalidEntityLocator, "no entity tag")
+	}
+	located := make(map[TagLocator]int, len(e))
+	for i, l := range e {
+		if l.FamilyOffset < 0 || l.TagOffset < 0 {
+			return errors.WithMessagef(ErrInvalidEntityLocator, "entry %d has a negative offset", i)
 		}
+		if j, ok := located[l]; ok {
+			return errors.WithMessagef(ErrInvalidEntityLocator, "entry %d duplicates entry %d", i, j)
+		}
+		located[l] = i
 	}
-	return locator
+	return nil
 }
 
 func (e EntityLocator) Find(value []*modelv1.TagFamilyForWrite) (tsdb.Entity, error) {
+	if len(e) == 0 {
+		return nil, errors.WithStack(ErrEmptyEntity)
+	}
 	entity := make(tsdb.Entity, len(e))
 	for i, index := range e {
 		tag, err := GetTagByOffset(value, index.FamilyOffset, index.TagOffset)
@@ -58,32 +110,39 @@ func (e EntityLocator) Find(value []*modelv1.TagFamilyForWrite) (tsdb.Entity, er
 		}
 		entry, errMarshal := pbv1.MarshalIndexFieldValue(tag)
 		if errMarshal != nil {
-			return nil, errMarshal
+			return nil, errors.WithMessagef(ErrMarshalEntity, "entry %d: %v", i, errMarshal)
+		}
+		// an empty binary can't identify an entity, and its hash is shared by every element missing the tag
+		if _, ok := tag.GetValue().(*modelv1.TagValue_BinaryData); ok && len(entry) == 0 {
+			return nil, errors.WithMessagef(ErrMarshalEntity, "entry %d: the binary data is empty", i)
 		}
 		entity[i] = entry
 	}
 	return entity, nil
 }
 
-func (e EntityLocator) Locate(value []*modelv1.TagFamilyForWrite, shardNum uint32) (tsdb.Entity, common.ShardID, error) {
+// Locate finds the entity of an element and its shard, which is looked up in overrides before routing the entity
+// by the strategy. A nil overrides routes all the entities by the strategy.
+func (e EntityLocator) Locate(value []*modelv1.TagFamilyForWrite, shardNum uint32,
+	overrides ShardOverrides, strategy ShardingStrategy) (tsdb.Entity, common.ShardID, error) {
 	entity, err := e.Find(value)
 	if err != nil {
 		return nil, 0, err
 	}
-	id, err := ShardID(entity.Marshal(), shardNum)
+	id, err := overrides.ShardID(entity, shardNum, strategy)
 	if err != nil {
 		return nil, 0, err
 	}
-	return entity, common.ShardID(id), nil
+	return entity, id, nil
 }
 
 func GetTagByOffset(value []*modelv1.TagFamilyForWrite, fIndex, tIndex int) (*modelv1.TagValue, error) {
 	if fIndex >= len(value) {
-		return nil, errors.Wrap(ErrMalformedElement, "tag family offset is invalid")
+		return nil, errors.WithStack(ErrInvalidFamilyOffset)
 	}
 	family := value[fIndex]
 	if tIndex >= len(family.GetTags()) {
-		return nil, errors.Wrap(ErrMalformedElement, "tag offset is invalid")
+		return nil, errors.WithStack(ErrInvalidTagOffset)
 	}
 	return family.GetTags()[tIndex], nil
 }
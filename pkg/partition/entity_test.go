@@ -0,0 +1,179 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package partition
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	databasev1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/database/v1"
+	modelv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/model/v1"
+	"github.com/apache/skywalking-banyandb/banyand/tsdb"
+	"github.com/apache/skywalking-banyandb/pkg/convert"
+	pbv1 "github.com/apache/skywalking-banyandb/pkg/pb/v1"
+)
+
+func TestEntityLocator_Locate(t *testing.T) {
+	families := []*databasev1.TagFamilySpec{
+		{
+			Name: "searchable",
+			Tags: []*databasev1.TagSpec{
+				{Name: "service_id", Type: databasev1.TagType_TAG_TYPE_STRING},
+				{Name: "instance_id", Type: databasev1.TagType_TAG_TYPE_STRING},
+			},
+		},
+	}
+	value := []*modelv1.TagFamilyForWrite{
+		{
+			Tags: []*modelv1.TagValue{
+				{Value: &modelv1.TagValue_Str{Str: &modelv1.Str{Value: "webapp"}}},
+				{Value: &modelv1.TagValue_Str{Str: &modelv1.Str{Value: "10.0.0.1"}}},
+			},
+		},
+	}
+	tests := []struct {
+		name     string
+		tagNames []string
+		wantErr  error
+	}{
+		{
+			name:     "resolved",
+			tagNames: []string{"service_id", "instance_id"},
+		},
+		{
+			name:     "reordered",
+			tagNames: []string{"instance_id", "service_id"},
+		},
+		{
+			name:     "partially resolved",
+			tagNames: []string{"service_id", "endpoint_id"},
+			wantErr:  ErrInvalidEntityLocator,
+		},
+		{
+			name:     "all unresolved",
+			tagNames: []string{"endpoint_id", "trace_id"},
+			wantErr:  ErrInvalidEntityLocator,
+		},
+		{
+			name:     "duplicated",
+			tagNames: []string{"service_id", "service_id"},
+			wantErr:  ErrInvalidEntityLocator,
+		},
+		{
+			name:    "no entity tag",
+			wantErr: ErrInvalidEntityLocator,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			locator, err := NewEntityLocator(families, &databasev1.Entity{TagNames: tt.tagNames})
+			if tt.wantErr != nil {
+				require.Error(t, err)
+				assert.True(t, errors.Is(err, tt.wantErr))
+				assert.Nil(t, locator)
+				return
+			}
+			require.NoError(t, err)
+			require.NoError(t, locator.Validate())
+			entity, shardID, err := locator.Locate(value, 16, nil, ModuloStrategy{})
+			require.NoError(t, err)
+			// the entries follow the order of the entity tags
+			require.Len(t, entity, len(tt.tagNames))
+			for i, name := range tt.tagNames {
+				_, tIndex, _ := pbv1.FindTagByName(families, name)
+				want, errMarshal := pbv1.MarshalIndexFieldValue(value[0].GetTags()[tIndex])
+				require.NoError(t, errMarshal)
+				assert.Equal(t, tsdb.Entry(want), entity[i])
+			}
+			assert.Less(t, uint32(shardID), uint32(16))
+		})
+	}
+
+	// the locator received from another node might be empty
+	entity, shardID, err := EntityLocator{}.Locate(value, 16, nil, ModuloStrategy{})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrEmptyEntity))
+	assert.True(t, errors.Is(err, ErrMalformedElement))
+	assert.Equal(t, "empty_entity", FailureReason(err))
+	assert.Nil(t, entity)
+	assert.Zero(t, shardID)
+	assert.True(t, errors.Is(EntityLocator{{FamilyOffset: -1}}.Validate(), ErrInvalidEntityLocator))
+}
+
+func TestEntityLocator_BinaryTag(t *testing.T) {
+	families := []*databasev1.TagFamilySpec{
+		{
+			Name: "searchable",
+			Tags: []*databasev1.TagSpec{
+				{Name: "trace_id", Type: databasev1.TagType_TAG_TYPE_DATA_BINARY},
+				{Name: "span_id", Type: databasev1.TagType_TAG_TYPE_DATA_BINARY},
+			},
+		},
+	}
+	locator, err := NewEntityLocator(families, &databasev1.Entity{TagNames: []string{"trace_id", "span_id"}})
+	require.NoError(t, err)
+	newValue := func(traceID, spanID []byte) []*modelv1.TagFamilyForWrite {
+		return []*modelv1.TagFamilyForWrite{
+			{
+				Tags: []*modelv1.TagValue{
+					{Value: &modelv1.TagValue_BinaryData{BinaryData: traceID}},
+					{Value: &modelv1.TagValue_BinaryData{BinaryData: spanID}},
+				},
+			},
+		}
+	}
+	values := [][]*modelv1.TagFamilyForWrite{
+		newValue([]byte{0x01, 0x02}, []byte{0x03}),
+		// the same bytes split at another position
+		newValue([]byte{0x01}, []byte{0x02, 0x03}),
+		newValue([]byte{0x00, 0x01, 0x02}, []byte{0x03}),
+		newValue([]byte{0x01, 0x02, 0x00}, []byte{0x03}),
+		newValue([]byte{0xff, 0xfe}, []byte{0x00}),
+	}
+	seriesIDs := make(map[uint64]int)
+	for i, v := range values {
+		entity, shardID, err := locator.Locate(v, 16, nil, ModuloStrategy{})
+		require.NoError(t, err)
+		seriesID := convert.Hash(tsdb.HashEntity(entity))
+		require.NotContains(t, seriesIDs, seriesID, "value %d collides with value %d", i, seriesIDs[seriesID])
+		seriesIDs[seriesID] = i
+
+		// the entity is detached from the request whose buffer might be reused
+		want := entity.Marshal()
+		for _, tag := range v[0].GetTags() {
+			for j := range tag.GetBinaryData() {
+				tag.GetBinaryData()[j] = 0xaa
+			}
+		}
+		assert.Equal(t, want, entity.Marshal())
+
+		// locating the same values again routes to the same shard and series
+		v = newValue(entity[0], entity[1])
+		entityAgain, shardIDAgain, err := locator.Locate(v, 16, nil, ModuloStrategy{})
+		require.NoError(t, err)
+		assert.Equal(t, shardID, shardIDAgain)
+		assert.Equal(t, seriesID, convert.Hash(tsdb.HashEntity(entityAgain)))
+	}
+
+	_, _, err = locator.Locate(newValue(nil, []byte{0x01}), 16, nil, ModuloStrategy{})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrMarshalEntity))
+}
@@ -0,0 +1,79 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package partition
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/apache/skywalking-banyandb/api/common"
+	databasev1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/database/v1"
+	"github.com/apache/skywalking-banyandb/banyand/tsdb"
+	pbv1 "github.com/apache/skywalking-banyandb/pkg/pb/v1"
+)
+
+var ErrInvalidShardOverride = errors.New("invalid shard override")
+
+// ShardOverrides routes the hot entities to explicit shards instead of the hashed ones.
+// The keys are the marshaled entities.
+type ShardOverrides map[string]common.ShardID
+
+// ParseShardOverrides marshals the entities of the overrides, each of which should have tagNum tags
+func ParseShardOverrides(tagNum int, overrides []*databasev1.ShardOverride) (ShardOverrides, error) {
+	if len(overrides) == 0 {
+		return nil, nil
+	}
+	result := make(ShardOverrides, len(overrides))
+	for i, o := range overrides {
+		if len(o.GetEntity()) != tagNum {
+			return nil, errors.WithMessagef(ErrInvalidShardOverride, "override %d has %d entity tags, want %d",
+				i, len(o.GetEntity()), tagNum)
+		}
+		en := make(tsdb.Entity, tagNum)
+		for j, tag := range o.GetEntity() {
+			entry, err := pbv1.MarshalIndexFieldValue(tag)
+			if err != nil {
+				return nil, errors.WithMessagef(ErrInvalidShardOverride, "override %d entry %d: %v", i, j, err)
+			}
+			en[j] = entry
+		}
+		key := string(en.Marshal())
+		if id, ok := result[key]; ok && id != common.ShardID(o.GetShardId()) {
+			return nil, errors.WithMessagef(ErrInvalidShardOverride, "override %d conflicts with shard %d", i, id)
+		}
+		result[key] = common.ShardID(o.GetShardId())
+	}
+	return result, nil
+}
+
+// Validate checks every override references an existing shard
+func (o ShardOverrides) Validate(shardNum uint32) error {
+	for _, id := range o {
+		if uint32(id) >= shardNum {
+			return errors.WithMessagef(ErrInvalidShardOverride, "shard %d is out of %d shards", id, shardNum)
+		}
+	}
+	return nil
+}
+
+// ShardID returns the overridden shard of the entity, or routes the entity by the strategy if there's no override
+func (o ShardOverrides) ShardID(entity tsdb.Entity, shardNum uint32, strategy ShardingStrategy) (common.ShardID, error) {
+	if id, ok := o[string(entity.Marshal())]; ok {
+		return id, nil
+	}
+	return strategy.Shard(entity, shardNum)
+}
@@ -0,0 +1,95 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package partition
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	databasev1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/database/v1"
+	modelv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/model/v1"
+)
+
+func TestShardOverrides(t *testing.T) {
+	families := []*databasev1.TagFamilySpec{
+		{
+			Name: "searchable",
+			Tags: []*databasev1.TagSpec{
+				{Name: "service_id", Type: databasev1.TagType_TAG_TYPE_STRING},
+				{Name: "instance_id", Type: databasev1.TagType_TAG_TYPE_STRING},
+			},
+		},
+	}
+	entity := &databasev1.Entity{TagNames: []string{"service_id", "instance_id"}}
+	locator, err := NewEntityLocator(families, entity)
+	require.NoError(t, err)
+	str := func(v string) *modelv1.TagValue {
+		return &modelv1.TagValue{Value: &modelv1.TagValue_Str{Str: &modelv1.Str{Value: v}}}
+	}
+	newValue := func(service, instance string) []*modelv1.TagFamilyForWrite {
+		return []*modelv1.TagFamilyForWrite{{Tags: []*modelv1.TagValue{str(service), str(instance)}}}
+	}
+	const shardNum = 16
+
+	_, hotShardID, err := locator.Locate(newValue("hot", "10.0.0.1"), shardNum, nil, ModuloStrategy{})
+	require.NoError(t, err)
+	designated := (hotShardID + 1) % shardNum
+	overrides, err := ParseShardOverrides(len(entity.GetTagNames()), []*databasev1.ShardOverride{
+		{Entity: []*modelv1.TagValue{str("hot"), str("10.0.0.1")}, ShardId: uint32(designated)},
+	})
+	require.NoError(t, err)
+	require.NoError(t, overrides.Validate(shardNum))
+
+	_, shardID, err := locator.Locate(newValue("hot", "10.0.0.1"), shardNum, overrides, ModuloStrategy{})
+	require.NoError(t, err)
+	assert.Equal(t, designated, shardID)
+	for _, instance := range []string{"10.0.0.2", "10.0.0.3", "10.0.0.4"} {
+		_, want, errLocate := locator.Locate(newValue("hot", instance), shardNum, nil, ModuloStrategy{})
+		require.NoError(t, errLocate)
+		_, got, errLocate := locator.Locate(newValue("hot", instance), shardNum, overrides, ModuloStrategy{})
+		require.NoError(t, errLocate)
+		assert.Equal(t, want, got)
+	}
+
+	assert.True(t, errors.Is(overrides.Validate(uint32(designated)), ErrInvalidShardOverride))
+	tests := []struct {
+		name      string
+		overrides []*databasev1.ShardOverride
+	}{
+		{
+			name:      "missing entity tags",
+			overrides: []*databasev1.ShardOverride{{Entity: []*modelv1.TagValue{str("hot")}}},
+		},
+		{
+			name: "conflicting shards",
+			overrides: []*databasev1.ShardOverride{
+				{Entity: []*modelv1.TagValue{str("hot"), str("10.0.0.1")}, ShardId: 1},
+				{Entity: []*modelv1.TagValue{str("hot"), str("10.0.0.1")}, ShardId: 2},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseShardOverrides(len(entity.GetTagNames()), tt.overrides)
+			assert.True(t, errors.Is(err, ErrInvalidShardOverride))
+		})
+	}
+}
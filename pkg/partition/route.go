@@ -30,3 +30,23 @@ func ShardID(key []byte, shardNum uint32) (uint, error) {
 	encodeKey := convert.Hash(key)
 	return uint(encodeKey % uint64(shardNum)), nil
 }
+
+// ConsistentShardID hashes the key by the jump consistent hash, so that growing the shards
+// from N to M only moves (M-N)/M of the keys, all of which move to the new shards.
+func ConsistentShardID(key []byte, shardNum uint32) (uint, error) {
+	if shardNum < 1 {
+		return 0, errors.New("invalid shardNum")
+	}
+	return uint(jumpHash(convert.Hash(key), shardNum)), nil
+}
+
+// jumpHash is the algorithm described in "A Fast, Minimal Memory, Consistent Hash Algorithm" by Lamping and Veach
+func jumpHash(key uint64, buckets uint32) uint32 {
+	var b, j int64 = -1, 0
+	for j < int64(buckets) {
+		b = j
+		key = key*2862933555777941757 + 1
+		j = int64(float64(b+1) * (float64(int64(1)<<31) / float64((key>>33)+1)))
+	}
+	return uint32(b)
+}
@@ -0,0 +1,116 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package partition
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	databasev1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/database/v1"
+	"github.com/apache/skywalking-banyandb/banyand/tsdb"
+)
+
+const keyNum = 100000
+
+func newKeys(n int) [][]byte {
+	keys := make([][]byte, n)
+	for i := range keys {
+		keys[i] = []byte(fmt.Sprintf("service_%d|instance_%d", i%100, i))
+	}
+	return keys
+}
+
+// movedRatio returns the ratio of the keys routed to another shard once the shards grow from oldNum to newNum
+func movedRatio(keys [][]byte, oldNum, newNum uint32, algorithm databasev1.ShardingAlgorithm) (float64, error) {
+	strategy, err := NewShardingStrategy(algorithm)
+	if err != nil {
+		return 0, err
+	}
+	var moved int
+	for _, k := range keys {
+		before, err := strategy.Shard(tsdb.Entity{k}, oldNum)
+		if err != nil {
+			return 0, err
+		}
+		after, err := strategy.Shard(tsdb.Entity{k}, newNum)
+		if err != nil {
+			return 0, err
+		}
+		if before != after {
+			moved++
+		}
+	}
+	return float64(moved) / float64(len(keys)), nil
+}
+
+func TestConsistentShardID(t *testing.T) {
+	keys := newKeys(keyNum)
+	counts := make([]int, 8)
+	for _, k := range keys {
+		before, err := ConsistentShardID(k, 4)
+		require.NoError(t, err)
+		after, err := ConsistentShardID(k, 8)
+		require.NoError(t, err)
+		// a key either stays or moves to a new shard
+		if before != after {
+			assert.GreaterOrEqual(t, after, uint(4))
+		}
+		counts[after]++
+	}
+	for i, c := range counts {
+		assert.InDeltaf(t, keyNum/8, c, keyNum/80, "shard %d is unbalanced", i)
+	}
+	ratio, err := movedRatio(keys, 4, 8, databasev1.ShardingAlgorithm_SHARDING_ALGORITHM_CONSISTENT)
+	require.NoError(t, err)
+	assert.InDelta(t, 0.5, ratio, 0.02)
+	ratio, err = movedRatio(keys, 8, 9, databasev1.ShardingAlgorithm_SHARDING_ALGORITHM_CONSISTENT)
+	require.NoError(t, err)
+	assert.InDelta(t, 1.0/9, ratio, 0.02)
+	ratio, err = movedRatio(keys, 8, 9, databasev1.ShardingAlgorithm_SHARDING_ALGORITHM_MODULO)
+	require.NoError(t, err)
+	assert.Greater(t, ratio, 0.8)
+
+	_, err = ConsistentShardID(keys[0], 0)
+	assert.Error(t, err)
+}
+
+// BenchmarkShardMovement reports the ratio of the moved keys. Both algorithms move half of the keys once the shards
+// double, which is the least, but the modulo moves most of the keys if the shards grow by any other factor.
+func BenchmarkShardMovement(b *testing.B) {
+	keys := newKeys(keyNum)
+	for _, growth := range []struct{ oldNum, newNum uint32 }{{4, 8}, {4, 5}} {
+		for _, algorithm := range []databasev1.ShardingAlgorithm{
+			databasev1.ShardingAlgorithm_SHARDING_ALGORITHM_MODULO,
+			databasev1.ShardingAlgorithm_SHARDING_ALGORITHM_CONSISTENT,
+		} {
+			b.Run(fmt.Sprintf("%d-%d/%s", growth.oldNum, growth.newNum, algorithm), func(b *testing.B) {
+				var ratio float64
+				for i := 0; i < b.N; i++ {
+					var err error
+					if ratio, err = movedRatio(keys, growth.oldNum, growth.newNum, algorithm); err != nil {
+						b.Fatal(err)
+					}
+				}
+				b.ReportMetric(ratio, "moved/key")
+			})
+		}
+	}
+}
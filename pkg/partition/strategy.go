@@ -0,0 +1,91 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package partition
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/apache/skywalking-banyandb/api/common"
+	databasev1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/database/v1"
+	"github.com/apache/skywalking-banyandb/banyand/tsdb"
+)
+
+var ErrUnknownShardingAlgorithm = errors.New("unknown sharding algorithm")
+
+var (
+	_ ShardingStrategy = ModuloStrategy{}
+	_ ShardingStrategy = ConsistentStrategy{}
+	_ ShardingStrategy = PrefixStrategy{}
+)
+
+// ShardingStrategy routes an entity to one of the shards.
+// It receives the entries of the entity since the marshaled one loses their boundaries.
+type ShardingStrategy interface {
+	Shard(entity tsdb.Entity, shardNum uint32) (common.ShardID, error)
+}
+
+// NewShardingStrategy returns the strategy of the algorithm, which is selected by the sharding_algorithm
+// of a schema's ResourceOpts. An unspecified algorithm works as the modulo to keep the shards of the existing data.
+func NewShardingStrategy(algorithm databasev1.ShardingAlgorithm) (ShardingStrategy, error) {
+	switch algorithm {
+	case databasev1.ShardingAlgorithm_SHARDING_ALGORITHM_UNSPECIFIED, databasev1.ShardingAlgorithm_SHARDING_ALGORITHM_MODULO:
+		return ModuloStrategy{}, nil
+	case databasev1.ShardingAlgorithm_SHARDING_ALGORITHM_CONSISTENT:
+		return ConsistentStrategy{}, nil
+	case databasev1.ShardingAlgorithm_SHARDING_ALGORITHM_PREFIX:
+		return PrefixStrategy{}, nil
+	}
+	return nil, errors.WithMessagef(ErrUnknownShardingAlgorithm, "%d", algorithm)
+}
+
+// ModuloStrategy takes the hash of the whole entity modulo the shard number
+type ModuloStrategy struct{}
+
+func (ModuloStrategy) Shard(entity tsdb.Entity, shardNum uint32) (common.ShardID, error) {
+	id, err := ShardID(entity.Marshal(), shardNum)
+	if err != nil {
+		return 0, err
+	}
+	return common.ShardID(id), nil
+}
+
+// ConsistentStrategy hashes the whole entity by the jump consistent hash
+type ConsistentStrategy struct{}
+
+func (ConsistentStrategy) Shard(entity tsdb.Entity, shardNum uint32) (common.ShardID, error) {
+	id, err := ConsistentShardID(entity.Marshal(), shardNum)
+	if err != nil {
+		return 0, err
+	}
+	return common.ShardID(id), nil
+}
+
+// PrefixStrategy takes the hash of the first entry modulo the shard number, so that the entities
+// sharing the first tag are located in a single shard. The shard might be hot if the tag has few values.
+type PrefixStrategy struct{}
+
+func (PrefixStrategy) Shard(entity tsdb.Entity, shardNum uint32) (common.ShardID, error) {
+	if len(entity) == 0 {
+		return 0, errors.WithStack(ErrEmptyEntity)
+	}
+	id, err := ShardID(entity[0], shardNum)
+	if err != nil {
+		return 0, err
+	}
+	return common.ShardID(id), nil
+}
@@ -0,0 +1,84 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package partition
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/apache/skywalking-banyandb/api/common"
+	databasev1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/database/v1"
+	"github.com/apache/skywalking-banyandb/banyand/tsdb"
+)
+
+func TestNewShardingStrategy(t *testing.T) {
+	tests := []struct {
+		algorithm databasev1.ShardingAlgorithm
+		want      ShardingStrategy
+	}{
+		{algorithm: databasev1.ShardingAlgorithm_SHARDING_ALGORITHM_UNSPECIFIED, want: ModuloStrategy{}},
+		{algorithm: databasev1.ShardingAlgorithm_SHARDING_ALGORITHM_MODULO, want: ModuloStrategy{}},
+		{algorithm: databasev1.ShardingAlgorithm_SHARDING_ALGORITHM_CONSISTENT, want: ConsistentStrategy{}},
+		{algorithm: databasev1.ShardingAlgorithm_SHARDING_ALGORITHM_PREFIX, want: PrefixStrategy{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.algorithm.String(), func(t *testing.T) {
+			got, err := NewShardingStrategy(tt.algorithm)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+	_, err := NewShardingStrategy(databasev1.ShardingAlgorithm(-1))
+	assert.True(t, errors.Is(err, ErrUnknownShardingAlgorithm))
+}
+
+func TestShardingStrategy(t *testing.T) {
+	const shardNum = 16
+	newEntity := func(service, instance string) tsdb.Entity {
+		return tsdb.Entity{tsdb.Entry(service), tsdb.Entry(instance)}
+	}
+	// the modulo keeps the shards of the existing data
+	entity := newEntity("webapp", "10.0.0.1")
+	want, err := ShardID(entity.Marshal(), shardNum)
+	require.NoError(t, err)
+	got, err := ModuloStrategy{}.Shard(entity, shardNum)
+	require.NoError(t, err)
+	assert.Equal(t, common.ShardID(want), got)
+
+	// the prefix locates all the instances of a service in a single shard
+	for _, service := range []string{"webapp", "gateway", "storage"} {
+		shards := make(map[common.ShardID]struct{})
+		for i := 0; i < 100; i++ {
+			id, errShard := PrefixStrategy{}.Shard(newEntity(service, fmt.Sprintf("10.0.0.%d", i)), shardNum)
+			require.NoError(t, errShard)
+			shards[id] = struct{}{}
+		}
+		assert.Len(t, shards, 1)
+	}
+	_, err = PrefixStrategy{}.Shard(nil, shardNum)
+	assert.True(t, errors.Is(err, ErrEmptyEntity))
+
+	for _, strategy := range []ShardingStrategy{ModuloStrategy{}, ConsistentStrategy{}, PrefixStrategy{}} {
+		_, err = strategy.Shard(entity, 0)
+		assert.Error(t, err)
+	}
+}
@@ -18,8 +18,13 @@
 package v1
 
 import (
+	"time"
+
+	"github.com/pkg/errors"
+
 	databasev1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/database/v1"
 	modelv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/model/v1"
+	"github.com/apache/skywalking-banyandb/pkg/encoding"
 )
 
 func FindTagByName(families []*databasev1.TagFamilySpec, tagName string) (int, int, *databasev1.TagSpec) {
@@ -64,3 +69,40 @@ func FieldValueTypeConv(tagValue *modelv1.FieldValue) (tagType databasev1.FieldT
 	}
 	return databasev1.FieldType_FIELD_TYPE_UNSPECIFIED, false
 }
+
+// DurationOf converts a duration of the schema. The months are rejected since their lengths vary.
+func DurationOf(d *databasev1.Duration) (time.Duration, error) {
+	val := time.Duration(d.GetVal())
+	if val < 1 {
+		return 0, errors.Errorf("non-positive value %d", d.GetVal())
+	}
+	switch d.GetUnit() {
+	case databasev1.Duration_DURATION_UNIT_HOUR:
+		return val * time.Hour, nil
+	case databasev1.Duration_DURATION_UNIT_DAY:
+		return val * 24 * time.Hour, nil
+	case databasev1.Duration_DURATION_UNIT_WEEK:
+		return val * 7 * 24 * time.Hour, nil
+	}
+	return 0, errors.Errorf("unsupported unit %s", d.GetUnit())
+}
+
+// TTLOf converts the ttl of the opts, and an absent one is zero which keeps the data forever
+func TTLOf(opts *databasev1.ResourceOpts) (time.Duration, error) {
+	if opts.GetTtl() == nil {
+		return 0, nil
+	}
+	ttl, err := DurationOf(opts.GetTtl())
+	return ttl, errors.WithMessage(err, "invalid ttl")
+}
+
+// CodecOf converts the encoding method of the opts, and an absent one is the plain codec
+func CodecOf(opts *databasev1.ResourceOpts) (encoding.Codec, error) {
+	switch opts.GetEncodingMethod() {
+	case databasev1.EncodingMethod_ENCODING_METHOD_UNSPECIFIED, databasev1.EncodingMethod_ENCODING_METHOD_PLAIN:
+		return encoding.CodecPlain, nil
+	case databasev1.EncodingMethod_ENCODING_METHOD_GORILLA:
+		return encoding.CodecGorilla, nil
+	}
+	return 0, errors.Errorf("unsupported encoding method %s", opts.GetEncodingMethod())
+}
@@ -38,6 +38,11 @@ var (
 		"<=":         modelv1.Condition_BINARY_OP_LE,
 		"having":     modelv1.Condition_BINARY_OP_HAVING,
 		"not having": modelv1.Condition_BINARY_OP_NOT_HAVING,
+		"in":         modelv1.Condition_BINARY_OP_IN,
+		"not in":     modelv1.Condition_BINARY_OP_NOT_IN,
+		"match":      modelv1.Condition_BINARY_OP_MATCH,
+		"exists":     modelv1.Condition_BINARY_OP_EXISTS,
+		"missing":    modelv1.Condition_BINARY_OP_MISSING,
 	}
 )
 
@@ -96,6 +101,10 @@ func (b *QueryRequestBuilder) FieldsInTagFamily(tagFamilyName string, items ...i
 
 func buildTagValue(value interface{}) *modelv1.TagValue {
 	switch v := value.(type) {
+	case nil:
+		return &modelv1.TagValue{
+			Value: &modelv1.TagValue_Null{},
+		}
 	case int:
 		return &modelv1.TagValue{
 			Value: &modelv1.TagValue_Int{Int: &modelv1.Int{Value: int64(v)}},
@@ -50,7 +50,8 @@ func MarshalIndexFieldValue(tagValue *modelv1.TagValue) ([]byte, error) {
 		}
 		return buf.Bytes(), nil
 	case *modelv1.TagValue_BinaryData:
-		return x.BinaryData, nil
+		// copy the data to detach it from the request, and an absent one is marshaled as an empty value
+		return append(make([]byte, 0, len(x.BinaryData)), x.BinaryData...), nil
 	}
 	return nil, ErrUnsupportedTagForIndexField
 }
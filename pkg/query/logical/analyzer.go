@@ -18,14 +18,17 @@
 package logical
 
 import (
+	"bytes"
 	"context"
 
 	"github.com/pkg/errors"
 
 	commonv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/common/v1"
+	databasev1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/database/v1"
 	modelv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/model/v1"
 	streamv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/stream/v1"
 	"github.com/apache/skywalking-banyandb/banyand/metadata"
+	metaschema "github.com/apache/skywalking-banyandb/banyand/metadata/schema"
 	"github.com/apache/skywalking-banyandb/banyand/tsdb"
 	"github.com/apache/skywalking-banyandb/pkg/convert"
 )
@@ -36,6 +39,7 @@ var (
 	ErrIncompatibleQueryCondition = errors.New("incompatible query condition type")
 	ErrIndexNotDefined            = errors.New("index is not define for the field")
 	ErrMultipleGlobalIndexes      = errors.New("multiple global indexes are not supported")
+	ErrUnsupportedConditionOp     = errors.New("the operator is not supported by the tag type")
 )
 
 var (
@@ -75,24 +79,72 @@ func (t *Tag) GetFamilyName() string {
 	return t.familyName
 }
 
+// schemaRepo provides the streams and the index rules bound to them
+type schemaRepo interface {
+	metadata.IndexFilter
+	StreamRegistry() metaschema.Stream
+}
+
 type Analyzer struct {
-	metadataRepoImpl metadata.Repo
+	metadataRepoImpl schemaRepo
+	// cache is nil unless the analyzer is created by DefaultAnalyzerWithCache or CreateCachedAnalyzerFromMetaService
+	cache *schemaCache
 }
 
 func CreateAnalyzerFromMetaService(metaSvc metadata.Service) (*Analyzer, error) {
 	return &Analyzer{
-		metaSvc,
+		metadataRepoImpl: metaSvc,
 	}, nil
 }
 
 func (a *Analyzer) BuildStreamSchema(ctx context.Context, metadata *commonv1.Metadata) (Schema, error) {
-	stream, err := a.metadataRepoImpl.StreamRegistry().GetStream(ctx, metadata)
+	var generation uint64
+	if a.cache != nil {
+		var s Schema
+		var ok bool
+		if s, generation, ok = a.cache.lookup(newSchemaKey(metadata, true), nil); ok {
+			return s, nil
+		}
+	}
+	indexRules, err := a.metadataRepoImpl.IndexRules(context.TODO(), metadata)
 
 	if err != nil {
 		return nil, err
 	}
 
-	indexRules, err := a.metadataRepoImpl.IndexRules(context.TODO(), metadata)
+	s, err := a.buildStreamSchema(ctx, metadata, indexRules)
+	if err != nil {
+		return nil, err
+	}
+	if a.cache != nil {
+		a.cache.store(newSchemaKey(metadata, true), generation, s, indexRules)
+	}
+	return s, nil
+}
+
+// BuildStreamSchemaWithIndexRules builds the schema filtering with the given rules instead of the registered ones,
+// e.g. the ones a stream's indices are generated by while the registered ones are being reindexed.
+func (a *Analyzer) BuildStreamSchemaWithIndexRules(ctx context.Context, metadata *commonv1.Metadata,
+	indexRules []*databasev1.IndexRule) (Schema, error) {
+	if a.cache == nil {
+		return a.buildStreamSchema(ctx, metadata, indexRules)
+	}
+	key := newSchemaKey(metadata, false)
+	s, generation, ok := a.cache.lookup(key, indexRules)
+	if ok {
+		return s, nil
+	}
+	s, err := a.buildStreamSchema(ctx, metadata, indexRules)
+	if err != nil {
+		return nil, err
+	}
+	a.cache.store(key, generation, s, indexRules)
+	return s, nil
+}
+
+func (a *Analyzer) buildStreamSchema(ctx context.Context, metadata *commonv1.Metadata,
+	indexRules []*databasev1.IndexRule) (Schema, error) {
+	stream, err := a.metadataRepoImpl.StreamRegistry().GetStream(ctx, metadata)
 
 	if err != nil {
 		return nil, err
@@ -149,6 +201,7 @@ func (a *Analyzer) Analyze(_ context.Context, criteria *streamv1.QueryRequest, m
 // 2 - If criteria is given, but all of those fields exist in the "entity" definition,
 //     i.e. they are top-level sharding keys. For example, for the current skywalking's schema,
 //     we use service_id + service_instance_id + state as the compound sharding keys.
+// 3 - If an IN is given on an "entity" tag, the union of the series located by each of its values is scanned.
 func parseFields(criteria *streamv1.QueryRequest, metadata *commonv1.Metadata, s Schema) (UnresolvedPlan, error) {
 	timeRange := criteria.GetTimeRange()
 
@@ -172,46 +225,202 @@ func parseFields(criteria *streamv1.QueryRequest, metadata *commonv1.Metadata, s
 		entity[idx] = tsdb.AnyEntry
 	}
 
-	for _, criteriaFamily := range criteria.GetCriteria() {
+	// the criteria in the top-level AND of the expression can locate the series as well
+	criteriaList := append([]*modelv1.Criteria{}, criteria.GetCriteria()...)
+	var expressions []*modelv1.Expression
+	collectCriteria(criteria.GetExpression(), &criteriaList, &expressions)
+
+	var ins []entityIn
+	for _, criteriaFamily := range criteriaList {
 		for _, pairQuery := range criteriaFamily.GetConditions() {
-			op := pairQuery.GetOp()
-			typedTagValue := pairQuery.GetValue()
-			var e Expr
-			switch v := typedTagValue.GetValue().(type) {
-			case *modelv1.TagValue_Str:
-				if entityIdx, ok := entityMap[pairQuery.GetName()]; ok {
-					entity[entityIdx] = []byte(v.Str.GetValue())
-				} else {
-					e = &strLiteral{
-						string: v.Str.GetValue(),
-					}
+			// only the equality or the IN on an entity tag can be served by locating the series
+			if idx, isEntity := entityMap[pairQuery.GetName()]; isEntity && pairQuery.GetOp() == modelv1.Condition_BINARY_OP_EQ {
+				switch v := pairQuery.GetValue().GetValue().(type) {
+				case *modelv1.TagValue_Str:
+					entity[idx] = []byte(v.Str.GetValue())
+					continue
+				case *modelv1.TagValue_Int:
+					entity[idx] = convert.Int64ToBytes(v.Int.GetValue())
+					continue
 				}
-			case *modelv1.TagValue_StrArray:
-				e = &strArrLiteral{
-					arr: v.StrArray.GetValue(),
-				}
-			case *modelv1.TagValue_Int:
-				if entityIdx, ok := entityMap[pairQuery.GetName()]; ok {
-					entity[entityIdx] = convert.Int64ToBytes(v.Int.GetValue())
-				} else {
-					e = &int64Literal{
-						int64: v.Int.GetValue(),
-					}
-				}
-			case *modelv1.TagValue_IntArray:
-				e = &int64ArrLiteral{
-					arr: v.IntArray.GetValue(),
+			}
+			if idx, isEntity := entityMap[pairQuery.GetName()]; isEntity && pairQuery.GetOp() == modelv1.Condition_BINARY_OP_IN {
+				if values := entryValues(pairQuery.GetValue()); len(values) > 0 {
+					ins = append(ins, entityIn{idx: idx, values: values})
+					continue
 				}
-			default:
-				return nil, ErrInvalidConditionType
 			}
 			// we collect Condition only if it is not a part of entity
-			if e != nil {
-				tagExprs = append(tagExprs, binaryOpFactory[op](NewFieldRef(criteriaFamily.GetTagFamilyName(), pairQuery.GetName()), e))
+			e, err := parseCondition(criteriaFamily.GetTagFamilyName(), pairQuery)
+			if err != nil {
+				return nil, err
 			}
+			tagExprs = append(tagExprs, e)
 		}
 	}
 
-	return IndexScan(timeRange.GetBegin().AsTime(), timeRange.GetEnd().AsTime(), metadata,
-		tagExprs, entity, nil, projTags...), nil
+	for _, expression := range expressions {
+		e, err := parseExpression(expression)
+		if err != nil {
+			return nil, err
+		}
+		tagExprs = append(tagExprs, e)
+	}
+
+	// each IN expands the entities to one per value, unless the tag is located by another condition,
+	// in which case only the entities located by one of the values are kept
+	entities := []tsdb.Entity{entity}
+	expandedBy := make(map[int]struct{})
+	for _, in := range ins {
+		if _, expanded := expandedBy[in.idx]; entity[in.idx] != nil || expanded {
+			kept := make([]tsdb.Entity, 0, len(entities))
+			for _, e := range entities {
+				if containsEntry(in.values, e[in.idx]) {
+					kept = append(kept, e)
+				}
+			}
+			entities = kept
+			continue
+		}
+		product := make([]tsdb.Entity, 0, len(entities)*len(in.values))
+		for _, e := range entities {
+			for _, v := range in.values {
+				located := append(tsdb.Entity{}, e...)
+				located[in.idx] = v
+				product = append(product, located)
+			}
+		}
+		entities = product
+		expandedBy[in.idx] = struct{}{}
+	}
+
+	return MultiEntityIndexScan(timeRange.GetBegin().AsTime(), timeRange.GetEnd().AsTime(), metadata,
+		tagExprs, entities, nil, projTags...), nil
+}
+
+// entityIn is an IN on an entity tag, whose values locate the series
+type entityIn struct {
+	idx    int
+	values []tsdb.Entry
+}
+
+func containsEntry(entries []tsdb.Entry, entry tsdb.Entry) bool {
+	for _, e := range entries {
+		if bytes.Equal(e, entry) {
+			return true
+		}
+	}
+	return false
+}
+
+// entryValues converts the values of an IN to the entries of the entity, dropping the duplicated ones
+func entryValues(value *modelv1.TagValue) []tsdb.Entry {
+	var entries []tsdb.Entry
+	seen := make(map[string]struct{})
+	add := func(entry tsdb.Entry) {
+		if _, ok := seen[string(entry)]; ok {
+			return
+		}
+		seen[string(entry)] = struct{}{}
+		entries = append(entries, entry)
+	}
+	switch v := value.GetValue().(type) {
+	case *modelv1.TagValue_StrArray:
+		for _, s := range v.StrArray.GetValue() {
+			add([]byte(s))
+		}
+	case *modelv1.TagValue_IntArray:
+		for _, i := range v.IntArray.GetValue() {
+			add(convert.Int64ToBytes(i))
+		}
+	}
+	return entries
+}
+
+// collectCriteria walks through the top-level AND of the expression. The criteria are collected into criteriaList,
+// while the other expressions are collected into expressions.
+func collectCriteria(expression *modelv1.Expression, criteriaList *[]*modelv1.Criteria, expressions *[]*modelv1.Expression) {
+	if expression == nil {
+		return
+	}
+	if c := expression.GetCriteria(); c != nil {
+		*criteriaList = append(*criteriaList, c)
+		return
+	}
+	if l := expression.GetLogical(); l != nil && l.GetOp() == modelv1.LogicalExpression_LOGICAL_OP_AND {
+		for _, child := range l.GetExpressions() {
+			collectCriteria(child, criteriaList, expressions)
+		}
+		return
+	}
+	*expressions = append(*expressions, expression)
+}
+
+func parseExpression(expression *modelv1.Expression) (Expr, error) {
+	if c := expression.GetCriteria(); c != nil {
+		conditions := make([]Expr, 0, len(c.GetConditions()))
+		for _, pairQuery := range c.GetConditions() {
+			e, err := parseCondition(c.GetTagFamilyName(), pairQuery)
+			if err != nil {
+				return nil, err
+			}
+			conditions = append(conditions, e)
+		}
+		if len(conditions) == 1 {
+			return conditions[0], nil
+		}
+		return And(conditions...), nil
+	}
+	l := expression.GetLogical()
+	if l == nil {
+		return nil, errors.Wrap(ErrInvalidLogicalExpr, "empty expression")
+	}
+	children := make([]Expr, 0, len(l.GetExpressions()))
+	for _, child := range l.GetExpressions() {
+		e, err := parseExpression(child)
+		if err != nil {
+			return nil, err
+		}
+		children = append(children, e)
+	}
+	// the number of children is validated when the expression is resolved
+	return &logicalExpr{
+		op:       l.GetOp(),
+		children: children,
+	}, nil
+}
+
+func parseCondition(familyName string, pairQuery *modelv1.Condition) (Expr, error) {
+	op := pairQuery.GetOp()
+	factory, ok := binaryOpFactory[op]
+	if !ok {
+		return nil, errors.Wrapf(ErrUnsupportedConditionOp, "op:%s", op.String())
+	}
+	var e Expr
+	switch v := pairQuery.GetValue().GetValue().(type) {
+	case nil, *modelv1.TagValue_Null:
+		if op != modelv1.Condition_BINARY_OP_EXISTS && op != modelv1.Condition_BINARY_OP_MISSING {
+			return nil, ErrInvalidConditionType
+		}
+		e = &nullLiteral{}
+	case *modelv1.TagValue_Str:
+		e = &strLiteral{
+			string: v.Str.GetValue(),
+		}
+	case *modelv1.TagValue_StrArray:
+		e = &strArrLiteral{
+			arr: v.StrArray.GetValue(),
+		}
+	case *modelv1.TagValue_Int:
+		e = &int64Literal{
+			int64: v.Int.GetValue(),
+		}
+	case *modelv1.TagValue_IntArray:
+		e = &int64ArrLiteral{
+			arr: v.IntArray.GetValue(),
+		}
+	default:
+		return nil, ErrInvalidConditionType
+	}
+	return factory(NewFieldRef(familyName, pairQuery.GetName()), e), nil
 }
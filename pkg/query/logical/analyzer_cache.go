@@ -0,0 +1,242 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package logical
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	commonv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/common/v1"
+	databasev1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/database/v1"
+	"github.com/apache/skywalking-banyandb/banyand/metadata"
+	metaschema "github.com/apache/skywalking-banyandb/banyand/metadata/schema"
+)
+
+// watchRetryInterval is the backoff of watching the changes again once the watch is broken
+var watchRetryInterval = time.Second
+
+var watchedKinds = []metaschema.Kind{
+	metaschema.KindGroup, metaschema.KindStream,
+	metaschema.KindIndexRule, metaschema.KindIndexRuleBinding,
+}
+
+// DefaultAnalyzerWithCache creates an analyzer building the schemas from the registry and keeping them,
+// which are invalidated by the changes of the streams, the index rules and their bindings.
+// Close stops watching the changes.
+func DefaultAnalyzerWithCache(registry metaschema.Registry) (*Analyzer, error) {
+	return newCachedAnalyzer(registryRepo{Registry: registry}, registry)
+}
+
+// CreateCachedAnalyzerFromMetaService works like DefaultAnalyzerWithCache with the registry of the service.
+func CreateCachedAnalyzerFromMetaService(metaSvc metadata.Service) (*Analyzer, error) {
+	return newCachedAnalyzer(metaSvc, metaSvc.SchemaRegistry())
+}
+
+func newCachedAnalyzer(repo schemaRepo, registry metaschema.Registry) (*Analyzer, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	events, err := registry.Watch(ctx, metaschema.WatchOpt{Kinds: watchedKinds})
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	c := &schemaCache{
+		entries:  make(map[schemaKey]schemaEntry),
+		watching: true,
+		cancel:   cancel,
+	}
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		c.watch(ctx, registry, events)
+	}()
+	return &Analyzer{
+		metadataRepoImpl: repo,
+		cache:            c,
+	}, nil
+}
+
+// registryRepo resolves the index rules bound to a stream from the registry
+type registryRepo struct {
+	metaschema.Registry
+}
+
+func (r registryRepo) IndexRules(ctx context.Context, subject *commonv1.Metadata) ([]*databasev1.IndexRule, error) {
+	return metaschema.BoundIndexRules(ctx, r.Registry, subject)
+}
+
+func (r registryRepo) StreamRegistry() metaschema.Stream {
+	return r.Registry
+}
+
+// Close stops the cache of the schemas from watching the changes. It's a no-op for the analyzer without the cache.
+func (a *Analyzer) Close() error {
+	if a.cache != nil {
+		a.cache.cancel()
+		a.cache.wg.Wait()
+	}
+	return nil
+}
+
+// CacheStats returns the number of the schemas served by the cache and the ones built from the metadata
+func (a *Analyzer) CacheStats() (hits, misses uint64) {
+	if a.cache == nil {
+		return 0, 0
+	}
+	return atomic.LoadUint64(&a.cache.hits), atomic.LoadUint64(&a.cache.misses)
+}
+
+// schemaKey identifies a schema. The registered one is filtered with the index rules bound to the stream,
+// while the other is filtered with the rules given by the caller.
+type schemaKey struct {
+	group      string
+	name       string
+	registered bool
+}
+
+type schemaEntry struct {
+	schema     Schema
+	indexRules []*databasev1.IndexRule
+}
+
+type schemaCache struct {
+	mu      sync.Mutex
+	entries map[schemaKey]schemaEntry
+	// watching is false while the watch is broken, when the schemas are built without the cache
+	watching bool
+	// generation increases on each invalidation, so a schema built across an invalidation isn't cached
+	generation uint64
+
+	hits   uint64
+	misses uint64
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// watch applies the changes to the cache until ctx is done. The schemas are built without the cache
+// from the breaking of the watch until it's established again.
+func (c *schemaCache) watch(ctx context.Context, registry metaschema.Registry, events <-chan metaschema.Event) {
+	for {
+		for event := range events {
+			c.invalidate(event)
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		c.setWatching(false)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(watchRetryInterval):
+			}
+			var err error
+			if events, err = registry.Watch(ctx, metaschema.WatchOpt{Kinds: watchedKinds}); err == nil {
+				break
+			}
+		}
+		c.setWatching(true)
+	}
+}
+
+// setWatching flushes the cache since the changes are missed while the watch is broken
+func (c *schemaCache) setWatching(watching bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.watching = watching
+	c.generation++
+	c.entries = make(map[schemaKey]schemaEntry)
+}
+
+func (c *schemaCache) invalidate(event metaschema.Event) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.generation++
+	switch {
+	case event.Type == metaschema.EventTypeResync:
+		c.entries = make(map[schemaKey]schemaEntry)
+	case event.Kind == metaschema.KindGroup:
+		for key := range c.entries {
+			if key.group == event.Metadata.GetName() {
+				delete(c.entries, key)
+			}
+		}
+	case event.Kind == metaschema.KindStream:
+		for _, registered := range []bool{true, false} {
+			delete(c.entries, schemaKey{
+				group:      event.Metadata.GetGroup(),
+				name:       event.Metadata.GetName(),
+				registered: registered,
+			})
+		}
+	default:
+		// the rules bound to any stream of the group might be changed
+		for key := range c.entries {
+			if key.registered && key.group == event.Metadata.GetGroup() {
+				delete(c.entries, key)
+			}
+		}
+	}
+}
+
+// lookup returns the cached schema if it's filtered with indexRules, or the registered rules if the key is registered
+func (c *schemaCache) lookup(key schemaKey, indexRules []*databasev1.IndexRule) (Schema, uint64, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if ok && (key.registered || sameIndexRules(entry.indexRules, indexRules)) {
+		atomic.AddUint64(&c.hits, 1)
+		return entry.schema, c.generation, true
+	}
+	atomic.AddUint64(&c.misses, 1)
+	return nil, c.generation, false
+}
+
+// store caches the schema unless an invalidation happens after the generation
+func (c *schemaCache) store(key schemaKey, generation uint64, s Schema, indexRules []*databasev1.IndexRule) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.watching || generation != c.generation {
+		return
+	}
+	c.entries[key] = schemaEntry{schema: s, indexRules: indexRules}
+}
+
+func newSchemaKey(metadata *commonv1.Metadata, registered bool) schemaKey {
+	return schemaKey{
+		group:      metadata.GetGroup(),
+		name:       metadata.GetName(),
+		registered: registered,
+	}
+}
+
+// sameIndexRules reports whether both are the same revisions of the same rules
+func sameIndexRules(a, b []*databasev1.IndexRule) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].GetMetadata().GetId() != b[i].GetMetadata().GetId() ||
+			a[i].GetMetadata().GetModRevision() != b[i].GetMetadata().GetModRevision() {
+			return false
+		}
+	}
+	return true
+}
@@ -20,14 +20,20 @@ package logical_test
 import (
 	"context"
 	"os"
+	"strconv"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/stretchr/testify/require"
 
+	commonv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/common/v1"
+	databasev1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/database/v1"
 	modelv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/model/v1"
+	streamv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/stream/v1"
 	"github.com/apache/skywalking-banyandb/banyand/metadata"
+	metaschema "github.com/apache/skywalking-banyandb/banyand/metadata/schema"
 	"github.com/apache/skywalking-banyandb/banyand/tsdb"
 	pb "github.com/apache/skywalking-banyandb/pkg/pb/v1"
 	"github.com/apache/skywalking-banyandb/pkg/query/logical"
@@ -37,6 +43,20 @@ import (
 // setUpAnalyzer creates a default analyzer for testing.
 // You have to close the underlying metadata after teststream
 func setUpAnalyzer() (*logical.Analyzer, func(), error) {
+	metadataService, stopFunc, err := setUpMetadataService()
+	if err != nil {
+		return nil, stopFunc, err
+	}
+
+	ana, err := logical.CreateAnalyzerFromMetaService(metadataService)
+	if err != nil {
+		return nil, stopFunc, err
+	}
+	return ana, stopFunc, nil
+}
+
+// setUpMetadataService creates a metadata service with the preloaded schemas
+func setUpMetadataService() (metadata.Service, func(), error) {
 	metadataService, err := metadata.NewService(context.TODO())
 	if err != nil {
 		return nil, func() {
@@ -57,21 +77,15 @@ func setUpAnalyzer() (*logical.Analyzer, func(), error) {
 		}, err
 	}
 
-	err = teststream.PreloadSchema(metadataService.SchemaRegistry())
-	if err != nil {
-		return nil, func() {
-		}, err
+	stopFunc := func() {
+		metadataService.GracefulStop()
+		os.RemoveAll(rootDir)
 	}
-
-	ana, err := logical.CreateAnalyzerFromMetaService(metadataService)
+	err = teststream.PreloadSchema(metadataService.SchemaRegistry())
 	if err != nil {
-		return nil, func() {
-		}, err
+		return nil, stopFunc, err
 	}
-	return ana, func() {
-		metadataService.GracefulStop()
-		os.RemoveAll(rootDir)
-	}, nil
+	return metadataService, stopFunc, nil
 }
 
 func TestAnalyzer_SimpleTimeScan(t *testing.T) {
@@ -269,7 +283,31 @@ func TestAnalyzer_Projection_FieldNotDefined(t *testing.T) {
 	assert.ErrorIs(err, logical.ErrFieldNotDefined)
 }
 
-func TestAnalyzer_Fields_IndexNotDefined(t *testing.T) {
+func TestAnalyzer_Projection_FamilyMismatch(t *testing.T) {
+	assert := require.New(t)
+
+	ana, stopFunc, err := setUpAnalyzer()
+	assert.NoError(err)
+	assert.NotNil(ana)
+	defer stopFunc()
+
+	// trace_id belongs to the searchable family
+	criteria := pb.NewQueryRequestBuilder().
+		Metadata("default", "sw").
+		Projection("data", "trace_id").
+		TimeRange(time.Now().Add(-3*time.Hour), time.Now()).
+		Build()
+
+	metadata := criteria.GetMetadata()
+
+	schema, err := ana.BuildStreamSchema(context.TODO(), metadata)
+	assert.NoError(err)
+
+	_, err = ana.Analyze(context.TODO(), criteria, metadata, schema)
+	assert.ErrorIs(err, logical.ErrFieldNotDefined)
+}
+
+func TestAnalyzer_Fields_ScannedWithoutIndex(t *testing.T) {
 	assert := require.New(t)
 
 	ana, stopFunc, err := setUpAnalyzer()
@@ -281,7 +319,7 @@ func TestAnalyzer_Fields_IndexNotDefined(t *testing.T) {
 		Limit(5).
 		Offset(10).
 		Metadata("default", "sw").
-		Projection("duration", "service_id").
+		Projection("searchable", "duration", "service_id").
 		TimeRange(time.Now().Add(-3*time.Hour), time.Now()).
 		FieldsInTagFamily("searchable", "start_time", ">", 10000).
 		Build()
@@ -291,6 +329,402 @@ func TestAnalyzer_Fields_IndexNotDefined(t *testing.T) {
 	schema, err := ana.BuildStreamSchema(context.TODO(), metadata)
 	assert.NoError(err)
 
+	// start_time isn't bound to any index, so it's evaluated during the scan
+	plan, err := ana.Analyze(context.TODO(), criteria, metadata, schema)
+	assert.NoError(err)
+	assert.NotNil(plan)
+}
+
+func TestAnalyzer_Fields_UnsupportedOperator(t *testing.T) {
+	ana, stopFunc, err := setUpAnalyzer()
+	require.NoError(t, err)
+	require.NotNil(t, ana)
+	defer stopFunc()
+
+	tests := []struct {
+		name    string
+		tag     string
+		op      string
+		value   interface{}
+		wantErr error
+	}{
+		{
+			name:    "lt on a string tag",
+			tag:     "http.method",
+			op:      "<",
+			value:   "GET",
+			wantErr: logical.ErrUnsupportedConditionOp,
+		},
+		{
+			name:    "match on an int tag",
+			tag:     "duration",
+			op:      "match",
+			value:   100,
+			wantErr: logical.ErrUnsupportedConditionOp,
+		},
+		{
+			name:    "match with an invalid pattern",
+			tag:     "http.method",
+			op:      "match",
+			value:   "(GET",
+			wantErr: logical.ErrIncompatibleQueryCondition,
+		},
+		{
+			name:    "in with a single value",
+			tag:     "http.method",
+			op:      "in",
+			value:   "GET",
+			wantErr: logical.ErrIncompatibleQueryCondition,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			criteria := pb.NewQueryRequestBuilder().
+				Metadata("default", "sw").
+				Projection("searchable", "trace_id").
+				FieldsInTagFamily("searchable", tt.tag, tt.op, tt.value).
+				TimeRange(time.Now().Add(-3*time.Hour), time.Now()).
+				Build()
+
+			metadata := criteria.GetMetadata()
+
+			schema, err := ana.BuildStreamSchema(context.TODO(), metadata)
+			assert.NoError(err)
+
+			_, err = ana.Analyze(context.TODO(), criteria, metadata, schema)
+			assert.ErrorIs(err, tt.wantErr)
+		})
+	}
+}
+
+func TestAnalyzer_LogicalExpression(t *testing.T) {
+	assert := require.New(t)
+
+	ana, stopFunc, err := setUpAnalyzer()
+	assert.NoError(err)
+	assert.NotNil(ana)
+	defer stopFunc()
+
+	sT, eT := time.Now().Add(-3*time.Hour), time.Now()
+	condition := func(name string, op modelv1.Condition_BinaryOp, value *modelv1.TagValue) *modelv1.Expression {
+		return &modelv1.Expression{Exp: &modelv1.Expression_Criteria{Criteria: &modelv1.Criteria{
+			TagFamilyName: "searchable",
+			Conditions:    []*modelv1.Condition{{Name: name, Op: op, Value: value}},
+		}}}
+	}
+	logicalExpression := func(op modelv1.LogicalExpression_LogicalOp, exps ...*modelv1.Expression) *modelv1.Expression {
+		return &modelv1.Expression{Exp: &modelv1.Expression_Logical{Logical: &modelv1.LogicalExpression{
+			Op:          op,
+			Expressions: exps,
+		}}}
+	}
+	str := func(v string) *modelv1.TagValue {
+		return &modelv1.TagValue{Value: &modelv1.TagValue_Str{Str: &modelv1.Str{Value: v}}}
+	}
+
+	// (endpoint_id = "/home_id" OR duration = 500) AND NOT status_code = "500" AND service_id = "my_app"
+	criteria := pb.NewQueryRequestBuilder().
+		Metadata("default", "sw").
+		Projection("searchable", "trace_id").
+		TimeRange(sT, eT).
+		Build()
+	criteria.Expression = logicalExpression(modelv1.LogicalExpression_LOGICAL_OP_AND,
+		logicalExpression(modelv1.LogicalExpression_LOGICAL_OP_OR,
+			condition("endpoint_id", modelv1.Condition_BINARY_OP_EQ, str("/home_id")),
+			condition("duration", modelv1.Condition_BINARY_OP_EQ,
+				&modelv1.TagValue{Value: &modelv1.TagValue_Int{Int: &modelv1.Int{Value: 500}}}),
+		),
+		logicalExpression(modelv1.LogicalExpression_LOGICAL_OP_NOT,
+			condition("status_code", modelv1.Condition_BINARY_OP_EQ, str("500")),
+		),
+		condition("service_id", modelv1.Condition_BINARY_OP_EQ, str("my_app")),
+	)
+
+	metadata := criteria.GetMetadata()
+
+	schema, err := ana.BuildStreamSchema(context.TODO(), metadata)
+	assert.NoError(err)
+
+	plan, err := ana.Analyze(context.TODO(), criteria, metadata, schema)
+	assert.NoError(err)
+	assert.NotNil(plan)
+
+	correctPlan, err := logical.Limit(
+		logical.Offset(
+			logical.IndexScan(sT, eT, metadata,
+				[]logical.Expr{
+					logical.Or(
+						logical.Eq(logical.NewSearchableFieldRef("endpoint_id"), logical.Str("/home_id")),
+						logical.Eq(logical.NewSearchableFieldRef("duration"), logical.Int(500)),
+					),
+					logical.Not(logical.Eq(logical.NewSearchableFieldRef("status_code"), logical.Str("500"))),
+				}, tsdb.Entity{tsdb.Entry("my_app"), tsdb.AnyEntry, tsdb.AnyEntry},
+				nil,
+				logical.NewTags("searchable", "trace_id")),
+			0),
+		logical.DefaultLimit).
+		Analyze(schema)
+	assert.NoError(err)
+	assert.NotNil(correctPlan)
+	assert.True(cmp.Equal(plan, correctPlan), "plan is not equal to correct plan")
+
+	// NOT takes only one expression
+	criteria.Expression = logicalExpression(modelv1.LogicalExpression_LOGICAL_OP_NOT,
+		condition("endpoint_id", modelv1.Condition_BINARY_OP_EQ, str("/home_id")),
+		condition("status_code", modelv1.Condition_BINARY_OP_EQ, str("500")),
+	)
 	_, err = ana.Analyze(context.TODO(), criteria, metadata, schema)
-	assert.ErrorIs(err, logical.ErrIndexNotDefined)
+	assert.ErrorIs(err, logical.ErrInvalidLogicalExpr)
+
+	// the comparison on a tag without any index is evaluated during the scan when it's nested
+	criteria.Expression = logicalExpression(modelv1.LogicalExpression_LOGICAL_OP_OR,
+		condition("endpoint_id", modelv1.Condition_BINARY_OP_EQ, str("/home_id")),
+		condition("start_time", modelv1.Condition_BINARY_OP_GT,
+			&modelv1.TagValue{Value: &modelv1.TagValue_Int{Int: &modelv1.Int{Value: 10000}}}),
+	)
+	plan, err = ana.Analyze(context.TODO(), criteria, metadata, schema)
+	assert.NoError(err)
+	assert.NotNil(plan)
+	correctPlan, err = logical.Limit(
+		logical.Offset(
+			logical.IndexScan(sT, eT, metadata,
+				[]logical.Expr{
+					logical.Or(
+						logical.Eq(logical.NewSearchableFieldRef("endpoint_id"), logical.Str("/home_id")),
+						logical.Gt(logical.NewSearchableFieldRef("start_time"), logical.Int(10000)),
+					),
+				}, tsdb.Entity{tsdb.AnyEntry, tsdb.AnyEntry, tsdb.AnyEntry},
+				nil,
+				logical.NewTags("searchable", "trace_id")),
+			0),
+		logical.DefaultLimit).
+		Analyze(schema)
+	assert.NoError(err)
+	assert.True(cmp.Equal(plan, correctPlan), "plan is not equal to correct plan")
+}
+
+func TestAnalyzer_EntityIn(t *testing.T) {
+	assert := require.New(t)
+
+	ana, stopFunc, err := setUpAnalyzer()
+	assert.NoError(err)
+	assert.NotNil(ana)
+	defer stopFunc()
+
+	sT, eT := time.Now().Add(-3*time.Hour), time.Now()
+
+	criteria := pb.NewQueryRequestBuilder().
+		Metadata("default", "sw").
+		Projection("searchable", "trace_id").
+		FieldsInTagFamily("searchable", "service_id", "=", "my_app",
+			"service_instance_id", "in", []string{"10.0.0.1_id", "10.0.0.3_id", "10.0.0.1_id", "10.0.0.5_id"}).
+		TimeRange(sT, eT).
+		Build()
+
+	metadata := criteria.GetMetadata()
+
+	schema, err := ana.BuildStreamSchema(context.TODO(), metadata)
+	assert.NoError(err)
+
+	plan, err := ana.Analyze(context.TODO(), criteria, metadata, schema)
+	assert.NoError(err)
+	assert.NotNil(plan)
+
+	// the duplicated value is dropped
+	correctPlan, err := logical.Limit(
+		logical.Offset(
+			logical.MultiEntityIndexScan(sT, eT, metadata, nil,
+				[]tsdb.Entity{
+					{tsdb.Entry("my_app"), tsdb.Entry("10.0.0.1_id"), tsdb.AnyEntry},
+					{tsdb.Entry("my_app"), tsdb.Entry("10.0.0.3_id"), tsdb.AnyEntry},
+					{tsdb.Entry("my_app"), tsdb.Entry("10.0.0.5_id"), tsdb.AnyEntry},
+				},
+				nil,
+				logical.NewTags("searchable", "trace_id")),
+			0),
+		logical.DefaultLimit).
+		Analyze(schema)
+	assert.NoError(err)
+	assert.NotNil(correctPlan)
+	assert.True(cmp.Equal(plan, correctPlan), "plan is not equal to correct plan")
+
+	// the IN on a tag located by the equality keeps the entity only if the value is in it
+	criteria = pb.NewQueryRequestBuilder().
+		Metadata("default", "sw").
+		Projection("searchable", "trace_id").
+		FieldsInTagFamily("searchable", "service_id", "in", []string{"my_app", "other_app"},
+			"service_id", "=", "my_app").
+		TimeRange(sT, eT).
+		Build()
+	plan, err = ana.Analyze(context.TODO(), criteria, metadata, schema)
+	assert.NoError(err)
+	correctPlan, err = logical.Limit(
+		logical.Offset(
+			logical.IndexScan(sT, eT, metadata, nil, tsdb.Entity{tsdb.Entry("my_app"), tsdb.AnyEntry, tsdb.AnyEntry},
+				nil, logical.NewTags("searchable", "trace_id")),
+			0),
+		logical.DefaultLimit).
+		Analyze(schema)
+	assert.NoError(err)
+	assert.True(cmp.Equal(plan, correctPlan), "plan is not equal to correct plan")
+}
+
+func TestAnalyzer_SchemaCache(t *testing.T) {
+	assert := require.New(t)
+
+	metadataService, stopFunc, err := setUpMetadataService()
+	assert.NoError(err)
+	defer stopFunc()
+	ana, err := logical.CreateCachedAnalyzerFromMetaService(metadataService)
+	assert.NoError(err)
+	defer func() {
+		assert.NoError(ana.Close())
+	}()
+
+	metadata := &commonv1.Metadata{Group: "default", Name: "sw"}
+	s, err := ana.BuildStreamSchema(context.TODO(), metadata)
+	assert.NoError(err)
+	cached, err := ana.BuildStreamSchema(context.TODO(), metadata)
+	assert.NoError(err)
+	assert.True(s == cached, "the schema isn't served by the cache")
+	hits, misses := ana.CacheStats()
+	assert.Equal(uint64(1), hits)
+	assert.Equal(uint64(1), misses)
+
+	// the given rules are compared with the cached ones
+	rules, err := metadataService.IndexRules(context.TODO(), metadata)
+	assert.NoError(err)
+	_, err = ana.BuildStreamSchemaWithIndexRules(context.TODO(), metadata, rules)
+	assert.NoError(err)
+	_, err = ana.BuildStreamSchemaWithIndexRules(context.TODO(), metadata, rules)
+	assert.NoError(err)
+	_, err = ana.BuildStreamSchemaWithIndexRules(context.TODO(), metadata, rules[1:])
+	assert.NoError(err)
+	hits, misses = ana.CacheStats()
+	assert.Equal(uint64(2), hits)
+	assert.Equal(uint64(3), misses)
+
+	// updating the stream invalidates its schema
+	stream, err := metadataService.StreamRegistry().GetStream(context.TODO(), metadata)
+	assert.NoError(err)
+	stream.TagFamilies[1].Tags = append(stream.TagFamilies[1].Tags,
+		&databasev1.TagSpec{Name: "extra", Type: databasev1.TagType_TAG_TYPE_STRING})
+	assert.NoError(metadataService.StreamRegistry().UpdateStream(context.TODO(), stream))
+	assert.Eventually(func() bool {
+		updated, errBuild := ana.BuildStreamSchema(context.TODO(), metadata)
+		return errBuild == nil && updated.FieldDefined("extra")
+	}, 10*time.Second, 50*time.Millisecond)
+}
+
+// brokenWatchRegistry breaks the first watch once broken is closed, which misses the changes before breaking
+type brokenWatchRegistry struct {
+	metaschema.Registry
+	broken  chan struct{}
+	watches int32
+}
+
+func (r *brokenWatchRegistry) Watch(ctx context.Context, opt metaschema.WatchOpt) (<-chan metaschema.Event, error) {
+	if atomic.AddInt32(&r.watches, 1) > 1 {
+		return r.Registry.Watch(ctx, opt)
+	}
+	events := make(chan metaschema.Event)
+	go func() {
+		<-r.broken
+		close(events)
+	}()
+	return events, nil
+}
+
+func TestAnalyzer_SchemaCacheRewatch(t *testing.T) {
+	assert := require.New(t)
+
+	metadataService, stopFunc, err := setUpMetadataService()
+	assert.NoError(err)
+	defer stopFunc()
+	registry := &brokenWatchRegistry{Registry: metadataService.SchemaRegistry(), broken: make(chan struct{})}
+	ana, err := logical.DefaultAnalyzerWithCache(registry)
+	assert.NoError(err)
+	defer func() {
+		assert.NoError(ana.Close())
+	}()
+
+	metadata := &commonv1.Metadata{Group: "default", Name: "sw"}
+	s, err := ana.BuildStreamSchema(context.TODO(), metadata)
+	assert.NoError(err)
+	cached, err := ana.BuildStreamSchema(context.TODO(), metadata)
+	assert.NoError(err)
+	assert.True(s == cached, "the schema isn't served by the cache")
+
+	// the update missed by the broken watch is built once the watch breaks
+	stream, err := metadataService.StreamRegistry().GetStream(context.TODO(), metadata)
+	assert.NoError(err)
+	stream.TagFamilies[1].Tags = append(stream.TagFamilies[1].Tags,
+		&databasev1.TagSpec{Name: "extra", Type: databasev1.TagType_TAG_TYPE_STRING})
+	assert.NoError(metadataService.StreamRegistry().UpdateStream(context.TODO(), stream))
+	close(registry.broken)
+	assert.Eventually(func() bool {
+		updated, errBuild := ana.BuildStreamSchema(context.TODO(), metadata)
+		return errBuild == nil && updated.FieldDefined("extra")
+	}, 10*time.Second, 50*time.Millisecond)
+
+	// the schemas are cached again once the watch is established again
+	assert.Eventually(func() bool {
+		hits, _ := ana.CacheStats()
+		if _, errBuild := ana.BuildStreamSchema(context.TODO(), metadata); errBuild != nil {
+			return false
+		}
+		updated, _ := ana.CacheStats()
+		return updated > hits
+	}, 10*time.Second, 50*time.Millisecond)
+	assert.True(atomic.LoadInt32(&registry.watches) > 1)
+}
+
+// BenchmarkAnalyzer_Write resolves the schema of each element of a write stream,
+// which is what the write handler does before locating the element.
+func BenchmarkAnalyzer_Write(b *testing.B) {
+	metadataService, stopFunc, err := setUpMetadataService()
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer stopFunc()
+	cachedAnalyzer, err := logical.DefaultAnalyzerWithCache(metadataService.SchemaRegistry())
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer func() {
+		_ = cachedAnalyzer.Close()
+	}()
+	analyzer, err := logical.CreateAnalyzerFromMetaService(metadataService)
+	if err != nil {
+		b.Fatal(err)
+	}
+	requests := make([]*streamv1.WriteRequest, 100)
+	for i := range requests {
+		requests[i] = pb.NewStreamWriteRequestBuilder().
+			ID(strconv.Itoa(i)).
+			Metadata("default", "sw").
+			Timestamp(time.Now()).
+			TagFamily([]byte("data")).
+			TagFamily("trace_id-"+strconv.Itoa(i), 0, "webapp_id", "10.0.0.1_id", "/home_id", 300, 1622933202000000000).
+			Build()
+	}
+	for name, ana := range map[string]*logical.Analyzer{"uncached": analyzer, "cached": cachedAnalyzer} {
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				for _, req := range requests {
+					s, errBuild := ana.BuildStreamSchema(context.TODO(), req.GetMetadata())
+					if errBuild != nil {
+						b.Fatal(errBuild)
+					}
+					for _, tagName := range s.EntityList() {
+						if !s.FieldDefined(tagName) {
+							b.Fatalf("the entity tag %s isn't defined", tagName)
+						}
+					}
+				}
+			}
+		})
+	}
 }
@@ -40,25 +40,70 @@ func createComparator(sortDirection modelv1.Sort) comparator {
 	}
 }
 
+// itemTagFamilies decodes the tag families of an item on demand, so the families neither filtered
+// nor projected are never decoded, and each family is decoded once for both.
+type itemTagFamilies struct {
+	ec       executor.ExecutionContext
+	item     tsdb.Item
+	families map[string]*modelv1.TagFamily
+}
+
+func newItemTagFamilies(ec executor.ExecutionContext, item tsdb.Item) *itemTagFamilies {
+	return &itemTagFamilies{
+		ec:       ec,
+		item:     item,
+		families: make(map[string]*modelv1.TagFamily),
+	}
+}
+
+func (f *itemTagFamilies) get(familyName string) (*modelv1.TagFamily, error) {
+	if parsedTagFamily, ok := f.families[familyName]; ok {
+		return parsedTagFamily, nil
+	}
+	parsedTagFamily, err := f.ec.ParseTagFamily(familyName, f.item)
+	if err != nil {
+		return nil, err
+	}
+	f.families[familyName] = parsedTagFamily
+	return parsedTagFamily, nil
+}
+
+// lookup returns the value of the tag referred by ref, which is nil if the item is written without the tag
+func (f *itemTagFamilies) lookup(ref *FieldRef) (*modelv1.TagValue, error) {
+	parsedTagFamily, err := f.get(ref.tag.GetFamilyName())
+	if err != nil {
+		return nil, err
+	}
+	if tags := parsedTagFamily.GetTags(); ref.Spec.TagIdx < len(tags) {
+		return tags[ref.Spec.TagIdx].GetValue(), nil
+	}
+	return nil, nil
+}
+
 // projectItem parses the item within the ExecutionContext.
 // projectionFieldRefs must be prepared before calling this method, projectionFieldRefs should be a list of
 // tag list where the inner list must exist in the same tag family.
-// Strict order can be guaranteed in the result.
-func projectItem(ec executor.ExecutionContext, item tsdb.Item, projectionFieldRefs [][]*FieldRef) ([]*modelv1.TagFamily, error) {
+// Strict order can be guaranteed in the result, and a tag the item is written without is returned as null.
+func projectItem(families *itemTagFamilies, projectionFieldRefs [][]*FieldRef) ([]*modelv1.TagFamily, error) {
 	tagFamily := make([]*modelv1.TagFamily, len(projectionFieldRefs))
 	for i, refs := range projectionFieldRefs {
 		tags := make([]*modelv1.Tag, len(refs))
-		familyName := refs[0].tag.GetFamilyName()
-		parsedTagFamily, err := ec.ParseTagFamily(familyName, item)
-		if err != nil {
-			return nil, err
-		}
 		for j, ref := range refs {
-			tags[j] = parsedTagFamily.GetTags()[ref.Spec.TagIdx]
+			value, err := families.lookup(ref)
+			if err != nil {
+				return nil, err
+			}
+			if value == nil {
+				value = &modelv1.TagValue{Value: &modelv1.TagValue_Null{}}
+			}
+			tags[j] = &modelv1.Tag{
+				Key:   ref.tag.GetTagName(),
+				Value: value,
+			}
 		}
 
 		tagFamily[i] = &modelv1.TagFamily{
-			Name: familyName,
+			Name: refs[0].tag.GetFamilyName(),
 			Tags: tags,
 		}
 	}
@@ -66,6 +111,26 @@ func projectItem(ec executor.ExecutionContext, item tsdb.Item, projectionFieldRe
 	return tagFamily, nil
 }
 
+// tagLookup returns the value of the tag referred by ref. A nil value means the tag is absent.
+type tagLookup func(ref *FieldRef) (*modelv1.TagValue, error)
+
+// evaluable is the boolean expression evaluated against the tags of an item during the scan
+type evaluable interface {
+	Expr
+	evaluate(lookup tagLookup) (bool, error)
+}
+
+// filterItem evaluates the conditions which can't be served by indices against the item's tags.
+func filterItem(families *itemTagFamilies, filters []evaluable) (bool, error) {
+	for _, filter := range filters {
+		matched, err := filter.evaluate(families.lookup)
+		if err != nil || !matched {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
 // executeForShard fetches elements from series within a single shard. A list of series must be prepared in advanced
 // with the help of Entity. The result is a list of element set, where the order of inner list is kept
 // as what the users specify in the seekerBuilder.
@@ -0,0 +1,147 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package logical
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	databasev1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/database/v1"
+	"github.com/apache/skywalking-banyandb/banyand/tsdb"
+	"github.com/apache/skywalking-banyandb/pkg/index"
+	"github.com/apache/skywalking-banyandb/pkg/query/executor"
+)
+
+var ErrQueryTooExpensive = errors.New("the estimated cost of the query exceeds the threshold")
+
+// Cost is the estimated expense of executing a plan
+type Cost struct {
+	Shards int
+	Series int
+	// Blocks is the number of series' blocks to scan
+	Blocks int
+	// Items is the number of items to read, which are resolved from the indices
+	Items int
+}
+
+func (c Cost) String() string {
+	return fmt.Sprintf("Cost: shards=%d,series=%d,blocks=%d,items=%d", c.Shards, c.Series, c.Blocks, c.Items)
+}
+
+// Exceeds reports whether the cost is above the threshold. A non-positive threshold is never exceeded.
+func (c Cost) Exceeds(threshold int) bool {
+	return threshold > 0 && c.Items > threshold
+}
+
+func (c Cost) add(other Cost) Cost {
+	return Cost{
+		Shards: c.Shards + other.Shards,
+		Series: c.Series + other.Series,
+		Blocks: c.Blocks + other.Blocks,
+		Items:  c.Items + other.Items,
+	}
+}
+
+type estimator interface {
+	estimate(ec executor.ExecutionContext) (Cost, error)
+}
+
+// Explain estimates the cost of a plan before executing it.
+// It selects blocks by their time ranges and counts items by the indices, which never touches the data.
+func Explain(p Plan, ec executor.ExecutionContext) (Cost, error) {
+	if e, ok := p.(estimator); ok {
+		return e.estimate(ec)
+	}
+	var cost Cost
+	for _, child := range p.Children() {
+		childCost, err := Explain(child, ec)
+		if err != nil {
+			return cost, err
+		}
+		cost = cost.add(childCost)
+	}
+	return cost, nil
+}
+
+func (i *localIndexScan) estimate(ec executor.ExecutionContext) (Cost, error) {
+	seriesInShards, err := i.locateSeries(ec)
+	if err != nil {
+		return Cost{}, err
+	}
+	conditions := make(map[*databasev1.IndexRule]tsdb.Condition, len(i.conditionMap))
+	for rule, exprs := range i.conditionMap {
+		conditions[rule] = exprToCondition(exprs)
+	}
+	cost := Cost{Shards: len(seriesInShards)}
+	for _, seriesList := range seriesInShards {
+		cost.Series += len(seriesList)
+		for _, series := range seriesList {
+			spanCost, errSpan := estimateSpan(series, i.timeRange, conditions)
+			if errSpan != nil {
+				return cost, errSpan
+			}
+			cost = cost.add(spanCost)
+		}
+	}
+	return cost, nil
+}
+
+func estimateSpan(series tsdb.Series, timeRange tsdb.TimeRange, conditions map[*databasev1.IndexRule]tsdb.Condition) (Cost, error) {
+	span, err := series.Span(timeRange)
+	if errors.Is(err, tsdb.ErrEmptySeriesSpan) {
+		return Cost{}, nil
+	}
+	if err != nil {
+		return Cost{}, err
+	}
+	defer func() {
+		_ = span.Close()
+	}()
+	items, err := span.Cardinality(conditions)
+	if err != nil {
+		return Cost{}, err
+	}
+	return Cost{
+		Blocks: span.BlockNum(),
+		Items:  items,
+	}, nil
+}
+
+func (t *globalIndexScan) estimate(ec executor.ExecutionContext) (Cost, error) {
+	shards, err := ec.Shards(nil)
+	if err != nil {
+		return Cost{}, err
+	}
+	cost := Cost{Shards: len(shards)}
+	for _, shard := range shards {
+		itemIDs, errSeek := shard.Index().Seek(index.Field{
+			Key: index.FieldKey{
+				IndexRuleID: t.globalIndexRule.GetMetadata().GetId(),
+			},
+			Term: t.expr.(*binaryExpr).r.(LiteralExpr).Bytes()[0],
+		})
+		if errSeek != nil {
+			return Cost{}, errSeek
+		}
+		// the items are fetched one by one, and each of them reads a block
+		cost.Blocks += len(itemIDs)
+		cost.Items += len(itemIDs)
+	}
+	return cost, nil
+}
@@ -19,11 +19,13 @@ package logical
 
 import (
 	"fmt"
+	"regexp"
 
 	"github.com/pkg/errors"
 
 	databasev1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/database/v1"
 	modelv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/model/v1"
+	pbv1 "github.com/apache/skywalking-banyandb/pkg/pb/v1"
 )
 
 var binaryOpFactory = map[modelv1.Condition_BinaryOp]func(l, r Expr) Expr{
@@ -35,6 +37,15 @@ var binaryOpFactory = map[modelv1.Condition_BinaryOp]func(l, r Expr) Expr{
 	modelv1.Condition_BINARY_OP_GE:         Ge,
 	modelv1.Condition_BINARY_OP_HAVING:     Having,
 	modelv1.Condition_BINARY_OP_NOT_HAVING: NotHaving,
+	modelv1.Condition_BINARY_OP_IN:         In,
+	modelv1.Condition_BINARY_OP_NOT_IN:     NotIn,
+	modelv1.Condition_BINARY_OP_MATCH:      Match,
+	modelv1.Condition_BINARY_OP_EXISTS: func(l, _ Expr) Expr {
+		return Exists(l)
+	},
+	modelv1.Condition_BINARY_OP_MISSING: func(l, _ Expr) Expr {
+		return Missing(l)
+	},
 }
 
 var _ ResolvableExpr = (*FieldRef)(nil)
@@ -96,6 +107,8 @@ type binaryExpr struct {
 	op modelv1.Condition_BinaryOp
 	l  Expr
 	r  Expr
+	// matcher is compiled from r when op is MATCH
+	matcher *regexp.Regexp
 }
 
 func (b *binaryExpr) Equal(expr Expr) bool {
@@ -116,12 +129,42 @@ func (b *binaryExpr) Resolve(s Schema) error {
 			return err
 		}
 	}
-	if rr, ok := b.l.(ResolvableExpr); ok {
+	if rr, ok := b.r.(ResolvableExpr); ok {
 		err := rr.Resolve(s)
 		if err != nil {
 			return err
 		}
 	}
+	switch b.op {
+	case modelv1.Condition_BINARY_OP_LT, modelv1.Condition_BINARY_OP_GT,
+		modelv1.Condition_BINARY_OP_LE, modelv1.Condition_BINARY_OP_GE:
+		if b.l.FieldType() != databasev1.TagType_TAG_TYPE_INT {
+			return errors.Wrapf(ErrUnsupportedConditionOp, "%s on %s", b.op.String(), b.l.FieldType().String())
+		}
+	case modelv1.Condition_BINARY_OP_IN, modelv1.Condition_BINARY_OP_NOT_IN:
+		if arrayType(b.l.FieldType()) != b.r.FieldType() {
+			return errors.Wrapf(ErrIncompatibleQueryCondition, "left is %s while right is %s",
+				b.l.FieldType().String(),
+				b.r.FieldType().String(),
+			)
+		}
+		return nil
+	case modelv1.Condition_BINARY_OP_MATCH:
+		if b.l.FieldType() != databasev1.TagType_TAG_TYPE_STRING {
+			return errors.Wrapf(ErrUnsupportedConditionOp, "%s on %s", b.op.String(), b.l.FieldType().String())
+		}
+		pattern, ok := b.r.(*strLiteral)
+		if !ok {
+			return errors.Wrapf(ErrIncompatibleQueryCondition, "right is %s", b.r.FieldType().String())
+		}
+		var err error
+		if b.matcher, err = regexp.Compile(pattern.string); err != nil {
+			return errors.Wrapf(ErrIncompatibleQueryCondition, "invalid pattern: %v", err)
+		}
+		return nil
+	case modelv1.Condition_BINARY_OP_EXISTS, modelv1.Condition_BINARY_OP_MISSING:
+		return nil
+	}
 	if b.l.FieldType() != b.r.FieldType() {
 		return errors.Wrapf(ErrIncompatibleQueryCondition, "left is %s while right is %s",
 			b.l.FieldType().String(),
@@ -131,6 +174,172 @@ func (b *binaryExpr) Resolve(s Schema) error {
 	return nil
 }
 
+// indexed reports whether the expression can be served by an index.
+// The rest are evaluated against the tag values during the scan.
+// Items missing a tag are absent from its index, hence MISSING can't be served by the index.
+func (b *binaryExpr) indexed() bool {
+	switch b.op {
+	case modelv1.Condition_BINARY_OP_MATCH, modelv1.Condition_BINARY_OP_MISSING:
+		return false
+	}
+	return true
+}
+
+// seekable reports whether the expression looks up a single term, which is the only one the global index serves
+func (b *binaryExpr) seekable() bool {
+	if b.op != modelv1.Condition_BINARY_OP_EQ {
+		return false
+	}
+	r, ok := b.r.(LiteralExpr)
+	return ok && len(r.Bytes()) == 1
+}
+
+// eval evaluates the expression against the value of the tag referred by the left operand.
+// A nil value means the tag is absent, which only satisfies MISSING.
+func (b *binaryExpr) eval(value *modelv1.TagValue) bool {
+	_, isNull := pbv1.TagValueTypeConv(value)
+	isNull = isNull || value == nil
+	switch b.op {
+	case modelv1.Condition_BINARY_OP_MATCH:
+		v, ok := value.GetValue().(*modelv1.TagValue_Str)
+		return ok && b.matcher.MatchString(v.Str.GetValue())
+	case modelv1.Condition_BINARY_OP_EXISTS:
+		return !isNull
+	case modelv1.Condition_BINARY_OP_MISSING:
+		return isNull
+	}
+	if isNull {
+		return false
+	}
+	switch b.op {
+	case modelv1.Condition_BINARY_OP_EQ:
+		return elementsEqual(tagElements(value), literalElements(b.r))
+	case modelv1.Condition_BINARY_OP_NE:
+		return !elementsEqual(tagElements(value), literalElements(b.r))
+	case modelv1.Condition_BINARY_OP_LT, modelv1.Condition_BINARY_OP_GT,
+		modelv1.Condition_BINARY_OP_LE, modelv1.Condition_BINARY_OP_GE:
+		v, ok := value.GetValue().(*modelv1.TagValue_Int)
+		r, rOk := b.r.(*int64Literal)
+		if !ok || !rOk {
+			return false
+		}
+		switch b.op {
+		case modelv1.Condition_BINARY_OP_LT:
+			return v.Int.GetValue() < r.int64
+		case modelv1.Condition_BINARY_OP_GT:
+			return v.Int.GetValue() > r.int64
+		case modelv1.Condition_BINARY_OP_LE:
+			return v.Int.GetValue() <= r.int64
+		}
+		return v.Int.GetValue() >= r.int64
+	case modelv1.Condition_BINARY_OP_HAVING:
+		return containsAll(tagElements(value), literalElements(b.r))
+	case modelv1.Condition_BINARY_OP_NOT_HAVING:
+		return !containsAll(tagElements(value), literalElements(b.r))
+	case modelv1.Condition_BINARY_OP_IN:
+		return containsAll(literalElements(b.r), tagElements(value))
+	case modelv1.Condition_BINARY_OP_NOT_IN:
+		return !containsAll(literalElements(b.r), tagElements(value))
+	}
+	return false
+}
+
+func (b *binaryExpr) evaluate(lookup tagLookup) (bool, error) {
+	value, err := lookup(b.l.(*FieldRef))
+	if err != nil {
+		return false, err
+	}
+	return b.eval(value), nil
+}
+
+// tagElements returns the elements of a string or int tag, which has only one element unless it's an array
+func tagElements(value *modelv1.TagValue) []interface{} {
+	switch v := value.GetValue().(type) {
+	case *modelv1.TagValue_Str:
+		return []interface{}{v.Str.GetValue()}
+	case *modelv1.TagValue_Int:
+		return []interface{}{v.Int.GetValue()}
+	case *modelv1.TagValue_StrArray:
+		elements := make([]interface{}, 0, len(v.StrArray.GetValue()))
+		for _, e := range v.StrArray.GetValue() {
+			elements = append(elements, e)
+		}
+		return elements
+	case *modelv1.TagValue_IntArray:
+		elements := make([]interface{}, 0, len(v.IntArray.GetValue()))
+		for _, e := range v.IntArray.GetValue() {
+			elements = append(elements, e)
+		}
+		return elements
+	}
+	return nil
+}
+
+// literalElements returns the elements of a literal in the same form as tagElements
+func literalElements(expr Expr) []interface{} {
+	switch l := expr.(type) {
+	case *strLiteral:
+		return []interface{}{l.string}
+	case *int64Literal:
+		return []interface{}{l.int64}
+	case *strArrLiteral:
+		elements := make([]interface{}, 0, len(l.arr))
+		for _, e := range l.arr {
+			elements = append(elements, e)
+		}
+		return elements
+	case *int64ArrLiteral:
+		elements := make([]interface{}, 0, len(l.arr))
+		for _, e := range l.arr {
+			elements = append(elements, e)
+		}
+		return elements
+	}
+	return nil
+}
+
+func elementsEqual(a, b []interface{}) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// containsAll reports whether all the elements of subset are in set
+func containsAll(set, subset []interface{}) bool {
+	if len(subset) < 1 {
+		return false
+	}
+	for _, e := range subset {
+		found := false
+		for _, candidate := range set {
+			if candidate == e {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func arrayType(tagType databasev1.TagType) databasev1.TagType {
+	switch tagType {
+	case databasev1.TagType_TAG_TYPE_STRING:
+		return databasev1.TagType_TAG_TYPE_STRING_ARRAY
+	case databasev1.TagType_TAG_TYPE_INT:
+		return databasev1.TagType_TAG_TYPE_INT_ARRAY
+	}
+	return databasev1.TagType_TAG_TYPE_UNSPECIFIED
+}
+
 func (b *binaryExpr) String() string {
 	return fmt.Sprintf("%s %s %s", b.l.String(), b.op.String(), b.r.String())
 }
@@ -198,3 +407,46 @@ func NotHaving(l, r Expr) Expr {
 		r:  r,
 	}
 }
+
+func In(l, r Expr) Expr {
+	return &binaryExpr{
+		op: modelv1.Condition_BINARY_OP_IN,
+		l:  l,
+		r:  r,
+	}
+}
+
+func NotIn(l, r Expr) Expr {
+	return &binaryExpr{
+		op: modelv1.Condition_BINARY_OP_NOT_IN,
+		l:  l,
+		r:  r,
+	}
+}
+
+// Match tests the string tag against a regular expression
+func Match(l, r Expr) Expr {
+	return &binaryExpr{
+		op: modelv1.Condition_BINARY_OP_MATCH,
+		l:  l,
+		r:  r,
+	}
+}
+
+// Exists tests whether the tag is set
+func Exists(l Expr) Expr {
+	return &binaryExpr{
+		op: modelv1.Condition_BINARY_OP_EXISTS,
+		l:  l,
+		r:  &nullLiteral{},
+	}
+}
+
+// Missing tests whether the tag is absent or null
+func Missing(l Expr) Expr {
+	return &binaryExpr{
+		op: modelv1.Condition_BINARY_OP_MISSING,
+		l:  l,
+		r:  &nullLiteral{},
+	}
+}
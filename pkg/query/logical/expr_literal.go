@@ -158,3 +158,26 @@ func (s *strArrLiteral) FieldType() databasev1.TagType {
 func (s *strArrLiteral) String() string {
 	return fmt.Sprintf("%v", s.arr)
 }
+
+var _ LiteralExpr = (*nullLiteral)(nil)
+
+// nullLiteral is a placeholder of the right operand for the operators which don't take any value,
+// for example, EXISTS and MISSING
+type nullLiteral struct{}
+
+func (n *nullLiteral) Bytes() [][]byte {
+	return nil
+}
+
+func (n *nullLiteral) Equal(expr Expr) bool {
+	_, ok := expr.(*nullLiteral)
+	return ok
+}
+
+func (n *nullLiteral) FieldType() databasev1.TagType {
+	return databasev1.TagType_TAG_TYPE_UNSPECIFIED
+}
+
+func (n *nullLiteral) String() string {
+	return "null"
+}
@@ -0,0 +1,157 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package logical
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	databasev1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/database/v1"
+	modelv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/model/v1"
+)
+
+var ErrInvalidLogicalExpr = errors.New("invalid logical expression")
+
+var _ ResolvableExpr = (*logicalExpr)(nil)
+
+// logicalExpr combines the boolean expressions, which are either binaryExpr or logicalExpr.
+// NOT takes exactly one child.
+type logicalExpr struct {
+	op       modelv1.LogicalExpression_LogicalOp
+	children []Expr
+}
+
+func (l *logicalExpr) Equal(expr Expr) bool {
+	other, ok := expr.(*logicalExpr)
+	if !ok || l.op != other.op || len(l.children) != len(other.children) {
+		return false
+	}
+	for i, child := range l.children {
+		if !child.Equal(other.children[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func (l *logicalExpr) FieldType() databasev1.TagType {
+	panic("Boolean should be added")
+}
+
+func (l *logicalExpr) Resolve(s Schema) error {
+	switch l.op {
+	case modelv1.LogicalExpression_LOGICAL_OP_AND, modelv1.LogicalExpression_LOGICAL_OP_OR:
+		if len(l.children) < 1 {
+			return errors.Wrapf(ErrInvalidLogicalExpr, "%s without any expression", l.op.String())
+		}
+	case modelv1.LogicalExpression_LOGICAL_OP_NOT:
+		if len(l.children) != 1 {
+			return errors.Wrapf(ErrInvalidLogicalExpr, "NOT takes one expression rather than %d", len(l.children))
+		}
+	default:
+		return errors.Wrapf(ErrInvalidLogicalExpr, "op:%s", l.op.String())
+	}
+	for _, child := range l.children {
+		switch child.(type) {
+		case *binaryExpr, *logicalExpr:
+		default:
+			return errors.Wrapf(ErrInvalidLogicalExpr, "%s is not a boolean expression", child.String())
+		}
+		if err := child.(ResolvableExpr).Resolve(s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (l *logicalExpr) String() string {
+	if l.op == modelv1.LogicalExpression_LOGICAL_OP_NOT {
+		return fmt.Sprintf("NOT (%s)", l.children[0].String())
+	}
+	children := make([]string, 0, len(l.children))
+	for _, child := range l.children {
+		children = append(children, child.String())
+	}
+	sep := " AND "
+	if l.op == modelv1.LogicalExpression_LOGICAL_OP_OR {
+		sep = " OR "
+	}
+	return fmt.Sprintf("(%s)", strings.Join(children, sep))
+}
+
+func (l *logicalExpr) evaluate(lookup tagLookup) (bool, error) {
+	switch l.op {
+	case modelv1.LogicalExpression_LOGICAL_OP_NOT:
+		matched, err := l.children[0].(evaluable).evaluate(lookup)
+		return !matched, err
+	case modelv1.LogicalExpression_LOGICAL_OP_OR:
+		for _, child := range l.children {
+			matched, err := child.(evaluable).evaluate(lookup)
+			if err != nil || matched {
+				return matched, err
+			}
+		}
+		return false, nil
+	}
+	for _, child := range l.children {
+		matched, err := child.(evaluable).evaluate(lookup)
+		if err != nil || !matched {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// flatten expands the nested AND into its children
+func flatten(exprs []Expr) []Expr {
+	var result []Expr
+	for _, expr := range exprs {
+		if l, ok := expr.(*logicalExpr); ok && l.op == modelv1.LogicalExpression_LOGICAL_OP_AND {
+			result = append(result, flatten(l.children)...)
+			continue
+		}
+		result = append(result, expr)
+	}
+	return result
+}
+
+// And requires all the expressions to match
+func And(exprs ...Expr) Expr {
+	return &logicalExpr{
+		op:       modelv1.LogicalExpression_LOGICAL_OP_AND,
+		children: exprs,
+	}
+}
+
+// Or requires any of the expressions to match
+func Or(exprs ...Expr) Expr {
+	return &logicalExpr{
+		op:       modelv1.LogicalExpression_LOGICAL_OP_OR,
+		children: exprs,
+	}
+}
+
+// Not negates the expression
+func Not(expr Expr) Expr {
+	return &logicalExpr{
+		op:       modelv1.LogicalExpression_LOGICAL_OP_NOT,
+		children: []Expr{expr},
+	}
+}
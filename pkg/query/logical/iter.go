@@ -20,6 +20,8 @@ package logical
 import (
 	"container/heap"
 
+	"go.uber.org/multierr"
+
 	"github.com/apache/skywalking-banyandb/banyand/tsdb"
 )
 
@@ -28,6 +30,8 @@ var _ ItemIterator = (*itemIter)(nil)
 type ItemIterator interface {
 	HasNext() bool
 	Next() tsdb.Item
+	// Close closes the iterators which aren't exhausted yet
+	Close() error
 }
 
 var _ heap.Interface = (*containerHeap)(nil)
@@ -122,3 +126,12 @@ func (it *itemIter) Next() tsdb.Item {
 
 	return c.item
 }
+
+func (it *itemIter) Close() error {
+	var err error
+	for it.h.Len() > 0 {
+		c := heap.Pop(it.h).(*container)
+		err = multierr.Append(err, c.iter.Close())
+	}
+	return err
+}
@@ -27,6 +27,12 @@ import (
 var _ Plan = (*limit)(nil)
 var _ UnresolvedPlan = (*limit)(nil)
 
+// limitable is the plan which can stop producing the elements early once it has produced num of them.
+// A zero num means no limit.
+type limitable interface {
+	pushDownLimit(num uint32)
+}
+
 type parent struct {
 	unresolvedInput UnresolvedPlan
 	input           Plan
@@ -67,6 +73,10 @@ func (l *limit) Analyze(s Schema) (Plan, error) {
 	if err != nil {
 		return nil, err
 	}
+	// the input stops scanning once it has produced enough elements
+	if input, ok := l.input.(limitable); ok && l.limitNum > 0 {
+		input.pushDownLimit(l.limitNum)
+	}
 	return l, nil
 }
 
@@ -136,6 +146,17 @@ func (l *offset) Analyze(s Schema) (Plan, error) {
 	return l, nil
 }
 
+// pushDownLimit makes the input produce the skipped elements along with the limited ones
+func (l *offset) pushDownLimit(num uint32) {
+	input, ok := l.input.(limitable)
+	if !ok {
+		return
+	}
+	if total := num + l.offsetNum; total >= num {
+		input.pushDownLimit(total)
+	}
+}
+
 func (l *offset) Schema() Schema {
 	return l.input.Schema()
 }
@@ -27,6 +27,8 @@ import (
 	commonv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/common/v1"
 	modelv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/model/v1"
 	"github.com/apache/skywalking-banyandb/banyand/tsdb"
+	pb "github.com/apache/skywalking-banyandb/pkg/pb/v1"
+	"github.com/apache/skywalking-banyandb/pkg/query/executor"
 	"github.com/apache/skywalking-banyandb/pkg/query/logical"
 )
 
@@ -296,6 +298,264 @@ func TestPlanExecution_IndexScan(t *testing.T) {
 	}
 }
 
+func TestPlanExecution_ComparisonOperators(t *testing.T) {
+	tester := require.New(t)
+	streamSvc, metaService, deferFunc := setup(tester)
+	defer deferFunc()
+	baseTs := setupQueryData(t, "multiple_shards.json", streamSvc)
+
+	metadata := &commonv1.Metadata{
+		Name:  "sw",
+		Group: "default",
+	}
+
+	sT, eT := baseTs, baseTs.Add(1*time.Hour)
+
+	analyzer, err := logical.CreateAnalyzerFromMetaService(metaService)
+	tester.NoError(err)
+	tester.NotNil(analyzer)
+
+	tests := []struct {
+		name       string
+		cond       logical.Expr
+		wantLength int
+	}{
+		{
+			name:       "eq",
+			cond:       logical.Eq(logical.NewFieldRef("searchable", "endpoint_id"), logical.Str("/home_id")),
+			wantLength: 2,
+		},
+		{
+			name:       "ne",
+			cond:       logical.Ne(logical.NewFieldRef("searchable", "endpoint_id"), logical.Str("/home_id")),
+			wantLength: 3,
+		},
+		{
+			name:       "lt",
+			cond:       logical.Lt(logical.NewFieldRef("searchable", "duration"), logical.Int(60)),
+			wantLength: 1,
+		},
+		{
+			name:       "le",
+			cond:       logical.Le(logical.NewFieldRef("searchable", "duration"), logical.Int(60)),
+			wantLength: 2,
+		},
+		{
+			name:       "gt",
+			cond:       logical.Gt(logical.NewFieldRef("searchable", "duration"), logical.Int(300)),
+			wantLength: 2,
+		},
+		{
+			name:       "ge",
+			cond:       logical.Ge(logical.NewFieldRef("searchable", "duration"), logical.Int(300)),
+			wantLength: 3,
+		},
+		{
+			name:       "in",
+			cond:       logical.In(logical.NewFieldRef("searchable", "endpoint_id"), logical.Strs("/home_id", "/price_id")),
+			wantLength: 3,
+		},
+		{
+			name:       "not in",
+			cond:       logical.NotIn(logical.NewFieldRef("searchable", "endpoint_id"), logical.Strs("/home_id", "/price_id")),
+			wantLength: 2,
+		},
+		{
+			name:       "match",
+			cond:       logical.Match(logical.NewFieldRef("searchable", "endpoint_id"), logical.Str("^/(home|item)")),
+			wantLength: 3,
+		},
+		{
+			name:       "gt on the tag without any index",
+			cond:       logical.Gt(logical.NewFieldRef("searchable", "start_time"), logical.Int(1622933202000000000)),
+			wantLength: 0,
+		},
+		{
+			name:       "le on the tag without any index",
+			cond:       logical.Le(logical.NewFieldRef("searchable", "start_time"), logical.Int(1622933202000000000)),
+			wantLength: 5,
+		},
+		{
+			name:       "eq on the global index",
+			cond:       logical.Eq(logical.NewFieldRef("searchable", "trace_id"), logical.Str("1")),
+			wantLength: 1,
+		},
+		{
+			name:       "ne on the global index",
+			cond:       logical.Ne(logical.NewFieldRef("searchable", "trace_id"), logical.Str("1")),
+			wantLength: 4,
+		},
+		{
+			name:       "in on the global index",
+			cond:       logical.In(logical.NewFieldRef("searchable", "trace_id"), logical.Strs("1", "2")),
+			wantLength: 2,
+		},
+		{
+			name:       "not in on the global index",
+			cond:       logical.NotIn(logical.NewFieldRef("searchable", "trace_id"), logical.Strs("1", "2")),
+			wantLength: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tester := require.New(t)
+			schema, err := analyzer.BuildStreamSchema(context.TODO(), metadata)
+			tester.NoError(err)
+
+			plan, err := logical.IndexScan(sT, eT, metadata, []logical.Expr{tt.cond},
+				tsdb.Entity{tsdb.AnyEntry, tsdb.AnyEntry, tsdb.AnyEntry}, nil).Analyze(schema)
+			tester.NoError(err)
+			tester.NotNil(plan)
+
+			entities, err := plan.Execute(streamSvc)
+			tester.NoError(err)
+			tester.Len(entities, tt.wantLength)
+		})
+	}
+}
+
+func TestPlanExecution_TagExistence(t *testing.T) {
+	tester := require.New(t)
+	streamSvc, metaService, deferFunc := setup(tester)
+	defer deferFunc()
+	baseTs := setupQueryData(t, "multiple_shards.json", streamSvc)
+
+	metadata := &commonv1.Metadata{
+		Name:  "sw",
+		Group: "default",
+	}
+
+	sT, eT := baseTs, baseTs.Add(1*time.Hour)
+
+	analyzer, err := logical.CreateAnalyzerFromMetaService(metaService)
+	tester.NoError(err)
+	tester.NotNil(analyzer)
+	schema, err := analyzer.BuildStreamSchema(context.TODO(), metadata)
+	tester.NoError(err)
+
+	execute := func(cond logical.Expr) map[string]struct{} {
+		plan, errInner := logical.IndexScan(sT, eT, metadata, []logical.Expr{cond},
+			tsdb.Entity{tsdb.AnyEntry, tsdb.AnyEntry, tsdb.AnyEntry}, nil).Analyze(schema)
+		tester.NoError(errInner)
+		entities, errInner := plan.Execute(streamSvc)
+		tester.NoError(errInner)
+		ids := make(map[string]struct{}, len(entities))
+		for _, entity := range entities {
+			ids[entity.GetElementId()] = struct{}{}
+		}
+		return ids
+	}
+
+	// http.method is absent from the first two elements, and it's bound to an inverted index
+	existing := execute(logical.Exists(logical.NewFieldRef("searchable", "http.method")))
+	missing := execute(logical.Missing(logical.NewFieldRef("searchable", "http.method")))
+	tester.Len(existing, 3)
+	tester.Len(missing, 2)
+	for id := range missing {
+		tester.NotContains(existing, id)
+	}
+	// start_time is set in all elements, but there is no index bound to it
+	tester.Len(execute(logical.Exists(logical.NewFieldRef("searchable", "start_time"))), 5)
+	tester.Len(execute(logical.Missing(logical.NewFieldRef("searchable", "start_time"))), 0)
+}
+
+func TestPlanExecution_LogicalExpression(t *testing.T) {
+	tester := require.New(t)
+	streamSvc, metaService, deferFunc := setup(tester)
+	defer deferFunc()
+	baseTs := setupQueryData(t, "multiple_shards.json", streamSvc)
+
+	metadata := &commonv1.Metadata{
+		Name:  "sw",
+		Group: "default",
+	}
+
+	sT, eT := baseTs, baseTs.Add(1*time.Hour)
+
+	analyzer, err := logical.CreateAnalyzerFromMetaService(metaService)
+	tester.NoError(err)
+	tester.NotNil(analyzer)
+
+	endpoint := logical.NewFieldRef("searchable", "endpoint_id")
+	duration := logical.NewFieldRef("searchable", "duration")
+	statusCode := logical.NewFieldRef("searchable", "status_code")
+
+	tests := []struct {
+		name    string
+		conds   []logical.Expr
+		wantIDs []string
+	}{
+		{
+			// the OR is served by the indices, while the NOT is evaluated during the scan
+			name: "(a=1 OR b=2) AND NOT c=3",
+			conds: []logical.Expr{
+				logical.And(
+					logical.Or(logical.Eq(endpoint, logical.Str("/home_id")), logical.Eq(duration, logical.Int(500))),
+					logical.Not(logical.Eq(statusCode, logical.Str("500"))),
+				),
+			},
+			wantIDs: []string{"0", "1"},
+		},
+		{
+			name: "OR served by the indices",
+			conds: []logical.Expr{
+				logical.Or(logical.Eq(endpoint, logical.Str("/home_id")), logical.Eq(duration, logical.Int(500))),
+			},
+			wantIDs: []string{"0", "1", "2"},
+		},
+		{
+			name: "OR of ANDs",
+			conds: []logical.Expr{
+				logical.Or(
+					logical.And(logical.Eq(endpoint, logical.Str("/home_id")), logical.Gt(duration, logical.Int(100))),
+					logical.And(logical.Eq(statusCode, logical.Str("400")), logical.Le(duration, logical.Int(60))),
+				),
+			},
+			wantIDs: []string{"0", "3"},
+		},
+		{
+			name: "OR evaluated during the scan",
+			conds: []logical.Expr{
+				logical.Or(logical.Eq(endpoint, logical.Str("/price_id")), logical.Not(logical.Ge(duration, logical.Int(60)))),
+			},
+			wantIDs: []string{"2", "3"},
+		},
+		{
+			name: "nested groups",
+			conds: []logical.Expr{
+				logical.Eq(logical.NewFieldRef("searchable", "http.method"), logical.Str("GET")),
+				logical.Not(logical.Or(
+					logical.Eq(statusCode, logical.Str("400")),
+					logical.And(logical.Eq(endpoint, logical.Str("/home_id")), logical.Not(logical.Lt(duration, logical.Int(10)))),
+				)),
+			},
+			wantIDs: []string{"4"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tester := require.New(t)
+			schema, err := analyzer.BuildStreamSchema(context.TODO(), metadata)
+			tester.NoError(err)
+
+			plan, err := logical.IndexScan(sT, eT, metadata, tt.conds,
+				tsdb.Entity{tsdb.AnyEntry, tsdb.AnyEntry, tsdb.AnyEntry}, nil).Analyze(schema)
+			tester.NoError(err)
+			tester.NotNil(plan)
+
+			entities, err := plan.Execute(streamSvc)
+			tester.NoError(err)
+			ids := make([]string, 0, len(entities))
+			for _, entity := range entities {
+				ids = append(ids, entity.GetElementId())
+			}
+			tester.ElementsMatch(tt.wantIDs, ids)
+		})
+	}
+}
+
 func TestPlanExecution_OrderBy(t *testing.T) {
 	tester := require.New(t)
 	streamSvc, metaService, deferFunc := setup(tester)
@@ -382,3 +642,246 @@ func TestPlanExecution_OrderBy(t *testing.T) {
 		})
 	}
 }
+
+func TestPlanExecution_Explain(t *testing.T) {
+	tester := require.New(t)
+	streamSvc, metaService, deferFunc := setup(tester)
+	defer deferFunc()
+	baseTs := setupQueryData(t, "multiple_shards.json", streamSvc)
+
+	metadata := &commonv1.Metadata{
+		Name:  "sw",
+		Group: "default",
+	}
+
+	sT, eT := baseTs, baseTs.Add(1*time.Hour)
+
+	analyzer, err := logical.CreateAnalyzerFromMetaService(metaService)
+	tester.NoError(err)
+	tester.NotNil(analyzer)
+	schema, err := analyzer.BuildStreamSchema(context.TODO(), metadata)
+	tester.NoError(err)
+
+	explain := func(unresolvedPlan logical.UnresolvedPlan) (logical.Cost, int) {
+		plan, errInner := unresolvedPlan.Analyze(schema)
+		tester.NoError(errInner)
+		cost, errInner := logical.Explain(plan, streamSvc)
+		tester.NoError(errInner)
+		entities, errInner := plan.Execute(streamSvc)
+		tester.NoError(errInner)
+		return cost, len(entities)
+	}
+
+	broad, broadLen := explain(logical.Limit(logical.IndexScan(sT, eT, metadata, nil,
+		tsdb.Entity{tsdb.AnyEntry, tsdb.AnyEntry, tsdb.AnyEntry}, nil), 10))
+	tester.Equal(5, broadLen)
+	tester.Equal(broadLen, broad.Items)
+	tester.Equal(4, broad.Series)
+
+	narrow, narrowLen := explain(logical.IndexScan(sT, eT, metadata, []logical.Expr{
+		logical.Eq(logical.NewFieldRef("searchable", "http.method"), logical.Str("GET")),
+		logical.Eq(logical.NewFieldRef("searchable", "endpoint_id"), logical.Str("/home_id")),
+	}, tsdb.Entity{tsdb.AnyEntry, tsdb.AnyEntry, tsdb.AnyEntry}, nil))
+	// the index covers all the conditions, so the estimation is accurate
+	tester.Equal(narrowLen, narrow.Items)
+	tester.Greater(broad.Items, narrow.Items)
+	tester.True(broad.Exceeds(narrow.Items))
+	tester.False(narrow.Exceeds(narrow.Items))
+	tester.False(broad.Exceeds(0))
+}
+
+func TestPlanExecution_EntityIn(t *testing.T) {
+	tester := require.New(t)
+	streamSvc, metaService, deferFunc := setup(tester)
+	defer deferFunc()
+	baseTs := setupQueryData(t, "multiple_shards.json", streamSvc)
+
+	sT, eT := baseTs, baseTs.Add(1*time.Hour)
+
+	analyzer, err := logical.CreateAnalyzerFromMetaService(metaService)
+	tester.NoError(err)
+	tester.NotNil(analyzer)
+
+	tests := []struct {
+		name       string
+		instances  []string
+		wantLength int
+	}{
+		{
+			name:       "the union of three values",
+			instances:  []string{"10.0.0.1_id", "10.0.0.3_id", "10.0.0.5_id"},
+			wantLength: 5,
+		},
+		{
+			name:       "a value without any series",
+			instances:  []string{"10.0.0.1_id", "10.0.0.3_id", "10.0.0.9_id"},
+			wantLength: 4,
+		},
+		{
+			name:       "the duplicated values",
+			instances:  []string{"10.0.0.1_id", "10.0.0.1_id"},
+			wantLength: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tester := require.New(t)
+			criteria := pb.NewQueryRequestBuilder().
+				Metadata("default", "sw").
+				Projection("searchable", "trace_id").
+				FieldsInTagFamily("searchable", "service_instance_id", "in", tt.instances).
+				TimeRange(sT, eT).
+				Build()
+			schema, err := analyzer.BuildStreamSchema(context.TODO(), criteria.GetMetadata())
+			tester.NoError(err)
+
+			plan, err := analyzer.Analyze(context.TODO(), criteria, criteria.GetMetadata(), schema)
+			tester.NoError(err)
+			tester.NotNil(plan)
+
+			entities, err := plan.Execute(streamSvc)
+			tester.NoError(err)
+			tester.Len(entities, tt.wantLength)
+		})
+	}
+}
+
+// parseCounter counts the tag families and the element ids decoded by the execution
+type parseCounter struct {
+	executor.ExecutionContext
+	parsed     map[string]int
+	elementIDs int
+}
+
+func (c *parseCounter) ParseTagFamily(family string, item tsdb.Item) (*modelv1.TagFamily, error) {
+	c.parsed[family]++
+	return c.ExecutionContext.ParseTagFamily(family, item)
+}
+
+func (c *parseCounter) ParseElementID(item tsdb.Item) (string, error) {
+	c.elementIDs++
+	return c.ExecutionContext.ParseElementID(item)
+}
+
+func TestPlanExecution_Projection(t *testing.T) {
+	tester := require.New(t)
+	streamSvc, metaService, deferFunc := setup(tester)
+	defer deferFunc()
+	baseTs := setupQueryData(t, "multiple_shards.json", streamSvc)
+
+	sT, eT := baseTs, baseTs.Add(1*time.Hour)
+
+	analyzer, err := logical.CreateAnalyzerFromMetaService(metaService)
+	tester.NoError(err)
+	tester.NotNil(analyzer)
+
+	// the stream has the data and the searchable families, and only one tag of the latter is projected
+	criteria := pb.NewQueryRequestBuilder().
+		Metadata("default", "sw").
+		Projection("searchable", "http.method").
+		TimeRange(sT, eT).
+		Build()
+	schema, err := analyzer.BuildStreamSchema(context.TODO(), criteria.GetMetadata())
+	tester.NoError(err)
+	plan, err := analyzer.Analyze(context.TODO(), criteria, criteria.GetMetadata(), schema)
+	tester.NoError(err)
+
+	ec := &parseCounter{ExecutionContext: streamSvc, parsed: make(map[string]int)}
+	elements, err := plan.Execute(ec)
+	tester.NoError(err)
+	tester.Len(elements, 5)
+	var methods []string
+	for _, element := range elements {
+		tester.Len(element.GetTagFamilies(), 1)
+		family := element.GetTagFamilies()[0]
+		tester.Equal("searchable", family.GetName())
+		tester.Len(family.GetTags(), 1)
+		tester.Equal("http.method", family.GetTags()[0].GetKey())
+		// the elements written without the tag return null
+		if v, ok := family.GetTags()[0].GetValue().GetValue().(*modelv1.TagValue_Str); ok {
+			methods = append(methods, v.Str.GetValue())
+		} else {
+			tester.IsType(&modelv1.TagValue_Null{}, family.GetTags()[0].GetValue().GetValue())
+		}
+	}
+	tester.Equal([]string{"GET", "GET", "GET"}, methods)
+	tester.Equal(map[string]int{"searchable": 5}, ec.parsed)
+}
+
+func TestPlanExecution_OrderByTimeWithLimit(t *testing.T) {
+	tester := require.New(t)
+	streamSvc, metaService, deferFunc := setup(tester)
+	defer deferFunc()
+	baseTs := setupQueryData(t, "multiple_shards.json", streamSvc)
+
+	sT, eT := baseTs, baseTs.Add(1*time.Hour)
+
+	analyzer, err := logical.CreateAnalyzerFromMetaService(metaService)
+	tester.NoError(err)
+	tester.NotNil(analyzer)
+
+	// the elements are written at an interval of 500ms in the order of their ids
+	tests := []struct {
+		name    string
+		sort    modelv1.Sort
+		offset  uint32
+		limit   uint32
+		wantIDs []string
+	}{
+		{
+			name:    "asc",
+			sort:    modelv1.Sort_SORT_ASC,
+			limit:   2,
+			wantIDs: []string{"0", "1"},
+		},
+		{
+			name:    "desc",
+			sort:    modelv1.Sort_SORT_DESC,
+			limit:   2,
+			wantIDs: []string{"4", "3"},
+		},
+		{
+			name:    "desc with offset",
+			sort:    modelv1.Sort_SORT_DESC,
+			offset:  1,
+			limit:   3,
+			wantIDs: []string{"3", "2", "1"},
+		},
+		{
+			name:    "limit over the matching set",
+			sort:    modelv1.Sort_SORT_ASC,
+			limit:   10,
+			wantIDs: []string{"0", "1", "2", "3", "4"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tester := require.New(t)
+			criteria := pb.NewQueryRequestBuilder().
+				Metadata("default", "sw").
+				Projection("searchable", "trace_id").
+				OrderBy("", tt.sort).
+				Offset(tt.offset).
+				Limit(tt.limit).
+				TimeRange(sT, eT).
+				Build()
+			schema, err := analyzer.BuildStreamSchema(context.TODO(), criteria.GetMetadata())
+			tester.NoError(err)
+			plan, err := analyzer.Analyze(context.TODO(), criteria, criteria.GetMetadata(), schema)
+			tester.NoError(err)
+
+			ec := &parseCounter{ExecutionContext: streamSvc, parsed: make(map[string]int)}
+			elements, err := plan.Execute(ec)
+			tester.NoError(err)
+			ids := make([]string, 0, len(elements))
+			for _, element := range elements {
+				ids = append(ids, element.GetElementId())
+			}
+			tester.Equal(tt.wantIDs, ids)
+			// the scan stops once the skipped and the limited elements are produced
+			tester.Equal(len(tt.wantIDs)+int(tt.offset), ec.elementIDs)
+		})
+	}
+}
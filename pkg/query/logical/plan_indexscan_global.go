@@ -41,6 +41,7 @@ type globalIndexScan struct {
 	metadata            *commonv1.Metadata
 	globalIndexRule     *databasev1.IndexRule
 	expr                Expr
+	filters             []evaluable
 	projectionFieldRefs [][]*FieldRef
 }
 
@@ -76,7 +77,8 @@ func (t *globalIndexScan) Equal(plan Plan) bool {
 		cmp.Equal(t.projectionFieldRefs, other.projectionFieldRefs) &&
 		cmp.Equal(t.schema, other.schema) &&
 		cmp.Equal(t.globalIndexRule.GetMetadata().GetId(), other.globalIndexRule.GetMetadata().GetId()) &&
-		cmp.Equal(t.expr, other.expr)
+		cmp.Equal(t.expr, other.expr) &&
+		cmp.Equal(t.filters, other.filters)
 }
 
 func (t *globalIndexScan) Execute(ec executor.ExecutionContext) ([]*streamv1.Element, error) {
@@ -84,9 +86,13 @@ func (t *globalIndexScan) Execute(ec executor.ExecutionContext) ([]*streamv1.Ele
 	if err != nil {
 		return nil, err
 	}
+	snapshot := ec.Snapshot()
+	defer func() {
+		_ = snapshot.Close()
+	}()
 	var elements []*streamv1.Element
 	for _, shard := range shards {
-		elementsInShard, err := t.executeForShard(ec, shard)
+		elementsInShard, err := t.executeForShard(ec, shard, snapshot)
 		if err != nil {
 			return elements, err
 		}
@@ -95,7 +101,8 @@ func (t *globalIndexScan) Execute(ec executor.ExecutionContext) ([]*streamv1.Ele
 	return elements, nil
 }
 
-func (t *globalIndexScan) executeForShard(ec executor.ExecutionContext, shard tsdb.Shard) ([]*streamv1.Element, error) {
+func (t *globalIndexScan) executeForShard(ec executor.ExecutionContext, shard tsdb.Shard,
+	snapshot tsdb.Snapshot) ([]*streamv1.Element, error) {
 	var elementsInShard []*streamv1.Element
 	itemIDs, err := shard.Index().Seek(index.Field{
 		Key: index.FieldKey{
@@ -107,6 +114,9 @@ func (t *globalIndexScan) executeForShard(ec executor.ExecutionContext, shard ts
 		return elementsInShard, nil
 	}
 	for _, itemID := range itemIDs {
+		if !snapshot.Visible(itemID) {
+			continue
+		}
 		segShard, err := ec.Shard(itemID.ShardID)
 		if err != nil {
 			return elementsInShard, errors.WithStack(err)
@@ -123,7 +133,15 @@ func (t *globalIndexScan) executeForShard(ec executor.ExecutionContext, shard ts
 			if errInner != nil {
 				return errors.WithStack(errInner)
 			}
-			tagFamilies, errInner := projectItem(ec, item, t.projectionFieldRefs)
+			families := newItemTagFamilies(ec, item)
+			matched, errInner := filterItem(families, t.filters)
+			if errInner != nil {
+				return errors.WithStack(errInner)
+			}
+			if !matched {
+				return nil
+			}
+			tagFamilies, errInner := projectItem(families, t.projectionFieldRefs)
 			if errInner != nil {
 				return errors.WithStack(errInner)
 			}
@@ -24,11 +24,12 @@ import (
 	"time"
 
 	"github.com/google/go-cmp/cmp"
-	"github.com/pkg/errors"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/apache/skywalking-banyandb/api/common"
 	commonv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/common/v1"
 	databasev1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/database/v1"
+	modelv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/model/v1"
 	streamv1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/stream/v1"
 	"github.com/apache/skywalking-banyandb/banyand/tsdb"
 	"github.com/apache/skywalking-banyandb/pkg/index"
@@ -44,34 +45,54 @@ type unresolvedIndexScan struct {
 	metadata          *commonv1.Metadata
 	conditions        []Expr
 	projectionFields  [][]*Tag
-	entity            tsdb.Entity
+	// entities locate the series to scan, and the series located by several of them are scanned once
+	entities []tsdb.Entity
 }
 
 func (uis *unresolvedIndexScan) Analyze(s Schema) (Plan, error) {
 	localConditionMap := make(map[*databasev1.IndexRule][]Expr)
 	globalConditions := make([]interface{}, 0)
-	for _, cond := range uis.conditions {
+	var filters []evaluable
+	var disjunctions []*logicalExpr
+	var indexFilters [][]tsdb.IndexFilter
+	for _, cond := range flatten(uis.conditions) {
 		if resolvable, ok := cond.(ResolvableExpr); ok {
 			err := resolvable.Resolve(s)
 			if err != nil {
 				return nil, err
 			}
 
+			if lCond, ok := cond.(*logicalExpr); ok {
+				// the disjunction of the conditions served by the series indices is pushed down,
+				// and the rest are evaluated during the scan
+				if branches, pushed := toIndexFilters(s, lCond); pushed {
+					disjunctions = append(disjunctions, lCond)
+					indexFilters = append(indexFilters, branches)
+				} else {
+					filters = append(filters, lCond)
+				}
+				continue
+			}
+
 			if bCond, ok := cond.(*binaryExpr); ok {
 				tag := bCond.l.(*FieldRef).tag
-				if defined, indexObj := s.IndexDefined(tag); defined {
-					if indexObj.GetLocation() == databasev1.IndexRule_LOCATION_SERIES {
-						if v, exist := localConditionMap[indexObj]; exist {
-							v = append(v, cond)
-							localConditionMap[indexObj] = v
-						} else {
-							localConditionMap[indexObj] = []Expr{cond}
-						}
-					} else if indexObj.GetLocation() == databasev1.IndexRule_LOCATION_GLOBAL {
-						globalConditions = append(globalConditions, indexObj, cond)
+				defined, indexObj := s.IndexDefined(tag)
+				// the conditions on the tags without any index are evaluated during the scan,
+				// and so are the ones the global index can't seek since it only serves the terms' lookup
+				if !bCond.indexed() || !defined ||
+					(indexObj.GetLocation() == databasev1.IndexRule_LOCATION_GLOBAL && !bCond.seekable()) {
+					filters = append(filters, bCond)
+					continue
+				}
+				if indexObj.GetLocation() == databasev1.IndexRule_LOCATION_SERIES {
+					if v, exist := localConditionMap[indexObj]; exist {
+						v = append(v, cond)
+						localConditionMap[indexObj] = v
+					} else {
+						localConditionMap[indexObj] = []Expr{cond}
 					}
-				} else {
-					return nil, errors.Wrap(ErrIndexNotDefined, tag.GetCompoundName())
+				} else if indexObj.GetLocation() == databasev1.IndexRule_LOCATION_GLOBAL {
+					globalConditions = append(globalConditions, indexObj, cond)
 				}
 			}
 		}
@@ -90,12 +111,17 @@ func (uis *unresolvedIndexScan) Analyze(s Schema) (Plan, error) {
 		if len(globalConditions)/2 > 1 {
 			return nil, ErrMultipleGlobalIndexes
 		}
+		// the global index scan doesn't seek the series indices
+		for _, d := range disjunctions {
+			filters = append(filters, d)
+		}
 		return &globalIndexScan{
 			schema:              s,
 			projectionFieldRefs: projFieldsRefs,
 			metadata:            uis.metadata,
 			globalIndexRule:     globalConditions[0].(*databasev1.IndexRule),
 			expr:                globalConditions[1].(Expr),
+			filters:             filters,
 		}, nil
 	}
 
@@ -113,7 +139,10 @@ func (uis *unresolvedIndexScan) Analyze(s Schema) (Plan, error) {
 		projectionFieldRefs: projFieldsRefs,
 		metadata:            uis.metadata,
 		conditionMap:        localConditionMap,
-		entity:              uis.entity,
+		disjunctions:        disjunctions,
+		indexFilters:        indexFilters,
+		filters:             filters,
+		entities:            uis.entities,
 	}, nil
 }
 
@@ -125,18 +154,32 @@ type localIndexScan struct {
 	schema              Schema
 	metadata            *commonv1.Metadata
 	conditionMap        map[*databasev1.IndexRule][]Expr
+	disjunctions        []*logicalExpr
+	indexFilters        [][]tsdb.IndexFilter // the series indices serve the disjunctions through them
+	filters             []evaluable
 	projectionFieldRefs [][]*FieldRef
-	entity              tsdb.Entity
+	entities            []tsdb.Entity
+	// maxElements stops the scan once the elements are enough, which is unlimited if it's zero
+	maxElements uint32
+}
+
+func (i *localIndexScan) pushDownLimit(num uint32) {
+	i.maxElements = num
 }
 
 func (i *localIndexScan) Execute(ec executor.ExecutionContext) ([]*streamv1.Element, error) {
-	shards, err := ec.Shards(i.entity)
+	seriesInShards, err := i.locateSeries(ec)
 	if err != nil {
 		return nil, err
 	}
+	// the snapshot keeps the writes during the query from being seen by some of the shards
+	snapshot := ec.Snapshot()
+	defer func() {
+		_ = snapshot.Close()
+	}()
 	var iters []tsdb.Iterator
-	for _, shard := range shards {
-		itersInShard, err := i.executeInShard(shard)
+	for _, seriesList := range seriesInShards {
+		itersInShard, err := i.executeInShard(seriesList, snapshot)
 		if err != nil {
 			return nil, err
 		}
@@ -147,9 +190,23 @@ func (i *localIndexScan) Execute(ec executor.ExecutionContext) ([]*streamv1.Elem
 
 	var elems []*streamv1.Element
 	it := NewItemIter(iters, c)
+	defer func() {
+		_ = it.Close()
+	}()
 	for it.HasNext() {
+		if i.maxElements > 0 && len(elems) >= int(i.maxElements) {
+			break
+		}
 		nextItem := it.Next()
-		tagFamilies, innerErr := projectItem(ec, nextItem, i.projectionFieldRefs)
+		families := newItemTagFamilies(ec, nextItem)
+		matched, innerErr := filterItem(families, i.filters)
+		if innerErr != nil {
+			return nil, innerErr
+		}
+		if !matched {
+			continue
+		}
+		tagFamilies, innerErr := projectItem(families, i.projectionFieldRefs)
 		if innerErr != nil {
 			return nil, innerErr
 		}
@@ -166,13 +223,41 @@ func (i *localIndexScan) Execute(ec executor.ExecutionContext) ([]*streamv1.Elem
 	return elems, nil
 }
 
-func (i *localIndexScan) executeInShard(shard tsdb.Shard) ([]tsdb.Iterator, error) {
-	seriesList, err := shard.Series().List(tsdb.NewPath(i.entity))
-	if err != nil {
-		return nil, err
+// locateSeries lists the series matching any of the entities in each shard the entities are routed to.
+// A series located by several entities is listed once.
+func (i *localIndexScan) locateSeries(ec executor.ExecutionContext) (map[common.ShardID]tsdb.SeriesList, error) {
+	result := make(map[common.ShardID]tsdb.SeriesList)
+	located := make(map[common.ShardID]map[common.SeriesID]struct{})
+	for _, entity := range i.entities {
+		shards, err := ec.Shards(entity)
+		if err != nil {
+			return nil, err
+		}
+		for _, shard := range shards {
+			seriesList, err := shard.Series().List(tsdb.NewPath(entity))
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := located[shard.ID()]; !ok {
+				located[shard.ID()] = make(map[common.SeriesID]struct{})
+				result[shard.ID()] = nil
+			}
+			for _, series := range seriesList {
+				if _, ok := located[shard.ID()][series.ID()]; ok {
+					continue
+				}
+				located[shard.ID()][series.ID()] = struct{}{}
+				result[shard.ID()] = append(result[shard.ID()], series)
+			}
+		}
 	}
+	return result, nil
+}
 
-	var builders []seekerBuilder
+func (i *localIndexScan) executeInShard(seriesList tsdb.SeriesList, snapshot tsdb.Snapshot) ([]tsdb.Iterator, error) {
+	builders := []seekerBuilder{func(builder tsdb.SeekerBuilder) {
+		builder.Snapshot(snapshot)
+	}}
 
 	if i.index != nil {
 		builders = append(builders, func(builder tsdb.SeekerBuilder) {
@@ -192,6 +277,14 @@ func (i *localIndexScan) executeInShard(shard tsdb.Shard) ([]tsdb.Iterator, erro
 		})
 	}
 
+	if len(i.indexFilters) > 0 {
+		builders = append(builders, func(b tsdb.SeekerBuilder) {
+			for _, branches := range i.indexFilters {
+				b.FilterAny(branches...)
+			}
+		})
+	}
+
 	return executeForShard(seriesList, i.timeRange, builders...)
 }
 
@@ -204,6 +297,12 @@ func (i *localIndexScan) String() string {
 		}
 		exprStr = append(exprStr, fmt.Sprintf("(%s)", strings.Join(conditionStr, " AND ")))
 	}
+	for _, d := range i.disjunctions {
+		exprStr = append(exprStr, d.String())
+	}
+	for _, filter := range i.filters {
+		exprStr = append(exprStr, filter.String())
+	}
 	if len(i.projectionFieldRefs) == 0 {
 		return fmt.Sprintf("IndexScan: startTime=%d,endTime=%d,Metadata{group=%s,name=%s},conditions=%s; projection=None",
 			i.timeRange.Start.Unix(), i.timeRange.End.Unix(), i.metadata.GetGroup(), i.metadata.GetName(), strings.Join(exprStr, " AND "))
@@ -237,14 +336,36 @@ func (i *localIndexScan) Equal(plan Plan) bool {
 		i.metadata.GetName() == other.metadata.GetName() &&
 		i.timeRange.Start.UnixNano() == other.timeRange.Start.UnixNano() &&
 		i.timeRange.End.UnixNano() == other.timeRange.End.UnixNano() &&
-		bytes.Equal(i.entity.Marshal(), other.entity.Marshal()) &&
+		entitiesEqual(i.entities, other.entities) &&
+		i.maxElements == other.maxElements &&
 		cmp.Equal(i.projectionFieldRefs, other.projectionFieldRefs) &&
 		cmp.Equal(i.schema, other.schema) &&
 		cmp.Equal(i.conditionMap, other.conditionMap) &&
+		cmp.Equal(i.disjunctions, other.disjunctions) &&
+		cmp.Equal(i.filters, other.filters) &&
 		cmp.Equal(i.orderBy, other.orderBy)
 }
 
+func entitiesEqual(a, b []tsdb.Entity) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for idx := range a {
+		if !bytes.Equal(a[idx].Marshal(), b[idx].Marshal()) {
+			return false
+		}
+	}
+	return true
+}
+
 func IndexScan(startTime, endTime time.Time, metadata *commonv1.Metadata, conditions []Expr, entity tsdb.Entity,
+	orderBy *UnresolvedOrderBy, projection ...[]*Tag) UnresolvedPlan {
+	return MultiEntityIndexScan(startTime, endTime, metadata, conditions, []tsdb.Entity{entity}, orderBy, projection...)
+}
+
+// MultiEntityIndexScan scans the union of the series located by the entities,
+// e.g. the ones an IN on the entity tags is expanded to
+func MultiEntityIndexScan(startTime, endTime time.Time, metadata *commonv1.Metadata, conditions []Expr, entities []tsdb.Entity,
 	orderBy *UnresolvedOrderBy, projection ...[]*Tag) UnresolvedPlan {
 	return &unresolvedIndexScan{
 		unresolvedOrderBy: orderBy,
@@ -253,7 +374,7 @@ func IndexScan(startTime, endTime time.Time, metadata *commonv1.Metadata, condit
 		metadata:          metadata,
 		conditions:        conditions,
 		projectionFields:  projection,
-		entity:            entity,
+		entities:          entities,
 	}
 }
 
@@ -289,3 +410,39 @@ func exprToCondition(exprs []Expr) tsdb.Condition {
 	}
 	return cond
 }
+
+// toIndexFilters converts an OR to the filters on the series indices. Each branch of it should be
+// a condition or an AND of conditions, all of which are served by the series indices.
+func toIndexFilters(s Schema, expr *logicalExpr) ([]tsdb.IndexFilter, bool) {
+	if expr.op != modelv1.LogicalExpression_LOGICAL_OP_OR {
+		return nil, false
+	}
+	branches := make([]tsdb.IndexFilter, 0, len(expr.children))
+	for _, child := range expr.children {
+		conditions := []Expr{child}
+		if l, ok := child.(*logicalExpr); ok {
+			if l.op != modelv1.LogicalExpression_LOGICAL_OP_AND {
+				return nil, false
+			}
+			conditions = flatten(l.children)
+		}
+		conditionMap := make(map[*databasev1.IndexRule][]Expr)
+		for _, cond := range conditions {
+			bCond, ok := cond.(*binaryExpr)
+			if !ok || !bCond.indexed() {
+				return nil, false
+			}
+			defined, indexObj := s.IndexDefined(bCond.l.(*FieldRef).tag)
+			if !defined || indexObj.GetLocation() != databasev1.IndexRule_LOCATION_SERIES {
+				return nil, false
+			}
+			conditionMap[indexObj] = append(conditionMap[indexObj], cond)
+		}
+		branch := make(tsdb.IndexFilter, len(conditionMap))
+		for indexObj, exprs := range conditionMap {
+			branch[indexObj] = exprToCondition(exprs)
+		}
+		branches = append(branches, branch)
+	}
+	return branches, true
+}
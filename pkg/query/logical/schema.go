@@ -22,6 +22,7 @@ import (
 	"github.com/pkg/errors"
 
 	databasev1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/database/v1"
+	pbv1 "github.com/apache/skywalking-banyandb/pkg/pb/v1"
 )
 
 type Schema interface {
@@ -129,18 +130,25 @@ func (s *schema) FieldDefined(name string) bool {
 }
 
 // CreateRef create FieldRef to the given tags.
-// The family name of the tag is actually not used
-// since the uniqueness of the tag names can be guaranteed across families.
+// The tag is looked up by its name since the uniqueness of the tag names can be guaranteed across families,
+// while a non-empty family name should be the one the tag belongs to.
 func (s *schema) CreateRef(tags ...[]*Tag) ([][]*FieldRef, error) {
 	fieldRefs := make([][]*FieldRef, len(tags))
 	for i, tagInFamily := range tags {
 		var fieldRefsInFamily []*FieldRef
 		for _, tag := range tagInFamily {
-			if fs, ok := s.fieldMap[tag.GetTagName()]; ok {
-				fieldRefsInFamily = append(fieldRefsInFamily, &FieldRef{tag, fs})
-			} else {
+			fs, ok := s.fieldMap[tag.GetTagName()]
+			if !ok {
 				return nil, errors.Wrap(ErrFieldNotDefined, tag.GetCompoundName())
 			}
+			if tag.GetFamilyName() != "" {
+				families := s.stream.GetTagFamilies()
+				if familyIdx, _, spec := pbv1.FindTagByName(families, tag.GetTagName()); spec == nil ||
+					families[familyIdx].GetName() != tag.GetFamilyName() {
+					return nil, errors.Wrap(ErrFieldNotDefined, tag.GetCompoundName())
+				}
+			}
+			fieldRefsInFamily = append(fieldRefsInFamily, &FieldRef{tag, fs})
 		}
 		fieldRefs[i] = fieldRefsInFamily
 	}
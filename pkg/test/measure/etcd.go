@@ -26,9 +26,7 @@ import (
 	"path"
 
 	"github.com/google/uuid"
-	"google.golang.org/protobuf/encoding/protojson"
 
-	databasev1 "github.com/apache/skywalking-banyandb/api/proto/banyandb/database/v1"
 	"github.com/apache/skywalking-banyandb/banyand/metadata/schema"
 )
 
@@ -48,45 +46,15 @@ func PreloadSchema(e schema.Registry) error {
 		return err
 	}
 
-	s := &databasev1.Measure{}
-	if err := protojson.Unmarshal([]byte(measureJSON), s); err != nil {
-		return err
-	}
-	err := e.UpdateMeasure(context.Background(), s)
-	if err != nil {
-		return err
-	}
-
-	indexRuleBinding := &databasev1.IndexRuleBinding{}
-	if err = protojson.Unmarshal([]byte(indexRuleBindingJSON), indexRuleBinding); err != nil {
-		return err
-	}
-	err = e.UpdateIndexRuleBinding(context.Background(), indexRuleBinding)
+	docs, err := schema.ReadDocuments(indexRuleStore, indexRuleDir, schema.KindIndexRule)
 	if err != nil {
 		return err
 	}
-
-	entries, err := indexRuleStore.ReadDir(indexRuleDir)
-	if err != nil {
-		return err
-	}
-	for _, entry := range entries {
-		data, err := indexRuleStore.ReadFile(indexRuleDir + "/" + entry.Name())
-		if err != nil {
-			return err
-		}
-		var idxRule databasev1.IndexRule
-		err = protojson.Unmarshal(data, &idxRule)
-		if err != nil {
-			return err
-		}
-		err = e.UpdateIndexRule(context.Background(), &idxRule)
-		if err != nil {
-			return err
-		}
-	}
-
-	return nil
+	// the index rules are imported before the binding referring to them
+	return schema.Import(context.Background(), e, append(docs, []schema.Document{
+		{Kind: schema.KindMeasure, Source: "testdata/measure.json", Data: []byte(measureJSON)},
+		{Kind: schema.KindIndexRuleBinding, Source: "testdata/index_rule_binding.json", Data: []byte(indexRuleBindingJSON)},
+	}...)...)
 }
 
 func RandomTempDir() string {